@@ -0,0 +1,190 @@
+package analytics
+
+import (
+	"cmp"
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/kehiy/RoboPac/store"
+)
+
+// Usage is the recorded usage of a command since the storage is created.
+type Usage struct {
+	Command   string `json:"-"`
+	Succeeded int64  `json:"succeeded"`
+	Failed    int64  `json:"failed"`
+	Errored   int64  `json:"errored"`
+	// Latencies counts the calls by the buckets of metrics.DefaultBuckets, the last one is for the slower calls.
+	Latencies []int64 `json:"latencies"`
+}
+
+// Calls returns the number of all the calls.
+func (u *Usage) Calls() int64 {
+	return u.Succeeded + u.Failed + u.Errored
+}
+
+// ErrorRate returns the ratio of the calls that returned an error, the failed results are not errors.
+func (u *Usage) ErrorRate() float64 {
+	if u.Calls() == 0 {
+		return 0
+	}
+
+	return float64(u.Errored) / float64(u.Calls())
+}
+
+// SuccessRate returns the ratio of the calls that returned a successful result.
+func (u *Usage) SuccessRate() float64 {
+	if u.Calls() == 0 {
+		return 0
+	}
+
+	return float64(u.Succeeded) / float64(u.Calls())
+}
+
+// Percentile estimates the latency percentile, like 0.95, by the upper bound of its bucket.
+// The calls slower than the last bucket are estimated by the last bound.
+func (u *Usage) Percentile(p float64) time.Duration {
+	total := int64(0)
+	for _, count := range u.Latencies {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+
+	rank := int64(math.Ceil(p * float64(total)))
+	cumulative := int64(0)
+	for i, count := range u.Latencies {
+		cumulative += count
+		if cumulative >= rank && count > 0 {
+			return bucketBound(i)
+		}
+	}
+
+	return bucketBound(len(u.Latencies) - 1)
+}
+
+func (u *Usage) observe(latency time.Duration) {
+	if len(u.Latencies) != len(metrics.DefaultBuckets)+1 {
+		u.Latencies = make([]int64, len(metrics.DefaultBuckets)+1)
+	}
+
+	i, _ := slices.BinarySearch(metrics.DefaultBuckets, latency.Seconds())
+	u.Latencies[i]++
+}
+
+func bucketBound(i int) time.Duration {
+	i = min(i, len(metrics.DefaultBuckets)-1)
+
+	return time.Duration(metrics.DefaultBuckets[i] * float64(time.Second))
+}
+
+// Tracker records the usage of the commands and keeps it in the storage,
+// so the analytics survive the restarts. The records are written by Flush.
+type Tracker struct {
+	lk sync.Mutex
+
+	kv    store.KV
+	usage map[string]*Usage
+	dirty map[string]bool
+}
+
+// NewTracker loads the recorded usage from the bucket.
+func NewTracker(kv store.KV) (*Tracker, error) {
+	t := &Tracker{
+		kv:    kv,
+		usage: map[string]*Usage{},
+		dirty: map[string]bool{},
+	}
+
+	err := kv.Iterate(func(cmdName string, value []byte) bool {
+		usage := &Usage{}
+		if err := json.Unmarshal(value, usage); err != nil {
+			log.Warn("unable to load the command usage", "err", err, "command", cmdName)
+
+			return true
+		}
+		usage.Command = cmdName
+		t.usage[cmdName] = usage
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Record records a call of the command, the errors are the errors returned by the handlers.
+func (t *Tracker) Record(cmdName string, successful bool, err error, latency time.Duration) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	usage, ok := t.usage[cmdName]
+	if !ok {
+		usage = &Usage{Command: cmdName}
+		t.usage[cmdName] = usage
+	}
+
+	switch {
+	case err != nil:
+		usage.Errored++
+	case successful:
+		usage.Succeeded++
+	default:
+		usage.Failed++
+	}
+	usage.observe(latency)
+
+	t.dirty[cmdName] = true
+}
+
+// Usage returns the usage of the commands, the most used first.
+func (t *Tracker) Usage() []Usage {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	usages := make([]Usage, 0, len(t.usage))
+	for _, usage := range t.usage {
+		cloned := *usage
+		cloned.Latencies = slices.Clone(usage.Latencies)
+		usages = append(usages, cloned)
+	}
+
+	slices.SortFunc(usages, func(a, b Usage) int {
+		if c := cmp.Compare(b.Calls(), a.Calls()); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Command, b.Command)
+	})
+
+	return usages
+}
+
+// Flush writes the changed records to the storage, it's called periodically and on stop.
+func (t *Tracker) Flush() error {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	for cmdName := range t.dirty {
+		data, err := json.Marshal(t.usage[cmdName])
+		if err != nil {
+			return err
+		}
+
+		if err := t.kv.Set(cmdName, data); err != nil {
+			return err
+		}
+		delete(t.dirty, cmdName)
+	}
+
+	return nil
+}
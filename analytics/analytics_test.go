@@ -0,0 +1,79 @@
+package analytics
+
+import (
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTracker(t *testing.T) (*Tracker, string) {
+	t.Helper()
+
+	kvPath := path.Join(t.TempDir(), "command_usage.json")
+	kv, err := store.NewJSONKV(kvPath)
+	require.NoError(t, err)
+
+	tracker, err := NewTracker(kv)
+	require.NoError(t, err)
+
+	return tracker, kvPath
+}
+
+func TestRecord(t *testing.T) {
+	tracker, _ := setupTracker(t)
+
+	tracker.Record("network", true, nil, 20*time.Millisecond)
+	tracker.Record("network", true, nil, 40*time.Millisecond)
+	tracker.Record("network", false, nil, 40*time.Millisecond)
+	tracker.Record("network", false, errors.New("node is down"), 3*time.Second)
+	tracker.Record("help", true, nil, time.Millisecond)
+
+	usages := tracker.Usage()
+	require.Len(t, usages, 2)
+	assert.Equal(t, "network", usages[0].Command, "the most used first")
+	assert.Equal(t, "help", usages[1].Command)
+
+	network := usages[0]
+	assert.Equal(t, int64(4), network.Calls())
+	assert.Equal(t, int64(2), network.Succeeded)
+	assert.Equal(t, int64(1), network.Failed)
+	assert.Equal(t, int64(1), network.Errored)
+	assert.InDelta(t, 0.5, network.SuccessRate(), 1e-9)
+	assert.InDelta(t, 0.25, network.ErrorRate(), 1e-9)
+	assert.Equal(t, 50*time.Millisecond, network.Percentile(0.5))
+	assert.Equal(t, 5*time.Second, network.Percentile(0.95))
+}
+
+func TestPercentile(t *testing.T) {
+	usage := &Usage{}
+	assert.Zero(t, usage.Percentile(0.5))
+
+	for i := 0; i < 99; i++ {
+		usage.observe(3 * time.Millisecond)
+	}
+	usage.observe(time.Minute)
+
+	assert.Equal(t, 5*time.Millisecond, usage.Percentile(0.5))
+	assert.Equal(t, 5*time.Millisecond, usage.Percentile(0.99))
+	assert.Equal(t, 10*time.Second, usage.Percentile(1), "the slower calls are estimated by the last bound")
+}
+
+func TestFlush(t *testing.T) {
+	tracker, kvPath := setupTracker(t)
+
+	tracker.Record("network", true, nil, 20*time.Millisecond)
+	tracker.Record("peers", false, errors.New("node is down"), time.Second)
+	require.NoError(t, tracker.Flush())
+	require.NoError(t, tracker.Flush(), "nothing is changed")
+
+	kv, err := store.NewJSONKV(kvPath)
+	require.NoError(t, err)
+	loaded, err := NewTracker(kv)
+	require.NoError(t, err)
+	assert.Equal(t, tracker.Usage(), loaded.Usage())
+}
@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/store"
+)
+
+// The kinds of the operations that are recorded.
+const (
+	KindAdminCommand = "admin-command"
+	KindTransfer     = "transfer"
+	KindFaucet       = "faucet"
+	KindClaim        = "claim"
+	KindTip          = "tip"
+	KindReferral     = "referral"
+)
+
+// Kinds returns the kinds of the operations that are recorded.
+func Kinds() []string {
+	return []string{KindAdminCommand, KindTransfer, KindFaucet, KindClaim, KindTip, KindReferral}
+}
+
+// Entry is the record of one mutating operation, like a wallet transfer or an admin command.
+type Entry struct {
+	Seq      uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	AppID    string    `json:"app_id,omitempty"`
+	CallerID string    `json:"caller_id"`
+	Inputs   []string  `json:"inputs,omitempty"`
+	// TxID is the hash of the transaction that the operation sent, if any.
+	TxID  string `json:"tx_id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Filter selects the entries by their kind and caller, the empty fields match all.
+type Filter struct {
+	Kind     string
+	CallerID string
+}
+
+func (f Filter) match(entry *Entry) bool {
+	return (f.Kind == "" || f.Kind == entry.Kind) &&
+		(f.CallerID == "" || f.CallerID == entry.CallerID)
+}
+
+// Log is the append-only log of the mutating operations, kept in the storage.
+// The entries are keyed by their sequence, so they are iterated in the order that they are appended.
+type Log struct {
+	lk sync.Mutex
+
+	kv   store.KV
+	next uint64
+	now  func() time.Time
+}
+
+// NewLog opens the log of the bucket, the new entries are appended after the last one.
+func NewLog(kv store.KV) (*Log, error) {
+	l := &Log{kv: kv, next: 1, now: time.Now}
+
+	err := kv.Iterate(func(key string, _ []byte) bool {
+		seq, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			log.Warn("invalid audit entry key", "key", key)
+
+			return true
+		}
+		l.next = max(l.next, seq+1)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Append records the entry, its sequence and time are set by the log.
+func (l *Log) Append(entry Entry) error {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	entry.Seq = l.next
+	entry.Time = l.now().UTC()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := l.kv.Set(entryKey(entry.Seq), data); err != nil {
+		return err
+	}
+	l.next++
+
+	return nil
+}
+
+// Recent returns the last entries that match the filter, up to the limit and the newest first.
+func (l *Log) Recent(filter Filter, limit int) ([]Entry, error) {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	matched := []Entry{}
+	err := l.kv.Iterate(func(key string, value []byte) bool {
+		entry := Entry{}
+		if err := json.Unmarshal(value, &entry); err != nil {
+			log.Warn("unable to load the audit entry", "err", err, "key", key)
+
+			return true
+		}
+
+		if filter.match(&entry) {
+			matched = append(matched, entry)
+			if len(matched) > limit {
+				matched = matched[1:]
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(matched)
+
+	return matched, nil
+}
+
+// entryKey pads the sequence, so the keys are sorted by the sequences.
+func entryKey(seq uint64) string {
+	return fmt.Sprintf("%020d", seq)
+}
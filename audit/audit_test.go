@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLog(t *testing.T) (*Log, string) {
+	t.Helper()
+
+	kvPath := path.Join(t.TempDir(), "audit_log.json")
+	kv, err := store.NewJSONKV(kvPath)
+	require.NoError(t, err)
+
+	l, err := NewLog(kv)
+	require.NoError(t, err)
+
+	return l, kvPath
+}
+
+func TestAppend(t *testing.T) {
+	l, kvPath := setupLog(t)
+	l.now = func() time.Time { return time.Unix(1_700_000_000, 0) }
+
+	require.NoError(t, l.Append(Entry{Kind: KindFaucet, CallerID: "alice", Inputs: []string{"faucet", "tpc1"}, TxID: "tx1"}))
+	require.NoError(t, l.Append(Entry{Kind: KindAdminCommand, CallerID: "bob", Inputs: []string{"maintenance", "on"}}))
+
+	entries, err := l.Recent(Filter{}, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(2), entries[0].Seq, "the newest first")
+	assert.Equal(t, "tx1", entries[1].TxID)
+	assert.Equal(t, time.Unix(1_700_000_000, 0).UTC(), entries[1].Time)
+
+	t.Run("reopened", func(t *testing.T) {
+		kv, err := store.NewJSONKV(kvPath)
+		require.NoError(t, err)
+
+		reopened, err := NewLog(kv)
+		require.NoError(t, err)
+		require.NoError(t, reopened.Append(Entry{Kind: KindClaim, CallerID: "alice", TxID: "tx2"}))
+
+		entries, err := reopened.Recent(Filter{}, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, uint64(3), entries[0].Seq, "the entries are appended after the last one")
+	})
+}
+
+func TestRecent(t *testing.T) {
+	l, _ := setupLog(t)
+
+	for i := 0; i < 12; i++ {
+		kind := KindFaucet
+		if i%3 == 0 {
+			kind = KindTransfer
+		}
+		require.NoError(t, l.Append(Entry{Kind: kind, CallerID: []string{"alice", "bob"}[i%2]}))
+	}
+
+	entries, err := l.Recent(Filter{}, 5)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+	assert.Equal(t, uint64(12), entries[0].Seq)
+	assert.Equal(t, uint64(8), entries[4].Seq)
+
+	entries, err = l.Recent(Filter{Kind: KindTransfer}, 10)
+	require.NoError(t, err)
+	assert.Len(t, entries, 4)
+
+	entries, err = l.Recent(Filter{Kind: KindTransfer, CallerID: "alice"}, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(7), entries[0].Seq)
+}
@@ -0,0 +1,132 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"time"
+)
+
+// The default size of the charts, in pixels.
+const (
+	DefaultWidth  = 800
+	DefaultHeight = 400
+)
+
+const (
+	margin    = 24
+	gridLines = 4
+)
+
+// ErrNotEnoughPoints is returned when the series has less than two points.
+var ErrNotEnoughPoints = errors.New("at least two points are needed for a chart")
+
+var (
+	backgroundColor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	gridColor       = color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+	axisColor       = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	lineColor       = color.RGBA{R: 0x05, G: 0x2f, B: 0xf5, A: 0xff}
+)
+
+// Point is a value of the series at a point in time.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// LineChart renders the series, ordered by time, as a PNG line chart that fits the size.
+// The chart has no text, the values are reported along with it.
+func LineChart(points []Point, width, height int) ([]byte, error) {
+	if len(points) < 2 {
+		return nil, ErrNotEnoughPoints
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+
+	area := image.Rect(margin, margin, width-margin, height-margin)
+	for i := 0; i <= gridLines; i++ {
+		y := area.Min.Y + i*area.Dy()/gridLines
+		drawLine(img, area.Min.X, y, area.Max.X, y, gridColor)
+	}
+	drawLine(img, area.Min.X, area.Min.Y, area.Min.X, area.Max.Y, axisColor)
+	drawLine(img, area.Min.X, area.Max.Y, area.Max.X, area.Max.Y, axisColor)
+
+	minValue, maxValue := points[0].Value, points[0].Value
+	for _, p := range points {
+		minValue = min(minValue, p.Value)
+		maxValue = max(maxValue, p.Value)
+	}
+	// the flat series are drawn in the middle.
+	if minValue == maxValue {
+		minValue--
+		maxValue++
+	}
+
+	from, to := points[0].Time, points[len(points)-1].Time
+	span := max(to.Sub(from), time.Second)
+
+	pixel := func(p Point) (int, int) {
+		x := area.Min.X + int(float64(area.Dx())*float64(p.Time.Sub(from))/float64(span))
+		y := area.Max.Y - int(float64(area.Dy())*(p.Value-minValue)/(maxValue-minValue))
+
+		return x, y
+	}
+
+	prevX, prevY := pixel(points[0])
+	for _, p := range points[1:] {
+		x, y := pixel(p)
+		// the line is two pixels thick.
+		drawLine(img, prevX, prevY, x, y, lineColor)
+		drawLine(img, prevX, prevY-1, x, y-1, lineColor)
+		prevX, prevY = x, y
+	}
+
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// drawLine draws the line between the two pixels by the Bresenham's algorithm.
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	e := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,51 @@
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineChart(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := []Point{
+		{Time: start, Value: 100},
+		{Time: start.Add(time.Hour), Value: 300},
+		{Time: start.Add(2 * time.Hour), Value: 200},
+	}
+
+	data, err := LineChart(points, 200, 100)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 200, img.Bounds().Dx())
+	assert.Equal(t, 100, img.Bounds().Dy())
+
+	// the lowest point is at the bottom left of the area, and the highest one is at the top.
+	assert.Equal(t, lineColor, img.At(margin, 100-margin))
+	assert.Equal(t, lineColor, img.At(100, margin))
+	assert.Equal(t, backgroundColor, img.At(1, 1))
+}
+
+func TestLineChartFlat(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	data, err := LineChart([]Point{
+		{Time: start, Value: 7},
+		{Time: start.Add(time.Hour), Value: 7},
+	}, 200, 100)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, lineColor, img.At(100, 50), "the flat series is drawn in the middle")
+}
+
+func TestNotEnoughPoints(t *testing.T) {
+	_, err := LineChart([]Point{{Time: time.Now(), Value: 1}}, 200, 100)
+	assert.ErrorIs(t, err, ErrNotEnoughPoints)
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kehiy/RoboPac/engine"
+)
+
+// Complete returns the candidates of the last word of the line, like the command names,
+// the subcommand names and the argument values, see engine.Autocomplete.
+func (r *REPL) Complete(line string) []string {
+	words := parseInputs(line)
+	current := ""
+	if len(words) > 0 && !strings.HasSuffix(line, " ") {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	if len(words) == 0 {
+		names := []string{exitCommand, historyCommand, quitCommand}
+		for _, cmd := range r.engine.Commands() {
+			if cmd.HasAppId(engine.AppIdCLI) {
+				names = append(names, cmd.Name)
+			}
+		}
+
+		return withPrefix(names, current)
+	}
+
+	cmds := r.engine.Commands()
+	index := slices.IndexFunc(cmds, func(cmd engine.Command) bool { return cmd.Name == words[0] })
+	if index == -1 {
+		return nil
+	}
+	cmd := &cmds[index]
+	inputs := words[:1]
+
+	if len(cmd.SubCommands) > 0 {
+		if len(words) == 1 {
+			names := make([]string, 0, len(cmd.SubCommands))
+			for _, sub := range cmd.SubCommands {
+				names = append(names, sub.Name)
+			}
+
+			return withPrefix(names, current)
+		}
+
+		cmd = cmd.SubCommand(words[1])
+		if cmd == nil {
+			return nil
+		}
+		inputs = words[:2]
+	}
+
+	argIndex := len(words) - len(inputs)
+	if argIndex >= len(cmd.Args) {
+		return nil
+	}
+
+	return r.engine.Autocomplete(r.callerID, inputs, cmd.Args[argIndex].Name, current)
+}
+
+func withPrefix(values []string, prefix string) []string {
+	matched := []string{}
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			matched = append(matched, value)
+		}
+	}
+	slices.Sort(matched)
+
+	return slices.Compact(matched)
+}
+
+// commonPrefix returns the longest prefix of the values.
+func commonPrefix(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	// the prefix may end in the middle of a character.
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+
+	return prefix
+}
@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// errInterrupted is returned when the line is canceled by Ctrl-C.
+var errInterrupted = errors.New("interrupted")
+
+// The control keys of the terminal.
+const (
+	keyCtrlA     = 1
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyBackspace = 8
+	keyTab       = 9
+	keyLineFeed  = 10
+	keyEnter     = 13
+	keyCtrlU     = 21
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// lineEditor reads the lines from a terminal in the raw mode. It supports moving the cursor,
+// walking through the history by the arrow keys and completing the words by the tab key.
+type lineEditor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	history  func() []string
+	complete func(line string) []string
+
+	line []rune
+	pos  int
+	// histPos is the index of the shown history line, it's the length of the history for the new line.
+	histPos int
+	// draft keeps the new line while walking through the history.
+	draft []rune
+}
+
+func newLineEditor(in io.Reader, out io.Writer, history func() []string,
+	complete func(line string) []string,
+) *lineEditor {
+	return &lineEditor{
+		in:       bufio.NewReader(in),
+		out:      out,
+		history:  history,
+		complete: complete,
+	}
+}
+
+// readLine reads a line, it returns io.EOF by Ctrl-D on an empty line and errInterrupted by Ctrl-C.
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	e.line, e.pos = nil, 0
+	e.histPos, e.draft = len(e.history()), nil
+	fmt.Fprint(e.out, prompt)
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case keyEnter, keyLineFeed:
+			fmt.Fprint(e.out, "\r\n")
+
+			return string(e.line), nil
+
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+
+			return "", errInterrupted
+
+		case keyCtrlD:
+			if len(e.line) == 0 {
+				return "", io.EOF
+			}
+			e.deleteAt(e.pos)
+
+		case keyBackspace, keyDelete:
+			if e.pos > 0 {
+				e.pos--
+				e.deleteAt(e.pos)
+			}
+
+		case keyCtrlA:
+			e.pos = 0
+
+		case keyCtrlE:
+			e.pos = len(e.line)
+
+		case keyCtrlU:
+			e.line = e.line[e.pos:]
+			e.pos = 0
+
+		case keyTab:
+			e.completeWord(prompt)
+
+		case keyEscape:
+			if err := e.escape(); err != nil {
+				return "", err
+			}
+
+		default:
+			if !unicode.IsPrint(r) {
+				continue
+			}
+			e.insert(r)
+		}
+
+		e.refresh(prompt)
+	}
+}
+
+// escape handles the escape sequences of the arrow, home, end and delete keys.
+// The keys are like "ESC [ A", or "ESC O A" in the application mode of the terminal.
+func (e *lineEditor) escape() error {
+	if b, err := e.in.ReadByte(); err != nil || (b != '[' && b != 'O') {
+		return err
+	}
+
+	key, err := e.in.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch key {
+	case 'A':
+		e.walkHistory(-1)
+	case 'B':
+		e.walkHistory(1)
+	case 'C':
+		e.pos = min(e.pos+1, len(e.line))
+	case 'D':
+		e.pos = max(e.pos-1, 0)
+	case 'H':
+		e.pos = 0
+	case 'F':
+		e.pos = len(e.line)
+	case '3':
+		// the delete key is "ESC [ 3 ~".
+		if b, err := e.in.ReadByte(); err != nil || b != '~' {
+			return err
+		}
+		e.deleteAt(e.pos)
+	}
+
+	return nil
+}
+
+func (e *lineEditor) walkHistory(step int) {
+	history := e.history()
+	next := e.histPos + step
+	if next < 0 || next > len(history) {
+		return
+	}
+
+	if e.histPos == len(history) {
+		e.draft = e.line
+	}
+	e.histPos = next
+
+	if next == len(history) {
+		e.line = e.draft
+	} else {
+		e.line = []rune(history[next])
+	}
+	e.pos = len(e.line)
+}
+
+// completeWord completes the word before the cursor. The common prefix of the candidates is filled in,
+// and the candidates are listed if there is nothing to fill in.
+func (e *lineEditor) completeWord(prompt string) {
+	before := string(e.line[:e.pos])
+	candidates := e.complete(before)
+	if len(candidates) == 0 {
+		return
+	}
+
+	word := before[strings.LastIndexAny(before, " \t")+1:]
+	completion := commonPrefix(candidates)
+	if len(candidates) == 1 {
+		completion += " "
+	}
+
+	if len(completion) > len(word) && strings.HasPrefix(completion, word) {
+		for _, r := range completion[len(word):] {
+			e.insert(r)
+		}
+
+		return
+	}
+
+	if len(candidates) > 1 {
+		fmt.Fprint(e.out, "\r\n"+strings.Join(candidates, "  ")+"\r\n")
+		e.refresh(prompt)
+	}
+}
+
+func (e *lineEditor) insert(r rune) {
+	e.line = append(e.line[:e.pos], append([]rune{r}, e.line[e.pos:]...)...)
+	e.pos++
+}
+
+func (e *lineEditor) deleteAt(pos int) {
+	if pos < len(e.line) {
+		e.line = append(e.line[:pos], e.line[pos+1:]...)
+	}
+}
+
+// refresh redraws the line and moves the cursor to its position.
+func (e *lineEditor) refresh(prompt string) {
+	fmt.Fprint(e.out, "\r"+prompt+string(e.line)+"\x1b[K")
+	if back := len(e.line) - e.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
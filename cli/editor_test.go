@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readEditorLine(t *testing.T, keys string, history []string, completions ...string) (string, error) {
+	t.Helper()
+
+	complete := func(line string) []string {
+		word := line[strings.LastIndex(line, " ")+1:]
+
+		return withPrefix(completions, word)
+	}
+	editor := newLineEditor(strings.NewReader(keys), &bytes.Buffer{},
+		func() []string { return history }, complete)
+
+	return editor.readLine(prompt)
+}
+
+func TestLineEditor(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        string
+		history     []string
+		completions []string
+		want        string
+	}{
+		{name: "typing", keys: "network\r", want: "network"},
+		{name: "backspace", keys: "networkk\x7f\r", want: "network"},
+		{name: "moving the cursor", keys: "ntwork\x1b[D\x1b[D\x1b[D\x1b[D\x1b[De\r", want: "network"},
+		{name: "home and end", keys: "etwor\x01n\x05k\r", want: "network"},
+		{name: "delete", keys: "networkx\x1b[D\x1b[3~\r", want: "network"},
+		{name: "clear before the cursor", keys: "help network\x01\x1b[C\x1b[C\x1b[C\x1b[C\x1b[C\x15\r", want: "network"},
+		{name: "previous history", keys: "\x1b[A\x1b[A\r", history: []string{"help", "network"}, want: "help"},
+		{name: "back to the draft", keys: "no\x1b[A\x1b[B\r", history: []string{"help"}, want: "no"},
+		{name: "history in the application mode", keys: "\x1bOA\r", history: []string{"help"}, want: "help"},
+		{name: "single completion", keys: "net\t\r", completions: []string{"network", "node-info"}, want: "network "},
+		{name: "common prefix", keys: "node-info pc\t\r", completions: []string{"pc1pabc", "pc1pabd"}, want: "node-info pc1pab"},
+		{name: "listing the candidates", keys: "n\t\r", completions: []string{"network", "node-info"}, want: "n"},
+		{name: "unicode", keys: "memo héllo\r", want: "memo héllo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, err := readEditorLine(t, tt.keys, tt.history, tt.completions...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, line)
+		})
+	}
+
+	t.Run("interrupt", func(t *testing.T) {
+		_, err := readEditorLine(t, "network\x03", nil)
+		assert.ErrorIs(t, err, errInterrupted)
+	})
+
+	t.Run("end of input", func(t *testing.T) {
+		_, err := readEditorLine(t, "\x04", nil)
+		assert.ErrorIs(t, err, io.EOF)
+
+		line, err := readEditorLine(t, "ab\x01\x04\r", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "b", line)
+	})
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+)
+
+// History keeps the entered lines, the oldest first. The lines are appended to the history file, if it's set,
+// so they are available in the next sessions.
+type History struct {
+	path  string
+	max   int
+	lines []string
+}
+
+// LoadHistory reads the last max lines of the history file, the file is created by the first added line.
+// The history is kept in memory only if the path is empty.
+func LoadHistory(path string, max int) (*History, error) {
+	h := &History{path: path, max: max}
+	if path == "" {
+		return h, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return h, nil
+		}
+
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		h.push(scanner.Text())
+	}
+
+	return h, scanner.Err()
+}
+
+// Add adds the line to the history, the empty lines and the repeats of the last line are skipped.
+func (h *History) Add(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || (len(h.lines) > 0 && h.lines[len(h.lines)-1] == line) {
+		return nil
+	}
+	h.push(line)
+
+	if h.path == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(line + "\n")
+
+	return err
+}
+
+// Lines returns the lines of the history, the oldest first.
+func (h *History) Lines() []string {
+	return h.lines
+}
+
+func (h *History) push(line string) {
+	if line == "" {
+		return
+	}
+
+	h.lines = append(h.lines, line)
+	if h.max > 0 && len(h.lines) > h.max {
+		h.lines = h.lines[len(h.lines)-h.max:]
+	}
+}
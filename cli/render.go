@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kehiy/RoboPac/engine"
+)
+
+// resultText renders the result of a command as plain text for the terminal.
+func resultText(res *engine.CommandResult, messages *engine.MessageCatalog) string {
+	var title string
+	switch {
+	case res.Maintenance:
+		title = "🔧 " + messages.Get(engine.MsgTitleMaintenance)
+	case res.Successful:
+		title = messages.Get(engine.MsgTitleSuccessful)
+	default:
+		title = messages.Get(engine.MsgTitleFailed)
+	}
+
+	if res.Title != "" && !res.Maintenance {
+		title = res.Title
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("# " + title + "\n\n")
+
+	for _, w := range res.Warnings {
+		sb.WriteString("⚠️ " + w + "\n")
+	}
+	if len(res.Warnings) > 0 {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(res.Message)
+
+	if res.Table != nil {
+		sb.WriteString("\n\n" + res.Table.String())
+	}
+
+	if len(res.Fields) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, f := range res.Fields {
+		sb.WriteString("\n" + f.Name + ": " + f.Value)
+	}
+
+	if res.Attachment != nil {
+		sb.WriteString(fmt.Sprintf("\n\nAttachment: %s (%s, %d bytes)",
+			res.Attachment.Name, res.Attachment.ContentType, len(res.Attachment.Data)))
+	}
+
+	footer := []string{}
+	if res.List != nil {
+		footer = append(footer, res.List.Footer())
+	}
+	if len(res.Suggestions) > 0 {
+		footer = append(footer, "Try next: "+strings.Join(res.Suggestions, ", "))
+	}
+	if len(footer) > 0 {
+		sb.WriteString("\n\n" + strings.Join(footer, " • "))
+	}
+
+	return sb.String()
+}
+
+func errorText(messages *engine.MessageCatalog, errStr string) string {
+	if errStr == "" {
+		errStr = messages.Get(engine.MsgErrorFallback)
+	}
+
+	return "# " + messages.Get(engine.MsgTitleError) + "\n\n" + errStr
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kehiy/RoboPac/engine"
+)
+
+const (
+	prompt = ">> "
+
+	exitCommand    = "exit"
+	quitCommand    = "quit"
+	historyCommand = "history"
+)
+
+// Engine is the part of the bot engine that the REPL uses.
+type Engine interface {
+	Run(appID engine.AppID, callerID string, inputs []string) (*engine.CommandResult, error)
+	Commands() []engine.Command
+	MessagesFor(callerID string) *engine.MessageCatalog
+	Autocomplete(callerID string, inputs []string, argName, prefix string) []string
+}
+
+// REPL runs the commands of the engine in an interactive shell, like the other apps run them.
+type REPL struct {
+	engine   Engine
+	callerID string
+	history  *History
+	out      io.Writer
+}
+
+// New creates a REPL that runs the commands as the caller, and writes the results to the out.
+func New(be Engine, callerID string, history *History, out io.Writer) *REPL {
+	return &REPL{
+		engine:   be,
+		callerID: callerID,
+		history:  history,
+		out:      out,
+	}
+}
+
+// Run reads the lines from the input and executes them, until the exit command or the end of the input.
+// The line editing, the history and the completion are available if the input is a terminal.
+func (r *REPL) Run(in *os.File) error {
+	fmt.Fprintf(r.out, "RoboPac shell, type `%s` for the commands and `%s` to leave.\n", engine.HelpCommandName, exitCommand)
+
+	readLine := r.plainReader(in)
+	if term, err := newTerminal(in); err == nil {
+		editor := newLineEditor(in, r.out, r.history.Lines, r.Complete)
+		readLine = func() (string, error) {
+			if err := term.makeRaw(); err != nil {
+				return "", err
+			}
+			defer term.restore()
+
+			return editor.readLine(prompt)
+		}
+	}
+
+	for {
+		line, err := readLine()
+		if err != nil {
+			if errors.Is(err, errInterrupted) {
+				continue
+			}
+			if errors.Is(err, io.EOF) {
+				fmt.Fprintln(r.out)
+
+				return nil
+			}
+
+			return err
+		}
+
+		if !r.Exec(line) {
+			return nil
+		}
+	}
+}
+
+func (r *REPL) plainReader(in io.Reader) func() (string, error) {
+	reader := bufio.NewReader(in)
+
+	return func() (string, error) {
+		fmt.Fprint(r.out, prompt)
+
+		line, err := reader.ReadString('\n')
+		if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+			return "", err
+		}
+
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+}
+
+// Exec executes the line and writes the result, it returns false if the line is the exit command.
+func (r *REPL) Exec(line string) bool {
+	inputs := parseInputs(line)
+	if len(inputs) == 0 {
+		return true
+	}
+
+	if err := r.history.Add(line); err != nil {
+		fmt.Fprintf(r.out, "can't save the history: %v\n", err)
+	}
+
+	switch strings.ToLower(inputs[0]) {
+	case exitCommand, quitCommand:
+		return false
+
+	case historyCommand:
+		for i, l := range r.history.Lines() {
+			fmt.Fprintf(r.out, "%4d  %s\n", i+1, l)
+		}
+
+		return true
+	}
+
+	msgs := r.engine.MessagesFor(r.callerID)
+	res, err := r.engine.Run(engine.AppIdCLI, r.callerID, inputs)
+	if err != nil {
+		fmt.Fprintln(r.out, errorText(msgs, err.Error()))
+
+		return true
+	}
+
+	fmt.Fprintln(r.out, resultText(res, msgs))
+
+	return true
+}
+
+// parseInputs splits the line into the engine inputs by the spaces,
+// the quoted parts are kept together, like `create-offer "10 PAC"`.
+func parseInputs(line string) []string {
+	inputs := []string{}
+	current := strings.Builder{}
+	quote, quoted := rune(0), false
+
+	for _, c := range line {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote, quoted = c, true
+		case quote == 0 && (c == ' ' || c == '\t'):
+			if current.Len() > 0 || quoted {
+				inputs = append(inputs, current.String())
+			}
+			current.Reset()
+			quoted = false
+		default:
+			current.WriteRune(c)
+		}
+	}
+
+	if current.Len() > 0 || quoted {
+		inputs = append(inputs, current.String())
+	}
+
+	return inputs
+}
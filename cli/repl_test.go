@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEngine records the inputs and returns the result or the error.
+type fakeEngine struct {
+	cmds   []engine.Command
+	inputs [][]string
+	res    *engine.CommandResult
+	err    error
+
+	// completions are the autocomplete values of the arguments by their names.
+	completions map[string][]string
+}
+
+func (f *fakeEngine) Run(_ engine.AppID, _ string, inputs []string) (*engine.CommandResult, error) {
+	f.inputs = append(f.inputs, inputs)
+
+	return f.res, f.err
+}
+
+func (f *fakeEngine) Commands() []engine.Command {
+	return f.cmds
+}
+
+func (*fakeEngine) MessagesFor(_ string) *engine.MessageCatalog {
+	return engine.NewMessageCatalog()
+}
+
+func (f *fakeEngine) Autocomplete(_ string, _ []string, argName, prefix string) []string {
+	return withPrefix(f.completions[argName], prefix)
+}
+
+func setupREPL(t *testing.T) (*REPL, *fakeEngine, *bytes.Buffer) {
+	t.Helper()
+
+	be := &fakeEngine{
+		cmds: []engine.Command{
+			{Name: "node-info", AppIDs: []engine.AppID{engine.AppIdCLI}, Args: []engine.Args{{Name: "validator-address"}}},
+			{Name: "network", AppIDs: []engine.AppID{engine.AppIdCLI}},
+			{Name: "faucet", AppIDs: []engine.AppID{engine.AppIdDiscord}},
+			{
+				Name:   "wallet",
+				AppIDs: []engine.AppID{engine.AppIdCLI},
+				SubCommands: []engine.Command{
+					{Name: "balance"},
+					{Name: "send", Args: []engine.Args{{Name: "address"}, {Name: "amount"}}},
+				},
+			},
+		},
+		res: engine.MakeSuccessfulResult("ok"),
+		completions: map[string][]string{
+			"validator-address": {"pc1pabc", "pc1pabd", "pc1pxyz"},
+			"amount":            {"10"},
+		},
+	}
+
+	history, err := LoadHistory("", 10)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+
+	return New(be, "tester", history, out), be, out
+}
+
+func TestExec(t *testing.T) {
+	repl, be, out := setupREPL(t)
+
+	t.Run("command", func(t *testing.T) {
+		assert.True(t, repl.Exec(`create-offer "10 PAC"  ""`))
+		assert.Equal(t, []string{"create-offer", "10 PAC", ""}, be.inputs[0])
+		assert.Equal(t, "# Successful\n\nok\n", out.String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		out.Reset()
+		be.err = errors.New("node is down")
+		assert.True(t, repl.Exec("network"))
+		assert.Equal(t, "# Error\n\nnode is down\n", out.String())
+	})
+
+	t.Run("history", func(t *testing.T) {
+		out.Reset()
+		assert.True(t, repl.Exec("history"))
+		assert.Equal(t, "   1  create-offer \"10 PAC\"  \"\"\n   2  network\n   3  history\n", out.String())
+	})
+
+	t.Run("empty line", func(t *testing.T) {
+		assert.True(t, repl.Exec("   "))
+		assert.Len(t, repl.history.Lines(), 3)
+	})
+
+	t.Run("exit", func(t *testing.T) {
+		assert.False(t, repl.Exec("exit"))
+		assert.False(t, repl.Exec("QUIT"))
+	})
+}
+
+func TestRun(t *testing.T) {
+	repl, be, out := setupREPL(t)
+
+	// a file is not a terminal, so the lines are read without editing.
+	input, err := os.CreateTemp(t.TempDir(), "input")
+	require.NoError(t, err)
+	_, err = input.WriteString("network\nnode-info pc1p\nexit\nnetwork\n")
+	require.NoError(t, err)
+	_, err = input.Seek(0, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, repl.Run(input))
+	assert.Equal(t, [][]string{{"network"}, {"node-info", "pc1p"}}, be.inputs)
+	assert.Contains(t, out.String(), prompt+"# Successful")
+}
+
+func TestComplete(t *testing.T) {
+	repl, _, _ := setupREPL(t)
+
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"", []string{"exit", "history", "network", "node-info", "quit", "wallet"}},
+		{"n", []string{"network", "node-info"}},
+		{"fau", []string{}},
+		{"wallet ", []string{"balance", "send"}},
+		{"wallet s", []string{"send"}},
+		{"wallet send pc1 ", []string{"10"}},
+		{"wallet send pc1 10 ", nil},
+		{"node-info pc1pab", []string{"pc1pabc", "pc1pabd"}},
+		{"unknown ", nil},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, repl.Complete(tt.line), "line: %q", tt.line)
+	}
+
+	assert.Equal(t, "pc1pab", commonPrefix([]string{"pc1pabc", "pc1pabd"}))
+	assert.Equal(t, "", commonPrefix(nil))
+}
+
+func TestHistory(t *testing.T) {
+	historyPath := path.Join(t.TempDir(), "history")
+
+	history, err := LoadHistory(historyPath, 2)
+	require.NoError(t, err)
+	require.NoError(t, history.Add("help"))
+	require.NoError(t, history.Add("help"))
+	require.NoError(t, history.Add("network"))
+	require.NoError(t, history.Add("node-info"))
+	assert.Equal(t, []string{"network", "node-info"}, history.Lines())
+
+	loaded, err := LoadHistory(historyPath, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"network", "node-info"}, loaded.Lines())
+}
+
+func TestResultText(t *testing.T) {
+	messages := engine.NewMessageCatalog()
+
+	res := engine.MakeSuccessfulResult("height 100")
+	res.Title = "Network"
+	res.AddWarning("deprecated")
+	res.AddField("Peers", "10", true)
+	res.Attach("peers.csv", "text/csv", []byte("a,b"))
+	res.List = &engine.ListResult{Total: 25, Page: 2, PageSize: 10}
+	res.Suggest("node-info")
+
+	assert.Equal(t, "# Network\n\n"+
+		"⚠️ deprecated\n\n"+
+		"height 100\n\n"+
+		"Peers: 10\n\n"+
+		"Attachment: peers.csv (text/csv, 3 bytes)\n\n"+
+		"Page 2/3 (25 items) • Try next: node-info", resultText(res, messages))
+
+	assert.Equal(t, "# Failed\n\noops", resultText(engine.MakeFailedResult("oops"), messages))
+	assert.Equal(t, "# Error\n\nSomething went wrong, please try again later", errorText(messages, ""))
+}
@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package cli
+
+import (
+	"errors"
+	"os"
+)
+
+// terminal is not supported on this platform, the lines are read without editing.
+type terminal struct{}
+
+func newTerminal(_ *os.File) (*terminal, error) {
+	return nil, errors.New("terminal is not supported")
+}
+
+func (*terminal) makeRaw() error {
+	return errors.New("terminal is not supported")
+}
+
+func (*terminal) restore() {}
@@ -0,0 +1,44 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package cli
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminal switches the terminal between the raw mode, for editing the lines, and its original mode.
+type terminal struct {
+	fd       int
+	original unix.Termios
+}
+
+// newTerminal returns an error if the file is not a terminal.
+func newTerminal(file *os.File) (*terminal, error) {
+	fd := int(file.Fd())
+	termios, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	return &terminal{fd: fd, original: *termios}, nil
+}
+
+// makeRaw disables the echo and the line buffering of the terminal, like cfmakeraw,
+// but the output processing is kept, so the other writers are not garbled.
+func (t *terminal) makeRaw() error {
+	raw := t.original
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+
+	return unix.IoctlSetTermios(t.fd, ioctlWriteTermios, &raw)
+}
+
+func (t *terminal) restore() {
+	_ = unix.IoctlSetTermios(t.fd, ioctlWriteTermios, &t.original)
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// MaxBlocksRange caps the number of the blocks fetched by GetBlocksWithTxs, to protect the node.
+	MaxBlocksRange = 1000
+
+	blocksConcurrency = 8
+)
+
+// GetBlocksWithTxs returns the blocks from fromHeight to toHeight with their transactions, both inclusive,
+// ordered by height. The blocks are fetched concurrently, with a bounded number of the calls in progress.
+func (c *Client) GetBlocksWithTxs(ctx context.Context, fromHeight, toHeight uint32) ([]*pactus.GetBlockResponse, error) {
+	if fromHeight == 0 || toHeight < fromHeight {
+		return nil, fmt.Errorf("invalid block range: %d-%d", fromHeight, toHeight)
+	}
+
+	count := int(toHeight-fromHeight) + 1
+	if count > MaxBlocksRange {
+		return nil, fmt.Errorf("block range is too large: %d blocks, the maximum is %d", count, MaxBlocksRange)
+	}
+
+	blocks := make([]*pactus.GetBlockResponse, count)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(blocksConcurrency)
+	for i := 0; i < count; i++ {
+		i := i
+		height := fromHeight + uint32(i)
+
+		g.Go(func() error {
+			block, err := c.GetBlockWithTxs(ctx, height)
+			if err != nil {
+				return fmt.Errorf("block %d: %w", height, err)
+			}
+			blocks[i] = block
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlocksWithTxs(t *testing.T) {
+	blocks := map[uint32]*pactus.GetBlockResponse{}
+	for height := uint32(1); height <= 20; height++ {
+		blocks[height] = &pactus.GetBlockResponse{
+			Height: height,
+			Txs:    []*pactus.TransactionInfo{{Id: []byte{byte(height)}}},
+		}
+	}
+
+	c := setupClient(t)
+	c.blockchainClient = &fakeBlockchainClient{blocks: blocks}
+
+	t.Run("ordered by height", func(t *testing.T) {
+		res, err := c.GetBlocksWithTxs(context.Background(), 5, 15)
+		require.NoError(t, err)
+		require.Len(t, res, 11)
+
+		for i, block := range res {
+			assert.Equal(t, uint32(5+i), block.Height)
+			assert.Len(t, block.Txs, 1)
+		}
+	})
+
+	t.Run("missing block", func(t *testing.T) {
+		_, err := c.GetBlocksWithTxs(context.Background(), 15, 25)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := c.GetBlocksWithTxs(context.Background(), 10, 5)
+		assert.Error(t, err)
+
+		_, err = c.GetBlocksWithTxs(context.Background(), 1, MaxBlocksRange+1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// MaxBlockTimesRange caps the number of the blocks fetched by GetBlockTimes, to protect the node.
+	MaxBlockTimesRange = 1000
+
+	blockTimesConcurrency = 8
+)
+
+// BlockTimePoint is the time of a block.
+type BlockTimePoint struct {
+	Height uint32
+	Time   time.Time
+}
+
+// GetBlockTimes returns the times of the blocks from fromHeight to toHeight, both inclusive, ordered by height.
+// The blocks are fetched concurrently, with a bounded number of the calls in progress.
+func (c *Client) GetBlockTimes(ctx context.Context, fromHeight, toHeight uint32) ([]BlockTimePoint, error) {
+	if fromHeight == 0 || toHeight < fromHeight {
+		return nil, fmt.Errorf("invalid block range: %d-%d", fromHeight, toHeight)
+	}
+
+	count := int(toHeight-fromHeight) + 1
+	if count > MaxBlockTimesRange {
+		return nil, fmt.Errorf("block range is too large: %d blocks, the maximum is %d", count, MaxBlockTimesRange)
+	}
+
+	points := make([]BlockTimePoint, count)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(blockTimesConcurrency)
+	for i := 0; i < count; i++ {
+		i := i
+		height := fromHeight + uint32(i)
+
+		g.Go(func() error {
+			block, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetBlockResponse, error) {
+				return c.blockchainClient.GetBlock(ctx, &pactus.GetBlockRequest{
+					Height:    height,
+					Verbosity: pactus.BlockVerbosity_BLOCK_INFO,
+				})
+			})
+			if err != nil {
+				return fmt.Errorf("block %d: %w", height, err)
+			}
+
+			points[i] = BlockTimePoint{
+				Height: height,
+				Time:   time.Unix(int64(block.BlockTime), 0),
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// AverageBlockTime returns the average time between the blocks, or zero if there are less than two blocks.
+func AverageBlockTime(points []BlockTimePoint) time.Duration {
+	if len(points) < 2 {
+		return 0
+	}
+
+	first, last := points[0], points[len(points)-1]
+
+	return last.Time.Sub(first.Time) / time.Duration(last.Height-first.Height)
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlockTimes(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	blocks := map[uint32]*pactus.GetBlockResponse{}
+	for height := uint32(1); height <= 20; height++ {
+		blocks[height] = &pactus.GetBlockResponse{
+			Height:    height,
+			BlockTime: uint32(start.Add(time.Duration(height) * 10 * time.Second).Unix()),
+		}
+	}
+
+	c := setupClient(t)
+	c.blockchainClient = &fakeBlockchainClient{blocks: blocks}
+
+	t.Run("small range", func(t *testing.T) {
+		points, err := c.GetBlockTimes(context.Background(), 5, 15)
+		require.NoError(t, err)
+		require.Len(t, points, 11)
+
+		for i, p := range points {
+			assert.Equal(t, uint32(5+i), p.Height)
+			assert.Equal(t, time.Unix(int64(blocks[p.Height].BlockTime), 0), p.Time)
+		}
+
+		assert.Equal(t, 10*time.Second, AverageBlockTime(points))
+	})
+
+	t.Run("single block", func(t *testing.T) {
+		points, err := c.GetBlockTimes(context.Background(), 7, 7)
+		require.NoError(t, err)
+		assert.Len(t, points, 1)
+		assert.Zero(t, AverageBlockTime(points))
+	})
+
+	t.Run("missing block", func(t *testing.T) {
+		_, err := c.GetBlockTimes(context.Background(), 15, 25)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := c.GetBlockTimes(context.Background(), 10, 5)
+		assert.Error(t, err)
+
+		_, err = c.GetBlockTimes(context.Background(), 0, 5)
+		assert.Error(t, err)
+	})
+
+	t.Run("too large range", func(t *testing.T) {
+		_, err := c.GetBlockTimes(context.Background(), 1, MaxBlockTimesRange+1)
+		assert.EqualError(t, err, "block range is too large: 1001 blocks, the maximum is 1000")
+	})
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNodeUnavailable is returned without calling the node, while the circuit breaker of the client is open.
+var ErrNodeUnavailable = errors.New("node unavailable")
+
+// nodeBreaker stops calling the node after threshold consecutive transient failures, for the cooldown period.
+// Then a single call is let through to probe the recovery: a success closes the breaker
+// and a failure opens it again. The permanent errors, like not found, mean that the node is reachable.
+type nodeBreaker struct {
+	lk sync.Mutex
+
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	open      bool
+	probing   bool
+	openedAt  time.Time
+}
+
+// WithCircuitBreaker makes the client fail fast with ErrNodeUnavailable after threshold consecutive failures,
+// until the cooldown is passed. A non-positive threshold disables the circuit breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		if threshold <= 0 {
+			c.breaker = nil
+
+			return
+		}
+
+		c.breaker = &nodeBreaker{
+			threshold: threshold,
+			cooldown:  cooldown,
+		}
+	}
+}
+
+// allow reports whether the node can be called now.
+func (b *nodeBreaker) allow(now time.Time) bool {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	if !b.open {
+		return true
+	}
+
+	// only one probing call is allowed after the cooldown.
+	if b.probing || now.Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+
+	return true
+}
+
+// report records the outcome of a call that was allowed by the breaker.
+func (b *nodeBreaker) report(err error, now time.Time) {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	if err == nil || !isRetryable(err) {
+		b.failures = 0
+		b.open = false
+		b.probing = false
+
+		return
+	}
+
+	b.failures++
+	if b.probing || b.failures >= b.threshold {
+		b.open = true
+		b.probing = false
+		b.openedAt = now
+	}
+}
+
+func (b *nodeBreaker) isOpen() bool {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	return b.open
+}
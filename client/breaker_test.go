@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "node is down")
+
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		clock := NewFakeClock(time.Unix(0, 0))
+		c := setupClient(t, WithClock(clock), WithCircuitBreaker(3, 30*time.Second))
+		fake := &fakeBlockchainClient{
+			info:     &pactus.GetBlockchainInfoResponse{LastBlockHeight: 100},
+			failures: 4,
+			err:      unavailable,
+		}
+		c.blockchainClient = fake
+
+		for i := 0; i < 3; i++ {
+			_, err := c.GetBlockchainInfo(context.Background())
+			assert.Equal(t, codes.Unavailable, status.Code(err))
+		}
+		assert.False(t, c.Healthy())
+
+		_, err := c.GetBlockchainInfo(context.Background())
+		assert.ErrorIs(t, err, ErrNodeUnavailable)
+		assert.Equal(t, 3, fake.calls)
+
+		// the probe after the cooldown fails, so the breaker opens again.
+		clock.Advance(30 * time.Second)
+		_, err = c.GetBlockchainInfo(context.Background())
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		_, err = c.GetBlockchainInfo(context.Background())
+		assert.ErrorIs(t, err, ErrNodeUnavailable)
+
+		// the next probe succeeds and closes the breaker.
+		clock.Advance(30 * time.Second)
+		info, err := c.GetBlockchainInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), info.LastBlockHeight)
+		assert.True(t, c.Healthy())
+		assert.Equal(t, 5, fake.calls)
+	})
+
+	t.Run("permanent errors don't open it", func(t *testing.T) {
+		c := setupClient(t, WithCircuitBreaker(2, time.Minute))
+		fake := &fakeBlockchainClient{failures: 5, err: status.Error(codes.NotFound, "not found")}
+		c.blockchainClient = fake
+
+		for i := 0; i < 5; i++ {
+			_, err := c.GetBlockchainInfo(context.Background())
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		}
+		assert.Equal(t, 5, fake.calls)
+	})
+
+	t.Run("retries stop when it opens", func(t *testing.T) {
+		c := setupClient(t, WithClock(NewFakeClock(time.Unix(0, 0))),
+			WithRetry(5, 0), WithRetryBudget(nil), WithCircuitBreaker(2, time.Minute))
+		fake := &fakeBlockchainClient{failures: 5, err: unavailable}
+		c.blockchainClient = fake
+
+		_, err := c.GetBlockchainInfo(context.Background())
+		assert.ErrorIs(t, err, ErrNodeUnavailable)
+		assert.Equal(t, 2, fake.calls)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c := setupClient(t, WithCircuitBreaker(0, time.Minute))
+		assert.Nil(t, c.breaker)
+	})
+}
@@ -0,0 +1,241 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// CacheTTLs are the time to live of the cached responses per method. A zero TTL disables the cache of the method.
+type CacheTTLs struct {
+	BlockchainInfo time.Duration
+	NetworkInfo    time.Duration
+	NodeInfo       time.Duration
+	ValidatorInfo  time.Duration
+	Account        time.Duration
+	Transaction    time.Duration
+}
+
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		BlockchainInfo: 10 * time.Second,
+		NetworkInfo:    10 * time.Second,
+		NodeInfo:       30 * time.Second,
+		ValidatorInfo:  30 * time.Second,
+		Account:        10 * time.Second,
+		Transaction:    5 * time.Minute,
+	}
+}
+
+// CacheStats are the hit and miss counts of the cached responses.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// HitRate returns the ratio of the calls that were served from the cache, or zero if there is no call.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+	// the entries that depend on the chain state are dropped when a new block is seen.
+	heightBound bool
+}
+
+// CachedClient caches the responses of the wrapped client, so the frequent queries don't hammer the node.
+// The responses that depend on the chain state are invalidated when a new block height is seen.
+// The errors are never cached.
+type CachedClient struct {
+	IClient
+
+	ttls  CacheTTLs
+	clock Clock
+
+	lk      sync.Mutex
+	entries map[string]cacheEntry
+	height  uint32
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func NewCachedClient(c IClient, ttls CacheTTLs) *CachedClient {
+	return &CachedClient{
+		IClient: c,
+		ttls:    ttls,
+		clock:   realClock{},
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func cached[T any](cc *CachedClient, key string, ttl time.Duration, heightBound bool,
+	fetch func() (T, error),
+) (T, error) {
+	if ttl <= 0 {
+		return fetch()
+	}
+
+	now := cc.clock.Now()
+
+	cc.lk.Lock()
+	entry, ok := cc.entries[key]
+	cc.lk.Unlock()
+
+	if ok && now.Before(entry.expires) {
+		cc.hits.Add(1)
+
+		return entry.value.(T), nil
+	}
+
+	cc.misses.Add(1)
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+
+	cc.lk.Lock()
+	cc.entries[key] = cacheEntry{value: value, expires: now.Add(ttl), heightBound: heightBound}
+	cc.lk.Unlock()
+
+	return value, nil
+}
+
+// observeHeight drops the entries that depend on the chain state, if the height is new.
+func (cc *CachedClient) observeHeight(height uint32) {
+	cc.lk.Lock()
+	defer cc.lk.Unlock()
+
+	if height <= cc.height {
+		return
+	}
+
+	cc.height = height
+	for key, entry := range cc.entries {
+		if entry.heightBound {
+			delete(cc.entries, key)
+		}
+	}
+}
+
+func (cc *CachedClient) GetBlockchainInfo(ctx context.Context) (*pactus.GetBlockchainInfoResponse, error) {
+	info, err := cached(cc, "blockchain-info", cc.ttls.BlockchainInfo, false,
+		func() (*pactus.GetBlockchainInfoResponse, error) {
+			info, err := cc.IClient.GetBlockchainInfo(ctx)
+			if err == nil {
+				cc.observeHeight(info.LastBlockHeight)
+			}
+
+			return info, err
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func (cc *CachedClient) GetBlockchainHeight(ctx context.Context) (uint32, error) {
+	info, err := cc.GetBlockchainInfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.LastBlockHeight, nil
+}
+
+func (cc *CachedClient) GetNetworkInfo(ctx context.Context) (*pactus.GetNetworkInfoResponse, error) {
+	return cached(cc, "network-info", cc.ttls.NetworkInfo, false,
+		func() (*pactus.GetNetworkInfoResponse, error) {
+			return cc.IClient.GetNetworkInfo(ctx)
+		})
+}
+
+func (cc *CachedClient) GetNodeInfo(ctx context.Context) (*pactus.GetNodeInfoResponse, error) {
+	return cached(cc, "node-info", cc.ttls.NodeInfo, false,
+		func() (*pactus.GetNodeInfoResponse, error) {
+			return cc.IClient.GetNodeInfo(ctx)
+		})
+}
+
+func (cc *CachedClient) GetValidatorInfo(ctx context.Context, address string) (*pactus.GetValidatorResponse, error) {
+	return cached(cc, "validator:"+address, cc.ttls.ValidatorInfo, true,
+		func() (*pactus.GetValidatorResponse, error) {
+			return cc.IClient.GetValidatorInfo(ctx, address)
+		})
+}
+
+func (cc *CachedClient) GetValidatorInfoByNumber(ctx context.Context, num int32) (*pactus.GetValidatorResponse, error) {
+	return cached(cc, fmt.Sprintf("validator-number:%d", num), cc.ttls.ValidatorInfo, true,
+		func() (*pactus.GetValidatorResponse, error) {
+			return cc.IClient.GetValidatorInfoByNumber(ctx, num)
+		})
+}
+
+// GetTransactionData caches the committed transactions, they never change.
+func (cc *CachedClient) GetTransactionData(ctx context.Context, txID string) (*pactus.GetTransactionResponse, error) {
+	return cached(cc, "transaction:"+txID, cc.ttls.Transaction, false,
+		func() (*pactus.GetTransactionResponse, error) {
+			return cc.IClient.GetTransactionData(ctx, txID)
+		})
+}
+
+func (cc *CachedClient) GetAccount(ctx context.Context, address string) (*pactus.AccountInfo, error) {
+	return cached(cc, "account:"+address, cc.ttls.Account, true,
+		func() (*pactus.AccountInfo, error) {
+			return cc.IClient.GetAccount(ctx, address)
+		})
+}
+
+func (cc *CachedClient) GetBalance(ctx context.Context, address string) (int64, error) {
+	account, err := cc.GetAccount(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return account.Balance, nil
+}
+
+// CacheStats returns the hit and miss counts of the cache.
+func (cc *CachedClient) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   cc.hits.Load(),
+		Misses: cc.misses.Load(),
+	}
+}
+
+// InFlight, Healthy and LastSuccess report the state of the wrapped client, see Mgr.Gauges.
+
+func (cc *CachedClient) InFlight() int64 {
+	if reporter, ok := cc.IClient.(gaugeReporter); ok {
+		return reporter.InFlight()
+	}
+
+	return 0
+}
+
+func (cc *CachedClient) Healthy() bool {
+	if reporter, ok := cc.IClient.(gaugeReporter); ok {
+		return reporter.Healthy()
+	}
+
+	return true
+}
+
+func (cc *CachedClient) LastSuccess() time.Time {
+	if reporter, ok := cc.IClient.(interface{ LastSuccess() time.Time }); ok {
+		return reporter.LastSuccess()
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setupCachedClient(t *testing.T, ttls CacheTTLs) (*CachedClient, *MockIClient, *FakeClock) {
+	t.Helper()
+
+	mockClient := NewMockIClient(gomock.NewController(t))
+	clock := NewFakeClock(time.Unix(1_700_000_000, 0))
+
+	cc := NewCachedClient(mockClient, ttls)
+	cc.clock = clock
+
+	return cc, mockClient, clock
+}
+
+func TestCachedClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("served from the cache until the TTL", func(t *testing.T) {
+		cc, mockClient, clock := setupCachedClient(t, DefaultCacheTTLs())
+
+		mockClient.EXPECT().GetNetworkInfo(ctx).Return(&pactus.GetNetworkInfoResponse{NetworkName: "a"}, nil)
+		mockClient.EXPECT().GetNetworkInfo(ctx).Return(&pactus.GetNetworkInfoResponse{NetworkName: "b"}, nil)
+
+		info, err := cc.GetNetworkInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "a", info.NetworkName)
+
+		clock.Advance(9 * time.Second)
+		info, err = cc.GetNetworkInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "a", info.NetworkName)
+
+		clock.Advance(time.Second)
+		info, err = cc.GetNetworkInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "b", info.NetworkName)
+
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 2}, cc.CacheStats())
+	})
+
+	t.Run("invalidated on a new block", func(t *testing.T) {
+		cc, mockClient, clock := setupCachedClient(t, DefaultCacheTTLs())
+
+		mockClient.EXPECT().GetBlockchainInfo(ctx).Return(&pactus.GetBlockchainInfoResponse{LastBlockHeight: 100}, nil)
+		mockClient.EXPECT().GetBlockchainInfo(ctx).Return(&pactus.GetBlockchainInfoResponse{LastBlockHeight: 101}, nil)
+		mockClient.EXPECT().GetAccount(ctx, "addr").Return(&pactus.AccountInfo{Balance: 1}, nil)
+		mockClient.EXPECT().GetAccount(ctx, "addr").Return(&pactus.AccountInfo{Balance: 2}, nil)
+		mockClient.EXPECT().GetTransactionData(ctx, "tx").Return(&pactus.GetTransactionResponse{BlockHeight: 99}, nil)
+
+		height, err := cc.GetBlockchainHeight(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), height)
+
+		balance, err := cc.GetBalance(ctx, "addr")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), balance)
+		_, err = cc.GetTransactionData(ctx, "tx")
+		require.NoError(t, err)
+
+		clock.Advance(10 * time.Second)
+		height, err = cc.GetBlockchainHeight(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(101), height)
+
+		// the account is still in its TTL, but it's dropped by the new block.
+		balance, err = cc.GetBalance(ctx, "addr")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), balance)
+
+		// the transactions don't depend on the chain state.
+		tx, err := cc.GetTransactionData(ctx, "tx")
+		require.NoError(t, err)
+		assert.Equal(t, uint32(99), tx.BlockHeight)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		cc, mockClient, _ := setupCachedClient(t, DefaultCacheTTLs())
+
+		mockClient.EXPECT().GetValidatorInfo(ctx, "addr").Return(nil, status.Error(codes.Unavailable, "down"))
+		mockClient.EXPECT().GetValidatorInfo(ctx, "addr").Return(&pactus.GetValidatorResponse{}, nil)
+
+		_, err := cc.GetValidatorInfo(ctx, "addr")
+		require.Error(t, err)
+
+		_, err = cc.GetValidatorInfo(ctx, "addr")
+		require.NoError(t, err)
+	})
+
+	t.Run("zero TTL disables the cache", func(t *testing.T) {
+		cc, mockClient, _ := setupCachedClient(t, CacheTTLs{})
+
+		mockClient.EXPECT().GetNodeInfo(ctx).Return(&pactus.GetNodeInfoResponse{}, nil).Times(2)
+
+		_, err := cc.GetNodeInfo(ctx)
+		require.NoError(t, err)
+		_, err = cc.GetNodeInfo(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, CacheStats{}, cc.CacheStats())
+	})
+
+	t.Run("stats of the manager", func(t *testing.T) {
+		cc, mockClient, _ := setupCachedClient(t, DefaultCacheTTLs())
+		mockClient.EXPECT().GetNodeInfo(gomock.Any()).Return(&pactus.GetNodeInfoResponse{}, nil)
+
+		cm := NewClientMgr(ctx)
+		cm.AddClient(cc)
+		cm.AddClient(NewMockIClient(gomock.NewController(t)))
+
+		_, err := cm.GetNodeInfo()
+		require.NoError(t, err)
+		_, err = cm.GetNodeInfo()
+		require.NoError(t, err)
+
+		stats := cm.CacheStats()
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1}, stats)
+		assert.InDelta(t, 0.5, stats.HitRate(), 0.001)
+	})
+}
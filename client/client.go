@@ -2,12 +2,30 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/pactus-project/pactus/types/tx/payload"
 	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrAccountNotFound is returned when the account doesn't exist on the chain.
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrTransactionNotFound is returned when the transaction doesn't exist on the chain.
+	ErrTransactionNotFound = errors.New("transaction not found")
 )
 
 type Client struct {
@@ -15,27 +33,122 @@ type Client struct {
 	networkClient     pactus.NetworkClient
 	transactionClient pactus.TransactionClient
 	conn              *grpc.ClientConn
+	clock             Clock
+	retry             *retryPolicy
+	breaker           *nodeBreaker
+	callTimeout       time.Duration
+	readOnly          bool
+
+	tlsConfig *tls.Config
+	authToken string
+
+	inFlight    atomic.Int64
+	unhealthy   atomic.Bool
+	lastSuccess atomic.Int64
+
+	// the unix time of the first block, zero if it's not fetched yet.
+	genesisTime atomic.Int64
 }
 
-func NewClient(endpoint string) (*Client, error) {
-	conn, err := grpc.Dial(endpoint,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+type Option func(*Client)
+
+// WithClock replaces the real clock of the client, mostly used for testing.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithCallTimeout bounds each call to the node, a retried call gets a new timeout for each attempt.
+// A non-positive timeout keeps the deadline of the caller.
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.callTimeout = timeout
+	}
+}
+
+// WithTLSConfig sets the TLS config of the grpcs:// endpoints, like the CA and the client certificate.
+// Without it, the system roots verify the nodes. See LoadTLSConfig.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithAuthToken sends the token in the metadata of the calls, for the nodes behind an authenticating proxy.
+// The token requires a grpcs:// endpoint, so it's not sent in plain text.
+func WithAuthToken(token string) Option {
+	return func(c *Client) {
+		c.authToken = token
+	}
+}
+
+// NewClient creates a new client for the endpoint.
+// If the endpoint is empty, it's resolved from the environment. See ResolveEndpoint for the details.
+// The endpoints with the grpcs:// scheme are connected over TLS.
+func NewClient(endpoint string, opts ...Option) (*Client, error) {
+	target := resolveTarget(endpoint)
+	endpoint, err := ParseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		clock: realClock{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithUnaryInterceptor(metricsInterceptor)}
+	if IsTLSTarget(target) {
+		tlsConfig := c.tlsConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	if c.authToken != "" {
+		if !IsTLSTarget(target) {
+			return nil, fmt.Errorf("the auth token of %s requires TLS, use the %s scheme", endpoint, TLSScheme)
+		}
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenCredentials{token: c.authToken}))
+	}
+
+	conn, err := grpc.Dial(endpoint, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Info("establishing new connection", "addr", endpoint)
+	log.Info("establishing new connection", "addr", endpoint, "tls", IsTLSTarget(target))
 
-	return &Client{
-		blockchainClient:  pactus.NewBlockchainClient(conn),
-		networkClient:     pactus.NewNetworkClient(conn),
-		transactionClient: pactus.NewTransactionClient(conn),
-		conn:              conn,
-	}, nil
+	c.blockchainClient = pactus.NewBlockchainClient(conn)
+	c.networkClient = pactus.NewNetworkClient(conn)
+	c.transactionClient = pactus.NewTransactionClient(conn)
+	c.conn = conn
+
+	return c, nil
+}
+
+// metricsInterceptor observes the latency of each gRPC call, by the method and the status code.
+func metricsInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	metrics.GRPCCallDuration.Observe(time.Since(start).Seconds(), method, status.Code(err).String())
+
+	return err
 }
 
 func (c *Client) GetBlockchainInfo(ctx context.Context) (*pactus.GetBlockchainInfoResponse, error) {
-	blockchainInfo, err := c.blockchainClient.GetBlockchainInfo(ctx, &pactus.GetBlockchainInfoRequest{})
+	blockchainInfo, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetBlockchainInfoResponse, error) {
+		return c.blockchainClient.GetBlockchainInfo(ctx, &pactus.GetBlockchainInfoRequest{})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +156,7 @@ func (c *Client) GetBlockchainInfo(ctx context.Context) (*pactus.GetBlockchainIn
 }
 
 func (c *Client) GetBlockchainHeight(ctx context.Context) (uint32, error) {
-	blockchainInfo, err := c.blockchainClient.GetBlockchainInfo(ctx, &pactus.GetBlockchainInfoRequest{})
+	blockchainInfo, err := c.GetBlockchainInfo(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -51,7 +164,9 @@ func (c *Client) GetBlockchainHeight(ctx context.Context) (uint32, error) {
 }
 
 func (c *Client) GetNetworkInfo(ctx context.Context) (*pactus.GetNetworkInfoResponse, error) {
-	networkInfo, err := c.networkClient.GetNetworkInfo(ctx, &pactus.GetNetworkInfoRequest{})
+	networkInfo, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetNetworkInfoResponse, error) {
+		return c.networkClient.GetNetworkInfo(ctx, &pactus.GetNetworkInfoRequest{})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +191,9 @@ func (c *Client) GetPeerInfo(ctx context.Context, address string) (*pactus.PeerI
 }
 
 func (c *Client) GetValidatorInfo(ctx context.Context, address string) (*pactus.GetValidatorResponse, error) {
-	validator, err := c.blockchainClient.GetValidator(ctx,
-		&pactus.GetValidatorRequest{Address: address})
+	validator, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetValidatorResponse, error) {
+		return c.blockchainClient.GetValidator(ctx, &pactus.GetValidatorRequest{Address: address})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -86,8 +202,9 @@ func (c *Client) GetValidatorInfo(ctx context.Context, address string) (*pactus.
 }
 
 func (c *Client) GetValidatorInfoByNumber(ctx context.Context, num int32) (*pactus.GetValidatorResponse, error) {
-	validator, err := c.blockchainClient.GetValidatorByNumber(ctx,
-		&pactus.GetValidatorByNumberRequest{Number: num})
+	validator, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetValidatorResponse, error) {
+		return c.blockchainClient.GetValidatorByNumber(ctx, &pactus.GetValidatorByNumberRequest{Number: num})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -96,11 +213,7 @@ func (c *Client) GetValidatorInfoByNumber(ctx context.Context, num int32) (*pact
 }
 
 func (c *Client) TransactionData(ctx context.Context, hash string) (*pactus.TransactionInfo, error) {
-	data, err := c.transactionClient.GetTransaction(ctx,
-		&pactus.GetTransactionRequest{
-			Id:        []byte(hash),
-			Verbosity: pactus.TransactionVerbosity_TRANSACTION_DATA,
-		})
+	data, err := c.GetTransactionData(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
@@ -108,22 +221,44 @@ func (c *Client) TransactionData(ctx context.Context, hash string) (*pactus.Tran
 	return data.GetTransaction(), nil
 }
 
+// GetBlockWithTxs returns the block at the height, with the raw data of its transactions.
+func (c *Client) GetBlockWithTxs(ctx context.Context, height uint32) (*pactus.GetBlockResponse, error) {
+	block, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetBlockResponse, error) {
+		return c.blockchainClient.GetBlock(ctx, &pactus.GetBlockRequest{
+			Height:    height,
+			Verbosity: pactus.BlockVerbosity_BLOCK_TRANSACTIONS,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
 func (c *Client) LastBlockTime(ctx context.Context) (uint32, uint32, error) {
-	info, err := c.blockchainClient.GetBlockchainInfo(ctx, &pactus.GetBlockchainInfoRequest{})
+	info, err := c.GetBlockchainInfo(ctx)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	lastBlockTime, err := c.blockchainClient.GetBlock(ctx, &pactus.GetBlockRequest{
-		Height:    info.LastBlockHeight,
-		Verbosity: pactus.BlockVerbosity_BLOCK_INFO,
+	lastBlockTime, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetBlockResponse, error) {
+		return c.blockchainClient.GetBlock(ctx, &pactus.GetBlockRequest{
+			Height:    info.LastBlockHeight,
+			Verbosity: pactus.BlockVerbosity_BLOCK_INFO,
+		})
 	})
+	if err != nil {
+		return 0, 0, err
+	}
 
-	return lastBlockTime.BlockTime, info.LastBlockHeight, err
+	return lastBlockTime.BlockTime, info.LastBlockHeight, nil
 }
 
 func (c *Client) GetNodeInfo(ctx context.Context) (*pactus.GetNodeInfoResponse, error) {
-	info, err := c.networkClient.GetNodeInfo(ctx, &pactus.GetNodeInfoRequest{})
+	info, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetNodeInfoResponse, error) {
+		return c.networkClient.GetNodeInfo(ctx, &pactus.GetNodeInfoRequest{})
+	})
 	if err != nil {
 		return &pactus.GetNodeInfoResponse{}, err
 	}
@@ -131,24 +266,117 @@ func (c *Client) GetNodeInfo(ctx context.Context) (*pactus.GetNodeInfoResponse,
 	return info, err
 }
 
+// GetTransactionData returns the transaction by its ID in hex.
+// It returns ErrTransactionNotFound if the transaction doesn't exist on the chain.
 func (c *Client) GetTransactionData(ctx context.Context, txID string) (*pactus.GetTransactionResponse, error) {
-	return c.transactionClient.GetTransaction(ctx, &pactus.GetTransactionRequest{
-		Id:        []byte(txID),
-		Verbosity: pactus.TransactionVerbosity_TRANSACTION_DATA,
+	id, err := hex.DecodeString(txID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction ID: %s", txID)
+	}
+
+	data, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetTransactionResponse, error) {
+		return c.transactionClient.GetTransaction(ctx, &pactus.GetTransactionRequest{
+			Id:        id,
+			Verbosity: pactus.TransactionVerbosity_TRANSACTION_DATA,
+		})
 	})
+	if err != nil {
+		// the node reports the unknown transactions as invalid arguments.
+		if status.Code(err) == codes.NotFound ||
+			(status.Code(err) == codes.InvalidArgument && strings.Contains(status.Convert(err).Message(), "not found")) {
+			return nil, fmt.Errorf("%w: %s", ErrTransactionNotFound, txID)
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// GetAccount returns the account of the address.
+// It returns ErrAccountNotFound if the account doesn't exist on the chain.
+//
+// Note that the accounts don't have any sequence number in this version of the protocol.
+// The transactions are protected against replay by their lock time, which is
+// the current block height (see GetBlockchainHeight).
+func (c *Client) GetAccount(ctx context.Context, address string) (*pactus.AccountInfo, error) {
+	res, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetAccountResponse, error) {
+		return c.blockchainClient.GetAccount(ctx, &pactus.GetAccountRequest{
+			Address: address,
+		})
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, address)
+		}
+
+		return nil, err
+	}
+
+	return res.Account, nil
 }
 
 func (c *Client) GetBalance(ctx context.Context, address string) (int64, error) {
-	account, err := c.blockchainClient.GetAccount(ctx, &pactus.GetAccountRequest{
-		Address: address,
+	account, err := c.GetAccount(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return account.Balance, nil
+}
+
+// CalculateFee returns the fee of a transaction with the amount and the payload type.
+func (c *Client) CalculateFee(ctx context.Context, amount int64, payloadType payload.Type) (int64, error) {
+	res, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.CalculateFeeResponse, error) {
+		return c.transactionClient.CalculateFee(ctx, &pactus.CalculateFeeRequest{
+			Amount:      amount,
+			PayloadType: pactus.PayloadType(payloadType),
+		})
 	})
 	if err != nil {
 		return 0, err
 	}
 
-	return account.Account.Balance, nil
+	return res.Fee, nil
+}
+
+// InFlight returns the number of the calls in progress.
+func (c *Client) InFlight() int64 {
+	return c.inFlight.Load()
+}
+
+// Healthy reports whether the last call to the node didn't fail with a transient error,
+// and the circuit breaker is not open.
+func (c *Client) Healthy() bool {
+	return !c.unhealthy.Load() && (c.breaker == nil || !c.breaker.isOpen())
+}
+
+// LastSuccess returns the time of the last successful call, or the zero time if no call succeeded yet.
+// A long gap means the connection is stale, even if the connection state is still ready.
+func (c *Client) LastSuccess() time.Time {
+	lastSuccess := c.lastSuccess.Load()
+	if lastSuccess == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, lastSuccess)
+}
+
+// Target returns the endpoint that the client is connected to.
+func (c *Client) Target() string {
+	return c.conn.Target()
 }
 
 func (c *Client) Close() error {
 	return c.conn.Close()
 }
+
+// LastBlockAge returns the elapsed time since the last block was committed.
+func (c *Client) LastBlockAge(ctx context.Context) (time.Duration, error) {
+	lastBlockTime, _, err := c.LastBlockTime(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.clock.Now().Sub(time.Unix(int64(lastBlockTime), 0)), nil
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kehiy/RoboPac/log"
+	"github.com/pactus-project/pactus/types/tx/payload"
 	"github.com/pactus-project/pactus/util/logger"
 	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
 )
@@ -17,7 +19,14 @@ type Mgr struct {
 	valMap     map[string]*pactus.PeerInfo
 
 	ctx     context.Context
+	network string
 	clients []IClient
+	states  []*endpointState
+
+	policy              SelectionPolicy
+	healthCheckInterval time.Duration
+	onHealthChange      func(HealthChange)
+	next                atomic.Uint32
 }
 
 func NewClientMgr(ctx context.Context) *Mgr {
@@ -26,6 +35,8 @@ func NewClientMgr(ctx context.Context) *Mgr {
 		valMap:     make(map[string]*pactus.PeerInfo),
 		valMapLock: sync.RWMutex{},
 		ctx:        ctx,
+		network:    NetworkMainnet,
+		policy:     SelectionPriority,
 	}
 }
 
@@ -46,6 +57,25 @@ func (cm *Mgr) Start() {
 	}()
 
 	cm.updateValMap()
+
+	if cm.healthCheckInterval > 0 {
+		go cm.runHealthCheck()
+	}
+}
+
+func (cm *Mgr) runHealthCheck() {
+	ticker := time.NewTicker(cm.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cm.ctx.Done():
+			return
+
+		case <-ticker.C:
+			cm.checkHealth()
+		}
+	}
 }
 
 func (cm *Mgr) Stop() {
@@ -95,6 +125,7 @@ func (cm *Mgr) updateValMap() {
 // AddClient should call before Start.
 func (cm *Mgr) AddClient(c IClient) {
 	cm.clients = append(cm.clients, c)
+	cm.states = append(cm.states, &endpointState{})
 }
 
 // NOTE: local client is always the first client.
@@ -111,17 +142,29 @@ func (cm *Mgr) GetRandomClient() IClient {
 }
 
 func (cm *Mgr) GetBlockchainInfo() (*pactus.GetBlockchainInfoResponse, error) {
-	localClient := cm.getLocalClient()
-	info, err := localClient.GetBlockchainInfo(cm.ctx)
+	info, err := withFailover(cm, func(c IClient) (*pactus.GetBlockchainInfoResponse, error) {
+		return c.GetBlockchainInfo(cm.ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
 	return info, nil
 }
 
+// GetBlockchainInfoLite returns the blockchain information of the local client with only the selected fields.
+func (cm *Mgr) GetBlockchainInfoLite(fields BlockchainInfoField) (*pactus.GetBlockchainInfoResponse, error) {
+	info, err := cm.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	return LiteBlockchainInfo(info, fields), nil
+}
+
 func (cm *Mgr) GetBlockchainHeight() (uint32, error) {
-	localClient := cm.getLocalClient()
-	height, err := localClient.GetBlockchainHeight(cm.ctx)
+	height, err := withFailover(cm, func(c IClient) (uint32, error) {
+		return c.GetBlockchainHeight(cm.ctx)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -129,18 +172,23 @@ func (cm *Mgr) GetBlockchainHeight() (uint32, error) {
 }
 
 func (cm *Mgr) GetLastBlockTime() (uint32, uint32) {
-	localClient := cm.getLocalClient()
-	lastBlockTime, lastBlockHeight, err := localClient.LastBlockTime(cm.ctx)
+	times, err := withFailover(cm, func(c IClient) ([2]uint32, error) {
+		lastBlockTime, lastBlockHeight, err := c.LastBlockTime(cm.ctx)
+
+		return [2]uint32{lastBlockTime, lastBlockHeight}, err
+	})
 	if err != nil {
 		return 0, 0
 	}
 
-	return lastBlockTime, lastBlockHeight
+	return times[0], times[1]
 }
 
 func (cm *Mgr) GetNetworkInfo() (*pactus.GetNetworkInfoResponse, error) {
-	for _, c := range cm.clients {
-		info, err := c.GetNetworkInfo(cm.ctx)
+	for _, idx := range cm.candidates() {
+		start := time.Now()
+		info, err := cm.clients[idx].GetNetworkInfo(cm.ctx)
+		cm.report(idx, err, time.Since(start))
 		if err != nil {
 			continue
 		}
@@ -150,6 +198,16 @@ func (cm *Mgr) GetNetworkInfo() (*pactus.GetNetworkInfoResponse, error) {
 	return nil, errors.New("unable to get network info")
 }
 
+// GetNodeInfo returns the information of the local node. Unlike the chain queries, it doesn't fail over.
+func (cm *Mgr) GetNodeInfo() (*pactus.GetNodeInfoResponse, error) {
+	return cm.getLocalClient().GetNodeInfo(cm.ctx)
+}
+
+// LocalTarget returns the endpoint of the local client.
+func (cm *Mgr) LocalTarget() string {
+	return cm.getLocalClient().Target()
+}
+
 func (cm *Mgr) FindPublicKey(address string, firstVal bool) (string, error) {
 	peerInfo, err := cm.GetPeerInfo(address)
 	if err != nil {
@@ -181,8 +239,9 @@ func (cm *Mgr) GetPeerInfo(address string) (*pactus.PeerInfo, error) {
 }
 
 func (cm *Mgr) GetValidatorInfo(address string) (*pactus.GetValidatorResponse, error) {
-	localClient := cm.getLocalClient()
-	val, err := localClient.GetValidatorInfo(cm.ctx, address)
+	val, err := withFailover(cm, func(c IClient) (*pactus.GetValidatorResponse, error) {
+		return c.GetValidatorInfo(cm.ctx, address)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -190,8 +249,9 @@ func (cm *Mgr) GetValidatorInfo(address string) (*pactus.GetValidatorResponse, e
 }
 
 func (cm *Mgr) GetValidatorInfoByNumber(num int32) (*pactus.GetValidatorResponse, error) {
-	localClient := cm.getLocalClient()
-	val, err := localClient.GetValidatorInfoByNumber(cm.ctx, num)
+	val, err := withFailover(cm, func(c IClient) (*pactus.GetValidatorResponse, error) {
+		return c.GetValidatorInfoByNumber(cm.ctx, num)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -199,21 +259,74 @@ func (cm *Mgr) GetValidatorInfoByNumber(num int32) (*pactus.GetValidatorResponse
 }
 
 func (cm *Mgr) GetTransactionData(txID string) (*pactus.GetTransactionResponse, error) {
-	localClient := cm.getLocalClient()
-	txData, err := localClient.GetTransactionData(cm.ctx, txID)
+	txData, err := withFailover(cm, func(c IClient) (*pactus.GetTransactionResponse, error) {
+		return c.GetTransactionData(cm.ctx, txID)
+	})
 	if err != nil {
 		return nil, err
 	}
 	return txData, nil
 }
 
+func (cm *Mgr) GetAccount(addr string) (*pactus.AccountInfo, error) {
+	return withFailover(cm, func(c IClient) (*pactus.AccountInfo, error) {
+		return c.GetAccount(cm.ctx, addr)
+	})
+}
+
 func (cm *Mgr) GetBalance(addr string) (int64, error) {
-	return cm.getLocalClient().GetBalance(cm.ctx, addr)
+	return withFailover(cm, func(c IClient) (int64, error) {
+		return c.GetBalance(cm.ctx, addr)
+	})
+}
+
+// CalculateFee returns the fee of a transaction with the amount and the payload type.
+func (cm *Mgr) CalculateFee(amount int64, payloadType payload.Type) (int64, error) {
+	return withFailover(cm, func(c IClient) (int64, error) {
+		return c.CalculateFee(cm.ctx, amount, payloadType)
+	})
+}
+
+// BroadcastTransaction broadcasts the signed raw transaction through the local node and returns its ID.
+// Unlike the reads, it doesn't fail over, so a transaction that may have reached the node is not sent twice.
+func (cm *Mgr) BroadcastTransaction(signedRawTx []byte) (string, error) {
+	return cm.getLocalClient().BroadcastTransaction(cm.ctx, signedRawTx)
+}
+
+// GetGenesisTime returns the start time of the chain.
+// It returns ErrNoDataYet if the local node doesn't have the first block yet.
+func (cm *Mgr) GetGenesisTime() (time.Time, error) {
+	return withFailover(cm, func(c IClient) (time.Time, error) {
+		return c.GetGenesisTime(cm.ctx)
+	})
+}
+
+// GetBlockTimes returns the times of the blocks in the range. See Client.GetBlockTimes.
+func (cm *Mgr) GetBlockTimes(fromHeight, toHeight uint32) ([]BlockTimePoint, error) {
+	return withFailover(cm, func(c IClient) ([]BlockTimePoint, error) {
+		return c.GetBlockTimes(cm.ctx, fromHeight, toHeight)
+	})
+}
+
+// GetBlockWithTxs returns the block at the height. See Client.GetBlockWithTxs.
+func (cm *Mgr) GetBlockWithTxs(height uint32) (*pactus.GetBlockResponse, error) {
+	return withFailover(cm, func(c IClient) (*pactus.GetBlockResponse, error) {
+		return c.GetBlockWithTxs(cm.ctx, height)
+	})
+}
+
+// GetBlocksWithTxs returns the blocks in the range. See Client.GetBlocksWithTxs.
+func (cm *Mgr) GetBlocksWithTxs(fromHeight, toHeight uint32) ([]*pactus.GetBlockResponse, error) {
+	return withFailover(cm, func(c IClient) ([]*pactus.GetBlockResponse, error) {
+		return c.GetBlocksWithTxs(cm.ctx, fromHeight, toHeight)
+	})
 }
 
 func (cm *Mgr) GetCirculatingSupply() (int64, error) {
-	localClient := cm.getLocalClient()
+	return withFailover(cm, cm.circulatingSupply)
+}
 
+func (cm *Mgr) circulatingSupply(localClient IClient) (int64, error) {
 	height, err := localClient.GetBlockchainInfo(cm.ctx)
 	if err != nil {
 		return 0, err
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeBlockchainClient overrides the needed methods of the blockchain gRPC client.
+// Calling any other method panics.
+type fakeBlockchainClient struct {
+	pactus.BlockchainClient
+
+	info     *pactus.GetBlockchainInfoResponse
+	blocks   map[uint32]*pactus.GetBlockResponse
+	accounts map[string]*pactus.AccountInfo
+
+	// the next failures calls return err.
+	failures int
+	err      error
+	calls    int
+	// the context of the last call.
+	ctx context.Context
+
+	// if set, the calls block until it's closed.
+	block chan struct{}
+}
+
+func (f *fakeBlockchainClient) GetBlockchainInfo(ctx context.Context, _ *pactus.GetBlockchainInfoRequest,
+	_ ...grpc.CallOption,
+) (*pactus.GetBlockchainInfoResponse, error) {
+	f.calls++
+	f.ctx = ctx
+	if f.block != nil {
+		<-f.block
+	}
+
+	if f.failures > 0 {
+		f.failures--
+
+		return nil, f.err
+	}
+
+	return f.info, nil
+}
+
+func (f *fakeBlockchainClient) GetBlock(_ context.Context, req *pactus.GetBlockRequest,
+	_ ...grpc.CallOption,
+) (*pactus.GetBlockResponse, error) {
+	block, ok := f.blocks[req.Height]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+
+	return block, nil
+}
+
+func (f *fakeBlockchainClient) GetAccount(_ context.Context, req *pactus.GetAccountRequest,
+	_ ...grpc.CallOption,
+) (*pactus.GetAccountResponse, error) {
+	acc, ok := f.accounts[req.Address]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	return &pactus.GetAccountResponse{Account: acc}, nil
+}
+
+func setupClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+
+	c, err := NewClient("localhost:0", opts...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func TestLastBlockAge(t *testing.T) {
+	blockTime := time.Unix(1_700_000_000, 0)
+	clock := NewFakeClock(blockTime)
+	c := setupClient(t, WithClock(clock))
+
+	c.blockchainClient = &fakeBlockchainClient{
+		info: &pactus.GetBlockchainInfoResponse{LastBlockHeight: 100},
+		blocks: map[uint32]*pactus.GetBlockResponse{
+			100: {Height: 100, BlockTime: uint32(blockTime.Unix())},
+		},
+	}
+
+	age, err := c.LastBlockAge(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, age)
+
+	clock.Advance(25 * time.Second)
+
+	age, err = c.LastBlockAge(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 25*time.Second, age)
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	done := make(chan struct{})
+
+	go func() {
+		clock.Sleep(time.Minute)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, 1, clock.Waiters())
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sleep is not finished after advancing the clock")
+	}
+}
+
+func TestGetAccount(t *testing.T) {
+	c := setupClient(t)
+	c.blockchainClient = &fakeBlockchainClient{
+		accounts: map[string]*pactus.AccountInfo{
+			"pc1-known": {Address: "pc1-known", Number: 7, Balance: 1_000_000_000},
+		},
+	}
+
+	t.Run("known account", func(t *testing.T) {
+		acc, err := c.GetAccount(context.Background(), "pc1-known")
+		require.NoError(t, err)
+		assert.Equal(t, int32(7), acc.Number)
+
+		balance, err := c.GetBalance(context.Background(), "pc1-known")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1_000_000_000), balance)
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		_, err := c.GetAccount(context.Background(), "pc1-unknown")
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+		assert.Contains(t, err.Error(), "pc1-unknown")
+
+		_, err = c.GetBalance(context.Background(), "pc1-unknown")
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+}
+
+func TestGetTransactionData(t *testing.T) {
+	c := setupClient(t)
+	c.transactionClient = &fakeTransactionClient{
+		txs: map[string]*pactus.GetTransactionResponse{
+			"abcd": {BlockHeight: 99},
+		},
+	}
+
+	t.Run("known transaction", func(t *testing.T) {
+		res, err := c.GetTransactionData(context.Background(), "abcd")
+		require.NoError(t, err)
+		assert.Equal(t, uint32(99), res.BlockHeight)
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		_, err := c.GetTransactionData(context.Background(), "abce")
+		assert.ErrorIs(t, err, ErrTransactionNotFound)
+		assert.Contains(t, err.Error(), "abce")
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		_, err := c.GetTransactionData(context.Background(), "xyz")
+		assert.ErrorContains(t, err, "invalid transaction ID")
+	})
+}
+
+func TestGetGenesisTime(t *testing.T) {
+	genesisTime := time.Unix(1_700_000_000, 0)
+
+	t.Run("fresh node", func(t *testing.T) {
+		c := setupClient(t)
+		c.blockchainClient = &fakeBlockchainClient{}
+
+		_, err := c.GetGenesisTime(context.Background())
+		assert.ErrorIs(t, err, ErrNoDataYet)
+	})
+
+	t.Run("cached after the first call", func(t *testing.T) {
+		c := setupClient(t)
+		fake := &fakeBlockchainClient{
+			blocks: map[uint32]*pactus.GetBlockResponse{
+				1: {Height: 1, BlockTime: uint32(genesisTime.Unix())},
+			},
+		}
+		c.blockchainClient = fake
+
+		got, err := c.GetGenesisTime(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, genesisTime, got)
+
+		delete(fake.blocks, 1)
+
+		got, err = c.GetGenesisTime(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, genesisTime, got)
+	})
+}
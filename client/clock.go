@@ -0,0 +1,103 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts the time functions, so the time-based logic of the client can be tested deterministically.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+type fakeTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// FakeClock is a manually advanced Clock, intended to be used in tests.
+type FakeClock struct {
+	lk     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+	}
+}
+
+func (fc *FakeClock) Now() time.Time {
+	fc.lk.Lock()
+	defer fc.lk.Unlock()
+
+	return fc.now
+}
+
+func (fc *FakeClock) After(d time.Duration) <-chan time.Time {
+	fc.lk.Lock()
+	defer fc.lk.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- fc.now
+
+		return ch
+	}
+
+	fc.timers = append(fc.timers, &fakeTimer{
+		deadline: fc.now.Add(d),
+		ch:       ch,
+	})
+
+	return ch
+}
+
+// Sleep blocks until the clock is advanced by at least d.
+func (fc *FakeClock) Sleep(d time.Duration) {
+	<-fc.After(d)
+}
+
+// Advance moves the clock forward and fires the timers that are due.
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.lk.Lock()
+	defer fc.lk.Unlock()
+
+	fc.now = fc.now.Add(d)
+
+	pending := fc.timers[:0]
+	for _, t := range fc.timers {
+		if t.deadline.After(fc.now) {
+			pending = append(pending, t)
+
+			continue
+		}
+		t.ch <- fc.now
+	}
+	fc.timers = pending
+}
+
+// Waiters returns the number of timers that are waiting for the clock to be advanced.
+func (fc *FakeClock) Waiters() int {
+	fc.lk.Lock()
+	defer fc.lk.Unlock()
+
+	return len(fc.timers)
+}
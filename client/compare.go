@@ -0,0 +1,120 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"sync"
+)
+
+// NodeHead is the last block of an endpoint.
+type NodeHead struct {
+	Target string
+	Height uint32
+	// CommonHash is the hash of the block at the common height of the endpoints,
+	// the hashes of the last blocks are not comparable when the endpoints are at different heights.
+	CommonHash []byte
+	// Err is the error of the endpoint, the other fields are not set if it's unreachable.
+	Err error
+}
+
+// NodeComparison is the last blocks of all the endpoints of the network.
+type NodeComparison struct {
+	Heads []NodeHead
+	// CommonHeight is the lowest height of the reachable endpoints.
+	CommonHeight uint32
+	// MaxHeight is the highest height of the reachable endpoints.
+	MaxHeight uint32
+	// CanonicalHash is the hash at the common height that most of the endpoints agree on,
+	// the first endpoint wins the ties.
+	CanonicalHash []byte
+}
+
+// Lag returns how many blocks the endpoint is behind the highest endpoint.
+func (c *NodeComparison) Lag(head *NodeHead) uint32 {
+	return c.MaxHeight - head.Height
+}
+
+// IsForked reports whether the endpoint has a different block at the common height.
+func (c *NodeComparison) IsForked(head *NodeHead) bool {
+	return head.Err == nil && !bytes.Equal(head.CommonHash, c.CanonicalHash)
+}
+
+// CompareNodes queries the last block of all the endpoints at once, and the hash of their blocks
+// at the common height, so the lagging and the forked endpoints are found.
+func (cm *Mgr) CompareNodes() *NodeComparison {
+	heads := make([]NodeHead, len(cm.clients))
+	cm.eachClient(func(ctx context.Context, idx int, c IClient) {
+		heads[idx].Target = c.Target()
+		heads[idx].Height, heads[idx].Err = c.GetBlockchainHeight(ctx)
+	})
+
+	comparison := &NodeComparison{Heads: heads}
+	reachable := 0
+	for _, head := range heads {
+		if head.Err != nil {
+			continue
+		}
+
+		if reachable == 0 || head.Height < comparison.CommonHeight {
+			comparison.CommonHeight = head.Height
+		}
+		comparison.MaxHeight = max(comparison.MaxHeight, head.Height)
+		reachable++
+	}
+
+	if reachable == 0 {
+		return comparison
+	}
+
+	cm.eachClient(func(ctx context.Context, idx int, c IClient) {
+		if heads[idx].Err != nil {
+			return
+		}
+
+		block, err := c.GetBlockWithTxs(ctx, comparison.CommonHeight)
+		if err != nil {
+			heads[idx].Err = err
+
+			return
+		}
+		heads[idx].CommonHash = block.Hash
+	})
+
+	votes := 0
+	for _, head := range heads {
+		if head.Err != nil {
+			continue
+		}
+
+		count := 0
+		for _, other := range heads {
+			if other.Err == nil && bytes.Equal(head.CommonHash, other.CommonHash) {
+				count++
+			}
+		}
+
+		if count > votes {
+			votes = count
+			comparison.CanonicalHash = head.CommonHash
+		}
+	}
+
+	return comparison
+}
+
+// eachClient calls all the endpoints at once, and waits for them.
+func (cm *Mgr) eachClient(call func(ctx context.Context, idx int, c IClient)) {
+	var wg sync.WaitGroup
+	for idx, c := range cm.clients {
+		wg.Add(1)
+		go func(idx int, c IClient) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(cm.ctx, healthCheckTimeout)
+			defer cancel()
+
+			call(ctx, idx, c)
+		}(idx, c)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCompareNodes(t *testing.T) {
+	cm, clients := setupFailover(t, SelectionPriority, 4)
+	heights := []uint32{100, 98, 100, 0}
+	hashes := [][]byte{{0x01}, {0x02}, {0x02}, nil}
+	for i, c := range clients {
+		c.EXPECT().Target().Return(string(rune('a' + i))).AnyTimes()
+	}
+	for i, c := range clients[:3] {
+		c.EXPECT().GetBlockchainHeight(gomock.Any()).Return(heights[i], nil)
+		c.EXPECT().GetBlockWithTxs(gomock.Any(), uint32(98)).Return(&pactus.GetBlockResponse{Hash: hashes[i]}, nil)
+	}
+	clients[3].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(0), errors.New("connection refused"))
+
+	comparison := cm.CompareNodes()
+	assert.Equal(t, uint32(98), comparison.CommonHeight)
+	assert.Equal(t, uint32(100), comparison.MaxHeight)
+	assert.Equal(t, []byte{0x02}, comparison.CanonicalHash, "most of the nodes agree on the hash")
+
+	require.Len(t, comparison.Heads, 4)
+	assert.True(t, comparison.IsForked(&comparison.Heads[0]))
+	assert.False(t, comparison.IsForked(&comparison.Heads[1]))
+	assert.Equal(t, uint32(2), comparison.Lag(&comparison.Heads[1]))
+	assert.Error(t, comparison.Heads[3].Err)
+	assert.False(t, comparison.IsForked(&comparison.Heads[3]))
+}
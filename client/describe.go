@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/kehiy/RoboPac/utils"
+	"github.com/libp2p/go-libp2p/core/peer"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// DescriptionField is a human-readable property of a node or the network.
+type DescriptionField struct {
+	Name  string
+	Value string
+}
+
+// Description is an ordered list of human-readable properties.
+type Description []DescriptionField
+
+func (d Description) add(name, value string) Description {
+	return append(d, DescriptionField{Name: name, Value: value})
+}
+
+// DescribeTraffic extracts the traffic counters of the node from the network info.
+// The counters which aren't reported by the node are omitted.
+func DescribeTraffic(info *pactus.GetNetworkInfoResponse) Description {
+	desc := Description{}
+	if info == nil {
+		return desc
+	}
+
+	sentBytes := uint64(info.TotalSentBytes)
+	if sentBytes == 0 {
+		for _, b := range info.SentBytes {
+			sentBytes += b
+		}
+	}
+
+	receivedBytes := uint64(info.TotalReceivedBytes)
+	if receivedBytes == 0 {
+		for _, b := range info.ReceivedBytes {
+			receivedBytes += b
+		}
+	}
+
+	if sentBytes > 0 {
+		desc = desc.add("Total Sent", humanize.Bytes(sentBytes))
+	}
+
+	if receivedBytes > 0 {
+		desc = desc.add("Total Received", humanize.Bytes(receivedBytes))
+	}
+
+	if len(info.ConnectedPeers) > 0 {
+		receivedMsgs, invalidMsgs := int64(0), int64(0)
+		for _, p := range info.ConnectedPeers {
+			receivedMsgs += int64(p.ReceivedMessages)
+			invalidMsgs += int64(p.InvalidMessages)
+		}
+
+		desc = desc.add("Received Messages", utils.FormatNumber(receivedMsgs))
+		desc = desc.add("Invalid Messages", utils.FormatNumber(invalidMsgs))
+	}
+
+	return desc
+}
+
+// NetworkType returns the type of the network, like Mainnet or Testnet, based on the network name.
+func NetworkType(networkName string) string {
+	name := strings.ToLower(networkName)
+	switch {
+	case name == "":
+		return "Unknown"
+	case strings.Contains(name, "testnet"):
+		return "Testnet"
+	case strings.Contains(name, "localnet"):
+		return "Localnet"
+	default:
+		return "Mainnet"
+	}
+}
+
+// DescribeNode extracts the identity and the services of the node from the node info.
+// The properties which aren't reported by the node are omitted.
+func DescribeNode(info *pactus.GetNodeInfoResponse) Description {
+	desc := Description{}
+	if info == nil {
+		return desc
+	}
+
+	if info.Moniker != "" {
+		desc = desc.add("Moniker", info.Moniker)
+	}
+
+	if info.Agent != "" {
+		desc = desc.add("Agent", info.Agent)
+	}
+
+	if peerID, err := peer.IDFromBytes(info.PeerId); err == nil {
+		desc = desc.add("Peer ID", peerID.String())
+	}
+
+	if info.StartedAt > 0 {
+		desc = desc.add("Started", humanize.Time(time.Unix(int64(info.StartedAt), 0)))
+	}
+
+	if info.Reachability != "" {
+		desc = desc.add("Reachability", info.Reachability)
+	}
+
+	if len(info.ServicesNames) > 0 {
+		desc = desc.add("Services", strings.Join(info.ServicesNames, ", "))
+	}
+
+	return desc
+}
+
+// DescribePeers extracts a summary of the connected peers from the network info.
+func DescribePeers(info *pactus.GetNetworkInfoResponse) Description {
+	desc := Description{}
+	if info == nil {
+		return desc
+	}
+
+	if info.NetworkName != "" {
+		desc = desc.add("Network", info.NetworkName)
+	}
+
+	desc = desc.add("Connected Peers", utils.FormatNumber(int64(info.ConnectedPeersCount)))
+
+	if len(info.ConnectedPeers) > 0 {
+		agents := map[string]bool{}
+		for _, p := range info.ConnectedPeers {
+			agents[p.Agent] = true
+		}
+
+		desc = desc.add("Distinct Agents", utils.FormatNumber(int64(len(agents))))
+	}
+
+	return desc
+}
+
+func (c *Client) DescribeNode(ctx context.Context) (Description, error) {
+	info, err := c.GetNodeInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return DescribeNode(info), nil
+}
+
+func (c *Client) DescribeTraffic(ctx context.Context) (Description, error) {
+	info, err := c.GetNetworkInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return DescribeTraffic(info), nil
+}
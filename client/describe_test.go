@@ -0,0 +1,87 @@
+package client
+
+import (
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeTraffic(t *testing.T) {
+	t.Run("nil info", func(t *testing.T) {
+		assert.Empty(t, DescribeTraffic(nil))
+	})
+
+	t.Run("full response", func(t *testing.T) {
+		info := &pactus.GetNetworkInfoResponse{
+			TotalSentBytes:     1_500_000,
+			TotalReceivedBytes: 2_000_000_000,
+			ConnectedPeers: []*pactus.PeerInfo{
+				{ReceivedMessages: 1200, InvalidMessages: 3},
+				{ReceivedMessages: 800, InvalidMessages: 1},
+			},
+		}
+
+		assert.Equal(t, Description{
+			{Name: "Total Sent", Value: "1.5 MB"},
+			{Name: "Total Received", Value: "2.0 GB"},
+			{Name: "Received Messages", Value: "2,000"},
+			{Name: "Invalid Messages", Value: "4"},
+		}, DescribeTraffic(info))
+	})
+
+	t.Run("per message counters", func(t *testing.T) {
+		info := &pactus.GetNetworkInfoResponse{
+			SentBytes: map[uint32]uint64{1: 1000, 2: 2000},
+		}
+
+		assert.Equal(t, Description{
+			{Name: "Total Sent", Value: "3.0 kB"},
+		}, DescribeTraffic(info))
+	})
+
+	t.Run("missing fields are omitted", func(t *testing.T) {
+		assert.Empty(t, DescribeTraffic(&pactus.GetNetworkInfoResponse{NetworkName: "test"}))
+	})
+}
+
+func TestDescribeNode(t *testing.T) {
+	t.Run("nil info", func(t *testing.T) {
+		assert.Empty(t, DescribeNode(nil))
+	})
+
+	t.Run("missing fields are omitted", func(t *testing.T) {
+		desc := DescribeNode(&pactus.GetNodeInfoResponse{
+			Moniker:       "robopac",
+			ServicesNames: []string{"NETWORK", "GOSSIP"},
+		})
+
+		assert.Equal(t, Description{
+			{Name: "Moniker", Value: "robopac"},
+			{Name: "Services", Value: "NETWORK, GOSSIP"},
+		}, desc)
+	})
+}
+
+func TestDescribePeers(t *testing.T) {
+	desc := DescribePeers(&pactus.GetNetworkInfoResponse{
+		NetworkName:         "pactus",
+		ConnectedPeersCount: 3,
+		ConnectedPeers: []*pactus.PeerInfo{
+			{Agent: "a"}, {Agent: "b"}, {Agent: "a"},
+		},
+	})
+
+	assert.Equal(t, Description{
+		{Name: "Network", Value: "pactus"},
+		{Name: "Connected Peers", Value: "3"},
+		{Name: "Distinct Agents", Value: "2"},
+	}, desc)
+}
+
+func TestNetworkType(t *testing.T) {
+	assert.Equal(t, "Mainnet", NetworkType("pactus"))
+	assert.Equal(t, "Testnet", NetworkType("pactus-testnet-2"))
+	assert.Equal(t, "Localnet", NetworkType("pactus-localnet"))
+	assert.Equal(t, "Unknown", NetworkType(""))
+}
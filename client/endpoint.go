@@ -0,0 +1,74 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// EndpointEnv is the environment variable to set the endpoint, when it's not passed explicitly.
+	EndpointEnv = "PACTUS_GRPC_ENDPOINT"
+
+	// DefaultEndpoint is the default gRPC endpoint of a Pactus node.
+	DefaultEndpoint = "localhost:50051"
+
+	// TLSScheme is the scheme of the targets that are connected over TLS.
+	TLSScheme = "grpcs://"
+)
+
+// ParseTarget validates the gRPC target and returns it in the host:port form.
+// The target can have an optional "grpc://", "grpcs://" or "dns:///" scheme.
+func ParseTarget(target string) (string, error) {
+	hostPort := strings.TrimSpace(target)
+	for _, scheme := range []string{"grpc://", TLSScheme, "dns:///"} {
+		hostPort = strings.TrimPrefix(hostPort, scheme)
+	}
+
+	if strings.Contains(hostPort, "://") {
+		return "", fmt.Errorf("unsupported scheme in target: %s", target)
+	}
+
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", fmt.Errorf("invalid target %s: %w", target, err)
+	}
+
+	if host == "" {
+		return "", fmt.Errorf("invalid target %s: missing host", target)
+	}
+
+	portNum, err := strconv.Atoi(port)
+	if err != nil || portNum < 0 || portNum > 65535 {
+		return "", fmt.Errorf("invalid target %s: invalid port %s", target, port)
+	}
+
+	return hostPort, nil
+}
+
+// ResolveEndpoint resolves the endpoint with this precedence:
+// the explicit endpoint, then the PACTUS_GRPC_ENDPOINT environment variable, then the default endpoint.
+// The resolved endpoint is validated by ParseTarget.
+func ResolveEndpoint(explicit string) (string, error) {
+	return ParseTarget(resolveTarget(explicit))
+}
+
+// IsTLSTarget reports whether the target has the grpcs:// scheme.
+func IsTLSTarget(target string) bool {
+	return strings.HasPrefix(strings.TrimSpace(target), TLSScheme)
+}
+
+// resolveTarget resolves the target like ResolveEndpoint, and keeps its scheme.
+func resolveTarget(explicit string) string {
+	target := strings.TrimSpace(explicit)
+	if target == "" {
+		target = strings.TrimSpace(os.Getenv(EndpointEnv))
+	}
+	if target == "" {
+		target = DefaultEndpoint
+	}
+
+	return target
+}
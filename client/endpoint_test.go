@@ -0,0 +1,75 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{target: "localhost:50051", want: "localhost:50051"},
+		{target: "grpc://bootstrap.pactus.org:50051", want: "bootstrap.pactus.org:50051"},
+		{target: "dns:///bootstrap.pactus.org:50051", want: "bootstrap.pactus.org:50051"},
+		{target: "grpcs://node.example.com:443", want: "node.example.com:443"},
+		{target: "[::1]:50051", want: "[::1]:50051"},
+		{target: "localhost", wantErr: true},
+		{target: ":50051", wantErr: true},
+		{target: "localhost:port", wantErr: true},
+		{target: "localhost:70000", wantErr: true},
+		{target: "http://localhost:50051", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTarget(tt.target)
+		if tt.wantErr {
+			assert.Error(t, err, tt.target)
+
+			continue
+		}
+
+		require.NoError(t, err, tt.target)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	t.Run("explicit endpoint", func(t *testing.T) {
+		t.Setenv(EndpointEnv, "env-node:50051")
+
+		endpoint, err := ResolveEndpoint("explicit-node:50052")
+		require.NoError(t, err)
+		assert.Equal(t, "explicit-node:50052", endpoint)
+	})
+
+	t.Run("environment variable", func(t *testing.T) {
+		t.Setenv(EndpointEnv, "env-node:50051")
+
+		endpoint, err := ResolveEndpoint("")
+		require.NoError(t, err)
+		assert.Equal(t, "env-node:50051", endpoint)
+	})
+
+	t.Run("default endpoint", func(t *testing.T) {
+		t.Setenv(EndpointEnv, "")
+
+		endpoint, err := ResolveEndpoint("")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultEndpoint, endpoint)
+	})
+
+	t.Run("invalid endpoint", func(t *testing.T) {
+		t.Setenv(EndpointEnv, "invalid-endpoint")
+
+		_, err := ResolveEndpoint("")
+		assert.Error(t, err)
+
+		_, err = NewClient("")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+)
+
+// SelectionPolicy decides the order that the endpoints are tried in.
+type SelectionPolicy string
+
+const (
+	// SelectionPriority tries the local node first, then the network nodes in their configured order.
+	SelectionPriority SelectionPolicy = "priority"
+	// SelectionRoundRobin spreads the calls over the endpoints in turn.
+	SelectionRoundRobin SelectionPolicy = "round-robin"
+	// SelectionLatency tries the endpoints with the lower latency first.
+	SelectionLatency SelectionPolicy = "latency"
+)
+
+const healthCheckTimeout = 5 * time.Second
+
+// ParseSelectionPolicy parses the selection policy, an empty policy is SelectionPriority.
+func ParseSelectionPolicy(policy string) (SelectionPolicy, error) {
+	switch SelectionPolicy(policy) {
+	case "":
+		return SelectionPriority, nil
+	case SelectionPriority, SelectionRoundRobin, SelectionLatency:
+		return SelectionPolicy(policy), nil
+	default:
+		return "", fmt.Errorf("unknown selection policy: %s", policy)
+	}
+}
+
+// endpointState is the health and the latency of an endpoint, as seen by the manager.
+type endpointState struct {
+	unhealthy atomic.Bool
+	// the moving average of the latency of the successful calls in nanoseconds, zero if unknown.
+	latency atomic.Int64
+}
+
+// report updates the state by the result of a call, it returns true if the health of the endpoint is changed.
+func (s *endpointState) report(err error, latency time.Duration) bool {
+	unhealthy := err != nil && isRetryable(err)
+	changed := s.unhealthy.Swap(unhealthy) != unhealthy
+	if err != nil {
+		return changed
+	}
+
+	avg := s.latency.Load()
+	if avg == 0 {
+		s.latency.Store(int64(latency))
+	} else {
+		s.latency.Store((3*avg + int64(latency)) / 4)
+	}
+
+	return changed
+}
+
+// HealthChange is the event of an endpoint that goes down or comes back.
+type HealthChange struct {
+	Network string
+	Target  string
+	Healthy bool
+	// Err is the error that made the endpoint unhealthy.
+	Err error
+}
+
+// SetHealthChangeHandler sets the function that is called when an endpoint goes down or comes back,
+// so the calls fail over to the other endpoints. It should be called before Start.
+func (cm *Mgr) SetHealthChangeHandler(fn func(HealthChange)) {
+	cm.onHealthChange = fn
+}
+
+// report updates the state of the endpoint by the result of a call.
+func (cm *Mgr) report(idx int, err error, latency time.Duration) {
+	if cm.states[idx].report(err, latency) && cm.onHealthChange != nil {
+		cm.onHealthChange(HealthChange{
+			Network: cm.network,
+			Target:  cm.clients[idx].Target(),
+			Healthy: !cm.states[idx].unhealthy.Load(),
+			Err:     err,
+		})
+	}
+}
+
+// SetSelectionPolicy sets the order that the endpoints are tried in. It should be called before Start.
+func (cm *Mgr) SetSelectionPolicy(policy SelectionPolicy) {
+	cm.policy = policy
+}
+
+// SetHealthCheckInterval sets the interval of the active health checks of the endpoints.
+// A non-positive interval disables the health checks. It should be called before Start.
+func (cm *Mgr) SetHealthCheckInterval(interval time.Duration) {
+	cm.healthCheckInterval = interval
+}
+
+// candidates returns the endpoints in the order they should be tried.
+// The unhealthy endpoints are kept as the last resort.
+func (cm *Mgr) candidates() []int {
+	order := make([]int, len(cm.clients))
+	for i := range order {
+		order[i] = i
+	}
+
+	switch cm.policy {
+	case SelectionRoundRobin:
+		if len(order) > 0 {
+			start := int(cm.next.Add(1)-1) % len(order)
+			order = append(order[start:], order[:start]...)
+		}
+
+	case SelectionLatency:
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(cm.states[a].latency.Load(), cm.states[b].latency.Load())
+		})
+
+	case SelectionPriority:
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return boolToInt(cm.states[a].unhealthy.Load()) - boolToInt(cm.states[b].unhealthy.Load())
+	})
+
+	return order
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// withFailover calls the endpoints in the order of the selection policy,
+// until one of them succeeds or fails with a permanent error, like not found.
+func withFailover[T any](cm *Mgr, call func(IClient) (T, error)) (T, error) {
+	var res T
+	var err error
+	for _, idx := range cm.candidates() {
+		start := time.Now()
+		res, err = call(cm.clients[idx])
+		cm.report(idx, err, time.Since(start))
+
+		if err == nil || !isRetryable(err) || cm.ctx.Err() != nil {
+			return res, err
+		}
+
+		log.Warn("node call failed, trying the next node", "endpoint", idx, "err", err)
+	}
+
+	return res, err
+}
+
+// checkHealth probes all the endpoints and updates their health and latency.
+func (cm *Mgr) checkHealth() {
+	for idx, c := range cm.clients {
+		ctx, cancel := context.WithTimeout(cm.ctx, healthCheckTimeout)
+		start := time.Now()
+		_, err := c.GetBlockchainHeight(ctx)
+		cancel()
+
+		cm.report(idx, err, time.Since(start))
+	}
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setupFailover(t *testing.T, policy SelectionPolicy, count int) (*Mgr, []*MockIClient) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	cm := NewClientMgr(context.Background())
+	cm.SetSelectionPolicy(policy)
+
+	clients := make([]*MockIClient, count)
+	for i := range clients {
+		clients[i] = NewMockIClient(ctrl)
+		cm.AddClient(clients[i])
+	}
+
+	return cm, clients
+}
+
+func TestParseSelectionPolicy(t *testing.T) {
+	policy, err := ParseSelectionPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, SelectionPriority, policy)
+
+	policy, err = ParseSelectionPolicy("latency")
+	require.NoError(t, err)
+	assert.Equal(t, SelectionLatency, policy)
+
+	_, err = ParseSelectionPolicy("random")
+	assert.Error(t, err)
+}
+
+func TestFailover(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "node is down")
+
+	t.Run("next node on transient errors", func(t *testing.T) {
+		cm, clients := setupFailover(t, SelectionPriority, 2)
+
+		clients[0].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(0), unavailable)
+		clients[1].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(100), nil).Times(2)
+
+		height, err := cm.GetBlockchainHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), height)
+
+		// the unhealthy node is tried last.
+		height, err = cm.GetBlockchainHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), height)
+	})
+
+	t.Run("all nodes are down", func(t *testing.T) {
+		cm, clients := setupFailover(t, SelectionPriority, 2)
+
+		clients[0].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(0), unavailable)
+		clients[1].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(0), unavailable)
+
+		_, err := cm.GetBlockchainHeight()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("permanent errors are not failed over", func(t *testing.T) {
+		cm, clients := setupFailover(t, SelectionPriority, 2)
+
+		notFound := status.Error(codes.NotFound, "not found")
+		clients[0].EXPECT().GetValidatorInfo(gomock.Any(), "addr").Return(nil, notFound)
+
+		_, err := cm.GetValidatorInfo("addr")
+		assert.ErrorIs(t, err, notFound)
+	})
+
+	t.Run("round-robin", func(t *testing.T) {
+		cm, clients := setupFailover(t, SelectionRoundRobin, 2)
+
+		gomock.InOrder(
+			clients[0].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(1), nil),
+			clients[0].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(1), nil),
+		)
+		gomock.InOrder(
+			clients[1].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(2), nil),
+			clients[1].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(2), nil),
+		)
+
+		heights := []uint32{}
+		for i := 0; i < 4; i++ {
+			height, err := cm.GetBlockchainHeight()
+			require.NoError(t, err)
+			heights = append(heights, height)
+		}
+		assert.Equal(t, []uint32{1, 2, 1, 2}, heights)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		cm, clients := setupFailover(t, SelectionLatency, 3)
+		cm.states[0].report(nil, 30*time.Millisecond)
+		cm.states[1].report(nil, 10*time.Millisecond)
+		cm.states[2].report(nil, 20*time.Millisecond)
+		assert.Equal(t, []int{1, 2, 0}, cm.candidates())
+
+		clients[1].EXPECT().GetAccount(gomock.Any(), "addr").Return(&pactus.AccountInfo{Balance: 1}, nil)
+
+		acc, err := cm.GetAccount("addr")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), acc.Balance)
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	cm, clients := setupFailover(t, SelectionPriority, 2)
+
+	clients[0].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(0), status.Error(codes.Unavailable, "down"))
+	clients[1].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(100), nil)
+
+	cm.checkHealth()
+	assert.True(t, cm.states[0].unhealthy.Load())
+	assert.False(t, cm.states[1].unhealthy.Load())
+	assert.NotZero(t, cm.states[1].latency.Load())
+	assert.Equal(t, []int{1, 0}, cm.candidates())
+}
+
+func TestHealthChange(t *testing.T) {
+	cm, clients := setupFailover(t, SelectionPriority, 2)
+	unavailable := status.Error(codes.Unavailable, "node is down")
+
+	changes := []HealthChange{}
+	cm.SetHealthChangeHandler(func(c HealthChange) { changes = append(changes, c) })
+
+	clients[0].EXPECT().Target().Return("node1:50051").AnyTimes()
+	gomock.InOrder(
+		clients[0].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(0), unavailable),
+		clients[0].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(0), unavailable),
+		clients[0].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(100), nil),
+	)
+	clients[1].EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(100), nil).Times(3)
+
+	for i := 0; i < 3; i++ {
+		cm.checkHealth()
+	}
+
+	// the second failure doesn't change the health, so it's reported once.
+	require.Len(t, changes, 2)
+	assert.Equal(t, HealthChange{Network: NetworkMainnet, Target: "node1:50051", Healthy: false, Err: unavailable}, changes[0])
+	assert.True(t, changes[1].Healthy)
+}
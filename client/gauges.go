@@ -0,0 +1,90 @@
+package client
+
+import (
+	"time"
+
+	"github.com/kehiy/RoboPac/metrics"
+)
+
+// Gauges are the connection metrics of the client manager, for capacity planning.
+type Gauges struct {
+	Endpoints        int
+	HealthyEndpoints int
+	InFlightCalls    int64
+}
+
+type gaugeReporter interface {
+	InFlight() int64
+	Healthy() bool
+}
+
+// Gauges returns the current connection metrics of the clients.
+// The clients which don't report their state are counted as healthy.
+func (cm *Mgr) Gauges() Gauges {
+	g := Gauges{
+		Endpoints: len(cm.clients),
+	}
+
+	for _, c := range cm.clients {
+		reporter, ok := c.(gaugeReporter)
+		if !ok {
+			g.HealthyEndpoints++
+
+			continue
+		}
+
+		if reporter.Healthy() {
+			g.HealthyEndpoints++
+		}
+		g.InFlightCalls += reporter.InFlight()
+	}
+
+	return g
+}
+
+// LastSuccess returns the time of the last successful call of the local client.
+// It returns the zero time if the client doesn't report it or no call succeeded yet.
+func (cm *Mgr) LastSuccess() time.Time {
+	reporter, ok := cm.getLocalClient().(interface{ LastSuccess() time.Time })
+	if !ok {
+		return time.Time{}
+	}
+
+	return reporter.LastSuccess()
+}
+
+// CacheStats returns the sum of the cache stats of the caching clients.
+func (cm *Mgr) CacheStats() CacheStats {
+	stats := CacheStats{}
+	for _, c := range cm.clients {
+		if cc, ok := c.(interface{ CacheStats() CacheStats }); ok {
+			s := cc.CacheStats()
+			stats.Hits += s.Hits
+			stats.Misses += s.Misses
+		}
+	}
+
+	return stats
+}
+
+// GaugeCollectors returns the gauges of the connections and the last success of the local client,
+// they are read from the client manager when the metrics are scraped.
+func (cm *Mgr) GaugeCollectors() []metrics.Collector {
+	return []metrics.Collector{
+		metrics.NewGaugeFunc("robopac_node_endpoints",
+			"Number of the node endpoints.", func() float64 { return float64(cm.Gauges().Endpoints) }),
+		metrics.NewGaugeFunc("robopac_node_healthy_endpoints",
+			"Number of the healthy node endpoints.", func() float64 { return float64(cm.Gauges().HealthyEndpoints) }),
+		metrics.NewGaugeFunc("robopac_node_in_flight_calls",
+			"Number of the in-flight calls to the nodes.", func() float64 { return float64(cm.Gauges().InFlightCalls) }),
+		metrics.NewGaugeFunc("robopac_node_last_success_timestamp_seconds",
+			"Time of the last successful call of the local node, zero if there is none.", func() float64 {
+				lastSuccess := cm.LastSuccess()
+				if lastSuccess.IsZero() {
+					return 0
+				}
+
+				return float64(lastSuccess.Unix())
+			}),
+	}
+}
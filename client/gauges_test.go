@@ -0,0 +1,118 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/metrics"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGauges(t *testing.T) {
+	c := setupClient(t)
+	fake := &fakeBlockchainClient{
+		info:  &pactus.GetBlockchainInfoResponse{LastBlockHeight: 100},
+		block: make(chan struct{}),
+	}
+	c.blockchainClient = fake
+
+	cm := NewClientMgr(context.Background())
+	cm.AddClient(c)
+	cm.AddClient(NewMockIClient(gomock.NewController(t)))
+
+	assert.Equal(t, Gauges{Endpoints: 2, HealthyEndpoints: 2}, cm.Gauges())
+
+	t.Run("in-flight calls", func(t *testing.T) {
+		done := make(chan struct{})
+		go func() {
+			_, _ = c.GetBlockchainInfo(context.Background())
+			close(done)
+		}()
+
+		assert.Eventually(t, func() bool { return cm.Gauges().InFlightCalls == 1 }, time.Second, time.Millisecond)
+
+		close(fake.block)
+		<-done
+
+		assert.Equal(t, int64(0), cm.Gauges().InFlightCalls)
+	})
+
+	t.Run("unhealthy endpoint", func(t *testing.T) {
+		fake.failures = 1
+		fake.err = status.Error(codes.Unavailable, "node is down")
+
+		_, err := c.GetBlockchainInfo(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, 1, cm.Gauges().HealthyEndpoints)
+
+		_, err = c.GetBlockchainInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, cm.Gauges().HealthyEndpoints)
+	})
+
+	t.Run("permanent errors don't make the endpoint unhealthy", func(t *testing.T) {
+		fake.failures = 1
+		fake.err = status.Error(codes.NotFound, "not found")
+
+		_, err := c.GetBlockchainInfo(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, 2, cm.Gauges().HealthyEndpoints)
+	})
+}
+
+func TestLastSuccess(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	clock := NewFakeClock(start)
+	c := setupClient(t, WithClock(clock))
+	fake := &fakeBlockchainClient{
+		info: &pactus.GetBlockchainInfoResponse{LastBlockHeight: 100},
+	}
+	c.blockchainClient = fake
+
+	cm := NewClientMgr(context.Background())
+	cm.AddClient(c)
+
+	assert.True(t, c.LastSuccess().IsZero())
+
+	_, err := c.GetBlockchainInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, start, c.LastSuccess())
+	assert.Equal(t, start, cm.LastSuccess())
+
+	t.Run("not updated on failure", func(t *testing.T) {
+		clock.Advance(time.Minute)
+		fake.failures = 1
+		fake.err = status.Error(codes.NotFound, "not found")
+
+		_, err := c.GetBlockchainInfo(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, start, c.LastSuccess())
+	})
+
+	t.Run("updated on the next success", func(t *testing.T) {
+		_, err := c.GetBlockchainInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, start.Add(time.Minute), c.LastSuccess())
+	})
+}
+
+func TestGaugeCollectors(t *testing.T) {
+	cm := NewClientMgr(context.Background())
+	cm.AddClient(NewMockIClient(gomock.NewController(t)))
+
+	reg := metrics.NewRegistry(cm.GaugeCollectors()...)
+	buf := &bytes.Buffer{}
+	require.NoError(t, reg.Write(buf))
+
+	assert.Contains(t, buf.String(), "robopac_node_endpoints 1\n")
+	assert.Contains(t, buf.String(), "robopac_node_healthy_endpoints 1\n")
+	assert.Contains(t, buf.String(), "robopac_node_in_flight_calls 0\n")
+	assert.Contains(t, buf.String(), "robopac_node_last_success_timestamp_seconds 0\n")
+}
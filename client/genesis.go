@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoDataYet is returned when the node doesn't have the requested data yet,
+// like a fresh node that hasn't synced the first block.
+var ErrNoDataYet = errors.New("no data yet")
+
+// GetGenesisTime returns the time of the first block, which is the start time of the chain.
+// It never changes, so it's fetched once and cached.
+func (c *Client) GetGenesisTime(ctx context.Context) (time.Time, error) {
+	if genesis := c.genesisTime.Load(); genesis != 0 {
+		return time.Unix(genesis, 0), nil
+	}
+
+	block, err := withRetry(ctx, c, func(ctx context.Context) (*pactus.GetBlockResponse, error) {
+		return c.blockchainClient.GetBlock(ctx, &pactus.GetBlockRequest{
+			Height:    1,
+			Verbosity: pactus.BlockVerbosity_BLOCK_INFO,
+		})
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return time.Time{}, ErrNoDataYet
+		}
+
+		return time.Time{}, err
+	}
+
+	if block == nil || block.BlockTime == 0 {
+		return time.Time{}, ErrNoDataYet
+	}
+
+	c.genesisTime.Store(int64(block.BlockTime))
+
+	return time.Unix(int64(block.BlockTime), 0), nil
+}
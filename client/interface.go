@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"time"
 
+	"github.com/pactus-project/pactus/types/tx/payload"
 	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
 )
 
@@ -11,9 +13,18 @@ type IClient interface {
 	GetBlockchainHeight(context.Context) (uint32, error)
 	LastBlockTime(context.Context) (uint32, uint32, error)
 	GetNetworkInfo(context.Context) (*pactus.GetNetworkInfoResponse, error)
+	GetNodeInfo(context.Context) (*pactus.GetNodeInfoResponse, error)
 	GetValidatorInfo(context.Context, string) (*pactus.GetValidatorResponse, error)
 	GetValidatorInfoByNumber(context.Context, int32) (*pactus.GetValidatorResponse, error)
 	GetTransactionData(context.Context, string) (*pactus.GetTransactionResponse, error)
+	GetAccount(context.Context, string) (*pactus.AccountInfo, error)
 	GetBalance(context.Context, string) (int64, error)
+	GetGenesisTime(context.Context) (time.Time, error)
+	GetBlockTimes(context.Context, uint32, uint32) ([]BlockTimePoint, error)
+	GetBlockWithTxs(context.Context, uint32) (*pactus.GetBlockResponse, error)
+	GetBlocksWithTxs(context.Context, uint32, uint32) ([]*pactus.GetBlockResponse, error)
+	CalculateFee(context.Context, int64, payload.Type) (int64, error)
+	BroadcastTransaction(context.Context, []byte) (string, error)
+	Target() string
 	Close() error
 }
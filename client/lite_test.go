@@ -0,0 +1,74 @@
+package client
+
+import (
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testBlockchainInfo() *pactus.GetBlockchainInfoResponse {
+	committee := make([]*pactus.ValidatorInfo, 51)
+	for i := range committee {
+		committee[i] = &pactus.ValidatorInfo{
+			Hash:              make([]byte, 32),
+			Data:              make([]byte, 138),
+			PublicKey:         "public1p4u8hfytl2pj6l9rj0t54gxcdmna4hq52ncqkkqjf3arha5mlk3x4mzpyjkhmdl20jae7f65aamjrvqcvf4sudcapz52ctcwc8r9wz3z2gwxs38880cgvfy49ta5ssyjut05myd4zgmjqstggmetyuyg7v5jhx47a",
+			Number:            int32(i),
+			Stake:             1_000_000_000_000,
+			LastBondingHeight: 1000,
+			AvailabilityScore: 0.98,
+			Address:           "pc1pqn7uaeduklpg00rqt6uq0m9wy5txnyt0kmxmgf",
+		}
+	}
+
+	return &pactus.GetBlockchainInfoResponse{
+		LastBlockHeight:     1_234_567,
+		LastBlockHash:       make([]byte, 32),
+		TotalAccounts:       15_000,
+		TotalValidators:     2_000,
+		TotalPower:          40_000_000_000_000_000,
+		CommitteePower:      1_000_000_000_000_000,
+		CommitteeValidators: committee,
+	}
+}
+
+func TestLiteBlockchainInfo(t *testing.T) {
+	info := testBlockchainInfo()
+
+	t.Run("nil info", func(t *testing.T) {
+		assert.Nil(t, LiteBlockchainInfo(nil, FieldsBlockchainSummary))
+	})
+
+	t.Run("summary fields", func(t *testing.T) {
+		lite := LiteBlockchainInfo(info, FieldsBlockchainSummary)
+
+		assert.Equal(t, info.LastBlockHeight, lite.LastBlockHeight)
+		assert.Equal(t, info.TotalAccounts, lite.TotalAccounts)
+		assert.Equal(t, info.TotalValidators, lite.TotalValidators)
+		assert.Equal(t, info.TotalPower, lite.TotalPower)
+		assert.Equal(t, info.CommitteePower, lite.CommitteePower)
+		assert.Nil(t, lite.LastBlockHash)
+		assert.Nil(t, lite.CommitteeValidators)
+
+		// the original response is not modified.
+		assert.Len(t, info.CommitteeValidators, 51)
+	})
+
+	t.Run("selected fields", func(t *testing.T) {
+		lite := LiteBlockchainInfo(info, FieldLastBlockHash|FieldCommitteeValidators)
+
+		assert.Zero(t, lite.LastBlockHeight)
+		assert.Equal(t, info.LastBlockHash, lite.LastBlockHash)
+		assert.Len(t, lite.CommitteeValidators, 51)
+	})
+
+	t.Run("payload size", func(t *testing.T) {
+		fullSize := proto.Size(info)
+		liteSize := proto.Size(LiteBlockchainInfo(info, FieldsBlockchainSummary))
+
+		t.Logf("full size: %d bytes, lite size: %d bytes", fullSize, liteSize)
+		assert.Less(t, liteSize*50, fullSize)
+	})
+}
@@ -12,7 +12,9 @@ package client
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	payload "github.com/pactus-project/pactus/types/tx/payload"
 	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,6 +42,36 @@ func (m *MockIClient) EXPECT() *MockIClientMockRecorder {
 	return m.recorder
 }
 
+// BroadcastTransaction mocks base method.
+func (m *MockIClient) BroadcastTransaction(arg0 context.Context, arg1 []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BroadcastTransaction", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BroadcastTransaction indicates an expected call of BroadcastTransaction.
+func (mr *MockIClientMockRecorder) BroadcastTransaction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastTransaction", reflect.TypeOf((*MockIClient)(nil).BroadcastTransaction), arg0, arg1)
+}
+
+// CalculateFee mocks base method.
+func (m *MockIClient) CalculateFee(arg0 context.Context, arg1 int64, arg2 payload.Type) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateFee", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateFee indicates an expected call of CalculateFee.
+func (mr *MockIClientMockRecorder) CalculateFee(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateFee", reflect.TypeOf((*MockIClient)(nil).CalculateFee), arg0, arg1, arg2)
+}
+
 // Close mocks base method.
 func (m *MockIClient) Close() error {
 	m.ctrl.T.Helper()
@@ -54,6 +86,21 @@ func (mr *MockIClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIClient)(nil).Close))
 }
 
+// GetAccount mocks base method.
+func (m *MockIClient) GetAccount(arg0 context.Context, arg1 string) (*pactus.AccountInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
+	ret0, _ := ret[0].(*pactus.AccountInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockIClientMockRecorder) GetAccount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockIClient)(nil).GetAccount), arg0, arg1)
+}
+
 // GetBalance mocks base method.
 func (m *MockIClient) GetBalance(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +116,36 @@ func (mr *MockIClientMockRecorder) GetBalance(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockIClient)(nil).GetBalance), arg0, arg1)
 }
 
+// GetBlockTimes mocks base method.
+func (m *MockIClient) GetBlockTimes(arg0 context.Context, arg1, arg2 uint32) ([]BlockTimePoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockTimes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]BlockTimePoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockTimes indicates an expected call of GetBlockTimes.
+func (mr *MockIClientMockRecorder) GetBlockTimes(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockTimes", reflect.TypeOf((*MockIClient)(nil).GetBlockTimes), arg0, arg1, arg2)
+}
+
+// GetBlockWithTxs mocks base method.
+func (m *MockIClient) GetBlockWithTxs(arg0 context.Context, arg1 uint32) (*pactus.GetBlockResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockWithTxs", arg0, arg1)
+	ret0, _ := ret[0].(*pactus.GetBlockResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockWithTxs indicates an expected call of GetBlockWithTxs.
+func (mr *MockIClientMockRecorder) GetBlockWithTxs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockWithTxs", reflect.TypeOf((*MockIClient)(nil).GetBlockWithTxs), arg0, arg1)
+}
+
 // GetBlockchainHeight mocks base method.
 func (m *MockIClient) GetBlockchainHeight(arg0 context.Context) (uint32, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +176,36 @@ func (mr *MockIClientMockRecorder) GetBlockchainInfo(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockchainInfo", reflect.TypeOf((*MockIClient)(nil).GetBlockchainInfo), arg0)
 }
 
+// GetBlocksWithTxs mocks base method.
+func (m *MockIClient) GetBlocksWithTxs(arg0 context.Context, arg1, arg2 uint32) ([]*pactus.GetBlockResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksWithTxs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*pactus.GetBlockResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksWithTxs indicates an expected call of GetBlocksWithTxs.
+func (mr *MockIClientMockRecorder) GetBlocksWithTxs(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithTxs", reflect.TypeOf((*MockIClient)(nil).GetBlocksWithTxs), arg0, arg1, arg2)
+}
+
+// GetGenesisTime mocks base method.
+func (m *MockIClient) GetGenesisTime(arg0 context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenesisTime", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenesisTime indicates an expected call of GetGenesisTime.
+func (mr *MockIClientMockRecorder) GetGenesisTime(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenesisTime", reflect.TypeOf((*MockIClient)(nil).GetGenesisTime), arg0)
+}
+
 // GetNetworkInfo mocks base method.
 func (m *MockIClient) GetNetworkInfo(arg0 context.Context) (*pactus.GetNetworkInfoResponse, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +221,21 @@ func (mr *MockIClientMockRecorder) GetNetworkInfo(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkInfo", reflect.TypeOf((*MockIClient)(nil).GetNetworkInfo), arg0)
 }
 
+// GetNodeInfo mocks base method.
+func (m *MockIClient) GetNodeInfo(arg0 context.Context) (*pactus.GetNodeInfoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeInfo", arg0)
+	ret0, _ := ret[0].(*pactus.GetNodeInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeInfo indicates an expected call of GetNodeInfo.
+func (mr *MockIClientMockRecorder) GetNodeInfo(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeInfo", reflect.TypeOf((*MockIClient)(nil).GetNodeInfo), arg0)
+}
+
 // GetTransactionData mocks base method.
 func (m *MockIClient) GetTransactionData(arg0 context.Context, arg1 string) (*pactus.GetTransactionResponse, error) {
 	m.ctrl.T.Helper()
@@ -174,3 +296,17 @@ func (mr *MockIClientMockRecorder) LastBlockTime(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastBlockTime", reflect.TypeOf((*MockIClient)(nil).LastBlockTime), arg0)
 }
+
+// Target mocks base method.
+func (m *MockIClient) Target() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Target")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Target indicates an expected call of Target.
+func (mr *MockIClientMockRecorder) Target() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Target", reflect.TypeOf((*MockIClient)(nil).Target))
+}
@@ -0,0 +1,105 @@
+package mock
+
+import (
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/types/tx"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// The fixtures of a small chain, loaded by NewFixtureNode.
+const (
+	FixtureHeight     = uint32(1_000)
+	FixtureBlockTime  = uint32(1_706_054_400)
+	FixtureValidators = 4
+	FixtureBalance    = int64(500e9)
+	FixtureAmount     = int64(25e9)
+	FixtureFee        = int64(1e7)
+	FixtureMoniker    = "fixture"
+	FixtureAgent      = "node=daemon/pactus=1.0.0"
+)
+
+// ValidatorAddress returns the address of the fixture validator by its number.
+func ValidatorAddress(num int32) crypto.Address {
+	data := make([]byte, 20)
+	data[19] = byte(num)
+
+	return crypto.NewAddress(crypto.AddressTypeValidator, data)
+}
+
+// AccountAddress returns the address of the fixture account by its number.
+func AccountAddress(num int32) crypto.Address {
+	data := make([]byte, 20)
+	data[19] = byte(num)
+
+	return crypto.NewAddress(crypto.AddressTypeBLSAccount, data)
+}
+
+// FixtureSubsidy returns the subsidy transaction of the last fixture block, proposed by the first validator
+// and paid to its reward address, the first account.
+func FixtureSubsidy() *tx.Tx {
+	return tx.NewSubsidyTx(FixtureHeight, AccountAddress(1), 1e9+FixtureFee, "")
+}
+
+// FixtureTransfer returns the transfer of the last fixture block, from the first account to the second one.
+func FixtureTransfer() *tx.Tx {
+	return tx.NewTransferTx(FixtureHeight, AccountAddress(1), AccountAddress(2), FixtureAmount, FixtureFee, "fixture")
+}
+
+// NewFixtureNode creates a node at FixtureHeight, with a committee of FixtureValidators validators,
+// two accounts, and the last block that contains FixtureSubsidy and FixtureTransfer.
+// The validator n stakes n thousand PAC, and the last one has a low availability score.
+func NewFixtureNode(target string) (*Node, error) {
+	n := NewNode(target)
+
+	info := &pactus.GetBlockchainInfoResponse{
+		LastBlockHeight: FixtureHeight,
+		TotalAccounts:   2,
+		TotalValidators: FixtureValidators,
+	}
+	for num := int32(1); num <= FixtureValidators; num++ {
+		val := &pactus.ValidatorInfo{
+			Address:             ValidatorAddress(num).String(),
+			Number:              num,
+			Stake:               int64(num) * 1_000e9,
+			LastBondingHeight:   uint32(num) * 10,
+			LastSortitionHeight: FixtureHeight - uint32(num),
+			AvailabilityScore:   1 - float64(num-1)*0.05,
+		}
+		if num == FixtureValidators {
+			val.AvailabilityScore = 0.6
+		}
+
+		n.AddValidator(val)
+		info.CommitteeValidators = append(info.CommitteeValidators, val)
+		info.TotalPower += val.Stake
+		info.CommitteePower += val.Stake
+	}
+	n.SetBlockchainInfo(info)
+
+	for num := int32(1); num <= 2; num++ {
+		n.AddAccount(AccountAddress(num).String(), &pactus.AccountInfo{
+			Address: AccountAddress(num).String(),
+			Number:  num,
+			Balance: FixtureBalance,
+		})
+	}
+
+	n.SetNetworkInfo(&pactus.GetNetworkInfoResponse{
+		NetworkName:         "pactus",
+		ConnectedPeersCount: 3,
+	})
+	n.SetNodeInfo(&pactus.GetNodeInfoResponse{
+		Moniker: FixtureMoniker,
+		Agent:   FixtureAgent,
+	})
+	n.SetFee(FixtureFee)
+
+	block, err := n.AddBlock(FixtureHeight, FixtureBlockTime, FixtureSubsidy(), FixtureTransfer())
+	if err != nil {
+		return nil, err
+	}
+	block.Hash = []byte{0xab, 0xcd}
+	block.Header.ProposerAddress = ValidatorAddress(1).String()
+
+	return n, nil
+}
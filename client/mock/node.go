@@ -0,0 +1,488 @@
+// Package mock provides an in-memory Pactus node that implements client.IClient,
+// so the tests can run the commands without a live node.
+package mock
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/pactus-project/pactus/types/tx"
+	"github.com/pactus-project/pactus/types/tx/payload"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrUnavailable is the error of a node that is down, the client manager fails over to the next node on it.
+var ErrUnavailable = status.Error(codes.Unavailable, "node is unavailable")
+
+// BlockInterval is the time between the blocks that are not added explicitly.
+const BlockInterval = 10 * time.Second
+
+// Node is an in-memory Pactus node with scriptable responses and failure injection.
+// It's safe for concurrent use.
+type Node struct {
+	mu sync.Mutex
+
+	target       string
+	genesisTime  time.Time
+	fee          int64
+	info         *pactus.GetBlockchainInfoResponse
+	networkInfo  *pactus.GetNetworkInfoResponse
+	nodeInfo     *pactus.GetNodeInfoResponse
+	validators   map[string]*pactus.ValidatorInfo
+	accounts     map[string]*pactus.AccountInfo
+	transactions map[string]*pactus.GetTransactionResponse
+	blocks       map[uint32]*pactus.GetBlockResponse
+	broadcasted  [][]byte
+
+	down     error
+	failures map[string][]error
+	calls    map[string]int
+	closed   bool
+}
+
+var _ client.IClient = (*Node)(nil)
+
+// NewNode creates an empty node at height zero, the responses are set by the setters or loaded by Fixtures.
+func NewNode(target string) *Node {
+	return &Node{
+		target:       target,
+		genesisTime:  time.Date(2024, 1, 24, 0, 0, 0, 0, time.UTC),
+		info:         &pactus.GetBlockchainInfoResponse{},
+		networkInfo:  &pactus.GetNetworkInfoResponse{},
+		nodeInfo:     &pactus.GetNodeInfoResponse{},
+		validators:   make(map[string]*pactus.ValidatorInfo),
+		accounts:     make(map[string]*pactus.AccountInfo),
+		transactions: make(map[string]*pactus.GetTransactionResponse),
+		blocks:       make(map[uint32]*pactus.GetBlockResponse),
+		failures:     make(map[string][]error),
+		calls:        make(map[string]int),
+	}
+}
+
+// Fail makes the next calls of the method, like "GetNetworkInfo", fail with the errors in order.
+func (n *Node) Fail(method string, errs ...error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.failures[method] = append(n.failures[method], errs...)
+}
+
+// SetDown makes all the calls fail with the error until it's set to nil.
+func (n *Node) SetDown(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.down = err
+}
+
+// Calls returns the number of the calls of the method, including the failed ones.
+func (n *Node) Calls(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.calls[method]
+}
+
+// Broadcasted returns the raw transactions that are broadcasted to the node.
+func (n *Node) Broadcasted() [][]byte {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([][]byte{}, n.broadcasted...)
+}
+
+// Closed reports whether the node is closed.
+func (n *Node) Closed() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.closed
+}
+
+// SetBlockchainInfo sets the blockchain information, the last block height is the height of the node.
+func (n *Node) SetBlockchainInfo(info *pactus.GetBlockchainInfoResponse) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.info = info
+}
+
+// SetHeight moves the last block of the node to the height.
+func (n *Node) SetHeight(height uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.info.LastBlockHeight = height
+}
+
+func (n *Node) SetNetworkInfo(info *pactus.GetNetworkInfoResponse) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.networkInfo = info
+}
+
+func (n *Node) SetNodeInfo(info *pactus.GetNodeInfoResponse) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.nodeInfo = info
+}
+
+func (n *Node) SetGenesisTime(genesisTime time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.genesisTime = genesisTime
+}
+
+// SetFee sets the fee that is calculated for all the transactions.
+func (n *Node) SetFee(fee int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.fee = fee
+}
+
+// AddValidator adds or replaces the validator by its address.
+func (n *Node) AddValidator(val *pactus.ValidatorInfo) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.validators[val.Address] = val
+}
+
+// AddAccount adds or replaces the account of the address.
+func (n *Node) AddAccount(address string, acc *pactus.AccountInfo) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.accounts[address] = acc
+}
+
+// AddBlock adds the block with the transactions at the height, the transactions can be queried by their IDs.
+// The height of the node is moved forward if the block is after the last block.
+func (n *Node) AddBlock(height, blockTime uint32, trxs ...*tx.Tx) (*pactus.GetBlockResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	block := &pactus.GetBlockResponse{
+		Height:    height,
+		BlockTime: blockTime,
+		Header:    &pactus.BlockHeaderInfo{},
+	}
+	for _, trx := range trxs {
+		data, err := trx.Bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		info := &pactus.TransactionInfo{Id: trx.ID().Bytes(), Data: data}
+		block.Txs = append(block.Txs, info)
+		n.transactions[trx.ID().String()] = &pactus.GetTransactionResponse{
+			BlockHeight: height,
+			BlockTime:   blockTime,
+			Transaction: info,
+		}
+	}
+
+	n.blocks[height] = block
+	if height > n.info.LastBlockHeight {
+		n.info.LastBlockHeight = height
+	}
+
+	return block, nil
+}
+
+// call counts the call of the method, and returns the injected failure of it, if any.
+func (n *Node) call(method string) error {
+	n.calls[method]++
+	if n.down != nil {
+		return n.down
+	}
+
+	if errs := n.failures[method]; len(errs) > 0 {
+		n.failures[method] = errs[1:]
+
+		return errs[0]
+	}
+
+	return nil
+}
+
+// blockTime returns the time of the added block, or the time of the block by the interval from the genesis.
+func (n *Node) blockTime(height uint32) uint32 {
+	if block, ok := n.blocks[height]; ok {
+		return block.BlockTime
+	}
+
+	return uint32(n.genesisTime.Add(time.Duration(height) * BlockInterval).Unix())
+}
+
+func (n *Node) GetBlockchainInfo(_ context.Context) (*pactus.GetBlockchainInfoResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetBlockchainInfo"); err != nil {
+		return nil, err
+	}
+
+	return n.info, nil
+}
+
+func (n *Node) GetBlockchainHeight(_ context.Context) (uint32, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetBlockchainHeight"); err != nil {
+		return 0, err
+	}
+
+	return n.info.LastBlockHeight, nil
+}
+
+func (n *Node) LastBlockTime(_ context.Context) (uint32, uint32, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("LastBlockTime"); err != nil {
+		return 0, 0, err
+	}
+
+	return n.blockTime(n.info.LastBlockHeight), n.info.LastBlockHeight, nil
+}
+
+func (n *Node) GetNetworkInfo(_ context.Context) (*pactus.GetNetworkInfoResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetNetworkInfo"); err != nil {
+		return nil, err
+	}
+
+	return n.networkInfo, nil
+}
+
+func (n *Node) GetNodeInfo(_ context.Context) (*pactus.GetNodeInfoResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetNodeInfo"); err != nil {
+		return nil, err
+	}
+
+	return n.nodeInfo, nil
+}
+
+func (n *Node) GetValidatorInfo(_ context.Context, address string) (*pactus.GetValidatorResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetValidatorInfo"); err != nil {
+		return nil, err
+	}
+
+	val, ok := n.validators[address]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "validator not found: %s", address)
+	}
+
+	return &pactus.GetValidatorResponse{Validator: val}, nil
+}
+
+func (n *Node) GetValidatorInfoByNumber(_ context.Context, num int32) (*pactus.GetValidatorResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetValidatorInfoByNumber"); err != nil {
+		return nil, err
+	}
+
+	for _, val := range n.validators {
+		if val.Number == num {
+			return &pactus.GetValidatorResponse{Validator: val}, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "validator not found: %d", num)
+}
+
+func (n *Node) GetTransactionData(_ context.Context, txID string) (*pactus.GetTransactionResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetTransactionData"); err != nil {
+		return nil, err
+	}
+
+	if _, err := hex.DecodeString(txID); err != nil {
+		return nil, fmt.Errorf("invalid transaction ID: %s", txID)
+	}
+
+	res, ok := n.transactions[txID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", client.ErrTransactionNotFound, txID)
+	}
+
+	return res, nil
+}
+
+func (n *Node) GetAccount(_ context.Context, address string) (*pactus.AccountInfo, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetAccount"); err != nil {
+		return nil, err
+	}
+
+	acc, ok := n.accounts[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", client.ErrAccountNotFound, address)
+	}
+
+	return acc, nil
+}
+
+func (n *Node) GetBalance(ctx context.Context, address string) (int64, error) {
+	acc, err := n.GetAccount(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return acc.Balance, nil
+}
+
+func (n *Node) GetGenesisTime(_ context.Context) (time.Time, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetGenesisTime"); err != nil {
+		return time.Time{}, err
+	}
+
+	return n.genesisTime, nil
+}
+
+func (n *Node) GetBlockTimes(_ context.Context, fromHeight, toHeight uint32) ([]client.BlockTimePoint, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetBlockTimes"); err != nil {
+		return nil, err
+	}
+
+	if fromHeight == 0 || toHeight < fromHeight || toHeight > n.info.LastBlockHeight {
+		return nil, fmt.Errorf("invalid block range: %d-%d", fromHeight, toHeight)
+	}
+
+	points := make([]client.BlockTimePoint, 0, toHeight-fromHeight+1)
+	for height := fromHeight; height <= toHeight; height++ {
+		points = append(points, client.BlockTimePoint{
+			Height: height,
+			Time:   time.Unix(int64(n.blockTime(height)), 0),
+		})
+	}
+
+	return points, nil
+}
+
+// GetBlockWithTxs returns the added block, or an empty block if there is no block added at the height.
+func (n *Node) GetBlockWithTxs(_ context.Context, height uint32) (*pactus.GetBlockResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetBlockWithTxs"); err != nil {
+		return nil, err
+	}
+
+	return n.block(height)
+}
+
+// GetBlocksWithTxs returns the blocks in the range, like GetBlockWithTxs.
+func (n *Node) GetBlocksWithTxs(_ context.Context, fromHeight, toHeight uint32) ([]*pactus.GetBlockResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("GetBlocksWithTxs"); err != nil {
+		return nil, err
+	}
+
+	if fromHeight == 0 || toHeight < fromHeight {
+		return nil, fmt.Errorf("invalid block range: %d-%d", fromHeight, toHeight)
+	}
+
+	blocks := make([]*pactus.GetBlockResponse, 0, toHeight-fromHeight+1)
+	for height := fromHeight; height <= toHeight; height++ {
+		block, err := n.block(height)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+func (n *Node) block(height uint32) (*pactus.GetBlockResponse, error) {
+	if height == 0 || height > n.info.LastBlockHeight {
+		return nil, status.Errorf(codes.NotFound, "block not found: %d", height)
+	}
+
+	if block, ok := n.blocks[height]; ok {
+		return block, nil
+	}
+
+	return &pactus.GetBlockResponse{
+		Height:    height,
+		BlockTime: n.blockTime(height),
+		Header:    &pactus.BlockHeaderInfo{},
+	}, nil
+}
+
+func (n *Node) CalculateFee(_ context.Context, _ int64, _ payload.Type) (int64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("CalculateFee"); err != nil {
+		return 0, err
+	}
+
+	return n.fee, nil
+}
+
+// BroadcastTransaction keeps the transaction, it's not added to any block.
+func (n *Node) BroadcastTransaction(_ context.Context, signedRawTx []byte) (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.call("BroadcastTransaction"); err != nil {
+		return "", err
+	}
+
+	trx, err := tx.FromBytes(signedRawTx)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid transaction: %v", err)
+	}
+	n.broadcasted = append(n.broadcasted, signedRawTx)
+
+	return trx.ID().String(), nil
+}
+
+func (n *Node) Target() string {
+	return n.target
+}
+
+func (n *Node) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.closed = true
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureNode(t *testing.T) {
+	ctx := context.Background()
+	n, err := NewFixtureNode("node1:50051")
+	require.NoError(t, err)
+
+	height, err := n.GetBlockchainHeight(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FixtureHeight, height)
+
+	info, err := n.GetBlockchainInfo(ctx)
+	require.NoError(t, err)
+	assert.Len(t, info.CommitteeValidators, FixtureValidators)
+	assert.Equal(t, int64(10_000e9), info.TotalPower)
+
+	val, err := n.GetValidatorInfoByNumber(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, ValidatorAddress(2).String(), val.Validator.Address)
+
+	_, err = n.GetValidatorInfo(ctx, ValidatorAddress(9).String())
+	assert.Error(t, err)
+
+	res, err := n.GetTransactionData(ctx, FixtureTransfer().ID().String())
+	require.NoError(t, err)
+	assert.Equal(t, FixtureHeight, res.BlockHeight)
+
+	_, err = n.GetTransactionData(ctx, "00")
+	assert.ErrorIs(t, err, client.ErrTransactionNotFound)
+
+	balance, err := n.GetBalance(ctx, AccountAddress(1).String())
+	require.NoError(t, err)
+	assert.Equal(t, FixtureBalance, balance)
+
+	_, err = n.GetBalance(ctx, AccountAddress(9).String())
+	assert.ErrorIs(t, err, client.ErrAccountNotFound)
+
+	blockTime, _, err := n.LastBlockTime(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FixtureBlockTime, blockTime)
+
+	block, err := n.GetBlockWithTxs(ctx, FixtureHeight-1)
+	require.NoError(t, err)
+	assert.Empty(t, block.Txs)
+
+	_, err = n.GetBlockWithTxs(ctx, FixtureHeight+1)
+	assert.Error(t, err)
+
+	points, err := n.GetBlockTimes(ctx, FixtureHeight-1, FixtureHeight)
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.Equal(t, int64(FixtureBlockTime), points[1].Time.Unix())
+}
+
+func TestFailures(t *testing.T) {
+	ctx := context.Background()
+	n, err := NewFixtureNode("node1:50051")
+	require.NoError(t, err)
+
+	errFirst := errors.New("first")
+	n.Fail("GetNetworkInfo", errFirst, ErrUnavailable)
+
+	_, err = n.GetNetworkInfo(ctx)
+	assert.ErrorIs(t, err, errFirst)
+	_, err = n.GetNetworkInfo(ctx)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	_, err = n.GetNetworkInfo(ctx)
+	assert.NoError(t, err)
+	_, err = n.GetNodeInfo(ctx)
+	assert.NoError(t, err, "the failures are injected per method")
+	assert.Equal(t, 3, n.Calls("GetNetworkInfo"))
+
+	n.SetDown(ErrUnavailable)
+	_, err = n.GetBlockchainHeight(ctx)
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	n.SetDown(nil)
+	_, err = n.GetBlockchainHeight(ctx)
+	assert.NoError(t, err)
+}
+
+func TestBroadcastTransaction(t *testing.T) {
+	n := NewNode("node1:50051")
+	data, err := FixtureSubsidy().Bytes()
+	require.NoError(t, err)
+
+	id, err := n.BroadcastTransaction(context.Background(), data)
+	require.NoError(t, err)
+	assert.Equal(t, FixtureSubsidy().ID().String(), id)
+	assert.Len(t, n.Broadcasted(), 1)
+
+	_, err = n.BroadcastTransaction(context.Background(), []byte{0x01})
+	assert.Error(t, err)
+	assert.Len(t, n.Broadcasted(), 1)
+}
+
+func TestFailoverBetweenNodes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	local, err := NewFixtureNode("local:50051")
+	require.NoError(t, err)
+	remote, err := NewFixtureNode("remote:50051")
+	require.NoError(t, err)
+
+	cm := client.NewClientMgr(ctx)
+	cm.AddClient(local)
+	cm.AddClient(remote)
+
+	local.SetDown(ErrUnavailable)
+	info, err := cm.GetNetworkInfo()
+	require.NoError(t, err)
+	assert.Equal(t, "pactus", info.NetworkName)
+	assert.Equal(t, 1, remote.Calls("GetNetworkInfo"))
+}
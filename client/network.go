@@ -0,0 +1,47 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	NetworkMainnet  = "mainnet"
+	NetworkTestnet  = "testnet"
+	NetworkLocalnet = "localnet"
+)
+
+// NetworkOf returns the network of the name, like "testnet" for "Testnet" or "pactus-testnet".
+// An empty name is the mainnet.
+func NetworkOf(name string) string {
+	if name == "" {
+		return NetworkMainnet
+	}
+
+	return strings.ToLower(NetworkType(name))
+}
+
+// SetNetwork sets the network that the nodes of the manager belong to. It should be called before Start.
+func (cm *Mgr) SetNetwork(network string) {
+	cm.network = network
+}
+
+// Network returns the network that the nodes of the manager belong to, the mainnet by default.
+func (cm *Mgr) Network() string {
+	return cm.network
+}
+
+// CheckNetwork checks that the nodes report the network of the manager, so the testnet nodes
+// are not configured as the mainnet ones by mistake.
+func (cm *Mgr) CheckNetwork() error {
+	info, err := cm.GetNetworkInfo()
+	if err != nil {
+		return err
+	}
+
+	if network := NetworkOf(info.NetworkName); network != cm.network {
+		return fmt.Errorf("the nodes of the %s are on the %s: %s", cm.network, network, info.NetworkName)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNetworkOf(t *testing.T) {
+	assert.Equal(t, NetworkMainnet, NetworkOf(""))
+	assert.Equal(t, NetworkMainnet, NetworkOf("Mainnet"))
+	assert.Equal(t, NetworkTestnet, NetworkOf("pactus-testnet"))
+	assert.Equal(t, NetworkLocalnet, NetworkOf("Localnet"))
+}
+
+func TestCheckNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := NewMockIClient(ctrl)
+
+	cm := NewClientMgr(context.Background())
+	cm.AddClient(mockClient)
+	assert.Equal(t, NetworkMainnet, cm.Network())
+
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(
+		&pactus.GetNetworkInfoResponse{NetworkName: "pactus-testnet"}, nil).Times(2)
+
+	assert.ErrorContains(t, cm.CheckNetwork(), "the nodes of the mainnet are on the testnet")
+
+	cm.SetNetwork(NetworkTestnet)
+	assert.NoError(t, cm.CheckNetwork())
+}
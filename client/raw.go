@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// rawPackage is the protobuf package of the pactus services.
+const rawPackage = "pactus"
+
+// ErrRawNotSupported is returned by the clients that can't call the raw methods, like the mocks.
+var ErrRawNotSupported = errors.New("the client doesn't support the raw calls")
+
+// RawInvoker is implemented by the clients that can call the methods of the node by their JSON requests.
+type RawInvoker interface {
+	InvokeRaw(ctx context.Context, method string, request []byte) ([]byte, error)
+}
+
+// ResolveRawMethod returns the pactus method by its name, like "Blockchain.GetBlock" or "pactus.Blockchain.GetBlock".
+// Only the read methods are allowed, the wallet service and the broadcasts are rejected.
+func ResolveRawMethod(name string) (protoreflect.MethodDescriptor, error) {
+	name = strings.ReplaceAll(strings.Trim(strings.TrimSpace(name), "/"), "/", ".")
+	if !strings.HasPrefix(name, rawPackage+".") {
+		name = rawPackage + "." + name
+	}
+
+	idx := strings.LastIndex(name, ".")
+	serviceName, methodName := name[:idx], name[idx+1:]
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service %s, expected a method like Blockchain.GetBlock", serviceName)
+	}
+
+	service, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("unknown service %s", serviceName)
+	}
+
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, fmt.Errorf("unknown method %s of %s", methodName, serviceName)
+	}
+
+	if service.Name() == "Wallet" || (!strings.HasPrefix(methodName, "Get") && methodName != "CalculateFee") {
+		return nil, fmt.Errorf("%s is not allowed, only the read methods can be called", method.FullName())
+	}
+
+	return method, nil
+}
+
+// InvokeRaw calls the method with the JSON request, and returns the JSON response.
+// The JSON is in the protobuf format, so the bytes fields are in base64.
+func (c *Client) InvokeRaw(ctx context.Context, method string, request []byte) ([]byte, error) {
+	desc, err := ResolveRawMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := newRawMessage(desc.Input())
+	if err != nil {
+		return nil, err
+	}
+	if err := protojson.Unmarshal(request, req); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", desc.Input().Name(), err)
+	}
+
+	fullMethod := fmt.Sprintf("/%s/%s", desc.Parent().FullName(), desc.Name())
+	res, err := withRetry(ctx, c, func(ctx context.Context) (proto.Message, error) {
+		res, err := newRawMessage(desc.Output())
+		if err != nil {
+			return nil, err
+		}
+
+		return res, c.conn.Invoke(ctx, fullMethod, req, res)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(res)
+}
+
+// InvokeRaw is not cached, the raw calls are for debugging the node.
+func (cc *CachedClient) InvokeRaw(ctx context.Context, method string, request []byte) ([]byte, error) {
+	invoker, ok := cc.IClient.(RawInvoker)
+	if !ok {
+		return nil, ErrRawNotSupported
+	}
+
+	return invoker.InvokeRaw(ctx, method, request)
+}
+
+// InvokeRaw calls the method with the JSON request on the nodes, see Client.InvokeRaw.
+func (cm *Mgr) InvokeRaw(method string, request []byte) ([]byte, error) {
+	return withFailover(cm, func(c IClient) ([]byte, error) {
+		invoker, ok := c.(RawInvoker)
+		if !ok {
+			return nil, ErrRawNotSupported
+		}
+
+		return invoker.InvokeRaw(cm.ctx, method, request)
+	})
+}
+
+func newRawMessage(desc protoreflect.MessageDescriptor) (proto.Message, error) {
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+	if err != nil {
+		return nil, err
+	}
+
+	return msgType.New().Interface(), nil
+}
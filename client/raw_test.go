@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type rawBlockchainServer struct {
+	pactus.UnimplementedBlockchainServer
+}
+
+func (*rawBlockchainServer) GetBlockHash(_ context.Context, req *pactus.GetBlockHashRequest,
+) (*pactus.GetBlockHashResponse, error) {
+	if req.Height == 0 {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+
+	return &pactus.GetBlockHashResponse{Hash: []byte{0x01, 0x02}}, nil
+}
+
+func TestResolveRawMethod(t *testing.T) {
+	for _, name := range []string{"Blockchain.GetBlock", "pactus.Blockchain.GetBlock", "/pactus.Blockchain/GetBlock"} {
+		method, err := ResolveRawMethod(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, "pactus.Blockchain.GetBlock", string(method.FullName()))
+	}
+
+	_, err := ResolveRawMethod("Blockchain.GetNothing")
+	assert.ErrorContains(t, err, "unknown method")
+
+	_, err = ResolveRawMethod("Ledger.GetBlock")
+	assert.ErrorContains(t, err, "unknown service")
+
+	_, err = ResolveRawMethod("Transaction.BroadcastTransaction")
+	assert.ErrorContains(t, err, "not allowed")
+
+	_, err = ResolveRawMethod("Wallet.GetValidatorAddress")
+	assert.Error(t, err)
+}
+
+func TestInvokeRaw(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	pactus.RegisterBlockchainServer(srv, &rawBlockchainServer{})
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	cm := NewClientMgr(context.Background())
+	cm.AddClient(NewCachedClient(setupClientFor(t, listener.Addr().String()), DefaultCacheTTLs()))
+
+	res, err := cm.InvokeRaw("Blockchain.GetBlockHash", []byte(`{"height": 10}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"hash": "AQI="}`, string(res))
+
+	t.Run("node error", func(t *testing.T) {
+		_, err := cm.InvokeRaw("Blockchain.GetBlockHash", []byte(`{}`))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err := cm.InvokeRaw("Blockchain.GetBlockHash", []byte(`{"hight": 10}`))
+		assert.ErrorContains(t, err, "invalid GetBlockHashRequest")
+	})
+}
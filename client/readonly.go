@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// ErrReadOnly is returned by the write methods of a read-only client.
+var ErrReadOnly = errors.New("client is read-only")
+
+// WithReadOnly makes the client return ErrReadOnly from the write methods, like BroadcastTransaction,
+// for the bots that must never move funds. The reads keep working.
+func WithReadOnly() Option {
+	return func(c *Client) {
+		c.readOnly = true
+	}
+}
+
+// ReadOnly reports whether the client blocks the write methods.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// BroadcastTransaction broadcasts the signed raw transaction and returns its ID.
+// It's not retried, since a failed broadcast may have reached the node.
+// A read-only client returns ErrReadOnly.
+func (c *Client) BroadcastTransaction(ctx context.Context, signedRawTx []byte) (string, error) {
+	if c.readOnly {
+		return "", ErrReadOnly
+	}
+
+	res, err := attemptCall(ctx, c, func(ctx context.Context) (*pactus.BroadcastTransactionResponse, error) {
+		return c.transactionClient.BroadcastTransaction(ctx, &pactus.BroadcastTransactionRequest{
+			SignedRawTransaction: signedRawTx,
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(res.Id), nil
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTransactionClient records the broadcasts and returns the transactions by their hex IDs.
+// Calling any other method panics.
+type fakeTransactionClient struct {
+	pactus.TransactionClient
+
+	broadcasts int
+	txs        map[string]*pactus.GetTransactionResponse
+}
+
+func (f *fakeTransactionClient) GetTransaction(_ context.Context, req *pactus.GetTransactionRequest,
+	_ ...grpc.CallOption,
+) (*pactus.GetTransactionResponse, error) {
+	res, ok := f.txs[hex.EncodeToString(req.Id)]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction not found")
+	}
+
+	return res, nil
+}
+
+func (f *fakeTransactionClient) BroadcastTransaction(_ context.Context, _ *pactus.BroadcastTransactionRequest,
+	_ ...grpc.CallOption,
+) (*pactus.BroadcastTransactionResponse, error) {
+	f.broadcasts++
+
+	return &pactus.BroadcastTransactionResponse{Id: []byte{0xab, 0xcd}}, nil
+}
+
+func TestReadOnly(t *testing.T) {
+	t.Run("writes are blocked", func(t *testing.T) {
+		c := setupClient(t, WithReadOnly())
+		txClient := &fakeTransactionClient{}
+		c.transactionClient = txClient
+		c.blockchainClient = &fakeBlockchainClient{
+			info: &pactus.GetBlockchainInfoResponse{LastBlockHeight: 100},
+		}
+
+		assert.True(t, c.ReadOnly())
+
+		_, err := c.BroadcastTransaction(context.Background(), []byte{1, 2, 3})
+		assert.ErrorIs(t, err, ErrReadOnly)
+		assert.Zero(t, txClient.broadcasts)
+
+		height, err := c.GetBlockchainHeight(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), height)
+	})
+
+	t.Run("writes are allowed by default", func(t *testing.T) {
+		c := setupClient(t)
+		txClient := &fakeTransactionClient{}
+		c.transactionClient = txClient
+
+		assert.False(t, c.ReadOnly())
+
+		id, err := c.BroadcastTransaction(context.Background(), []byte{1, 2, 3})
+		require.NoError(t, err)
+		assert.Equal(t, "abcd", id)
+		assert.Equal(t, 1, txClient.broadcasts)
+	})
+}
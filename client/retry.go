@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRetryBudgetTokens = 10
+	defaultRetryBudgetRatio  = 0.1
+	maxRetryBackoff          = 10 * time.Second
+)
+
+// RetryBudget caps the fraction of the calls that may be retried, like the retry throttling of gRPC.
+// Every failed call takes a token and every successful call gives back ratio tokens.
+// Retries are suppressed while less than half of the tokens are left,
+// so during a broad outage the node is not flooded with retries.
+type RetryBudget struct {
+	lk sync.Mutex
+
+	maxTokens float64
+	ratio     float64
+	tokens    float64
+}
+
+func NewRetryBudget(maxTokens, ratio float64) *RetryBudget {
+	return &RetryBudget{
+		maxTokens: maxTokens,
+		ratio:     ratio,
+		tokens:    maxTokens,
+	}
+}
+
+func (rb *RetryBudget) onSuccess() {
+	rb.lk.Lock()
+	defer rb.lk.Unlock()
+
+	rb.tokens = min(rb.tokens+rb.ratio, rb.maxTokens)
+}
+
+func (rb *RetryBudget) onFailure() {
+	rb.lk.Lock()
+	defer rb.lk.Unlock()
+
+	rb.tokens = max(rb.tokens-1, 0)
+}
+
+func (rb *RetryBudget) allowRetry() bool {
+	rb.lk.Lock()
+	defer rb.lk.Unlock()
+
+	return rb.tokens > rb.maxTokens/2
+}
+
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+	budget      *RetryBudget
+}
+
+// backoffOf returns the wait before the next attempt, it doubles after each attempt up to maxRetryBackoff.
+func (p *retryPolicy) backoffOf(attempt int) time.Duration {
+	backoff := p.backoff
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxRetryBackoff)
+}
+
+// WithRetry retries the failed read calls, maxAttempts is the total number of the attempts including the first one.
+// The first retry waits backoff, and the wait doubles for each next retry. The wait ends if the context is done.
+// The retries are limited by a default retry budget, use WithRetryBudget to change it.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(c *Client) {
+		budget := NewRetryBudget(defaultRetryBudgetTokens, defaultRetryBudgetRatio)
+		if c.retry != nil {
+			budget = c.retry.budget
+		}
+
+		c.retry = &retryPolicy{
+			maxAttempts: maxAttempts,
+			backoff:     backoff,
+			budget:      budget,
+		}
+	}
+}
+
+// WithRetryBudget replaces the retry budget of the client. A nil budget doesn't limit the retries.
+// It should be passed after WithRetry.
+func WithRetryBudget(budget *RetryBudget) Option {
+	return func(c *Client) {
+		if c.retry != nil {
+			c.retry.budget = budget
+		}
+	}
+}
+
+// IsNodeFailure reports whether the error is a transient failure of the node or the connection to it.
+// The permanent errors, like not found, mean that the node is reachable.
+func IsNodeFailure(err error) bool {
+	return isRetryable(err)
+}
+
+// isRetryable reports whether the error is transient and the call can be retried.
+func isRetryable(err error) bool {
+	// the node is failing, but the next node can be tried.
+	if errors.Is(err, ErrNodeUnavailable) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// tracked wraps the call to keep the in-flight calls, the health and the last success of the client updated.
+func tracked[T any](c *Client, call func() (T, error)) func() (T, error) {
+	return func() (T, error) {
+		c.inFlight.Add(1)
+		defer c.inFlight.Add(-1)
+
+		res, err := call()
+		c.unhealthy.Store(err != nil && isRetryable(err))
+		if err == nil {
+			c.lastSuccess.Store(c.clock.Now().UnixNano())
+		}
+
+		return res, err
+	}
+}
+
+// attemptCall calls the node once, bounded by the call timeout and guarded by the circuit breaker of the client.
+func attemptCall[T any](ctx context.Context, c *Client, call func(context.Context) (T, error)) (T, error) {
+	if c.breaker != nil && !c.breaker.allow(c.clock.Now()) {
+		var zero T
+
+		return zero, fmt.Errorf("%w: %s", ErrNodeUnavailable, c.Target())
+	}
+
+	if c.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.callTimeout)
+		defer cancel()
+	}
+
+	res, err := tracked(c, func() (T, error) { return call(ctx) })()
+	if c.breaker != nil {
+		c.breaker.report(err, c.clock.Now())
+	}
+
+	return res, err
+}
+
+// withRetry calls the function and retries it on transient errors, based on the retry policy of the client.
+// The calls are not retried while the circuit breaker is open.
+func withRetry[T any](ctx context.Context, c *Client, call func(context.Context) (T, error)) (T, error) {
+	res, err := attemptCall(ctx, c, call)
+	if c.retry == nil {
+		return res, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		if errors.Is(err, ErrNodeUnavailable) {
+			return res, err
+		}
+
+		if c.retry.budget != nil {
+			if err == nil {
+				c.retry.budget.onSuccess()
+			} else {
+				c.retry.budget.onFailure()
+			}
+		}
+
+		if err == nil || !isRetryable(err) || attempt >= c.retry.maxAttempts || ctx.Err() != nil {
+			return res, err
+		}
+
+		if c.retry.budget != nil && !c.retry.budget.allowRetry() {
+			return res, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return res, err
+		case <-c.clock.After(c.retry.backoffOf(attempt)):
+		}
+
+		res, err = attemptCall(ctx, c, call)
+	}
+}
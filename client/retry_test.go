@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetry(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "node is down")
+
+	t.Run("no retry by default", func(t *testing.T) {
+		c := setupClient(t)
+		fake := &fakeBlockchainClient{failures: 1, err: unavailable}
+		c.blockchainClient = fake
+
+		_, err := c.GetBlockchainInfo(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, 1, fake.calls)
+	})
+
+	t.Run("transient error is retried", func(t *testing.T) {
+		c := setupClient(t, WithClock(NewFakeClock(time.Unix(0, 0))), WithRetry(3, 0))
+		fake := &fakeBlockchainClient{
+			info:     &pactus.GetBlockchainInfoResponse{LastBlockHeight: 100},
+			failures: 2,
+			err:      unavailable,
+		}
+		c.blockchainClient = fake
+
+		info, err := c.GetBlockchainInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), info.LastBlockHeight)
+		assert.Equal(t, 3, fake.calls)
+	})
+
+	t.Run("backoff is canceled with the context", func(t *testing.T) {
+		clock := NewFakeClock(time.Unix(0, 0))
+		c := setupClient(t, WithClock(clock), WithRetry(3, time.Minute))
+		fake := &fakeBlockchainClient{failures: 3, err: unavailable}
+		c.blockchainClient = fake
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			_, err := c.GetBlockchainInfo(ctx)
+			done <- err
+		}()
+
+		assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+		cancel()
+
+		assert.Error(t, <-done)
+		assert.Equal(t, 1, fake.calls)
+	})
+
+	t.Run("permanent error is not retried", func(t *testing.T) {
+		c := setupClient(t, WithClock(NewFakeClock(time.Unix(0, 0))), WithRetry(3, 0))
+		fake := &fakeBlockchainClient{failures: 1, err: status.Error(codes.NotFound, "not found")}
+		c.blockchainClient = fake
+
+		_, err := c.GetBlockchainInfo(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, 1, fake.calls)
+	})
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := &retryPolicy{backoff: time.Second}
+
+	assert.Equal(t, time.Second, p.backoffOf(1))
+	assert.Equal(t, 2*time.Second, p.backoffOf(2))
+	assert.Equal(t, 8*time.Second, p.backoffOf(4))
+	assert.Equal(t, maxRetryBackoff, p.backoffOf(10))
+}
+
+func TestCallTimeout(t *testing.T) {
+	c := setupClient(t, WithCallTimeout(time.Minute))
+	fake := &fakeBlockchainClient{info: &pactus.GetBlockchainInfoResponse{}}
+	c.blockchainClient = fake
+
+	_, err := c.GetBlockchainInfo(context.Background())
+	require.NoError(t, err)
+
+	deadline, ok := fake.ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+func TestRetryBudgetOutage(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "node is down")
+	numCalls := 100
+
+	t.Run("without budget", func(t *testing.T) {
+		c := setupClient(t, WithClock(NewFakeClock(time.Unix(0, 0))),
+			WithRetry(3, 0), WithRetryBudget(nil))
+		fake := &fakeBlockchainClient{failures: numCalls * 3, err: unavailable}
+		c.blockchainClient = fake
+
+		for i := 0; i < numCalls; i++ {
+			_, err := c.GetBlockchainInfo(context.Background())
+			assert.Error(t, err)
+		}
+
+		assert.Equal(t, numCalls*3, fake.calls)
+	})
+
+	t.Run("with budget", func(t *testing.T) {
+		c := setupClient(t, WithClock(NewFakeClock(time.Unix(0, 0))),
+			WithRetry(3, 0), WithRetryBudget(NewRetryBudget(10, 0.1)))
+		fake := &fakeBlockchainClient{failures: numCalls * 3, err: unavailable}
+		c.blockchainClient = fake
+
+		for i := 0; i < numCalls; i++ {
+			_, err := c.GetBlockchainInfo(context.Background())
+			assert.Error(t, err)
+		}
+
+		// only the first calls are retried, until half of the budget is spent.
+		assert.Equal(t, numCalls+3, fake.calls)
+	})
+
+	t.Run("budget recovers after the outage", func(t *testing.T) {
+		budget := NewRetryBudget(10, 0.1)
+		for i := 0; i < 10; i++ {
+			budget.onFailure()
+		}
+		assert.False(t, budget.allowRetry())
+
+		for i := 0; i < 51; i++ {
+			budget.onSuccess()
+		}
+		assert.True(t, budget.allowRetry())
+	})
+}
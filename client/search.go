@@ -0,0 +1,121 @@
+package client
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// The fields of the peers that the queries are matched against.
+const (
+	MatchMoniker = "moniker"
+	MatchPeerID  = "peer id"
+	MatchAddress = "address"
+)
+
+// PeerMatch is a connected peer that matches a search query.
+type PeerMatch struct {
+	Peer   *pactus.PeerInfo
+	PeerID string
+	// Address is the matched consensus address, or the first one if the address is not matched.
+	Address string
+	// MatchedBy is the field that matches the query, like MatchMoniker.
+	MatchedBy string
+
+	rank int
+}
+
+// The ranks of the matches, the exact matches come first and the moniker substrings last.
+const (
+	rankContains = iota + 1
+	rankPrefix
+	rankExact
+)
+
+// SearchPeers returns at most limit connected peers that match the query, the best matches first.
+// The query matches the moniker case-insensitively, or a prefix of the peer ID or of a consensus address.
+func (cm *Mgr) SearchPeers(query string, limit int) ([]PeerMatch, error) {
+	netInfo, err := cm.GetNetworkInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+
+	matches := make([]PeerMatch, 0)
+	for _, p := range netInfo.ConnectedPeers {
+		if m, ok := matchPeer(p, query); ok {
+			matches = append(matches, m)
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b PeerMatch) int {
+		if a.rank != b.rank {
+			return b.rank - a.rank
+		}
+
+		return cmp.Compare(strings.ToLower(a.Peer.Moniker), strings.ToLower(b.Peer.Moniker))
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
+
+// matchPeer matches the peer against the query, the best match of its fields is returned.
+func matchPeer(p *pactus.PeerInfo, query string) (PeerMatch, bool) {
+	m := PeerMatch{Peer: p}
+	if id, err := peer.IDFromBytes(p.PeerId); err == nil {
+		m.PeerID = id.String()
+	}
+	if len(p.ConsensusAddress) > 0 {
+		m.Address = p.ConsensusAddress[0]
+	}
+
+	better := func(rank int, matchedBy string) {
+		if rank > m.rank {
+			m.rank = rank
+			m.MatchedBy = matchedBy
+		}
+	}
+
+	moniker, lowerQuery := strings.ToLower(p.Moniker), strings.ToLower(query)
+	switch {
+	case moniker == "":
+	case moniker == lowerQuery:
+		better(rankExact, MatchMoniker)
+	case strings.HasPrefix(moniker, lowerQuery):
+		better(rankPrefix, MatchMoniker)
+	case strings.Contains(moniker, lowerQuery):
+		better(rankContains, MatchMoniker)
+	}
+
+	if m.PeerID != "" && strings.HasPrefix(m.PeerID, query) {
+		better(prefixRank(m.PeerID, query), MatchPeerID)
+	}
+
+	for _, addr := range p.ConsensusAddress {
+		if strings.HasPrefix(addr, query) && prefixRank(addr, query) > m.rank {
+			better(prefixRank(addr, query), MatchAddress)
+			m.Address = addr
+		}
+	}
+
+	return m, m.rank > 0
+}
+
+func prefixRank(value, query string) int {
+	if value == query {
+		return rankExact
+	}
+
+	return rankPrefix
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestPeerID(t *testing.T) peer.ID {
+	t.Helper()
+
+	_, pub, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestSearchPeers(t *testing.T) {
+	mockClient := NewMockIClient(gomock.NewController(t))
+	cm := NewClientMgr(context.Background())
+	cm.AddClient(mockClient)
+
+	peerID := newTestPeerID(t)
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(&pactus.GetNetworkInfoResponse{
+		ConnectedPeers: []*pactus.PeerInfo{
+			{Moniker: "my-pactus-node", ConsensusAddress: []string{"pc1pabc111", "pc1pxyz222"}},
+			{Moniker: "Pactus", PeerId: []byte(peerID), ConsensusAddress: []string{"pc1pdef333"}},
+			{Moniker: "other", ConsensusAddress: []string{"pc1pxyz999"}},
+		},
+	}, nil).AnyTimes()
+
+	t.Run("moniker", func(t *testing.T) {
+		matches, err := cm.SearchPeers("pactus", 10)
+		require.NoError(t, err)
+		require.Len(t, matches, 2)
+
+		// the exact match comes first.
+		assert.Equal(t, "Pactus", matches[0].Peer.Moniker)
+		assert.Equal(t, peerID.String(), matches[0].PeerID)
+		assert.Equal(t, MatchMoniker, matches[0].MatchedBy)
+		assert.Equal(t, "my-pactus-node", matches[1].Peer.Moniker)
+		assert.Equal(t, "pc1pabc111", matches[1].Address)
+	})
+
+	t.Run("address prefix", func(t *testing.T) {
+		matches, err := cm.SearchPeers("pc1pxyz", 10)
+		require.NoError(t, err)
+		require.Len(t, matches, 2)
+
+		assert.Equal(t, MatchAddress, matches[0].MatchedBy)
+		assert.Equal(t, "pc1pxyz222", matches[0].Address)
+		assert.Equal(t, "pc1pxyz999", matches[1].Address)
+	})
+
+	t.Run("peer id prefix", func(t *testing.T) {
+		matches, err := cm.SearchPeers(peerID.String()[:20], 10)
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.Equal(t, MatchPeerID, matches[0].MatchedBy)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		matches, err := cm.SearchPeers("pc1p", 1)
+		require.NoError(t, err)
+		assert.Len(t, matches, 1)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		matches, err := cm.SearchPeers("nothing", 10)
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authBlockchainServer accepts the calls with the token only.
+type authBlockchainServer struct {
+	pactus.UnimplementedBlockchainServer
+
+	token string
+}
+
+func (s *authBlockchainServer) GetBlockchainInfo(ctx context.Context, _ *pactus.GetBlockchainInfoRequest,
+) (*pactus.GetBlockchainInfoResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if auth := md.Get("authorization"); len(auth) != 1 || auth[0] != "Bearer "+s.token {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	return &pactus.GetBlockchainInfoResponse{LastBlockHeight: 100}, nil
+}
+
+// selfSignedCert creates a certificate of 127.0.0.1 and writes it in the PEM file.
+func selfSignedCert(t *testing.T) (tls.Certificate, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-node"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certFile := path.Join(t.TempDir(), "node.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, certFile
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	_, caFile := selfSignedCert(t)
+
+	cfg, err := LoadTLSConfig(caFile, "", "")
+	require.NoError(t, err)
+	assert.NotNil(t, cfg.RootCAs)
+
+	cfg, err = LoadTLSConfig("", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, cfg.RootCAs, "the system roots are used")
+
+	_, err = LoadTLSConfig(path.Join(t.TempDir(), "missing.pem"), "", "")
+	assert.Error(t, err)
+
+	emptyFile := path.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("no certificate"), 0o600))
+	_, err = LoadTLSConfig(emptyFile, "", "")
+	assert.ErrorContains(t, err, "no certificate")
+
+	_, err = LoadTLSConfig("", caFile, "")
+	assert.Error(t, err, "the key is missing")
+}
+
+func TestTLSConnection(t *testing.T) {
+	cert, caFile := selfSignedCert(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	})))
+	pactus.RegisterBlockchainServer(srv, &authBlockchainServer{token: "secret"})
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	endpoint := TLSScheme + listener.Addr().String()
+	tlsConfig, err := LoadTLSConfig(caFile, "", "")
+	require.NoError(t, err)
+
+	t.Run("verified node with the token", func(t *testing.T) {
+		c := setupClientFor(t, endpoint, WithTLSConfig(tlsConfig), WithAuthToken("secret"))
+
+		info, err := c.GetBlockchainInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint32(100), info.LastBlockHeight)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		c := setupClientFor(t, endpoint, WithTLSConfig(tlsConfig), WithAuthToken("wrong"))
+
+		_, err := c.GetBlockchainInfo(context.Background())
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("unknown CA", func(t *testing.T) {
+		c := setupClientFor(t, endpoint, WithAuthToken("secret"))
+
+		_, err := c.GetBlockchainInfo(context.Background())
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("token without TLS", func(t *testing.T) {
+		_, err := NewClient(listener.Addr().String(), WithAuthToken("secret"))
+		assert.ErrorContains(t, err, "requires TLS")
+	})
+}
+
+func setupClientFor(t *testing.T, endpoint string, opts ...Option) *Client {
+	t.Helper()
+
+	c, err := NewClient(endpoint, opts...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"fmt"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaxValidators caps the number of the validators fetched by GetValidators, to protect the node.
+	MaxValidators = 10000
+
+	validatorsConcurrency = 8
+)
+
+// GetValidators returns all the validators of the blockchain, ordered by their numbers.
+// The node has no query of all the validators, so they are fetched by their numbers concurrently,
+// from 0 to the total, and the missing numbers are skipped.
+func (cm *Mgr) GetValidators(total int32) ([]*pactus.ValidatorInfo, error) {
+	if total < 0 || total > MaxValidators {
+		return nil, fmt.Errorf("invalid number of the validators: %d, the maximum is %d", total, MaxValidators)
+	}
+
+	found := make([]*pactus.ValidatorInfo, total+1)
+
+	var g errgroup.Group
+	g.SetLimit(validatorsConcurrency)
+	for num := int32(0); num <= total; num++ {
+		num := num
+
+		g.Go(func() error {
+			val, err := cm.GetValidatorInfoByNumber(num)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return nil
+				}
+
+				return fmt.Errorf("validator %d: %w", num, err)
+			}
+			found[num] = val.Validator
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	validators := make([]*pactus.ValidatorInfo, 0, total)
+	for _, val := range found {
+		if val != nil {
+			validators = append(validators, val)
+		}
+	}
+
+	return validators, nil
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetValidators(t *testing.T) {
+	cm, clients := setupFailover(t, SelectionPriority, 1)
+	c := clients[0]
+
+	c.EXPECT().GetValidatorInfoByNumber(gomock.Any(), int32(0)).Return(
+		nil, status.Error(codes.NotFound, "validator not found"))
+	for num := int32(1); num <= 3; num++ {
+		c.EXPECT().GetValidatorInfoByNumber(gomock.Any(), num).Return(
+			&pactus.GetValidatorResponse{Validator: &pactus.ValidatorInfo{Number: num}}, nil)
+	}
+
+	validators, err := cm.GetValidators(3)
+	require.NoError(t, err)
+	require.Len(t, validators, 3, "the missing numbers are skipped")
+	for i, val := range validators {
+		assert.Equal(t, int32(i+1), val.Number)
+	}
+
+	t.Run("failed query", func(t *testing.T) {
+		c.EXPECT().GetValidatorInfoByNumber(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error")).MinTimes(1)
+
+		_, err := cm.GetValidators(1)
+		assert.ErrorContains(t, err, "internal error")
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		_, err := cm.GetValidators(MaxValidators + 1)
+		assert.Error(t, err)
+	})
+}
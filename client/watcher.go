@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// DefaultBlockWatchInterval is about the block time of the network, so most of the blocks are seen one by one.
+const DefaultBlockWatchInterval = 10 * time.Second
+
+// BlockEvent is emitted when the watcher sees new blocks. The nodes don't stream the blocks,
+// so the watcher polls them and one event can cover a few blocks.
+type BlockEvent struct {
+	// FromHeight is the first new height and Height is the last one.
+	FromHeight uint32
+	Height     uint32
+
+	// Joined and Left are the validators that joined or left the committee since the previous event.
+	Joined []string
+	Left   []string
+}
+
+// Milestone returns the last height of the event that is a multiple of every, if there is any.
+func (e BlockEvent) Milestone(every uint32) (uint32, bool) {
+	if every == 0 {
+		return 0, false
+	}
+
+	milestone := e.Height - e.Height%every
+	if milestone == 0 || milestone < e.FromHeight {
+		return 0, false
+	}
+
+	return milestone, true
+}
+
+// CommitteeChanged returns true if any validator joined or left the committee.
+func (e BlockEvent) CommitteeChanged() bool {
+	return len(e.Joined) > 0 || len(e.Left) > 0
+}
+
+type blockchainInfoSource interface {
+	GetBlockchainInfo() (*pactus.GetBlockchainInfoResponse, error)
+}
+
+// BlockWatcher polls the blockchain and emits the new blocks to the subscribers.
+// It starts polling on the first subscription and stops when the context is done.
+type BlockWatcher struct {
+	ctx      context.Context
+	source   blockchainInfoSource
+	interval time.Duration
+
+	lk        sync.Mutex
+	subs      []chan BlockEvent
+	startOnce sync.Once
+
+	lastHeight    uint32
+	lastCommittee []string
+}
+
+func NewBlockWatcher(ctx context.Context, source blockchainInfoSource, interval time.Duration) *BlockWatcher {
+	return &BlockWatcher{
+		ctx:      ctx,
+		source:   source,
+		interval: interval,
+	}
+}
+
+// Subscribe returns a channel of the new block events, it's closed when the watcher stops.
+// The events are dropped if the subscriber doesn't keep up with the buffer.
+func (w *BlockWatcher) Subscribe(buffer int) <-chan BlockEvent {
+	ch := make(chan BlockEvent, buffer)
+
+	w.lk.Lock()
+	if w.ctx.Err() != nil {
+		w.lk.Unlock()
+		close(ch)
+
+		return ch
+	}
+	w.subs = append(w.subs, ch)
+	w.lk.Unlock()
+
+	w.startOnce.Do(func() {
+		go w.run()
+	})
+
+	return ch
+}
+
+func (w *BlockWatcher) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.poll()
+	for {
+		select {
+		case <-w.ctx.Done():
+			w.closeSubs()
+
+			return
+
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// poll checks the last block, the first poll only sets the starting point, so nothing is emitted on start.
+func (w *BlockWatcher) poll() {
+	info, err := w.source.GetBlockchainInfo()
+	if err != nil {
+		log.Warn("unable to watch the new blocks", "err", err)
+
+		return
+	}
+
+	committee := make([]string, 0, len(info.CommitteeValidators))
+	for _, val := range info.CommitteeValidators {
+		committee = append(committee, val.Address)
+	}
+	slices.Sort(committee)
+
+	if w.lastHeight == 0 {
+		w.lastHeight = info.LastBlockHeight
+		w.lastCommittee = committee
+
+		return
+	}
+
+	if info.LastBlockHeight <= w.lastHeight {
+		return
+	}
+
+	event := BlockEvent{
+		FromHeight: w.lastHeight + 1,
+		Height:     info.LastBlockHeight,
+		Joined:     difference(committee, w.lastCommittee),
+		Left:       difference(w.lastCommittee, committee),
+	}
+	w.lastHeight = info.LastBlockHeight
+	w.lastCommittee = committee
+
+	w.emit(event)
+}
+
+func (w *BlockWatcher) emit(event BlockEvent) {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	for _, ch := range w.subs {
+		select {
+		case ch <- event:
+		default:
+			log.Warn("block event dropped, the subscriber is slow", "height", event.Height)
+		}
+	}
+}
+
+func (w *BlockWatcher) closeSubs() {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	for _, ch := range w.subs {
+		close(ch)
+	}
+	w.subs = nil
+}
+
+// difference returns the items of a that are not in b, both are sorted.
+func difference(a, b []string) []string {
+	diff := []string{}
+	for _, item := range a {
+		if _, found := slices.BinarySearch(b, item); !found {
+			diff = append(diff, item)
+		}
+	}
+
+	return diff
+}
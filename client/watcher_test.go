@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChainSource struct {
+	info *pactus.GetBlockchainInfoResponse
+	err  error
+}
+
+func (s *fakeChainSource) GetBlockchainInfo() (*pactus.GetBlockchainInfoResponse, error) {
+	return s.info, s.err
+}
+
+func chainInfo(height uint32, committee ...string) *pactus.GetBlockchainInfoResponse {
+	info := &pactus.GetBlockchainInfoResponse{LastBlockHeight: height}
+	for _, addr := range committee {
+		info.CommitteeValidators = append(info.CommitteeValidators, &pactus.ValidatorInfo{Address: addr})
+	}
+
+	return info
+}
+
+func TestBlockWatcherPoll(t *testing.T) {
+	source := &fakeChainSource{info: chainInfo(100, "val-a", "val-b")}
+	w := NewBlockWatcher(context.Background(), source, time.Hour)
+	ch := make(chan BlockEvent, 10)
+	w.subs = append(w.subs, ch)
+
+	t.Run("first poll sets the starting point", func(t *testing.T) {
+		w.poll()
+		assert.Empty(t, ch)
+	})
+
+	t.Run("no new block", func(t *testing.T) {
+		w.poll()
+		assert.Empty(t, ch)
+	})
+
+	t.Run("new blocks", func(t *testing.T) {
+		source.info = chainInfo(103, "val-a", "val-b")
+		w.poll()
+
+		require.Len(t, ch, 1)
+		event := <-ch
+		assert.Equal(t, uint32(101), event.FromHeight)
+		assert.Equal(t, uint32(103), event.Height)
+		assert.False(t, event.CommitteeChanged())
+	})
+
+	t.Run("committee changed", func(t *testing.T) {
+		source.info = chainInfo(104, "val-c", "val-a")
+		w.poll()
+
+		require.Len(t, ch, 1)
+		event := <-ch
+		assert.True(t, event.CommitteeChanged())
+		assert.Equal(t, []string{"val-c"}, event.Joined)
+		assert.Equal(t, []string{"val-b"}, event.Left)
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		source.err = errors.New("node is down")
+		w.poll()
+		assert.Empty(t, ch)
+	})
+}
+
+func TestBlockEventMilestone(t *testing.T) {
+	tests := []struct {
+		from, to  uint32
+		every     uint32
+		milestone uint32
+		ok        bool
+	}{
+		{from: 9_999, to: 10_001, every: 10_000, milestone: 10_000, ok: true},
+		{from: 10_000, to: 10_000, every: 10_000, milestone: 10_000, ok: true},
+		{from: 10_001, to: 19_999, every: 10_000, ok: false},
+		{from: 1, to: 9_999, every: 10_000, ok: false},
+		{from: 1, to: 100, every: 0, ok: false},
+	}
+
+	for _, tt := range tests {
+		milestone, ok := BlockEvent{FromHeight: tt.from, Height: tt.to}.Milestone(tt.every)
+		assert.Equal(t, tt.ok, ok, "from %d to %d", tt.from, tt.to)
+		assert.Equal(t, tt.milestone, milestone)
+	}
+}
+
+func TestBlockWatcherStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	source := &fakeChainSource{info: chainInfo(100)}
+	w := NewBlockWatcher(ctx, source, time.Millisecond)
+
+	ch := w.Subscribe(1)
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("the subscription is not closed")
+	}
+
+	_, ok := <-w.Subscribe(1)
+	assert.False(t, ok)
+}
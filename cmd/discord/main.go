@@ -13,6 +13,7 @@ func main() {
 	}
 
 	RunCommand(rootCmd)
+	SecretsCommand(rootCmd)
 
 	err := rootCmd.Execute()
 	if err != nil {
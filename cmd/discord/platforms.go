@@ -0,0 +1,79 @@
+package main
+
+import (
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/discord"
+	"github.com/kehiy/RoboPac/engine"
+	rphttp "github.com/kehiy/RoboPac/http"
+	"github.com/kehiy/RoboPac/matrix"
+	"github.com/kehiy/RoboPac/platform"
+	"github.com/kehiy/RoboPac/slack"
+	"github.com/kehiy/RoboPac/telegram"
+)
+
+// platforms returns the registry of the platforms that RoboPac runs on.
+func platforms() *platform.Registry {
+	reg := platform.NewRegistry()
+
+	reg.Register("discord",
+		func(be *engine.BotEngine, cfg *config.Config) (platform.Adapter, error) {
+			bot, err := discord.NewDiscordBot(be, cfg.DiscordBotCfg)
+			if err != nil {
+				return nil, err
+			}
+
+			return bot, nil
+		},
+		func(cfg *config.Config) bool { return cfg.DiscordBotCfg.DiscordToken != "" },
+	)
+
+	reg.Register("telegram",
+		func(be *engine.BotEngine, cfg *config.Config) (platform.Adapter, error) {
+			bot, err := telegram.NewTelegramBot(be, cfg.TelegramBotCfg)
+			if err != nil {
+				return nil, err
+			}
+
+			return bot, nil
+		},
+		func(cfg *config.Config) bool { return cfg.TelegramBotCfg.Token != "" },
+	)
+
+	reg.Register("matrix",
+		func(be *engine.BotEngine, cfg *config.Config) (platform.Adapter, error) {
+			bot, err := matrix.NewMatrixBot(be, cfg.MatrixBotCfg)
+			if err != nil {
+				return nil, err
+			}
+
+			return bot, nil
+		},
+		func(cfg *config.Config) bool { return cfg.MatrixBotCfg.AccessToken != "" },
+	)
+
+	reg.Register("slack",
+		func(be *engine.BotEngine, cfg *config.Config) (platform.Adapter, error) {
+			bot, err := slack.NewSlackBot(be, cfg.SlackBotCfg)
+			if err != nil {
+				return nil, err
+			}
+
+			return bot, nil
+		},
+		func(cfg *config.Config) bool { return cfg.SlackBotCfg.AppToken != "" },
+	)
+
+	reg.Register("http",
+		func(be *engine.BotEngine, cfg *config.Config) (platform.Adapter, error) {
+			srv, err := rphttp.NewServer(be, cfg.HTTPCfg)
+			if err != nil {
+				return nil, err
+			}
+
+			return srv, nil
+		},
+		func(cfg *config.Config) bool { return cfg.HTTPCfg.ListenAddr != "" },
+	)
+
+	return reg
+}
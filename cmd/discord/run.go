@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/kehiy/RoboPac/config"
-	"github.com/kehiy/RoboPac/discord"
 	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/lifecycle"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/kehiy/RoboPac/secrets"
+	"github.com/kehiy/RoboPac/ws"
 	"github.com/spf13/cobra"
 )
 
@@ -18,38 +23,86 @@ func RunCommand(parentCmd *cobra.Command) {
 	}
 	parentCmd.AddCommand(run)
 
+	configPath := run.Flags().StringP("config", "c", "", "the YAML or TOML config file, the .env file is used if it's not set")
+
 	run.Run = func(cmd *cobra.Command, _ []string) {
+		// the passphrase of the encrypted settings is asked on the terminal, if it's not in the environment.
+		config.SetSecretsUnlocker(secrets.EnvOrPrompt(os.Stdin, cmd.ErrOrStderr()))
+
 		// load configuration.
-		config, err := config.Load()
+		cfg, err := loadConfig(*configPath, false)
 		if err != nil {
 			kill(cmd, err)
 		}
 
 		// starting botEngine.
-		botEngine, err := engine.NewBotEngine(config)
+		botEngine, err := engine.NewBotEngine(cfg)
 		if err != nil {
 			kill(cmd, err)
 		}
 
+		botEngine.SetConfigLoader(func() (*config.Config, error) {
+			return loadConfig(*configPath, true)
+		})
 		botEngine.RegisterCommands()
-		botEngine.Start()
 
-		discordBot, err := discord.NewDiscordBot(botEngine, config.DiscordBotCfg.DiscordToken,
-			config.DiscordBotCfg.DiscordGuildID)
+		// the subsystems are stopped in the reverse order, so the engine is stopped after the front-ends.
+		group := lifecycle.NewGroup(cfg.ShutdownTimeout)
+		group.Add("engine", botEngine)
+
+		adapters, err := platforms().Build(botEngine, cfg)
 		if err != nil {
 			kill(cmd, err)
 		}
+		if len(adapters) == 0 {
+			log.Warn("no platform is enabled", "platforms", platforms().Names())
+		}
+		for _, adapter := range adapters {
+			group.Add(adapter.Name(), adapter)
+		}
+
+		if cfg.WSCfg.ListenAddr != "" {
+			srv, err := ws.NewServer(botEngine, cfg.WSCfg)
+			if err != nil {
+				kill(cmd, err)
+			}
+			group.Add("ws", srv)
+		}
 
-		if err = discordBot.Start(); err != nil {
+		if cfg.MetricsAddr != "" {
+			group.Add("metrics", metrics.NewServer(cfg.MetricsAddr, metrics.Default))
+		}
+
+		if err := group.Start(context.Background()); err != nil {
 			kill(cmd, err)
 		}
 
+		// SIGHUP reloads the config, the other signals stop the bot.
 		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
-		<-sigChan
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, os.Interrupt)
+		for sig := range sigChan {
+			if sig != syscall.SIGHUP {
+				break
+			}
+
+			if err := botEngine.ReloadConfig(); err != nil {
+				log.Error("unable to reload the config", "err", err)
+			}
+		}
 
 		// gracefully shutdown the bot.
-		discordBot.Stop()
-		botEngine.Stop()
+		group.Stop()
+	}
+}
+
+// loadConfig loads the config from the config file if it's set, otherwise from the .env file.
+func loadConfig(path string, reload bool) (*config.Config, error) {
+	switch {
+	case path != "":
+		return config.LoadFile(path)
+	case reload:
+		return config.Reload()
+	default:
+		return config.Load()
 	}
 }
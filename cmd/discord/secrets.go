@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kehiy/RoboPac/secrets"
+	"github.com/spf13/cobra"
+)
+
+func SecretsCommand(parentCmd *cobra.Command) {
+	secretsCmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Manages the encrypted secrets of the config, like the bot tokens and the wallet seed",
+	}
+	parentCmd.AddCommand(secretsCmd)
+
+	keygen := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generates a random key for " + secrets.KeyEnv,
+		Run: func(cmd *cobra.Command, _ []string) {
+			key, err := secrets.GenerateKey()
+			if err != nil {
+				kill(cmd, err)
+			}
+
+			cmd.Println(key)
+		},
+	}
+
+	encrypt := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypts a secret from the terminal or the standard input, to put it in the config",
+		Long: "Encrypts a secret by the key of " + secrets.KeyEnv + ", or by the passphrase of " +
+			secrets.PassphraseEnv + " or the terminal. The result is like \"" + secrets.Prefix + "...\".",
+		Run: func(cmd *cobra.Command, _ []string) {
+			key, err := encryptionKey(cmd)
+			if err != nil {
+				kill(cmd, err)
+			}
+
+			secret, err := secrets.ReadPassphrase(os.Stdin, cmd.ErrOrStderr(), "Secret: ")
+			if err != nil {
+				// the secret is piped, like "echo $TOKEN | robopac-discord secrets encrypt".
+				data, readErr := io.ReadAll(os.Stdin)
+				if readErr != nil {
+					kill(cmd, readErr)
+				}
+				secret = strings.TrimRight(string(data), "\r\n")
+			}
+			if secret == "" {
+				kill(cmd, errors.New("the secret is empty"))
+			}
+
+			encrypted, err := key.Encrypt(secret)
+			if err != nil {
+				kill(cmd, err)
+			}
+
+			cmd.Println(encrypted)
+		},
+	}
+
+	secretsCmd.AddCommand(keygen, encrypt)
+}
+
+// encryptionKey returns the key of the environment variables, or asks a new passphrase twice.
+func encryptionKey(cmd *cobra.Command) (*secrets.Key, error) {
+	key, err := secrets.KeyFromEnv()
+	if key != nil || err != nil {
+		return key, err
+	}
+
+	passphrase, err := secrets.ReadPassphrase(os.Stdin, cmd.ErrOrStderr(), "Passphrase: ")
+	if err != nil {
+		return nil, errors.Join(secrets.ErrLocked, err)
+	}
+
+	confirmed, err := secrets.ReadPassphrase(os.Stdin, cmd.ErrOrStderr(), "Confirm the passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if passphrase != confirmed {
+		return nil, errors.New("the passphrases don't match")
+	}
+
+	return secrets.NewPassphraseKey(passphrase)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/kehiy/RoboPac/cli"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/secrets"
+	"github.com/spf13/cobra"
+)
+
+const maxHistory = 1000
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:     "robopac-cli",
+		Short:   "Runs the bot engine locally in an interactive shell, to test the commands without the apps",
+		Version: "0.0.1", //! should come from version.go file.
+	}
+
+	configPath := rootCmd.Flags().StringP("config", "c", "", "the YAML or TOML config file, the .env file is used if it's not set")
+	callerID := rootCmd.Flags().String("caller", "cli", "the caller ID of the commands, like a Discord user ID")
+	historyPath := rootCmd.Flags().String("history", defaultHistoryPath(), "the command history file, the history is not kept if it's empty")
+
+	rootCmd.Run = func(cmd *cobra.Command, _ []string) {
+		config.SetSecretsUnlocker(secrets.EnvOrPrompt(os.Stdin, cmd.ErrOrStderr()))
+
+		cfg, err := loadConfig(*configPath)
+		if err != nil {
+			kill(cmd, err)
+		}
+
+		botEngine, err := engine.NewBotEngine(cfg)
+		if err != nil {
+			kill(cmd, err)
+		}
+		botEngine.RegisterCommands()
+
+		if err := botEngine.Start(context.Background()); err != nil {
+			kill(cmd, err)
+		}
+		defer botEngine.Stop()
+
+		history, err := cli.LoadHistory(*historyPath, maxHistory)
+		if err != nil {
+			kill(cmd, err)
+		}
+
+		repl := cli.New(botEngine, *callerID, history, cmd.OutOrStdout())
+		if err := repl.Run(os.Stdin); err != nil {
+			cmd.PrintErrln(err)
+		}
+	}
+
+	if err := rootCmd.Execute(); err != nil {
+		kill(rootCmd, err)
+	}
+}
+
+func loadConfig(path string) (*config.Config, error) {
+	if path != "" {
+		return config.LoadFile(path)
+	}
+
+	return config.Load()
+}
+
+func defaultHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".robopac_history")
+}
+
+func kill(cmd *cobra.Command, err error) {
+	cmd.PrintErrln(err.Error())
+	os.Exit(1)
+}
@@ -1,76 +1,700 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/notification"
 	"github.com/kehiy/RoboPac/nowpayments"
+	"github.com/kehiy/RoboPac/store"
 	"github.com/pactus-project/pactus/util"
 )
 
+const (
+	StatusModeCombined = "combined"
+	StatusModeCycle    = "cycle"
+
+	MarketSourceXeggex        = "xeggex"
+	MarketSourceCoinMarketCap = "coinmarketcap"
+)
+
 type Config struct {
-	Network           string
-	WalletAddress     string
-	WalletPath        string
-	WalletPassword    string
-	NetworkNodes      []string
-	LocalNode         string
-	StorePath         string
-	DataBasePath      string
+	Network         string
+	WalletAddress   string
+	WalletPath      string
+	WalletPassword  string
+	WalletSeed      string
+	NetworkNodes    []string
+	TestnetNodes    []string
+	LocalNode       string
+	NodeFailover    NodeFailoverConfig
+	NodeClient      NodeClientConfig
+	StorePath       string
+	StoreBackend    string
+	DataBasePath    string
+	MessagesPath    string
+	MetricsAddr     string
+	ShutdownTimeout time.Duration
+	Log             log.Config
+	// Platforms are the names of the enabled platforms, like discord and telegram.
+	// If it's empty, the platforms that have their settings, like the token, are enabled.
+	Platforms         []string
 	AuthIDs           []string
+	CircuitBreaker    CircuitBreakerConfig
+	InputLimits       InputLimitsConfig
+	CommandAccess     CommandAccessConfig
+	Moderation        ModerationConfig
+	Faucet            FaucetConfig
+	Tip               TipConfig
+	Referral          ReferralConfig
+	Monitor           MonitorConfig
+	Snapshot          SnapshotConfig
+	Status            StatusConfig
+	Notification      NotificationConfig
+	Market            MarketConfig
 	DiscordBotCfg     DiscordBotConfig
+	TelegramBotCfg    TelegramBotConfig
+	MatrixBotCfg      MatrixBotConfig
+	SlackBotCfg       SlackBotConfig
+	HTTPCfg           HTTPConfig
+	WSCfg             WSConfig
 	TwitterAPICfg     TwitterAPIConfig
 	NowPaymentsConfig nowpayments.Config
 }
 
+// NodeFailoverConfig holds how the calls are spread over the nodes and failed over when a node is down.
+type NodeFailoverConfig struct {
+	Selection           string
+	HealthCheckInterval time.Duration
+}
+
+// NodeClientConfig holds the timeouts, the retries and the circuit breaker of the calls to each node,
+// and the credentials of the remote nodes. The nodes with the grpcs:// scheme are connected over TLS.
+type NodeClientConfig struct {
+	CallTimeout      time.Duration
+	RetryAttempts    int
+	RetryBackoff     time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	// TLSCAFile, TLSCertFile and TLSKeyFile are the CA and the client certificate of the TLS connections.
+	TLSCAFile   string
+	TLSCertFile string
+	TLSKeyFile  string
+	// AuthTokens maps the endpoints (host:port) to the tokens that are sent to them.
+	AuthTokens map[string]string
+	// ReadOnly blocks the transactions of the nodes, for the bots that must never move funds.
+	ReadOnly bool
+}
+
+type CircuitBreakerConfig struct {
+	Threshold int
+	Cooldown  time.Duration
+}
+
+type InputLimitsConfig struct {
+	MaxArgs      int
+	MaxArgLength int
+}
+
+// CommandAccessConfig holds the per-app allow and deny lists of the commands, keyed by the app name.
+type CommandAccessConfig struct {
+	Allow map[string][]string
+	Deny  map[string][]string
+}
+
+// FaucetConfig holds the testnet faucet settings, the faucet is disabled if the amount is zero.
+type FaucetConfig struct {
+	// Amount is the amount of each claim in nanoPAC.
+	Amount          int64
+	UserCooldown    time.Duration
+	AddressCooldown time.Duration
+}
+
+// TipConfig holds the settings of the tips between the users, the tips are disabled if the daily limit is zero.
+type TipConfig struct {
+	// DailyLimit is the total amount in nanoPAC that each user can tip in a day.
+	DailyLimit int64
+}
+
+// ReferralConfig holds the settings of the referral program, it's disabled if the reward is zero.
+type ReferralConfig struct {
+	// Reward is the amount in nanoPAC that the referrers are rewarded for each referred user.
+	Reward int64
+	// PayoutSchedule is the cron spec of the payouts of the rewards, like "@daily".
+	PayoutSchedule string
+	// MinBalance is the balance in nanoPAC that the address of a referred user must have on the chain.
+	MinBalance int64
+}
+
+// NotificationConfig holds the webhooks that the operators are notified by,
+// of the events like the node failovers and the low balance of the wallet.
+type NotificationConfig struct {
+	Webhooks []WebhookConfig
+	// LowBalance is the wallet balance in nanoPAC that the operators are notified below, zero disables it.
+	LowBalance int64
+}
+
+// WebhookConfig is a webhook and its payload format, see notification.NewWebhook.
+type WebhookConfig struct {
+	Format string
+	URL    string
+}
+
+// ModerationConfig holds the anti-abuse settings, the users that send more than BurstLimit commands
+// in BurstWindow are throttled for Throttle. The throttling is disabled if the limit is zero.
+type ModerationConfig struct {
+	BurstLimit  int
+	BurstWindow time.Duration
+	Throttle    time.Duration
+}
+
+// MonitorConfig holds the validator monitor settings, the monitor is disabled if the interval is zero.
+type MonitorConfig struct {
+	Interval       time.Duration
+	AlertThreshold float64
+}
+
+// SnapshotConfig holds the settings of the network metrics snapshots, they are disabled if the interval is zero.
+type SnapshotConfig struct {
+	Interval time.Duration
+}
+
+// StatusConfig holds the templates of the bot status, like "height: {{.Height}}".
+// The apps rotate the entries, see engine.StatusData for the fields.
+type StatusConfig struct {
+	Entries []string
+}
+
+// MarketConfig holds the market data settings, the price command is not available without any source.
+type MarketConfig struct {
+	// Sources are asked in order, until one of them responds.
+	Sources          []string
+	CoinMarketCapKey string
+	CacheTTL         time.Duration
+}
+
 type TwitterAPIConfig struct {
 	BearerToken string
 	TwitterID   string
 }
 
+// TelegramBotConfig holds the Telegram bot settings, the bot is not started if the token is empty.
+type TelegramBotConfig struct {
+	Token string
+}
+
+// MatrixBotConfig holds the Matrix bot settings, the bot is not started if the access token is empty.
+type MatrixBotConfig struct {
+	HomeserverURL string
+	UserID        string
+	AccessToken   string
+	// Rooms are the IDs or the aliases of the rooms that the bot joins and listens to.
+	Rooms []string
+}
+
+// SlackBotConfig holds the Slack bot settings, the bot is not started if the app token is empty.
+type SlackBotConfig struct {
+	// AppToken is the app-level token with the connections:write scope, like "xapp-...".
+	// The bot connects by the Socket Mode, and it's called by the /robopac slash command.
+	AppToken string
+}
+
+// HTTPConfig holds the REST gateway settings, the gateway is not started if the address is empty.
+type HTTPConfig struct {
+	ListenAddr string
+	// APIKeys maps the API keys to the names of their clients.
+	APIKeys map[string]string
+}
+
+// WSConfig holds the WebSocket event stream settings, the server is not started if the address is empty.
+type WSConfig struct {
+	ListenAddr string
+	// Tokens maps the tokens to the names of their consumers, like the API keys of the HTTP gateway.
+	Tokens map[string]string
+}
+
 type DiscordBotConfig struct {
-	DiscordToken   string
-	DiscordGuildID string
+	DiscordToken             string
+	DiscordGuildID           string
+	DiscordAnnounceChannelID string
+	CommandCooldown          time.Duration
+	TrustedRoleIDs           []string
+	TrustedUserIDs           []string
+	AdminRoleIDs             []string
+	StatusMode               string
+	StatusInterval           time.Duration
+	SummaryChannelID         string
+	SummarySchedule          string
+	SummaryEdit              bool
+	BlocksChannelID          string
+	BlockMilestone           uint32
+	AnnounceCommittee        bool
 }
 
+// Load loads the config from the environment variables, after loading the given env files.
+// Without any file, the .env file of the working directory is loaded.
 func Load(filePaths ...string) (*Config, error) {
 	err := godotenv.Load(filePaths...)
 	if err != nil {
 		return nil, err
 	}
 
+	return parse(&source{})
+}
+
+// Reload loads the config like Load, to reload it at runtime. The values of the env files override
+// the environment, since Load has already exported the previous values of the files.
+func Reload(filePaths ...string) (*Config, error) {
+	err := godotenv.Overload(filePaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(&source{})
+}
+
+// parse parses and checks the config values of the source.
+func parse(src *source) (*Config, error) {
+	var err error
+
 	// Fetch config values from environment variables.
 	cfg := &Config{
-		Network:        os.Getenv("NETWORK"),
-		WalletAddress:  os.Getenv("WALLET_ADDRESS"),
-		WalletPath:     os.Getenv("WALLET_PATH"),
-		WalletPassword: os.Getenv("WALLET_PASSWORD"),
-		LocalNode:      os.Getenv("LOCAL_NODE"),
-		NetworkNodes:   strings.Split(os.Getenv("NETWORK_NODES"), ","),
-		StorePath:      os.Getenv("STORE_PATH"),
-		DataBasePath:   os.Getenv("DATABASE_PATH"),
-		AuthIDs:        strings.Split(os.Getenv("AUTHORIZED_DISCORD_IDS"), ","),
+		Network:        src.get("NETWORK"),
+		WalletAddress:  src.get("WALLET_ADDRESS"),
+		WalletPath:     src.get("WALLET_PATH"),
+		WalletPassword: src.get("WALLET_PASSWORD"),
+		WalletSeed:     src.get("WALLET_SEED"),
+		LocalNode:      src.get("LOCAL_NODE"),
+		NetworkNodes:   strings.Split(src.get("NETWORK_NODES"), ","),
+		TestnetNodes:   splitList(src.get("TESTNET_NODES")),
+		StorePath:      src.get("STORE_PATH"),
+		StoreBackend:   src.get("STORE_BACKEND"),
+		DataBasePath:   src.get("DATABASE_PATH"),
+		MessagesPath:   src.get("MESSAGES_PATH"),
+		MetricsAddr:    src.get("METRICS_LISTEN_ADDR"),
+		Platforms:      splitList(src.get("PLATFORMS")),
+		AuthIDs:        strings.Split(src.get("AUTHORIZED_DISCORD_IDS"), ","),
 		DiscordBotCfg: DiscordBotConfig{
-			DiscordToken:   os.Getenv("DISCORD_TOKEN"),
-			DiscordGuildID: os.Getenv("DISCORD_GUILD_ID"),
+			DiscordToken:             src.get("DISCORD_TOKEN"),
+			DiscordGuildID:           src.get("DISCORD_GUILD_ID"),
+			DiscordAnnounceChannelID: src.get("DISCORD_ANNOUNCE_CHANNEL_ID"),
+			TrustedRoleIDs:           splitList(src.get("DISCORD_TRUSTED_ROLE_IDS")),
+			TrustedUserIDs:           splitList(src.get("DISCORD_TRUSTED_USER_IDS")),
+			AdminRoleIDs:             splitList(src.get("DISCORD_ADMIN_ROLE_IDS")),
+			StatusMode:               src.get("DISCORD_STATUS_MODE"),
+			SummaryChannelID:         src.get("DISCORD_SUMMARY_CHANNEL_ID"),
+			SummarySchedule:          src.get("DISCORD_SUMMARY_SCHEDULE"),
+			BlocksChannelID:          src.get("DISCORD_BLOCKS_CHANNEL_ID"),
+		},
+		TelegramBotCfg: TelegramBotConfig{
+			Token: src.get("TELEGRAM_TOKEN"),
+		},
+		MatrixBotCfg: MatrixBotConfig{
+			HomeserverURL: src.get("MATRIX_HOMESERVER_URL"),
+			UserID:        src.get("MATRIX_USER_ID"),
+			AccessToken:   src.get("MATRIX_ACCESS_TOKEN"),
+			Rooms:         splitList(src.get("MATRIX_ROOMS")),
+		},
+		SlackBotCfg: SlackBotConfig{
+			AppToken: src.get("SLACK_APP_TOKEN"),
+		},
+		HTTPCfg: HTTPConfig{
+			ListenAddr: src.get("HTTP_LISTEN_ADDR"),
+		},
+		WSCfg: WSConfig{
+			ListenAddr: src.get("WS_LISTEN_ADDR"),
 		},
 		TwitterAPICfg: TwitterAPIConfig{
-			BearerToken: os.Getenv("TWITTER_BEARER_TOKEN"),
-			TwitterID:   os.Getenv("TWITTER_ID"),
+			BearerToken: src.get("TWITTER_BEARER_TOKEN"),
+			TwitterID:   src.get("TWITTER_ID"),
 		},
 		NowPaymentsConfig: nowpayments.Config{
-			ListenPort: os.Getenv("NOWPAYMENTS_LISTEN_PORT"),
-			Webhook:    os.Getenv("NOWPAYMENTS_WEBHOOK"),
-			APIToken:   os.Getenv("NOWPAYMENTS_API_KEY"),
-			APIUrl:     os.Getenv("NOWPAYMENTS_API_URL"),
-			IPNSecret:  os.Getenv("NOWPAYMENTS_IPN_SECRET"),
-			Username:   os.Getenv("NOWPAYMENTS_USERNAME"),
-			Password:   os.Getenv("NOWPAYMENTS_PASSWORD"),
+			ListenPort: src.get("NOWPAYMENTS_LISTEN_PORT"),
+			Webhook:    src.get("NOWPAYMENTS_WEBHOOK"),
+			APIToken:   src.get("NOWPAYMENTS_API_KEY"),
+			APIUrl:     src.get("NOWPAYMENTS_API_URL"),
+			IPNSecret:  src.get("NOWPAYMENTS_IPN_SECRET"),
+			Username:   src.get("NOWPAYMENTS_USERNAME"),
+			Password:   src.get("NOWPAYMENTS_PASSWORD"),
 		},
 	}
 
+	if cooldown := src.get("DISCORD_COMMAND_COOLDOWN"); cooldown != "" {
+		cfg.DiscordBotCfg.CommandCooldown, err = time.ParseDuration(cooldown)
+		if err != nil {
+			return nil, fmt.Errorf("DISCORD_COMMAND_COOLDOWN is invalid: %w", err)
+		}
+	}
+
+	switch cfg.DiscordBotCfg.StatusMode {
+	case "":
+		cfg.DiscordBotCfg.StatusMode = StatusModeCombined
+	case StatusModeCombined, StatusModeCycle:
+	default:
+		return nil, fmt.Errorf("DISCORD_STATUS_MODE is invalid: %s", cfg.DiscordBotCfg.StatusMode)
+	}
+
+	cfg.DiscordBotCfg.StatusInterval = time.Minute
+	if interval := src.get("DISCORD_STATUS_INTERVAL"); interval != "" {
+		cfg.DiscordBotCfg.StatusInterval, err = time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("DISCORD_STATUS_INTERVAL is invalid: %w", err)
+		}
+	}
+
+	if edit := src.get("DISCORD_SUMMARY_EDIT"); edit != "" {
+		cfg.DiscordBotCfg.SummaryEdit, err = strconv.ParseBool(edit)
+		if err != nil {
+			return nil, fmt.Errorf("DISCORD_SUMMARY_EDIT is invalid: %w", err)
+		}
+	}
+
+	// A zero milestone disables the milestone announcements.
+	cfg.DiscordBotCfg.BlockMilestone = 10_000
+	if milestone := src.get("DISCORD_BLOCK_MILESTONE"); milestone != "" {
+		every, err := strconv.ParseUint(milestone, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("DISCORD_BLOCK_MILESTONE is invalid: %w", err)
+		}
+		cfg.DiscordBotCfg.BlockMilestone = uint32(every)
+	}
+
+	if announce := src.get("DISCORD_ANNOUNCE_COMMITTEE"); announce != "" {
+		cfg.DiscordBotCfg.AnnounceCommittee, err = strconv.ParseBool(announce)
+		if err != nil {
+			return nil, fmt.Errorf("DISCORD_ANNOUNCE_COMMITTEE is invalid: %w", err)
+		}
+	}
+
+	cfg.NodeFailover.Selection = src.get("NODE_SELECTION")
+	if _, err := client.ParseSelectionPolicy(cfg.NodeFailover.Selection); err != nil {
+		return nil, fmt.Errorf("NODE_SELECTION is invalid: %w", err)
+	}
+
+	// A non-positive interval disables the health checks.
+	cfg.NodeFailover.HealthCheckInterval = 30 * time.Second
+	if interval := src.get("NODE_HEALTH_CHECK_INTERVAL"); interval != "" {
+		cfg.NodeFailover.HealthCheckInterval, err = time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("NODE_HEALTH_CHECK_INTERVAL is invalid: %w", err)
+		}
+	}
+
+	// A non-positive timeout doesn't bound the calls.
+	cfg.NodeClient.CallTimeout = 10 * time.Second
+	if timeout := src.get("NODE_CALL_TIMEOUT"); timeout != "" {
+		cfg.NodeClient.CallTimeout, err = time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("NODE_CALL_TIMEOUT is invalid: %w", err)
+		}
+	}
+
+	// The attempts include the first call, a value less than 2 disables the retries.
+	cfg.NodeClient.RetryAttempts = 3
+	if attempts := src.get("NODE_RETRY_ATTEMPTS"); attempts != "" {
+		cfg.NodeClient.RetryAttempts, err = strconv.Atoi(attempts)
+		if err != nil {
+			return nil, fmt.Errorf("NODE_RETRY_ATTEMPTS is invalid: %w", err)
+		}
+	}
+
+	cfg.NodeClient.RetryBackoff = 200 * time.Millisecond
+	if backoff := src.get("NODE_RETRY_BACKOFF"); backoff != "" {
+		cfg.NodeClient.RetryBackoff, err = time.ParseDuration(backoff)
+		if err != nil {
+			return nil, fmt.Errorf("NODE_RETRY_BACKOFF is invalid: %w", err)
+		}
+	}
+
+	// A non-positive threshold disables the circuit breaker of the nodes.
+	cfg.NodeClient.BreakerThreshold = 5
+	if threshold := src.get("NODE_BREAKER_THRESHOLD"); threshold != "" {
+		cfg.NodeClient.BreakerThreshold, err = strconv.Atoi(threshold)
+		if err != nil {
+			return nil, fmt.Errorf("NODE_BREAKER_THRESHOLD is invalid: %w", err)
+		}
+	}
+
+	cfg.NodeClient.TLSCAFile = src.get("NODE_TLS_CA_FILE")
+	cfg.NodeClient.TLSCertFile = src.get("NODE_TLS_CERT_FILE")
+	cfg.NodeClient.TLSKeyFile = src.get("NODE_TLS_KEY_FILE")
+
+	// The tokens are like "node1.example.com:443=token1;node2.example.com:443=token2".
+	cfg.NodeClient.AuthTokens, err = parseAuthTokens(src.get("NODE_AUTH_TOKENS"))
+	if err != nil {
+		return nil, fmt.Errorf("NODE_AUTH_TOKENS is invalid: %w", err)
+	}
+
+	cfg.NodeClient.BreakerCooldown = 30 * time.Second
+	if cooldown := src.get("NODE_BREAKER_COOLDOWN"); cooldown != "" {
+		cfg.NodeClient.BreakerCooldown, err = time.ParseDuration(cooldown)
+		if err != nil {
+			return nil, fmt.Errorf("NODE_BREAKER_COOLDOWN is invalid: %w", err)
+		}
+	}
+
+	if readOnly := src.get("NODE_READ_ONLY"); readOnly != "" {
+		cfg.NodeClient.ReadOnly, err = strconv.ParseBool(readOnly)
+		if err != nil {
+			return nil, fmt.Errorf("NODE_READ_ONLY is invalid: %w", err)
+		}
+	}
+
+	// ShutdownTimeout bounds how long the bot waits for the subsystems to stop.
+	cfg.ShutdownTimeout = 10 * time.Second
+	if timeout := src.get("SHUTDOWN_TIMEOUT"); timeout != "" {
+		cfg.ShutdownTimeout, err = time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("SHUTDOWN_TIMEOUT is invalid: %w", err)
+		}
+	}
+
+	// A non-positive threshold disables the circuit breaker.
+	cfg.CircuitBreaker.Threshold = 5
+	if threshold := src.get("CIRCUIT_BREAKER_THRESHOLD"); threshold != "" {
+		cfg.CircuitBreaker.Threshold, err = strconv.Atoi(threshold)
+		if err != nil {
+			return nil, fmt.Errorf("CIRCUIT_BREAKER_THRESHOLD is invalid: %w", err)
+		}
+	}
+
+	cfg.CircuitBreaker.Cooldown = 30 * time.Second
+	if cooldown := src.get("CIRCUIT_BREAKER_COOLDOWN"); cooldown != "" {
+		cfg.CircuitBreaker.Cooldown, err = time.ParseDuration(cooldown)
+		if err != nil {
+			return nil, fmt.Errorf("CIRCUIT_BREAKER_COOLDOWN is invalid: %w", err)
+		}
+	}
+
+	cfg.InputLimits = InputLimitsConfig{MaxArgs: 10, MaxArgLength: 1024}
+	if maxArgs := src.get("INPUT_MAX_ARGS"); maxArgs != "" {
+		cfg.InputLimits.MaxArgs, err = strconv.Atoi(maxArgs)
+		if err != nil {
+			return nil, fmt.Errorf("INPUT_MAX_ARGS is invalid: %w", err)
+		}
+	}
+
+	if maxArgLength := src.get("INPUT_MAX_ARG_LENGTH"); maxArgLength != "" {
+		cfg.InputLimits.MaxArgLength, err = strconv.Atoi(maxArgLength)
+		if err != nil {
+			return nil, fmt.Errorf("INPUT_MAX_ARG_LENGTH is invalid: %w", err)
+		}
+	}
+
+	// The lists are like "discord:claim,booster-claim;cli:help".
+	cfg.CommandAccess.Allow, err = parseAppLists(src.get("COMMANDS_ALLOW"))
+	if err != nil {
+		return nil, fmt.Errorf("COMMANDS_ALLOW is invalid: %w", err)
+	}
+
+	cfg.CommandAccess.Deny, err = parseAppLists(src.get("COMMANDS_DENY"))
+	if err != nil {
+		return nil, fmt.Errorf("COMMANDS_DENY is invalid: %w", err)
+	}
+
+	// The keys are like "web:key1,scripts:key2".
+	cfg.HTTPCfg.APIKeys, err = parseAPIKeys(src.get("HTTP_API_KEYS"))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP_API_KEYS is invalid: %w", err)
+	}
+
+	// The tokens are like "dashboard:token1,website:token2".
+	cfg.WSCfg.Tokens, err = parseAPIKeys(src.get("WS_TOKENS"))
+	if err != nil {
+		return nil, fmt.Errorf("WS_TOKENS is invalid: %w", err)
+	}
+
+	if amount := src.get("FAUCET_AMOUNT"); amount != "" {
+		cfg.Faucet.Amount, err = util.StringToChange(amount)
+		if err != nil {
+			return nil, fmt.Errorf("FAUCET_AMOUNT is invalid: %w", err)
+		}
+	}
+
+	cfg.Faucet.UserCooldown = 24 * time.Hour
+	if cooldown := src.get("FAUCET_USER_COOLDOWN"); cooldown != "" {
+		cfg.Faucet.UserCooldown, err = time.ParseDuration(cooldown)
+		if err != nil {
+			return nil, fmt.Errorf("FAUCET_USER_COOLDOWN is invalid: %w", err)
+		}
+	}
+
+	cfg.Faucet.AddressCooldown = 24 * time.Hour
+	if cooldown := src.get("FAUCET_ADDRESS_COOLDOWN"); cooldown != "" {
+		cfg.Faucet.AddressCooldown, err = time.ParseDuration(cooldown)
+		if err != nil {
+			return nil, fmt.Errorf("FAUCET_ADDRESS_COOLDOWN is invalid: %w", err)
+		}
+	}
+
+	if limit := src.get("TIP_DAILY_LIMIT"); limit != "" {
+		cfg.Tip.DailyLimit, err = util.StringToChange(limit)
+		if err != nil {
+			return nil, fmt.Errorf("TIP_DAILY_LIMIT is invalid: %w", err)
+		}
+	}
+
+	if reward := src.get("REFERRAL_REWARD"); reward != "" {
+		cfg.Referral.Reward, err = util.StringToChange(reward)
+		if err != nil {
+			return nil, fmt.Errorf("REFERRAL_REWARD is invalid: %w", err)
+		}
+	}
+
+	cfg.Referral.PayoutSchedule = "@daily"
+	if schedule := src.get("REFERRAL_PAYOUT_SCHEDULE"); schedule != "" {
+		cfg.Referral.PayoutSchedule = schedule
+	}
+
+	cfg.Referral.MinBalance = 1e9
+	if minBalance := src.get("REFERRAL_MIN_BALANCE"); minBalance != "" {
+		cfg.Referral.MinBalance, err = util.StringToChange(minBalance)
+		if err != nil {
+			return nil, fmt.Errorf("REFERRAL_MIN_BALANCE is invalid: %w", err)
+		}
+	}
+
+	cfg.Log = *log.DefaultConfig()
+	if format := src.get("LOG_FORMAT"); format != "" {
+		cfg.Log.Format = format
+	}
+
+	// The levels are like "info,engine:debug", the level without a module is the default level.
+	if levels := src.get("LOG_LEVELS"); levels != "" {
+		cfg.Log.Levels, err = parseLogLevels(levels)
+		if err != nil {
+			return nil, fmt.Errorf("LOG_LEVELS is invalid: %w", err)
+		}
+	}
+
+	if file := src.get("LOG_FILE"); file != "" {
+		cfg.Log.File = file
+	}
+	for name, value := range map[string]*int{
+		"LOG_MAX_SIZE":    &cfg.Log.MaxSize,
+		"LOG_MAX_BACKUPS": &cfg.Log.MaxBackups,
+		"LOG_MAX_AGE":     &cfg.Log.MaxAge,
+	} {
+		if setting := src.get(name); setting != "" {
+			*value, err = strconv.Atoi(setting)
+			if err != nil {
+				return nil, fmt.Errorf("%s is invalid: %w", name, err)
+			}
+		}
+	}
+
+	if compress := src.get("LOG_COMPRESS"); compress != "" {
+		cfg.Log.Compress, err = strconv.ParseBool(compress)
+		if err != nil {
+			return nil, fmt.Errorf("LOG_COMPRESS is invalid: %w", err)
+		}
+	}
+
+	cfg.Monitor.Interval = 10 * time.Minute
+	if interval := src.get("MONITOR_INTERVAL"); interval != "" {
+		cfg.Monitor.Interval, err = time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("MONITOR_INTERVAL is invalid: %w", err)
+		}
+	}
+
+	cfg.Monitor.AlertThreshold = 0.9
+	if threshold := src.get("MONITOR_ALERT_THRESHOLD"); threshold != "" {
+		cfg.Monitor.AlertThreshold, err = strconv.ParseFloat(threshold, 64)
+		if err != nil {
+			return nil, fmt.Errorf("MONITOR_ALERT_THRESHOLD is invalid: %w", err)
+		}
+	}
+
+	cfg.Snapshot.Interval = 10 * time.Minute
+	if interval := src.get("SNAPSHOT_INTERVAL"); interval != "" {
+		cfg.Snapshot.Interval, err = time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("SNAPSHOT_INTERVAL is invalid: %w", err)
+		}
+	}
+
+	cfg.Moderation.BurstLimit = 20
+	if limit := src.get("MODERATION_BURST_LIMIT"); limit != "" {
+		cfg.Moderation.BurstLimit, err = strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("MODERATION_BURST_LIMIT is invalid: %w", err)
+		}
+	}
+
+	cfg.Moderation.BurstWindow = time.Minute
+	if window := src.get("MODERATION_BURST_WINDOW"); window != "" {
+		cfg.Moderation.BurstWindow, err = time.ParseDuration(window)
+		if err != nil {
+			return nil, fmt.Errorf("MODERATION_BURST_WINDOW is invalid: %w", err)
+		}
+	}
+
+	cfg.Moderation.Throttle = 10 * time.Minute
+	if throttle := src.get("MODERATION_THROTTLE"); throttle != "" {
+		cfg.Moderation.Throttle, err = time.ParseDuration(throttle)
+		if err != nil {
+			return nil, fmt.Errorf("MODERATION_THROTTLE is invalid: %w", err)
+		}
+	}
+
+	// The entries are separated by semicolons, like "height: {{.Height}};supply: {{.CircSupply}} PAC".
+	for _, entry := range strings.Split(src.get("STATUS_ENTRIES"), ";") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			cfg.Status.Entries = append(cfg.Status.Entries, entry)
+		}
+	}
+
+	// The webhooks are like "slack=https://hooks.slack.com/...;json=https://example.com/hook".
+	cfg.Notification.Webhooks, err = parseWebhooks(src.get("NOTIFY_WEBHOOKS"))
+	if err != nil {
+		return nil, fmt.Errorf("NOTIFY_WEBHOOKS is invalid: %w", err)
+	}
+
+	if balance := src.get("NOTIFY_LOW_BALANCE"); balance != "" {
+		cfg.Notification.LowBalance, err = util.StringToChange(balance)
+		if err != nil {
+			return nil, fmt.Errorf("NOTIFY_LOW_BALANCE is invalid: %w", err)
+		}
+	}
+
+	cfg.Market.Sources = splitList(src.get("MARKET_SOURCES"))
+	cfg.Market.CoinMarketCapKey = src.get("MARKET_COINMARKETCAP_API_KEY")
+	for _, source := range cfg.Market.Sources {
+		switch source {
+		case MarketSourceXeggex:
+		case MarketSourceCoinMarketCap:
+			if cfg.Market.CoinMarketCapKey == "" {
+				return nil, errors.New("MARKET_COINMARKETCAP_API_KEY is not set")
+			}
+		default:
+			return nil, fmt.Errorf("MARKET_SOURCES is invalid, unknown source: %s", source)
+		}
+	}
+
+	cfg.Market.CacheTTL = time.Minute
+	if ttl := src.get("MARKET_CACHE_TTL"); ttl != "" {
+		cfg.Market.CacheTTL, err = time.ParseDuration(ttl)
+		if err != nil {
+			return nil, fmt.Errorf("MARKET_CACHE_TTL is invalid: %w", err)
+		}
+	}
+
+	if err := errors.Join(src.errs...); err != nil {
+		return nil, err
+	}
+
+	if err := src.checkUnknown(); err != nil {
+		return nil, err
+	}
+
 	// Check if the required configurations are set.
 	if err := cfg.BasicCheck(); err != nil {
 		return nil, err
@@ -79,23 +703,94 @@ func Load(filePaths ...string) (*Config, error) {
 	return cfg, nil
 }
 
-// Validate checks for the presence of required environment variables.
+// BasicCheck checks that the required configurations are set and valid.
+// All the problems are reported together, so they can be fixed at once.
 func (cfg *Config) BasicCheck() error {
-	if cfg.WalletAddress == "" {
-		return fmt.Errorf("WALLET_ADDRESS is not set")
-	}
+	errs := []error{}
+
+	// The wallet is created from the seed if it doesn't exist, then its first address is used.
+	if cfg.WalletSeed == "" {
+		if cfg.WalletAddress == "" {
+			errs = append(errs, fmt.Errorf("WALLET_ADDRESS is not set"))
+		}
 
-	// Check if the WalletPath exists.
-	if !util.PathExists(cfg.WalletPath) {
-		return fmt.Errorf("WALLET_PATH does not exist")
+		// Check if the WalletPath exists.
+		if !util.PathExists(cfg.WalletPath) {
+			errs = append(errs, fmt.Errorf("WALLET_PATH does not exist"))
+		}
+	} else if cfg.WalletPath == "" {
+		errs = append(errs, fmt.Errorf("WALLET_PATH is not set"))
 	}
 
 	if len(cfg.NetworkNodes) == 0 {
-		return fmt.Errorf("RPCNODES is not set or incorrect")
+		errs = append(errs, fmt.Errorf("NETWORK_NODES is not set or incorrect"))
+	}
+
+	// The testnet nodes are the second network of a mainnet bot.
+	if len(cfg.TestnetNodes) > 0 && client.NetworkOf(cfg.Network) != client.NetworkMainnet {
+		errs = append(errs, fmt.Errorf("TESTNET_NODES can't be set on the %s", client.NetworkOf(cfg.Network)))
 	}
 
 	if cfg.StorePath == "" {
-		return fmt.Errorf("STORE_PATH is not set or incorrect")
+		errs = append(errs, fmt.Errorf("STORE_PATH is not set or incorrect"))
+	}
+
+	switch cfg.StoreBackend {
+	case "", store.BackendJSON, store.BackendSQLite:
+	default:
+		errs = append(errs, fmt.Errorf("STORE_BACKEND is invalid: %s", cfg.StoreBackend))
+	}
+
+	if (cfg.NodeClient.TLSCertFile == "") != (cfg.NodeClient.TLSKeyFile == "") {
+		errs = append(errs, fmt.Errorf("NODE_TLS_CERT_FILE and NODE_TLS_KEY_FILE should be set together"))
+	}
+
+	if cfg.Faucet.Amount < 0 {
+		errs = append(errs, fmt.Errorf("FAUCET_AMOUNT can't be negative"))
+	}
+
+	if cfg.Tip.DailyLimit < 0 {
+		errs = append(errs, fmt.Errorf("TIP_DAILY_LIMIT can't be negative"))
+	}
+
+	if cfg.Referral.Reward < 0 {
+		errs = append(errs, fmt.Errorf("REFERRAL_REWARD can't be negative"))
+	}
+
+	// The referred users must be active on the chain, so the referrals are not farmed by the empty addresses.
+	if cfg.Referral.Reward > 0 && cfg.Referral.MinBalance <= 0 {
+		errs = append(errs, fmt.Errorf("REFERRAL_MIN_BALANCE must be positive"))
+	}
+
+	if err := cfg.Log.BasicCheck(); err != nil {
+		errs = append(errs, fmt.Errorf("the log settings are invalid: %w", err))
+	}
+
+	// The faucet gives away the coins of the wallet, it's for the test networks only.
+	// The empty and the unknown networks are the mainnet.
+	if network := client.NetworkOf(cfg.Network); cfg.Faucet.Amount > 0 &&
+		network != client.NetworkTestnet && network != client.NetworkLocalnet {
+		errs = append(errs, fmt.Errorf("the faucet can only be enabled on the testnet or the localnet"))
+	}
+
+	if cfg.MatrixBotCfg.AccessToken != "" {
+		if cfg.MatrixBotCfg.HomeserverURL == "" {
+			errs = append(errs, fmt.Errorf("MATRIX_HOMESERVER_URL is not set"))
+		}
+		if cfg.MatrixBotCfg.UserID == "" {
+			errs = append(errs, fmt.Errorf("MATRIX_USER_ID is not set"))
+		}
+		if len(cfg.MatrixBotCfg.Rooms) == 0 {
+			errs = append(errs, fmt.Errorf("MATRIX_ROOMS is not set"))
+		}
+	}
+
+	if cfg.HTTPCfg.ListenAddr != "" && len(cfg.HTTPCfg.APIKeys) == 0 {
+		errs = append(errs, fmt.Errorf("HTTP_API_KEYS is not set"))
+	}
+
+	if cfg.WSCfg.ListenAddr != "" && len(cfg.WSCfg.Tokens) == 0 {
+		errs = append(errs, fmt.Errorf("WS_TOKENS is not set"))
 	}
 
 	// if cfg.DiscordBotCfg.DiscordToken == "" {
@@ -111,5 +806,132 @@ func (cfg *Config) BasicCheck() error {
 	// 	return fmt.Errorf("DISCORD_GUILD_ID is not set or incorrect")
 	// }
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// splitList splits a comma separated list and drops the empty items.
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// parseAppLists parses the per-app lists like "discord:a,b;cli:c".
+func parseAppLists(lists string) (map[string][]string, error) {
+	appLists := map[string][]string{}
+	for _, list := range strings.Split(lists, ";") {
+		list = strings.TrimSpace(list)
+		if list == "" {
+			continue
+		}
+
+		app, items, ok := strings.Cut(list, ":")
+		app = strings.TrimSpace(app)
+		if !ok || app == "" {
+			return nil, fmt.Errorf("the app of the list is missing: %s", list)
+		}
+
+		appLists[app] = append(appLists[app], splitList(items)...)
+	}
+
+	return appLists, nil
+}
+
+// parseAPIKeys parses the API keys like "web:key1,scripts:key2" and maps the keys to the client names.
+func parseAPIKeys(list string) (map[string]string, error) {
+	keys := map[string]string{}
+	for _, item := range splitList(list) {
+		name, key, ok := strings.Cut(item, ":")
+		name = strings.TrimSpace(name)
+		key = strings.TrimSpace(key)
+		if !ok || name == "" || key == "" {
+			return nil, errors.New("the keys must be like name:key")
+		}
+
+		if _, exists := keys[key]; exists {
+			return nil, fmt.Errorf("the key of %s is used twice", name)
+		}
+		keys[key] = name
+	}
+
+	return keys, nil
+}
+
+// parseLogLevels parses the levels like "info,engine:debug" and maps the modules to them.
+func parseLogLevels(list string) (map[string]string, error) {
+	levels := map[string]string{}
+	for _, item := range splitList(list) {
+		module, level, ok := strings.Cut(item, ":")
+		if !ok {
+			module, level = log.DefaultModule, item
+		}
+
+		module, level = strings.TrimSpace(module), strings.TrimSpace(level)
+		if module == "" {
+			return nil, fmt.Errorf("the module of the level is missing: %s", item)
+		}
+		if _, err := log.ParseLevel(level); err != nil {
+			return nil, err
+		}
+		levels[module] = level
+	}
+
+	return levels, nil
+}
+
+// parseAuthTokens parses the tokens like "host1:port=token1;host2:port=token2" and maps the endpoints to them.
+// The endpoints are normalized, so "grpcs://host:port" and "host:port" are the same.
+func parseWebhooks(list string) ([]WebhookConfig, error) {
+	webhooks := []WebhookConfig{}
+	for _, item := range strings.Split(list, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		format, url, ok := strings.Cut(item, "=")
+		format, url = strings.TrimSpace(format), strings.TrimSpace(url)
+		if !ok || url == "" {
+			return nil, errors.New("the webhooks must be like format=url")
+		}
+
+		switch format {
+		case notification.FormatJSON, notification.FormatSlack, notification.FormatDiscord:
+		default:
+			return nil, fmt.Errorf("unknown webhook format: %s", format)
+		}
+		webhooks = append(webhooks, WebhookConfig{Format: format, URL: url})
+	}
+
+	return webhooks, nil
+}
+
+func parseAuthTokens(list string) (map[string]string, error) {
+	tokens := map[string]string{}
+	for _, item := range strings.Split(list, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		endpoint, token, ok := strings.Cut(item, "=")
+		token = strings.TrimSpace(token)
+		if !ok || token == "" {
+			return nil, errors.New("the tokens must be like host:port=token")
+		}
+
+		addr, err := client.ParseTarget(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		tokens[addr] = token
+	}
+
+	return tokens, nil
 }
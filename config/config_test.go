@@ -48,6 +48,140 @@ func TestBasicCheck(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Wallet from seed",
+			cfg: Config{
+				WalletPath:   tempWalletPath + "/wallet.json",
+				WalletSeed:   "test seed",
+				NetworkNodes: []string{"http://127.0.0.1:8545"},
+				StorePath:    tempStorePath,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Wallet without path",
+			cfg: Config{
+				WalletSeed:   "test seed",
+				NetworkNodes: []string{"http://127.0.0.1:8545"},
+				StorePath:    tempStorePath,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Faucet on mainnet",
+			cfg: Config{
+				Network:        "Mainnet",
+				WalletAddress:  "test_wallet_address",
+				WalletPath:     tempWalletPath,
+				WalletPassword: "test_password",
+				NetworkNodes:   []string{"http://127.0.0.1:8545"},
+				StorePath:      tempStorePath,
+				Faucet: FaucetConfig{
+					Amount: 5e9,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Faucet on the empty network",
+			cfg: Config{
+				Network:        "",
+				WalletAddress:  "test_wallet_address",
+				WalletPath:     tempWalletPath,
+				WalletPassword: "test_password",
+				NetworkNodes:   []string{"http://127.0.0.1:8545"},
+				StorePath:      tempStorePath,
+				Faucet: FaucetConfig{
+					Amount: 5e9,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Faucet on an unknown network",
+			cfg: Config{
+				Network:        "pactus-mainnet",
+				WalletAddress:  "test_wallet_address",
+				WalletPath:     tempWalletPath,
+				WalletPassword: "test_password",
+				NetworkNodes:   []string{"http://127.0.0.1:8545"},
+				StorePath:      tempStorePath,
+				Faucet: FaucetConfig{
+					Amount: 5e9,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Faucet on testnet",
+			cfg: Config{
+				Network:        "Testnet",
+				WalletAddress:  "test_wallet_address",
+				WalletPath:     tempWalletPath,
+				WalletPassword: "test_password",
+				NetworkNodes:   []string{"http://127.0.0.1:8545"},
+				StorePath:      tempStorePath,
+				Faucet: FaucetConfig{
+					Amount: 5e9,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Referrals without minimum balance",
+			cfg: Config{
+				WalletAddress:  "test_wallet_address",
+				WalletPath:     tempWalletPath,
+				WalletPassword: "test_password",
+				NetworkNodes:   []string{"http://127.0.0.1:8545"},
+				StorePath:      tempStorePath,
+				Referral: ReferralConfig{
+					Reward: 2e9,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Testnet nodes on testnet",
+			cfg: Config{
+				Network:        "Testnet",
+				WalletAddress:  "test_wallet_address",
+				WalletPath:     tempWalletPath,
+				WalletPassword: "test_password",
+				NetworkNodes:   []string{"http://127.0.0.1:8545"},
+				TestnetNodes:   []string{"http://127.0.0.1:8546"},
+				StorePath:      tempStorePath,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Matrix without rooms",
+			cfg: Config{
+				WalletAddress:  "test_wallet_address",
+				WalletPath:     tempWalletPath,
+				WalletPassword: "test_password",
+				NetworkNodes:   []string{"http://127.0.0.1:8545"},
+				StorePath:      tempStorePath,
+				MatrixBotCfg: MatrixBotConfig{
+					HomeserverURL: "https://matrix.org",
+					UserID:        "@robopac:matrix.org",
+					AccessToken:   "token",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "WebSocket without tokens",
+			cfg: Config{
+				WalletAddress:  "test_wallet_address",
+				WalletPath:     tempWalletPath,
+				WalletPassword: "test_password",
+				NetworkNodes:   []string{"http://127.0.0.1:8545"},
+				StorePath:      tempStorePath,
+				WSCfg:          WSConfig{ListenAddr: "127.0.0.1:8081"},
+			},
+			wantErr: true,
+		},
 	}
 
 	// Run test cases
@@ -65,3 +199,80 @@ func TestBasicCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAppLists(t *testing.T) {
+	lists, err := parseAppLists("discord: claim, booster-claim ; cli:help;")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"discord": {"claim", "booster-claim"},
+		"cli":     {"help"},
+	}, lists)
+
+	lists, err = parseAppLists("")
+	assert.NoError(t, err)
+	assert.Empty(t, lists)
+
+	_, err = parseAppLists("claim,help")
+	assert.Error(t, err)
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := parseAPIKeys("web: key1, scripts:key2")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"key1": "web",
+		"key2": "scripts",
+	}, keys)
+
+	_, err = parseAPIKeys("key1")
+	assert.Error(t, err)
+
+	_, err = parseAPIKeys("web:key1,scripts:key1")
+	assert.Error(t, err)
+}
+
+func TestParseAuthTokens(t *testing.T) {
+	tokens, err := parseAuthTokens("grpcs://node1.example.com:443=abc=; node2.example.com:50051 = xyz;")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"node1.example.com:443":   "abc=",
+		"node2.example.com:50051": "xyz",
+	}, tokens)
+
+	_, err = parseAuthTokens("node1.example.com:443")
+	assert.Error(t, err)
+
+	_, err = parseAuthTokens("node1.example.com=abc")
+	assert.Error(t, err)
+}
+
+func TestParseWebhooks(t *testing.T) {
+	webhooks, err := parseWebhooks("slack=https://hooks.slack.com/a?b=c; json = https://example.com/hook;")
+	assert.NoError(t, err)
+	assert.Equal(t, []WebhookConfig{
+		{Format: "slack", URL: "https://hooks.slack.com/a?b=c"},
+		{Format: "json", URL: "https://example.com/hook"},
+	}, webhooks)
+
+	_, err = parseWebhooks("https://example.com/hook")
+	assert.Error(t, err)
+
+	_, err = parseWebhooks("teams=https://example.com/hook")
+	assert.Error(t, err)
+}
+
+func TestParseLogLevels(t *testing.T) {
+	levels, err := parseLogLevels("info, engine:debug ,wallet: WARN")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"default": "info",
+		"engine":  "debug",
+		"wallet":  "WARN",
+	}, levels)
+
+	_, err = parseLogLevels("engine:loud")
+	assert.Error(t, err)
+
+	_, err = parseLogLevels(":debug")
+	assert.Error(t, err)
+}
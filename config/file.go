@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kehiy/RoboPac/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// source is where the config values are read from. The environment variables take precedence
+// over the settings of the config file, so a deployment can override the file, like the .env files.
+type source struct {
+	path string
+	// settings are the values of the file keyed by the environment variable names.
+	settings map[string]string
+	// keys are the keys of the settings as written in the file, for the errors.
+	keys map[string]string
+	used map[string]bool
+	// errs are the errors of the values, like the encrypted values that can't be decrypted.
+	errs []error
+}
+
+// get returns the value of the setting. The settings of the file can refer to the environment variables,
+// like "${DISCORD_TOKEN}", and the encrypted values are decrypted, see the secrets package.
+func (src *source) get(name string) string {
+	if src.used == nil {
+		src.used = make(map[string]bool)
+	}
+	src.used[name] = true
+
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		var err error
+		value, err = interpolate(src.settings[name])
+		if err != nil {
+			src.errs = append(src.errs, fmt.Errorf("%s: %w", src.keys[name], err))
+
+			return ""
+		}
+	}
+
+	if secrets.IsEncrypted(value) {
+		plaintext, err := decryptSetting(value)
+		if err != nil {
+			src.errs = append(src.errs, fmt.Errorf("%s: %w", name, err))
+
+			return ""
+		}
+		value = plaintext
+	}
+
+	return value
+}
+
+// checkUnknown returns an error for the settings of the file that are never read, mostly the typos.
+func (src *source) checkUnknown() error {
+	unknown := []string{}
+	for name := range src.settings {
+		if !src.used[name] {
+			unknown = append(unknown, src.keys[name])
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+	slices.Sort(unknown)
+
+	return fmt.Errorf("unknown settings in %s: %s", src.path, strings.Join(unknown, ", "))
+}
+
+// LoadFile loads the config from a YAML or a TOML file, detected by the extension.
+//
+// The settings have the names of the environment variables, in lower case and optionally nested,
+// so "discord_token" and "discord: {token: ...}" both set DISCORD_TOKEN.
+// The lists, like network_nodes, can be written as arrays.
+// The environment variables override the settings of the file.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		tree, err = parseTOML(string(data))
+	default:
+		return nil, fmt.Errorf("unsupported config file: %s, expected a .yaml or a .toml file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	src := &source{
+		path:     path,
+		settings: make(map[string]string),
+		keys:     make(map[string]string),
+	}
+	if err := src.flatten("", tree); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return parse(src)
+}
+
+// flatten adds the settings of the tree, the nested keys are joined by underscores.
+func (src *source) flatten(prefix string, tree map[string]any) error {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if subtree, ok := value.(map[string]any); ok {
+			if err := src.flatten(key, subtree); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		str, err := settingValue(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		name := strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		if _, ok := src.settings[name]; ok {
+			return fmt.Errorf("%s is set twice", key)
+		}
+		src.settings[name] = str
+		src.keys[name] = key
+	}
+
+	return nil
+}
+
+// settingValue returns the value as it's written in the environment variables.
+func settingValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			str, err := settingValue(item)
+			if err != nil {
+				return "", err
+			}
+			if _, nested := item.([]any); nested {
+				return "", fmt.Errorf("nested lists are not supported")
+			}
+			items = append(items, str)
+		}
+
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value: %v", value)
+	}
+}
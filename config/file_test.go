@@ -0,0 +1,221 @@
+package config
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	filePath := path.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+	return filePath
+}
+
+func TestLoadFile(t *testing.T) {
+	walletPath := t.TempDir()
+
+	t.Run("yaml", func(t *testing.T) {
+		filePath := writeConfigFile(t, "config.yaml", `
+wallet:
+  address: test_wallet_address
+  path: `+walletPath+`
+network_nodes: [node1:50052, node2:50052]
+store_path: /tmp/store
+discord:
+  token: MTEabc123
+  status_interval: 5m
+  announce_committee: true
+  block_milestone: 500
+`)
+
+		cfg, err := LoadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "test_wallet_address", cfg.WalletAddress)
+		assert.Equal(t, []string{"node1:50052", "node2:50052"}, cfg.NetworkNodes)
+		assert.Equal(t, "MTEabc123", cfg.DiscordBotCfg.DiscordToken)
+		assert.Equal(t, 5*time.Minute, cfg.DiscordBotCfg.StatusInterval)
+		assert.True(t, cfg.DiscordBotCfg.AnnounceCommittee)
+		assert.Equal(t, uint32(500), cfg.DiscordBotCfg.BlockMilestone)
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		filePath := writeConfigFile(t, "config.toml", `
+# the wallet of the bot
+network_nodes = ["node1:50052", "node2:50052",]
+store_path = "/tmp/store"
+
+[wallet]
+address = "test_wallet_address"
+path = '`+walletPath+`'
+
+[discord]
+status_interval = "5m" # the status rotation
+block_milestone = 1_000
+`)
+
+		cfg, err := LoadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "test_wallet_address", cfg.WalletAddress)
+		assert.Equal(t, []string{"node1:50052", "node2:50052"}, cfg.NetworkNodes)
+		assert.Equal(t, 5*time.Minute, cfg.DiscordBotCfg.StatusInterval)
+		assert.Equal(t, uint32(1000), cfg.DiscordBotCfg.BlockMilestone)
+	})
+
+	t.Run("environment overrides the file", func(t *testing.T) {
+		t.Setenv("DISCORD_STATUS_INTERVAL", "10m")
+
+		filePath := writeConfigFile(t, "config.yaml", `
+wallet: {address: test_wallet_address, path: `+walletPath+`}
+store_path: /tmp/store
+discord: {status_interval: 5m}
+`)
+
+		cfg, err := LoadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Minute, cfg.DiscordBotCfg.StatusInterval)
+	})
+
+	t.Run("unknown settings", func(t *testing.T) {
+		filePath := writeConfigFile(t, "config.yaml", `
+wallet: {address: test_wallet_address, path: `+walletPath+`}
+store_path: /tmp/store
+discord: {status_intervall: 5m}
+`)
+
+		_, err := LoadFile(filePath)
+		assert.ErrorContains(t, err, "discord.status_intervall")
+	})
+
+	t.Run("market sources", func(t *testing.T) {
+		filePath := writeConfigFile(t, "config.yaml", `
+wallet: {address: test_wallet_address, path: `+walletPath+`}
+store_path: /tmp/store
+market: {sources: [xeggex, coinmarketcap], cache_ttl: 30s}
+`)
+
+		_, err := LoadFile(filePath)
+		assert.ErrorContains(t, err, "MARKET_COINMARKETCAP_API_KEY is not set")
+
+		t.Setenv("MARKET_COINMARKETCAP_API_KEY", "cmc-key")
+		cfg, err := LoadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"xeggex", "coinmarketcap"}, cfg.Market.Sources)
+		assert.Equal(t, 30*time.Second, cfg.Market.CacheTTL)
+
+		t.Setenv("MARKET_SOURCES", "binance")
+		_, err = LoadFile(filePath)
+		assert.ErrorContains(t, err, "unknown source: binance")
+	})
+
+	t.Run("node client", func(t *testing.T) {
+		filePath := writeConfigFile(t, "config.yaml", `
+wallet: {address: test_wallet_address, path: `+walletPath+`}
+store_path: /tmp/store
+node: {call_timeout: 3s, retry_attempts: 5, breaker_threshold: 0,
+  tls_ca_file: /etc/robopac/ca.pem, auth_tokens: "grpcs://node.example.com:443=abc", read_only: true}
+`)
+
+		cfg, err := LoadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, NodeClientConfig{
+			CallTimeout:      3 * time.Second,
+			RetryAttempts:    5,
+			RetryBackoff:     200 * time.Millisecond,
+			BreakerThreshold: 0,
+			BreakerCooldown:  30 * time.Second,
+			TLSCAFile:        "/etc/robopac/ca.pem",
+			AuthTokens:       map[string]string{"node.example.com:443": "abc"},
+			ReadOnly:         true,
+		}, cfg.NodeClient)
+	})
+
+	t.Run("log file", func(t *testing.T) {
+		filePath := writeConfigFile(t, "config.yaml", `
+wallet: {address: test_wallet_address, path: `+walletPath+`}
+store_path: /tmp/store
+`)
+
+		cfg, err := LoadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "RoboPac.log", cfg.Log.File, "the log file is written by default")
+
+		t.Setenv("LOG_FILE", "/var/log/robopac.log")
+		cfg, err = LoadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "/var/log/robopac.log", cfg.Log.File)
+	})
+
+	t.Run("missing required settings", func(t *testing.T) {
+		filePath := writeConfigFile(t, "config.yaml", "discord: {token: MTEabc123}\n")
+
+		_, err := LoadFile(filePath)
+		assert.ErrorContains(t, err, "WALLET_ADDRESS is not set")
+		assert.ErrorContains(t, err, "STORE_PATH is not set")
+	})
+
+	t.Run("unsupported file", func(t *testing.T) {
+		filePath := writeConfigFile(t, "config.json", "{}")
+
+		_, err := LoadFile(filePath)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseTOML(t *testing.T) {
+	tree, err := parseTOML(`
+name = "robo # pac" # comment
+count = 3
+ratio = 0.5
+enabled = false
+items = ["a, b", 'c']
+
+[discord.summary]
+edit = true
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":    "robo # pac",
+		"count":   int64(3),
+		"ratio":   0.5,
+		"enabled": false,
+		"items":   []any{"a, b", "c"},
+		"discord": map[string]any{
+			"summary": map[string]any{"edit": true},
+		},
+	}, tree)
+
+	escaped, err := parseTOML(`path = "C:\\bot\t\u00e9"`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"path": "C:\\bot\té"}, escaped)
+
+	invalids := map[string]string{
+		"key":                        "expected key = value",
+		"key = ":                     "the value is missing",
+		"key = unquoted":             "invalid value",
+		"[table":                     "invalid table",
+		"key = 1\nkey = 2":           "set twice",
+		"key = 1\n[key]":             "not a table",
+		"[[nodes]]":                  "arrays of tables are not supported",
+		"discord.token = \"abc\"":    "dotted keys are not supported",
+		"\"key\" = 1":                "quoted keys are not supported",
+		"[discord.\"summary\"]":      "quoted keys are not supported",
+		"wallet = {address = \"a\"}": "inline tables are not supported",
+		"nodes = [\n\"a\",\n]":       "multi-line arrays are not supported",
+		"memo = \"\"\"text\"\"\"":    "multi-line strings are not supported",
+		"memo = \"\\x41\"":           "unsupported escape",
+		"memo = 'it's'":              "invalid string",
+		"date = 2024-01-01":          "invalid value",
+	}
+	for invalid, msg := range invalids {
+		_, err := parseTOML(invalid)
+		assert.ErrorContains(t, err, msg, invalid)
+	}
+}
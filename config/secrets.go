@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/kehiy/RoboPac/secrets"
+)
+
+// envRefPattern matches the references to the environment variables in the settings, like "${DISCORD_TOKEN}".
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+var (
+	secretsLk       sync.Mutex
+	secretsUnlocker = secrets.KeyFromEnv
+	secretsKey      *secrets.Key
+)
+
+// SetSecretsUnlocker sets how the key of the encrypted settings is found, like secrets.EnvOrPrompt.
+// The key is asked when the first encrypted setting is loaded, and it's kept for the reloads.
+func SetSecretsUnlocker(fn func() (*secrets.Key, error)) {
+	secretsLk.Lock()
+	defer secretsLk.Unlock()
+
+	secretsUnlocker = fn
+	secretsKey = nil
+}
+
+// decryptSetting decrypts the encrypted setting, the key is forgotten if it's wrong,
+// so it's asked again on the next load.
+func decryptSetting(value string) (string, error) {
+	secretsLk.Lock()
+	defer secretsLk.Unlock()
+
+	if secretsKey == nil {
+		key, err := secretsUnlocker()
+		if err != nil {
+			return "", err
+		}
+		if key == nil {
+			return "", secrets.ErrLocked
+		}
+		secretsKey = key
+	}
+
+	plaintext, err := secretsKey.Decrypt(value)
+	if errors.Is(err, secrets.ErrWrongKey) {
+		secretsKey = nil
+	}
+
+	return plaintext, err
+}
+
+// interpolate replaces the references to the environment variables in the setting.
+func interpolate(value string) (string, error) {
+	var err error
+	expanded := envRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envRefPattern.FindStringSubmatch(ref)[1]
+		envValue, ok := os.LookupEnv(name)
+		if !ok && err == nil {
+			err = fmt.Errorf("the environment variable %s is not set", name)
+		}
+
+		return envValue
+	})
+
+	return expanded, err
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/kehiy/RoboPac/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretSettings(t *testing.T) {
+	t.Cleanup(func() { SetSecretsUnlocker(secrets.KeyFromEnv) })
+	walletPath := t.TempDir()
+
+	encoded, err := secrets.GenerateKey()
+	require.NoError(t, err)
+	key, err := secrets.ParseKey(encoded)
+	require.NoError(t, err)
+
+	token, err := key.Encrypt("MTEabc123")
+	require.NoError(t, err)
+
+	t.Setenv("ROBOPAC_TEST_SEED", "seed words")
+	filePath := writeConfigFile(t, "config.yaml", `
+wallet: {address: test_wallet_address, path: `+walletPath+`, seed: "${ROBOPAC_TEST_SEED}"}
+store_path: /tmp/store
+discord: {token: "`+token+`"}
+`)
+
+	t.Run("locked", func(t *testing.T) {
+		SetSecretsUnlocker(secrets.KeyFromEnv)
+
+		_, err := LoadFile(filePath)
+		assert.ErrorIs(t, err, secrets.ErrLocked)
+	})
+
+	t.Run("unlocked once", func(t *testing.T) {
+		unlocked := 0
+		SetSecretsUnlocker(func() (*secrets.Key, error) {
+			unlocked++
+
+			return key, nil
+		})
+
+		for i := 0; i < 2; i++ {
+			cfg, err := LoadFile(filePath)
+			require.NoError(t, err)
+			assert.Equal(t, "MTEabc123", cfg.DiscordBotCfg.DiscordToken)
+			assert.Equal(t, "seed words", cfg.WalletSeed)
+		}
+		assert.Equal(t, 1, unlocked, "the key is kept for the reloads")
+	})
+
+	t.Run("encrypted environment", func(t *testing.T) {
+		t.Setenv("DISCORD_TOKEN", token)
+
+		cfg, err := LoadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "MTEabc123", cfg.DiscordBotCfg.DiscordToken)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		otherEncoded, err := secrets.GenerateKey()
+		require.NoError(t, err)
+		SetSecretsUnlocker(func() (*secrets.Key, error) { return secrets.ParseKey(otherEncoded) })
+
+		_, err = LoadFile(filePath)
+		assert.ErrorIs(t, err, secrets.ErrWrongKey)
+	})
+
+	t.Run("unset variable", func(t *testing.T) {
+		SetSecretsUnlocker(func() (*secrets.Key, error) { return key, nil })
+		filePath := writeConfigFile(t, "config.yaml", `
+wallet: {address: test_wallet_address, path: `+walletPath+`, seed: "${ROBOPAC_TEST_UNSET}"}
+store_path: /tmp/store
+`)
+
+		_, err := LoadFile(filePath)
+		assert.ErrorContains(t, err, "wallet.seed: the environment variable ROBOPAC_TEST_UNSET is not set")
+	})
+}
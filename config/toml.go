@@ -0,0 +1,234 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML parses the subset of TOML that the config files need:
+//   - the tables, like [discord] or [discord.summary],
+//   - the key/value pairs with the bare keys, like status_interval = "5m",
+//   - the one-line basic and literal strings, the integers, the floats and the booleans,
+//   - the one-line arrays of them, and the comments.
+//
+// The rest of TOML is rejected with an error, like the arrays of tables, the inline tables, the dotted keys,
+// the multi-line arrays and strings, the dates and the escapes that TOML doesn't have.
+func parseTOML(data string) (map[string]any, error) {
+	root := map[string]any{}
+	table := root
+
+	for num, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(stripComment(line))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: arrays of tables are not supported: %s", num+1, line)
+			}
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid table: %s", num+1, line)
+			}
+
+			var err error
+			table, err = tomlTable(root, strings.TrimSpace(line[1:len(line)-1]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", num+1, err)
+			}
+
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected key = value: %s", num+1, line)
+		}
+
+		if err := checkTOMLKey(key); err != nil {
+			return nil, fmt.Errorf("line %d: %w", num+1, err)
+		}
+
+		if _, exists := table[key]; exists {
+			return nil, fmt.Errorf("line %d: %s is set twice", num+1, key)
+		}
+
+		value, err := tomlValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", num+1, err)
+		}
+		table[key] = value
+	}
+
+	return root, nil
+}
+
+// tomlTable returns the table with the dotted name, creating it if it doesn't exist.
+func tomlTable(root map[string]any, name string) (map[string]any, error) {
+	table := root
+	for _, part := range strings.Split(name, ".") {
+		part = strings.TrimSpace(part)
+		if err := checkTOMLKey(part); err != nil {
+			return nil, fmt.Errorf("invalid table name: %s: %w", name, err)
+		}
+
+		sub, exists := table[part]
+		if !exists {
+			sub = map[string]any{}
+			table[part] = sub
+		}
+
+		subTable, ok := sub.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a table", part)
+		}
+		table = subTable
+	}
+
+	return table, nil
+}
+
+// checkTOMLKey checks that the key is a bare key, the quoted and the dotted keys are not supported.
+func checkTOMLKey(key string) error {
+	if key == "" {
+		return errors.New("the key is missing")
+	}
+
+	for _, r := range key {
+		switch {
+		case r == '.':
+			return fmt.Errorf("dotted keys are not supported, use a table: %s", key)
+		case r == '"' || r == '\'':
+			return fmt.Errorf("quoted keys are not supported: %s", key)
+		case r != '_' && r != '-' && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') && !('0' <= r && r <= '9'):
+			return fmt.Errorf("invalid key: %s", key)
+		}
+	}
+
+	return nil
+}
+
+func tomlValue(raw string) (any, error) {
+	switch {
+	case raw == "":
+		return nil, errors.New("the value is missing")
+
+	case strings.HasPrefix(raw, `"""`) || strings.HasPrefix(raw, "'''"):
+		return nil, fmt.Errorf("multi-line strings are not supported: %s", raw)
+
+	case strings.HasPrefix(raw, `"`):
+		if err := checkTOMLEscapes(raw); err != nil {
+			return nil, err
+		}
+
+		return strconv.Unquote(raw)
+
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") || strings.Contains(raw[1:len(raw)-1], "'") {
+			return nil, fmt.Errorf("invalid string: %s", raw)
+		}
+
+		return raw[1 : len(raw)-1], nil
+
+	case strings.HasPrefix(raw, "{"):
+		return nil, fmt.Errorf("inline tables are not supported, use a table: %s", raw)
+
+	case strings.HasPrefix(raw, "["):
+		if !strings.HasSuffix(raw, "]") {
+			return nil, fmt.Errorf("multi-line arrays are not supported, write the array in one line: %s", raw)
+		}
+
+		items := []any{}
+		for _, item := range splitTOMLArray(raw[1 : len(raw)-1]) {
+			value, err := tomlValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+
+		return items, nil
+
+	case raw == "true" || raw == "false":
+		return raw == "true", nil
+	}
+
+	digits := strings.ReplaceAll(raw, "_", "")
+	if i, err := strconv.ParseInt(digits, 10, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(digits, 64); err == nil {
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("invalid value: %s", raw)
+}
+
+// checkTOMLEscapes checks that the basic string has only the escapes of TOML, which strconv.Unquote accepts too.
+func checkTOMLEscapes(raw string) error {
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '\\' {
+			continue
+		}
+
+		i++
+		if i == len(raw) || !strings.ContainsRune(`btnfr"\uU`, rune(raw[i])) {
+			return fmt.Errorf("unsupported escape in the string: %s", raw)
+		}
+	}
+
+	return nil
+}
+
+// splitTOMLArray splits the items of an array, the commas in the strings are kept.
+func splitTOMLArray(raw string) []string {
+	items := []string{}
+	start := 0
+	var quote rune
+	for i, r := range raw {
+		switch {
+		case quote != 0:
+			if r == quote && (quote == '\'' || i == 0 || raw[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			items = append(items, raw[start:i])
+			start = i + 1
+		}
+	}
+	items = append(items, raw[start:])
+
+	// the trailing comma is allowed.
+	trimmed := []string{}
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			trimmed = append(trimmed, item)
+		}
+	}
+
+	return trimmed
+}
+
+// stripComment removes the comment of the line, the '#' in the strings is kept.
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote && (quote == '\'' || line[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
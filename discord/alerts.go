@@ -0,0 +1,140 @@
+package discord
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/monitor"
+	"github.com/kehiy/RoboPac/payout"
+	"github.com/kehiy/RoboPac/utils"
+	"github.com/pactus-project/pactus/util"
+)
+
+func validatorAlertEmbed(alert monitor.Alert, now time.Time) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       "Validator Alert🚨",
+		Description: fmt.Sprintf("Your validator `%s` needs attention: %s.", alert.Address, alert.Reason),
+		Color:       RED,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Use /%s off to stop the alerts", engine.ValidatorAlertsCommandName),
+		},
+		Timestamp: now.Format(time.RFC3339),
+	}
+}
+
+// sendValidatorAlert sends the alert to the subscriber by DM.
+func (bot *DiscordBot) sendValidatorAlert(alert monitor.Alert) {
+	ch, err := bot.Session.UserChannelCreate(alert.UserID)
+	if err != nil {
+		log.Error("unable to open the DM channel for the validator alert", "error", err, "userID", alert.UserID)
+		return
+	}
+
+	_, err = bot.Session.ChannelMessageSendEmbed(ch.ID, validatorAlertEmbed(alert, time.Now()))
+	if err != nil {
+		log.Error("unable to send the validator alert", "error", discordErrMsg(err, ch.ID), "userID", alert.UserID)
+		return
+	}
+
+	log.Info("validator alert sent", "userID", alert.UserID, "address", alert.Address)
+}
+
+func addressActivityEmbed(activity engine.AddressActivity, now time.Time) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       "Address Activity🔔",
+		Description: fmt.Sprintf("Your watched address `%s` %s.", activity.Address, activity.Summary()),
+		Color:       PACTUS,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Transaction", Value: fmt.Sprintf("`%s`", activity.Tx.ID)},
+			{Name: "Block", Value: utils.FormatNumber(int64(activity.Tx.Height)), Inline: true},
+			{Name: "Fee", Value: util.ChangeToString(activity.Tx.Fee) + " PAC", Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Use /%s to stop watching it", engine.UnwatchCommandName),
+		},
+		Timestamp: now.Format(time.RFC3339),
+	}
+}
+
+// sendAddressActivity sends the activity to the watcher by DM.
+func (bot *DiscordBot) sendAddressActivity(activity engine.AddressActivity) {
+	ch, err := bot.Session.UserChannelCreate(activity.UserID)
+	if err != nil {
+		log.Error("unable to open the DM channel for the address activity", "error", err, "userID", activity.UserID)
+		return
+	}
+
+	_, err = bot.Session.ChannelMessageSendEmbed(ch.ID, addressActivityEmbed(activity, time.Now()))
+	if err != nil {
+		log.Error("unable to send the address activity", "error", discordErrMsg(err, ch.ID), "userID", activity.UserID)
+		return
+	}
+
+	log.Info("address activity sent", "userID", activity.UserID, "address", activity.Address, "txID", activity.Tx.ID)
+}
+
+func payoutProgressEmbed(p payout.Progress, now time.Time) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       "Payout Progress💸",
+		Description: fmt.Sprintf("Payout `%s` is running.", p.PlanID),
+		Color:       PACTUS,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Sent", Value: fmt.Sprintf("%d/%d", p.Sent, p.Total), Inline: true},
+			{Name: "Failed", Value: fmt.Sprintf("%d", p.Failed), Inline: true},
+			{Name: "Batches", Value: fmt.Sprintf("%d", p.Batches), Inline: true},
+		},
+		Timestamp: now.Format(time.RFC3339),
+	}
+
+	switch {
+	case p.Done:
+		embed.Title = "Payout Done✅"
+		embed.Description = fmt.Sprintf("Payout `%s` is done, the signed receipt is attached.", p.PlanID)
+		embed.Color = GREEN
+		if p.Failed > 0 {
+			embed.Color = YELLOW
+		}
+	case p.Err != nil:
+		embed.Title = "Payout Stopped🚨"
+		embed.Description = fmt.Sprintf("Payout `%s` is stopped: %s.", p.PlanID, p.Err.Error())
+		embed.Color = RED
+	}
+
+	return embed
+}
+
+// sendPayoutProgress sends the progress of the payout to the admin who started it by DM.
+// The receipt is attached to the last report.
+func (bot *DiscordBot) sendPayoutProgress(callerID string, p payout.Progress) {
+	ch, err := bot.Session.UserChannelCreate(callerID)
+	if err != nil {
+		log.Error("unable to open the DM channel for the payout progress", "error", err, "userID", callerID)
+		return
+	}
+
+	msg := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{payoutProgressEmbed(p, time.Now())}}
+	if p.Done || p.Err != nil {
+		if receipt, err := os.Open(p.Receipt); err == nil {
+			defer receipt.Close()
+
+			msg.Files = []*discordgo.File{{
+				Name:        filepath.Base(p.Receipt),
+				ContentType: "application/jsonl",
+				Reader:      receipt,
+			}}
+		}
+	}
+
+	_, err = bot.Session.ChannelMessageSendComplex(ch.ID, msg)
+	if err != nil {
+		log.Error("unable to send the payout progress", "error", discordErrMsg(err, ch.ID), "userID", callerID)
+		return
+	}
+
+	log.Info("payout progress sent", "userID", callerID, "planID", p.PlanID, "sent", p.Sent)
+}
@@ -0,0 +1,101 @@
+package discord
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+const announceCommandName = "announce"
+
+func announceCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        announceCommandName,
+		Description: "broadcast an announcement to a channel (admin only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "title",
+				Description: "title of the announcement",
+				Required:    true,
+				MaxLength:   256,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "body",
+				Description: "body of the announcement",
+				Required:    true,
+				MaxLength:   4000,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "channel-id",
+				Description: "the channel ID to post in, the default announce channel is used if empty",
+				Required:    false,
+			},
+		},
+	}
+}
+
+func (bot *DiscordBot) announceHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !slices.Contains(bot.BotEngine.AuthIDs, i.User.ID) && bot.callerRole(s, i) < engine.RoleAdmin {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgUnauthorized), s, i)
+		return
+	}
+
+	var title, body string
+	channelID := bot.AnnounceChannelID
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "title":
+			title = opt.StringValue()
+		case "body":
+			body = opt.StringValue()
+		case "channel-id":
+			channelID = opt.StringValue()
+		}
+	}
+
+	if channelID == "" {
+		bot.respondErrMsg("no channel is provided and the announce channel is not configured", s, i)
+		return
+	}
+
+	ch, err := s.Channel(channelID)
+	if err != nil {
+		bot.respondErrMsg(discordErrMsg(err, channelID), s, i)
+		return
+	}
+
+	if ch.Type != discordgo.ChannelTypeGuildText && ch.Type != discordgo.ChannelTypeGuildNews {
+		bot.respondErrMsg(fmt.Sprintf("<#%s> is not a text channel", channelID), s, i)
+		return
+	}
+
+	announcement := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: body,
+		Color:       PACTUS,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+
+	msg, err := s.ChannelMessageSendEmbed(channelID, announcement)
+	if err != nil {
+		log.Error("unable to post the announcement", "error", err, "channelID", channelID)
+		bot.respondErrMsg(discordErrMsg(err, channelID), s, i)
+		return
+	}
+
+	log.Info("announcement posted", "channelID", channelID, "messageID", msg.ID, "by", i.User.ID)
+
+	bot.respondEphemeralEmbed(&discordgo.MessageEmbed{
+		Title: bot.messages(i).Get(engine.MsgTitleSuccessful),
+		Description: fmt.Sprintf("Announcement posted in <#%s>: https://discord.com/channels/%s/%s/%s",
+			channelID, ch.GuildID, channelID, msg.ID),
+		Color: GREEN,
+	}, s, i)
+}
@@ -0,0 +1,37 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/log"
+)
+
+// autocompleteHandler suggests the values of the option that is being typed, see engine.Autocomplete.
+func (bot *DiscordBot) autocompleteHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	inputs, opt := focusedOption(i.ApplicationCommandData())
+	if opt == nil {
+		return
+	}
+
+	// the focused options are sent as strings, even if they are typed as numbers.
+	prefix, _ := opt.Value.(string)
+	values := bot.BotEngine.Autocomplete(interactionUserID(i), inputs, opt.Name, prefix)
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(values))
+	for _, value := range values {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  value,
+			Value: value,
+		})
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		log.Debug("unable to respond to the autocomplete", "requestID", requestID(i),
+			"command", inputs[0], "option", opt.Name, "err", err)
+	}
+}
@@ -0,0 +1,94 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/utils"
+)
+
+// blockAnnouncements renders the announcements of the block event, based on the enabled announcements.
+func blockAnnouncements(event client.BlockEvent, milestoneEvery uint32, committee bool,
+	now time.Time,
+) []*discordgo.MessageEmbed {
+	embeds := []*discordgo.MessageEmbed{}
+
+	if milestone, ok := event.Milestone(milestoneEvery); ok {
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Title:       "Block Milestone🎉",
+			Description: fmt.Sprintf("The network reached the block **%s**!", utils.FormatNumber(int64(milestone))),
+			Color:       PACTUS,
+			Timestamp:   now.Format(time.RFC3339),
+		})
+	}
+
+	if committee && event.CommitteeChanged() {
+		fields := []*discordgo.MessageEmbedField{}
+		if len(event.Joined) > 0 {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name: "Joined", Value: formatAddresses(event.Joined),
+			})
+		}
+		if len(event.Left) > 0 {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name: "Left", Value: formatAddresses(event.Left),
+			})
+		}
+
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Title:       "Committee Changed🔄",
+			Description: fmt.Sprintf("The committee is changed at the block %s.", utils.FormatNumber(int64(event.Height))),
+			Color:       CALM,
+			Fields:      fields,
+			Timestamp:   now.Format(time.RFC3339),
+		})
+	}
+
+	return embeds
+}
+
+func formatAddresses(addrs []string) string {
+	lines := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		lines = append(lines, "`"+addr+"`")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// watchBlocks posts the block announcements to the blocks channel if it's enabled, until the context is canceled.
+func (bot *DiscordBot) watchBlocks(ctx context.Context) {
+	if bot.blocksChannelID == "" || (bot.blockMilestone == 0 && !bot.announceCommittee) {
+		return
+	}
+
+	events := bot.BotEngine.SubscribeBlocks()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			for _, embed := range blockAnnouncements(event, bot.blockMilestone, bot.announceCommittee, time.Now()) {
+				_, err := bot.Session.ChannelMessageSendEmbed(bot.blocksChannelID, embed)
+				if err != nil {
+					log.Error("unable to post the block announcement",
+						"error", discordErrMsg(err, bot.blocksChannelID), "height", event.Height)
+
+					continue
+				}
+
+				log.Info("block announcement posted", "channelID", bot.blocksChannelID, "title", embed.Title)
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockAnnouncements(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	t.Run("milestone", func(t *testing.T) {
+		event := client.BlockEvent{FromHeight: 19_999, Height: 20_001}
+
+		embeds := blockAnnouncements(event, 10_000, true, now)
+		require.Len(t, embeds, 1)
+		assert.Contains(t, embeds[0].Description, "20,000")
+	})
+
+	t.Run("no milestone", func(t *testing.T) {
+		event := client.BlockEvent{FromHeight: 20_001, Height: 20_002}
+
+		assert.Empty(t, blockAnnouncements(event, 10_000, true, now))
+	})
+
+	t.Run("committee changed", func(t *testing.T) {
+		event := client.BlockEvent{
+			FromHeight: 20_001,
+			Height:     20_002,
+			Joined:     []string{"pc1p-joined"},
+			Left:       []string{},
+		}
+
+		embeds := blockAnnouncements(event, 10_000, true, now)
+		require.Len(t, embeds, 1)
+		require.Len(t, embeds[0].Fields, 1)
+		assert.Equal(t, "Joined", embeds[0].Fields[0].Name)
+		assert.Contains(t, embeds[0].Fields[0].Value, "pc1p-joined")
+
+		assert.Empty(t, blockAnnouncements(event, 10_000, false, now))
+	})
+}
@@ -0,0 +1,304 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+const (
+	actionPrefix = "action:"
+	menuPrefix   = "menu:"
+
+	// componentsExpiry is the lifetime of the interaction token too, the follow-ups can't be sent after it.
+	componentsExpiry = 15 * time.Minute
+
+	// Discord allows up to 25 options in a select menu.
+	maxMenuOptions = 25
+)
+
+var errComponentsExpired = errors.New("the buttons are expired, please run the command again")
+
+// componentState is the actions of the components of a message, like the buttons.
+// The custom IDs of the components only refer to it, as they are limited to 100 characters.
+type componentState struct {
+	userID    string
+	actions   []engine.ResultAction
+	expiresAt time.Time
+}
+
+// componentStates keeps the states of the components by their ID.
+type componentStates struct {
+	lk sync.Mutex
+
+	states  map[string]*componentState
+	nowFunc func() time.Time
+}
+
+func newComponentStates() *componentStates {
+	return &componentStates{
+		states:  make(map[string]*componentState),
+		nowFunc: time.Now,
+	}
+}
+
+// add keeps the actions of the user and returns their ID.
+func (c *componentStates) add(userID string, actions ...engine.ResultAction) (string, error) {
+	id, err := gonanoid.New()
+	if err != nil {
+		return "", err
+	}
+
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	now := c.nowFunc()
+	for stateID, state := range c.states {
+		if now.After(state.expiresAt) {
+			delete(c.states, stateID)
+		}
+	}
+
+	c.states[id] = &componentState{
+		userID:    userID,
+		actions:   actions,
+		expiresAt: now.Add(componentsExpiry),
+	}
+
+	return id, nil
+}
+
+// get returns the action by its index, the actions of the other users are not found.
+// The actions can be taken many times until they expire.
+func (c *componentStates) get(id, userID string, index int) (engine.ResultAction, error) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	state, ok := c.states[id]
+	if !ok || state.userID != userID || c.nowFunc().After(state.expiresAt) {
+		return engine.ResultAction{}, errComponentsExpired
+	}
+
+	if index < 0 || index >= len(state.actions) {
+		return engine.ResultAction{}, fmt.Errorf("action %d is not found", index)
+	}
+
+	return state.actions[index], nil
+}
+
+// actionComponents renders the actions of the result as a row of buttons, like "action:<id>:0",
+// and its menu as a select menu, like "menu:<id>" with the indexes of the options as their values.
+func actionComponents(actionsID string, actions []engine.ResultAction,
+	menuID string, menu *engine.ResultMenu,
+) []discordgo.MessageComponent {
+	components := []discordgo.MessageComponent{}
+	if len(actions) > 0 {
+		buttons := make([]discordgo.MessageComponent, 0, len(actions))
+		for index, action := range actions[:min(len(actions), maxSuggestionButtons)] {
+			buttons = append(buttons, discordgo.Button{
+				Label:    action.Label,
+				Style:    discordgo.PrimaryButton,
+				CustomID: fmt.Sprintf("%s%s:%d", actionPrefix, actionsID, index),
+			})
+		}
+		components = append(components, discordgo.ActionsRow{Components: buttons})
+	}
+
+	if menu != nil {
+		options := make([]discordgo.SelectMenuOption, 0, len(menu.Options))
+		for index, option := range menu.Options[:min(len(menu.Options), maxMenuOptions)] {
+			options = append(options, discordgo.SelectMenuOption{
+				Label: option.Label,
+				Value: strconv.Itoa(index),
+			})
+		}
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    menuPrefix + menuID,
+					Placeholder: menu.Placeholder,
+					Options:     options,
+				},
+			},
+		})
+	}
+
+	return components
+}
+
+// parseActionID parses the custom ID of an action button, or of a select menu with its selected value,
+// into the state ID and the index of the action.
+func parseActionID(customID string, values []string) (string, int, error) {
+	if id, ok := strings.CutPrefix(customID, menuPrefix); ok {
+		if len(values) != 1 {
+			return "", 0, fmt.Errorf("invalid menu selection: %v", values)
+		}
+
+		index, err := strconv.Atoi(values[0])
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid menu selection: %v", values)
+		}
+
+		return id, index, nil
+	}
+
+	id, indexStr, ok := strings.Cut(strings.TrimPrefix(customID, actionPrefix), ":")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid action button: %s", customID)
+	}
+
+	index, err := strconv.Atoi(indexStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid action button: %s", customID)
+	}
+
+	return id, index, nil
+}
+
+// resultComponents renders the page buttons of the paged lists, the actions and the suggestions of the result.
+// The inputs of the lists are kept by the listID, or a new ID if it's empty.
+func (bot *DiscordBot) resultComponents(res *engine.CommandResult, userID, listID string,
+	inputs []string,
+) []discordgo.MessageComponent {
+	keep := func(actions ...engine.ResultAction) string {
+		id, err := bot.components.add(userID, actions...)
+		if err != nil {
+			log.Error("unable to keep the component actions", "error", err)
+		}
+
+		return id
+	}
+
+	components := []discordgo.MessageComponent{}
+	if res.List != nil && res.List.TotalPages() > 1 {
+		cmd := bot.BotEngine.FindCommand(inputs[0])
+		if cmd != nil && cmd.PageInputs(inputs, 1) != nil {
+			if listID == "" {
+				listID = keep(engine.ResultAction{Inputs: inputs, Replace: true})
+			}
+
+			if listID != "" {
+				components = append(components, pageButtons(listID, res.List))
+			}
+		}
+	}
+
+	actionsID, menuID := "", ""
+	if len(res.Actions) > 0 {
+		actionsID = keep(res.Actions...)
+	}
+	if res.Menu != nil {
+		menuID = keep(res.Menu.Options...)
+	}
+	if actionsID != "" || menuID != "" {
+		actions, menu := res.Actions, res.Menu
+		if actionsID == "" {
+			actions = nil
+		}
+		if menuID == "" {
+			menu = nil
+		}
+		components = append(components, actionComponents(actionsID, actions, menuID, menu)...)
+	}
+
+	return append(components, suggestionComponents(res.Suggestions)...)
+}
+
+// actionHandler takes the action of a clicked button or a selected menu option, it passes the same checks
+// as a slash command. The result replaces the message of the action if the action asks, like a refresh.
+func (bot *DiscordBot) actionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.MessageComponentData()
+	stateID, index, err := parseActionID(data.CustomID, data.Values)
+	if err != nil {
+		bot.respondErrMsg(err.Error(), s, i)
+		return
+	}
+
+	action, err := bot.components.get(stateID, interactionUserID(i), index)
+	if err != nil {
+		bot.respondErrMsg(err.Error(), s, i)
+		return
+	}
+
+	cmd := bot.BotEngine.FindCommand(action.Inputs[0])
+	if cmd == nil {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgUnknownCommand, action.Inputs[0]), s, i)
+		return
+	}
+
+	if !bot.BotEngine.IsCommandEnabled(cmd.Name, bot.GuildID) {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgGuildCommandDisabled, cmd.Name), s, i)
+		return
+	}
+
+	if reason := bot.checkAgeGate(s, cmd, i, time.Now()); reason != "" {
+		bot.respondEphemeralEmbed(&discordgo.MessageEmbed{
+			Title:       bot.messages(i).Get(engine.MsgTitleError),
+			Description: reason,
+			Color:       RED,
+		}, s, i)
+
+		return
+	}
+
+	if wait, ok := bot.checkCooldown(s, i); !ok {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgCooldown, max(wait.Round(time.Second), time.Second)), s, i)
+		return
+	}
+
+	if phrase := confirmPhrase(cmd, action.Inputs); phrase != "" {
+		bot.askConfirmation(phrase, action.Inputs, s, i)
+		return
+	}
+
+	log.Debug("action taken", "requestID", requestID(i), "command", cmd.Name, "inputs", action.Inputs)
+
+	res, err := bot.BotEngine.RunWithOptions(bot.runOptions(s, i), engine.AppIdDiscord, interactionUserID(i),
+		action.Inputs)
+	if err != nil {
+		bot.respondErrMsg(runErrMsg(err), s, i)
+		return
+	}
+
+	if action.Replace {
+		bot.updateResultMsg(res, "", action.Inputs, s, i)
+
+		return
+	}
+
+	bot.respondResultMsg(res, action.Inputs, s, i)
+}
+
+// updateResultMsg replaces the message of the clicked component with the result of the inputs,
+// the long results are continued in the follow-ups.
+func (bot *DiscordBot) updateResultMsg(res *engine.CommandResult, listID string, inputs []string,
+	s *discordgo.Session, i *discordgo.InteractionCreate,
+) {
+	out := renderResult(res, bot.messages(i), bot.resultComponents(res, interactionUserID(i), listID, inputs))
+	first := out.first()
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{first.embed},
+			Components: first.components,
+			Files:      first.files,
+		},
+	})
+	if err != nil {
+		metrics.DiscordInteractionFailuresTotal.Inc("update")
+		log.Error("unable to update the message", "requestID", requestID(i), "error", err)
+
+		return
+	}
+
+	sendFollowups(s, i.Interaction, out)
+}
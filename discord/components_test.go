@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentStates(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	states := newComponentStates()
+	states.nowFunc = func() time.Time { return now }
+	refresh := engine.ResultAction{Label: "Refresh", Inputs: []string{"network-status", "testnet"}, Replace: true}
+	raw := engine.ResultAction{Label: "Show raw tx", Inputs: []string{"tx", "abcd", "raw"}}
+
+	id, err := states.add("user-1", refresh, raw)
+	require.NoError(t, err)
+
+	got, err := states.get(id, "user-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, raw, got)
+
+	got, err = states.get(id, "user-1", 0)
+	require.NoError(t, err, "the actions can be taken many times")
+	assert.Equal(t, refresh, got)
+
+	_, err = states.get(id, "user-1", 2)
+	assert.Error(t, err)
+
+	_, err = states.get(id, "user-2", 0)
+	assert.ErrorIs(t, err, errComponentsExpired)
+
+	now = now.Add(componentsExpiry + time.Second)
+	_, err = states.get(id, "user-1", 0)
+	assert.ErrorIs(t, err, errComponentsExpired)
+
+	_, err = states.add("user-1", refresh)
+	require.NoError(t, err)
+	assert.Len(t, states.states, 1, "the expired states are dropped")
+}
+
+func TestActionComponents(t *testing.T) {
+	actions := []engine.ResultAction{
+		{Label: "Refresh", Inputs: []string{"network-status"}, Replace: true},
+		{Label: "Show raw tx", Inputs: []string{"tx", "abcd", "raw"}},
+	}
+	menu := &engine.ResultMenu{
+		Placeholder: "Show another network",
+		Options:     []engine.ResultAction{{Label: "testnet", Inputs: []string{"network-status", "testnet"}}},
+	}
+
+	components := actionComponents("abc", actions, "def", menu)
+	require.Len(t, components, 2)
+
+	buttons, ok := components[0].(discordgo.ActionsRow)
+	require.True(t, ok)
+	require.Len(t, buttons.Components, 2)
+	raw, ok := buttons.Components[1].(discordgo.Button)
+	require.True(t, ok)
+	assert.Equal(t, "Show raw tx", raw.Label)
+	assert.Equal(t, "action:abc:1", raw.CustomID)
+
+	id, index, err := parseActionID(raw.CustomID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", id)
+	assert.Equal(t, 1, index)
+
+	row, ok := components[1].(discordgo.ActionsRow)
+	require.True(t, ok)
+	selectMenu, ok := row.Components[0].(discordgo.SelectMenu)
+	require.True(t, ok)
+	assert.Equal(t, "menu:def", selectMenu.CustomID)
+	assert.Equal(t, "Show another network", selectMenu.Placeholder)
+	assert.Equal(t, "0", selectMenu.Options[0].Value)
+
+	id, index, err = parseActionID(selectMenu.CustomID, []string{"0"})
+	require.NoError(t, err)
+	assert.Equal(t, "def", id)
+	assert.Equal(t, 0, index)
+
+	assert.Empty(t, actionComponents("", nil, "", nil))
+
+	_, _, err = parseActionID("action:abc", nil)
+	assert.Error(t, err)
+	_, _, err = parseActionID("menu:def", nil)
+	assert.Error(t, err)
+}
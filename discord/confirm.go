@@ -0,0 +1,172 @@
+package discord
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+const (
+	confirmModalPrefix = "confirm:"
+	confirmPhraseInput = "phrase"
+	confirmExpiry      = 5 * time.Minute
+)
+
+var (
+	errConfirmExpired  = errors.New("the confirmation is expired, please run the command again")
+	errConfirmMismatch = errors.New("the confirmation phrase doesn't match, the command is canceled")
+)
+
+// pendingConfirm is a dangerous command that waits for the caller to type the confirmation phrase.
+type pendingConfirm struct {
+	userID    string
+	phrase    string
+	inputs    []string
+	expiresAt time.Time
+}
+
+// confirmations keeps the pending confirmations by their modal ID.
+type confirmations struct {
+	lk sync.Mutex
+
+	pending map[string]*pendingConfirm
+	nowFunc func() time.Time
+}
+
+func newConfirmations() *confirmations {
+	return &confirmations{
+		pending: make(map[string]*pendingConfirm),
+		nowFunc: time.Now,
+	}
+}
+
+// add registers a pending confirmation and returns the ID of its modal.
+func (c *confirmations) add(userID, phrase string, inputs []string) (string, error) {
+	id, err := gonanoid.New()
+	if err != nil {
+		return "", err
+	}
+
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	now := c.nowFunc()
+	for pendingID, p := range c.pending {
+		if now.After(p.expiresAt) {
+			delete(c.pending, pendingID)
+		}
+	}
+
+	c.pending[id] = &pendingConfirm{
+		userID:    userID,
+		phrase:    phrase,
+		inputs:    inputs,
+		expiresAt: now.Add(confirmExpiry),
+	}
+
+	return id, nil
+}
+
+// take removes the pending confirmation and returns its command inputs if the typed phrase matches.
+// A confirmation can be taken only once, even if the phrase doesn't match.
+func (c *confirmations) take(id, userID, typed string) ([]string, error) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	p, ok := c.pending[id]
+	if !ok || p.userID != userID {
+		return nil, errConfirmExpired
+	}
+	delete(c.pending, id)
+
+	if c.nowFunc().After(p.expiresAt) {
+		return nil, errConfirmExpired
+	}
+
+	if strings.TrimSpace(typed) != p.phrase {
+		return nil, errConfirmMismatch
+	}
+
+	return p.inputs, nil
+}
+
+func confirmModal(id, cmdName, phrase string) *discordgo.InteractionResponse {
+	return &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: confirmModalPrefix + id,
+			Title:    "Confirm /" + cmdName,
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    confirmPhraseInput,
+							Label:       "Type \"" + phrase + "\" to confirm",
+							Style:       discordgo.TextInputShort,
+							Placeholder: phrase,
+							Required:    true,
+							MaxLength:   100,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// askConfirmation shows a modal to the caller, asking to type the confirmation phrase of the command.
+func (bot *DiscordBot) askConfirmation(phrase string, inputs []string, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	id, err := bot.confirms.add(i.User.ID, phrase, inputs)
+	if err != nil {
+		bot.respondErrMsg(err.Error(), s, i)
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, confirmModal(id, inputs[0], phrase))
+	if err != nil {
+		metrics.DiscordInteractionFailuresTotal.Inc("modal")
+		log.Error("unable to show the confirmation modal", "error", err)
+	}
+}
+
+// confirmHandler verifies the typed phrase of a submitted confirmation modal and runs the command.
+func (bot *DiscordBot) confirmHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ModalSubmitData()
+	id := strings.TrimPrefix(data.CustomID, confirmModalPrefix)
+
+	typed := ""
+	for _, row := range data.Components {
+		actionsRow, ok := row.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, comp := range actionsRow.Components {
+			if input, ok := comp.(*discordgo.TextInput); ok && input.CustomID == confirmPhraseInput {
+				typed = input.Value
+			}
+		}
+	}
+
+	inputs, err := bot.confirms.take(id, i.User.ID, typed)
+	if err != nil {
+		bot.respondErrMsg(err.Error(), s, i)
+		return
+	}
+
+	log.Info("dangerous command confirmed", "requestID", requestID(i), "command", inputs[0], "by", i.User.ID)
+
+	res, err := bot.BotEngine.RunWithOptions(bot.runOptions(s, i), engine.AppIdDiscord, i.User.ID, inputs)
+	if err != nil {
+		bot.respondErrMsg(runErrMsg(err), s, i)
+		return
+	}
+
+	bot.respondResultMsg(res, inputs, s, i)
+}
@@ -0,0 +1,63 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmations(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	confirms := newConfirmations()
+	confirms.nowFunc = func() time.Time { return now }
+	inputs := []string{"toggle-command", "claim", "disable"}
+
+	t.Run("matching phrase", func(t *testing.T) {
+		id, err := confirms.add("user-1", "toggle the command", inputs)
+		require.NoError(t, err)
+
+		got, err := confirms.take(id, "user-1", " toggle the command ")
+		require.NoError(t, err)
+		assert.Equal(t, inputs, got)
+
+		// a confirmation can't be used twice.
+		_, err = confirms.take(id, "user-1", "toggle the command")
+		assert.ErrorIs(t, err, errConfirmExpired)
+	})
+
+	t.Run("mismatched phrase cancels", func(t *testing.T) {
+		id, err := confirms.add("user-1", "toggle the command", inputs)
+		require.NoError(t, err)
+
+		_, err = confirms.take(id, "user-1", "toggle")
+		assert.ErrorIs(t, err, errConfirmMismatch)
+
+		_, err = confirms.take(id, "user-1", "toggle the command")
+		assert.ErrorIs(t, err, errConfirmExpired)
+	})
+
+	t.Run("another user", func(t *testing.T) {
+		id, err := confirms.add("user-1", "toggle the command", inputs)
+		require.NoError(t, err)
+
+		_, err = confirms.take(id, "user-2", "toggle the command")
+		assert.ErrorIs(t, err, errConfirmExpired)
+
+		// the owner can still confirm it.
+		_, err = confirms.take(id, "user-1", "toggle the command")
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		id, err := confirms.add("user-1", "toggle the command", inputs)
+		require.NoError(t, err)
+
+		now = now.Add(confirmExpiry + time.Second)
+
+		_, err = confirms.take(id, "user-1", "toggle the command")
+		assert.ErrorIs(t, err, errConfirmExpired)
+		assert.Empty(t, confirms.pending)
+	})
+}
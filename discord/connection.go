@@ -0,0 +1,212 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+)
+
+const (
+	connectionStatusCommandName = "connection-status"
+
+	// The commands are registered again with a doubling backoff, until they are registered or the bot stops.
+	registerBackoff    = 5 * time.Second
+	maxRegisterBackoff = 5 * time.Minute
+
+	eventDisconnect = "disconnect"
+	eventResumed    = "resumed"
+	eventNewSession = "new_session"
+	eventRegistered = "registered"
+)
+
+// connectionState tracks the gateway connection of the session. The session reconnects by itself
+// with a backoff, and resumes the last session if it can, otherwise it identifies a new session.
+type connectionState struct {
+	lk sync.Mutex
+
+	connected      bool
+	userID         string
+	sessionID      string
+	connectedAt    time.Time
+	disconnectedAt time.Time
+	disconnects    int
+	resumes        int
+	newSessions    int
+	registering    bool
+}
+
+// ready records a new session, it reports whether the session identity is changed since the last one,
+// so the commands must be registered again. The first session is not a change.
+func (cs *connectionState) ready(userID, sessionID string, now time.Time) bool {
+	cs.lk.Lock()
+	defer cs.lk.Unlock()
+
+	changed := cs.sessionID != "" && (cs.sessionID != sessionID || cs.userID != userID)
+	if changed {
+		cs.newSessions++
+	}
+
+	cs.connected = true
+	cs.userID = userID
+	cs.sessionID = sessionID
+	cs.connectedAt = now
+
+	return changed
+}
+
+func (cs *connectionState) resumed(now time.Time) {
+	cs.lk.Lock()
+	defer cs.lk.Unlock()
+
+	cs.connected = true
+	cs.connectedAt = now
+	cs.resumes++
+}
+
+func (cs *connectionState) disconnected(now time.Time) {
+	cs.lk.Lock()
+	defer cs.lk.Unlock()
+
+	if !cs.connected {
+		return
+	}
+
+	cs.connected = false
+	cs.disconnectedAt = now
+	cs.disconnects++
+}
+
+// startRegistering reports whether the caller should register the commands,
+// only one registration runs at a time.
+func (cs *connectionState) startRegistering() bool {
+	cs.lk.Lock()
+	defer cs.lk.Unlock()
+
+	if cs.registering {
+		return false
+	}
+	cs.registering = true
+
+	return true
+}
+
+func (cs *connectionState) doneRegistering() {
+	cs.lk.Lock()
+	defer cs.lk.Unlock()
+
+	cs.registering = false
+}
+
+// trackConnection adds the handlers of the gateway events, it's called before opening the session.
+func (bot *DiscordBot) trackConnection(ctx context.Context) {
+	bot.Session.ShouldReconnectOnError = true
+
+	bot.Session.AddHandler(func(_ *discordgo.Session, r *discordgo.Ready) {
+		if !bot.connection.ready(r.User.ID, r.SessionID, time.Now()) {
+			return
+		}
+
+		log.Warn("discord session is identified again, registering the commands", "sessionID", r.SessionID)
+		metrics.DiscordConnectionEventsTotal.Inc(eventNewSession)
+		if bot.connection.startRegistering() {
+			bot.goBackground(func() { bot.registerAgain(ctx) })
+		}
+	})
+	bot.Session.AddHandler(func(_ *discordgo.Session, _ *discordgo.Resumed) {
+		log.Info("discord session is resumed")
+		metrics.DiscordConnectionEventsTotal.Inc(eventResumed)
+		bot.connection.resumed(time.Now())
+	})
+	bot.Session.AddHandler(func(_ *discordgo.Session, _ *discordgo.Disconnect) {
+		// the session is closed by Stop.
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Warn("discord session is disconnected, reconnecting")
+		metrics.DiscordConnectionEventsTotal.Inc(eventDisconnect)
+		bot.connection.disconnected(time.Now())
+	})
+}
+
+// registerAgain registers the commands for the new session, the failed attempts are retried with a backoff.
+func (bot *DiscordBot) registerAgain(ctx context.Context) {
+	defer bot.connection.doneRegistering()
+
+	backoff := registerBackoff
+	for {
+		err := bot.registerCommands()
+		if err == nil {
+			metrics.DiscordConnectionEventsTotal.Inc(eventRegistered)
+
+			return
+		}
+
+		log.Error("unable to register the commands again", "error", err, "retryIn", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxRegisterBackoff)
+	}
+}
+
+func connectionStatusCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        connectionStatusCommandName,
+		Description: "the state of the connection to the Discord gateway (admin only)",
+	}
+}
+
+// connectionStatusEmbed renders the connection state, the latency is the last heartbeat round trip.
+func connectionStatusEmbed(cs *connectionState, latency time.Duration) *discordgo.MessageEmbed {
+	cs.lk.Lock()
+	defer cs.lk.Unlock()
+
+	embed := &discordgo.MessageEmbed{
+		Title: "Connection Status",
+		Color: GREEN,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Status", Value: "Connected✅", Inline: true},
+			{Name: "Gateway Latency", Value: latency.Round(time.Millisecond).String(), Inline: true},
+			{Name: "Session", Value: "`" + cs.sessionID + "`", Inline: false},
+		},
+	}
+
+	since := cs.connectedAt
+	if !cs.connected {
+		embed.Color = RED
+		embed.Fields[0].Value = "Disconnected❌"
+		since = cs.disconnectedAt
+	}
+	if !since.IsZero() {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: "Since", Value: fmt.Sprintf("<t:%d:R>", since.Unix()), Inline: true,
+		})
+	}
+
+	embed.Fields = append(embed.Fields,
+		&discordgo.MessageEmbedField{Name: "Disconnects", Value: fmt.Sprint(cs.disconnects), Inline: true},
+		&discordgo.MessageEmbedField{Name: "Resumed", Value: fmt.Sprint(cs.resumes), Inline: true},
+		&discordgo.MessageEmbedField{Name: "New Sessions", Value: fmt.Sprint(cs.newSessions), Inline: true},
+	)
+
+	return embed
+}
+
+func (bot *DiscordBot) connectionStatusHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !slices.Contains(bot.BotEngine.AuthIDs, i.User.ID) && bot.callerRole(s, i) < engine.RoleAdmin {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgUnauthorized), s, i)
+		return
+	}
+
+	bot.respondEphemeralEmbed(connectionStatusEmbed(bot.connection, s.HeartbeatLatency()), s, i)
+}
@@ -0,0 +1,50 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionState(t *testing.T) {
+	cs := &connectionState{}
+	now := time.Date(2024, 1, 24, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, cs.ready("bot", "session-1", now), "the first session is not a change")
+
+	cs.disconnected(now.Add(time.Minute))
+	cs.disconnected(now.Add(2 * time.Minute))
+	assert.Equal(t, 1, cs.disconnects, "the repeated disconnects are counted once")
+
+	embed := connectionStatusEmbed(cs, 0)
+	assert.Equal(t, RED, embed.Color)
+	assert.Equal(t, "Disconnected❌", embed.Fields[0].Value)
+
+	cs.resumed(now.Add(3 * time.Minute))
+	assert.True(t, cs.connected)
+	assert.Equal(t, 1, cs.resumes)
+
+	cs.disconnected(now.Add(4 * time.Minute))
+	assert.True(t, cs.ready("bot", "session-2", now.Add(5*time.Minute)), "the session is identified again")
+	assert.False(t, cs.ready("bot", "session-2", now.Add(6*time.Minute)))
+
+	embed = connectionStatusEmbed(cs, 42*time.Millisecond)
+	assert.Equal(t, GREEN, embed.Color)
+	require.Len(t, embed.Fields, 7)
+	assert.Equal(t, "Connected✅", embed.Fields[0].Value)
+	assert.Equal(t, "42ms", embed.Fields[1].Value)
+	assert.Equal(t, "`session-2`", embed.Fields[2].Value)
+	assert.Equal(t, "<t:1706097960:R>", embed.Fields[3].Value)
+	assert.Equal(t, "2", embed.Fields[4].Value)
+	assert.Equal(t, "1", embed.Fields[5].Value)
+	assert.Equal(t, "1", embed.Fields[6].Value)
+
+	t.Run("one registration at a time", func(t *testing.T) {
+		assert.True(t, cs.startRegistering())
+		assert.False(t, cs.startRegistering())
+		cs.doneRegistering()
+		assert.True(t, cs.startRegistering())
+	})
+}
@@ -0,0 +1,128 @@
+package discord
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+)
+
+// defaultDeferTimeout is how long the deferred response waits for the command,
+// before it's replaced by the still working message.
+const defaultDeferTimeout = 10 * time.Second
+
+// interactionResponder is the part of the Discord session that responds to the interactions.
+type interactionResponder interface {
+	InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse,
+		options ...discordgo.RequestOption) error
+	InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit,
+		options ...discordgo.RequestOption) (*discordgo.Message, error)
+	FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams,
+		options ...discordgo.RequestOption) (*discordgo.Message, error)
+}
+
+type deferredResult struct {
+	embed *discordgo.MessageEmbed
+	// followups are the rest of the parts of a long result, they are sent as the follow-up messages.
+	followups  []*discordgo.MessageEmbed
+	components []discordgo.MessageComponent
+	files      []*discordgo.File
+}
+
+// renderResult renders the result of a command as the response of the interaction.
+func renderResult(res *engine.CommandResult, messages *engine.MessageCatalog,
+	components []discordgo.MessageComponent,
+) deferredResult {
+	embeds := resultEmbeds(res, messages)
+
+	return deferredResult{
+		embed:      embeds[0],
+		followups:  embeds[1:],
+		components: components,
+		files:      resultFiles(res),
+	}
+}
+
+// first returns the first message of the result, the components and the files are sent
+// with the last follow-up if there is any.
+func (res deferredResult) first() deferredResult {
+	if len(res.followups) == 0 {
+		return res
+	}
+
+	return deferredResult{embed: res.embed}
+}
+
+// respondDeferred acknowledges the interaction right away, so the slow commands don't miss
+// the 3 seconds deadline of Discord, then edits the response with the result of the command.
+// If the command takes longer than the timeout, the response shows the still working embed
+// until the result is ready. The deferred response can be edited for 15 minutes.
+func respondDeferred(r interactionResponder, i *discordgo.Interaction, timeout time.Duration,
+	stillWorking *discordgo.MessageEmbed, run func() deferredResult,
+) {
+	err := r.InteractionRespond(i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+	if err != nil {
+		metrics.DiscordInteractionFailuresTotal.Inc("defer")
+		log.Error("unable to defer the interaction response",
+			"requestID", requestID(&discordgo.InteractionCreate{Interaction: i}), "error", err)
+		return
+	}
+
+	done := make(chan deferredResult, 1)
+	go func() {
+		done <- run()
+	}()
+
+	var res deferredResult
+	select {
+	case res = <-done:
+	case <-time.After(timeout):
+		editResponse(r, i, deferredResult{embed: stillWorking})
+		res = <-done
+	}
+
+	editResponse(r, i, res.first())
+	sendFollowups(r, i, res)
+}
+
+func editResponse(r interactionResponder, i *discordgo.Interaction, res deferredResult) {
+	components := res.components
+	if components == nil {
+		components = []discordgo.MessageComponent{}
+	}
+
+	_, err := r.InteractionResponseEdit(i, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{res.embed},
+		Components: &components,
+		Files:      res.files,
+	})
+	if err != nil {
+		metrics.DiscordInteractionFailuresTotal.Inc("edit")
+		log.Error("unable to edit the interaction response",
+			"requestID", requestID(&discordgo.InteractionCreate{Interaction: i}), "error", err)
+	}
+}
+
+// sendFollowups sends the rest of the parts of the result as the follow-up messages of the interaction,
+// the last one has the components and the files of the result.
+func sendFollowups(r interactionResponder, i *discordgo.Interaction, res deferredResult) {
+	for num, embed := range res.followups {
+		params := &discordgo.WebhookParams{Embeds: []*discordgo.MessageEmbed{embed}}
+		if num == len(res.followups)-1 {
+			params.Components = res.components
+			params.Files = res.files
+		}
+
+		if _, err := r.FollowupMessageCreate(i, true, params); err != nil {
+			metrics.DiscordInteractionFailuresTotal.Inc("followup")
+			log.Error("unable to send the follow-up message",
+				"requestID", requestID(&discordgo.InteractionCreate{Interaction: i}), "error", err)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package discord
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResponder struct {
+	lk        sync.Mutex
+	responses []*discordgo.InteractionResponse
+	edits     []*discordgo.WebhookEdit
+	followups []*discordgo.WebhookParams
+}
+
+func (r *fakeResponder) InteractionRespond(_ *discordgo.Interaction, resp *discordgo.InteractionResponse,
+	_ ...discordgo.RequestOption,
+) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	r.responses = append(r.responses, resp)
+
+	return nil
+}
+
+func (r *fakeResponder) InteractionResponseEdit(_ *discordgo.Interaction, edit *discordgo.WebhookEdit,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	r.edits = append(r.edits, edit)
+
+	return &discordgo.Message{}, nil
+}
+
+func (r *fakeResponder) FollowupMessageCreate(_ *discordgo.Interaction, _ bool, data *discordgo.WebhookParams,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	r.followups = append(r.followups, data)
+
+	return &discordgo.Message{}, nil
+}
+
+func (r *fakeResponder) editedTitles() []string {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	titles := []string{}
+	for _, edit := range r.edits {
+		titles = append(titles, (*edit.Embeds)[0].Title)
+	}
+
+	return titles
+}
+
+func TestRespondDeferred(t *testing.T) {
+	stillWorking := &discordgo.MessageEmbed{Title: "Working on it"}
+	i := dmInteraction("user-1").Interaction
+
+	t.Run("fast command", func(t *testing.T) {
+		r := &fakeResponder{}
+		respondDeferred(r, i, time.Second, stillWorking, func() deferredResult {
+			return deferredResult{embed: &discordgo.MessageEmbed{Title: "Successful"}}
+		})
+
+		require.Len(t, r.responses, 1)
+		assert.Equal(t, discordgo.InteractionResponseDeferredChannelMessageWithSource, r.responses[0].Type)
+		assert.Equal(t, []string{"Successful"}, r.editedTitles())
+		assert.Empty(t, *r.edits[0].Components)
+	})
+
+	t.Run("slow command", func(t *testing.T) {
+		r := &fakeResponder{}
+		release := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			respondDeferred(r, i, 10*time.Millisecond, stillWorking, func() deferredResult {
+				<-release
+
+				return deferredResult{embed: &discordgo.MessageEmbed{Title: "Successful"}}
+			})
+			close(done)
+		}()
+
+		assert.Eventually(t, func() bool { return len(r.editedTitles()) == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, []string{"Working on it"}, r.editedTitles())
+
+		close(release)
+		<-done
+		assert.Equal(t, []string{"Working on it", "Successful"}, r.editedTitles())
+	})
+	t.Run("long result", func(t *testing.T) {
+		r := &fakeResponder{}
+		res := engine.MakeSuccessfulResult(strings.Repeat("line\n", 1000))
+		res.Suggest("me")
+
+		respondDeferred(r, i, time.Second, stillWorking, func() deferredResult {
+			return renderResult(res, engine.NewMessageCatalog(), suggestionComponents(res.Suggestions))
+		})
+
+		require.Len(t, r.edits, 1)
+		assert.Equal(t, "Part 1/2", (*r.edits[0].Embeds)[0].Footer.Text)
+		assert.Empty(t, *r.edits[0].Components, "the buttons are under the last part")
+
+		require.Len(t, r.followups, 1)
+		assert.Equal(t, "Part 2/2", r.followups[0].Embeds[0].Footer.Text)
+		assert.Len(t, r.followups[0].Components, 1)
+	})
+}
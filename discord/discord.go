@@ -1,41 +1,122 @@
 package discord
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/config"
 	"github.com/kehiy/RoboPac/engine"
 	"github.com/kehiy/RoboPac/log"
-	"github.com/kehiy/RoboPac/utils"
+	"github.com/kehiy/RoboPac/market"
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/kehiy/RoboPac/scheduler"
 )
 
 type DiscordBot struct {
-	Session   *discordgo.Session
-	BotEngine *engine.BotEngine
-	GuildID   string
+	Session           *discordgo.Session
+	BotEngine         *engine.BotEngine
+	GuildID           string
+	AnnounceChannelID string
+
+	limiter        *userLimiter
+	trusted        *trustedCallers
+	adminRoleIDs   []string
+	confirms       *confirmations
+	components     *componentStates
+	connection     *connectionState
+	statusLk       sync.RWMutex
+	statusMode     string
+	statusInterval time.Duration
+
+	// statusQueue is the rest of the statuses of the current cycle, see updateStatus.
+	statusQueueLk sync.Mutex
+	statusQueue   []discordgo.UpdateStatusData
+
+	summaryChannelID string
+	summarySchedule  string
+	summaryEdit      bool
+	tracked          *trackedMessages
+
+	blocksChannelID   string
+	blockMilestone    uint32
+	announceCommittee bool
+
+	deferTimeout time.Duration
+
+	// cancel stops the background work of the bot, like the status updater, wg waits for them.
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
-func NewDiscordBot(botEngine *engine.BotEngine, token, guildID string) (*DiscordBot, error) {
-	s, err := discordgo.New("Bot " + token)
+func NewDiscordBot(botEngine *engine.BotEngine, cfg config.DiscordBotConfig) (*DiscordBot, error) {
+	s, err := discordgo.New("Bot " + cfg.DiscordToken)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DiscordBot{
-		Session:   s,
-		BotEngine: botEngine,
-		GuildID:   guildID,
+		Session:           s,
+		BotEngine:         botEngine,
+		GuildID:           cfg.DiscordGuildID,
+		AnnounceChannelID: cfg.DiscordAnnounceChannelID,
+		limiter:           newUserLimiter(cfg.CommandCooldown),
+		trusted: &trustedCallers{
+			roleIDs: cfg.TrustedRoleIDs,
+			userIDs: cfg.TrustedUserIDs,
+		},
+		adminRoleIDs:   cfg.AdminRoleIDs,
+		confirms:       newConfirmations(),
+		components:     newComponentStates(),
+		connection:     &connectionState{},
+		statusMode:     cfg.StatusMode,
+		statusInterval: cfg.StatusInterval,
+
+		summaryChannelID: cfg.SummaryChannelID,
+		summarySchedule:  cfg.SummarySchedule,
+		summaryEdit:      cfg.SummaryEdit,
+		tracked:          newTrackedMessages(),
+
+		blocksChannelID:   cfg.BlocksChannelID,
+		blockMilestone:    cfg.BlockMilestone,
+		announceCommittee: cfg.AnnounceCommittee,
+
+		deferTimeout: defaultDeferTimeout,
 	}, nil
 }
 
-func (bot *DiscordBot) Start() error {
+func (bot *DiscordBot) Start(ctx context.Context) error {
 	log.Info("starting Discord Bot...")
 
+	ctx, bot.cancel = context.WithCancel(ctx)
+	bot.trackConnection(ctx)
+
 	err := bot.Session.Open()
 	if err != nil {
 		return err
 	}
 
+	if err := bot.scheduleNetworkSummary(); err != nil {
+		return err
+	}
+	if err := bot.scheduleStatus(); err != nil {
+		return err
+	}
+
+	bot.goBackground(func() { bot.watchBlocks(ctx) })
+	bot.goBackground(func() { bot.BotEngine.WatchAddresses(ctx, bot.sendAddressActivity) })
+	bot.goBackground(bot.updateStatus)
+	bot.BotEngine.SetValidatorAlertHandler(bot.sendValidatorAlert)
+	bot.BotEngine.SetPayoutProgressHandler(bot.sendPayoutProgress)
+	bot.BotEngine.OnConfigReload(func(cfg *config.Config) {
+		bot.applyConfig(cfg.DiscordBotCfg)
+	})
+
+	bot.Session.AddHandler(bot.interactionHandler)
 	bot.deleteAllCommands()
 	return bot.registerCommands()
 }
@@ -55,161 +136,334 @@ func (bot *DiscordBot) deleteAllCommands() {
 	}
 }
 
-func (bot *DiscordBot) registerCommands() error {
-	bot.Session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (bot *DiscordBot) interactionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
 		bot.commandHandler(bot, s, i)
-	})
 
-	beCmds := bot.BotEngine.Commands()
-	for _, beCmd := range beCmds {
-		if !beCmd.HasAppId(engine.AppIdDiscord) {
-			continue
-		}
-		discordCmd := discordgo.ApplicationCommand{
-			Name:        beCmd.Name,
-			Description: beCmd.Desc,
-			Options:     make([]*discordgo.ApplicationCommandOption, len(beCmd.Args)),
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		bot.autocompleteHandler(s, i)
+
+	case discordgo.InteractionModalSubmit:
+		if strings.HasPrefix(i.ModalSubmitData().CustomID, confirmModalPrefix) {
+			bot.confirmHandler(s, i)
 		}
-		for index, arg := range beCmd.Args {
-			discordCmd.Options[index] = &discordgo.ApplicationCommandOption{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        arg.Name,
-				Description: arg.Desc,
-				Required:    !arg.Optional,
-			}
+
+	case discordgo.InteractionMessageComponent:
+		customID := i.MessageComponentData().CustomID
+		switch {
+		case strings.HasPrefix(customID, suggestionPrefix):
+			bot.suggestionHandler(s, i)
+		case strings.HasPrefix(customID, pagePrefix):
+			bot.pageHandler(s, i)
+		case strings.HasPrefix(customID, actionPrefix), strings.HasPrefix(customID, menuPrefix):
+			bot.actionHandler(s, i)
 		}
 
-		cmd, err := bot.Session.ApplicationCommandCreate(bot.Session.State.User.ID, "", &discordCmd)
-		if err != nil {
-			log.Error("can not register discord command", "name", discordCmd.Name, "error", err)
-			return err
+	default:
+	}
+}
+
+// registerCommands registers the commands of the engine and the Discord commands by one bulk overwrite,
+// so the registrations don't use the daily quota of the command creates, and the removed commands are deleted.
+func (bot *DiscordBot) registerCommands() error {
+	discordCmds := []*discordgo.ApplicationCommand{}
+	for _, beCmd := range bot.BotEngine.Commands() {
+		if !bot.BotEngine.IsCommandAllowed(beCmd.Name, engine.AppIdDiscord) {
+			continue
 		}
-		log.Info("discord command registered", "name", cmd.Name)
+		discordCmds = append(discordCmds, applicationCommand(beCmd))
+	}
+	discordCmds = append(discordCmds, announceCommand(), connectionStatusCommand())
+
+	cmds, err := bot.Session.ApplicationCommandBulkOverwrite(bot.Session.State.User.ID, "", discordCmds)
+	if err != nil {
+		log.Error("can not register discord commands", "error", err)
+		return err
 	}
+	log.Info("discord commands registered", "count", len(cmds))
 
 	return nil
 }
 
 func (bot *DiscordBot) commandHandler(db *DiscordBot, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	cmdName := i.ApplicationCommandData().Name
+	msgs := bot.messages(i)
 	if i.GuildID != "" {
-		bot.respondErrMsg("Send a message in a bottle, ye say? Cast it into me DMs, and I'll be at yer service!", s, i)
+		bot.respondErrMsg(msgs.Get(engine.MsgDMOnly), s, i)
 		return
 	}
 
-	beInput := []string{}
+	// Discord registers commands for the whole application, so the per-guild state can only be enforced
+	// at dispatch time. The commands are run in DMs, so the state of the guild of the bot applies.
+	if !bot.BotEngine.IsCommandEnabled(cmdName, bot.GuildID) {
+		bot.respondErrMsg(msgs.Get(engine.MsgGuildCommandDisabled, cmdName), s, i)
+		return
+	}
+
+	if cmd := bot.BotEngine.FindCommand(cmdName); cmd != nil {
+		if reason := bot.checkAgeGate(s, cmd, i, time.Now()); reason != "" {
+			bot.respondEphemeralEmbed(&discordgo.MessageEmbed{
+				Title:       msgs.Get(engine.MsgTitleError),
+				Description: reason,
+				Color:       RED,
+			}, s, i)
+
+			return
+		}
+	}
+
+	if wait, ok := bot.checkCooldown(s, i); !ok {
+		bot.respondErrMsg(msgs.Get(engine.MsgCooldown, max(wait.Round(time.Second), time.Second)), s, i)
+		return
+	}
 
 	// Get the application command data
 	discordCmd := i.ApplicationCommandData()
-	beInput = append(beInput, discordCmd.Name)
-	for _, opt := range discordCmd.Options {
-		beInput = append(beInput, opt.StringValue())
+	switch discordCmd.Name {
+	case announceCommandName:
+		bot.announceHandler(s, i)
+		return
+	case connectionStatusCommandName:
+		bot.connectionStatusHandler(s, i)
+		return
 	}
 
-	res, err := db.BotEngine.Run(engine.AppIdDiscord, i.User.ID, beInput)
-	if err != nil {
-		db.respondErrMsg(err.Error(), s, i)
+	beInput := commandInputs(discordCmd)
+
+	if phrase := confirmPhrase(bot.BotEngine.FindCommand(discordCmd.Name), beInput); phrase != "" {
+		bot.askConfirmation(phrase, beInput, s, i)
 		return
 	}
 
-	bot.respondResultMsg(res, s, i)
+	reqID := requestID(i)
+	log.Debug("discord command", "requestID", reqID, "command", discordCmd.Name, "by", i.User.ID)
+
+	stillWorking := &discordgo.MessageEmbed{
+		Title:       msgs.Get(engine.MsgTitlePending),
+		Description: msgs.Get(engine.MsgStillWorking),
+		Color:       CALM,
+	}
+	opts := bot.runOptions(s, i)
+	respondDeferred(s, i.Interaction, bot.deferTimeout, stillWorking, func() deferredResult {
+		res, err := db.BotEngine.RunWithOptions(opts, engine.AppIdDiscord, i.User.ID, beInput)
+		if err != nil {
+			log.Warn("discord command failed", "requestID", reqID, "command", discordCmd.Name, "error", err)
+
+			return deferredResult{embed: bot.errEmbed(runErrMsg(err), i)}
+		}
+
+		return renderResult(res, msgs, bot.resultComponents(res, i.User.ID, "", beInput))
+	})
+}
+
+// messages returns the message catalog in the language of the caller.
+func (bot *DiscordBot) messages(i *discordgo.InteractionCreate) *engine.MessageCatalog {
+	return bot.BotEngine.MessagesFor(interactionUserID(i))
 }
 
 func (bot *DiscordBot) respondErrMsg(errStr string, s *discordgo.Session, i *discordgo.InteractionCreate) {
-	errorEmbed := &discordgo.MessageEmbed{
-		Title:       "Error",
-		Description: errStr,
-		Color:       RED,
-	}
-	bot.respondEmbed(errorEmbed, s, i)
+	bot.respondEmbed(bot.errEmbed(errStr, i), s, i)
 }
 
-func (bot *DiscordBot) respondResultMsg(res *engine.CommandResult, s *discordgo.Session, i *discordgo.InteractionCreate) {
-	var resEmbed *discordgo.MessageEmbed
-	if res.Successful {
-		resEmbed = &discordgo.MessageEmbed{
-			Title:       "Successful",
-			Description: res.Message,
-			Color:       GREEN,
-		}
-	} else {
-		resEmbed = &discordgo.MessageEmbed{
-			Title:       "Failed",
-			Description: res.Message,
-			Color:       YELLOW,
-		}
+func (bot *DiscordBot) errEmbed(errStr string, i *discordgo.InteractionCreate) *discordgo.MessageEmbed {
+	if errStr == "" {
+		errStr = bot.messages(i).Get(engine.MsgErrorFallback)
 	}
 
-	bot.respondEmbed(resEmbed, s, i)
+	return errorEmbed(bot.messages(i).Get(engine.MsgTitleError), errStr, requestID(i))
+}
+
+// respondResultMsg responds with the result of the inputs, the long results are continued in the follow-ups.
+func (bot *DiscordBot) respondResultMsg(res *engine.CommandResult, inputs []string,
+	s *discordgo.Session, i *discordgo.InteractionCreate,
+) {
+	out := renderResult(res, bot.messages(i), bot.resultComponents(res, interactionUserID(i), "", inputs))
+	first := out.first()
+	bot.respondEmbedWithFlags(first.embed, 0, first.components, first.files, s, i)
+	sendFollowups(s, i.Interaction, out)
 }
 
 func (db *DiscordBot) respondEmbed(embed *discordgo.MessageEmbed, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	db.respondEmbedWithFlags(embed, 0, nil, nil, s, i)
+}
+
+func (db *DiscordBot) respondEphemeralEmbed(embed *discordgo.MessageEmbed, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	db.respondEmbedWithFlags(embed, discordgo.MessageFlagsEphemeral, nil, nil, s, i)
+}
+
+func (db *DiscordBot) respondEmbedWithFlags(embed *discordgo.MessageEmbed, flags discordgo.MessageFlags,
+	components []discordgo.MessageComponent, files []*discordgo.File,
+	s *discordgo.Session, i *discordgo.InteractionCreate,
+) {
 	response := &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{embed},
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Flags:      flags,
+			Components: components,
+			Files:      files,
 		},
 	}
 
 	err := s.InteractionRespond(i.Interaction, response)
 	if err != nil {
-		log.Error("InteractionRespond error:", "error", err)
+		metrics.DiscordInteractionFailuresTotal.Inc("respond")
+		log.Error("InteractionRespond error:", "requestID", requestID(i), "error", err)
 	}
 }
 
-func (db *DiscordBot) UpdateStatusInfo() {
-	log.Info("info status started")
-	for {
-		ns, err := db.BotEngine.NetworkStatus()
-		if err != nil {
-			continue
-		}
+// scheduleStatus schedules the updates of the bot presence with the network status, on every status interval.
+func (db *DiscordBot) scheduleStatus() error {
+	mode, interval := db.statusSettings()
+	log.Info("info status started", "mode", mode, "interval", interval)
 
-		err = db.Session.UpdateStatusComplex(newStatus("validators count", utils.FormatNumber(int64(ns.ValidatorsCount))))
-		if err != nil {
-			log.Error("can't set status", "err", err)
-			continue
-		}
+	return db.BotEngine.Schedule(scheduler.Job{
+		Name: statusJobName,
+		Spec: fmt.Sprintf("@every %s", interval),
+		Run:  func(_ context.Context) { db.updateStatus() },
+	})
+}
 
-		time.Sleep(time.Second * 5)
+// updateStatus sets the next status of the cycle on the bot presence.
+// The statuses of a cycle are computed together, so the network status is fetched once per cycle.
+func (db *DiscordBot) updateStatus() {
+	db.statusQueueLk.Lock()
+	if len(db.statusQueue) == 0 {
+		db.statusQueue = db.statusCycle()
+	}
+	if len(db.statusQueue) == 0 {
+		db.statusQueueLk.Unlock()
 
-		err = db.Session.UpdateStatusComplex(newStatus("total accounts", utils.FormatNumber(int64(ns.TotalAccounts))))
+		return
+	}
+	status := db.statusQueue[0]
+	db.statusQueue = db.statusQueue[1:]
+	db.statusQueueLk.Unlock()
+
+	if err := db.Session.UpdateStatusComplex(status); err != nil {
+		log.Error("can't set status", "err", err)
+	}
+}
+
+// statusCycle fetches the network status and computes the statuses of a cycle.
+// In the combined mode, the cycle is one status with all the information.
+// In the cycle mode, the information is shown one by one.
+// If the status entries are configured, they are the cycle instead, see engine.RenderStatuses.
+func (db *DiscordBot) statusCycle() []discordgo.UpdateStatusData {
+	mode, _ := db.statusSettings()
+
+	// the other networks are skipped if their status is not available, but not the primary one.
+	statuses := make([]*engine.NetStatus, 0, 1)
+	for i, network := range db.BotEngine.Networks() {
+		ns, err := db.BotEngine.NetworkStatusOf(network)
 		if err != nil {
-			log.Error("can't set status", "err", err)
+			log.Error("can't get network status", "err", err, "network", network)
+			if i == 0 {
+				return nil
+			}
+
 			continue
 		}
+		statuses = append(statuses, ns)
+	}
 
-		time.Sleep(time.Second * 5)
+	price := db.statusPrice()
+	cycle := []discordgo.UpdateStatusData{}
 
-		err = db.Session.UpdateStatusComplex(newStatus("height", utils.FormatNumber(int64(ns.CurrentBlockHeight))))
-		if err != nil {
-			log.Error("can't set status", "err", err)
-			continue
+	// the configured status entries take precedence over the mode, while any of them is enabled.
+	if texts := db.BotEngine.RenderStatuses(engine.NewStatusData(statuses[0], price)); len(texts) > 0 {
+		for _, text := range texts {
+			cycle = append(cycle, newCustomStatus(text))
 		}
 
-		time.Sleep(time.Second * 5)
+		return cycle
+	}
 
-		err = db.Session.UpdateStatusComplex(newStatus("circ supply",
-			utils.FormatNumber(int64(utils.ChangeToCoin(ns.CirculatingSupply)))+" PAC"))
-		if err != nil {
-			log.Error("can't set status", "err", err)
-			continue
+	if mode == config.StatusModeCycle {
+		for _, item := range statusItems(statuses, price) {
+			cycle = append(cycle, newStatus(item.name, item.value))
 		}
 
-		time.Sleep(time.Second * 5)
+		return cycle
+	}
 
-		err = db.Session.UpdateStatusComplex(newStatus("total power",
-			utils.FormatNumber(int64(utils.ChangeToCoin(ns.TotalNetworkPower)))+" PAC"))
-		if err != nil {
-			log.Error("can't set status", "err", err)
-			continue
+	return append(cycle, newCustomStatus(combinedStatus(statuses)))
+}
+
+// statusPrice returns the price for the status, or nil if the market data is not available.
+func (db *DiscordBot) statusPrice() *market.Price {
+	price, err := db.BotEngine.MarketPrice()
+	if err != nil {
+		if !errors.Is(err, engine.ErrMarketDisabled) {
+			log.Warn("can't get the price for the status", "err", err)
 		}
 
-		time.Sleep(time.Second * 5)
+		return nil
 	}
+
+	return price
+}
+
+// statusSettings returns the mode and the interval of the status, they can change by reloading the config.
+func (db *DiscordBot) statusSettings() (string, time.Duration) {
+	db.statusLk.RLock()
+	defer db.statusLk.RUnlock()
+
+	return db.statusMode, db.statusInterval
 }
 
+// applyConfig applies the reloaded settings, the token, the guild and the channels need a restart.
+func (db *DiscordBot) applyConfig(cfg config.DiscordBotConfig) {
+	db.statusLk.Lock()
+	intervalChanged := db.statusInterval != cfg.StatusInterval
+	db.statusMode = cfg.StatusMode
+	db.statusInterval = cfg.StatusInterval
+	db.statusLk.Unlock()
+
+	// the next status starts a new cycle by the new mode.
+	db.statusQueueLk.Lock()
+	db.statusQueue = nil
+	db.statusQueueLk.Unlock()
+
+	if intervalChanged {
+		db.BotEngine.Unschedule(statusJobName)
+		if err := db.scheduleStatus(); err != nil {
+			log.Error("unable to reschedule the status", "err", err)
+		}
+	}
+
+	db.limiter.setInterval(cfg.CommandCooldown)
+	log.Info("discord settings reloaded", "statusMode", cfg.StatusMode,
+		"statusInterval", cfg.StatusInterval, "commandCooldown", cfg.CommandCooldown)
+}
+
+// goBackground runs the function in the background, Stop waits for it to return.
+func (db *DiscordBot) goBackground(fn func()) {
+	db.wg.Add(1)
+	go func() {
+		defer db.wg.Done()
+		fn()
+	}()
+}
+
+func (*DiscordBot) Name() string {
+	return "discord"
+}
+
+func (*DiscordBot) AppID() engine.AppID {
+	return engine.AppIdDiscord
+}
+
+// Stop stops the background work of the bot and closes the session.
 func (db *DiscordBot) Stop() {
 	log.Info("shutting down Discord Bot...")
 
+	db.BotEngine.Unschedule(statusJobName)
+	db.BotEngine.Unschedule(summaryJobName)
+	if db.cancel != nil {
+		db.cancel()
+	}
 	_ = db.Session.Close()
+	db.wg.Wait()
 }
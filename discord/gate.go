@@ -0,0 +1,88 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+// accountAge returns the age of the Discord account, computed from its snowflake ID.
+func accountAge(userID string, now time.Time) (time.Duration, error) {
+	createdAt, err := discordgo.SnowflakeTimestamp(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return now.Sub(createdAt), nil
+}
+
+// memberAge returns the time since the member joined the guild.
+// It returns false if the join time of the member is unknown.
+func memberAge(member *discordgo.Member, now time.Time) (time.Duration, bool) {
+	if member == nil || member.JoinedAt.IsZero() {
+		return 0, false
+	}
+
+	return now.Sub(member.JoinedAt), true
+}
+
+func formatDays(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	if days == 1 {
+		return "1 day"
+	}
+
+	return fmt.Sprintf("%d days", days)
+}
+
+// ageGate checks the minimum account and membership age of the command.
+// If the caller is blocked, it returns the message key of the reason and the required age.
+// The member is looked up only for the membership age, and the caller is blocked if it's unknown.
+func ageGate(cmd *engine.Command, i *discordgo.InteractionCreate, member func() (*discordgo.Member, error),
+	now time.Time,
+) (engine.MessageKey, time.Duration, bool) {
+	if cmd.MinAccountAge > 0 {
+		age, err := accountAge(interactionUserID(i), now)
+		if err != nil || age < cmd.MinAccountAge {
+			return engine.MsgAccountTooNew, cmd.MinAccountAge, false
+		}
+	}
+
+	if cmd.MinMemberAge > 0 {
+		m, err := member()
+		if err != nil {
+			return engine.MsgMemberTooNew, cmd.MinMemberAge, false
+		}
+
+		if age, ok := memberAge(m, now); !ok || age < cmd.MinMemberAge {
+			return engine.MsgMemberTooNew, cmd.MinMemberAge, false
+		}
+	}
+
+	return "", 0, true
+}
+
+// checkAgeGate returns the reason if the caller is blocked by the age gate of the command,
+// otherwise an empty string.
+func (bot *DiscordBot) checkAgeGate(fetcher memberFetcher, cmd *engine.Command, i *discordgo.InteractionCreate,
+	now time.Time,
+) string {
+	member := func() (*discordgo.Member, error) {
+		m, err := bot.guildMember(fetcher, i)
+		if err != nil {
+			log.Warn("unable to get the guild member", "requestID", requestID(i), "error", err)
+		}
+
+		return m, err
+	}
+
+	key, minAge, ok := ageGate(cmd, i, member, now)
+	if ok {
+		return ""
+	}
+
+	return bot.messages(i).Get(key, formatDays(minAge), cmd.Name)
+}
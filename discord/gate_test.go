@@ -0,0 +1,101 @@
+package discord
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// snowflakeAt makes a Discord snowflake ID created at the given time.
+func snowflakeAt(t time.Time) string {
+	return strconv.FormatInt((t.UnixMilli()-1420070400000)<<22, 10)
+}
+
+func TestAccountAge(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	age, err := accountAge(snowflakeAt(now.Add(-48*time.Hour)), now)
+	require.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, age)
+
+	// a real snowflake, created at 2016-04-30 11:18:25.796 UTC.
+	age, err = accountAge("175928847299117063", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Sub(time.UnixMilli(1462015105796)), age)
+
+	_, err = accountAge("invalid-id", now)
+	assert.Error(t, err)
+}
+
+func TestMemberAge(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	age, ok := memberAge(&discordgo.Member{JoinedAt: now.Add(-time.Hour)}, now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, age)
+
+	_, ok = memberAge(nil, now)
+	assert.False(t, ok)
+
+	_, ok = memberAge(&discordgo.Member{}, now)
+	assert.False(t, ok)
+}
+
+func TestAgeGate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cmd := &engine.Command{
+		Name:          "faucet",
+		MinAccountAge: 30 * 24 * time.Hour,
+		MinMemberAge:  7 * 24 * time.Hour,
+	}
+
+	oldAccount := snowflakeAt(now.Add(-365 * 24 * time.Hour))
+	newAccount := snowflakeAt(now.Add(-24 * time.Hour))
+	memberJoined := func(joinedAt time.Time) func() (*discordgo.Member, error) {
+		return func() (*discordgo.Member, error) { return &discordgo.Member{JoinedAt: joinedAt}, nil }
+	}
+	unknownMember := func() (*discordgo.Member, error) { return nil, errors.New("unknown member") }
+
+	t.Run("new account", func(t *testing.T) {
+		key, minAge, ok := ageGate(cmd, dmInteraction(newAccount), memberJoined(now.Add(-365*24*time.Hour)), now)
+		assert.False(t, ok)
+		assert.Equal(t, engine.MsgAccountTooNew, key)
+		assert.Equal(t, cmd.MinAccountAge, minAge)
+	})
+
+	t.Run("new member", func(t *testing.T) {
+		key, _, ok := ageGate(cmd, dmInteraction(oldAccount), memberJoined(now.Add(-24*time.Hour)), now)
+		assert.False(t, ok)
+		assert.Equal(t, engine.MsgMemberTooNew, key)
+	})
+
+	t.Run("old member", func(t *testing.T) {
+		_, _, ok := ageGate(cmd, dmInteraction(oldAccount), memberJoined(now.Add(-30*24*time.Hour)), now)
+		assert.True(t, ok)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		key, _, ok := ageGate(cmd, dmInteraction(oldAccount), unknownMember, now)
+		assert.False(t, ok, "the gate fails closed")
+		assert.Equal(t, engine.MsgMemberTooNew, key)
+
+		_, _, ok = ageGate(cmd, dmInteraction(oldAccount), memberJoined(time.Time{}), now)
+		assert.False(t, ok, "the join time is unknown")
+	})
+
+	t.Run("no gate", func(t *testing.T) {
+		_, _, ok := ageGate(&engine.Command{Name: "free"}, dmInteraction(newAccount), unknownMember, now)
+		assert.True(t, ok)
+	})
+}
+
+func TestFormatDays(t *testing.T) {
+	assert.Equal(t, "1 day", formatDays(24*time.Hour))
+	assert.Equal(t, "30 days", formatDays(30*24*time.Hour))
+}
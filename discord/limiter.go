@@ -0,0 +1,120 @@
+package discord
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// userLimiter allows each user to run one command per interval.
+type userLimiter struct {
+	lk sync.Mutex
+
+	interval time.Duration
+	lastCall map[string]time.Time
+	nowFunc  func() time.Time
+}
+
+func newUserLimiter(interval time.Duration) *userLimiter {
+	return &userLimiter{
+		interval: interval,
+		lastCall: make(map[string]time.Time),
+		nowFunc:  time.Now,
+	}
+}
+
+// allow records a call for the user if the interval has passed since the last call,
+// otherwise it returns the remaining time to wait.
+func (l *userLimiter) allow(userID string) (time.Duration, bool) {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	if l.interval <= 0 {
+		return 0, true
+	}
+
+	now := l.nowFunc()
+	if last, ok := l.lastCall[userID]; ok {
+		if wait := last.Add(l.interval).Sub(now); wait > 0 {
+			return wait, false
+		}
+	}
+
+	// the expired entries are removed from time to time to keep the memory bounded.
+	if len(l.lastCall) >= 1024 {
+		for id, last := range l.lastCall {
+			if now.Sub(last) >= l.interval {
+				delete(l.lastCall, id)
+			}
+		}
+	}
+
+	l.lastCall[userID] = now
+
+	return 0, true
+}
+
+// setInterval changes the interval, the recorded calls are kept.
+func (l *userLimiter) setInterval(interval time.Duration) {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	l.interval = interval
+}
+
+// trustedCallers are the users who bypass the command cooldowns.
+type trustedCallers struct {
+	roleIDs []string
+	userIDs []string
+}
+
+// isTrusted checks the user ID allowlist, then the roles of the caller as a guild member.
+// The member is looked up only if there are trusted roles, and the caller is not trusted if it's unknown.
+func (tc *trustedCallers) isTrusted(i *discordgo.InteractionCreate, member func() (*discordgo.Member, error)) bool {
+	if slices.Contains(tc.userIDs, interactionUserID(i)) {
+		return true
+	}
+
+	if len(tc.roleIDs) == 0 {
+		return false
+	}
+
+	m, err := member()
+	if err != nil {
+		return false
+	}
+
+	for _, role := range m.Roles {
+		if slices.Contains(tc.roleIDs, role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkCooldown returns the remaining cooldown time of the caller, trusted callers are never throttled.
+func (bot *DiscordBot) checkCooldown(fetcher memberFetcher, i *discordgo.InteractionCreate) (time.Duration, bool) {
+	member := func() (*discordgo.Member, error) { return bot.guildMember(fetcher, i) }
+	if bot.trusted.isTrusted(i, member) {
+		return 0, true
+	}
+
+	return bot.limiter.allow(interactionUserID(i))
+}
+
+// interactionUserID returns the ID of the user who invoked the interaction.
+// The user is set for DMs and the member is set for guilds.
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+
+	if i.User != nil {
+		return i.User.ID
+	}
+
+	return ""
+}
@@ -0,0 +1,139 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func dmInteraction(userID string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			User: &discordgo.User{ID: userID},
+		},
+	}
+}
+
+func guildInteraction(userID string, roles ...string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			GuildID: "guild-1",
+			Member: &discordgo.Member{
+				User:  &discordgo.User{ID: userID},
+				Roles: roles,
+			},
+		},
+	}
+}
+
+func setupLimitedBot(t *testing.T) (*DiscordBot, *time.Time) {
+	t.Helper()
+
+	now := time.Unix(1_700_000_000, 0)
+	limiter := newUserLimiter(10 * time.Second)
+	limiter.nowFunc = func() time.Time { return now }
+
+	bot := &DiscordBot{
+		GuildID: "guild-1",
+		limiter: limiter,
+		trusted: &trustedCallers{
+			roleIDs: []string{"role-admin"},
+			userIDs: []string{"user-trusted"},
+		},
+	}
+
+	return bot, &now
+}
+
+func TestCooldown(t *testing.T) {
+	fetcher := &fakeFetcher{members: map[string]*discordgo.Member{
+		"user-admin": {Roles: []string{"role-member", "role-admin"}},
+		"user-1":     {Roles: []string{"role-member"}},
+	}}
+
+	t.Run("regular user is throttled", func(t *testing.T) {
+		bot, now := setupLimitedBot(t)
+		i := dmInteraction("user-1")
+
+		_, ok := bot.checkCooldown(fetcher, i)
+		assert.True(t, ok)
+
+		wait, ok := bot.checkCooldown(fetcher, i)
+		assert.False(t, ok)
+		assert.Equal(t, 10*time.Second, wait)
+
+		// other users are not affected.
+		_, ok = bot.checkCooldown(fetcher, dmInteraction("user-2"))
+		assert.True(t, ok)
+
+		*now = now.Add(10 * time.Second)
+		_, ok = bot.checkCooldown(fetcher, i)
+		assert.True(t, ok)
+	})
+
+	t.Run("trusted user bypasses in DMs", func(t *testing.T) {
+		bot, _ := setupLimitedBot(t)
+		i := dmInteraction("user-trusted")
+
+		for n := 0; n < 3; n++ {
+			_, ok := bot.checkCooldown(fetcher, i)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("trusted role bypasses in guilds", func(t *testing.T) {
+		bot, _ := setupLimitedBot(t)
+		i := guildInteraction("user-1", "role-member", "role-admin")
+
+		for n := 0; n < 3; n++ {
+			_, ok := bot.checkCooldown(fetcher, i)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("trusted role bypasses in DMs", func(t *testing.T) {
+		bot, _ := setupLimitedBot(t)
+		i := dmInteraction("user-admin")
+
+		for n := 0; n < 3; n++ {
+			_, ok := bot.checkCooldown(fetcher, i)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("unknown member is throttled", func(t *testing.T) {
+		bot, _ := setupLimitedBot(t)
+		i := dmInteraction("stranger")
+
+		_, ok := bot.checkCooldown(fetcher, i)
+		assert.True(t, ok)
+
+		_, ok = bot.checkCooldown(fetcher, i)
+		assert.False(t, ok)
+	})
+
+	t.Run("untrusted role is throttled", func(t *testing.T) {
+		bot, _ := setupLimitedBot(t)
+		i := guildInteraction("user-1", "role-member")
+
+		_, ok := bot.checkCooldown(fetcher, i)
+		assert.True(t, ok)
+
+		_, ok = bot.checkCooldown(fetcher, i)
+		assert.False(t, ok)
+	})
+
+	t.Run("disabled cooldown", func(t *testing.T) {
+		bot := &DiscordBot{
+			limiter: newUserLimiter(0),
+			trusted: &trustedCallers{},
+		}
+
+		for n := 0; n < 3; n++ {
+			_, ok := bot.checkCooldown(fetcher, dmInteraction("user-1"))
+			assert.True(t, ok)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package discord
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/log"
+)
+
+// messageSender is the part of the Discord session that posts and edits the messages.
+type messageSender interface {
+	ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed,
+		options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageEditEmbed(channelID, messageID string, embed *discordgo.MessageEmbed,
+		options ...discordgo.RequestOption) (*discordgo.Message, error)
+}
+
+// trackedMessages keeps the IDs of the messages that the bot updates in place, like the network summary.
+// The IDs are kept in memory, so the first update after a restart posts a new message.
+type trackedMessages struct {
+	lk  sync.Mutex
+	ids map[string]string
+}
+
+func newTrackedMessages() *trackedMessages {
+	return &trackedMessages{
+		ids: make(map[string]string),
+	}
+}
+
+func (tm *trackedMessages) get(key string) string {
+	tm.lk.Lock()
+	defer tm.lk.Unlock()
+
+	return tm.ids[key]
+}
+
+func (tm *trackedMessages) set(key, messageID string) {
+	tm.lk.Lock()
+	defer tm.lk.Unlock()
+
+	tm.ids[key] = messageID
+}
+
+// editOrRepost edits the tracked message of the key with the embed.
+// If there is no tracked message, or it has been deleted, a new message is posted and tracked instead.
+func editOrRepost(sender messageSender, tracked *trackedMessages, key, channelID string,
+	embed *discordgo.MessageEmbed,
+) (*discordgo.Message, error) {
+	if messageID := tracked.get(key); messageID != "" {
+		msg, err := sender.ChannelMessageEditEmbed(channelID, messageID, embed)
+		if err == nil {
+			return msg, nil
+		}
+
+		if !isUnknownMessage(err) {
+			return nil, err
+		}
+
+		log.Info("tracked message is deleted, posting a new one", "key", key, "messageID", messageID)
+	}
+
+	msg, err := sender.ChannelMessageSendEmbed(channelID, embed)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked.set(key, msg.ID)
+
+	return msg, nil
+}
+
+func isUnknownMessage(err error) bool {
+	var restErr *discordgo.RESTError
+
+	return errors.As(err, &restErr) && restErr.Message != nil &&
+		restErr.Message.Code == discordgo.ErrCodeUnknownMessage
+}
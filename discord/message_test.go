@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender keeps the posted messages in memory.
+type fakeSender struct {
+	messages map[string]*discordgo.MessageEmbed
+	nextID   int
+	sent     int
+	edited   int
+	editErr  error
+}
+
+func newFakeSender() *fakeSender {
+	return &fakeSender{messages: make(map[string]*discordgo.MessageEmbed)}
+}
+
+func (f *fakeSender) ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	f.sent++
+	f.nextID++
+	id := fmt.Sprintf("msg-%d", f.nextID)
+	f.messages[id] = embed
+
+	return &discordgo.Message{ID: id, ChannelID: channelID}, nil
+}
+
+func (f *fakeSender) ChannelMessageEditEmbed(channelID, messageID string, embed *discordgo.MessageEmbed,
+	_ ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	f.edited++
+	if f.editErr != nil {
+		return nil, f.editErr
+	}
+
+	if _, ok := f.messages[messageID]; !ok {
+		return nil, &discordgo.RESTError{
+			Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeUnknownMessage, Message: "Unknown Message"},
+		}
+	}
+	f.messages[messageID] = embed
+
+	return &discordgo.Message{ID: messageID, ChannelID: channelID}, nil
+}
+
+func TestEditOrRepost(t *testing.T) {
+	sender := newFakeSender()
+	tracked := newTrackedMessages()
+
+	msg, err := editOrRepost(sender, tracked, "summary", "channel-1", &discordgo.MessageEmbed{Title: "first"})
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", msg.ID)
+	assert.Equal(t, 1, sender.sent)
+
+	t.Run("edits the tracked message", func(t *testing.T) {
+		msg, err := editOrRepost(sender, tracked, "summary", "channel-1", &discordgo.MessageEmbed{Title: "second"})
+		require.NoError(t, err)
+		assert.Equal(t, "msg-1", msg.ID)
+		assert.Equal(t, 1, sender.sent)
+		assert.Equal(t, "second", sender.messages["msg-1"].Title)
+	})
+
+	t.Run("reposts a deleted message", func(t *testing.T) {
+		delete(sender.messages, "msg-1")
+
+		msg, err := editOrRepost(sender, tracked, "summary", "channel-1", &discordgo.MessageEmbed{Title: "third"})
+		require.NoError(t, err)
+		assert.Equal(t, "msg-2", msg.ID)
+		assert.Equal(t, 2, sender.sent)
+		assert.Equal(t, "msg-2", tracked.get("summary"))
+	})
+
+	t.Run("other errors are returned", func(t *testing.T) {
+		sender.editErr = errors.New("rate limited")
+		defer func() { sender.editErr = nil }()
+
+		_, err := editOrRepost(sender, tracked, "summary", "channel-1", &discordgo.MessageEmbed{Title: "fourth"})
+		assert.Error(t, err)
+		assert.Equal(t, 2, sender.sent)
+		assert.Equal(t, "msg-2", tracked.get("summary"))
+	})
+}
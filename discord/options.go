@@ -0,0 +1,188 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+)
+
+// applicationCommand declares the engine command as a Discord slash command.
+// The subcommands are declared as the subcommand options, each with the options of its arguments.
+func applicationCommand(beCmd engine.Command) *discordgo.ApplicationCommand {
+	discordCmd := &discordgo.ApplicationCommand{
+		Name:        beCmd.Name,
+		Description: beCmd.Desc,
+		Options:     make([]*discordgo.ApplicationCommandOption, 0, len(beCmd.Args)+len(beCmd.SubCommands)),
+	}
+
+	for _, sub := range beCmd.SubCommands {
+		subOpt := &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        sub.Name,
+			Description: sub.Desc,
+		}
+		for _, arg := range sub.Args {
+			subOpt.Options = append(subOpt.Options, commandOption(arg))
+		}
+
+		discordCmd.Options = append(discordCmd.Options, subOpt)
+	}
+
+	for _, arg := range beCmd.Args {
+		discordCmd.Options = append(discordCmd.Options, commandOption(arg))
+	}
+
+	return discordCmd
+}
+
+// commandInputs returns the engine inputs of the invoked command, the name of the subcommand comes
+// right after the name of the command, like the other apps.
+func commandInputs(data discordgo.ApplicationCommandInteractionData) []string {
+	inputs := []string{data.Name}
+	for _, opt := range data.Options {
+		if opt.Type == discordgo.ApplicationCommandOptionSubCommand {
+			inputs = append(inputs, opt.Name)
+			for _, subOpt := range opt.Options {
+				inputs = append(inputs, resolvedValue(data, subOpt))
+			}
+
+			continue
+		}
+
+		inputs = append(inputs, resolvedValue(data, opt))
+	}
+
+	return inputs
+}
+
+// resolvedValue returns the value of the option, the attachments are passed by their URLs.
+func resolvedValue(data discordgo.ApplicationCommandInteractionData,
+	opt *discordgo.ApplicationCommandInteractionDataOption,
+) string {
+	if opt.Type == discordgo.ApplicationCommandOptionAttachment {
+		id, _ := opt.Value.(string)
+		if data.Resolved != nil {
+			if attachment, ok := data.Resolved.Attachments[id]; ok {
+				return attachment.URL
+			}
+		}
+	}
+
+	return optionValue(opt)
+}
+
+// focusedOption returns the engine inputs of the command up to its subcommand,
+// and the option that is being typed, for the autocomplete.
+func focusedOption(data discordgo.ApplicationCommandInteractionData) ([]string,
+	*discordgo.ApplicationCommandInteractionDataOption,
+) {
+	inputs := []string{data.Name}
+	opts := data.Options
+	for _, opt := range data.Options {
+		if opt.Type == discordgo.ApplicationCommandOptionSubCommand {
+			inputs = append(inputs, opt.Name)
+			opts = opt.Options
+
+			break
+		}
+	}
+
+	for _, opt := range opts {
+		if opt.Focused {
+			return inputs, opt
+		}
+	}
+
+	return inputs, nil
+}
+
+// confirmPhrase returns the phrase that must be typed before running the inputs,
+// the subcommands have their own phrases.
+func confirmPhrase(cmd *engine.Command, inputs []string) string {
+	if cmd == nil {
+		return ""
+	}
+
+	if len(cmd.SubCommands) > 0 && len(inputs) > 1 {
+		if sub := cmd.SubCommand(inputs[1]); sub != nil {
+			return sub.ConfirmPhrase
+		}
+	}
+
+	return cmd.ConfirmPhrase
+}
+
+// commandOption declares the argument as a Discord option.
+// Discord validates the type and the range of the value, and the engine validates them again,
+// so both layers use the same constraints of the argument.
+func commandOption(arg engine.Args) *discordgo.ApplicationCommandOption {
+	opt := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        arg.Name,
+		Description: arg.Desc,
+		Required:    !arg.Optional,
+	}
+
+	switch arg.Type {
+	case engine.ArgTypeInteger:
+		opt.Type = discordgo.ApplicationCommandOptionInteger
+	case engine.ArgTypeNumber:
+		opt.Type = discordgo.ApplicationCommandOptionNumber
+	case engine.ArgTypeAttachment:
+		opt.Type = discordgo.ApplicationCommandOptionAttachment
+	case engine.ArgTypeUser:
+		opt.Type = discordgo.ApplicationCommandOptionUser
+	case engine.ArgTypeString:
+		if arg.Autocomplete != nil {
+			// Discord doesn't allow the choices of an autocomplete option, the engine suggests them instead.
+			opt.Autocomplete = true
+
+			break
+		}
+
+		for _, choice := range arg.Choices {
+			opt.Choices = append(opt.Choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  choice,
+				Value: choice,
+			})
+		}
+	}
+
+	if arg.Type == engine.ArgTypeInteger || arg.Type == engine.ArgTypeNumber {
+		opt.MinValue = arg.MinValue
+		if arg.MaxValue != nil {
+			opt.MaxValue = *arg.MaxValue
+		}
+	}
+
+	return opt
+}
+
+// optionValue returns the value of the option as the engine input.
+func optionValue(opt *discordgo.ApplicationCommandInteractionDataOption) string {
+	switch opt.Type { //nolint:exhaustive
+	case discordgo.ApplicationCommandOptionInteger:
+		return strconv.FormatInt(opt.IntValue(), 10)
+	case discordgo.ApplicationCommandOptionNumber:
+		return strconv.FormatFloat(opt.FloatValue(), 'f', -1, 64)
+	case discordgo.ApplicationCommandOptionUser:
+		return opt.UserValue(nil).ID
+	default:
+		return opt.StringValue()
+	}
+}
+
+// runErrMsg returns the message of an engine error.
+// The invalid option values are reported with the same constraints that the option declares.
+func runErrMsg(err error) string {
+	var argErr *engine.ArgError
+	if errors.As(err, &argErr) {
+		return fmt.Sprintf("Invalid value `%s` for option `%s`, expected %s.",
+			argErr.Value, argErr.Arg.Name, argErr.Arg.Constraint())
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,203 @@
+package discord
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandOption(t *testing.T) {
+	t.Run("string option with choices", func(t *testing.T) {
+		opt := commandOption(engine.Args{Name: "mode", Choices: []string{"a", "b"}})
+		assert.Equal(t, discordgo.ApplicationCommandOptionString, opt.Type)
+		assert.True(t, opt.Required)
+		require.Len(t, opt.Choices, 2)
+		assert.Equal(t, "b", opt.Choices[1].Value)
+	})
+
+	t.Run("string option with autocomplete", func(t *testing.T) {
+		opt := commandOption(engine.Args{
+			Name:         "address",
+			Choices:      []string{"a"},
+			Autocomplete: func(_, _ string) []string { return nil },
+		})
+		assert.True(t, opt.Autocomplete)
+		assert.Empty(t, opt.Choices)
+	})
+
+	t.Run("integer option with range", func(t *testing.T) {
+		opt := commandOption(engine.Args{
+			Name:     "count",
+			Optional: true,
+			Type:     engine.ArgTypeInteger,
+			MinValue: engine.Bound(1),
+			MaxValue: engine.Bound(10),
+		})
+		assert.Equal(t, discordgo.ApplicationCommandOptionInteger, opt.Type)
+		assert.False(t, opt.Required)
+		assert.Equal(t, 1.0, *opt.MinValue)
+		assert.Equal(t, 10.0, opt.MaxValue)
+	})
+}
+
+func TestOptionValue(t *testing.T) {
+	assert.Equal(t, "42", optionValue(&discordgo.ApplicationCommandInteractionDataOption{
+		Type: discordgo.ApplicationCommandOptionInteger, Value: float64(42),
+	}))
+	assert.Equal(t, "0.5", optionValue(&discordgo.ApplicationCommandInteractionDataOption{
+		Type: discordgo.ApplicationCommandOptionNumber, Value: 0.5,
+	}))
+	assert.Equal(t, "text", optionValue(&discordgo.ApplicationCommandInteractionDataOption{
+		Type: discordgo.ApplicationCommandOptionString, Value: "text",
+	}))
+	assert.Equal(t, "123", optionValue(&discordgo.ApplicationCommandInteractionDataOption{
+		Type: discordgo.ApplicationCommandOptionUser, Value: "123",
+	}))
+}
+
+func TestTypedOptionMismatch(t *testing.T) {
+	arg := engine.Args{
+		Name:     "count",
+		Type:     engine.ArgTypeInteger,
+		MinValue: engine.Bound(1),
+		MaxValue: engine.Bound(10),
+	}
+	cmd := engine.Command{Name: "cmd", Args: []engine.Args{arg}}
+	opt := commandOption(arg)
+
+	// 11 is an integer, so it passes the type check of Discord, but the engine rejects it.
+	input := optionValue(&discordgo.ApplicationCommandInteractionDataOption{
+		Type: opt.Type, Value: float64(11),
+	})
+	err := cmd.CheckArgs([]string{input})
+	require.Error(t, err)
+
+	msg := runErrMsg(err)
+	assert.Equal(t, "Invalid value `11` for option `count`, expected an integer between 1 and 10.", msg)
+	assert.Contains(t, msg, strconv.FormatFloat(*opt.MinValue, 'f', -1, 64))
+	assert.Contains(t, msg, strconv.FormatFloat(opt.MaxValue, 'f', -1, 64))
+
+	// other errors are reported as they are.
+	assert.Equal(t, "boom", runErrMsg(errors.New("boom")))
+}
+
+func TestApplicationCommandWithSubCommands(t *testing.T) {
+	discordCmd := applicationCommand(engine.Command{
+		Name: "wallet",
+		Desc: "the wallet",
+		SubCommands: []engine.Command{
+			{Name: "balance", Desc: "the balance"},
+			{Name: "address", Desc: "the address", Args: []engine.Args{{Name: "index", Optional: true}}},
+		},
+	})
+
+	require.Len(t, discordCmd.Options, 2)
+	assert.Equal(t, discordgo.ApplicationCommandOptionSubCommand, discordCmd.Options[0].Type)
+	assert.Equal(t, "balance", discordCmd.Options[0].Name)
+	assert.Empty(t, discordCmd.Options[0].Options)
+	require.Len(t, discordCmd.Options[1].Options, 1)
+	assert.Equal(t, "index", discordCmd.Options[1].Options[0].Name)
+}
+
+func TestCommandInputs(t *testing.T) {
+	t.Run("flat command", func(t *testing.T) {
+		inputs := commandInputs(discordgo.ApplicationCommandInteractionData{
+			Name: "calc-reward",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Type: discordgo.ApplicationCommandOptionInteger, Value: float64(100)},
+			},
+		})
+		assert.Equal(t, []string{"calc-reward", "100"}, inputs)
+	})
+
+	t.Run("subcommand", func(t *testing.T) {
+		inputs := commandInputs(discordgo.ApplicationCommandInteractionData{
+			Name: "wallet",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "address",
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{
+						{Type: discordgo.ApplicationCommandOptionString, Value: "2"},
+					},
+				},
+			},
+		})
+		assert.Equal(t, []string{"wallet", "address", "2"}, inputs)
+	})
+
+	t.Run("attachment", func(t *testing.T) {
+		inputs := commandInputs(discordgo.ApplicationCommandInteractionData{
+			Name: "payout",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "load",
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{
+						{Type: discordgo.ApplicationCommandOptionAttachment, Value: "42"},
+					},
+				},
+			},
+			Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+				Attachments: map[string]*discordgo.MessageAttachment{
+					"42": {ID: "42", URL: "https://cdn.discordapp.com/attachments/1/42/payout.csv"},
+				},
+			},
+		})
+		assert.Equal(t, []string{"payout", "load", "https://cdn.discordapp.com/attachments/1/42/payout.csv"}, inputs)
+	})
+}
+
+func TestFocusedOption(t *testing.T) {
+	t.Run("command option", func(t *testing.T) {
+		inputs, opt := focusedOption(discordgo.ApplicationCommandInteractionData{
+			Name: "node-info",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "validator-address", Type: discordgo.ApplicationCommandOptionString, Value: "pc1p", Focused: true},
+			},
+		})
+		assert.Equal(t, []string{"node-info"}, inputs)
+		require.NotNil(t, opt)
+		assert.Equal(t, "validator-address", opt.Name)
+	})
+
+	t.Run("subcommand option", func(t *testing.T) {
+		inputs, opt := focusedOption(discordgo.ApplicationCommandInteractionData{
+			Name: "wallet",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{
+					Name: "send",
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{
+						{Name: "amount", Type: discordgo.ApplicationCommandOptionNumber, Value: "1"},
+						{Name: "address", Type: discordgo.ApplicationCommandOptionString, Value: "pc1", Focused: true},
+					},
+				},
+			},
+		})
+		assert.Equal(t, []string{"wallet", "send"}, inputs)
+		require.NotNil(t, opt)
+		assert.Equal(t, "address", opt.Name)
+	})
+
+	t.Run("no focused option", func(t *testing.T) {
+		_, opt := focusedOption(discordgo.ApplicationCommandInteractionData{Name: "help"})
+		assert.Nil(t, opt)
+	})
+}
+
+func TestConfirmPhrase(t *testing.T) {
+	cmd := &engine.Command{
+		Name:        "wallet",
+		SubCommands: []engine.Command{{Name: "reset", ConfirmPhrase: "reset the wallet"}, {Name: "balance"}},
+	}
+
+	assert.Equal(t, "reset the wallet", confirmPhrase(cmd, []string{"wallet", "reset"}))
+	assert.Empty(t, confirmPhrase(cmd, []string{"wallet", "balance"}))
+	assert.Empty(t, confirmPhrase(nil, []string{"unknown"}))
+}
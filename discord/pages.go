@@ -0,0 +1,89 @@
+package discord
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+const pagePrefix = "page:"
+
+// pageButtons renders the previous and the next page buttons of the list, like "page:<id>:2".
+// The ID refers to the inputs of the list command, they are run again with the page.
+func pageButtons(id string, list *engine.ListResult) discordgo.ActionsRow {
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    "◀ Prev",
+				Style:    discordgo.SecondaryButton,
+				CustomID: fmt.Sprintf("%s%s:%d", pagePrefix, id, list.Page-1),
+				Disabled: !list.HasPrev(),
+			},
+			discordgo.Button{
+				Label:    "Next ▶",
+				Style:    discordgo.SecondaryButton,
+				CustomID: fmt.Sprintf("%s%s:%d", pagePrefix, id, list.Page+1),
+				Disabled: !list.HasNext(),
+			},
+		},
+	}
+}
+
+// parsePageID parses the custom ID of a page button into the list ID and the page.
+func parsePageID(customID string) (string, int, error) {
+	id, pageStr, ok := strings.Cut(strings.TrimPrefix(customID, pagePrefix), ":")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid page button: %s", customID)
+	}
+
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		return "", 0, fmt.Errorf("invalid page button: %s", customID)
+	}
+
+	return id, page, nil
+}
+
+// pageHandler runs the list command of a clicked page button with the page, and updates its message.
+func (bot *DiscordBot) pageHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	userID := interactionUserID(i)
+	listID, page, err := parsePageID(i.MessageComponentData().CustomID)
+	if err != nil {
+		bot.respondErrMsg(err.Error(), s, i)
+		return
+	}
+
+	list, err := bot.components.get(listID, userID, 0)
+	if err != nil {
+		bot.respondErrMsg(err.Error(), s, i)
+		return
+	}
+	inputs := list.Inputs
+
+	cmd := bot.BotEngine.FindCommand(inputs[0])
+	if cmd == nil {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgUnknownCommand, inputs[0]), s, i)
+		return
+	}
+
+	if !bot.BotEngine.IsCommandEnabled(cmd.Name, bot.GuildID) {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgGuildCommandDisabled, cmd.Name), s, i)
+		return
+	}
+
+	pageInputs := cmd.PageInputs(inputs, page)
+	log.Debug("page clicked", "requestID", requestID(i), "command", cmd.Name, "inputs", pageInputs)
+
+	res, err := bot.BotEngine.RunWithOptions(bot.runOptions(s, i), engine.AppIdDiscord, userID, pageInputs)
+	if err != nil {
+		bot.respondErrMsg(runErrMsg(err), s, i)
+		return
+	}
+
+	// the next clicks run the same inputs with their pages.
+	bot.updateResultMsg(res, listID, inputs, s, i)
+}
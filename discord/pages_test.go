@@ -0,0 +1,34 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageButtons(t *testing.T) {
+	row := pageButtons("abc", &engine.ListResult{Total: 25, Page: 1, PageSize: 10})
+	require.Len(t, row.Components, 2)
+
+	prev, ok := row.Components[0].(discordgo.Button)
+	require.True(t, ok)
+	assert.True(t, prev.Disabled)
+
+	next, ok := row.Components[1].(discordgo.Button)
+	require.True(t, ok)
+	assert.False(t, next.Disabled)
+	assert.Equal(t, "page:abc:2", next.CustomID)
+
+	id, page, err := parsePageID(next.CustomID)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", id)
+	assert.Equal(t, 2, page)
+
+	_, _, err = parsePageID("page:abc:0")
+	assert.Error(t, err)
+	_, _, err = parsePageID("page:abc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package discord
+
+import (
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// requestID returns a short ID for the interaction to correlate the logs of one execution.
+// It's derived from the interaction ID, so all the handlers of an interaction share it
+// and it can be matched with the interaction on the Discord side.
+func requestID(i *discordgo.InteractionCreate) string {
+	if i == nil || i.Interaction == nil {
+		return ""
+	}
+
+	snowflake, err := strconv.ParseUint(i.ID, 10, 64)
+	if err != nil {
+		return i.ID
+	}
+
+	return strconv.FormatUint(snowflake, 36)
+}
+
+// errorEmbed builds the error embed, the request ID is shown in the footer
+// so users can share it when reporting an issue.
+func errorEmbed(title, errStr, reqID string) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: errStr,
+		Color:       RED,
+	}
+
+	if reqID != "" {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: "Request ID: " + reqID,
+		}
+	}
+
+	return embed
+}
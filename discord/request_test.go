@@ -0,0 +1,34 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	i := dmInteraction("user-1")
+	i.ID = "1163201463487311933"
+
+	reqID := requestID(i)
+	assert.Equal(t, "8u5ci7asjzul", reqID)
+	assert.Equal(t, reqID, requestID(i), "the ID should be stable for the interaction")
+
+	other := dmInteraction("user-1")
+	other.ID = "1163201463487311934"
+	assert.NotEqual(t, reqID, requestID(other))
+
+	assert.Empty(t, requestID(&discordgo.InteractionCreate{}))
+}
+
+func TestErrorEmbed(t *testing.T) {
+	embed := errorEmbed("Error", "node is down", "8u5ci7asjzul")
+	assert.Equal(t, RED, embed.Color)
+	assert.Equal(t, "node is down", embed.Description)
+	require.NotNil(t, embed.Footer)
+	assert.Equal(t, "Request ID: 8u5ci7asjzul", embed.Footer.Text)
+
+	assert.Nil(t, errorEmbed("Error", "node is down", "").Footer)
+}
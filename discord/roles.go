@@ -0,0 +1,64 @@
+package discord
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+var errNoGuild = errors.New("the guild of the bot is not set")
+
+// memberFetcher is the part of the Discord session that fetches the guild members.
+type memberFetcher interface {
+	GuildMember(guildID, userID string, options ...discordgo.RequestOption) (*discordgo.Member, error)
+}
+
+// guildMember returns the caller as a member of the guild of the bot.
+// The members are not sent with the DM interactions, so they are fetched from the guild.
+func (bot *DiscordBot) guildMember(fetcher memberFetcher, i *discordgo.InteractionCreate) (*discordgo.Member, error) {
+	if i.Member != nil {
+		return i.Member, nil
+	}
+
+	if bot.GuildID == "" {
+		return nil, errNoGuild
+	}
+
+	return fetcher.GuildMember(bot.GuildID, interactionUserID(i))
+}
+
+// callerRole resolves the engine role of the caller from the admin roles.
+// The roles are not available in DMs, so the member is fetched from the guild of the bot.
+// The admin user IDs are resolved by the engine, see engine.BotEngine.SetAuthorizer.
+func (bot *DiscordBot) callerRole(fetcher memberFetcher, i *discordgo.InteractionCreate) engine.Role {
+	if len(bot.adminRoleIDs) == 0 {
+		return engine.RoleUser
+	}
+
+	member, err := bot.guildMember(fetcher, i)
+	if err != nil {
+		if !errors.Is(err, errNoGuild) {
+			log.Warn("unable to get the guild member", "requestID", requestID(i), "error", err)
+		}
+
+		return engine.RoleUser
+	}
+
+	for _, role := range member.Roles {
+		if slices.Contains(bot.adminRoleIDs, role) {
+			return engine.RoleAdmin
+		}
+	}
+
+	return engine.RoleUser
+}
+
+func (bot *DiscordBot) runOptions(fetcher memberFetcher, i *discordgo.InteractionCreate) engine.RunOptions {
+	return engine.RunOptions{
+		RequestID: requestID(i),
+		Role:      bot.callerRole(fetcher, i),
+	}
+}
@@ -0,0 +1,77 @@
+package discord
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher struct {
+	members map[string]*discordgo.Member
+	calls   int
+}
+
+func (f *fakeFetcher) GuildMember(_, userID string, _ ...discordgo.RequestOption) (*discordgo.Member, error) {
+	f.calls++
+
+	member, ok := f.members[userID]
+	if !ok {
+		return nil, errors.New("unknown member")
+	}
+
+	return member, nil
+}
+
+func TestCallerRole(t *testing.T) {
+	bot := &DiscordBot{GuildID: "guild-1", adminRoleIDs: []string{"admin-role"}}
+	fetcher := &fakeFetcher{members: map[string]*discordgo.Member{
+		"admin-1": {Roles: []string{"member-role", "admin-role"}},
+		"user-1":  {Roles: []string{"member-role"}},
+	}}
+
+	t.Run("guild interactions", func(t *testing.T) {
+		assert.Equal(t, engine.RoleAdmin, bot.callerRole(fetcher, guildInteraction("admin-2", "admin-role")))
+		assert.Equal(t, engine.RoleUser, bot.callerRole(fetcher, guildInteraction("user-2", "member-role")))
+		assert.Zero(t, fetcher.calls)
+	})
+
+	t.Run("DMs", func(t *testing.T) {
+		assert.Equal(t, engine.RoleAdmin, bot.callerRole(fetcher, dmInteraction("admin-1")))
+		assert.Equal(t, engine.RoleUser, bot.callerRole(fetcher, dmInteraction("user-1")))
+		assert.Equal(t, engine.RoleUser, bot.callerRole(fetcher, dmInteraction("stranger")))
+	})
+
+	t.Run("no admin roles", func(t *testing.T) {
+		fetcher.calls = 0
+		noRoles := &DiscordBot{GuildID: "guild-1"}
+
+		assert.Equal(t, engine.RoleUser, noRoles.callerRole(fetcher, dmInteraction("admin-1")))
+		assert.Zero(t, fetcher.calls)
+	})
+}
+
+func TestGuildMember(t *testing.T) {
+	fetcher := &fakeFetcher{members: map[string]*discordgo.Member{
+		"user-1": {Roles: []string{"member-role"}},
+	}}
+	bot := &DiscordBot{GuildID: "guild-1"}
+
+	member, err := bot.guildMember(fetcher, guildInteraction("user-2", "admin-role"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin-role"}, member.Roles)
+	assert.Zero(t, fetcher.calls)
+
+	member, err = bot.guildMember(fetcher, dmInteraction("user-1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"member-role"}, member.Roles)
+
+	_, err = bot.guildMember(fetcher, dmInteraction("stranger"))
+	assert.Error(t, err)
+
+	_, err = (&DiscordBot{}).guildMember(fetcher, dmInteraction("user-1"))
+	assert.ErrorIs(t, err, errNoGuild)
+}
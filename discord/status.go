@@ -0,0 +1,60 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/market"
+	"github.com/kehiy/RoboPac/utils"
+)
+
+// statusJobName is the name of the scheduled job that updates the bot presence.
+const statusJobName = "discord-status"
+
+type statusItem struct {
+	name  string
+	value string
+}
+
+// statusItems returns the status items of the networks, shown one by one in the cycle mode.
+// If there is more than one network, the items are named by their network, like "testnet height".
+// The price is shown too, if it's available.
+func statusItems(statuses []*engine.NetStatus, price *market.Price) []statusItem {
+	items := []statusItem{}
+	for _, ns := range statuses {
+		prefix := ""
+		if len(statuses) > 1 {
+			prefix = ns.Network + " "
+		}
+
+		items = append(items,
+			statusItem{prefix + "validators count", utils.FormatNumber(int64(ns.ValidatorsCount))},
+			statusItem{prefix + "total accounts", utils.FormatNumber(int64(ns.TotalAccounts))},
+			statusItem{prefix + "height", utils.FormatNumber(int64(ns.CurrentBlockHeight))},
+			statusItem{prefix + "circ supply", utils.FormatNumber(int64(utils.ChangeToCoin(ns.CirculatingSupply))) + " PAC"},
+			statusItem{prefix + "total power", utils.FormatNumber(int64(utils.ChangeToCoin(ns.TotalNetworkPower))) + " PAC"},
+		)
+	}
+
+	if price != nil {
+		items = append(items, statusItem{"price", fmt.Sprintf("%s (%+.1f%%)", engine.FormatUSD(price.USD), price.Change24h)})
+	}
+
+	return items
+}
+
+// combinedStatus returns a short status with the main information of the primary network, like:
+// "H: 123.4k | Vals: 900 | Supply: 1.2M PAC". The heights of the other networks are added, like "testnet H: 45.6k".
+func combinedStatus(statuses []*engine.NetStatus) string {
+	ns := statuses[0]
+	status := fmt.Sprintf("H: %s | Vals: %s | Supply: %s PAC",
+		utils.FormatCompactNumber(int64(ns.CurrentBlockHeight)),
+		utils.FormatCompactNumber(int64(ns.ValidatorsCount)),
+		utils.FormatCompactNumber(int64(utils.ChangeToCoin(ns.CirculatingSupply))))
+
+	for _, other := range statuses[1:] {
+		status += fmt.Sprintf(" | %s H: %s", other.Network, utils.FormatCompactNumber(int64(other.CurrentBlockHeight)))
+	}
+
+	return status
+}
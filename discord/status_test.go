@@ -0,0 +1,49 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/market"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombinedStatus(t *testing.T) {
+	ns := &engine.NetStatus{
+		CurrentBlockHeight: 123_456,
+		ValidatorsCount:    900,
+		CirculatingSupply:  1_234_567 * 1e9,
+	}
+
+	status := combinedStatus([]*engine.NetStatus{ns})
+	assert.Equal(t, "H: 123.5k | Vals: 900 | Supply: 1.2M PAC", status)
+
+	testnet := &engine.NetStatus{Network: "testnet", CurrentBlockHeight: 45_678}
+	status = combinedStatus([]*engine.NetStatus{ns, testnet})
+	assert.Equal(t, "H: 123.5k | Vals: 900 | Supply: 1.2M PAC | testnet H: 45.7k", status)
+
+	// discord doesn't accept custom statuses longer than 128 characters.
+	assert.LessOrEqual(t, len(status), 128)
+}
+
+func TestStatusItems(t *testing.T) {
+	ns := &engine.NetStatus{
+		CurrentBlockHeight: 123_456,
+		ValidatorsCount:    900,
+	}
+
+	items := statusItems([]*engine.NetStatus{ns}, nil)
+	assert.Len(t, items, 5)
+	assert.Equal(t, statusItem{"height", "123,456"}, items[2])
+
+	items = statusItems([]*engine.NetStatus{ns}, &market.Price{USD: 0.35, Change24h: 2.45})
+	assert.Len(t, items, 6)
+	assert.Equal(t, statusItem{"price", "$0.3500 (+2.5%)"}, items[5])
+
+	ns.Network = "mainnet"
+	testnet := &engine.NetStatus{Network: "testnet", CurrentBlockHeight: 45_678}
+	items = statusItems([]*engine.NetStatus{ns, testnet}, nil)
+	assert.Len(t, items, 10)
+	assert.Equal(t, statusItem{"mainnet height", "123,456"}, items[2])
+	assert.Equal(t, statusItem{"testnet height", "45,678"}, items[7])
+}
@@ -0,0 +1,104 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+const (
+	suggestionPrefix = "suggest:"
+
+	// Discord allows up to 5 buttons in a row, more suggestions are shown as a hint.
+	maxSuggestionButtons = 5
+)
+
+// suggestionComponents renders the suggestions as buttons, or returns nil if they don't fit in a row.
+func suggestionComponents(suggestions []string) []discordgo.MessageComponent {
+	if len(suggestions) == 0 || len(suggestions) > maxSuggestionButtons {
+		return nil
+	}
+
+	buttons := make([]discordgo.MessageComponent, 0, len(suggestions))
+	for _, cmdName := range suggestions {
+		buttons = append(buttons, discordgo.Button{
+			Label:    "/" + cmdName,
+			Style:    discordgo.SecondaryButton,
+			CustomID: suggestionPrefix + cmdName,
+		})
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: buttons},
+	}
+}
+
+// suggestionHint renders the suggestions as a footer hint, if they don't fit as buttons.
+func suggestionHint(suggestions []string) string {
+	if len(suggestions) <= maxSuggestionButtons {
+		return ""
+	}
+
+	return fmt.Sprintf("Try next: /%s", strings.Join(suggestions, ", /"))
+}
+
+// suggestionInputs returns the inputs to run for a clicked suggestion.
+// If the command needs arguments, the help of the command is shown instead.
+func suggestionInputs(cmd *engine.Command) []string {
+	if cmd.HasRequiredArgs() {
+		return []string{engine.HelpCommandName, cmd.Name}
+	}
+
+	return []string{cmd.Name}
+}
+
+// suggestionHandler runs the command of a clicked suggestion button.
+// The command passes the same checks as a slash command.
+func (bot *DiscordBot) suggestionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	cmdName := strings.TrimPrefix(i.MessageComponentData().CustomID, suggestionPrefix)
+	cmd := bot.BotEngine.FindCommand(cmdName)
+	if cmd == nil {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgUnknownCommand, cmdName), s, i)
+		return
+	}
+
+	if !bot.BotEngine.IsCommandEnabled(cmdName, bot.GuildID) {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgGuildCommandDisabled, cmdName), s, i)
+		return
+	}
+
+	if reason := bot.checkAgeGate(s, cmd, i, time.Now()); reason != "" {
+		bot.respondEphemeralEmbed(&discordgo.MessageEmbed{
+			Title:       bot.messages(i).Get(engine.MsgTitleError),
+			Description: reason,
+			Color:       RED,
+		}, s, i)
+
+		return
+	}
+
+	if wait, ok := bot.checkCooldown(s, i); !ok {
+		bot.respondErrMsg(bot.messages(i).Get(engine.MsgCooldown, max(wait.Round(time.Second), time.Second)), s, i)
+		return
+	}
+
+	inputs := suggestionInputs(cmd)
+	if inputs[0] == cmdName && cmd.ConfirmPhrase != "" {
+		bot.askConfirmation(cmd.ConfirmPhrase, inputs, s, i)
+		return
+	}
+
+	log.Debug("suggestion clicked", "requestID", requestID(i), "command", cmdName, "inputs", inputs)
+
+	res, err := bot.BotEngine.RunWithOptions(bot.runOptions(s, i), engine.AppIdDiscord, interactionUserID(i), inputs)
+	if err != nil {
+		bot.respondErrMsg(runErrMsg(err), s, i)
+		return
+	}
+
+	bot.respondResultMsg(res, inputs, s, i)
+}
@@ -0,0 +1,51 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestionComponents(t *testing.T) {
+	t.Run("no suggestions", func(t *testing.T) {
+		assert.Nil(t, suggestionComponents(nil))
+		assert.Empty(t, suggestionHint(nil))
+	})
+
+	t.Run("rendered as buttons", func(t *testing.T) {
+		components := suggestionComponents([]string{"node-info", "me"})
+		require.Len(t, components, 1)
+
+		row, ok := components[0].(discordgo.ActionsRow)
+		require.True(t, ok)
+		require.Len(t, row.Components, 2)
+
+		button, ok := row.Components[0].(discordgo.Button)
+		require.True(t, ok)
+		assert.Equal(t, "/node-info", button.Label)
+		assert.Equal(t, "suggest:node-info", button.CustomID)
+
+		assert.Empty(t, suggestionHint([]string{"node-info", "me"}))
+	})
+
+	t.Run("too many for buttons", func(t *testing.T) {
+		suggestions := []string{"a", "b", "c", "d", "e", "f"}
+
+		assert.Nil(t, suggestionComponents(suggestions))
+		assert.Equal(t, "Try next: /a, /b, /c, /d, /e, /f", suggestionHint(suggestions))
+	})
+}
+
+func TestSuggestionInputs(t *testing.T) {
+	noArgs := &engine.Command{Name: "me"}
+	assert.Equal(t, []string{"me"}, suggestionInputs(noArgs))
+
+	optionalArgs := &engine.Command{Name: "node-info", Args: []engine.Args{{Name: "validator_address", Optional: true}}}
+	assert.Equal(t, []string{"node-info"}, suggestionInputs(optionalArgs))
+
+	requiredArgs := &engine.Command{Name: "link", Args: []engine.Args{{Name: "validator_address"}}}
+	assert.Equal(t, []string{"help", "link"}, suggestionInputs(requiredArgs))
+}
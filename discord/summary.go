@@ -0,0 +1,112 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/kehiy/RoboPac/utils"
+)
+
+const (
+	summaryJobName      = "network-summary"
+	summaryPostAttempts = 3
+	summaryPostBackoff  = 10 * time.Second
+)
+
+// networkSummaryEmbed renders the network summary, posted periodically to the summary channel.
+func networkSummaryEmbed(ns *engine.NetStatus, lastBlockTime, now time.Time) *discordgo.MessageEmbed {
+	lastBlock := "not available"
+	if !lastBlockTime.IsZero() {
+		lastBlock = fmt.Sprintf("<t:%d:R>", lastBlockTime.Unix())
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: "Network Summary📊",
+		Color: PACTUS,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Height", Value: utils.FormatNumber(int64(ns.CurrentBlockHeight)), Inline: true},
+			{Name: "Validators", Value: utils.FormatNumber(int64(ns.ValidatorsCount)), Inline: true},
+			{Name: "Peers", Value: utils.FormatNumber(int64(ns.ConnectedPeersCount)), Inline: true},
+			{
+				Name:   "Circulating Supply",
+				Value:  utils.FormatNumber(int64(utils.ChangeToCoin(ns.CirculatingSupply))) + " PAC",
+				Inline: true,
+			},
+			{Name: "Last Block", Value: lastBlock, Inline: true},
+		},
+		Timestamp: now.Format(time.RFC3339),
+	}
+
+	if len(ns.Warnings) > 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("⚠️ %d sub-queries failed, the summary is partial", len(ns.Warnings)),
+		}
+	}
+
+	return embed
+}
+
+// sendWithRetry calls send until it succeeds or the attempts are exhausted,
+// waiting longer between each attempt.
+func sendWithRetry(send func() error, attempts int, backoff time.Duration, sleep func(time.Duration)) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = send(); err == nil {
+			return nil
+		}
+
+		log.Warn("unable to post the network summary", "attempt", attempt, "error", err)
+		if attempt < attempts {
+			sleep(time.Duration(attempt) * backoff)
+		}
+	}
+
+	return err
+}
+
+// postNetworkSummary posts the network summary to the summary channel.
+// If the edit mode is enabled, the last summary message is updated instead.
+func (bot *DiscordBot) postNetworkSummary() {
+	ns, err := bot.BotEngine.CachedNetworkStatus()
+	if err != nil {
+		log.Error("unable to get the network status for the summary", "error", err)
+		return
+	}
+
+	embed := networkSummaryEmbed(ns, bot.BotEngine.LastBlockTime(), time.Now())
+	err = sendWithRetry(func() error {
+		if bot.summaryEdit {
+			_, err := editOrRepost(bot.Session, bot.tracked, summaryJobName, bot.summaryChannelID, embed)
+
+			return err
+		}
+
+		_, err := bot.Session.ChannelMessageSendEmbed(bot.summaryChannelID, embed)
+
+		return err
+	}, summaryPostAttempts, summaryPostBackoff, time.Sleep)
+	if err != nil {
+		log.Error("unable to post the network summary", "error", discordErrMsg(err, bot.summaryChannelID))
+		return
+	}
+
+	log.Info("network summary posted", "channelID", bot.summaryChannelID)
+}
+
+// scheduleNetworkSummary schedules the network summary posts, if it's enabled.
+func (bot *DiscordBot) scheduleNetworkSummary() error {
+	if bot.summarySchedule == "" || bot.summaryChannelID == "" {
+		return nil
+	}
+
+	return bot.BotEngine.Schedule(scheduler.Job{
+		Name: summaryJobName,
+		Spec: bot.summarySchedule,
+		Run:  func(_ context.Context) { bot.postNetworkSummary() },
+	})
+}
@@ -0,0 +1,78 @@
+package discord
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkSummaryEmbed(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ns := &engine.NetStatus{
+		CurrentBlockHeight:  123_456,
+		ValidatorsCount:     900,
+		ConnectedPeersCount: 42,
+		CirculatingSupply:   1_000_000 * 1e9,
+	}
+
+	embed := networkSummaryEmbed(ns, now.Add(-10*time.Second), now)
+
+	values := map[string]string{}
+	for _, f := range embed.Fields {
+		values[f.Name] = f.Value
+	}
+
+	assert.Equal(t, "123,456", values["Height"])
+	assert.Equal(t, "900", values["Validators"])
+	assert.Equal(t, "42", values["Peers"])
+	assert.Equal(t, "1,000,000 PAC", values["Circulating Supply"])
+	assert.Equal(t, "<t:1699999990:R>", values["Last Block"])
+	assert.Nil(t, embed.Footer)
+
+	t.Run("partial status", func(t *testing.T) {
+		ns.Warnings = []string{"circulating supply is not available"}
+
+		embed := networkSummaryEmbed(ns, time.Time{}, now)
+		assert.NotNil(t, embed.Footer)
+		assert.Equal(t, "not available", embed.Fields[4].Value)
+	})
+}
+
+func TestSendWithRetry(t *testing.T) {
+	var sleeps []time.Duration
+	sleep := func(d time.Duration) { sleeps = append(sleeps, d) }
+
+	t.Run("succeeds after failures", func(t *testing.T) {
+		sleeps = nil
+		calls := 0
+		err := sendWithRetry(func() error {
+			calls++
+			if calls < 3 {
+				return errors.New("discord is down")
+			}
+
+			return nil
+		}, 3, time.Second, sleep)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, sleeps)
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		sleeps = nil
+		calls := 0
+		err := sendWithRetry(func() error {
+			calls++
+
+			return errors.New("discord is down")
+		}, 3, time.Second, sleep)
+
+		assert.Error(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Len(t, sleeps, 2)
+	})
+}
@@ -1,9 +1,14 @@
 package discord
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/kehiy/RoboPac/engine"
 )
 
 const (
@@ -11,20 +16,215 @@ const (
 	RED    = 0xFF0000
 	YELLOW = 0xFFFF00
 	PACTUS = 0x052D5A
+	CALM   = 0x5DADE2
+
+	maxDescriptionLength = 4096
 )
 
 func newStatus(name string, value interface{}) discordgo.UpdateStatusData {
+	return newCustomStatus(fmt.Sprintf("%s: %v", name, value))
+}
+
+func newCustomStatus(text string) discordgo.UpdateStatusData {
 	return discordgo.UpdateStatusData{
 		Status: "online",
 		Activities: []*discordgo.Activity{
 			{
 				Type:     discordgo.ActivityTypeCustom,
-				Name:     fmt.Sprintf("%s: %v", name, value),
+				Name:     text,
 				URL:      "",
-				State:    fmt.Sprintf("%s: %v", name, value),
-				Details:  fmt.Sprintf("%s: %v", name, value),
+				State:    text,
+				Details:  text,
 				Instance: true,
 			},
 		},
 	}
 }
+
+// discordErrMsg turns the Discord API errors into a readable message.
+// Missing permission errors are reported clearly, so admins know what to fix.
+func discordErrMsg(err error, channelID string) string {
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Message != nil {
+		switch restErr.Message.Code {
+		case discordgo.ErrCodeMissingPermissions:
+			return fmt.Sprintf("I don't have permission to post in <#%s>, "+
+				"please check the channel permissions of the bot", channelID)
+
+		case discordgo.ErrCodeMissingAccess:
+			return fmt.Sprintf("I can't access <#%s>, please add the bot to the channel", channelID)
+
+		case discordgo.ErrCodeUnknownChannel:
+			return fmt.Sprintf("channel `%s` not found", channelID)
+		}
+	}
+
+	return err.Error()
+}
+
+// resultDescriptions prepends the warnings of the result to its message, and appends the table of the result
+// as a code block. The long descriptions are split into the parts that fit in an embed.
+func resultDescriptions(res *engine.CommandResult) []string {
+	text := ""
+	for _, w := range res.Warnings {
+		text += fmt.Sprintf("⚠️ %s\n", w)
+	}
+
+	if text != "" {
+		text += "\n"
+	}
+
+	text += res.Message
+	parts := splitText(text, maxDescriptionLength)
+	if res.Table == nil {
+		return parts
+	}
+
+	// the table is kept in one part, it's moved to its own part if it doesn't fit in the last one.
+	last := parts[len(parts)-1]
+	if last != "" {
+		table := codeBlock(res.Table.String(), maxDescriptionLength)
+		if utf8.RuneCountInString(last)+len("\n\n")+utf8.RuneCountInString(table) > maxDescriptionLength {
+			return append(parts, table)
+		}
+		last += "\n\n"
+	}
+	parts[len(parts)-1] = last + codeBlock(res.Table.String(), maxDescriptionLength-utf8.RuneCountInString(last))
+
+	return parts
+}
+
+// splitText splits the text into the parts of at most the limit characters, by the lines if possible.
+// The empty text is one empty part.
+func splitText(text string, limit int) []string {
+	parts := []string{}
+	for utf8.RuneCountInString(text) > limit {
+		runes := []rune(text)
+		cut := strings.LastIndex(string(runes[:limit]), "\n")
+		if cut <= 0 {
+			cut = len(string(runes[:limit]))
+		}
+
+		parts = append(parts, text[:cut])
+		text = strings.TrimPrefix(text[cut:], "\n")
+	}
+
+	return append(parts, text)
+}
+
+// codeBlock wraps the text in a code block of at most the limit characters.
+// The lines that don't fit are dropped, and it's noted at the end of the block.
+func codeBlock(text string, limit int) string {
+	const fence = "```"
+
+	lines := strings.Split(text, "\n")
+	for kept := len(lines); kept >= 0; kept-- {
+		body := strings.Join(lines[:kept], "\n")
+		if kept < len(lines) {
+			body += fmt.Sprintf("\n… %d more lines", len(lines)-kept)
+		}
+
+		block := fence + "\n" + body + "\n" + fence
+		if utf8.RuneCountInString(block) <= limit {
+			return block
+		}
+	}
+
+	return ""
+}
+
+// resultFiles returns the attachment of the result as the files of the Discord message.
+func resultFiles(res *engine.CommandResult) []*discordgo.File {
+	if res.Attachment == nil {
+		return nil
+	}
+
+	return []*discordgo.File{{
+		Name:        res.Attachment.Name,
+		ContentType: res.Attachment.ContentType,
+		Reader:      bytes.NewReader(res.Attachment.Data),
+	}}
+}
+
+// resultEmbeds renders the result of a command, in more than one embed if its description doesn't fit in one.
+// The first embed has the title, and the last one has the fields, the image and the footer,
+// the parts are numbered in their footers, like "Part 1/2".
+// The results rejected by the maintenance mode have a calm style, so users don't take them for a failure.
+func resultEmbeds(res *engine.CommandResult, messages *engine.MessageCatalog) []*discordgo.MessageEmbed {
+	descs := resultDescriptions(res)
+
+	var resEmbed *discordgo.MessageEmbed
+	switch {
+	case res.Maintenance:
+		resEmbed = &discordgo.MessageEmbed{
+			Title:       "🔧 " + messages.Get(engine.MsgTitleMaintenance),
+			Description: descs[0],
+			Color:       CALM,
+		}
+	case res.Successful && res.Branded:
+		resEmbed = &discordgo.MessageEmbed{
+			Title:       messages.Get(engine.MsgTitleSuccessful),
+			Description: descs[0],
+			Color:       PACTUS,
+		}
+	case res.Successful:
+		resEmbed = &discordgo.MessageEmbed{
+			Title:       messages.Get(engine.MsgTitleSuccessful),
+			Description: descs[0],
+			Color:       GREEN,
+		}
+	default:
+		resEmbed = &discordgo.MessageEmbed{
+			Title:       messages.Get(engine.MsgTitleFailed),
+			Description: descs[0],
+			Color:       YELLOW,
+		}
+	}
+
+	if res.Title != "" && !res.Maintenance {
+		resEmbed.Title = res.Title
+	}
+
+	footer := []string{}
+	if len(descs) > 1 {
+		footer = append(footer, fmt.Sprintf("Part %d/%d", len(descs), len(descs)))
+	}
+	if res.List != nil {
+		footer = append(footer, res.List.Footer())
+	}
+	if hint := suggestionHint(res.Suggestions); hint != "" {
+		footer = append(footer, hint)
+	}
+	if len(footer) > 0 {
+		resEmbed.Footer = &discordgo.MessageEmbedFooter{Text: strings.Join(footer, " • ")}
+	}
+
+	embeds := make([]*discordgo.MessageEmbed, 0, len(descs))
+	for num, desc := range descs[:len(descs)-1] {
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Description: desc,
+			Color:       resEmbed.Color,
+			Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Part %d/%d", num+1, len(descs))},
+		})
+	}
+	if len(embeds) > 0 {
+		embeds[0].Title = resEmbed.Title
+		resEmbed.Title = ""
+		resEmbed.Description = descs[len(descs)-1]
+	}
+
+	for _, f := range res.Fields {
+		resEmbed.Fields = append(resEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:   f.Name,
+			Value:  f.Value,
+			Inline: f.Inline,
+		})
+	}
+
+	// the attached images, like the charts, are shown in the embed.
+	if res.Attachment != nil && strings.HasPrefix(res.Attachment.ContentType, "image/") {
+		resEmbed.Image = &discordgo.MessageEmbedImage{URL: "attachment://" + res.Attachment.Name}
+	}
+
+	return append(embeds, resEmbed)
+}
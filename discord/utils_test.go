@@ -0,0 +1,146 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultEmbed(t *testing.T) {
+	messages := engine.NewMessageCatalog()
+
+	t.Run("successful", func(t *testing.T) {
+		embed := resultEmbeds(engine.MakeSuccessfulResult("done"), messages)[0]
+		assert.Equal(t, "Successful", embed.Title)
+		assert.Equal(t, GREEN, embed.Color)
+		assert.Equal(t, "done", embed.Description)
+	})
+
+	t.Run("branded", func(t *testing.T) {
+		res := engine.MakeSuccessfulResult("Serving: Mainnet")
+		res.Branded = true
+
+		embed := resultEmbeds(res, messages)[0]
+		assert.Equal(t, "Successful", embed.Title)
+		assert.Equal(t, PACTUS, embed.Color)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		embed := resultEmbeds(engine.MakeFailedResult("oops"), messages)[0]
+		assert.Equal(t, "Failed", embed.Title)
+		assert.Equal(t, YELLOW, embed.Color)
+	})
+
+	t.Run("maintenance", func(t *testing.T) {
+		res := &engine.CommandResult{
+			Message:     "The bot is under planned maintenance, please try again later.",
+			Maintenance: true,
+		}
+
+		embed := resultEmbeds(res, messages)[0]
+		assert.Equal(t, "🔧 Maintenance", embed.Title)
+		assert.Equal(t, CALM, embed.Color)
+		assert.NotEqual(t, RED, embed.Color)
+		assert.Equal(t, res.Message, embed.Description)
+	})
+
+	t.Run("footer", func(t *testing.T) {
+		res := engine.MakeSuccessfulResult("done")
+		res.List = &engine.ListResult{Total: 25, Page: 2, PageSize: 10}
+		res.Suggest("a", "b", "c", "d", "e", "f")
+
+		embed := resultEmbeds(res, messages)[0]
+		require.NotNil(t, embed.Footer)
+		assert.Equal(t, res.List.Footer()+" • Try next: /a, /b, /c, /d, /e, /f", embed.Footer.Text)
+	})
+}
+
+func TestStructuredResultEmbed(t *testing.T) {
+	messages := engine.NewMessageCatalog()
+
+	t.Run("title and table", func(t *testing.T) {
+		res := engine.MakeSuccessfulResult("Top validators")
+		res.Title = "Validators"
+		res.SetTable("Address", "Stake")
+		res.AddRow("pc1p...a", "1,000")
+
+		embed := resultEmbeds(res, messages)[0]
+		assert.Equal(t, "Validators", embed.Title)
+		assert.Equal(t, "Top validators\n\n```\nAddress   Stake\n--------  -----\npc1p...a  1,000\n```", embed.Description)
+	})
+
+	t.Run("long table", func(t *testing.T) {
+		res := engine.MakeSuccessfulResult("")
+		res.SetTable("Number")
+		for i := 0; i < 2000; i++ {
+			res.AddRow("123456")
+		}
+
+		embed := resultEmbeds(res, messages)[0]
+		assert.LessOrEqual(t, len(embed.Description), maxDescriptionLength)
+		assert.True(t, strings.HasPrefix(embed.Description, "```\nNumber\n"))
+		assert.Contains(t, embed.Description, "more lines\n```")
+	})
+
+	t.Run("attachment", func(t *testing.T) {
+		res := engine.MakeSuccessfulResult("exported")
+		assert.Nil(t, resultFiles(res))
+
+		res.Attach("peers.csv", "text/csv", []byte("id\n"))
+		files := resultFiles(res)
+		require.Len(t, files, 1)
+		assert.Equal(t, "peers.csv", files[0].Name)
+		assert.Equal(t, "text/csv", files[0].ContentType)
+		assert.Nil(t, resultEmbeds(res, messages)[0].Image)
+	})
+
+	t.Run("image attachment", func(t *testing.T) {
+		res := engine.MakeSuccessfulResult("chart")
+		res.Attach("height-7d.png", "image/png", []byte{0x89})
+
+		embed := resultEmbeds(res, messages)[0]
+		require.NotNil(t, embed.Image)
+		assert.Equal(t, "attachment://height-7d.png", embed.Image.URL)
+	})
+}
+
+func TestLongResultEmbeds(t *testing.T) {
+	messages := engine.NewMessageCatalog()
+
+	t.Run("split by lines", func(t *testing.T) {
+		line := strings.Repeat("x", 99)
+		res := engine.MakeSuccessfulResult(strings.Repeat(line+"\n", 100))
+		res.Title = "Peers"
+		res.AddField("Total", "100", true)
+		res.List = &engine.ListResult{Total: 100, Page: 1, PageSize: 100}
+
+		embeds := resultEmbeds(res, messages)
+		require.Len(t, embeds, 3)
+		assert.Equal(t, "Peers", embeds[0].Title)
+		assert.Equal(t, "Part 1/3", embeds[0].Footer.Text)
+		assert.Len(t, embeds[0].Description, 40*100-1, "the parts end at the lines")
+		assert.Empty(t, embeds[0].Fields)
+
+		assert.Empty(t, embeds[2].Title)
+		assert.Equal(t, "Part 3/3 • Page 1/1 (100 items)", embeds[2].Footer.Text)
+		assert.Len(t, embeds[2].Fields, 1)
+	})
+
+	t.Run("table in its own part", func(t *testing.T) {
+		res := engine.MakeSuccessfulResult(strings.Repeat("x", maxDescriptionLength-10))
+		res.SetTable("Number")
+		res.AddRow("1")
+
+		embeds := resultEmbeds(res, messages)
+		require.Len(t, embeds, 2)
+		assert.Equal(t, "```\nNumber\n------\n1\n```", embeds[1].Description)
+	})
+
+	t.Run("long line", func(t *testing.T) {
+		parts := splitText(strings.Repeat("é", 10), 4)
+		assert.Equal(t, []string{"éééé", "éééé", "éé"}, parts)
+	})
+}
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// appAccess keeps the per-app allow and deny lists of the commands.
+// They let operators pull a command from an app without changing its metadata.
+//
+// The checks are applied in this order, and a command must pass all of them:
+//  1. the command is declared for the app (AppIDs),
+//  2. the command isn't in the deny list of the app, and it's in the allow list of the app if the list is set,
+//  3. the command isn't disabled globally or for the guild (SetCommandEnabled).
+//
+// So enabling a command doesn't override the deny list, and the lists don't change the enable/disable state.
+type appAccess struct {
+	lk sync.RWMutex
+
+	allow map[AppID]map[string]bool
+	deny  map[AppID]map[string]bool
+}
+
+func newAppAccess() *appAccess {
+	return &appAccess{
+		allow: make(map[AppID]map[string]bool),
+		deny:  make(map[AppID]map[string]bool),
+	}
+}
+
+func (aa *appAccess) set(lists map[AppID]map[string]bool, appID AppID, cmdNames []string) {
+	aa.lk.Lock()
+	defer aa.lk.Unlock()
+
+	if len(cmdNames) == 0 {
+		delete(lists, appID)
+
+		return
+	}
+
+	names := make(map[string]bool, len(cmdNames))
+	for _, name := range cmdNames {
+		names[name] = true
+	}
+	lists[appID] = names
+}
+
+func (aa *appAccess) isAllowed(cmdName string, appID AppID) bool {
+	aa.lk.RLock()
+	defer aa.lk.RUnlock()
+
+	if aa.deny[appID][cmdName] {
+		return false
+	}
+
+	allowed, ok := aa.allow[appID]
+	if !ok {
+		return true
+	}
+
+	return allowed[cmdName]
+}
+
+// SetAppAllowList limits the commands of the app to the given commands.
+// An empty list removes the limit.
+func (be *BotEngine) SetAppAllowList(appID AppID, cmdNames []string) {
+	be.access.set(be.access.allow, appID, cmdNames)
+}
+
+// SetAppDenyList pulls the given commands from the app.
+// The deny list takes precedence over the allow list.
+func (be *BotEngine) SetAppDenyList(appID AppID, cmdNames []string) {
+	be.access.set(be.access.deny, appID, cmdNames)
+}
+
+// IsCommandAllowed reports whether the command is available on the app,
+// considering its metadata and the allow/deny lists of the app.
+// It doesn't check the enable/disable state of the command, see IsCommandEnabled.
+func (be *BotEngine) IsCommandAllowed(cmdName string, appID AppID) bool {
+	cmd := be.commandByName(cmdName)
+	if cmd == nil || !cmd.HasAppId(appID) {
+		return false
+	}
+
+	return be.access.isAllowed(cmdName, appID)
+}
+
+// ParseAppID returns the app with the given name, like "discord". The name is case-insensitive.
+func ParseAppID(name string) (AppID, error) {
+	for _, appID := range []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack} {
+		if strings.EqualFold(appID.String(), name) {
+			return appID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown app: %s", name)
+}
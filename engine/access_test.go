@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppAccess(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "both", AppIDs: []AppID{AppIdCLI, AppIdDiscord}, Handler: okHandler},
+		Command{Name: "other", AppIDs: []AppID{AppIdCLI, AppIdDiscord}, Handler: okHandler},
+		Command{Name: "cli-only", AppIDs: []AppID{AppIdCLI}, Handler: okHandler},
+	)
+
+	t.Run("no lists", func(t *testing.T) {
+		assert.True(t, be.IsCommandAllowed("both", AppIdDiscord))
+		assert.False(t, be.IsCommandAllowed("cli-only", AppIdDiscord))
+		assert.False(t, be.IsCommandAllowed("unknown", AppIdCLI))
+	})
+
+	t.Run("deny list pulls the command from one app", func(t *testing.T) {
+		be.SetAppDenyList(AppIdDiscord, []string{"both"})
+		defer be.SetAppDenyList(AppIdDiscord, nil)
+
+		_, err := be.Run(AppIdDiscord, "1", []string{"both"})
+		assert.EqualError(t, err, "command both is not available on Discord")
+
+		_, err = be.Run(AppIdCLI, "1", []string{"both"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("allow list limits the commands of the app", func(t *testing.T) {
+		be.SetAppAllowList(AppIdCLI, []string{"other"})
+		defer be.SetAppAllowList(AppIdCLI, nil)
+
+		assert.False(t, be.IsCommandAllowed("both", AppIdCLI))
+		assert.True(t, be.IsCommandAllowed("other", AppIdCLI))
+		assert.True(t, be.IsCommandAllowed("both", AppIdDiscord))
+	})
+
+	t.Run("allow list doesn't add commands to the app", func(t *testing.T) {
+		be.SetAppAllowList(AppIdDiscord, []string{"cli-only"})
+		defer be.SetAppAllowList(AppIdDiscord, nil)
+
+		assert.False(t, be.IsCommandAllowed("cli-only", AppIdDiscord))
+	})
+
+	t.Run("deny list takes precedence over allow list", func(t *testing.T) {
+		be.SetAppAllowList(AppIdCLI, []string{"both"})
+		be.SetAppDenyList(AppIdCLI, []string{"both"})
+		defer be.SetAppAllowList(AppIdCLI, nil)
+		defer be.SetAppDenyList(AppIdCLI, nil)
+
+		assert.False(t, be.IsCommandAllowed("both", AppIdCLI))
+	})
+
+	t.Run("enabling doesn't override the deny list", func(t *testing.T) {
+		be.SetAppDenyList(AppIdDiscord, []string{"both"})
+		defer be.SetAppDenyList(AppIdDiscord, nil)
+
+		require.NoError(t, be.SetCommandEnabled("both", "", true))
+
+		_, err := be.Run(AppIdDiscord, "1", []string{"both"})
+		assert.Error(t, err)
+	})
+
+	t.Run("allowing doesn't override the disabled state", func(t *testing.T) {
+		be.SetAppAllowList(AppIdCLI, []string{"both"})
+		defer be.SetAppAllowList(AppIdCLI, nil)
+
+		require.NoError(t, be.SetCommandEnabled("both", "", false))
+		defer func() { _ = be.SetCommandEnabled("both", "", true) }()
+
+		assert.True(t, be.IsCommandAllowed("both", AppIdCLI))
+
+		_, err := be.Run(AppIdCLI, "1", []string{"both"})
+		assert.EqualError(t, err, "command both is disabled")
+	})
+}
+
+func TestParseAppID(t *testing.T) {
+	appID, err := ParseAppID("discord")
+	require.NoError(t, err)
+	assert.Equal(t, AppIdDiscord, appID)
+
+	appID, err = ParseAppID("CLI")
+	require.NoError(t, err)
+	assert.Equal(t, AppIdCLI, appID)
+
+	appID, err = ParseAppID("http")
+	require.NoError(t, err)
+	assert.Equal(t, AppIdHTTP, appID)
+
+	appID, err = ParseAppID("slack")
+	require.NoError(t, err)
+	assert.Equal(t, AppIdSlack, appID)
+
+	_, err = ParseAppID("irc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/utils"
+	"github.com/pactus-project/pactus/types/tx/payload"
+	"github.com/pactus-project/pactus/util"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// defaultAccountBlocks is the number of the recent blocks that the account command scans, about an hour.
+const defaultAccountBlocks = 360
+
+// AccountActivity is the summary of the transactions of an account in a range of the blocks,
+// the amounts are in NanoPAC.
+type AccountActivity struct {
+	// Received includes the block rewards, and Sent includes the fees.
+	Received int64
+	Sent     int64
+	Rewards  int64
+	// RewardValidators are the validators that the account received the block rewards of.
+	RewardValidators []string
+	// BondedValidators are the validators that the account bonded stake to.
+	BondedValidators []string
+}
+
+// Validators returns the validators that are associated with the account, sorted.
+func (a *AccountActivity) Validators() []string {
+	validators := append(slices.Clone(a.RewardValidators), a.BondedValidators...)
+	slices.Sort(validators)
+
+	return slices.Compact(validators)
+}
+
+// accountActivity summarizes the transactions of the address in the blocks.
+func accountActivity(address string, blocks []*pactus.GetBlockResponse, lastHeight uint32) (*AccountActivity, error) {
+	activity := &AccountActivity{}
+	for _, block := range blocks {
+		for _, info := range block.Txs {
+			trx, err := decodeRawTransaction(info.Data, block.Height, block.BlockTime, lastHeight)
+			if err != nil {
+				return nil, err
+			}
+
+			if trx.Receiver == address {
+				activity.Received += trx.Amount
+			}
+
+			switch {
+			case trx.Subsidy:
+				if trx.Receiver == address {
+					activity.Rewards += trx.Amount
+					proposer := block.GetHeader().GetProposerAddress()
+					if proposer != "" && !slices.Contains(activity.RewardValidators, proposer) {
+						activity.RewardValidators = append(activity.RewardValidators, proposer)
+					}
+				}
+
+			case trx.Sender == address:
+				activity.Sent += trx.Amount + trx.Fee
+				if trx.Type == payload.TypeBond && !slices.Contains(activity.BondedValidators, trx.Receiver) {
+					activity.BondedValidators = append(activity.BondedValidators, trx.Receiver)
+				}
+			}
+		}
+	}
+
+	return activity, nil
+}
+
+// accountHandler shows the balance of the account, and its activity and validators in the recent blocks.
+func (be *BotEngine) accountHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	address := strings.TrimSpace(args[0])
+
+	count := uint32(defaultAccountBlocks)
+	if len(args) > 1 && args[1] != "" {
+		parsed, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil || parsed == 0 || parsed > client.MaxBlocksRange {
+			return MakeFailedResult("Invalid number of the blocks: %s, it should be between 1 and %d",
+				args[1], client.MaxBlocksRange), nil
+		}
+		count = uint32(parsed)
+	}
+
+	cm, err := be.networkClient(networkOf(args, 2))
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := cm.GetAccount(address)
+	if err != nil {
+		if errors.Is(err, client.ErrAccountNotFound) {
+			return MakeFailedResult("Account `%s` is not found", address), nil
+		}
+
+		return nil, err
+	}
+
+	lastHeight, err := cm.GetBlockchainHeight()
+	if err != nil {
+		return nil, err
+	}
+	fromHeight := uint32(1)
+	if lastHeight > count {
+		fromHeight = lastHeight - count + 1
+	}
+
+	blocks, err := cm.GetBlocksWithTxs(fromHeight, lastHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := accountActivity(address, blocks, lastHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	res := MakeSuccessfulResult("Address: %s\nThe activity is of the blocks %s to %s.", address,
+		utils.FormatNumber(int64(fromHeight)), utils.FormatNumber(int64(lastHeight)))
+	res.Title = "Account"
+	res.AddField("Balance", util.ChangeToString(acc.Balance)+" PAC", true)
+	res.AddField("Number", utils.FormatNumber(int64(acc.Number)), true)
+
+	rewardAddress := "No"
+	if len(activity.RewardValidators) > 0 {
+		rewardAddress = fmt.Sprintf("Yes, of %d validator(s)", len(activity.RewardValidators))
+	}
+	res.AddField("Reward Address", rewardAddress, true)
+
+	res.AddField("Received", util.ChangeToString(activity.Received)+" PAC", true)
+	res.AddField("Sent", util.ChangeToString(activity.Sent)+" PAC", true)
+	res.AddField("Block Rewards", util.ChangeToString(activity.Rewards)+" PAC", true)
+
+	validators := activity.Validators()
+	if len(validators) == 0 {
+		return res, nil
+	}
+
+	lines := make([]string, 0, len(validators))
+	totalStake := int64(0)
+	for _, valAddr := range validators {
+		val, err := cm.GetValidatorInfo(valAddr)
+		if err != nil {
+			be.logger.Warn("unable to get the validator of the account", "err", err, "validator", valAddr)
+			res.AddWarning("the stake of %s is not available", valAddr)
+
+			continue
+		}
+
+		totalStake += val.Validator.Stake
+		lines = append(lines, fmt.Sprintf("%s: %s PAC", valAddr, util.ChangeToString(val.Validator.Stake)))
+	}
+
+	if len(lines) > 0 {
+		res.AddField("Validators", strings.Join(lines, "\n"), false)
+		res.AddField("Total Stake", util.ChangeToString(totalStake)+" PAC", true)
+	}
+
+	return res, nil
+}
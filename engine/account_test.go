@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/kehiy/RoboPac/client/mock"
+	"github.com/pactus-project/pactus/types/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountCommand(t *testing.T) {
+	be, nodes := setupTestEngineWithNodes(t, "local:50051")
+
+	// the second account bonds to the third validator in the next block.
+	_, err := nodes[0].AddBlock(mock.FixtureHeight+1, mock.FixtureBlockTime+10,
+		tx.NewBondTx(mock.FixtureHeight+1, mock.AccountAddress(2), mock.ValidatorAddress(3), nil, 100e9, 1e7, ""))
+	require.NoError(t, err)
+
+	t.Run("reward address", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{AccountCommandName, mock.AccountAddress(1).String()})
+		require.NoError(t, err)
+		require.True(t, res.Successful)
+
+		assert.Equal(t, "Account", res.Title)
+		assert.Contains(t, res.Message, "The activity is of the blocks 642 to 1,001.")
+		assert.Equal(t, "500 PAC", res.Fields[0].Value)
+		assert.Equal(t, "Yes, of 1 validator(s)", res.Fields[2].Value)
+		assert.Equal(t, "1.01 PAC", res.Fields[3].Value, "the block reward is received")
+		assert.Equal(t, "25.01 PAC", res.Fields[4].Value, "the fee is sent")
+		assert.Equal(t, "1.01 PAC", res.Fields[5].Value)
+		assert.Equal(t, mock.ValidatorAddress(1).String()+": 1000 PAC", res.Fields[6].Value)
+		assert.Equal(t, "1000 PAC", res.Fields[7].Value)
+	})
+
+	t.Run("bonded validator", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{AccountCommandName, mock.AccountAddress(2).String(), "10"})
+		require.NoError(t, err)
+		require.True(t, res.Successful)
+
+		assert.Contains(t, res.Message, "The activity is of the blocks 992 to 1,001.")
+		assert.Equal(t, "No", res.Fields[2].Value)
+		assert.Equal(t, "25 PAC", res.Fields[3].Value)
+		assert.Equal(t, "100.01 PAC", res.Fields[4].Value)
+		assert.Equal(t, mock.ValidatorAddress(3).String()+": 3000 PAC", res.Fields[6].Value)
+	})
+
+	t.Run("out of the scanned blocks", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{AccountCommandName, mock.AccountAddress(1).String(), "1"})
+		require.NoError(t, err)
+		require.True(t, res.Successful)
+
+		assert.Equal(t, "No", res.Fields[2].Value)
+		assert.Equal(t, "0 PAC", res.Fields[3].Value)
+		assert.Len(t, res.Fields, 6, "no validator is found")
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{AccountCommandName, mock.AccountAddress(9).String()})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "is not found")
+	})
+
+	t.Run("validator address", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{AccountCommandName, mock.ValidatorAddress(1).String()})
+		assert.ErrorContains(t, err, "expected an account address like pc1z...")
+	})
+
+	t.Run("too many blocks", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{AccountCommandName, mock.AccountAddress(1).String(), "5000"})
+		assert.Error(t, err)
+	})
+}
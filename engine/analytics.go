@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kehiy/RoboPac/analytics"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/utils"
+)
+
+const (
+	usageFlushJobName = "usage-flush"
+	usageFlushSpec    = "@every 1m"
+
+	// botStatsTopCommands is the number of the most used commands that bot-stats reports.
+	botStatsTopCommands = 10
+)
+
+// enableAnalytics loads the recorded usage of the commands and schedules writing it to the storage.
+func (be *BotEngine) enableAnalytics(cfg *config.Config) error {
+	kv, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "command_usage")
+	if err != nil {
+		return err
+	}
+
+	be.analytics, err = analytics.NewTracker(kv)
+	if err != nil {
+		return err
+	}
+
+	return be.Schedule(scheduler.Job{
+		Name: usageFlushJobName,
+		Spec: usageFlushSpec,
+		Run:  func(_ context.Context) { be.flushUsage() },
+	})
+}
+
+func (be *BotEngine) flushUsage() {
+	if be.analytics == nil {
+		return
+	}
+
+	if err := be.analytics.Flush(); err != nil {
+		be.logger.Error("unable to save the command usage", "err", err)
+	}
+}
+
+func (be *BotEngine) recordUsage(cmdName string, res *CommandResult, err error, latency time.Duration) {
+	if be.analytics != nil {
+		be.analytics.Record(cmdName, res != nil && res.Successful, err, latency)
+	}
+}
+
+// botStatsHandler reports the most used commands, their error rates and latencies, and the uptime of the bot.
+func (be *BotEngine) botStatsHandler(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+	usages := be.analytics.Usage()
+
+	calls, errored := int64(0), int64(0)
+	for i := range usages {
+		calls += usages[i].Calls()
+		errored += usages[i].Errored
+	}
+
+	res := MakeSuccessfulResult("Usage of the commands since the analytics are recorded")
+	res.Title = "Bot Stats"
+	res.AddField("Uptime", time.Since(be.startedAt).Truncate(time.Second).String(), true)
+	res.AddField("Calls", utils.FormatNumber(calls), true)
+	if calls > 0 {
+		res.AddField("Error Rate", fmt.Sprintf("%.1f%%", float64(errored)/float64(calls)*100), true)
+	}
+
+	if len(usages) == 0 {
+		res.AddWarning("No command is called yet")
+
+		return res, nil
+	}
+
+	res.SetTable("Command", "Calls", "Success", "Errors", "p50", "p95")
+	for i := range usages[:min(len(usages), botStatsTopCommands)] {
+		usage := &usages[i]
+		res.AddRow(
+			usage.Command,
+			utils.FormatNumber(usage.Calls()),
+			fmt.Sprintf("%.1f%%", usage.SuccessRate()*100),
+			fmt.Sprintf("%.1f%%", usage.ErrorRate()*100),
+			usage.Percentile(0.5).String(),
+			usage.Percentile(0.95).String(),
+		)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/kehiy/RoboPac/analytics"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBotStats(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "ok", AppIDs: []AppID{AppIdCLI}, Handler: okHandler},
+		Command{
+			Name:   "fail",
+			AppIDs: []AppID{AppIdCLI},
+			Handler: func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+				return nil, errors.New("node is down")
+			},
+		},
+	)
+
+	kv, err := store.NewJSONKV(path.Join(t.TempDir(), "command_usage.json"))
+	require.NoError(t, err)
+	be.analytics, err = analytics.NewTracker(kv)
+	require.NoError(t, err)
+
+	t.Run("nothing is called", func(t *testing.T) {
+		res, err := be.botStatsHandler(AppIdCLI, "admin")
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Nil(t, res.Table)
+		assert.NotEmpty(t, res.Warnings)
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := be.Run(AppIdCLI, "1", []string{"ok"})
+		require.NoError(t, err)
+	}
+	_, err = be.Run(AppIdCLI, "1", []string{"fail"})
+	require.Error(t, err)
+
+	res, err := be.botStatsHandler(AppIdCLI, "admin")
+	require.NoError(t, err)
+	require.NotNil(t, res.Table)
+	require.Len(t, res.Table.Rows, 2)
+	assert.Equal(t, []string{"ok", "3", "100.0%", "0.0%"}, res.Table.Rows[0][:4])
+	assert.Equal(t, []string{"fail", "1", "0.0%", "100.0%"}, res.Table.Rows[1][:4])
+	assert.Equal(t, "25.0%", res.Fields[2].Value, "the error rate of all the calls")
+}
@@ -0,0 +1,225 @@
+package engine
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/util"
+)
+
+// ArgType is the type of the argument value.
+// The front-ends with typed inputs, like Discord, use it to declare their options.
+type ArgType int
+
+const (
+	ArgTypeString ArgType = iota
+	ArgTypeInteger
+	ArgTypeNumber
+	// ArgTypeAttachment is a file, the value is its URL. The apps without attachments take the URL as a string.
+	ArgTypeAttachment
+	// ArgTypeUser is a user of the app, the value is the user ID. The mentions, like <@123>, are taken as their IDs.
+	ArgTypeUser
+)
+
+func (t ArgType) String() string {
+	switch t {
+	case ArgTypeInteger:
+		return "integer"
+	case ArgTypeNumber:
+		return "number"
+	case ArgTypeAttachment:
+		return "attachment"
+	case ArgTypeUser:
+		return "user"
+	default:
+		return "string"
+	}
+}
+
+// ArgFormat is the format of the string arguments, like an address. The values are checked against it
+// before running the handlers, so the handlers don't need to validate them again.
+type ArgFormat int
+
+const (
+	FormatAny ArgFormat = iota
+	FormatAddress
+	FormatAccountAddress
+	FormatValidatorAddress
+	// FormatTxID is a transaction ID, the hash of the transaction in hex.
+	FormatTxID
+	// FormatAmount is a positive amount of PAC, like 1.5.
+	FormatAmount
+)
+
+func (f ArgFormat) String() string {
+	switch f {
+	case FormatAddress:
+		return "an address like pc1..."
+	case FormatAccountAddress:
+		return "an account address like pc1z..."
+	case FormatValidatorAddress:
+		return "a validator address like pc1p..."
+	case FormatTxID:
+		return "a transaction ID of 64 hex characters"
+	case FormatAmount:
+		return "a positive amount of PAC"
+	default:
+		return "a string"
+	}
+}
+
+// check reports whether the value is in the format.
+func (f ArgFormat) check(value string) bool {
+	switch f {
+	case FormatAddress, FormatAccountAddress, FormatValidatorAddress:
+		addr, err := crypto.AddressFromString(value)
+		if err != nil {
+			return false
+		}
+
+		return f == FormatAddress ||
+			(f == FormatAccountAddress && addr.IsAccountAddress()) ||
+			(f == FormatValidatorAddress && addr.IsValidatorAddress())
+
+	case FormatTxID:
+		bs, err := hex.DecodeString(value)
+
+		return err == nil && len(bs) == 32
+
+	case FormatAmount:
+		amount, err := util.StringToChange(value)
+
+		return err == nil && amount > 0
+
+	default:
+		return true
+	}
+}
+
+// ArgError is returned when an argument value doesn't satisfy the constraints of the argument.
+type ArgError struct {
+	Arg   Args
+	Value string
+}
+
+func (e *ArgError) Error() string {
+	return fmt.Sprintf("invalid value %q for %s: expected %s", e.Value, e.Arg.Name, e.Arg.Constraint())
+}
+
+// Constraint describes the accepted values of the argument, like "an integer between 1 and 10".
+func (arg Args) Constraint() string {
+	if len(arg.Choices) > 0 {
+		return fmt.Sprintf("one of %s", strings.Join(arg.Choices, ", "))
+	}
+
+	if arg.Format != FormatAny {
+		return arg.Format.String()
+	}
+
+	desc := "a string"
+	switch arg.Type {
+	case ArgTypeInteger:
+		desc = "an integer"
+	case ArgTypeNumber:
+		desc = "a number"
+	case ArgTypeAttachment:
+		return "an attachment"
+	case ArgTypeUser:
+		return "a user"
+	case ArgTypeString:
+		return desc
+	}
+
+	switch {
+	case arg.MinValue != nil && arg.MaxValue != nil:
+		desc += fmt.Sprintf(" between %s and %s", formatFloat(*arg.MinValue), formatFloat(*arg.MaxValue))
+	case arg.MinValue != nil:
+		desc += fmt.Sprintf(" of at least %s", formatFloat(*arg.MinValue))
+	case arg.MaxValue != nil:
+		desc += fmt.Sprintf(" of at most %s", formatFloat(*arg.MaxValue))
+	}
+
+	return desc
+}
+
+// ParseArg parses the raw value of the argument and checks it against the type, the range, the choices
+// and the format of the argument. The value is a string, an int64 or a float64, based on the type of the argument.
+// All the validation entry points, like the dispatch of the commands, use it to stay consistent.
+func ParseArg(arg Args, raw string) (any, error) {
+	if len(arg.Choices) > 0 && !slices.Contains(arg.Choices, raw) {
+		return nil, &ArgError{Arg: arg, Value: raw}
+	}
+
+	var value any
+	var number float64
+	switch arg.Type {
+	case ArgTypeInteger:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, &ArgError{Arg: arg, Value: raw}
+		}
+		value, number = n, float64(n)
+
+	case ArgTypeNumber:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, &ArgError{Arg: arg, Value: raw}
+		}
+		value, number = n, n
+
+	case ArgTypeString, ArgTypeAttachment:
+		if !arg.Format.check(strings.TrimSpace(raw)) {
+			return nil, &ArgError{Arg: arg, Value: raw}
+		}
+
+		return raw, nil
+
+	case ArgTypeUser:
+		userID := UserIDOf(raw)
+		if userID == "" || strings.ContainsAny(userID, " <>@") {
+			return nil, &ArgError{Arg: arg, Value: raw}
+		}
+
+		return userID, nil
+	}
+
+	if (arg.MinValue != nil && number < *arg.MinValue) ||
+		(arg.MaxValue != nil && number > *arg.MaxValue) {
+		return nil, &ArgError{Arg: arg, Value: raw}
+	}
+
+	return value, nil
+}
+
+// Validate checks the raw value of the argument. See ParseArg for the details.
+func (arg Args) Validate(raw string) error {
+	_, err := ParseArg(arg, raw)
+
+	return err
+}
+
+// UserIDOf returns the user ID of the user argument, the mentions like <@123> or <@!123> are unwrapped.
+func UserIDOf(value string) string {
+	value = strings.TrimSpace(value)
+	if mention, ok := strings.CutPrefix(value, "<@"); ok {
+		if id, ok := strings.CutSuffix(mention, ">"); ok {
+			return strings.TrimPrefix(id, "!")
+		}
+	}
+
+	return value
+}
+
+// Bound returns a pointer to the value, to set the range of the arguments.
+func Bound(v float64) *float64 {
+	return &v
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgsValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		arg   Args
+		value string
+		valid bool
+	}{
+		{"string", Args{}, "anything", true},
+		{"choice", Args{Choices: []string{"on", "off"}}, "on", true},
+		{"not a choice", Args{Choices: []string{"on", "off"}}, "maybe", false},
+		{"integer", Args{Type: ArgTypeInteger}, "-3", true},
+		{"not an integer", Args{Type: ArgTypeInteger}, "1.5", false},
+		{"number", Args{Type: ArgTypeNumber}, "1.5", true},
+		{"not a number", Args{Type: ArgTypeNumber}, "one", false},
+		{"below the minimum", Args{Type: ArgTypeInteger, MinValue: Bound(1)}, "0", false},
+		{"above the maximum", Args{Type: ArgTypeNumber, MaxValue: Bound(2.5)}, "2.6", false},
+		{"in the range", Args{Type: ArgTypeInteger, MinValue: Bound(1), MaxValue: Bound(10)}, "10", true},
+		{"address", Args{Format: FormatAddress}, fixtureValidator.String(), true},
+		{"not an address", Args{Format: FormatAddress}, "pc1xyz", false},
+		{"account address", Args{Format: FormatAccountAddress}, fixtureAccount.String(), true},
+		{"not an account address", Args{Format: FormatAccountAddress}, fixtureValidator.String(), false},
+		{"validator address", Args{Format: FormatValidatorAddress}, fixtureValidator.String(), true},
+		{"not a validator address", Args{Format: FormatValidatorAddress}, fixtureAccount.String(), false},
+		{"transaction ID", Args{Format: FormatTxID}, strings.Repeat("ab", 32), true},
+		{"short transaction ID", Args{Format: FormatTxID}, "abcd", false},
+		{"amount", Args{Format: FormatAmount}, "1.5", true},
+		{"zero amount", Args{Format: FormatAmount}, "0", false},
+		{"not an amount", Args{Format: FormatAmount}, "ten", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.arg.Validate(tt.value)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.IsType(t, &ArgError{}, err)
+			}
+		})
+	}
+}
+
+func TestArgsConstraint(t *testing.T) {
+	assert.Equal(t, "a string", Args{}.Constraint())
+	assert.Equal(t, "one of on, off", Args{Choices: []string{"on", "off"}}.Constraint())
+	assert.Equal(t, "an integer of at least 1", Args{Type: ArgTypeInteger, MinValue: Bound(1)}.Constraint())
+	assert.Equal(t, "a number of at most 0.5", Args{Type: ArgTypeNumber, MaxValue: Bound(0.5)}.Constraint())
+	assert.Equal(t, "a validator address like pc1p...", Args{Format: FormatValidatorAddress}.Constraint())
+	assert.Equal(t, "an integer between 1 and 10",
+		Args{Type: ArgTypeInteger, MinValue: Bound(1), MaxValue: Bound(10)}.Constraint())
+}
+
+func TestCheckArgsValidatesValues(t *testing.T) {
+	be := setupTestEngine(t, Command{
+		Name:    "page-cmd",
+		AppIDs:  []AppID{AppIdCLI},
+		Handler: okHandler,
+		Args:    []Args{{Name: "page", Optional: true, Type: ArgTypeInteger, MinValue: Bound(1)}},
+	})
+
+	_, err := be.Run(AppIdCLI, "1", []string{"page-cmd", "0"})
+	assert.EqualError(t, err, `invalid value "0" for page: expected an integer of at least 1`)
+
+	_, err = be.Run(AppIdCLI, "1", []string{"page-cmd", ""})
+	assert.NoError(t, err)
+
+	_, err = be.Run(AppIdCLI, "1", []string{"page-cmd", "2"})
+	assert.NoError(t, err)
+}
+
+func TestParseArg(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		value, err := ParseArg(Args{Name: "text"}, "hello")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", value)
+	})
+
+	t.Run("string with choices", func(t *testing.T) {
+		arg := Args{Name: "state", Choices: []string{"on", "off"}}
+
+		value, err := ParseArg(arg, "off")
+		assert.NoError(t, err)
+		assert.Equal(t, "off", value)
+
+		_, err = ParseArg(arg, "maybe")
+		assert.EqualError(t, err, `invalid value "maybe" for state: expected one of on, off`)
+	})
+
+	t.Run("integer", func(t *testing.T) {
+		arg := Args{Name: "count", Type: ArgTypeInteger, MinValue: Bound(-5), MaxValue: Bound(5)}
+
+		value, err := ParseArg(arg, "-5")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-5), value)
+
+		_, err = ParseArg(arg, "2.0")
+		assert.Error(t, err)
+
+		_, err = ParseArg(arg, "6")
+		assert.EqualError(t, err, `invalid value "6" for count: expected an integer between -5 and 5`)
+	})
+
+	t.Run("user", func(t *testing.T) {
+		arg := Args{Name: "user", Type: ArgTypeUser}
+
+		for _, raw := range []string{"123", "<@123>", "<@!123>"} {
+			value, err := ParseArg(arg, raw)
+			assert.NoError(t, err)
+			assert.Equal(t, "123", value)
+		}
+
+		_, err := ParseArg(arg, "<@>")
+		assert.EqualError(t, err, `invalid value "<@>" for user: expected a user`)
+	})
+
+	t.Run("number", func(t *testing.T) {
+		arg := Args{Name: "amount", Type: ArgTypeNumber, MinValue: Bound(0.5)}
+
+		value, err := ParseArg(arg, "1.25")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.25, value)
+
+		value, err = ParseArg(arg, "3")
+		assert.NoError(t, err)
+		assert.Equal(t, 3.0, value)
+
+		_, err = ParseArg(arg, "0.25")
+		assert.Error(t, err)
+
+		_, err = ParseArg(arg, "NaN")
+		assert.Error(t, err)
+
+		_, err = ParseArg(arg, "+Inf")
+		assert.Error(t, err)
+	})
+}
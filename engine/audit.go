@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kehiy/RoboPac/audit"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/store"
+)
+
+const (
+	// auditKindAll is the kind argument of audit-log that selects the entries of all the kinds.
+	auditKindAll = "all"
+
+	// auditLogLimit is the number of the recent entries that audit-log lists.
+	auditLogLimit    = 100
+	auditLogPageSize = 10
+)
+
+// enableAudit opens the audit log of the mutating operations, like the transfers and the admin commands.
+func (be *BotEngine) enableAudit(cfg *config.Config) error {
+	kv, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "audit_log")
+	if err != nil {
+		return err
+	}
+
+	be.audit, err = audit.NewLog(kv)
+
+	return err
+}
+
+// recordAudit appends the entry to the audit log. The operation is done already,
+// so the failure of the log is reported, but it doesn't fail the operation.
+func (be *BotEngine) recordAudit(entry audit.Entry) {
+	if be.audit == nil {
+		return
+	}
+
+	if err := be.audit.Append(entry); err != nil {
+		be.logger.Error("unable to record the audit entry", "err", err, "kind", entry.Kind,
+			"callerID", entry.CallerID, "txID", entry.TxID)
+	}
+}
+
+// auditError returns the error of the operation for the audit entry, or the message of the failed result.
+func auditError(res *CommandResult, err error) string {
+	switch {
+	case err != nil:
+		return err.Error()
+	case res != nil && !res.Successful:
+		return res.Message
+	default:
+		return ""
+	}
+}
+
+// auditLogHandler lists the recent entries of the audit log, filtered by the kind and the caller.
+func (be *BotEngine) auditLogHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	filter := audit.Filter{}
+	if len(args) > 0 && args[0] != auditKindAll {
+		filter.Kind = args[0]
+	}
+	if len(args) > 1 {
+		filter.CallerID = args[1]
+	}
+
+	page, err := parsePage(args[min(len(args), 2):])
+	if err != nil {
+		return MakeFailedResult(err.Error()), nil
+	}
+
+	entries, err := be.audit.Recent(filter, auditLogLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return MakeSuccessfulResult("No audit entry is recorded yet"), nil
+	}
+
+	items := make([]string, 0, len(entries))
+	for i := range entries {
+		items = append(items, auditEntrySummary(&entries[i]))
+	}
+
+	res := MakeListResult(Paginate(items, page, auditLogPageSize))
+	res.Title = "Audit Log"
+
+	return res, nil
+}
+
+// auditEntrySummary is the line of the entry in audit-log, like
+// "#12 2024-01-02 15:04:05 faucet by 123 (Discord): `faucet tpc1...` tx: 1a2b...".
+func auditEntrySummary(entry *audit.Entry) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#%d %s %s by %s", entry.Seq, entry.Time.UTC().Format(time.DateTime), entry.Kind, entry.CallerID)
+	if entry.AppID != "" {
+		fmt.Fprintf(&sb, " (%s)", entry.AppID)
+	}
+	if len(entry.Inputs) > 0 {
+		fmt.Fprintf(&sb, ": `%s`", strings.Join(entry.Inputs, " "))
+	}
+	if entry.TxID != "" {
+		fmt.Fprintf(&sb, " tx: %s", entry.TxID)
+	}
+	if entry.Error != "" {
+		fmt.Fprintf(&sb, " error: %s", entry.Error)
+	}
+
+	return sb.String()
+}
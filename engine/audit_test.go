@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/kehiy/RoboPac/audit"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "user-cmd", AppIDs: []AppID{AppIdCLI}, Handler: okHandler},
+		Command{
+			Name:    "admin-cmd",
+			Args:    []Args{{Name: "value", Optional: true}},
+			AppIDs:  []AppID{AppIdCLI},
+			Handler: okHandler,
+			MinRole: RoleAdmin,
+		},
+	)
+	require.NoError(t, be.enableAudit(&config.Config{StorePath: t.TempDir()}))
+	be.RegisterCommands()
+
+	admin := RunOptions{Role: RoleAdmin}
+
+	_, err := be.Run(AppIdCLI, "1", []string{"user-cmd"})
+	require.NoError(t, err)
+	_, err = be.RunWithOptions(admin, AppIdCLI, "1", []string{"admin-cmd", "on"})
+	require.NoError(t, err)
+	_, err = be.Run(AppIdCLI, "2", []string{"admin-cmd"})
+	require.Error(t, err)
+
+	entries, err := be.audit.Recent(audit.Filter{}, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "the user commands are not recorded")
+	assert.Equal(t, "2", entries[0].CallerID)
+	assert.NotEmpty(t, entries[0].Error, "the unauthorized attempts are recorded")
+	assert.Equal(t, audit.KindAdminCommand, entries[1].Kind)
+	assert.Equal(t, []string{"admin-cmd", "on"}, entries[1].Inputs)
+	assert.Equal(t, AppIdCLI.String(), entries[1].AppID)
+
+	t.Run("list", func(t *testing.T) {
+		be.recordAudit(audit.Entry{Kind: audit.KindFaucet, CallerID: "3", Inputs: []string{"faucet", "tpc1"}, TxID: "0x123"})
+
+		res, err := be.RunWithOptions(admin, AppIdCLI, "1", []string{AuditLogCommandName})
+		require.NoError(t, err)
+		require.NotNil(t, res.List)
+		assert.Equal(t, 3, res.List.Total)
+		assert.Contains(t, res.List.Items[0], "faucet by 3: `faucet tpc1` tx: 0x123")
+
+		res, err = be.RunWithOptions(admin, AppIdCLI, "1", []string{AuditLogCommandName, auditKindAll, "1"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.List.Total, "the audit-log call is recorded too")
+
+		res, err = be.RunWithOptions(admin, AppIdCLI, "1", []string{AuditLogCommandName, audit.KindClaim})
+		require.NoError(t, err)
+		assert.Nil(t, res.List)
+		assert.Contains(t, res.Message, "No audit entry")
+	})
+}
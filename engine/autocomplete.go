@@ -0,0 +1,162 @@
+package engine
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/kehiy/RoboPac/store"
+)
+
+const (
+	// MaxAutocompleteChoices is the most suggestions that are returned for an argument, like the limit of Discord.
+	MaxAutocompleteChoices = 25
+
+	maxRecentValidators = 10
+)
+
+// AutocompleteFunc suggests the values of an argument for the caller, the values should start with the prefix.
+type AutocompleteFunc func(callerID, prefix string) []string
+
+// Autocomplete suggests the values of the argument of the command, while the caller is typing the prefix.
+// The inputs are the name of the command and the name of its subcommand, if it has any.
+// The arguments without an AutocompleteFunc suggest their choices.
+func (be *BotEngine) Autocomplete(callerID string, inputs []string, argName, prefix string) []string {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	cmd := be.FindCommand(inputs[0])
+	if cmd == nil {
+		return nil
+	}
+
+	if len(cmd.SubCommands) > 0 {
+		if len(inputs) < 2 {
+			return nil
+		}
+
+		cmd = cmd.SubCommand(inputs[1])
+		if cmd == nil {
+			return nil
+		}
+	}
+
+	argIndex := slices.IndexFunc(cmd.Args, func(arg Args) bool { return arg.Name == argName })
+	if argIndex == -1 {
+		return nil
+	}
+	arg := cmd.Args[argIndex]
+
+	var values []string
+	if arg.Autocomplete != nil {
+		values = arg.Autocomplete(callerID, prefix)
+	} else {
+		values = withPrefix(arg.Choices, prefix)
+	}
+
+	if len(values) > MaxAutocompleteChoices {
+		values = values[:MaxAutocompleteChoices]
+	}
+
+	return values
+}
+
+// withPrefix returns the values that start with the prefix, case-insensitively.
+func withPrefix(values []string, prefix string) []string {
+	prefix = strings.ToLower(prefix)
+
+	matched := make([]string, 0, len(values))
+	for _, value := range values {
+		if strings.HasPrefix(strings.ToLower(value), prefix) && !slices.Contains(matched, value) {
+			matched = append(matched, value)
+		}
+	}
+
+	return matched
+}
+
+// completeValidatorAddress suggests the linked validator and the recently used validators of the caller
+// that start with the prefix first, then the addresses of the connected peers that match it, see SearchPeers.
+func (be *BotEngine) completeValidatorAddress(callerID, prefix string) []string {
+	known := []string{}
+	if be.store != nil && callerID != "" {
+		if prefs := be.store.UserPrefs(callerID); prefs != nil {
+			if prefs.ValidatorAddr != "" {
+				known = append(known, prefs.ValidatorAddr)
+			}
+			known = append(known, prefs.RecentValidators...)
+		}
+	}
+	values := withPrefix(known, prefix)
+
+	if be.clientMgr == nil || len([]rune(strings.TrimSpace(prefix))) < minPeerSearchQuery {
+		return values
+	}
+
+	matches, err := be.clientMgr.SearchPeers(prefix, MaxAutocompleteChoices)
+	if err != nil {
+		be.logger.Debug("unable to search the peers for autocomplete", "err", err)
+
+		return values
+	}
+
+	// the peers can match by moniker, so their addresses don't need to start with the prefix.
+	for _, m := range matches {
+		if m.Address != "" && !slices.Contains(values, m.Address) {
+			values = append(values, m.Address)
+		}
+	}
+
+	return values
+}
+
+// rememberValidator adds the validator to the recently used validators of the caller, for the autocomplete.
+func (be *BotEngine) rememberValidator(callerID, valAddr string) {
+	if be.store == nil || callerID == "" || valAddr == "" {
+		return
+	}
+
+	prefs := be.store.UserPrefs(callerID)
+	if prefs == nil {
+		prefs = &store.UserPrefs{DiscordID: callerID}
+	}
+
+	if len(prefs.RecentValidators) > 0 && prefs.RecentValidators[0] == valAddr {
+		return
+	}
+
+	recent := slices.DeleteFunc(prefs.RecentValidators, func(addr string) bool { return addr == valAddr })
+	prefs.RecentValidators = append([]string{valAddr}, recent...)
+	if len(prefs.RecentValidators) > maxRecentValidators {
+		prefs.RecentValidators = prefs.RecentValidators[:maxRecentValidators]
+	}
+
+	if err := be.store.SaveUserPrefs(prefs); err != nil {
+		be.logger.Warn("unable to save the recent validators", "callerID", callerID, "err", err)
+	}
+}
+
+// completeWatchedAddress completes the addresses that the caller is watching.
+func (be *BotEngine) completeWatchedAddress(callerID, prefix string) []string {
+	watches, err := be.addressWatchesOf(callerID)
+	if err != nil {
+		return []string{}
+	}
+
+	return withPrefix(watches, prefix)
+}
+
+// completeLinkedAddress suggests the addresses that are linked to the profile of the caller.
+func (be *BotEngine) completeLinkedAddress(callerID, prefix string) []string {
+	profile, err := be.profileOf(callerID)
+	if err != nil {
+		return []string{}
+	}
+
+	addrs := make([]string, 0, len(profile.Addresses))
+	for _, l := range profile.Addresses {
+		addrs = append(addrs, l.Address)
+	}
+
+	return withPrefix(addrs, prefix)
+}
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kehiy/RoboPac/store"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAutocomplete(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	be.RegisterCommands()
+	be.Cmds = append(be.Cmds, Command{
+		Name:    "mode",
+		Args:    []Args{{Name: "alerts", Choices: []string{"on", "off"}}},
+		Handler: okHandler,
+	})
+
+	mockStore := store.NewMockIStore(gomock.NewController(t))
+	be.store = mockStore
+
+	prefs := map[string]*store.UserPrefs{
+		"1": {DiscordID: "1", ValidatorAddr: "pc1plinked", RecentValidators: []string{"pc1precent", "pc1plinked"}},
+	}
+	mockStore.EXPECT().UserPrefs(gomock.Any()).DoAndReturn(func(id string) *store.UserPrefs {
+		return prefs[id]
+	}).AnyTimes()
+	mockStore.EXPECT().SaveUserPrefs(gomock.Any()).DoAndReturn(func(p *store.UserPrefs) error {
+		prefs[p.DiscordID] = p
+
+		return nil
+	}).AnyTimes()
+
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(&pactus.GetNetworkInfoResponse{
+		ConnectedPeers: []*pactus.PeerInfo{
+			{Moniker: "pc1-fans", ConsensusAddress: []string{"pc1ppeer"}},
+		},
+	}, nil).AnyTimes()
+
+	t.Run("linked and recent validators", func(t *testing.T) {
+		values := be.Autocomplete("1", []string{NodeInfoCommandName}, "validator-address", "")
+		assert.Equal(t, []string{"pc1plinked", "pc1precent"}, values)
+
+		values = be.Autocomplete("1", []string{NodeInfoCommandName}, "validator-address", "PC1PR")
+		assert.Equal(t, []string{"pc1precent"}, values)
+	})
+
+	t.Run("connected peers", func(t *testing.T) {
+		values := be.Autocomplete("1", []string{NodeInfoCommandName}, "validator-address", "pc1")
+		assert.Equal(t, []string{"pc1plinked", "pc1precent", "pc1ppeer"}, values)
+
+		values = be.Autocomplete("2", []string{NodeInfoCommandName}, "validator-address", "fans")
+		assert.Equal(t, []string{"pc1ppeer"}, values)
+	})
+
+	t.Run("choices", func(t *testing.T) {
+		values := be.Autocomplete("1", []string{"mode"}, "alerts", "o")
+		assert.Equal(t, []string{"on", "off"}, values)
+
+		values = be.Autocomplete("1", []string{"mode"}, "alerts", "of")
+		assert.Equal(t, []string{"off"}, values)
+	})
+
+	t.Run("unknown argument", func(t *testing.T) {
+		assert.Empty(t, be.Autocomplete("1", []string{"unknown"}, "validator-address", ""))
+		assert.Empty(t, be.Autocomplete("1", []string{NodeInfoCommandName}, "unknown", ""))
+		assert.Empty(t, be.Autocomplete("1", nil, "validator-address", ""))
+	})
+
+	t.Run("remember validators", func(t *testing.T) {
+		be.rememberValidator("3", "pc1pfirst")
+		be.rememberValidator("3", "pc1psecond")
+		be.rememberValidator("3", "pc1pfirst")
+		assert.Equal(t, []string{"pc1pfirst", "pc1psecond"}, prefs["3"].RecentValidators)
+
+		for i := 0; i < maxRecentValidators+5; i++ {
+			be.rememberValidator("3", fmt.Sprintf("pc1p%d", i))
+		}
+		assert.Len(t, prefs["3"].RecentValidators, maxRecentValidators)
+		assert.Equal(t, fmt.Sprintf("pc1p%d", maxRecentValidators+4), prefs["3"].RecentValidators[0])
+	})
+}
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kehiy/RoboPac/utils"
+	"github.com/pactus-project/pactus/util"
+)
+
+// blockPageSize is the number of the transactions on each page of the block.
+const blockPageSize = 10
+
+// blockHandler summarizes the block at the height, its transactions are paginated.
+func (be *BotEngine) blockHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	parsed, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil || parsed == 0 {
+		return MakeFailedResult("Invalid block height: %s", args[0]), nil
+	}
+	height := uint32(parsed)
+
+	page, err := parsePage(args[1:])
+	if err != nil {
+		return MakeFailedResult(err.Error()), nil
+	}
+
+	network := networkOf(args, 2)
+	cm, err := be.networkClient(network)
+	if err != nil {
+		return nil, err
+	}
+
+	lastHeight, err := cm.GetBlockchainHeight()
+	if err != nil {
+		return nil, err
+	}
+	if height > lastHeight {
+		return MakeFailedResult("Block %d is not committed yet, the last block is %d", height, lastHeight), nil
+	}
+
+	block, err := cm.GetBlockWithTxs(height)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]string, 0, len(block.Txs))
+	reward, fees := int64(0), int64(0)
+	for _, info := range block.Txs {
+		trx, err := decodeRawTransaction(info.Data, height, block.BlockTime, lastHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		if trx.Subsidy {
+			reward += trx.Amount
+		}
+		fees += trx.Fee
+		items = append(items, blockTxSummary(trx))
+	}
+
+	res := MakeListResult(Paginate(items, page, blockPageSize))
+	res.Title = fmt.Sprintf("Block %s", utils.FormatNumber(int64(height)))
+	res.AddField("Hash", hex.EncodeToString(block.Hash), false)
+	res.AddField("Proposer", block.GetHeader().GetProposerAddress(), false)
+	res.AddField("Time", time.Unix(int64(block.BlockTime), 0).UTC().Format(time.DateTime), true)
+	res.AddField("Transactions", utils.FormatNumber(int64(len(block.Txs))), true)
+	res.AddField("Confirmations", utils.FormatNumber(int64(lastHeight-height+1)), true)
+	res.AddField("Reward", util.ChangeToString(reward)+" PAC", true)
+	res.AddField("Fees", util.ChangeToString(fees)+" PAC", true)
+
+	if res.List.HasNext() {
+		next := strings.TrimSpace(fmt.Sprintf("%s %d %d %s", BlockCommandName, height, res.List.Page+1, network))
+		res.AddWarning(fmt.Sprintf("Run `%s` for the next transactions", next))
+	}
+
+	return res, nil
+}
+
+// blockTxSummary is the line of the transaction in the block, like "`1a2b...` Transfer: 1.5 PAC to pc1...".
+func blockTxSummary(trx *Transaction) string {
+	short := trx.ID
+	if len(short) > 8 {
+		short = short[:8] + "…"
+	}
+
+	switch {
+	case trx.Subsidy:
+		return fmt.Sprintf("`%s` Block Reward: %s PAC to %s", short, util.ChangeToString(trx.Amount), trx.Receiver)
+	case trx.Receiver != "":
+		return fmt.Sprintf("`%s` %s: %s PAC from %s to %s",
+			short, typeTitle(trx.Type), util.ChangeToString(trx.Amount), trx.Sender, trx.Receiver)
+	default:
+		return fmt.Sprintf("`%s` %s by %s", short, typeTitle(trx.Type), trx.Sender)
+	}
+}
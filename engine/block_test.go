@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pactus-project/pactus/types/tx"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlockCommand(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	be.RegisterCommands()
+
+	trxs := []*tx.Tx{tx.NewSubsidyTx(90, fixtureValidator, 1e9+12e7, "")}
+	for i := 0; i < 12; i++ {
+		trxs = append(trxs, tx.NewTransferTx(90, fixtureAccount, fixtureValidator, int64(i+1)*1e9, 1e7, ""))
+	}
+	block := fixtureBlock(t, trxs...)
+	block.Hash = []byte{0xab, 0xcd}
+	block.Header = &pactus.BlockHeaderInfo{ProposerAddress: fixtureValidator.String()}
+
+	t.Run("first page", func(t *testing.T) {
+		mockClient.EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(109), nil)
+		mockClient.EXPECT().GetBlockWithTxs(gomock.Any(), uint32(100)).Return(block, nil)
+
+		res, err := be.Run(AppIdCLI, "1", []string{BlockCommandName, "100"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "Block 100", res.Title)
+		assert.Equal(t, "abcd", res.Fields[0].Value)
+		assert.Equal(t, fixtureValidator.String(), res.Fields[1].Value)
+		assert.Equal(t, "13", res.Fields[3].Value)
+		assert.Equal(t, "10", res.Fields[4].Value)
+		assert.Equal(t, "1.12 PAC", res.Fields[5].Value)
+		assert.Equal(t, "0.12 PAC", res.Fields[6].Value)
+
+		require.NotNil(t, res.List)
+		assert.Len(t, res.List.Items, blockPageSize)
+		assert.Contains(t, res.List.Items[0], "Block Reward: 1.12 PAC")
+		assert.Contains(t, res.Warnings[0], fmt.Sprintf("%s 100 2", BlockCommandName))
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		mockClient.EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(109), nil)
+		mockClient.EXPECT().GetBlockWithTxs(gomock.Any(), uint32(100)).Return(block, nil)
+
+		res, err := be.Run(AppIdCLI, "1", []string{BlockCommandName, "100", "2"})
+		require.NoError(t, err)
+		assert.Len(t, res.List.Items, 3)
+		assert.Contains(t, res.List.Items[2], "Transfer: 12 PAC")
+		assert.Empty(t, res.Warnings)
+	})
+
+	t.Run("not committed", func(t *testing.T) {
+		mockClient.EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(109), nil)
+
+		res, err := be.Run(AppIdCLI, "1", []string{BlockCommandName, "110"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "not committed")
+	})
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type circuitBreaker struct {
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+// commandBreakers keeps a circuit breaker per command.
+// After threshold consecutive failures the breaker opens and the command is short-circuited
+// for the cooldown period. Then a single call is let through to probe the recovery:
+// a success closes the breaker and a failure opens it again.
+// A non-positive threshold disables the breakers.
+type commandBreakers struct {
+	lk sync.Mutex
+
+	threshold int
+	cooldown  time.Duration
+	breakers  map[string]*circuitBreaker
+	nowFunc   func() time.Time
+}
+
+func newCommandBreakers(threshold int, cooldown time.Duration) *commandBreakers {
+	return &commandBreakers{
+		threshold: threshold,
+		cooldown:  cooldown,
+		breakers:  make(map[string]*circuitBreaker),
+		nowFunc:   time.Now,
+	}
+}
+
+func (cb *commandBreakers) breaker(cmdName string) *circuitBreaker {
+	b, ok := cb.breakers[cmdName]
+	if !ok {
+		b = &circuitBreaker{}
+		cb.breakers[cmdName] = b
+	}
+
+	return b
+}
+
+// allow reports whether the command can be called now.
+func (cb *commandBreakers) allow(cmdName string) bool {
+	if cb.threshold <= 0 {
+		return true
+	}
+
+	cb.lk.Lock()
+	defer cb.lk.Unlock()
+
+	b := cb.breaker(cmdName)
+	switch b.state {
+	case breakerOpen:
+		if cb.nowFunc().Sub(b.openedAt) < cb.cooldown {
+			return false
+		}
+		// only one probing call is allowed while the breaker is half-open.
+		b.state = breakerHalfOpen
+
+		return true
+
+	case breakerHalfOpen:
+		return false
+
+	default:
+		return true
+	}
+}
+
+// report records the outcome of a call that was allowed by the breaker.
+func (cb *commandBreakers) report(cmdName string, succeeded bool) {
+	if cb.threshold <= 0 {
+		return
+	}
+
+	cb.lk.Lock()
+	defer cb.lk.Unlock()
+
+	b := cb.breaker(cmdName)
+	if succeeded {
+		b.state = breakerClosed
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= cb.threshold {
+		b.state = breakerOpen
+		b.openedAt = cb.nowFunc()
+	}
+}
+
+func (cb *commandBreakers) state(cmdName string) breakerState {
+	cb.lk.Lock()
+	defer cb.lk.Unlock()
+
+	return cb.breaker(cmdName).state
+}
+
+// configure changes the threshold and the cooldown, the states of the breakers are kept.
+func (cb *commandBreakers) configure(threshold int, cooldown time.Duration) {
+	cb.lk.Lock()
+	defer cb.lk.Unlock()
+
+	cb.threshold = threshold
+	cb.cooldown = cooldown
+}
+
+// SetCircuitBreaker configures the circuit breaker of the node dependent commands.
+// A non-positive threshold disables the circuit breaker. It's safe to call while the bot is running.
+func (be *BotEngine) SetCircuitBreaker(threshold int, cooldown time.Duration) {
+	be.breakers.configure(threshold, cooldown)
+}
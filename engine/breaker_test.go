@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cb := newCommandBreakers(3, time.Minute)
+	cb.nowFunc = func() time.Time { return now }
+
+	t.Run("closed until the threshold", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			assert.True(t, cb.allow("cmd"))
+			cb.report("cmd", false)
+		}
+		assert.Equal(t, breakerClosed, cb.state("cmd"))
+
+		// a success resets the consecutive failures.
+		assert.True(t, cb.allow("cmd"))
+		cb.report("cmd", true)
+
+		for i := 0; i < 2; i++ {
+			assert.True(t, cb.allow("cmd"))
+			cb.report("cmd", false)
+		}
+		assert.Equal(t, breakerClosed, cb.state("cmd"))
+	})
+
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		assert.True(t, cb.allow("cmd"))
+		cb.report("cmd", false)
+
+		assert.Equal(t, breakerOpen, cb.state("cmd"))
+		assert.False(t, cb.allow("cmd"))
+		assert.True(t, cb.allow("other-cmd"))
+	})
+
+	t.Run("half-open after the cooldown", func(t *testing.T) {
+		now = now.Add(time.Minute)
+
+		assert.True(t, cb.allow("cmd"))
+		assert.Equal(t, breakerHalfOpen, cb.state("cmd"))
+
+		// only one probing call is allowed.
+		assert.False(t, cb.allow("cmd"))
+	})
+
+	t.Run("failed probe opens again", func(t *testing.T) {
+		cb.report("cmd", false)
+
+		assert.Equal(t, breakerOpen, cb.state("cmd"))
+		assert.False(t, cb.allow("cmd"))
+	})
+
+	t.Run("successful probe closes", func(t *testing.T) {
+		now = now.Add(time.Minute)
+
+		assert.True(t, cb.allow("cmd"))
+		cb.report("cmd", true)
+
+		assert.Equal(t, breakerClosed, cb.state("cmd"))
+		assert.True(t, cb.allow("cmd"))
+	})
+
+	t.Run("concurrent calls", func(t *testing.T) {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				if cb.allow("concurrent-cmd") {
+					cb.report("concurrent-cmd", false)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, breakerOpen, cb.state("concurrent-cmd"))
+	})
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	cb := newCommandBreakers(0, time.Minute)
+
+	for i := 0; i < 10; i++ {
+		assert.True(t, cb.allow("cmd"))
+		cb.report("cmd", false)
+	}
+}
+
+func TestRunWithCircuitBreaker(t *testing.T) {
+	calls := 0
+	failingHandler := func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+		calls++
+
+		return nil, status.Error(codes.Unavailable, "node is down")
+	}
+
+	be := setupTestEngine(t,
+		Command{Name: "node-cmd", AppIDs: []AppID{AppIdCLI}, Handler: failingHandler, NodeDependent: true},
+		Command{Name: "local-cmd", AppIDs: []AppID{AppIdCLI}, Handler: failingHandler},
+	)
+	be.SetCircuitBreaker(2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		_, err := be.Run(AppIdCLI, "", []string{"node-cmd"})
+		assert.Error(t, err)
+	}
+
+	res, err := be.Run(AppIdCLI, "", []string{"node-cmd"})
+	require.NoError(t, err)
+	assert.False(t, res.Successful)
+	assert.Equal(t, 2, calls)
+
+	for i := 0; i < 3; i++ {
+		_, err := be.Run(AppIdCLI, "", []string{"local-cmd"})
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 5, calls)
+}
+
+func TestCircuitBreakerInputErrors(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{
+			Name: "node-cmd", AppIDs: []AppID{AppIdCLI}, NodeDependent: true,
+			Handler: func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+				return nil, errors.New("unknown network")
+			},
+		},
+	)
+	be.SetCircuitBreaker(2, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		_, err := be.Run(AppIdCLI, "", []string{"node-cmd"})
+		assert.ErrorContains(t, err, "unknown network")
+	}
+	assert.Equal(t, breakerClosed, be.breakers.state("node-cmd"), "the errors of the inputs are not node failures")
+}
+
+func TestCircuitBreakerPanickedProbe(t *testing.T) {
+	panics := true
+	be := setupTestEngine(t,
+		Command{
+			Name: "node-cmd", AppIDs: []AppID{AppIdCLI}, NodeDependent: true,
+			Handler: func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+				if panics {
+					panic("broken")
+				}
+
+				return MakeSuccessfulResult("ok"), nil
+			},
+		},
+	)
+	now := time.Unix(1_700_000_000, 0)
+	be.breakers.nowFunc = func() time.Time { return now }
+	be.SetCircuitBreaker(1, time.Minute)
+
+	_, err := be.Run(AppIdCLI, "", []string{"node-cmd"})
+	assert.Error(t, err)
+	assert.Equal(t, breakerOpen, be.breakers.state("node-cmd"))
+
+	now = now.Add(time.Minute)
+	_, err = be.Run(AppIdCLI, "", []string{"node-cmd"})
+	assert.Error(t, err)
+	assert.Equal(t, breakerOpen, be.breakers.state("node-cmd"), "the panicked probe opens the breaker again")
+
+	panics = false
+	now = now.Add(time.Minute)
+	res, err := be.Run(AppIdCLI, "", []string{"node-cmd"})
+	require.NoError(t, err)
+	assert.True(t, res.Successful)
+	assert.Equal(t, breakerClosed, be.breakers.state("node-cmd"))
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kehiy/RoboPac/audit"
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/util"
+)
+
+const (
+	claimPayoutJobName = "claim-payouts"
+	claimPayoutSpec    = "@every 1m"
+	claimMemo          = "TestNet reward claim from RoboPac"
+)
+
+// ClaimMessage is the message that the claimers sign with their main-net validator key,
+// it contains the testnet address, so the signature can't be used for another claim.
+func ClaimMessage(testnetAddr string) string {
+	return fmt.Sprintf("RoboPac claim: %s", testnetAddr)
+}
+
+// verifyClaimSignature checks that the signature of the claim message belongs to the validator.
+func verifyClaimSignature(pubKey, validatorAddr, testnetAddr, signature string) error {
+	addr, err := crypto.AddressFromString(validatorAddr)
+	if err != nil {
+		return err
+	}
+
+	if !addr.IsValidatorAddress() {
+		return fmt.Errorf("%s is not a validator address", validatorAddr)
+	}
+
+	return verifyAddressSignature(pubKey, validatorAddr, ClaimMessage(testnetAddr), signature)
+}
+
+// scheduleClaimPayouts schedules the payouts of the requested claims.
+func (be *BotEngine) scheduleClaimPayouts() error {
+	return be.Schedule(scheduler.Job{
+		Name: claimPayoutJobName,
+		Spec: claimPayoutSpec,
+		Run:  func(_ context.Context) { be.payClaims() },
+	})
+}
+
+// payClaims sends the bond transactions of the pending claims and saves their transaction IDs.
+func (be *BotEngine) payClaims() {
+	be.Lock()
+	defer be.Unlock()
+
+	if be.unsavedClaims == nil {
+		be.unsavedClaims = make(map[string]string)
+	}
+
+	for testnetAddr, claimer := range be.store.PendingClaims() {
+		// the reward is sent, but the store failed to save it. Don't send it again.
+		if txID, ok := be.unsavedClaims[testnetAddr]; ok {
+			be.logger.Error("claim transaction is not saved", "testnetAddr", testnetAddr, "txID", txID)
+
+			continue
+		}
+
+		if be.wallet.Balance() < claimer.TotalReward {
+			be.logger.Warn("bot wallet hasn't enough balance for the claims")
+
+			return
+		}
+
+		txID, err := be.wallet.BondTransaction(claimer.MainnetPubKey, claimer.MainnetAddr, claimMemo, claimer.TotalReward)
+		be.recordAudit(audit.Entry{
+			Kind:     audit.KindClaim,
+			CallerID: claimer.DiscordID,
+			Inputs:   []string{testnetAddr, claimer.MainnetAddr, util.ChangeToString(claimer.TotalReward) + " PAC"},
+			TxID:     txID,
+			Error:    auditError(nil, err),
+		})
+		if err != nil || txID == "" {
+			be.logger.Error("can't send the claim bond transaction", "err", err, "testnetAddr", testnetAddr)
+
+			continue
+		}
+
+		be.logger.Info("new bond transaction sent", "txID", txID, "testnetAddr", testnetAddr)
+
+		if err := be.store.AddClaimTransaction(testnetAddr, txID); err != nil {
+			be.logger.Error("unable to add the claim transaction",
+				"error", err,
+				"discordID", claimer.DiscordID,
+				"testnetAddr", testnetAddr,
+				"txID", txID,
+			)
+			be.unsavedClaims[testnetAddr] = txID
+		}
+	}
+}
+
+// requestClaim verifies the claim and queues its payout.
+func (be *BotEngine) requestClaim(callerID, mainnetAddr, testnetAddr, signature string) error {
+	be.Lock()
+	defer be.Unlock()
+
+	valInfo, _ := be.clientMgr.GetValidatorInfo(mainnetAddr)
+	if valInfo != nil {
+		return errors.New("this address is already a staked validator")
+	}
+
+	claimer := be.store.ClaimerInfo(testnetAddr)
+	if claimer == nil {
+		return errors.New("claimer not found")
+	}
+
+	if claimer.DiscordID != callerID {
+		be.logger.Warn("try to claim other's reward", "claimer", claimer.DiscordID, "discordID", callerID)
+
+		return errors.New("invalid claimer")
+	}
+
+	if claimer.IsClaimed() || claimer.IsPending() {
+		return errors.New("this claimer have already claimed rewards")
+	}
+
+	if be.wallet.Balance() < claimer.TotalReward {
+		be.logger.Warn("bot wallet hasn't enough balance")
+
+		return errors.New("insufficient wallet balance")
+	}
+
+	pubKey, err := be.clientMgr.FindPublicKey(mainnetAddr, true)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyClaimSignature(pubKey, mainnetAddr, testnetAddr, signature); err != nil {
+		be.logger.Warn("invalid claim signature", "err", err, "mainnetAddr", mainnetAddr, "discordID", callerID)
+
+		return fmt.Errorf("invalid signature, please sign \"%s\" with your main-net validator key",
+			ClaimMessage(testnetAddr))
+	}
+
+	return be.store.AddClaimRequest(testnetAddr, mainnetAddr, pubKey, time.Now().Unix())
+}
@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/wallet"
+	"github.com/pactus-project/pactus/crypto/bls"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClaim(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	ctrl := gomock.NewController(t)
+	mockWallet := wallet.NewMockIWallet(ctrl)
+	mockStore := store.NewMockIStore(ctrl)
+	be.wallet = mockWallet
+	be.store = mockStore
+
+	prv, err := bls.KeyGen(make([]byte, 32), nil)
+	require.NoError(t, err)
+	pub := prv.PublicKeyNative()
+	mainnetAddr := pub.ValidatorAddress().String()
+	testnetAddr := "tpc1pqn7uaeduklpg00rqt6uq0m9wy5txnyt0kmxmgf"
+	signature := prv.Sign([]byte(ClaimMessage(testnetAddr))).String()
+
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(&pactus.GetNetworkInfoResponse{
+		ConnectedPeers: []*pactus.PeerInfo{
+			{ConsensusAddress: []string{mainnetAddr}, ConsensusKeys: []string{pub.String()}},
+		},
+	}, nil)
+	be.clientMgr.Start()
+
+	mockClient.EXPECT().GetValidatorInfo(gomock.Any(), mainnetAddr).Return(nil, errors.New("not found")).AnyTimes()
+
+	claimer := &store.Claimer{DiscordID: "123", TotalReward: 100e9}
+
+	t.Run("invalid signature", func(t *testing.T) {
+		mockStore.EXPECT().ClaimerInfo(testnetAddr).Return(claimer)
+		mockWallet.EXPECT().Balance().Return(int64(500e9))
+
+		otherSig := prv.Sign([]byte(ClaimMessage("tpc1other"))).String()
+		_, err := be.claimHandler(AppIdDiscord, "123", mainnetAddr, testnetAddr, otherSig)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("other's reward", func(t *testing.T) {
+		mockStore.EXPECT().ClaimerInfo(testnetAddr).Return(claimer)
+
+		_, err := be.claimHandler(AppIdDiscord, "456", mainnetAddr, testnetAddr, signature)
+		assert.EqualError(t, err, "invalid claimer")
+	})
+
+	t.Run("queue the claim", func(t *testing.T) {
+		mockStore.EXPECT().ClaimerInfo(testnetAddr).Return(claimer)
+		mockWallet.EXPECT().Balance().Return(int64(500e9))
+		mockStore.EXPECT().AddClaimRequest(testnetAddr, mainnetAddr, pub.String(), gomock.Any()).Return(nil)
+
+		res, err := be.claimHandler(AppIdDiscord, "123", mainnetAddr, testnetAddr, signature)
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+	})
+
+	t.Run("double claim", func(t *testing.T) {
+		pending := *claimer
+		pending.MainnetAddr = mainnetAddr
+		mockStore.EXPECT().ClaimerInfo(testnetAddr).Return(&pending)
+
+		_, err := be.claimHandler(AppIdDiscord, "123", mainnetAddr, testnetAddr, signature)
+		assert.EqualError(t, err, "this claimer have already claimed rewards")
+	})
+}
+
+func TestPayClaims(t *testing.T) {
+	be := setupTestEngine(t)
+	ctrl := gomock.NewController(t)
+	mockWallet := wallet.NewMockIWallet(ctrl)
+	mockStore := store.NewMockIStore(ctrl)
+	be.wallet = mockWallet
+	be.store = mockStore
+
+	pending := map[string]*store.Claimer{
+		"tpc1-testnet": {
+			DiscordID:     "123",
+			TotalReward:   100e9,
+			MainnetAddr:   "pc1-mainnet",
+			MainnetPubKey: "public-key",
+		},
+	}
+
+	t.Run("not saved transaction is not sent again", func(t *testing.T) {
+		mockStore.EXPECT().PendingClaims().Return(pending)
+		mockWallet.EXPECT().Balance().Return(int64(500e9))
+		mockWallet.EXPECT().BondTransaction("public-key", "pc1-mainnet", claimMemo, int64(100e9)).Return("0x123", nil)
+		mockStore.EXPECT().AddClaimTransaction("tpc1-testnet", "0x123").Return(errors.New("disk is full"))
+		be.payClaims()
+
+		mockStore.EXPECT().PendingClaims().Return(pending)
+		be.payClaims()
+	})
+
+	t.Run("pay the claims", func(t *testing.T) {
+		be.unsavedClaims = nil
+
+		mockStore.EXPECT().PendingClaims().Return(pending)
+		mockWallet.EXPECT().Balance().Return(int64(500e9))
+		mockWallet.EXPECT().BondTransaction("public-key", "pc1-mainnet", claimMemo, int64(100e9)).Return("0x123", nil)
+		mockStore.EXPECT().AddClaimTransaction("tpc1-testnet", "0x123").Return(nil)
+		be.payClaims()
+	})
+
+	t.Run("insufficient balance", func(t *testing.T) {
+		mockStore.EXPECT().PendingClaims().Return(pending)
+		mockWallet.EXPECT().Balance().Return(int64(1e9))
+		be.payClaims()
+	})
+}
+
+func TestVerifyClaimSignature(t *testing.T) {
+	prv, err := bls.KeyGen(make([]byte, 32), nil)
+	require.NoError(t, err)
+	pub := prv.PublicKeyNative()
+	sig := prv.Sign([]byte(ClaimMessage("tpc1-testnet"))).String()
+
+	assert.NoError(t, verifyClaimSignature(pub.String(), pub.ValidatorAddress().String(), "tpc1-testnet", sig))
+	assert.Error(t, verifyClaimSignature(pub.String(), pub.AccountAddress().String(), "tpc1-testnet", sig))
+	assert.Error(t, verifyClaimSignature(pub.String(), pub.ValidatorAddress().String(), "tpc1-other", sig))
+	assert.Error(t, verifyClaimSignature(pub.String(), pub.ValidatorAddress().String(), "tpc1-testnet", "invalid"))
+}
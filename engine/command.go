@@ -3,19 +3,54 @@ package engine
 import (
 	"fmt"
 	"slices"
+	"time"
 )
 
 type AppID int
 
 const (
-	AppIdCLI     AppID = 1
-	AppIdDiscord AppID = 2
+	AppIdCLI      AppID = 1
+	AppIdDiscord  AppID = 2
+	AppIdTelegram AppID = 3
+	AppIdHTTP     AppID = 4
+	AppIdMatrix   AppID = 5
+	AppIdSlack    AppID = 6
 )
 
+func (id AppID) String() string {
+	switch id {
+	case AppIdCLI:
+		return "CLI"
+	case AppIdDiscord:
+		return "Discord"
+	case AppIdTelegram:
+		return "Telegram"
+	case AppIdHTTP:
+		return "HTTP"
+	case AppIdMatrix:
+		return "Matrix"
+	case AppIdSlack:
+		return "Slack"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(id))
+	}
+}
+
 type Args struct {
 	Name     string
 	Desc     string
 	Optional bool
+
+	// Type, range, choices and format of the value. They are validated before calling the handler.
+	// The format is checked for the string arguments, like FormatAddress.
+	Type     ArgType
+	MinValue *float64
+	MaxValue *float64
+	Choices  []string
+	Format   ArgFormat
+
+	// Autocomplete suggests the values while the argument is being typed, otherwise the choices are suggested.
+	Autocomplete AutocompleteFunc
 }
 
 type Command struct {
@@ -24,12 +59,94 @@ type Command struct {
 	Help    string
 	Args    []Args
 	AppIDs  []AppID
-	Handler func(source AppID, callerID string, args ...string) (*CommandResult, error)
+	Handler CommandHandler
+
+	// SubCommands are the nested commands, like "wallet balance". The command with subcommands
+	// has no handler and arguments of its own, the first input after its name selects the subcommand.
+	// The subcommands share the apps of their parent.
+	SubCommands []Command
+
+	// Category groups the command in the help, see CategoryOf.
+	Category Category
+	// Examples are the sample inputs of the command without its name, like "pc1z... 30", shown in the help.
+	Examples []string
+
+	// Deprecated commands keep working, but their results carry a warning.
+	Deprecated bool
+	ReplacedBy string
+
+	// NodeDependent commands are short-circuited by the circuit breaker while the node keeps failing.
+	NodeDependent bool
+	// Fallback is the behavior of the node dependent command when the node is unreachable.
+	Fallback Fallback
+	// Network is the default network of the node dependent command, like mainnet. The commands with
+	// a network accept the network argument, when the bot is connected to more than one network.
+	Network string
+
+	// RateLimit is checked by the engine for all the apps, unlike the per-app cooldowns.
+	RateLimit RateLimit
+
+	// MinRole is the minimum role of the callers who can run the command.
+	MinRole Role
+
+	// ConfirmPhrase, if set, must be typed by the caller before running the command.
+	// It guards the dangerous commands on the apps that support it, like Discord.
+	ConfirmPhrase string
+
+	// MinAccountAge and MinMemberAge are the minimum age of the caller's account and guild membership,
+	// to curb the abuse of faucet-like commands. They are checked on the apps that support them, like Discord.
+	MinAccountAge time.Duration
+	MinMemberAge  time.Duration
 }
 
 type CommandResult struct {
 	Message    string
 	Successful bool
+	Warnings   []string
+	Fields     []ResultField
+	List       *ListResult
+
+	// Title replaces the default title of the result, like "Successful", on the apps that support it.
+	Title string
+	// Table is the tabular data of the result, see ResultTable.
+	Table *ResultTable
+	// Attachment is a file that is sent along with the result, like an export.
+	Attachment *ResultAttachment
+
+	// Suggestions are the names of the commands that are relevant to run next.
+	Suggestions []string
+	// Actions are offered with the result on the apps that support them, like the buttons on Discord.
+	Actions []ResultAction
+	// Menu is a select menu of the actions on the apps that support it, like the networks to switch to.
+	Menu *ResultMenu
+
+	// Maintenance is set when the command is rejected by the maintenance mode.
+	Maintenance bool
+
+	// Branded results are rendered with the brand style on the apps that support it, like Discord.
+	Branded bool
+}
+
+// ResultField is a titled part of the result, rendered as a field on the apps that support it, like Discord.
+type ResultField struct {
+	Name   string
+	Value  string
+	Inline bool
+}
+
+// ResultAction is an action that is offered with the result, like a "Refresh" button.
+// When it's taken, its inputs are run as a command by the caller.
+type ResultAction struct {
+	Label  string
+	Inputs []string
+	// Replace shows the result of the action in place of this result, like a refresh.
+	Replace bool
+}
+
+// ResultMenu is a select menu of the actions, one of them is taken when it's selected.
+type ResultMenu struct {
+	Placeholder string
+	Options     []ResultAction
 }
 
 func MakeSuccessfulResult(message string, a ...interface{}) *CommandResult {
@@ -46,6 +163,82 @@ func MakeFailedResult(message string, a ...interface{}) *CommandResult {
 	}
 }
 
+// AddWarning appends a warning to the result.
+// Warnings are shown to the user alongside the message.
+func (res *CommandResult) AddWarning(warning string, a ...interface{}) {
+	res.Warnings = append(res.Warnings, fmt.Sprintf(warning, a...))
+}
+
+// AddField appends a field to the result.
+func (res *CommandResult) AddField(name, value string, inline bool) {
+	res.Fields = append(res.Fields, ResultField{Name: name, Value: value, Inline: inline})
+}
+
+// SetTable sets the table of the result with the given headers, the rows are added by AddRow.
+func (res *CommandResult) SetTable(headers ...string) {
+	res.Table = &ResultTable{Headers: headers}
+}
+
+// AddRow appends a row to the table of the result. SetTable must be called first.
+func (res *CommandResult) AddRow(cells ...string) {
+	res.Table.Rows = append(res.Table.Rows, cells)
+}
+
+// Attach attaches a file to the result.
+func (res *CommandResult) Attach(name, contentType string, data []byte) {
+	res.Attachment = &ResultAttachment{Name: name, ContentType: contentType, Data: data}
+}
+
+// Suggest appends the commands to the suggestions of the result.
+func (res *CommandResult) Suggest(cmdNames ...string) {
+	res.Suggestions = append(res.Suggestions, cmdNames...)
+}
+
+// AddAction appends an action to the result, its result is shown as a new result.
+func (res *CommandResult) AddAction(label string, inputs ...string) {
+	res.Actions = append(res.Actions, ResultAction{Label: label, Inputs: inputs})
+}
+
+// AddRefresh appends a "Refresh" action to the result, that runs the inputs again in place of the result.
+func (res *CommandResult) AddRefresh(inputs ...string) {
+	res.Actions = append(res.Actions, ResultAction{Label: "Refresh", Inputs: inputs, Replace: true})
+}
+
+// SetMenu sets the select menu of the actions of the result.
+func (res *CommandResult) SetMenu(placeholder string, options ...ResultAction) {
+	res.Menu = &ResultMenu{Placeholder: placeholder, Options: options}
+}
+
+// HasRequiredArgs reports whether the command can't be run without arguments.
+func (cmd *Command) HasRequiredArgs() bool {
+	if len(cmd.SubCommands) > 0 {
+		return true
+	}
+
+	for _, arg := range cmd.Args {
+		if !arg.Optional {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeprecationNote returns a note about the deprecation of the command,
+// or an empty string if the command is not deprecated.
+func (cmd *Command) DeprecationNote() string {
+	if !cmd.Deprecated {
+		return ""
+	}
+
+	if cmd.ReplacedBy == "" {
+		return fmt.Sprintf("`%s` is deprecated and will be removed soon", cmd.Name)
+	}
+
+	return fmt.Sprintf("`%s` is deprecated and will be removed soon, please use `%s` instead",
+		cmd.Name, cmd.ReplacedBy)
+}
+
 func (cmd *Command) CheckArgs(input []string) error {
 	minArg := len(cmd.Args)
 	maxArg := len(cmd.Args)
@@ -60,9 +253,43 @@ func (cmd *Command) CheckArgs(input []string) error {
 		return fmt.Errorf("incorrect number of arguments, expected %d but got %d", minArg, len(input))
 	}
 
+	for index, value := range input {
+		// the empty optional arguments are left to the handler.
+		if value == "" && cmd.Args[index].Optional {
+			continue
+		}
+
+		if err := cmd.Args[index].Validate(value); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// SubCommand returns the subcommand with the given name, or nil if there is no such subcommand.
+func (cmd *Command) SubCommand(name string) *Command {
+	foundIndex := slices.IndexFunc(cmd.SubCommands, func(sub Command) bool {
+		return sub.Name == name
+	})
+
+	if foundIndex == -1 {
+		return nil
+	}
+
+	return &cmd.SubCommands[foundIndex]
+}
+
+// SubCommandNames returns the names of the subcommands.
+func (cmd *Command) SubCommandNames() []string {
+	names := make([]string, 0, len(cmd.SubCommands))
+	for _, sub := range cmd.SubCommands {
+		names = append(names, sub.Name)
+	}
+
+	return names
+}
+
 func (cmd *Command) HasAppId(appID AppID) bool {
 	return slices.Contains(cmd.AppIDs, appID)
 }
@@ -0,0 +1,283 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupTestEngine(t *testing.T, cmds ...Command) *BotEngine {
+	t.Helper()
+
+	return &BotEngine{
+		logger:   log.NewSubLogger("test"),
+		Cmds:     cmds,
+		toggles:  newCommandToggles(),
+		outcomes: newRollingOutcomes(statsWindow, statsBucketSize),
+		messages: NewMessageCatalog(),
+		breakers: newCommandBreakers(defaultBreakerThreshold, defaultBreakerCooldown),
+
+		scheduler: scheduler.New(),
+		access:    newAppAccess(),
+		limits: inputLimits{
+			maxArgs:      defaultMaxArgs,
+			maxArgLength: defaultMaxArgLength,
+		},
+	}
+}
+
+func setupTestEngineWithClient(t *testing.T, cmds ...Command) (*BotEngine, *client.MockIClient) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockClient := client.NewMockIClient(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cm := client.NewClientMgr(ctx)
+	cm.AddClient(mockClient)
+
+	be := setupTestEngine(t, cmds...)
+	be.ctx = ctx
+	be.cancel = cancel
+	be.clientMgr = cm
+
+	return be, mockClient
+}
+
+func okHandler(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+	return MakeSuccessfulResult("ok"), nil
+}
+
+func TestDeprecatedCommand(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{
+			Name:    "new-cmd",
+			AppIDs:  []AppID{AppIdCLI},
+			Handler: okHandler,
+		},
+		Command{
+			Name:       "old-cmd",
+			AppIDs:     []AppID{AppIdCLI},
+			Handler:    okHandler,
+			Deprecated: true,
+			ReplacedBy: "new-cmd",
+		},
+	)
+	be.Cmds = append(be.Cmds, Command{
+		Name:    HelpCommandName,
+		AppIDs:  []AppID{AppIdCLI},
+		Handler: be.help,
+		Args:    []Args{{Name: "command", Optional: true}},
+	})
+
+	t.Run("not deprecated", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{"new-cmd"})
+		require.NoError(t, err)
+		assert.Empty(t, res.Warnings)
+	})
+
+	t.Run("deprecated command still works", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{"old-cmd"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "ok", res.Message)
+		require.Len(t, res.Warnings, 1)
+		assert.Contains(t, res.Warnings[0], "`old-cmd` is deprecated")
+		assert.Contains(t, res.Warnings[0], "`new-cmd`")
+	})
+
+	t.Run("help shows deprecation", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{HelpCommandName})
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "(deprecated)")
+
+		res, err = be.Run(AppIdCLI, "1", []string{HelpCommandName, "old-cmd"})
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "please use `new-cmd` instead")
+	})
+}
+
+func TestSuggestions(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{
+			Name:   "first",
+			AppIDs: []AppID{AppIdCLI, AppIdDiscord},
+			Handler: func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+				res := MakeSuccessfulResult("ok")
+				res.Suggest("second", "discord-only", "disabled", "unknown", "second")
+
+				return res, nil
+			},
+		},
+		Command{Name: "second", AppIDs: []AppID{AppIdCLI, AppIdDiscord}, Handler: okHandler},
+		Command{Name: "discord-only", AppIDs: []AppID{AppIdDiscord}, Handler: okHandler},
+		Command{Name: "disabled", AppIDs: []AppID{AppIdCLI, AppIdDiscord}, Handler: okHandler},
+	)
+	be.toggles.setEnabled("disabled", "", false)
+
+	res, err := be.Run(AppIdCLI, "1", []string{"first"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"second"}, res.Suggestions)
+
+	res, err = be.Run(AppIdDiscord, "1", []string{"first"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"second", "discord-only"}, res.Suggestions)
+}
+
+func TestActions(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{
+			Name:   "first",
+			AppIDs: []AppID{AppIdCLI, AppIdDiscord},
+			Handler: func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+				res := MakeSuccessfulResult("ok")
+				res.AddRefresh("first")
+				res.AddAction("Disabled", "disabled")
+				res.SetMenu("Pick one",
+					ResultAction{Label: "Discord only", Inputs: []string{"discord-only"}},
+					ResultAction{Label: "Unknown", Inputs: []string{"unknown"}})
+
+				return res, nil
+			},
+		},
+		Command{Name: "discord-only", AppIDs: []AppID{AppIdDiscord}, Handler: okHandler},
+		Command{Name: "disabled", AppIDs: []AppID{AppIdCLI, AppIdDiscord}, Handler: okHandler},
+	)
+	be.toggles.setEnabled("disabled", "", false)
+
+	res, err := be.Run(AppIdCLI, "1", []string{"first"})
+	require.NoError(t, err)
+	assert.Equal(t, []ResultAction{{Label: "Refresh", Inputs: []string{"first"}, Replace: true}}, res.Actions)
+	assert.Nil(t, res.Menu, "the menu without options is dropped")
+
+	res, err = be.Run(AppIdDiscord, "1", []string{"first"})
+	require.NoError(t, err)
+	require.NotNil(t, res.Menu)
+	assert.Equal(t, "Pick one", res.Menu.Placeholder)
+	assert.Equal(t, []ResultAction{{Label: "Discord only", Inputs: []string{"discord-only"}}}, res.Menu.Options)
+}
+
+func TestRunRequestID(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "ok", AppIDs: []AppID{AppIdDiscord}, Handler: okHandler},
+		Command{
+			Name:   "fail",
+			AppIDs: []AppID{AppIdDiscord},
+			Handler: func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+				return nil, errors.New("node is down")
+			},
+		},
+	)
+	buf := &bytes.Buffer{}
+	be.logger = log.NewSubLoggerWithWriter("test", buf)
+
+	_, err := be.RunWithOptions(RunOptions{RequestID: "req-1"}, AppIdDiscord, "1", []string{"ok"})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"requestID":"req-1"`)
+	assert.Contains(t, buf.String(), `"message":"run command"`)
+
+	buf.Reset()
+	_, err = be.RunWithOptions(RunOptions{RequestID: "req-2"}, AppIdDiscord, "1", []string{"fail"})
+	require.Error(t, err)
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		assert.Contains(t, string(line), `"requestID":"req-2"`)
+	}
+	assert.Contains(t, buf.String(), `"message":"command failed"`)
+
+	t.Run("without request ID", func(t *testing.T) {
+		buf.Reset()
+		_, err := be.Run(AppIdDiscord, "1", []string{"ok"})
+		require.NoError(t, err)
+		assert.NotContains(t, buf.String(), "requestID")
+	})
+}
+
+func TestRunMetrics(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "metrics-ok", AppIDs: []AppID{AppIdCLI}, Handler: okHandler},
+		Command{
+			Name:   "metrics-fail",
+			AppIDs: []AppID{AppIdCLI},
+			Handler: func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+				return nil, errors.New("node is down")
+			},
+		},
+	)
+
+	_, err := be.Run(AppIdCLI, "1", []string{"metrics-ok"})
+	require.NoError(t, err)
+	_, err = be.Run(AppIdCLI, "1", []string{"metrics-fail"})
+	require.Error(t, err)
+
+	assert.Equal(t, float64(1), metrics.CommandsTotal.Value("CLI", "metrics-ok", metrics.ResultSuccess))
+	assert.Equal(t, float64(1), metrics.CommandsTotal.Value("CLI", "metrics-fail", metrics.ResultError))
+	assert.Equal(t, float64(1), metrics.EngineErrorsTotal.Value("metrics-fail"))
+}
+
+func TestSubCommands(t *testing.T) {
+	echoHandler := func(_ AppID, _ string, args ...string) (*CommandResult, error) {
+		return MakeSuccessfulResult("%v", args), nil
+	}
+	be := setupTestEngine(t,
+		Command{
+			Name:   "wallet",
+			AppIDs: []AppID{AppIdCLI, AppIdDiscord},
+			SubCommands: []Command{
+				{Name: "balance", Handler: okHandler},
+				{Name: "address", Handler: echoHandler, Args: []Args{{Name: "index", Optional: true}}},
+				{Name: "reset", Handler: okHandler, MinRole: RoleAdmin},
+			},
+		},
+	)
+
+	t.Run("routing", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{"wallet", "balance"})
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res.Message)
+
+		res, err = be.Run(AppIdDiscord, "1", []string{"wallet", "address", "2"})
+		require.NoError(t, err)
+		assert.Equal(t, "[2]", res.Message)
+
+		assert.Equal(t, float64(1), metrics.CommandsTotal.Value("CLI", "wallet balance", metrics.ResultSuccess))
+	})
+
+	t.Run("missing or unknown subcommand", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{"wallet"})
+		assert.EqualError(t, err, "command wallet expects one of the subcommands: balance, address, reset")
+
+		_, err = be.Run(AppIdCLI, "1", []string{"wallet", "send"})
+		assert.Error(t, err)
+	})
+
+	t.Run("arguments of the subcommand", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{"wallet", "balance", "extra"})
+		assert.Error(t, err)
+	})
+
+	t.Run("role of the subcommand", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{"wallet", "reset"})
+		assert.Error(t, err)
+	})
+
+	t.Run("help", func(t *testing.T) {
+		cmd := be.FindCommand("wallet")
+		assert.True(t, cmd.HasRequiredArgs())
+		assert.Nil(t, cmd.SubCommand("send"))
+
+		res, err := be.help(AppIdCLI, "1", "wallet")
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "`wallet address [index]`")
+	})
+}
@@ -1,10 +1,17 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
+	"github.com/kehiy/RoboPac/audit"
+	"github.com/kehiy/RoboPac/client"
 	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/kehiy/RoboPac/snapshot"
 )
 
 const (
@@ -15,16 +22,69 @@ const (
 	NodeInfoCommandName      = "node-info"
 	NetworkStatusCommandName = "network"
 	NetworkHealthCommandName = "network-health"
+	NodeStatsCommandName     = "node-stats"
+	NodeCommandName          = "node"
+	NodeDiffCommandName      = "node-diff"
+	GrpcRawCommandName       = "grpc-raw"
+	PeersCommandName         = "peers"
+	PeerSearchCommandName    = "peer-search"
+	CommitteeCommandName     = "committee"
+	TopValidatorsCommandName = "top-validators"
+	TxCommandName            = "tx"
+	BlockCommandName         = "block"
+	AccountCommandName       = "account"
+	FeeCommandName           = "fee"
 
 	HelpCommandName       = "help"
 	WalletCommandName     = "wallet"
 	CalcRewardCommandName = "calc-reward"
+	PriceCommandName      = "price"
+
+	ToggleCommandCommandName = "toggle-command"
+	DiagCommandName          = "diag"
+	BotStatsCommandName      = "bot-stats"
+	AuditLogCommandName      = "audit-log"
+	CommandsCommandName      = "commands"
+	MaintenanceCommandName   = "maintenance"
+	ReloadConfigCommandName  = "reload-config"
+	SetLogLevelCommandName   = "set-log-level"
+	BanUserCommandName       = "ban-user"
+	UnbanUserCommandName     = "unban-user"
+	StatusEntriesCommandName = "status-entries"
 
 	BoosterPaymentCommandName   = "booster-payment"
 	BoosterClaimCommandName     = "booster-claim"
 	BoosterWhitelistCommandName = "booster-whitelist"
 	BoosterStatusCommandName    = "booster-status"
 
+	LinkCommandName        = "link"
+	UnlinkCommandName      = "unlink"
+	MeCommandName          = "me"
+	LinkAddressCommandName = "link-address"
+	MyAddressesCommandName = "my-addresses"
+	MyBalanceCommandName   = "my-balance"
+	MyValidatorCommandName = "my-validator"
+
+	SetLanguageCommandName = "set-language"
+
+	FaucetCommandName = "faucet"
+	TipCommandName    = "tip"
+
+	CreateReferralCommandName = "create-referral"
+	ClaimReferralCommandName  = "claim-referral"
+	ReferralReportCommandName = "referral-report"
+
+	ValidatorUptimeCommandName = "validator-uptime"
+	ValidatorAlertsCommandName = "validator-alerts"
+
+	ChartCommandName = "chart"
+
+	WatchAddressCommandName = "watch-address"
+	UnwatchCommandName      = "unwatch"
+	MyWatchesCommandName    = "my-watches"
+
+	PayoutCommandName = "payout"
+
 	DepositAddressCommandName = "deposit-address"
 	CreateOfferCommandName    = "create-offer"
 )
@@ -33,7 +93,7 @@ func (be *BotEngine) RegisterCommands() {
 	cmdClaim := Command{
 		Name: ClaimCommandName,
 		Desc: "claim your test-net rewards",
-		Help: "",
+		Help: "sign the message \"RoboPac claim: <testnet-address>\" with the key of your main-net validator",
 		Args: []Args{
 			{
 				Name:     "mainnet-address",
@@ -45,9 +105,18 @@ func (be *BotEngine) RegisterCommands() {
 				Desc:     "your test-net (validator) address like: tpc1p...",
 				Optional: false,
 			},
+			{
+				Name:     "signature",
+				Desc:     "the signature of the claim message, signed by your main-net validator",
+				Optional: false,
+			},
 		},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.claimHandler,
+
+		MinAccountAge: 30 * 24 * time.Hour,
+
+		Category: CategoryRewards,
 	}
 
 	cmdClaimerInfo := Command{
@@ -61,8 +130,10 @@ func (be *BotEngine) RegisterCommands() {
 				Optional: false,
 			},
 		},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.claimerInfoHandler,
+
+		Category: CategoryRewards,
 	}
 
 	cmdClaimStatus := Command{
@@ -70,8 +141,10 @@ func (be *BotEngine) RegisterCommands() {
 		Desc:    "check the status of testnet rewards claiming",
 		Help:    "",
 		Args:    []Args{},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.claimStatusHandler,
+
+		Category: CategoryRewards,
 	}
 
 	cmdNodeInfo := Command{
@@ -80,41 +153,333 @@ func (be *BotEngine) RegisterCommands() {
 		Help: "",
 		Args: []Args{
 			{
-				Name:     "validator-address",
-				Desc:     "your validator address",
-				Optional: false,
+				Name:         "validator-address",
+				Desc:         "your validator address, defaults to your linked validator",
+				Optional:     true,
+				Autocomplete: be.completeValidatorAddress,
 			},
 		},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.nodeInfoHandler,
+
+		Category: CategoryValidators,
 	}
 
 	cmdNetworkHealth := Command{
 		Name:    NetworkHealthCommandName,
-		Desc:    "checking network health status",
+		Desc:    "the network health score, by the block time lag and the connected peers",
 		Help:    "",
 		Args:    []Args{},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.networkHealthHandler,
+
+		NodeDependent: true,
+		Fallback:      FallbackUnavailable,
+		Network:       client.NetworkMainnet,
+
+		Category: CategoryNetwork,
 	}
 
 	cmdNetworkStatus := Command{
 		Name:    NetworkStatusCommandName,
-		Desc:    "network statistics",
+		Desc:    "the network and the node that the bot is serving, and the network statistics",
 		Help:    "",
 		Args:    []Args{},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.networkStatusHandler,
+
+		NodeDependent: true,
+		Fallback:      FallbackCached,
+		Network:       client.NetworkMainnet,
+		RateLimit:     RateLimit{PerUser: 30 * time.Second},
+
+		Category: CategoryNetwork,
+	}
+
+	cmdPeers := Command{
+		Name: PeersCommandName,
+		Desc: "list of the connected peers of the RoboPac node",
+		Help: "",
+		Args: []Args{
+			{
+				Name:     PageArgName,
+				Desc:     "page number, defaults to 1",
+				Optional: true,
+				Type:     ArgTypeInteger,
+				MinValue: Bound(1),
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.peersHandler,
+
+		NodeDependent: true,
+		Fallback:      FallbackCached,
+
+		Category: CategoryNetwork,
+	}
+
+	cmdPeerSearch := Command{
+		Name: PeerSearchCommandName,
+		Desc: "search the connected peers by their moniker, or the prefix of their peer ID or validator address",
+		Help: "",
+		Args: []Args{
+			{
+				Name:     "query",
+				Desc:     "a part of the moniker, or the start of the peer ID or the validator address",
+				Optional: false,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.peerSearchHandler,
+
+		NodeDependent: true,
+
+		Category: CategoryNetwork,
+	}
+
+	cmdTx := Command{
+		Name: TxCommandName,
+		Desc: "decode a transaction, like the sender, the receiver and the amount of it",
+		Help: "",
+		Args: []Args{
+			{
+				Name:     "id",
+				Desc:     "the transaction ID, 64 hex characters",
+				Optional: false,
+				Format:   FormatTxID,
+			},
+			{
+				Name:     "view",
+				Desc:     "the view of the transaction, the raw view shows it encoded",
+				Optional: true,
+				Choices:  []string{txViewSummary, txViewRaw},
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.txHandler,
+
+		NodeDependent: true,
+		Network:       client.NetworkMainnet,
+
+		Category: CategoryNetwork,
+	}
+
+	cmdBlock := Command{
+		Name: BlockCommandName,
+		Desc: "summarize a block, like the proposer, the reward and the transactions of it",
+		Help: "the transactions of the block are paginated",
+		Args: []Args{
+			{
+				Name:     "height",
+				Desc:     "the block height",
+				Optional: false,
+				Type:     ArgTypeInteger,
+				MinValue: Bound(1),
+			},
+			{
+				Name:     PageArgName,
+				Desc:     "page number of the transactions, defaults to 1",
+				Optional: true,
+				Type:     ArgTypeInteger,
+				MinValue: Bound(1),
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.blockHandler,
+
+		NodeDependent: true,
+		Network:       client.NetworkMainnet,
+
+		Category: CategoryNetwork,
+		Examples: []string{"1000"},
+	}
+
+	cmdAccount := Command{
+		Name: AccountCommandName,
+		Desc: "the balance of an account, and its validators and transfers in the recent blocks",
+		Help: "the reward address, the bonded validators and the transfers are found in the recent blocks",
+		Args: []Args{
+			{
+				Name:     "address",
+				Desc:     "the account address like: pc1z...",
+				Optional: false,
+				Format:   FormatAccountAddress,
+			},
+			{
+				Name:     "blocks",
+				Desc:     fmt.Sprintf("the number of the recent blocks to scan, defaults to %d", defaultAccountBlocks),
+				Optional: true,
+				Type:     ArgTypeInteger,
+				MinValue: Bound(1),
+				MaxValue: Bound(client.MaxBlocksRange),
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.accountHandler,
+
+		NodeDependent: true,
+		Network:       client.NetworkMainnet,
+
+		Category: CategoryNetwork,
+		Examples: []string{"pc1z... 100"},
+	}
+
+	cmdFee := Command{
+		Name: FeeCommandName,
+		Desc: "estimate the fee of sending an amount of PAC",
+		Help: "the fee is calculated by the node, for the transfer and the bond transactions",
+		Args: []Args{
+			{
+				Name:     "amount",
+				Desc:     "the amount in PAC",
+				Optional: false,
+				Format:   FormatAmount,
+			},
+			{
+				Name:     "type",
+				Desc:     "transfer | bond, both are estimated if it's not provided",
+				Optional: true,
+				Choices:  []string{"transfer", "bond"},
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.feeHandler,
+
+		NodeDependent: true,
+		Network:       client.NetworkMainnet,
+
+		Category: CategoryNetwork,
+		Examples: []string{"100", "1000 bond"},
+	}
+
+	cmdCommittee := Command{
+		Name: CommitteeCommandName,
+		Desc: "list of the validators in the committee, by their stake and with their availability scores",
+		Help: "the members are paginated, and the last sortition is shown",
+		Args: []Args{
+			{
+				Name:     PageArgName,
+				Desc:     "page number, defaults to 1",
+				Optional: true,
+				Type:     ArgTypeInteger,
+				MinValue: Bound(1),
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.committeeHandler,
+
+		NodeDependent: true,
+		Fallback:      FallbackCached,
+		Network:       client.NetworkMainnet,
+
+		Category: CategoryValidators,
+	}
+
+	cmdTopValidators := Command{
+		Name: TopValidatorsCommandName,
+		Desc: "leaderboard of the validators, by their stake and availability",
+		Help: "the validators are ranked by their stake, and by their availability score on equal stakes, " +
+			"the leaderboard is updated every few minutes",
+		Args: []Args{
+			{
+				Name:     "count",
+				Desc:     fmt.Sprintf("number of the validators, defaults to %d", defaultTopValidators),
+				Optional: true,
+				Type:     ArgTypeInteger,
+				MinValue: Bound(1),
+				MaxValue: Bound(maxTopValidators),
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.topValidatorsHandler,
+
+		NodeDependent: true,
+		Fallback:      FallbackCached,
+		Network:       client.NetworkMainnet,
+
+		Category: CategoryValidators,
+		Examples: []string{"", "25"},
+	}
+
+	cmdCommands := Command{
+		Name:    CommandsCommandName,
+		Desc:    "live configuration of the registered commands (admin only)",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.commandsHandler,
+		MinRole: RoleAdmin,
+	}
+
+	cmdNodeStats := Command{
+		Name:    NodeStatsCommandName,
+		Desc:    "traffic statistics of the RoboPac node",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.nodeStatsHandler,
+
+		NodeDependent: true,
+		Fallback:      FallbackUnavailable,
+
+		Category: CategoryNetwork,
+	}
+
+	cmdNodeDiff := Command{
+		Name:    NodeDiffCommandName,
+		Desc:    "compare the heights and the last blocks of the connected nodes (admin only)",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.nodeDiffHandler,
+		MinRole: RoleAdmin,
+
+		Network: client.NetworkMainnet,
+	}
+
+	cmdGrpcRaw := Command{
+		Name: GrpcRawCommandName,
+		Desc: "call a read method of the node by its JSON request, for debugging (admin only)",
+		Help: "the request and the response are in the protobuf JSON format, so the bytes are in base64",
+		Args: []Args{
+			{
+				Name:     "method",
+				Desc:     "the service and the method like: Blockchain.GetBlock",
+				Optional: false,
+			},
+			{
+				Name:     "request",
+				Desc:     "the JSON request like: {\"height\": 100}, defaults to {}",
+				Optional: true,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		Handler: be.grpcRawHandler,
+		MinRole: RoleAdmin,
+
+		Network: client.NetworkMainnet,
+
+		Examples: []string{"Blockchain.GetBlockchainInfo", `Blockchain.GetBlock '{"height": 100, "verbosity": 1}'`},
+	}
+
+	cmdNode := Command{
+		Name:    NodeCommandName,
+		Desc:    "diagnostic report of the RoboPac node (admin only)",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.nodeHandler,
+		MinRole: RoleAdmin,
 	}
 
 	cmdHelp := Command{
 		Name:    HelpCommandName,
-		Desc:    "This is Help!",
+		Desc:    "list the commands by their categories, or show the usage of a command",
 		Help:    "",
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.help,
 		Args: []Args{
-			{Name: "command", Desc: "help", Optional: true},
+			{Name: "command", Desc: "a command, a category or a phrase to search", Optional: true},
 		},
 	}
 
@@ -123,28 +488,246 @@ func (be *BotEngine) RegisterCommands() {
 		Desc:    "check the RoboPac wallet balance and address",
 		Help:    "",
 		Args:    []Args{},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.walletHandler,
+
+		Category: CategoryWallet,
 	}
 
 	cmdCalcReward := Command{
 		Name: CalcRewardCommandName,
-		Desc: "claculate how much PAC coins you will earn with your validator stakes",
-		Help: "",
+		Desc: "estimate how much PAC coins you will earn with your validator stake",
+		Help: "the estimation is based on the current total power of the network",
 		Args: []Args{
 			{
-				Name:     "stake-amount",
-				Desc:     "amount of stake in your validator (1-1000)",
+				Name:     "stake",
+				Desc:     "amount of stake in your validator in PAC",
 				Optional: false,
+				Type:     ArgTypeNumber,
+				MinValue: Bound(1),
+				MaxValue: Bound(1000),
 			},
 			{
-				Name:     "time-interval",
-				Desc:     "after one: day | month | year",
+				Name:     "days",
+				Desc:     "number of days, defaults to 1",
 				Optional: true,
+				Type:     ArgTypeInteger,
+				MinValue: Bound(1),
+				MaxValue: Bound(3650),
 			},
 		},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.calcRewardHandler,
+
+		NodeDependent: true,
+
+		Category: CategoryRewards,
+		Examples: []string{"1000 7"},
+	}
+
+	cmdToggleCommand := Command{
+		Name: ToggleCommandCommandName,
+		Desc: "enable or disable a command globally or for a guild (admin only)",
+		Help: "leave the guild-id empty to change the command globally",
+		Args: []Args{
+			{
+				Name:     "command",
+				Desc:     "name of the command",
+				Optional: false,
+			},
+			{
+				Name:     "state",
+				Desc:     "enable | disable",
+				Optional: false,
+			},
+			{
+				Name:     "guild-id",
+				Desc:     "the Discord guild (server) ID",
+				Optional: true,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.toggleCommandHandler,
+		MinRole: RoleAdmin,
+
+		ConfirmPhrase: "toggle the command",
+	}
+
+	cmdMaintenance := Command{
+		Name: MaintenanceCommandName,
+		Desc: "turn the maintenance mode on or off (admin only)",
+		Help: "during the maintenance only the admins can run the commands",
+		Args: []Args{
+			{
+				Name:     "state",
+				Desc:     "on | off",
+				Optional: false,
+				Choices:  []string{"on", "off"},
+			},
+			{
+				Name:     "reason",
+				Desc:     "the reason shown to the users",
+				Optional: true,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.maintenanceHandler,
+		MinRole: RoleAdmin,
+
+		ConfirmPhrase: "toggle maintenance",
+	}
+
+	cmdBanUser := Command{
+		Name: BanUserCommandName,
+		Desc: "ban a user from using the bot (admin only)",
+		Help: "leave the duration empty to ban the user permanently",
+		Args: []Args{
+			{
+				Name:     "user-id",
+				Desc:     "the ID of the user",
+				Optional: false,
+			},
+			{
+				Name:     "duration",
+				Desc:     "how long the ban lasts, like 12h",
+				Optional: true,
+			},
+			{
+				Name:     "reason",
+				Desc:     "the reason of the ban",
+				Optional: true,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.banUserHandler,
+		MinRole: RoleAdmin,
+
+		ConfirmPhrase: "ban the user",
+
+		Examples: []string{"1234567890 12h spam"},
+	}
+
+	cmdUnbanUser := Command{
+		Name: UnbanUserCommandName,
+		Desc: "lift the ban of a user (admin only)",
+		Args: []Args{
+			{
+				Name:     "user-id",
+				Desc:     "the ID of the user",
+				Optional: false,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.unbanUserHandler,
+		MinRole: RoleAdmin,
+	}
+
+	cmdStatusEntries := Command{
+		Name: StatusEntriesCommandName,
+		Desc: "list the status entries of the bot, or turn one on or off (admin only)",
+		Help: "leave the arguments empty to list the entries",
+		Args: []Args{
+			{
+				Name:     "number",
+				Desc:     "the number of the entry",
+				Type:     ArgTypeInteger,
+				MinValue: Bound(1),
+				Optional: true,
+			},
+			{
+				Name:     "state",
+				Desc:     "on | off",
+				Choices:  []string{"on", "off"},
+				Optional: true,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.statusEntriesHandler,
+		MinRole: RoleAdmin,
+	}
+
+	cmdReloadConfig := Command{
+		Name:    ReloadConfigCommandName,
+		Desc:    "reload the settings from the config (admin only)",
+		Help:    "the secrets and the nodes are not reloaded, they need a restart",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.reloadConfigHandler,
+		MinRole: RoleAdmin,
+	}
+
+	cmdSetLogLevel := Command{
+		Name: SetLogLevelCommandName,
+		Desc: "change the log level of the bot, or of one of its modules (admin only)",
+		Help: "leave the module empty to change the default level, the levels are reset by a restart or by reload-config",
+		Args: []Args{
+			{
+				Name:     "level",
+				Desc:     "trace | debug | info | warn | error",
+				Optional: false,
+				Choices:  log.Levels(),
+			},
+			{
+				Name:         "module",
+				Desc:         "the module, like engine or wallet",
+				Optional:     true,
+				Autocomplete: completeLogModule,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.setLogLevelHandler,
+		MinRole: RoleAdmin,
+
+		Examples: []string{"debug", "trace engine"},
+	}
+
+	cmdBotStats := Command{
+		Name:    BotStatsCommandName,
+		Desc:    "the most used commands, their error rates and latencies, and the uptime (admin only)",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.botStatsHandler,
+		MinRole: RoleAdmin,
+	}
+
+	cmdAuditLog := Command{
+		Name: AuditLogCommandName,
+		Desc: "the recent transfers, faucet drips, claims and admin commands (admin only)",
+		Help: "the entries are filtered by their kind and caller, the newest first",
+		Args: []Args{
+			{
+				Name:     "kind",
+				Desc:     "the kind of the entries, defaults to all",
+				Optional: true,
+				Choices:  append([]string{auditKindAll}, audit.Kinds()...),
+			},
+			{
+				Name:     "caller",
+				Desc:     "the ID of the caller",
+				Optional: true,
+			},
+			{
+				Name:     PageArgName,
+				Desc:     "page number, defaults to 1",
+				Optional: true,
+				Type:     ArgTypeInteger,
+				MinValue: Bound(1),
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.auditLogHandler,
+		MinRole: RoleAdmin,
+	}
+
+	cmdDiag := Command{
+		Name:    DiagCommandName,
+		Desc:    "diagnostic information of the bot commands (admin only)",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.diagHandler,
+		MinRole: RoleAdmin,
 	}
 
 	cmdBoosterPayment := Command{
@@ -163,8 +746,10 @@ func (be *BotEngine) RegisterCommands() {
 				Optional: false,
 			},
 		},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.boosterPaymentHandler,
+
+		Category: CategoryBooster,
 	}
 
 	cmdBoosterClaim := Command{
@@ -178,8 +763,12 @@ func (be *BotEngine) RegisterCommands() {
 				Optional: false,
 			},
 		},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.boosterClaimHandler,
+
+		MinAccountAge: 30 * 24 * time.Hour,
+
+		Category: CategoryBooster,
 	}
 
 	cmdBoosterWhitelist := Command{
@@ -193,8 +782,11 @@ func (be *BotEngine) RegisterCommands() {
 				Optional: false,
 			},
 		},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.boosterWhitelistHandler,
+		MinRole: RoleAdmin,
+
+		Category: CategoryBooster,
 	}
 
 	cmdBoosterStatus := Command{
@@ -202,8 +794,10 @@ func (be *BotEngine) RegisterCommands() {
 		Desc:    "status of booster program claims and ...",
 		Help:    "",
 		Args:    []Args{},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.boosterStatusHandler,
+
+		Category: CategoryBooster,
 	}
 
 	cmdDepositAddress := Command{
@@ -211,8 +805,10 @@ func (be *BotEngine) RegisterCommands() {
 		Desc:    "create a deposit address for P2P offer",
 		Help:    "it will show your address if you already have an deposit address",
 		Args:    []Args{},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.depositAddressHandler,
+
+		Category: CategoryBooster,
 	}
 
 	cmdCreateOffer := Command{
@@ -241,11 +837,390 @@ func (be *BotEngine) RegisterCommands() {
 				Optional: false,
 			},
 		},
-		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
 		Handler: be.createOfferHandler,
+
+		Category: CategoryBooster,
 	}
 
 	//! test-net reward commands
+	cmdLink := Command{
+		Name: LinkCommandName,
+		Desc: "link your validator address to your account",
+		Help: "",
+		Args: []Args{
+			{
+				Name:     "validator-address",
+				Desc:     "your validator address like: pc1p...",
+				Optional: false,
+				Format:   FormatValidatorAddress,
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord},
+		Handler: be.linkHandler,
+	}
+
+	cmdUnlink := Command{
+		Name: UnlinkCommandName,
+		Desc: "unlink the validator address, or an address of your profile, from your account",
+		Help: "",
+		Args: []Args{
+			{
+				Name:         "address",
+				Desc:         "the linked address of your profile",
+				Optional:     true,
+				Format:       FormatAddress,
+				Autocomplete: be.completeLinkedAddress,
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.unlinkHandler,
+	}
+
+	cmdMe := Command{
+		Name:    MeCommandName,
+		Desc:    "show your account information",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdDiscord},
+		Handler: be.meHandler,
+	}
+
+	cmdLinkAddress := Command{
+		Name: LinkAddressCommandName,
+		Desc: "link an address that you own to your profile",
+		Help: "run it with the address only to get the message to sign, then with the public key and the signature",
+		Args: []Args{
+			{
+				Name:     "address",
+				Desc:     "your account or validator address like: pc1z...",
+				Optional: false,
+				Format:   FormatAddress,
+			},
+			{
+				Name:     "public-key",
+				Desc:     "the public key of the address",
+				Optional: true,
+			},
+			{
+				Name:     "signature",
+				Desc:     "the signature of the link message",
+				Optional: true,
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.linkAddressHandler,
+
+		Category: CategoryWallet,
+	}
+
+	cmdMyAddresses := Command{
+		Name:    MyAddressesCommandName,
+		Desc:    "list the addresses that are linked to your profile",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.myAddressesHandler,
+
+		Category: CategoryWallet,
+	}
+
+	cmdMyBalance := Command{
+		Name:    MyBalanceCommandName,
+		Desc:    "show the balances of your linked accounts",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.myBalanceHandler,
+
+		NodeDependent: true,
+
+		Category: CategoryWallet,
+	}
+
+	cmdMyValidator := Command{
+		Name:    MyValidatorCommandName,
+		Desc:    "show the state of your linked validators",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.myValidatorHandler,
+
+		NodeDependent: true,
+
+		Category: CategoryValidators,
+	}
+
+	cmdSetLanguage := Command{
+		Name: SetLanguageCommandName,
+		Desc: "set the language of the bot responses",
+		Help: "the errors, the notices and the titles are translated, the results of the commands are in English",
+		Args: []Args{
+			{
+				Name:     "language",
+				Desc:     "the language code, like: es",
+				Optional: false,
+				Choices:  be.Locales(),
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.setLanguageHandler,
+	}
+
+	cmdFaucet := Command{
+		Name: FaucetCommandName,
+		Desc: "get some test-net coins for your address",
+		Help: "",
+		Args: []Args{
+			{
+				Name:     "address",
+				Desc:     "your test-net address like: tpc1z...",
+				Optional: false,
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord, AppIdTelegram},
+		Handler: be.faucetHandler,
+
+		Category: CategoryWallet,
+	}
+
+	cmdTip := Command{
+		Name: TipCommandName,
+		Desc: "tip PAC to a user from your deposit",
+		Help: "the tip is sent to the first account address that the user has linked",
+		Args: []Args{
+			{
+				Name:     "user",
+				Desc:     "the user to tip",
+				Optional: false,
+				Type:     ArgTypeUser,
+			},
+			{
+				Name:     "amount",
+				Desc:     "the amount of PAC like: 1.5",
+				Optional: false,
+				Format:   FormatAmount,
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord},
+		Handler: be.tipHandler,
+
+		NodeDependent: true,
+
+		Category: CategoryWallet,
+	}
+
+	cmdPrice := Command{
+		Name:    PriceCommandName,
+		Desc:    "the PAC price, volume and market cap",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.priceHandler,
+
+		Category: CategoryNetwork,
+	}
+
+	cmdValidatorUptime := Command{
+		Name: ValidatorUptimeCommandName,
+		Desc: "the availability and the recorded uptime of a validator",
+		Help: "",
+		Args: []Args{
+			{
+				Name:         "validator-address",
+				Desc:         "the validator address like: pc1p...",
+				Optional:     false,
+				Format:       FormatValidatorAddress,
+				Autocomplete: be.completeValidatorAddress,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.validatorUptimeHandler,
+
+		NodeDependent: true,
+
+		Category: CategoryValidators,
+	}
+
+	cmdValidatorAlerts := Command{
+		Name: ValidatorAlertsCommandName,
+		Desc: "get a DM when your validator goes down",
+		Help: "",
+		Args: []Args{
+			{
+				Name:     "alerts",
+				Desc:     "turn the alerts on or off",
+				Optional: false,
+				Choices:  []string{"on", "off"},
+			},
+			{
+				Name:         "validator-address",
+				Desc:         "the validator address, the linked validator is used if it's not provided",
+				Optional:     true,
+				Autocomplete: be.completeValidatorAddress,
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord},
+		Handler: be.validatorAlertsHandler,
+
+		Category: CategoryValidators,
+	}
+
+	cmdChart := Command{
+		Name: ChartCommandName,
+		Desc: "a chart of the network metrics over time",
+		Help: "the network metrics are recorded periodically, like the block height and the total power",
+		Args: []Args{
+			{
+				Name:     "metric",
+				Desc:     "the network metric to chart",
+				Optional: false,
+				Choices:  snapshot.Metrics(),
+			},
+			{
+				Name:     "period",
+				Desc:     "the period to chart, the default is " + defaultChartPeriod,
+				Optional: true,
+				Choices:  chartPeriodNames(),
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord},
+		Handler: be.chartHandler,
+
+		Category: CategoryNetwork,
+		Examples: []string{"supply 30d"},
+	}
+
+	cmdWatchAddress := Command{
+		Name: WatchAddressCommandName,
+		Desc: "get a DM when a transaction touches an address",
+		Help: "",
+		Args: []Args{
+			{
+				Name:     "address",
+				Desc:     "the account or validator address like: pc1z...",
+				Optional: false,
+				Format:   FormatAddress,
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord},
+		Handler: be.watchAddressHandler,
+
+		Category: CategoryWallet,
+	}
+
+	cmdUnwatch := Command{
+		Name: UnwatchCommandName,
+		Desc: "stop watching an address",
+		Help: "",
+		Args: []Args{
+			{
+				Name:         "address",
+				Desc:         "the watched address",
+				Optional:     false,
+				Autocomplete: be.completeWatchedAddress,
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord},
+		Handler: be.unwatchHandler,
+
+		Category: CategoryWallet,
+	}
+
+	cmdMyWatches := Command{
+		Name:    MyWatchesCommandName,
+		Desc:    "list the addresses you are watching",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdDiscord},
+		Handler: be.myWatchesHandler,
+
+		Category: CategoryWallet,
+	}
+
+	cmdCreateReferral := Command{
+		Name:    CreateReferralCommandName,
+		Desc:    "get your referral code, and the rewards of the users you referred",
+		Help:    "the rewards are sent to the first account address that you have linked",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack},
+		Handler: be.createReferralHandler,
+
+		Category: CategoryRewards,
+	}
+
+	cmdClaimReferral := Command{
+		Name: ClaimReferralCommandName,
+		Desc: "claim the referral code of the user who invited you",
+		Help: "link your account address with some PAC in it first, each user and each address can claim one code only",
+		Args: []Args{
+			{
+				Name:     "code",
+				Desc:     "the referral code",
+				Optional: false,
+			},
+		},
+		AppIDs:  []AppID{AppIdDiscord},
+		Handler: be.claimReferralHandler,
+
+		// the ages are checked on Discord only, so the code can't be claimed on the other apps.
+		MinAccountAge: 30 * 24 * time.Hour,
+		MinMemberAge:  7 * 24 * time.Hour,
+
+		Category: CategoryRewards,
+	}
+
+	cmdReferralReport := Command{
+		Name:    ReferralReportCommandName,
+		Desc:    "the referrers and their rewards (admin only)",
+		Help:    "",
+		Args:    []Args{},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		Handler: be.referralReportHandler,
+		MinRole: RoleAdmin,
+	}
+
+	cmdPayout := Command{
+		Name: PayoutCommandName,
+		Desc: "send the rewards to a list of addresses by the RoboPac wallet (admin only)",
+		Help: "load a CSV or JSON list of addresses and amounts in PAC, check the summary and start it",
+		SubCommands: []Command{
+			{
+				Name: "load",
+				Desc: "load and validate the payout list",
+				Help: "the list is CSV lines of \"address,amount\", or JSON like {\"pc1...\": 1.5}",
+				Args: []Args{
+					{
+						Name:     "file",
+						Desc:     "the CSV or JSON file of the payout",
+						Optional: false,
+						Type:     ArgTypeAttachment,
+					},
+				},
+				Handler: be.payoutLoadHandler,
+			},
+			{
+				Name:    "start",
+				Desc:    "start sending the loaded payout",
+				Args:    []Args{},
+				Handler: be.payoutStartHandler,
+
+				ConfirmPhrase: "send the payout",
+			},
+			{
+				Name:    "status",
+				Desc:    "show the loaded or running payout",
+				Args:    []Args{},
+				Handler: be.payoutStatusHandler,
+			},
+		},
+		AppIDs:  []AppID{AppIdCLI, AppIdDiscord},
+		MinRole: RoleAdmin,
+	}
+
+	be.cmdsLk.Lock()
+	defer be.cmdsLk.Unlock()
+
 	be.Cmds = append(be.Cmds, cmdClaim)
 	be.Cmds = append(be.Cmds, cmdClaimerInfo)
 	be.Cmds = append(be.Cmds, cmdClaimStatus)
@@ -254,11 +1229,38 @@ func (be *BotEngine) RegisterCommands() {
 	be.Cmds = append(be.Cmds, cmdNodeInfo)
 	be.Cmds = append(be.Cmds, cmdNetworkHealth)
 	be.Cmds = append(be.Cmds, cmdNetworkStatus)
+	be.Cmds = append(be.Cmds, cmdNodeStats)
+	be.Cmds = append(be.Cmds, cmdNode)
+	be.Cmds = append(be.Cmds, cmdNodeDiff)
+	be.Cmds = append(be.Cmds, cmdGrpcRaw)
+	be.Cmds = append(be.Cmds, cmdPeers)
+	be.Cmds = append(be.Cmds, cmdPeerSearch)
+	be.Cmds = append(be.Cmds, cmdTx)
+	be.Cmds = append(be.Cmds, cmdBlock)
+	be.Cmds = append(be.Cmds, cmdAccount)
+	be.Cmds = append(be.Cmds, cmdFee)
+	be.Cmds = append(be.Cmds, cmdCommittee)
+	be.Cmds = append(be.Cmds, cmdTopValidators)
 
 	//! bot info and util commands
 	be.Cmds = append(be.Cmds, cmdHelp)
 	be.Cmds = append(be.Cmds, cmdWallet)
 	be.Cmds = append(be.Cmds, cmdCalcReward)
+	be.Cmds = append(be.Cmds, cmdToggleCommand)
+	be.Cmds = append(be.Cmds, cmdMaintenance)
+	be.Cmds = append(be.Cmds, cmdBanUser)
+	be.Cmds = append(be.Cmds, cmdUnbanUser)
+	be.Cmds = append(be.Cmds, cmdStatusEntries)
+	be.Cmds = append(be.Cmds, cmdReloadConfig)
+	be.Cmds = append(be.Cmds, cmdSetLogLevel)
+	be.Cmds = append(be.Cmds, cmdDiag)
+	if be.analytics != nil {
+		be.Cmds = append(be.Cmds, cmdBotStats)
+	}
+	if be.audit != nil {
+		be.Cmds = append(be.Cmds, cmdAuditLog)
+	}
+	be.Cmds = append(be.Cmds, cmdCommands)
 
 	//! booster program commands
 	be.Cmds = append(be.Cmds, cmdBoosterPayment)
@@ -266,36 +1268,270 @@ func (be *BotEngine) RegisterCommands() {
 	be.Cmds = append(be.Cmds, cmdBoosterWhitelist)
 	be.Cmds = append(be.Cmds, cmdBoosterStatus)
 
+	//! user preference commands
+	be.Cmds = append(be.Cmds, cmdLink)
+	be.Cmds = append(be.Cmds, cmdUnlink)
+	be.Cmds = append(be.Cmds, cmdMe)
+	if be.profiles != nil {
+		be.Cmds = append(be.Cmds, cmdLinkAddress)
+		be.Cmds = append(be.Cmds, cmdMyAddresses)
+		be.Cmds = append(be.Cmds, cmdMyBalance)
+		be.Cmds = append(be.Cmds, cmdMyValidator)
+	}
+	if len(cmdSetLanguage.Args[0].Choices) > 1 {
+		be.Cmds = append(be.Cmds, cmdSetLanguage)
+	}
+
+	//! faucet is only available if it's enabled in the config
+	if be.faucet != nil {
+		be.Cmds = append(be.Cmds, cmdFaucet)
+	}
+
+	//! tips are only available if they're enabled in the config
+	if be.tips != nil && be.profiles != nil {
+		be.Cmds = append(be.Cmds, cmdTip)
+	}
+
+	//! referrals are only available if they're enabled in the config
+	if be.referrals != nil && be.profiles != nil {
+		be.Cmds = append(be.Cmds, cmdCreateReferral)
+		be.Cmds = append(be.Cmds, cmdClaimReferral)
+		be.Cmds = append(be.Cmds, cmdReferralReport)
+	}
+
+	//! market data is only available if a source is set in the config
+	if be.market != nil {
+		be.Cmds = append(be.Cmds, cmdPrice)
+	}
+
+	//! validator monitor commands are only available if it's enabled in the config
+	if be.monitor != nil {
+		be.Cmds = append(be.Cmds, cmdValidatorUptime)
+		be.Cmds = append(be.Cmds, cmdValidatorAlerts)
+	}
+
+	//! network charts are only available if the snapshots are enabled in the config
+	if be.snapshots != nil {
+		be.Cmds = append(be.Cmds, cmdChart)
+	}
+
+	//! address watch commands
+	if be.addressWatches != nil {
+		be.Cmds = append(be.Cmds, cmdWatchAddress)
+		be.Cmds = append(be.Cmds, cmdUnwatch)
+		be.Cmds = append(be.Cmds, cmdMyWatches)
+	}
+
+	//! payout commands
+	if be.payouts != nil {
+		be.Cmds = append(be.Cmds, cmdPayout)
+	}
+
 	//! P2P offer commands
 	be.Cmds = append(be.Cmds, cmdDepositAddress)
 	be.Cmds = append(be.Cmds, cmdCreateOffer)
+
+	be.addNetworkArgs()
 }
 
+// Commands returns a copy of the registered commands.
 func (be *BotEngine) Commands() []Command {
-	return be.Cmds
+	be.cmdsLk.RLock()
+	defer be.cmdsLk.RUnlock()
+
+	return slices.Clone(be.Cmds)
 }
 
 func (be *BotEngine) Run(appID AppID, callerID string, inputs []string) (*CommandResult, error) {
-	log.Debug("run command", "callerID", callerID, "inputs", inputs)
+	return be.RunWithOptions(RunOptions{}, appID, callerID, inputs)
+}
+
+// RunOptions are the optional context of a command execution, set by the apps.
+type RunOptions struct {
+	// RequestID is added to the engine logs, so the logs of one execution
+	// can be correlated with the app logs.
+	RequestID string
+
+	// Role is the role of the caller resolved by the app, like the Discord admin roles.
+	// The engine raises it by its own authorizer, see SetAuthorizer.
+	Role Role
+}
+
+// RunWithOptions runs the command like Run, with the options set by the app.
+func (be *BotEngine) RunWithOptions(opts RunOptions, appID AppID, callerID string,
+	inputs []string,
+) (*CommandResult, error) {
+	msgs := be.MessagesFor(callerID)
+	if err := be.checkInputs(msgs, inputs); err != nil {
+		return nil, err
+	}
+
+	logger := be.logger
+	if opts.RequestID != "" {
+		logger = logger.With("requestID", opts.RequestID)
+	}
+	logger.Debug("run command", "callerID", callerID, "inputs", inputs)
 
 	cmdName := inputs[0]
 	cmd := be.commandByName(cmdName)
 	if cmd == nil {
-		return nil, fmt.Errorf("unknown command: %s", cmdName)
+		return nil, errors.New(msgs.Get(MsgUnknownCommand, cmdName))
 	}
 	if !cmd.HasAppId(appID) {
-		return nil, fmt.Errorf("unauthorized appID: %v", appID)
+		return nil, errors.New(msgs.Get(MsgUnauthorizedApp, appID))
+	}
+	if !be.access.isAllowed(cmdName, appID) {
+		return nil, errors.New(msgs.Get(MsgCommandNotAllowed, cmdName, appID))
 	}
 	args := inputs[1:]
+	if len(cmd.SubCommands) > 0 {
+		var err error
+		cmd, args, err = be.subCommandOf(msgs, cmd, args)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !be.IsCommandEnabled(cmdName, "") {
+		return nil, errors.New(msgs.Get(MsgCommandDisabled, cmdName))
+	}
 	err := cmd.CheckArgs(args)
 	if err != nil {
 		return nil, err
 	}
+	args = be.withDefaultNetwork(cmd, args)
+
+	exec := &execution{
+		role:   be.callerRole(appID, callerID, opts.Role),
+		msgs:   msgs,
+		logger: logger,
+		inputs: inputs,
+	}
+	res, err := be.handlerOf(cmd, exec)(appID, callerID, args...)
+
+	if res != nil && cmd.Deprecated {
+		res.Warnings = append([]string{cmd.DeprecationNote()}, res.Warnings...)
+	}
+
+	if res != nil {
+		res.Suggestions = be.availableSuggestions(appID, res.Suggestions)
+		res.Actions = be.availableActions(appID, res.Actions)
+		if res.Menu != nil {
+			res.Menu.Options = be.availableActions(appID, res.Menu.Options)
+			if len(res.Menu.Options) == 0 {
+				res.Menu = nil
+			}
+		}
+	}
 
-	return cmd.Handler(appID, callerID, args...)
+	return res, err
+}
+
+// nodeBreakerHandler runs the handler of the command, unless the circuit breaker of the node dependent
+// command is open. The results are recorded, and the fallback result is returned if the node is unreachable.
+func (be *BotEngine) nodeBreakerHandler(cmd *Command, exec *execution) CommandHandler {
+	return func(source AppID, callerID string, args ...string) (*CommandResult, error) {
+		if cmd.NodeDependent && !be.breakers.allow(cmd.Name) {
+			be.recordOutcome(cmd.Name, false)
+
+			if cmd.Fallback != FallbackFail {
+				return be.fallbackResult(exec.msgs, cmd, exec.inputs), nil
+			}
+
+			return MakeFailedResult(exec.msgs.Get(MsgTemporarilyUnavailable, cmd.Name)), nil
+		}
+
+		start := time.Now()
+		res, err := be.callReported(cmd, source, callerID, args)
+		be.recordUsage(cmd.Name, res, err, time.Since(start))
+		be.recordOutcome(cmd.Name, err == nil && res != nil && res.Successful)
+		metrics.CommandsTotal.Inc(source.String(), cmd.Name, metrics.CommandResult(res != nil && res.Successful, err))
+		if err != nil {
+			metrics.EngineErrorsTotal.Inc(cmd.Name)
+		}
+
+		if cmd.NodeDependent {
+			switch {
+			case err == nil && res != nil && res.Successful && cmd.Fallback == FallbackCached:
+				cached := *res
+				be.lastResults.store(exec.inputs, &cached, time.Now())
+
+			case err != nil && cmd.Fallback != FallbackFail &&
+				(errors.Is(err, client.ErrNodeUnavailable) || !be.nodeReachable()):
+				exec.logger.Info("node is unreachable, falling back", "command", cmd.Name, "fallback", cmd.Fallback)
+				res, err = be.fallbackResult(exec.msgs, cmd, exec.inputs), nil
+			}
+		}
+
+		if errors.Is(err, client.ErrNodeUnavailable) {
+			err = errors.New(exec.msgs.Get(MsgNodeUnavailable))
+		}
+
+		return res, err
+	}
+}
+
+// callReported calls the handler of the command, the outcome of the node dependent command is reported
+// to its circuit breaker. Only the failures of the node count, not the errors of the inputs, like an unknown
+// network. A panic is reported as a failure too, so the breaker isn't stuck half-open.
+func (be *BotEngine) callReported(cmd *Command, source AppID, callerID string, args []string) (*CommandResult, error) {
+	if !cmd.NodeDependent {
+		return cmd.Handler(source, callerID, args...)
+	}
+
+	reported := false
+	defer func() {
+		if !reported {
+			be.breakers.report(cmd.Name, false)
+		}
+	}()
+
+	res, err := cmd.Handler(source, callerID, args...)
+	be.breakers.report(cmd.Name, !client.IsNodeFailure(err))
+	reported = true
+
+	return res, err
+}
+
+// availableSuggestions drops the suggested commands that the app can't run.
+func (be *BotEngine) availableSuggestions(appID AppID, suggestions []string) []string {
+	var available []string
+	for _, cmdName := range suggestions {
+		if !be.IsCommandAllowed(cmdName, appID) || !be.IsCommandEnabled(cmdName, "") {
+			continue
+		}
+
+		if !slices.Contains(available, cmdName) {
+			available = append(available, cmdName)
+		}
+	}
+
+	return available
+}
+
+// availableActions drops the actions of the commands that the app can't run.
+func (be *BotEngine) availableActions(appID AppID, actions []ResultAction) []ResultAction {
+	var available []ResultAction
+	for _, action := range actions {
+		if len(action.Inputs) == 0 ||
+			!be.IsCommandAllowed(action.Inputs[0], appID) || !be.IsCommandEnabled(action.Inputs[0], "") {
+			continue
+		}
+
+		available = append(available, action)
+	}
+
+	return available
+}
+
+// FindCommand returns the registered command with the given name, or nil if there is no such command.
+func (be *BotEngine) FindCommand(cmdName string) *Command {
+	return be.commandByName(cmdName)
 }
 
 func (be *BotEngine) commandByName(cmdName string) *Command {
+	be.cmdsLk.RLock()
+	defer be.cmdsLk.RUnlock()
+
 	foundIndex := slices.IndexFunc(be.Cmds, func(cmd Command) bool {
 		return cmd.Name == cmdName
 	})
@@ -306,3 +1542,28 @@ func (be *BotEngine) commandByName(cmdName string) *Command {
 
 	return &be.Cmds[foundIndex]
 }
+
+// subCommandOf resolves the subcommand that the first argument selects and returns the rest of the arguments.
+// The resolved subcommand is named by its full name, like "wallet balance",
+// and it inherits the apps, the minimum role and the deprecation of its parent.
+func (be *BotEngine) subCommandOf(msgs *MessageCatalog, cmd *Command, args []string) (*Command, []string, error) {
+	var sub *Command
+	if len(args) > 0 {
+		sub = cmd.SubCommand(args[0])
+	}
+	if sub == nil {
+		return nil, nil, errors.New(msgs.Get(MsgUnknownSubCommand,
+			cmd.Name, strings.Join(cmd.SubCommandNames(), ", ")))
+	}
+
+	resolved := *sub
+	resolved.Name = cmd.Name + " " + sub.Name
+	resolved.AppIDs = cmd.AppIDs
+	resolved.MinRole = max(cmd.MinRole, sub.MinRole)
+	if cmd.Deprecated && !sub.Deprecated {
+		resolved.Deprecated = true
+		resolved.ReplacedBy = cmd.ReplacedBy
+	}
+
+	return &resolved, args[1:], nil
+}
@@ -2,17 +2,34 @@ package engine
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/kehiy/RoboPac/analytics"
+	"github.com/kehiy/RoboPac/audit"
 	"github.com/kehiy/RoboPac/client"
 	"github.com/kehiy/RoboPac/config"
 	"github.com/kehiy/RoboPac/database"
+	"github.com/kehiy/RoboPac/faucet"
+	"github.com/kehiy/RoboPac/locales"
 	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/market"
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/kehiy/RoboPac/monitor"
+	"github.com/kehiy/RoboPac/notification"
 	"github.com/kehiy/RoboPac/nowpayments"
+	"github.com/kehiy/RoboPac/referral"
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/kehiy/RoboPac/snapshot"
 	"github.com/kehiy/RoboPac/store"
 	"github.com/kehiy/RoboPac/twitter_api"
 	"github.com/kehiy/RoboPac/wallet"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"golang.org/x/sync/errgroup"
 )
 
 type BotEngine struct {
@@ -25,9 +42,73 @@ type BotEngine struct {
 	clientMgr     *client.Mgr
 	logger        *log.SubLogger
 	twitterClient twitter_api.IClient
+	faucet        *faucet.Faucet
+	monitor       *monitor.Monitor
+	snapshots     *snapshot.Recorder
+	market        *market.Market
+
+	analytics *analytics.Tracker
+	audit     *audit.Log
+	startedAt time.Time
+
+	// notifier notifies the operators of the internal events, like the node failovers. It's nil if it's not set.
+	notifier   notification.Sink
+	lowBalance atomic.Bool
+
+	// events is nil if the WebSocket server is not enabled in the config, see SubscribeEvents.
+	events *eventHub
+
+	// networks are the clients of the other networks than the primary one, like the testnet of a mainnet bot.
+	networks map[string]*client.Mgr
+
+	// addressWatches keeps the addresses that each user is watching, see WatchAddresses.
+	addressWatches store.KV
+
+	// profiles keeps the verified addresses that are linked to each user, see Profile.
+	profiles store.KV
+
+	payouts *payouts
+
+	// tips is nil if the tips are not enabled in the config.
+	tips *tips
+
+	// referrals is nil if the referrals are not enabled in the config.
+	referrals *referral.Referrals
+
+	netStatuses netStatusCache
+	rankings    validatorRankings
 
 	AuthIDs []string
 	Cmds    []Command
+	cmdsLk  sync.RWMutex
+
+	toggles  *commandToggles
+	outcomes *rollingOutcomes
+	messages *MessageCatalog
+	locales  map[string]*MessageCatalog
+	breakers *commandBreakers
+	limits   inputLimits
+	access   *appAccess
+
+	authorizer  Authorizer
+	middlewares []Middleware
+	limiter     rateLimiter
+	moderation  moderation
+
+	maintenance maintenanceMode
+	status      statusRotation
+	lastResults lastResults
+
+	// clock is the time of the engine, like the time of the block lag. It's the system clock if it's nil.
+	clock client.Clock
+
+	scheduler    *scheduler.Scheduler
+	blockWatcher *client.BlockWatcher
+
+	// unsavedClaims are the claim payouts that are sent, but not saved in the store.
+	unsavedClaims map[string]string
+
+	reload configReload
 
 	store        store.IStore //!
 	sync.RWMutex              //! remove this.
@@ -36,27 +117,51 @@ type BotEngine struct {
 func NewBotEngine(cfg *config.Config) (*BotEngine, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	cm := client.NewClientMgr(ctx)
+	tlsConfig, err := client.LoadTLSConfig(cfg.NodeClient.TLSCAFile, cfg.NodeClient.TLSCertFile,
+		cfg.NodeClient.TLSKeyFile)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	notifier, err := newNotifier(cfg.Notification)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
 
-	localClient, err := client.NewClient(cfg.LocalNode)
+	// the notifications are streamed as the alerts too.
+	var events *eventHub
+	if cfg.WSCfg.ListenAddr != "" {
+		events = newEventHub()
+		notifier = withEvents(notifier, events)
+	}
+
+	cm, err := newNetworkClient(ctx, cfg, tlsConfig, client.NetworkOf(cfg.Network),
+		append([]string{cfg.LocalNode}, cfg.NetworkNodes...), healthChangeNotifier(notifier))
 	if err != nil {
 		cancel()
 		return nil, err
 	}
 
-	cm.AddClient(localClient)
+	// the gauges of the nodes of the primary network are read when the metrics are scraped.
+	metrics.Default.Register(cm.GaugeCollectors()...)
 
-	for _, nn := range cfg.NetworkNodes {
-		c, err := client.NewClient(nn)
+	networks := map[string]*client.Mgr{}
+	if len(cfg.TestnetNodes) > 0 {
+		networks[client.NetworkTestnet], err = newNetworkClient(ctx, cfg, tlsConfig,
+			client.NetworkTestnet, cfg.TestnetNodes, healthChangeNotifier(notifier))
 		if err != nil {
-			log.Error("can't add new network node client", "err", err, "addr", nn)
+			cancel()
+			return nil, err
 		}
-		cm.AddClient(c)
 	}
-	cm.Start()
 
 	// initializing logger global instance.
-	log.InitGlobalLogger()
+	if err := log.InitGlobalLogger(&cfg.Log); err != nil {
+		cancel()
+		return nil, err
+	}
 
 	// new subLogger for engine.
 	eSl := log.NewSubLogger("engine")
@@ -68,21 +173,21 @@ func NewBotEngine(cfg *config.Config) (*BotEngine, error) {
 	wSl := log.NewSubLogger("wallet")
 
 	// load or create wallet.
-	wallet := wallet.Open(cfg, wSl)
-	if wallet == nil {
+	wallet, err := wallet.Open(cfg, cm, wSl)
+	if err != nil {
 		cancel()
-		return nil, errors.New("can't open the wallet")
+		return nil, err
 	}
 
 	log.Info("wallet opened successfully", "address", wallet.Address())
 
 	// load store.
-	store, err := store.NewStore(cfg.StorePath, sSl)
+	store, err := store.NewStoreWithBackend(cfg.StorePath, cfg.StoreBackend, sSl)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	log.Info("store loaded successfully", "path", cfg.StorePath)
+	log.Info("store loaded successfully", "path", cfg.StorePath, "backend", cfg.StoreBackend)
 
 	// twitter
 	twitterClient, err := twitter_api.NewClient(cfg.TwitterAPICfg.BearerToken, cfg.TwitterAPICfg.TwitterID)
@@ -106,7 +211,160 @@ func NewBotEngine(cfg *config.Config) (*BotEngine, error) {
 	}
 	log.Info("nowPayments loaded successfully")
 
-	return newBotEngine(eSl, cm, wallet, store, db, twitterClient, nowpayments, cfg.AuthIDs, ctx, cancel), nil
+	be := newBotEngine(eSl, cm, wallet, store, db, twitterClient, nowpayments, cfg.AuthIDs, ctx, cancel)
+	be.networks = networks
+	be.notifier = notifier
+	be.events = events
+
+	if err := be.LoadLocales(locales.FS); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if cfg.Faucet.Amount > 0 {
+		be.faucet = faucet.NewFaucet(wallet, store, cfg.Faucet.Amount,
+			cfg.Faucet.UserCooldown, cfg.Faucet.AddressCooldown)
+		log.Info("faucet enabled", "amount", cfg.Faucet.Amount)
+	}
+
+	if len(cfg.Market.Sources) > 0 {
+		be.market, err = market.NewMarketFromConfig(cfg.Market)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		log.Info("market data enabled", "sources", cfg.Market.Sources)
+	}
+
+	if err := be.scheduleClaimPayouts(); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if cfg.Monitor.Interval > 0 {
+		if err := be.enableMonitor(cfg); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	if cfg.Snapshot.Interval > 0 {
+		if err := be.enableSnapshots(cfg); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	if err := be.enableAnalytics(cfg); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := be.enableAudit(cfg); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := be.enableAddressWatches(cfg); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := be.enableProfiles(cfg); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := be.enableModeration(cfg); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := be.enableTips(cfg); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := be.enableReferrals(cfg); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	be.enablePayouts(cfg)
+
+	if err := be.enableLowBalanceCheck(cfg.Notification.LowBalance); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := be.applyConfig(cfg); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return be, nil
+}
+
+// newNetworkClient connects to the nodes of the network, the first node is the local one.
+// The nodes are checked to be on the network in the background, a mismatch is logged only.
+func newNetworkClient(ctx context.Context, cfg *config.Config, tlsConfig *tls.Config, network string,
+	nodes []string, onHealthChange func(client.HealthChange),
+) (*client.Mgr, error) {
+	cm := client.NewClientMgr(ctx)
+	cm.SetNetwork(network)
+	cm.SetHealthChangeHandler(onHealthChange)
+
+	localClient, err := client.NewClient(nodes[0], nodeClientOptions(cfg.NodeClient, tlsConfig, nodes[0])...)
+	if err != nil {
+		return nil, err
+	}
+
+	cm.AddClient(client.NewCachedClient(localClient, client.DefaultCacheTTLs()))
+
+	for _, nn := range nodes[1:] {
+		c, err := client.NewClient(nn, nodeClientOptions(cfg.NodeClient, tlsConfig, nn)...)
+		if err != nil {
+			log.Error("can't add new network node client", "err", err, "addr", nn, "network", network)
+
+			continue
+		}
+		cm.AddClient(client.NewCachedClient(c, client.DefaultCacheTTLs()))
+	}
+
+	// the policy is already validated by the config.
+	policy, _ := client.ParseSelectionPolicy(cfg.NodeFailover.Selection)
+	cm.SetSelectionPolicy(policy)
+	cm.SetHealthCheckInterval(cfg.NodeFailover.HealthCheckInterval)
+	cm.Start()
+
+	go func() {
+		if err := cm.CheckNetwork(); err != nil {
+			log.Warn("unable to check the network of the nodes", "err", err, "network", network)
+		}
+	}()
+
+	return cm, nil
+}
+
+// nodeClientOptions returns the client options of the timeouts, the retries and the circuit breaker,
+// and the credentials of the endpoint.
+func nodeClientOptions(cfg config.NodeClientConfig, tlsConfig *tls.Config, endpoint string) []client.Option {
+	opts := []client.Option{
+		client.WithCallTimeout(cfg.CallTimeout),
+		client.WithRetry(cfg.RetryAttempts, cfg.RetryBackoff),
+		client.WithCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+		client.WithTLSConfig(tlsConfig),
+	}
+
+	if addr, err := client.ResolveEndpoint(endpoint); err == nil && cfg.AuthTokens[addr] != "" {
+		opts = append(opts, client.WithAuthToken(cfg.AuthTokens[addr]))
+	}
+
+	if cfg.ReadOnly {
+		opts = append(opts, client.WithReadOnly())
+	}
+
+	return opts
 }
 
 func newBotEngine(logger *log.SubLogger, cm *client.Mgr, w wallet.IWallet, s store.IStore, db *database.DB,
@@ -124,46 +382,161 @@ func newBotEngine(logger *log.SubLogger, cm *client.Mgr, w wallet.IWallet, s sto
 		twitterClient: twitterClient,
 		nowpayments:   nowpayments,
 		AuthIDs:       authIDs,
+		toggles:       newCommandToggles(),
+		outcomes:      newRollingOutcomes(statsWindow, statsBucketSize),
+		messages:      NewMessageCatalog(),
+		breakers:      newCommandBreakers(defaultBreakerThreshold, defaultBreakerCooldown),
+		scheduler:     scheduler.New(),
+		startedAt:     time.Now(),
+		blockWatcher:  client.NewBlockWatcher(ctx, cm, client.DefaultBlockWatchInterval),
+		access:        newAppAccess(),
+		limits: inputLimits{
+			maxArgs:      defaultMaxArgs,
+			maxArgLength: defaultMaxArgLength,
+		},
 	}
 }
 
+// NetworkStatus fetches the status of the primary network, see NetworkStatusOf.
 func (be *BotEngine) NetworkStatus() (*NetStatus, error) {
-	netInfo, err := be.clientMgr.GetNetworkInfo()
+	return be.NetworkStatusOf("")
+}
+
+// NetworkStatusOf fetches the network and blockchain information of the network concurrently.
+// If some of the sub-queries fail, the status is returned partially with warnings.
+// The concurrent calls share one fetch, and the status is cached for CachedNetworkStatusOf.
+func (be *BotEngine) NetworkStatusOf(network string) (*NetStatus, error) {
+	cm, err := be.networkClient(network)
 	if err != nil {
 		return nil, err
 	}
 
-	chainInfo, err := be.clientMgr.GetBlockchainInfo()
+	status, err, _ := be.netStatuses.fetches.Do(cm.Network(), func() (any, error) {
+		status, err := be.fetchNetworkStatus(cm)
+		if err != nil {
+			return nil, err
+		}
+		be.netStatuses.set(cm.Network(), status, time.Now())
+
+		return status, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	cs, err := be.clientMgr.GetCirculatingSupply()
-	if err != nil {
-		cs = 0
-	}
-
-	return &NetStatus{
-		ConnectedPeersCount: netInfo.ConnectedPeersCount,
-		ValidatorsCount:     chainInfo.TotalValidators,
-		TotalBytesSent:      netInfo.TotalSentBytes,
-		TotalBytesReceived:  netInfo.TotalReceivedBytes,
-		CurrentBlockHeight:  chainInfo.LastBlockHeight,
-		TotalNetworkPower:   chainInfo.TotalPower,
-		TotalCommitteePower: chainInfo.CommitteePower,
-		NetworkName:         netInfo.NetworkName,
-		TotalAccounts:       chainInfo.TotalAccounts,
-		CirculatingSupply:   cs,
-	}, nil
+	return status.(*NetStatus), nil
 }
 
+// fetchNetworkStatus queries the status of the network from the nodes of the client.
+func (be *BotEngine) fetchNetworkStatus(cm *client.Mgr) (*NetStatus, error) {
+	var (
+		netInfo   *pactus.GetNetworkInfoResponse
+		chainInfo *pactus.GetBlockchainInfoResponse
+		cs        int64
+		csErr     error
+	)
+
+	g := errgroup.Group{}
+	g.Go(func() error {
+		var err error
+		netInfo, err = cm.GetNetworkInfo()
+		if err != nil {
+			return fmt.Errorf("network info: %w", err)
+		}
+
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		chainInfo, err = cm.GetBlockchainInfoLite(client.FieldsBlockchainSummary)
+		if err != nil {
+			return fmt.Errorf("blockchain info: %w", err)
+		}
+
+		return nil
+	})
+	g.Go(func() error {
+		cs, csErr = cm.GetCirculatingSupply()
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		be.logger.Warn("unable to fetch the network status completely", "err", err)
+	}
+
+	if netInfo == nil && chainInfo == nil {
+		return nil, errors.New("unable to get the network status")
+	}
+
+	status := &NetStatus{Network: cm.Network()}
+	if netInfo != nil {
+		status.ConnectedPeersCount = netInfo.ConnectedPeersCount
+		status.TotalBytesSent = netInfo.TotalSentBytes
+		status.TotalBytesReceived = netInfo.TotalReceivedBytes
+		status.NetworkName = netInfo.NetworkName
+	} else {
+		status.Warnings = append(status.Warnings, "network information is not available")
+	}
+
+	if chainInfo != nil {
+		status.ValidatorsCount = chainInfo.TotalValidators
+		status.CurrentBlockHeight = chainInfo.LastBlockHeight
+		status.TotalNetworkPower = chainInfo.TotalPower
+		status.TotalCommitteePower = chainInfo.CommitteePower
+		status.TotalAccounts = chainInfo.TotalAccounts
+	} else {
+		status.Warnings = append(status.Warnings, "blockchain information is not available")
+	}
+
+	if csErr == nil {
+		status.CirculatingSupply = cs
+	} else {
+		status.Warnings = append(status.Warnings, "circulating supply is not available")
+	}
+
+	return status, nil
+}
+
+// Stop stops the engine, after the running scheduled jobs are done.
 func (be *BotEngine) Stop() {
 	be.logger.Info("shutting bot engine down...")
 
 	be.cancel()
+	be.scheduler.Stop()
 	be.clientMgr.Stop()
+	for _, cm := range be.networks {
+		cm.Stop()
+	}
+	be.flushUsage()
+
+	if err := be.store.Close(); err != nil {
+		be.logger.Error("unable to close the store", "err", err)
+	}
 }
 
-func (be *BotEngine) Start() {
+// Start starts the scheduled jobs, they run until the engine is stopped.
+func (be *BotEngine) Start(ctx context.Context) error {
 	be.logger.Info("starting the bot engine...")
+
+	if be.events != nil {
+		go be.publishBlocks(be.SubscribeBlocks())
+	}
+
+	return be.scheduler.Start(ctx)
+}
+
+// SubscribeBlocks returns the new block events, see client.BlockWatcher.
+func (be *BotEngine) SubscribeBlocks() <-chan client.BlockEvent {
+	return be.blockWatcher.Subscribe(16)
+}
+
+// LastBlockTime returns the time of the last block, or the zero time if it's not available.
+func (be *BotEngine) LastBlockTime() time.Time {
+	lastBlockTime, _ := be.clientMgr.GetLastBlockTime()
+	if lastBlockTime == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(lastBlockTime), 0)
 }
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNetworkStatusConcurrent(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	chainInfoCalled := make(chan struct{})
+	chainInfo := &pactus.GetBlockchainInfoResponse{
+		LastBlockHeight: 150,
+		TotalValidators: 10,
+	}
+
+	// network info only returns after the blockchain info is requested,
+	// so the status can be built only if the calls are made concurrently.
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).DoAndReturn(
+		func(_ context.Context) (*pactus.GetNetworkInfoResponse, error) {
+			select {
+			case <-chainInfoCalled:
+				return &pactus.GetNetworkInfoResponse{NetworkName: "test", ConnectedPeersCount: 5}, nil
+			case <-time.After(time.Second):
+				return nil, errors.New("timeout")
+			}
+		})
+	mockClient.EXPECT().GetBlockchainInfo(gomock.Any()).DoAndReturn(
+		func(_ context.Context) (*pactus.GetBlockchainInfoResponse, error) {
+			select {
+			case <-chainInfoCalled:
+			default:
+				close(chainInfoCalled)
+			}
+
+			return chainInfo, nil
+		}).Times(2)
+	mockClient.EXPECT().GetBalance(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+
+	status, err := be.NetworkStatus()
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", status.NetworkName)
+	assert.Equal(t, uint32(5), status.ConnectedPeersCount)
+	assert.Equal(t, uint32(150), status.CurrentBlockHeight)
+	assert.Empty(t, status.Warnings)
+}
+
+func TestNetworkStatusPartialFailure(t *testing.T) {
+	t.Run("network info fails", func(t *testing.T) {
+		be, mockClient := setupTestEngineWithClient(t)
+
+		mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(nil, errors.New("unavailable"))
+		mockClient.EXPECT().GetBlockchainInfo(gomock.Any()).Return(
+			&pactus.GetBlockchainInfoResponse{LastBlockHeight: 150}, nil).Times(2)
+		mockClient.EXPECT().GetBalance(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+
+		status, err := be.NetworkStatus()
+		require.NoError(t, err)
+
+		assert.Equal(t, uint32(150), status.CurrentBlockHeight)
+		assert.Equal(t, []string{"network information is not available"}, status.Warnings)
+	})
+
+	t.Run("blockchain info fails", func(t *testing.T) {
+		be, mockClient := setupTestEngineWithClient(t)
+
+		mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(
+			&pactus.GetNetworkInfoResponse{NetworkName: "test"}, nil)
+		mockClient.EXPECT().GetBlockchainInfo(gomock.Any()).Return(nil, errors.New("unavailable")).Times(2)
+		mockClient.EXPECT().GetNodeInfo(gomock.Any()).Return(
+			&pactus.GetNodeInfoResponse{Agent: "node=gui/version=1.0.0"}, nil).AnyTimes()
+
+		status, err := be.NetworkStatus()
+		require.NoError(t, err)
+
+		assert.Equal(t, "test", status.NetworkName)
+		assert.Equal(t, []string{
+			"blockchain information is not available",
+			"circulating supply is not available",
+		}, status.Warnings)
+
+		// the command reuses the fetched status.
+		res, err := be.networkStatusHandler(AppIdCLI, "")
+		require.NoError(t, err)
+		assert.Len(t, res.Warnings, 2)
+	})
+
+	t.Run("everything fails", func(t *testing.T) {
+		be, mockClient := setupTestEngineWithClient(t)
+
+		mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(nil, errors.New("unavailable"))
+		mockClient.EXPECT().GetBlockchainInfo(gomock.Any()).Return(nil, errors.New("unavailable")).Times(2)
+
+		_, err := be.NetworkStatus()
+		assert.Error(t, err)
+	})
+}
+
+func TestCachedNetworkStatus(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(
+		&pactus.GetNetworkInfoResponse{NetworkName: "test", ConnectedPeersCount: 5}, nil).Times(2)
+	mockClient.EXPECT().GetBlockchainInfo(gomock.Any()).Return(
+		&pactus.GetBlockchainInfoResponse{LastBlockHeight: 150}, nil).Times(4)
+	mockClient.EXPECT().GetBalance(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+
+	fetched, err := be.CachedNetworkStatus()
+	require.NoError(t, err)
+
+	cached, err := be.CachedNetworkStatus()
+	require.NoError(t, err)
+	assert.Same(t, fetched, cached, "the fresh status is reused")
+
+	// the stale status is fetched again.
+	be.netStatuses.set(fetched.Network, fetched, time.Now().Add(-2*networkStatusMaxAge))
+	refetched, err := be.CachedNetworkStatus()
+	require.NoError(t, err)
+	assert.NotSame(t, fetched, refetched)
+	assert.Equal(t, uint32(150), refetched.CurrentBlockHeight)
+}
+
+func TestNetworkCommand(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(
+		&pactus.GetNetworkInfoResponse{NetworkName: "pactus-testnet-2", ConnectedPeersCount: 8}, nil).AnyTimes()
+	mockClient.EXPECT().GetBlockchainInfo(gomock.Any()).Return(
+		&pactus.GetBlockchainInfoResponse{LastBlockHeight: 1_234_567}, nil).AnyTimes()
+	mockClient.EXPECT().GetBalance(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+
+	t.Run("serving information", func(t *testing.T) {
+		mockClient.EXPECT().GetNodeInfo(gomock.Any()).Return(
+			&pactus.GetNodeInfoResponse{Agent: "node=daemon/version=1.0.0"}, nil)
+
+		res, err := be.networkStatusHandler(AppIdDiscord, "")
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.True(t, res.Branded)
+		assert.Contains(t, res.Message, "Serving: Testnet\nNode Agent: node=daemon/version=1.0.0\nHeight: 1,234,567\n")
+		assert.Contains(t, res.Message, "Network Name: pactus-testnet-2")
+	})
+
+	t.Run("node info is not available", func(t *testing.T) {
+		mockClient.EXPECT().GetNodeInfo(gomock.Any()).Return(nil, errors.New("unavailable"))
+
+		res, err := be.networkStatusHandler(AppIdDiscord, "")
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "Node Agent: not available")
+	})
+}
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/notification"
+)
+
+// The types of the events that the engine streams to the external consumers, see SubscribeEvents.
+const (
+	EventBlock   = "block"
+	EventCommand = "command"
+	EventAlert   = "alert"
+)
+
+// Event is an event of the engine, the data is one of BlockSummary, CommandExecution or notification.Event.
+type Event struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+	Data any       `json:"data"`
+}
+
+// BlockSummary is the new blocks since the previous summary, and the changes of the committee by them.
+type BlockSummary struct {
+	FromHeight uint32   `json:"from_height"`
+	Height     uint32   `json:"height"`
+	Joined     []string `json:"joined,omitempty"`
+	Left       []string `json:"left,omitempty"`
+}
+
+// CommandExecution is an executed command. The callers and the arguments are not included,
+// since the events are for the public dashboards.
+type CommandExecution struct {
+	Command    string `json:"command"`
+	AppID      string `json:"app_id"`
+	Successful bool   `json:"successful"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// eventHub fans the events out to the subscribers. The events are dropped for the subscribers
+// that are not keeping up, so a slow consumer doesn't block the commands.
+type eventHub struct {
+	lk   sync.Mutex
+	subs map[chan Event]struct{}
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{
+		subs: make(map[chan Event]struct{}),
+	}
+}
+
+func (h *eventHub) subscribe(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+
+	h.lk.Lock()
+	h.subs[ch] = struct{}{}
+	h.lk.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.lk.Lock()
+			delete(h.subs, ch)
+			h.lk.Unlock()
+
+			close(ch)
+		})
+	}
+
+	return ch, cancel
+}
+
+func (h *eventHub) publish(typ string, data any) {
+	event := Event{Type: typ, Time: time.Now().UTC(), Data: data}
+
+	h.lk.Lock()
+	defer h.lk.Unlock()
+
+	for ch := range h.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Notify publishes the notification as an alert, so the hub is a sink of the notifier.
+func (h *eventHub) Notify(_ context.Context, event notification.Event) error {
+	h.publish(EventAlert, event)
+
+	return nil
+}
+
+// withEvents adds the hub to the sink of the notifier, the sink can be nil.
+func withEvents(sink notification.Sink, hub *eventHub) notification.Sink {
+	if sink == nil {
+		return hub
+	}
+
+	return notification.Sinks{sink, hub}
+}
+
+// SubscribeEvents returns the events of the engine and the function that ends the subscription.
+// The channel is closed right away if the events are not enabled in the config.
+func (be *BotEngine) SubscribeEvents(buffer int) (<-chan Event, func()) {
+	if be.events == nil {
+		ch := make(chan Event)
+		close(ch)
+
+		return ch, func() {}
+	}
+
+	return be.events.subscribe(buffer)
+}
+
+// publishBlocks publishes the summaries of the block events, until the events are closed.
+func (be *BotEngine) publishBlocks(blocks <-chan client.BlockEvent) {
+	for event := range blocks {
+		be.events.publish(EventBlock, BlockSummary{
+			FromHeight: event.FromHeight,
+			Height:     event.Height,
+			Joined:     event.Joined,
+			Left:       event.Left,
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		be := setupTestEngine(t)
+
+		events, cancel := be.SubscribeEvents(1)
+		defer cancel()
+
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	be := setupTestEngine(t,
+		Command{Name: "ok", AppIDs: []AppID{AppIdCLI}, Handler: okHandler},
+	)
+	be.events = newEventHub()
+
+	t.Run("commands", func(t *testing.T) {
+		events, cancel := be.SubscribeEvents(4)
+		defer cancel()
+
+		_, err := be.Run(AppIdCLI, "1", []string{"ok"})
+		require.NoError(t, err)
+
+		event := <-events
+		assert.Equal(t, EventCommand, event.Type)
+		data := event.Data.(CommandExecution)
+		assert.Equal(t, "ok", data.Command)
+		assert.Equal(t, "CLI", data.AppID)
+		assert.True(t, data.Successful)
+	})
+
+	t.Run("alerts and blocks", func(t *testing.T) {
+		events, cancel := be.SubscribeEvents(4)
+		defer cancel()
+
+		notifier := withEvents(nil, be.events)
+		require.NoError(t, notifier.Notify(context.Background(),
+			notification.NewEvent(notification.KindLowBalance, "Low Wallet Balance", "balance is low")))
+
+		blocks := make(chan client.BlockEvent, 1)
+		blocks <- client.BlockEvent{FromHeight: 10, Height: 12, Joined: []string{"pc1p..."}}
+		close(blocks)
+		be.publishBlocks(blocks)
+
+		event := <-events
+		assert.Equal(t, EventAlert, event.Type)
+		assert.Equal(t, notification.KindLowBalance, event.Data.(notification.Event).Kind)
+
+		event = <-events
+		assert.Equal(t, EventBlock, event.Type)
+		assert.Equal(t, BlockSummary{FromHeight: 10, Height: 12, Joined: []string{"pc1p..."}}, event.Data)
+	})
+
+	t.Run("slow subscriber", func(t *testing.T) {
+		events, cancel := be.SubscribeEvents(1)
+
+		// the events that don't fit in the buffer are dropped, instead of blocking the commands.
+		for i := 0; i < 3; i++ {
+			_, err := be.Run(AppIdCLI, "1", []string{"ok"})
+			require.NoError(t, err)
+		}
+		assert.Len(t, events, 1)
+
+		cancel()
+		cancel()
+		be.events.publish(EventAlert, nil)
+		<-events
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+}
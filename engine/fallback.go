@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fallback is the behavior of a node dependent command when the node is unreachable.
+type Fallback int
+
+const (
+	// FallbackFail returns the error of the command, or the breaker message while the breaker is open.
+	FallbackFail Fallback = iota
+	// FallbackCached returns the last successful result of the command with the same arguments.
+	// If there is no such result, the network unavailable message is returned.
+	FallbackCached
+	// FallbackUnavailable returns a friendly network unavailable message.
+	FallbackUnavailable
+)
+
+func (f Fallback) String() string {
+	switch f {
+	case FallbackFail:
+		return "fail"
+	case FallbackCached:
+		return "cached"
+	case FallbackUnavailable:
+		return "unavailable"
+	default:
+		return "unknown"
+	}
+}
+
+type cachedResult struct {
+	res *CommandResult
+	at  time.Time
+}
+
+// lastResults keeps the last successful result of the commands with the cached fallback.
+// The results are kept per inputs, so each page of a list is kept separately.
+type lastResults struct {
+	lk sync.RWMutex
+
+	results map[string]cachedResult
+}
+
+func resultKey(inputs []string) string {
+	return strings.Join(inputs, "\x00")
+}
+
+func (lr *lastResults) store(inputs []string, res *CommandResult, at time.Time) {
+	lr.lk.Lock()
+	defer lr.lk.Unlock()
+
+	if lr.results == nil {
+		lr.results = make(map[string]cachedResult)
+	}
+	lr.results[resultKey(inputs)] = cachedResult{res: res, at: at}
+}
+
+func (lr *lastResults) load(inputs []string) (cachedResult, bool) {
+	lr.lk.RLock()
+	defer lr.lk.RUnlock()
+
+	cached, ok := lr.results[resultKey(inputs)]
+
+	return cached, ok
+}
+
+// nodeReachable reports whether any of the node endpoints is healthy.
+// The clients which don't report their state are counted as healthy, see client.Mgr.Gauges.
+func (be *BotEngine) nodeReachable() bool {
+	if be.clientMgr == nil {
+		return true
+	}
+
+	return be.clientMgr.Gauges().HealthyEndpoints > 0
+}
+
+// fallbackResult makes the result of the command when the node is unreachable.
+func (be *BotEngine) fallbackResult(msgs *MessageCatalog, cmd *Command, inputs []string) *CommandResult {
+	if cmd.Fallback == FallbackCached {
+		if cached, ok := be.lastResults.load(inputs); ok {
+			res := *cached.res
+			age := time.Since(cached.at).Round(time.Second)
+			res.Warnings = append([]string{msgs.Get(MsgStaleResult, age)}, slices.Clone(res.Warnings)...)
+
+			return &res
+		}
+	}
+
+	msg := msgs.Get(MsgNetworkUnavailable)
+	if be.clientMgr != nil {
+		if last := be.clientMgr.LastSuccess(); !last.IsZero() {
+			msg += " " + msgs.Get(MsgNodeLastReached, time.Since(last).Round(time.Second))
+		}
+	}
+
+	return MakeFailedResult("%s", msg)
+}
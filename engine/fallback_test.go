@@ -0,0 +1,161 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// downClient simulates the connectivity state of a node endpoint.
+type downClient struct {
+	*client.MockIClient
+
+	down bool
+}
+
+func (c *downClient) Healthy() bool   { return !c.down }
+func (c *downClient) InFlight() int64 { return 0 }
+
+func setupFallbackEngine(t *testing.T, fallback Fallback) (*BotEngine, *downClient, *error) {
+	t.Helper()
+
+	var handlerErr error
+	be := setupTestEngine(t, Command{
+		Name:   "cmd",
+		Args:   []Args{{Name: "page", Optional: true}},
+		AppIDs: []AppID{AppIdCLI},
+		Handler: func(_ AppID, _ string, args ...string) (*CommandResult, error) {
+			if handlerErr != nil {
+				return nil, handlerErr
+			}
+
+			return MakeSuccessfulResult("page " + args[0]), nil
+		},
+
+		NodeDependent: true,
+		Fallback:      fallback,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	c := &downClient{MockIClient: client.NewMockIClient(gomock.NewController(t))}
+	cm := client.NewClientMgr(ctx)
+	cm.AddClient(c)
+	be.clientMgr = cm
+
+	return be, c, &handlerErr
+}
+
+func TestFallback(t *testing.T) {
+	nodeErr := status.Error(codes.Unavailable, "connection refused")
+
+	t.Run("fail", func(t *testing.T) {
+		be, c, handlerErr := setupFallbackEngine(t, FallbackFail)
+
+		c.down = true
+		*handlerErr = nodeErr
+		_, err := be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+		assert.ErrorIs(t, err, nodeErr)
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		be, c, handlerErr := setupFallbackEngine(t, FallbackUnavailable)
+
+		c.down = true
+		*handlerErr = nodeErr
+		res, err := be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Equal(t, be.Message(MsgNetworkUnavailable), res.Message)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		be, c, handlerErr := setupFallbackEngine(t, FallbackCached)
+
+		res, err := be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+		require.NoError(t, err)
+		assert.Empty(t, res.Warnings)
+
+		c.down = true
+		*handlerErr = nodeErr
+		res, err = be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "page 1", res.Message)
+		require.Len(t, res.Warnings, 1)
+		assert.Contains(t, res.Warnings[0], "last known result")
+
+		// the cached result is not changed by the fallback.
+		res, err = be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+		require.NoError(t, err)
+		assert.Len(t, res.Warnings, 1)
+
+		t.Run("nothing cached for the arguments", func(t *testing.T) {
+			res, err := be.Run(AppIdCLI, "1", []string{"cmd", "2"})
+			require.NoError(t, err)
+			assert.False(t, res.Successful)
+			assert.Equal(t, be.Message(MsgNetworkUnavailable), res.Message)
+		})
+	})
+
+	t.Run("node unavailable", func(t *testing.T) {
+		be, _, handlerErr := setupFallbackEngine(t, FallbackFail)
+
+		*handlerErr = fmt.Errorf("%w: localhost:50051", client.ErrNodeUnavailable)
+		_, err := be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+		assert.EqualError(t, err, be.Message(MsgNodeUnavailable))
+
+		t.Run("falls back", func(t *testing.T) {
+			be, _, handlerErr := setupFallbackEngine(t, FallbackUnavailable)
+
+			*handlerErr = fmt.Errorf("%w: localhost:50051", client.ErrNodeUnavailable)
+			res, err := be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+			require.NoError(t, err)
+			assert.Equal(t, be.Message(MsgNetworkUnavailable), res.Message)
+		})
+	})
+
+	t.Run("errors are returned while the node is reachable", func(t *testing.T) {
+		be, _, handlerErr := setupFallbackEngine(t, FallbackUnavailable)
+
+		*handlerErr = errors.New("validator not found")
+		_, err := be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+		assert.ErrorIs(t, err, *handlerErr)
+	})
+
+	t.Run("open breaker", func(t *testing.T) {
+		be, _, handlerErr := setupFallbackEngine(t, FallbackUnavailable)
+
+		*handlerErr = nodeErr
+		for i := 0; i < defaultBreakerThreshold; i++ {
+			_, err := be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+			require.Error(t, err)
+		}
+
+		res, err := be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+		require.NoError(t, err)
+		assert.Equal(t, be.Message(MsgNetworkUnavailable), res.Message)
+
+		t.Run("fail mode keeps the breaker message", func(t *testing.T) {
+			be, _, handlerErr := setupFallbackEngine(t, FallbackFail)
+
+			*handlerErr = nodeErr
+			for i := 0; i < defaultBreakerThreshold; i++ {
+				_, _ = be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+			}
+
+			res, err := be.Run(AppIdCLI, "1", []string{"cmd", "1"})
+			require.NoError(t, err)
+			assert.Equal(t, be.Message(MsgTemporarilyUnavailable, "cmd"), res.Message)
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/faucet"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/wallet"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFaucetCommand(t *testing.T) {
+	be := setupTestEngine(t)
+	ctrl := gomock.NewController(t)
+	mockWallet := wallet.NewMockIWallet(ctrl)
+	mockStore := store.NewMockIStore(ctrl)
+
+	addr := crypto.NewAddress(crypto.AddressTypeBLSAccount, make([]byte, 20)).String()
+
+	t.Run("not registered if disabled", func(t *testing.T) {
+		be.RegisterCommands()
+
+		_, err := be.Run(AppIdDiscord, "123", []string{FaucetCommandName, addr})
+		assert.Error(t, err)
+	})
+
+	be.Cmds = nil
+	be.faucet = faucet.NewFaucet(mockWallet, mockStore, 5e9, time.Hour, time.Hour)
+	be.RegisterCommands()
+
+	t.Run("send coins", func(t *testing.T) {
+		mockStore.EXPECT().FaucetClaim(gomock.Any()).Return(nil).Times(2)
+		mockWallet.EXPECT().Balance().Return(int64(100e9))
+		mockWallet.EXPECT().TransferTransaction(addr, int64(5e9), gomock.Any()).Return("0x123", nil)
+		mockStore.EXPECT().SaveFaucetClaim(gomock.Any()).Return(nil).Times(2)
+
+		res, err := be.Run(AppIdDiscord, "123", []string{FaucetCommandName, addr})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Contains(t, res.Message, "0x123")
+	})
+
+	t.Run("cooldown", func(t *testing.T) {
+		mockStore.EXPECT().FaucetClaim("user:123").Return(
+			&store.FaucetClaim{Key: "user:123", ClaimedAt: time.Now().Unix()})
+		mockStore.EXPECT().FaucetClaim("address:" + addr).Return(nil)
+
+		res, err := be.Run(AppIdDiscord, "123", []string{FaucetCommandName, addr})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "123", []string{FaucetCommandName, "invalid-addr"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+}
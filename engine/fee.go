@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"strings"
+
+	"github.com/pactus-project/pactus/types/tx/payload"
+	"github.com/pactus-project/pactus/util"
+)
+
+// feePayloads are the payload types that the fee command estimates the fee of, by the names of their choices.
+var feePayloads = []struct {
+	Name  string
+	Title string
+	Type  payload.Type
+}{
+	{Name: "transfer", Title: "Transfer Fee", Type: payload.TypeTransfer},
+	{Name: "bond", Title: "Bond Fee", Type: payload.TypeBond},
+}
+
+// feeHandler estimates the fee of sending the amount, by the transfer and the bond transactions,
+// or only by the given type.
+func (be *BotEngine) feeHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	amount, err := util.StringToChange(strings.TrimSpace(args[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	payloadName := ""
+	if len(args) > 1 {
+		payloadName = args[1]
+	}
+
+	cm, err := be.networkClient(networkOf(args, 2))
+	if err != nil {
+		return nil, err
+	}
+
+	res := MakeSuccessfulResult("The estimated fee of sending %s PAC.", util.ChangeToString(amount))
+	res.Title = "Fee Estimation"
+	for _, p := range feePayloads {
+		if payloadName != "" && payloadName != p.Name {
+			continue
+		}
+
+		fee, err := cm.CalculateFee(amount, p.Type)
+		if err != nil {
+			return nil, err
+		}
+		res.AddField(p.Title, util.ChangeToString(fee)+" PAC", true)
+	}
+
+	return res, nil
+}
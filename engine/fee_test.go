@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeCommand(t *testing.T) {
+	be, nodes := setupTestEngineWithNodes(t, "local:50051")
+
+	t.Run("all the types", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{FeeCommandName, "100"})
+		require.NoError(t, err)
+		require.True(t, res.Successful)
+
+		assert.Equal(t, "The estimated fee of sending 100 PAC.", res.Message)
+		require.Len(t, res.Fields, 2)
+		assert.Equal(t, "Transfer Fee", res.Fields[0].Name)
+		assert.Equal(t, "0.01 PAC", res.Fields[0].Value)
+		assert.Equal(t, "Bond Fee", res.Fields[1].Name)
+	})
+
+	t.Run("one type", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{FeeCommandName, "100", "bond"})
+		require.NoError(t, err)
+		require.Len(t, res.Fields, 1)
+		assert.Equal(t, "Bond Fee", res.Fields[0].Name)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{FeeCommandName, "0"})
+		assert.ErrorContains(t, err, "expected a positive amount of PAC")
+
+		_, err = be.Run(AppIdCLI, "1", []string{FeeCommandName, "100", "unbond"})
+		assert.Error(t, err)
+	})
+
+	t.Run("node failure", func(t *testing.T) {
+		nodes[0].Fail("CalculateFee", assert.AnError)
+
+		_, err := be.Run(AppIdCLI, "1", []string{FeeCommandName, "100"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/client/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestEngineWithNodes creates the engine with the commands registered, connected to the fixture nodes.
+// The first node is the local one.
+func setupTestEngineWithNodes(t *testing.T, targets ...string) (*BotEngine, []*mock.Node) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cm := client.NewClientMgr(ctx)
+	nodes := make([]*mock.Node, 0, len(targets))
+	for _, target := range targets {
+		node, err := mock.NewFixtureNode(target)
+		require.NoError(t, err)
+
+		cm.AddClient(node)
+		nodes = append(nodes, node)
+	}
+
+	be := setupTestEngine(t)
+	be.ctx = ctx
+	be.cancel = cancel
+	be.clientMgr = cm
+	be.RegisterCommands()
+
+	return be, nodes
+}
+
+func TestFixtureCommands(t *testing.T) {
+	be, _ := setupTestEngineWithNodes(t, "local:50051")
+
+	t.Run("network", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{NetworkStatusCommandName})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Contains(t, res.Message, "Node Agent: "+mock.FixtureAgent)
+		assert.Contains(t, res.Message, "Current Block Height: 1,000")
+		assert.Contains(t, res.Message, "Total Committee Power: 10,000 PAC")
+		assert.Equal(t, []ResultAction{
+			{Label: "Refresh", Inputs: []string{NetworkStatusCommandName}, Replace: true},
+		}, res.Actions)
+	})
+
+	t.Run("committee", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{CommitteeCommandName})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Contains(t, res.Message, "#4 "+mock.ValidatorAddress(4).String()+": 4,000 PAC, availability 0.60")
+	})
+
+	t.Run("block", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{BlockCommandName, "1000"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "Block 1,000", res.Title)
+		assert.Equal(t, mock.ValidatorAddress(1).String(), res.Fields[1].Value)
+	})
+
+	t.Run("tx", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{TxCommandName, mock.FixtureTransfer().ID().String()})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "Transfer Transaction", res.Title)
+		assert.Equal(t, mock.AccountAddress(2).String(), res.Fields[4].Value)
+	})
+
+	t.Run("unknown tx", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{TxCommandName, strings.Repeat("ab", 32)})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "is not found")
+	})
+}
+
+func TestFixtureFailover(t *testing.T) {
+	be, nodes := setupTestEngineWithNodes(t, "local:50051", "remote:50051")
+	nodes[0].SetDown(mock.ErrUnavailable)
+
+	res, err := be.Run(AppIdCLI, "1", []string{CommitteeCommandName})
+	require.NoError(t, err)
+	assert.True(t, res.Successful)
+	assert.Positive(t, nodes[1].Calls("GetBlockchainInfo"), "the calls fail over to the remote node")
+
+	nodes[1].SetDown(mock.ErrUnavailable)
+	res, err = be.Run(AppIdCLI, "1", []string{BlockCommandName, "1000"})
+	assert.False(t, err == nil && res.Successful, "the command fails when all the nodes are down")
+}
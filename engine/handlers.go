@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"cmp"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"slices"
@@ -8,87 +10,406 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kehiy/RoboPac/audit"
+	"github.com/kehiy/RoboPac/client"
 	"github.com/kehiy/RoboPac/database"
+	"github.com/kehiy/RoboPac/faucet"
+	"github.com/kehiy/RoboPac/reward"
 	"github.com/kehiy/RoboPac/store"
 	"github.com/kehiy/RoboPac/utils"
 	"github.com/libp2p/go-libp2p/core/peer"
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/pactus-project/pactus/util"
 	"github.com/pactus-project/pactus/util/logger"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
 )
 
-func (be *BotEngine) networkHealthHandler(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+func (be *BotEngine) networkStatusHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	cm, err := be.networkClient(networkOf(args, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	net, err := be.CachedNetworkStatusOf(cm.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	agent := "not available"
+	nodeInfo, err := cm.GetNodeInfo()
+	if err != nil {
+		be.logger.Warn("unable to get node info", "err", err)
+	} else if nodeInfo.Agent != "" {
+		agent = nodeInfo.Agent
+	}
+
+	serving := fmt.Sprintf("Serving: %s\nNode Agent: %s\nHeight: %s\n\n",
+		client.NetworkType(net.NetworkName), agent, utils.FormatNumber(int64(net.CurrentBlockHeight)))
+
+	result := serving + fmt.Sprintf("Network Name: %s\nConnected Peers: %v\n"+
+		"Validators Count: %v\nAccounts Count: %v\nCurrent Block Height: %v\nTotal Power: %v PAC\nTotal Committee Power: %v PAC\nCirculating Supply: %v PAC\n"+
+		"\n> Note📝: This info is from one random network node. Non-blockchain data may not be consistent.",
+		net.NetworkName,
+		utils.FormatNumber(int64(net.ConnectedPeersCount)),
+		utils.FormatNumber(int64(net.ValidatorsCount)),
+		utils.FormatNumber(int64(net.TotalAccounts)),
+		utils.FormatNumber(int64(net.CurrentBlockHeight)),
+		utils.FormatNumber(int64(util.ChangeToCoin(net.TotalNetworkPower))),
+		utils.FormatNumber(int64(util.ChangeToCoin(net.TotalCommitteePower))),
+		utils.FormatNumber(int64(util.ChangeToCoin(net.CirculatingSupply))))
+
+	res := MakeSuccessfulResult("%s", result)
+	res.Branded = true
+	for _, w := range net.Warnings {
+		res.AddWarning(w)
+	}
+
+	res.AddRefresh(append([]string{NetworkStatusCommandName}, args...)...)
+	options := []ResultAction{}
+	for _, network := range be.Networks() {
+		if network != cm.Network() {
+			options = append(options, ResultAction{
+				Label:   network,
+				Inputs:  []string{NetworkStatusCommandName, network},
+				Replace: true,
+			})
+		}
+	}
+	if len(options) > 0 {
+		res.SetMenu("Show another network", options...)
+	}
+
+	return res, nil
+}
+
+func (be *BotEngine) nodeStatsHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	netInfo, err := be.clientMgr.GetNetworkInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	desc := client.DescribeTraffic(netInfo)
+	if len(desc) == 0 {
+		return MakeFailedResult(be.MessageFor(callerID, MsgNoResults)), nil
+	}
+
+	result := ""
+	for _, f := range desc {
+		result += fmt.Sprintf("%s: %s\n", f.Name, f.Value)
+	}
+
+	return MakeSuccessfulResult("%s", result), nil
+}
+
+// nodeSyncThreshold is the maximum age of the last block for a synced node.
+const nodeSyncThreshold = time.Minute
+
+func (be *BotEngine) nodeHandler(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+	res := MakeSuccessfulResult("")
+	result := ""
+	writeDesc := func(title string, desc client.Description) {
+		result += fmt.Sprintf("%s\n", title)
+		for _, f := range desc {
+			result += fmt.Sprintf("%s: %s\n", f.Name, f.Value)
+		}
+		result += "\n"
+	}
+
+	nodeInfo, err := be.clientMgr.GetNodeInfo()
+	if err != nil {
+		be.logger.Warn("unable to get node info", "err", err)
+		res.AddWarning("node information is not available")
+	} else {
+		writeDesc("Node🖥️", client.DescribeNode(nodeInfo))
+	}
+
 	lastBlockTime, lastBlockHeight := be.clientMgr.GetLastBlockTime()
-	lastBlockTimeFormatted := time.Unix(int64(lastBlockTime), 0).Format("02/01/2006, 15:04:05")
-	currentTime := time.Now()
+	if lastBlockTime == 0 {
+		res.AddWarning("sync status is not available")
+	} else {
+		now := time.Now()
+		blockTime := time.Unix(int64(lastBlockTime), 0)
+		age := now.Sub(blockTime)
+
+		syncStatus := "Synced✅"
+		if age > nodeSyncThreshold {
+			syncStatus = "Behind⚠️"
+			res.AddWarning("the last block is %v old", age.Round(time.Second))
+		}
 
-	timeDiff := (currentTime.Unix() - int64(lastBlockTime))
+		clockSkew := "not detected"
+		if blockTime.After(now) {
+			clockSkew = fmt.Sprintf("the local clock is %v behind⚠️", (-age).Round(time.Second))
+			res.AddWarning("the last block time is in the future, check the clock")
+		}
 
-	healthStatus := true
-	if timeDiff > 15 {
-		healthStatus = false
+		writeDesc("Sync⏱️", client.Description{
+			{Name: "Status", Value: syncStatus},
+			{Name: "Last Block Height", Value: utils.FormatNumber(int64(lastBlockHeight))},
+			{Name: "Last Block Age", Value: age.Round(time.Second).String()},
+			{Name: "Clock Skew", Value: clockSkew},
+		})
 	}
 
-	var status string
-	if healthStatus {
-		status = "Healthy✅"
+	netInfo, err := be.clientMgr.GetNetworkInfo()
+	if err != nil {
+		be.logger.Warn("unable to get network info", "err", err)
+		res.AddWarning("peer information is not available")
 	} else {
-		status = "UnHealthy❌"
+		writeDesc("Peers🌐", client.DescribePeers(netInfo))
 	}
 
-	return &CommandResult{
-		Successful: true,
-		Message: fmt.Sprintf("Network is %s\nCurrentTime: %v\nLastBlockTime: %v\nTime Diff: %v\nLast Block Height: %v",
-			status, currentTime.Format("02/01/2006, 15:04:05"), lastBlockTimeFormatted, timeDiff, utils.FormatNumber(int64(lastBlockHeight))),
-	}, nil
+	gauges := be.clientMgr.Gauges()
+	clientDesc := client.Description{
+		{Name: "Endpoints", Value: utils.FormatNumber(int64(gauges.Endpoints))},
+		{Name: "Healthy Endpoints", Value: utils.FormatNumber(int64(gauges.HealthyEndpoints))},
+		{Name: "In-flight Calls", Value: utils.FormatNumber(gauges.InFlightCalls)},
+	}
+	if lastSuccess := be.clientMgr.LastSuccess(); !lastSuccess.IsZero() {
+		clientDesc = append(clientDesc, client.DescriptionField{
+			Name:  "Last Successful Call",
+			Value: time.Since(lastSuccess).Round(time.Second).String() + " ago",
+		})
+	}
+	if cacheStats := be.clientMgr.CacheStats(); cacheStats.Hits+cacheStats.Misses > 0 {
+		clientDesc = append(clientDesc, client.DescriptionField{
+			Name: "Cache Hit Rate",
+			Value: fmt.Sprintf("%.1f%% (%s hits, %s misses)", cacheStats.HitRate()*100,
+				utils.FormatNumber(cacheStats.Hits), utils.FormatNumber(cacheStats.Misses)),
+		})
+	}
+	writeDesc("Client🔌", clientDesc)
+
+	result += fmt.Sprintf("Connected To: %s", be.clientMgr.LocalTarget())
+	res.Message = result
+
+	return res, nil
 }
 
-func (be *BotEngine) networkStatusHandler(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+// nodeDiffLagThreshold is the maximum lag of a synced endpoint in blocks,
+// the endpoints may be a block or two apart while the blocks are propagated.
+const nodeDiffLagThreshold = 3
+
+func (be *BotEngine) nodeDiffHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	cm, err := be.networkClient(networkOf(args, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := cm.CompareNodes()
+	res := MakeSuccessfulResult("Common Height: %s\nHighest Height: %s",
+		utils.FormatNumber(int64(comparison.CommonHeight)), utils.FormatNumber(int64(comparison.MaxHeight)))
+	res.Title = "Node Comparison"
+
+	res.SetTable("Endpoint", "Height", "Lag", "Hash", "Status")
+	for i := range comparison.Heads {
+		head := &comparison.Heads[i]
+		if head.Err != nil {
+			be.logger.Warn("unable to compare the node", "target", head.Target, "err", head.Err)
+			res.AddWarning("`%s` is unreachable", head.Target)
+			res.AddRow(head.Target, "-", "-", "-", "Unreachable❌")
+
+			continue
+		}
+
+		lag := comparison.Lag(head)
+		status := "Synced✅"
+		switch {
+		case comparison.IsForked(head):
+			status = "Forked❌"
+			res.AddWarning("`%s` has a different block at the height %s", head.Target,
+				utils.FormatNumber(int64(comparison.CommonHeight)))
+		case lag > nodeDiffLagThreshold:
+			status = "Behind⚠️"
+			res.AddWarning("`%s` is %s blocks behind", head.Target, utils.FormatNumber(int64(lag)))
+		}
+
+		res.AddRow(head.Target, utils.FormatNumber(int64(head.Height)), utils.FormatNumber(int64(lag)),
+			shortHash(head.CommonHash), status)
+	}
+	res.AddRefresh(append([]string{NodeDiffCommandName}, args...)...)
+
+	return res, nil
+}
+
+// grpcRawHandler calls the read method of the node by the JSON request, and shows the JSON response.
+func (be *BotEngine) grpcRawHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	method, err := client.ResolveRawMethod(args[0])
+	if err != nil {
+		return MakeFailedResult("%s", err.Error()), nil
+	}
+
+	request := "{}"
+	if len(args) > 1 && strings.TrimSpace(args[1]) != "" {
+		request = args[1]
+	}
+
+	cm, err := be.networkClient(networkOf(args, 2))
+	if err != nil {
+		return nil, err
+	}
+
+	// the errors of the request and of the node are shown as they are, the admin fixes the request by them.
+	response, err := cm.InvokeRaw(string(method.FullName()), []byte(request))
+	if errors.Is(err, client.ErrRawNotSupported) {
+		return nil, err
+	}
+	if err != nil {
+		return MakeFailedResult("%s", err.Error()), nil
+	}
+
+	res := MakeSuccessfulResult("```json\n%s\n```", response)
+	res.Title = "gRPC Response"
+	res.AddField("Method", string(method.FullName()), false)
+
+	return res, nil
+}
+
+// shortHash shows the first bytes of the hash in hex, they are enough to tell the hashes apart.
+func shortHash(hash []byte) string {
+	const shortLen = 6
+	if len(hash) > shortLen {
+		return hex.EncodeToString(hash[:shortLen]) + "…"
+	}
+
+	return hex.EncodeToString(hash)
+}
+
+func (be *BotEngine) peersHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	page, err := parsePage(args)
+	if err != nil {
+		return MakeFailedResult(err.Error()), nil
+	}
+
 	netInfo, err := be.clientMgr.GetNetworkInfo()
 	if err != nil {
 		return nil, err
 	}
 
-	chainInfo, err := be.clientMgr.GetBlockchainInfo()
+	if len(netInfo.ConnectedPeers) == 0 {
+		return MakeFailedResult(be.MessageFor(callerID, MsgNoResults)), nil
+	}
+
+	items := make([]string, 0, len(netInfo.ConnectedPeers))
+	for _, p := range netInfo.ConnectedPeers {
+		items = append(items, fmt.Sprintf("%s (%s)", p.Moniker, p.Agent))
+	}
+
+	return MakeListResult(Paginate(items, page, defaultPageSize)), nil
+}
+
+const (
+	// the peer search returns the top matches on a single page.
+	peerSearchLimit = 10
+	// the shorter queries match too many peers.
+	minPeerSearchQuery = 3
+)
+
+func (be *BotEngine) peerSearchHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	query := strings.TrimSpace(args[0])
+	if len([]rune(query)) < minPeerSearchQuery {
+		return MakeFailedResult("The query should have at least %d characters", minPeerSearchQuery), nil
+	}
+
+	matches, err := be.clientMgr.SearchPeers(query, peerSearchLimit)
 	if err != nil {
 		return nil, err
 	}
 
-	cs, err := be.clientMgr.GetCirculatingSupply()
+	if len(matches) == 0 {
+		return MakeFailedResult(be.MessageFor(callerID, MsgNoResults)), nil
+	}
+
+	items := make([]string, 0, len(matches))
+	for _, m := range matches {
+		moniker := m.Peer.Moniker
+		if moniker == "" {
+			moniker = "unknown"
+		}
+
+		item := fmt.Sprintf("%s (%s)", moniker, m.MatchedBy)
+		if m.PeerID != "" {
+			item += fmt.Sprintf("\npeer ID: %s", m.PeerID)
+		}
+		if m.Address != "" {
+			item += fmt.Sprintf("\naddress: %s", m.Address)
+		}
+		items = append(items, item)
+	}
+
+	res := MakeListResult(Paginate(items, 1, peerSearchLimit))
+	res.Suggest(NodeInfoCommandName)
+
+	return res, nil
+}
+
+func (be *BotEngine) committeeHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	page, err := parsePage(args)
 	if err != nil {
-		cs = 0
+		return MakeFailedResult(err.Error()), nil
 	}
 
-	net := NetStatus{
-		ValidatorsCount:     chainInfo.TotalValidators,
-		CurrentBlockHeight:  chainInfo.LastBlockHeight,
-		TotalNetworkPower:   chainInfo.TotalPower,
-		TotalCommitteePower: chainInfo.CommitteePower,
-		NetworkName:         netInfo.NetworkName,
-		TotalAccounts:       chainInfo.TotalAccounts,
-		CirculatingSupply:   cs,
+	network := networkOf(args, 1)
+	cm, err := be.networkClient(network)
+	if err != nil {
+		return nil, err
 	}
 
-	result := fmt.Sprintf("Network Name: %s\nConnected Peers: %v\n"+
-		"Validators Count: %v\nAccounts Count: %v\nCurrent Block Height: %v\nTotal Power: %v PAC\nTotal Committee Power: %v PAC\nCirculating Supply: %v PAC\n"+
-		"\n> Note📝: This info is from one random network node. Non-blockchain data may not be consistent.",
-		net.NetworkName,
-		utils.FormatNumber(int64(net.ConnectedPeersCount)),
-		utils.FormatNumber(int64(net.ValidatorsCount)),
-		utils.FormatNumber(int64(net.TotalAccounts)),
-		utils.FormatNumber(int64(net.CurrentBlockHeight)),
-		utils.FormatNumber(int64(util.ChangeToCoin(net.TotalNetworkPower))),
-		utils.FormatNumber(int64(util.ChangeToCoin(net.TotalCommitteePower))),
-		utils.FormatNumber(int64(util.ChangeToCoin(net.CirculatingSupply))))
+	chainInfo, err := cm.GetBlockchainInfoLite(client.FieldCommitteeValidators | client.FieldCommitteePower |
+		client.FieldLastBlockHeight)
+	if err != nil {
+		return nil, err
+	}
 
-	return &CommandResult{
-		Successful: true,
-		Message:    result,
-	}, nil
+	if len(chainInfo.CommitteeValidators) == 0 {
+		return MakeFailedResult(be.MessageFor(callerID, MsgNoResults)), nil
+	}
+
+	// the members are sorted by their stake, the larger first, and then by their numbers.
+	members := slices.Clone(chainInfo.CommitteeValidators)
+	slices.SortStableFunc(members, func(a, b *pactus.ValidatorInfo) int {
+		if c := cmp.Compare(b.Stake, a.Stake); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Number, b.Number)
+	})
+
+	// the last member that joined the committee is the one with the latest sortition.
+	latest := members[0]
+	items := make([]string, 0, len(members))
+	for _, val := range members {
+		items = append(items, fmt.Sprintf("#%v %s: %v PAC, availability %.2f",
+			val.Number, val.Address, utils.FormatNumber(int64(util.ChangeToCoin(val.Stake))), val.AvailabilityScore))
+
+		if val.LastSortitionHeight > latest.LastSortitionHeight {
+			latest = val
+		}
+	}
+
+	res := MakeListResult(Paginate(items, page, defaultPageSize))
+	res.Title = "Committee"
+	res.AddField("Members", utils.FormatNumber(int64(len(members))), true)
+	res.AddField("Committee Power", utils.FormatNumber(int64(util.ChangeToCoin(chainInfo.CommitteePower)))+" PAC", true)
+	res.AddField("Last Sortition", fmt.Sprintf("#%v %s at %s", latest.Number, latest.Address,
+		utils.FormatNumber(int64(latest.LastSortitionHeight))), false)
+	if res.List.HasNext() {
+		next := strings.TrimSpace(fmt.Sprintf("%s %d %s", CommitteeCommandName, res.List.Page+1, network))
+		res.AddWarning(fmt.Sprintf("Run `%s` for the next members", next))
+	}
+
+	return res, nil
 }
 
-func (be *BotEngine) nodeInfoHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
-	valAddress := args[0]
+func (be *BotEngine) nodeInfoHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	valAddress := be.linkedValidator(callerID)
+	if len(args) > 0 && args[0] != "" {
+		valAddress = args[0]
+	}
+
+	if valAddress == "" {
+		return MakeFailedResult("Please provide a validator address or link one with `/%s`", LinkCommandName), nil
+	}
 
 	peerInfo, err := be.clientMgr.GetPeerInfo(valAddress)
 	if err != nil {
@@ -147,6 +468,8 @@ func (be *BotEngine) nodeInfoHandler(_ AppID, _ string, args ...string) (*Comman
 		nodeInfo.City, nodeInfo.RegionName, nodeInfo.TimeZone, nodeInfo.ISP, utils.FormatNumber(int64(nodeInfo.ValidatorNum)),
 		pip19Score, utils.FormatNumber(int64(util.ChangeToCoin(nodeInfo.StakeAmount))))
 
+	be.rememberValidator(callerID, valAddress)
+
 	return &CommandResult{
 		Successful: true,
 		Message:    result,
@@ -166,76 +489,26 @@ func (be *BotEngine) claimerInfoHandler(_ AppID, _ string, args ...string) (*Com
 
 	return &CommandResult{
 		Successful: true,
-		Message: fmt.Sprintf("TestNet Address: %s\namount: %v PACs\nIsClaimed: %v\n txHash: %s",
-			args[0], util.ChangeToString(claimer.TotalReward), claimer.IsClaimed(), claimer.ClaimedTxID),
+		Message: fmt.Sprintf("TestNet Address: %s\namount: %v PACs\nIsClaimed: %v\nIsPending: %v\n txHash: %s",
+			args[0], util.ChangeToString(claimer.TotalReward), claimer.IsClaimed(), claimer.IsPending(), claimer.ClaimedTxID),
 	}, nil
 }
 
 func (be *BotEngine) claimHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
-	be.Lock()
-	defer be.Unlock()
-
 	mainnetAddr := args[0]
 	testnetAddr := args[1]
+	signature := args[2]
 
 	be.logger.Info("new claim request", "mainnetAddr", mainnetAddr, "testnetAddr", testnetAddr, "discordID", callerID)
 
-	valInfo, _ := be.clientMgr.GetValidatorInfo(mainnetAddr)
-	if valInfo != nil {
-		return nil, errors.New("this address is already a staked validator")
-	}
-
-	if utils.ChangeToCoin(be.wallet.Balance()) <= 500 {
-		be.logger.Warn("bot wallet hasn't enough balance")
-		return nil, errors.New("insufficient wallet balance")
-	}
-
-	claimer := be.store.ClaimerInfo(testnetAddr)
-	if claimer == nil {
-		return nil, errors.New("claimer not found")
-	}
-
-	if claimer.DiscordID != callerID {
-		be.logger.Warn("try to claim other's reward", "claimer", claimer.DiscordID, "discordID", callerID)
-		return nil, errors.New("invalid claimer")
-	}
-
-	if claimer.IsClaimed() {
-		return nil, errors.New("this claimer have already claimed rewards")
-	}
-
-	pubKey, err := be.clientMgr.FindPublicKey(mainnetAddr, true)
-	if err != nil {
-		return nil, err
-	}
-
-	memo := "TestNet reward claim from RoboPac"
-	txID, err := be.wallet.BondTransaction(pubKey, mainnetAddr, memo, claimer.TotalReward)
-	if err != nil {
-		return nil, err
-	}
-
-	if txID == "" {
-		return nil, errors.New("can't send bond transaction")
-	}
-
-	be.logger.Info("new bond transaction sent", "txID", txID)
-
-	err = be.store.AddClaimTransaction(testnetAddr, txID)
-	if err != nil {
-		be.logger.Panic("unable to add the claim transaction",
-			"error", err,
-			"discordID", callerID,
-			"testnetAddr", testnetAddr,
-			"txID", txID,
-		)
-
+	if err := be.requestClaim(callerID, mainnetAddr, testnetAddr, signature); err != nil {
 		return nil, err
 	}
 
 	return &CommandResult{
 		Successful: true,
-		Message:    fmt.Sprintf("Reward claimed successfully✅\nYour claim transaction: https://pacscan.org/transactions/%s", txID),
+		Message: "Your claim is accepted✅\nThe reward will be bonded to your validator in a few minutes, " +
+			"check it with the claimer-info command.",
 	}, nil
 }
 
@@ -263,45 +536,53 @@ func (be *BotEngine) claimStatusHandler(_ AppID, _ string, _ ...string) (*Comman
 }
 
 func (be *BotEngine) calcRewardHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
-	stake, err := strconv.Atoi(args[0])
+	stake, err := strconv.ParseFloat(args[0], 64)
 	if err != nil {
 		return nil, err
 	}
 
-	time := args[1]
-
-	if stake < 1 || stake > 1_000 {
-		return nil, errors.New("minimum of stake is 1 PAC and maximum is 1,000 PAC")
-	}
-
-	var blocks int
-	switch time {
-	case "day":
-		blocks = 8640
-	case "month":
-		blocks = 259200
-	case "year":
-		blocks = 3110400
-	default:
-		blocks = 8640
-		time = "day"
+	days := 1
+	if len(args) > 1 && args[1] != "" {
+		days, err = strconv.Atoi(args[1])
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	bi, err := be.clientMgr.GetBlockchainInfo()
+	bi, err := be.clientMgr.GetBlockchainInfoLite(client.FieldTotalPower | client.FieldCommitteeValidators)
 	if err != nil {
 		return nil, err
 	}
 
-	reward := int64(stake*blocks) / int64(util.ChangeToCoin(bi.TotalPower))
+	estimate, err := reward.Calculate(reward.Params{
+		TotalPower:    bi.TotalPower,
+		CommitteeSize: len(bi.CommitteeValidators),
+	}, util.CoinToChange(stake), days)
+	if err != nil {
+		return MakeFailedResult(err.Error()), nil
+	}
 
-	result := fmt.Sprintf("Approximately you earn %v PAC reward, with %v PAC stake 🔒 on your validator in one %s ⏰ with %v PAC total power ⚡ of committee."+
+	res := MakeSuccessfulResult("Approximately you earn %s PAC reward, with %s PAC stake 🔒 on your validator in %s ⏰"+
+		" with %s PAC total power ⚡ of the network."+
 		"\n\n> Note📝: This is an estimation and the number can get changed by changes of your stake amount, total power and ...",
-		utils.FormatNumber(reward), utils.FormatNumber(int64(stake)), time, utils.FormatNumber(bi.TotalPower))
+		util.ChangeToString(estimate.Reward), util.ChangeToString(estimate.Stake), pluralDays(days),
+		utils.FormatNumber(int64(util.ChangeToCoin(bi.TotalPower))))
+	res.Title = "Reward Estimation"
+	res.AddField("Reward", util.ChangeToString(estimate.Reward)+" PAC", true)
+	res.AddField("Blocks", utils.FormatNumber(estimate.Blocks), true)
+	res.AddField("Share of Power", fmt.Sprintf("%.4f%%", 100*estimate.Share), true)
+	res.AddField("Committee Seats", fmt.Sprintf("%.2f of %d", estimate.CommitteeSeats, len(bi.CommitteeValidators)), true)
+	res.AddField("APR", fmt.Sprintf("%.2f%%", estimate.APR), true)
+
+	return res, nil
+}
 
-	return &CommandResult{
-		Successful: true,
-		Message:    result,
-	}, nil
+func pluralDays(days int) string {
+	if days == 1 {
+		return "one day"
+	}
+
+	return utils.FormatNumber(int64(days)) + " days"
 }
 
 func (be *BotEngine) boosterPaymentHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
@@ -406,7 +687,7 @@ func (be *BotEngine) boosterPaymentHandler(_ AppID, callerID string, args ...str
 	}, nil
 }
 
-func (be *BotEngine) boosterClaimHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+func (be *BotEngine) boosterClaimHandler(appID AppID, callerID string, args ...string) (*CommandResult, error) {
 	be.Lock()
 	defer be.Unlock()
 
@@ -426,6 +707,14 @@ func (be *BotEngine) boosterClaimHandler(_ AppID, _ string, args ...string) (*Co
 			logger.Info("sending bond transaction", "receiver", party.ValAddr, "amount", party.AmountInPAC)
 			memo := "Booster Program"
 			txID, err := be.wallet.BondTransaction(party.ValPubKey, party.ValAddr, memo, utils.CoinToChange(float64(party.AmountInPAC)))
+			be.recordAudit(audit.Entry{
+				Kind:     audit.KindTransfer,
+				AppID:    appID.String(),
+				CallerID: callerID,
+				Inputs:   []string{BoosterClaimCommandName, twitterName, party.ValAddr, fmt.Sprintf("%v PAC", party.AmountInPAC)},
+				TxID:     txID,
+				Error:    auditError(nil, err),
+			})
 			if err != nil {
 				return nil, err
 			}
@@ -463,10 +752,6 @@ func (be *BotEngine) boosterClaimHandler(_ AppID, _ string, args ...string) (*Co
 }
 
 func (be *BotEngine) boosterWhitelistHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
-	if !slices.Contains(be.AuthIDs, callerID) {
-		return nil, fmt.Errorf("unauthorized person")
-	}
-
 	twitterName := args[0]
 
 	foundParty := be.store.FindTwitterParty(twitterName)
@@ -586,33 +871,188 @@ func (be *BotEngine) createOfferHandler(source AppID, callerID string, args ...s
 	), nil
 }
 
-func (be *BotEngine) help(source AppID, _ string, args ...string) (*CommandResult, error) {
-	helpStr := ""
-	if len(args) > 0 {
-		cmdName := args[0]
-		cmd := be.commandByName(cmdName)
-		if cmd == nil {
-			return nil, fmt.Errorf("unknown command: %s", cmdName)
+func (be *BotEngine) maintenanceHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	reason := ""
+	if len(args) > 1 {
+		reason = args[1]
+	}
+
+	if args[0] == "off" {
+		be.SetMaintenance(false, "")
+		be.logger.Info("maintenance mode turned off", "callerID", callerID)
+
+		return MakeSuccessfulResult("Maintenance mode is off"), nil
+	}
+
+	be.SetMaintenance(true, reason)
+	be.logger.Info("maintenance mode turned on", "callerID", callerID, "reason", reason)
+
+	return MakeSuccessfulResult("Maintenance mode is on, only the admins can run the commands"), nil
+}
+
+func (be *BotEngine) toggleCommandHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	cmdName := args[0]
+	state := args[1]
+	guildID := ""
+	if len(args) > 2 {
+		guildID = args[2]
+	}
+
+	if cmdName == ToggleCommandCommandName {
+		return nil, fmt.Errorf("the `%s` command can't be toggled", cmdName)
+	}
+
+	var enabled bool
+	switch state {
+	case "enable":
+		enabled = true
+	case "disable":
+		enabled = false
+	default:
+		return nil, fmt.Errorf("invalid state: %s, expected enable or disable", state)
+	}
+
+	if err := be.SetCommandEnabled(cmdName, guildID, enabled); err != nil {
+		return nil, err
+	}
+
+	scope := "globally"
+	if guildID != "" {
+		scope = fmt.Sprintf("for guild `%s`", guildID)
+	}
+
+	return MakeSuccessfulResult("Command `%s` %sd %s", cmdName, state, scope), nil
+}
+
+func (be *BotEngine) diagHandler(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+	result := "Command success rates in the last hour:\n"
+	for _, cmd := range be.Commands() {
+		succeeded, failed := be.outcomes.counts(cmd.Name)
+		if succeeded+failed == 0 {
+			result += fmt.Sprintf("`%s`: no calls\n", cmd.Name)
+
+			continue
 		}
 
-		argsStr := ""
-		for _, arg := range cmd.Args {
-			argsStr += fmt.Sprintf("<%v> ", arg.Name)
+		result += fmt.Sprintf("`%s`: %.1f%% (%v/%v)\n", cmd.Name,
+			be.SuccessRate(cmd.Name)*100, succeeded, succeeded+failed)
+	}
+
+	return MakeSuccessfulResult("%s", result), nil
+}
+
+func (be *BotEngine) linkHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	prefs := be.store.UserPrefs(callerID)
+	if prefs == nil {
+		prefs = &store.UserPrefs{DiscordID: callerID}
+	}
+	prefs.ValidatorAddr = strings.ToLower(strings.TrimSpace(args[0]))
+
+	if err := be.store.SaveUserPrefs(prefs); err != nil {
+		return nil, err
+	}
+
+	res := MakeSuccessfulResult("Validator `%s` linked to your account", prefs.ValidatorAddr)
+	res.Suggest(NodeInfoCommandName, MeCommandName)
+
+	return res, nil
+}
+
+func (be *BotEngine) unlinkHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	if len(args) > 0 && args[0] != "" {
+		return be.unlinkAddress(callerID, strings.ToLower(strings.TrimSpace(args[0])))
+	}
+
+	prefs := be.store.UserPrefs(callerID)
+	if prefs == nil || prefs.ValidatorAddr == "" {
+		return MakeFailedResult("No validator is linked to your account"), nil
+	}
+
+	valAddress := prefs.ValidatorAddr
+	prefs.ValidatorAddr = ""
+
+	if err := be.store.SaveUserPrefs(prefs); err != nil {
+		return nil, err
+	}
+
+	return MakeSuccessfulResult("Validator `%s` unlinked from your account", valAddress), nil
+}
+
+func (be *BotEngine) meHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	valAddress := be.linkedValidator(callerID)
+	if valAddress == "" {
+		res := MakeSuccessfulResult("Discord ID: %s\nLinked Validator: not linked, use `/%s` to link one",
+			callerID, LinkCommandName)
+		res.Suggest(LinkCommandName)
+
+		return res, nil
+	}
+
+	res := MakeSuccessfulResult("Discord ID: %s\nLinked Validator: %s", callerID, valAddress)
+	res.Suggest(NodeInfoCommandName, UnlinkCommandName)
+
+	return res, nil
+}
+
+// linkedValidator returns the validator address linked to the caller, or an empty string.
+func (be *BotEngine) linkedValidator(callerID string) string {
+	if callerID == "" {
+		return ""
+	}
+
+	prefs := be.store.UserPrefs(callerID)
+	if prefs == nil {
+		return ""
+	}
+
+	return prefs.ValidatorAddr
+}
+
+func (be *BotEngine) commandsHandler(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+	info := be.RegistryInfo()
+	listOrNone := func(names []string) string {
+		if len(names) == 0 {
+			return "none"
 		}
-		argsStr = argsStr[:len(argsStr)-1]
 
-		helpStr += cmd.Desc
-		helpStr += fmt.Sprintf("%v\nUsage: `%v %v`", cmd.Help, cmd.Name, argsStr)
-	} else {
-		helpStr += "List of available commands:\n"
-		for _, cmd := range be.Cmds {
-			if !slices.Contains(cmd.AppIDs, source) {
-				continue
-			}
+		return "`" + strings.Join(names, "`, `") + "`"
+	}
+
+	res := MakeSuccessfulResult("%v commands are registered", info.Total)
+	for _, appID := range []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack} {
+		res.AddField(appID.String(), utils.FormatNumber(int64(info.PerApp[appID])), true)
+	}
+	res.AddField("Disabled", listOrNone(info.Disabled), false)
+	res.AddField("Deprecated", listOrNone(info.Deprecated), false)
+	res.AddField("Confirmation Required", listOrNone(info.Confirmed), false)
 
-			padding := 12 - len(cmd.Name)
-			helpStr += fmt.Sprintf("`%s`:%s%v\n", cmd.Name, strings.Repeat(" ", padding), cmd.Desc)
+	return res, nil
+}
+
+func (be *BotEngine) faucetHandler(appID AppID, callerID string, args ...string) (*CommandResult, error) {
+	address := strings.TrimSpace(args[0])
+
+	txID, err := be.faucet.Send(callerID, address)
+	if err != nil {
+		var cooldownErr faucet.CooldownError
+		if errors.As(err, &cooldownErr) || errors.Is(err, faucet.ErrInsufficientBalance) ||
+			errors.Is(err, faucet.ErrInvalidAddress) {
+			return MakeFailedResult("%s", err.Error()), nil
 		}
 	}
-	return MakeSuccessfulResult(helpStr), nil
+
+	be.recordAudit(audit.Entry{
+		Kind:     audit.KindFaucet,
+		AppID:    appID.String(),
+		CallerID: callerID,
+		Inputs:   []string{FaucetCommandName, address},
+		TxID:     txID,
+		Error:    auditError(nil, err),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeSuccessfulResult("%v tPAC sent to `%s`\nTransaction: %s",
+		util.ChangeToCoin(be.faucet.Amount()), address, txID), nil
 }
@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalcRewardCommand(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	be.RegisterCommands()
+
+	mockClient.EXPECT().GetBlockchainInfo(gomock.Any()).Return(&pactus.GetBlockchainInfoResponse{
+		TotalPower:          9_900e9,
+		CommitteeValidators: make([]*pactus.ValidatorInfo, 51),
+	}, nil).AnyTimes()
+
+	t.Run("default days", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{CalcRewardCommandName, "100"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Contains(t, res.Message, "you earn 86.4 PAC reward, with 100 PAC stake")
+		assert.Contains(t, res.Message, "in one day")
+		assert.Equal(t, ResultField{Name: "Committee Seats", Value: "0.51 of 51", Inline: true}, res.Fields[3])
+	})
+
+	t.Run("days", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{CalcRewardCommandName, "100", "30"})
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "you earn 2592 PAC reward")
+		assert.Contains(t, res.Message, "in 30 days")
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{CalcRewardCommandName, "1001"})
+		assert.Error(t, err)
+
+		_, err = be.Run(AppIdCLI, "1", []string{CalcRewardCommandName, "100", "0"})
+		assert.Error(t, err)
+	})
+}
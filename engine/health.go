@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kehiy/RoboPac/utils"
+)
+
+const (
+	HealthStatusHealthy   = "HEALTHY"
+	HealthStatusDegraded  = "DEGRADED"
+	HealthStatusUnhealthy = "UNHEALTHY"
+
+	// the block lag scores full up to healthyBlockLag, and zero from unhealthyBlockLag.
+	healthyBlockLag   = 15 * time.Second
+	unhealthyBlockLag = 2 * time.Minute
+	// the peers score full from healthyPeers, and zero without any peer.
+	healthyPeers = 16
+
+	blockLagWeight = 0.7
+	peersWeight    = 0.3
+
+	healthyScore  = 80
+	degradedScore = 50
+)
+
+// NetworkHealth scores the health of the primary network, see NetworkHealthOf.
+func (be *BotEngine) NetworkHealth() (*NetworkHealth, error) {
+	return be.NetworkHealthOf("")
+}
+
+// NetworkHealthOf scores the health of the network from 0 to 100, by how long ago the last block is committed
+// and how many peers the node is connected to. If the peers are not available, the score is made of the block lag.
+func (be *BotEngine) NetworkHealthOf(network string) (*NetworkHealth, error) {
+	cm, err := be.networkClient(network)
+	if err != nil {
+		return nil, err
+	}
+
+	lastBlockTime, lastBlockHeight := cm.GetLastBlockTime()
+	if lastBlockTime == 0 {
+		return nil, errors.New("unable to get the last block")
+	}
+
+	health := &NetworkHealth{
+		LastBlockHeight: lastBlockHeight,
+		LastBlockTime:   time.Unix(int64(lastBlockTime), 0),
+	}
+	// the clocks of the node and the bot can drift a bit, so the block can be in the future.
+	health.BlockLag = max(be.now().Sub(health.LastBlockTime), 0)
+
+	score := blockLagWeight * partScore(float64(unhealthyBlockLag-health.BlockLag),
+		float64(unhealthyBlockLag-healthyBlockLag))
+	weights := blockLagWeight
+
+	netInfo, err := cm.GetNetworkInfo()
+	if err != nil {
+		be.logger.Warn("unable to get the network info for the health", "err", err)
+		health.Warnings = append(health.Warnings, "connected peers are not available")
+	} else {
+		health.ConnectedPeers = netInfo.ConnectedPeersCount
+		health.PeersAvailable = true
+		score += peersWeight * partScore(float64(health.ConnectedPeers), healthyPeers)
+		weights += peersWeight
+	}
+
+	health.Score = int(math.Round(100 * score / weights))
+	health.Status = healthStatus(health.Score)
+
+	return health, nil
+}
+
+// now returns the current time of the engine clock.
+func (be *BotEngine) now() time.Time {
+	if be.clock == nil {
+		return time.Now()
+	}
+
+	return be.clock.Now()
+}
+
+// partScore returns the ratio of the value to the full value, between 0 and 1.
+func partScore(value, full float64) float64 {
+	return min(max(value/full, 0), 1)
+}
+
+func healthStatus(score int) string {
+	switch {
+	case score >= healthyScore:
+		return HealthStatusHealthy
+	case score >= degradedScore:
+		return HealthStatusDegraded
+	default:
+		return HealthStatusUnhealthy
+	}
+}
+
+func healthEmoji(status string) string {
+	switch status {
+	case HealthStatusHealthy:
+		return "✅"
+	case HealthStatusDegraded:
+		return "⚠️"
+	default:
+		return "❌"
+	}
+}
+
+func (be *BotEngine) networkHealthHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	health, err := be.NetworkHealthOf(networkOf(args, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	peers := "not available"
+	if health.PeersAvailable {
+		peers = utils.FormatNumber(int64(health.ConnectedPeers))
+	}
+
+	res := MakeSuccessfulResult("Health score: %d/100 %s\nblock time lag: %s, connected peers: %s, status: %s",
+		health.Score, healthEmoji(health.Status), health.BlockLag.Round(time.Second), peers, health.Status)
+	res.Title = "Network Health"
+	res.AddField("Score", fmt.Sprintf("%d/100", health.Score), true)
+	res.AddField("Block Time Lag", health.BlockLag.Round(time.Second).String(), true)
+	res.AddField("Connected Peers", peers, true)
+	res.AddField("Last Block Height", utils.FormatNumber(int64(health.LastBlockHeight)), true)
+	res.AddField("Last Block Time", health.LastBlockTime.UTC().Format(time.DateTime), true)
+	for _, w := range health.Warnings {
+		res.AddWarning("%s", w)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNetworkHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		blockLag time.Duration
+		peers    uint32
+		peersErr error
+		score    int
+		status   string
+	}{
+		{name: "healthy", blockLag: 5 * time.Second, peers: 34, score: 100, status: HealthStatusHealthy},
+		{name: "few peers", blockLag: 5 * time.Second, peers: 2, score: 74, status: HealthStatusDegraded},
+		{name: "block lag", blockLag: time.Minute, peers: 34, score: 70, status: HealthStatusDegraded},
+		{name: "stalled", blockLag: 5 * time.Minute, peers: 34, score: 30, status: HealthStatusUnhealthy},
+		{
+			name: "peers not available", blockLag: 5 * time.Second, peersErr: errors.New("unavailable"),
+			score: 100, status: HealthStatusHealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			be, mockClient := setupTestEngineWithClient(t)
+			be.clock = client.NewFakeClock(time.Unix(1_700_000_000, 0))
+
+			lastBlockTime := be.clock.Now().Add(-tt.blockLag)
+			mockClient.EXPECT().LastBlockTime(gomock.Any()).Return(uint32(lastBlockTime.Unix()), uint32(100), nil)
+			if tt.peersErr != nil {
+				mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(nil, tt.peersErr)
+			} else {
+				mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(
+					&pactus.GetNetworkInfoResponse{ConnectedPeersCount: tt.peers}, nil)
+			}
+
+			health, err := be.NetworkHealth()
+			require.NoError(t, err)
+			assert.Equal(t, tt.score, health.Score)
+			assert.Equal(t, tt.status, health.Status)
+			assert.Equal(t, uint32(100), health.LastBlockHeight)
+			assert.Equal(t, tt.peersErr == nil, health.PeersAvailable)
+			assert.Equal(t, tt.blockLag, health.BlockLag)
+		})
+	}
+
+	t.Run("last block not available", func(t *testing.T) {
+		be, mockClient := setupTestEngineWithClient(t)
+		mockClient.EXPECT().LastBlockTime(gomock.Any()).Return(uint32(0), uint32(0), errors.New("unavailable")).AnyTimes()
+
+		_, err := be.NetworkHealth()
+		assert.Error(t, err)
+	})
+}
+
+func TestNetworkHealthCommand(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	be.RegisterCommands()
+	be.clock = client.NewFakeClock(time.Unix(1_700_000_000, 0))
+
+	mockClient.EXPECT().LastBlockTime(gomock.Any()).Return(
+		uint32(be.clock.Now().Add(-12*time.Second).Unix()), uint32(123_456), nil)
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(
+		&pactus.GetNetworkInfoResponse{ConnectedPeersCount: 34}, nil)
+
+	res, err := be.Run(AppIdCLI, "123", []string{NetworkHealthCommandName})
+	require.NoError(t, err)
+	assert.True(t, res.Successful)
+	assert.Equal(t, "Network Health", res.Title)
+	assert.Contains(t, res.Message, "block time lag: 12s, connected peers: 34, status: HEALTHY")
+	assert.Equal(t, ResultField{Name: "Last Block Height", Value: "123,456", Inline: true}, res.Fields[3])
+}
@@ -0,0 +1,190 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Category groups the commands in the help.
+type Category string
+
+const (
+	CategoryNetwork    Category = "Network"
+	CategoryValidators Category = "Validators"
+	CategoryWallet     Category = "Wallet"
+	CategoryRewards    Category = "Rewards"
+	CategoryBooster    Category = "Booster"
+	CategoryGeneral    Category = "General"
+	CategoryAdmin      Category = "Admin"
+)
+
+// categories are the categories in the order of the help.
+var categories = []Category{
+	CategoryNetwork, CategoryValidators, CategoryWallet, CategoryRewards,
+	CategoryBooster, CategoryGeneral, CategoryAdmin,
+}
+
+// findCategory returns the category by its name, case-insensitively.
+func findCategory(name string) (Category, bool) {
+	for _, category := range categories {
+		if strings.EqualFold(string(category), name) {
+			return category, true
+		}
+	}
+
+	return "", false
+}
+
+// CategoryOf returns the category of the command in the help. The commands without a category are
+// in CategoryAdmin if only the admins can run them, otherwise in CategoryGeneral.
+func (cmd *Command) CategoryOf() Category {
+	switch {
+	case cmd.Category != "":
+		return cmd.Category
+	case cmd.MinRole >= RoleAdmin:
+		return CategoryAdmin
+	default:
+		return CategoryGeneral
+	}
+}
+
+// help lists the commands by their categories. With an argument, it shows the usage of the command,
+// the commands of the category, or the commands that match the phrase, in this order.
+func (be *BotEngine) help(source AppID, callerID string, args ...string) (*CommandResult, error) {
+	cmds := make([]Command, 0, len(be.Commands()))
+	for _, cmd := range be.Commands() {
+		if be.IsCommandAllowed(cmd.Name, source) {
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	if len(args) == 0 || strings.TrimSpace(args[0]) == "" {
+		return MakeSuccessfulResult("List of available commands:\n%s\nUse `%s <command>` to see the usage of a command.",
+			commandList(cmds, categories...), HelpCommandName), nil
+	}
+
+	phrase := strings.TrimSpace(args[0])
+	if cmd := be.commandByName(phrase); cmd != nil {
+		return MakeSuccessfulResult("%s", commandHelp(cmd)), nil
+	}
+
+	if category, ok := findCategory(phrase); ok {
+		list := commandList(cmds, category)
+		if list == "" {
+			return MakeFailedResult("No commands are available in %s", category), nil
+		}
+
+		return MakeSuccessfulResult("%s", list), nil
+	}
+
+	matches := make([]Command, 0)
+	lowerPhrase := strings.ToLower(phrase)
+	for _, cmd := range cmds {
+		if strings.Contains(cmd.Name, lowerPhrase) || strings.Contains(strings.ToLower(cmd.Desc), lowerPhrase) {
+			matches = append(matches, cmd)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, errors.New(be.MessageFor(callerID, MsgUnknownCommand, phrase))
+	}
+
+	return MakeSuccessfulResult("Commands matching `%s`:\n%s", phrase, commandList(matches, categories...)), nil
+}
+
+// commandList lists the commands of the categories, grouped by the category if there are more than one.
+func commandList(cmds []Command, cats ...Category) string {
+	var sb strings.Builder
+	for _, category := range cats {
+		lines := ""
+		for i := range cmds {
+			cmd := &cmds[i]
+			if cmd.CategoryOf() != category {
+				continue
+			}
+
+			padding := max(12-len(cmd.Name), 1)
+			desc := cmd.Desc
+			if cmd.Deprecated {
+				desc += " (deprecated)"
+			}
+			lines += fmt.Sprintf("`%s`:%s%v\n", cmd.Name, strings.Repeat(" ", padding), desc)
+		}
+
+		if lines == "" {
+			continue
+		}
+		if len(cats) > 1 {
+			fmt.Fprintf(&sb, "\n%s:\n", category)
+		}
+		sb.WriteString(lines)
+	}
+
+	return sb.String()
+}
+
+// commandHelp returns the usage of the command, with its arguments and examples.
+func commandHelp(cmd *Command) string {
+	helpStr := cmd.Desc
+	if cmd.Help != "" {
+		helpStr += "\n" + cmd.Help
+	}
+
+	if len(cmd.SubCommands) > 0 {
+		helpStr += "\nSubcommands:"
+		for _, sub := range cmd.SubCommands {
+			helpStr += fmt.Sprintf("\n`%v`: %v", usage(cmd.Name+" "+sub.Name, sub.Args), sub.Desc)
+		}
+	} else {
+		helpStr += fmt.Sprintf("\nUsage: `%v`", usage(cmd.Name, cmd.Args))
+		if len(cmd.Args) > 0 {
+			helpStr += "\nArguments:"
+			for _, arg := range cmd.Args {
+				helpStr += "\n" + argHelp(arg)
+			}
+		}
+	}
+
+	if len(cmd.Examples) > 0 {
+		helpStr += "\nExamples:"
+		for _, example := range cmd.Examples {
+			helpStr += fmt.Sprintf("\n`%s %s`", cmd.Name, example)
+		}
+	}
+
+	if cmd.Deprecated {
+		helpStr += fmt.Sprintf("\n\n> Note📝: %s", cmd.DeprecationNote())
+	}
+
+	return helpStr
+}
+
+// argHelp describes the argument, like "`days` (optional): the number of days, an integer between 1 and 365".
+func argHelp(arg Args) string {
+	flag := "required"
+	if arg.Optional {
+		flag = "optional"
+	}
+
+	desc := arg.Constraint()
+	if arg.Desc != "" {
+		desc = arg.Desc + ", " + desc
+	}
+
+	return fmt.Sprintf("`%s` (%s): %s", arg.Name, flag, desc)
+}
+
+// usage returns the usage of the command, like "calc-reward <stake> <days> [network]".
+// The required arguments are in angle brackets, and the optional ones are in square brackets.
+func usage(cmdName string, args []Args) string {
+	usageStr := cmdName
+	for _, arg := range args {
+		if arg.Optional {
+			usageStr += fmt.Sprintf(" [%v]", arg.Name)
+		} else {
+			usageStr += fmt.Sprintf(" <%v>", arg.Name)
+		}
+	}
+
+	return usageStr
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelp(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{
+			Name:     "block",
+			Desc:     "show a block",
+			AppIDs:   []AppID{AppIdCLI},
+			Handler:  okHandler,
+			Category: CategoryNetwork,
+			Args: []Args{
+				{Name: "height", Desc: "the block height", Type: ArgTypeInteger, MinValue: Bound(1)},
+				{Name: "page", Optional: true},
+			},
+			Examples: []string{"1000", "1000 2"},
+		},
+		Command{Name: "faucet", Desc: "get some coins", AppIDs: []AppID{AppIdCLI}, Handler: okHandler, Category: CategoryWallet},
+		Command{Name: "maintenance", Desc: "toggle maintenance", AppIDs: []AppID{AppIdCLI}, Handler: okHandler, MinRole: RoleAdmin},
+		Command{Name: "discord-only", Desc: "discord", AppIDs: []AppID{AppIdDiscord}, Handler: okHandler},
+	)
+	be.Cmds = append(be.Cmds, Command{
+		Name:    HelpCommandName,
+		Desc:    "help",
+		AppIDs:  []AppID{AppIdCLI},
+		Handler: be.help,
+		Args:    []Args{{Name: "command", Optional: true}},
+	})
+
+	t.Run("grouped by category", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{HelpCommandName})
+		require.NoError(t, err)
+		assert.Equal(t, "List of available commands:\n"+
+			"\nNetwork:\n`block`:       show a block\n"+
+			"\nWallet:\n`faucet`:      get some coins\n"+
+			"\nGeneral:\n`help`:        help\n"+
+			"\nAdmin:\n`maintenance`: toggle maintenance\n"+
+			"\nUse `help <command>` to see the usage of a command.", res.Message)
+	})
+
+	t.Run("usage of a command", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{HelpCommandName, "block"})
+		require.NoError(t, err)
+		assert.Equal(t, "show a block\n"+
+			"Usage: `block <height> [page]`\n"+
+			"Arguments:\n"+
+			"`height` (required): the block height, an integer of at least 1\n"+
+			"`page` (optional): a string\n"+
+			"Examples:\n`block 1000`\n`block 1000 2`", res.Message)
+	})
+
+	t.Run("commands of a category", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{HelpCommandName, "wallet"})
+		require.NoError(t, err)
+		assert.Equal(t, "`faucet`:      get some coins\n", res.Message)
+
+		res, err = be.Run(AppIdCLI, "1", []string{HelpCommandName, "booster"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("search by phrase", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{HelpCommandName, "coins"})
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "`faucet`")
+		assert.NotContains(t, res.Message, "`block`")
+
+		_, err = be.Run(AppIdCLI, "1", []string{HelpCommandName, "nothing"})
+		assert.Error(t, err)
+	})
+}
@@ -1,9 +1,11 @@
 package engine
 
+import "context"
+
 type IEngine interface {
 	Run(appID AppID, callerID string, inputs []string) (*CommandResult, error)
 	Commands() []Command
 
 	Stop()
-	Start()
+	Start(ctx context.Context) error
 }
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/utils"
+	"github.com/pactus-project/pactus/util"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultTopValidators = 10
+	maxTopValidators     = 50
+
+	// validatorRankingMaxAge is how long a ranking is reused, fetching all the validators is expensive.
+	validatorRankingMaxAge = 5 * time.Minute
+)
+
+// validatorRanking is the bonded validators of a network, ranked by their stake,
+// and by their availability score on equal stakes.
+type validatorRanking struct {
+	validators []*pactus.ValidatorInfo
+	totalStake int64
+	fetchedAt  time.Time
+}
+
+// validatorRankings keeps the last ranking of each network, and merges the concurrent fetches
+// of a network into one. The zero value is ready to use.
+type validatorRankings struct {
+	lk      sync.Mutex
+	entries map[string]*validatorRanking
+	fetches singleflight.Group
+}
+
+func (r *validatorRankings) get(network string, now time.Time) *validatorRanking {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	ranking, ok := r.entries[network]
+	if !ok || now.Sub(ranking.fetchedAt) > validatorRankingMaxAge {
+		return nil
+	}
+
+	return ranking
+}
+
+func (r *validatorRankings) set(network string, ranking *validatorRanking) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	if r.entries == nil {
+		r.entries = make(map[string]*validatorRanking)
+	}
+	r.entries[network] = ranking
+}
+
+// validatorRankingOf returns the cached ranking of the network if it's fresh, or fetches it.
+func (be *BotEngine) validatorRankingOf(cm *client.Mgr) (*validatorRanking, error) {
+	if ranking := be.rankings.get(cm.Network(), time.Now()); ranking != nil {
+		return ranking, nil
+	}
+
+	ranking, err, _ := be.rankings.fetches.Do(cm.Network(), func() (any, error) {
+		chainInfo, err := cm.GetBlockchainInfoLite(client.FieldTotalValidators)
+		if err != nil {
+			return nil, err
+		}
+
+		validators, err := cm.GetValidators(chainInfo.TotalValidators)
+		if err != nil {
+			return nil, err
+		}
+
+		ranking := rankValidators(validators, time.Now())
+		be.rankings.set(cm.Network(), ranking)
+
+		return ranking, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ranking.(*validatorRanking), nil
+}
+
+// rankValidators ranks the bonded validators, the unbonded ones have no stake to rank.
+func rankValidators(validators []*pactus.ValidatorInfo, now time.Time) *validatorRanking {
+	ranking := &validatorRanking{fetchedAt: now}
+	for _, val := range validators {
+		if val.UnbondingHeight != 0 || val.Stake == 0 {
+			continue
+		}
+
+		ranking.validators = append(ranking.validators, val)
+		ranking.totalStake += val.Stake
+	}
+
+	slices.SortStableFunc(ranking.validators, func(a, b *pactus.ValidatorInfo) int {
+		if c := cmp.Compare(b.Stake, a.Stake); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.AvailabilityScore, a.AvailabilityScore); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Number, b.Number)
+	})
+
+	return ranking
+}
+
+func (be *BotEngine) topValidatorsHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	count := defaultTopValidators
+	if len(args) > 0 && args[0] != "" {
+		var err error
+		count, err = strconv.Atoi(args[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cm, err := be.networkClient(networkOf(args, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	ranking, err := be.validatorRankingOf(cm)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ranking.validators) == 0 {
+		return MakeFailedResult(be.MessageFor(callerID, MsgNoResults)), nil
+	}
+
+	top := ranking.validators[:min(count, len(ranking.validators))]
+	res := MakeSuccessfulResult("The top %d of %s bonded validators, by their stake and availability",
+		len(top), utils.FormatNumber(int64(len(ranking.validators))))
+	res.Title = "Top Validators"
+	res.SetTable("Rank", "Number", "Address", "Stake", "Availability")
+	for i, val := range top {
+		res.AddRow(strconv.Itoa(i+1), fmt.Sprintf("#%d", val.Number), val.Address,
+			utils.FormatNumber(int64(util.ChangeToCoin(val.Stake)))+" PAC", fmt.Sprintf("%.2f", val.AvailabilityScore))
+	}
+
+	res.AddField("Total Stake", utils.FormatNumber(int64(util.ChangeToCoin(ranking.totalStake)))+" PAC", true)
+	res.AddField("Updated", time.Since(ranking.fetchedAt).Round(time.Second).String()+" ago", true)
+	res.Suggest(CommitteeCommandName)
+
+	return res, nil
+}
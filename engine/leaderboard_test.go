@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kehiy/RoboPac/client/mock"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopValidators(t *testing.T) {
+	be, nodes := setupTestEngineWithNodes(t, "localhost:50051")
+	node := nodes[0]
+
+	// the fixture validator n stakes n thousand PAC, and the last one has a low availability score.
+	node.AddValidator(&pactus.ValidatorInfo{
+		Address: mock.ValidatorAddress(5).String(), Number: 5, Stake: 4_000e9, AvailabilityScore: 0.9,
+	})
+	node.AddValidator(&pactus.ValidatorInfo{
+		Address: mock.ValidatorAddress(6).String(), Number: 6, Stake: 5_000e9, UnbondingHeight: 10,
+	})
+	info, err := node.GetBlockchainInfo(context.Background())
+	require.NoError(t, err)
+	info.TotalValidators = 6
+	node.SetBlockchainInfo(info)
+
+	res, err := be.Run(AppIdCLI, "1", []string{TopValidatorsCommandName, "3"})
+	require.NoError(t, err)
+	require.True(t, res.Successful, res.Message)
+
+	assert.Equal(t, "The top 3 of 5 bonded validators, by their stake and availability", res.Message)
+	require.Len(t, res.Table.Rows, 3)
+	assert.Equal(t, []string{"1", "#5", mock.ValidatorAddress(5).String(), "4,000 PAC", "0.90"}, res.Table.Rows[0],
+		"the higher availability wins on equal stakes")
+	assert.Equal(t, "#4", res.Table.Rows[1][1])
+	assert.Equal(t, "#3", res.Table.Rows[2][1])
+	assert.Equal(t, "14,000 PAC", res.Fields[0].Value, "the unbonded validators are not ranked")
+
+	calls := node.Calls("GetValidatorInfoByNumber")
+	res, err = be.Run(AppIdCLI, "1", []string{TopValidatorsCommandName})
+	require.NoError(t, err)
+	assert.Len(t, res.Table.Rows, 5)
+	assert.Equal(t, calls, node.Calls("GetValidatorInfoByNumber"), "the ranking is cached")
+
+	t.Run("invalid count", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{TopValidatorsCommandName, "100"})
+		assert.ErrorContains(t, err, "expected an integer between 1 and 50")
+	})
+}
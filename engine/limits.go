@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+const (
+	defaultMaxArgs      = 10
+	defaultMaxArgLength = 1024
+)
+
+// inputLimits caps the number and the length of the arguments that Run accepts.
+// Discord bounds the inputs itself, but the other front-ends may not.
+// A non-positive limit is not enforced.
+type inputLimits struct {
+	maxArgs      int
+	maxArgLength int
+}
+
+// SetInputLimits configures the maximum number and length of the command arguments.
+func (be *BotEngine) SetInputLimits(maxArgs, maxArgLength int) {
+	be.cmdsLk.Lock()
+	defer be.cmdsLk.Unlock()
+
+	be.limits = inputLimits{
+		maxArgs:      maxArgs,
+		maxArgLength: maxArgLength,
+	}
+}
+
+// checkInputs checks the command name and its arguments against the input limits.
+func (be *BotEngine) checkInputs(msgs *MessageCatalog, inputs []string) error {
+	if len(inputs) == 0 {
+		return errors.New(msgs.Get(MsgUnknownCommand, ""))
+	}
+
+	be.cmdsLk.RLock()
+	limits := be.limits
+	be.cmdsLk.RUnlock()
+
+	if limits.maxArgs > 0 && len(inputs)-1 > limits.maxArgs {
+		return errors.New(msgs.Get(MsgTooManyArgs, len(inputs)-1, limits.maxArgs))
+	}
+
+	if limits.maxArgLength > 0 {
+		for i, input := range inputs {
+			if utf8.RuneCountInString(input) > limits.maxArgLength {
+				return errors.New(msgs.Get(MsgArgTooLong, i, limits.maxArgLength))
+			}
+		}
+	}
+
+	return nil
+}
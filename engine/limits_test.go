@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputLimits(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{
+			Name:    "echo",
+			AppIDs:  []AppID{AppIdCLI},
+			Handler: okHandler,
+			Args:    []Args{{Name: "text", Optional: true}},
+		},
+	)
+	be.SetInputLimits(2, 8)
+
+	t.Run("within the limits", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{"echo", "12345678"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+	})
+
+	t.Run("empty inputs", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{})
+		assert.Error(t, err)
+	})
+
+	t.Run("too many arguments", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{"echo", "a", "b", "c"})
+		require.Error(t, err)
+		assert.Equal(t, "too many arguments: 3, the maximum is 2", err.Error())
+	})
+
+	t.Run("argument too long", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{"echo", "123456789"})
+		require.Error(t, err)
+		assert.Equal(t, "argument 1 is too long, the maximum length is 8 characters", err.Error())
+	})
+
+	t.Run("length is counted in characters", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{"echo", strings.Repeat("🏴", 8)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("disabled limits", func(t *testing.T) {
+		be.SetInputLimits(0, 0)
+		defer be.SetInputLimits(2, 8)
+
+		_, err := be.Run(AppIdCLI, "1", []string{"echo", strings.Repeat("a", 100)})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const defaultPageSize = 10
+
+// PageArgName is the name of the page argument of the list commands.
+const PageArgName = "page"
+
+// ListResult carries a page of a list and its pagination metadata,
+// so every front-end paginates the list commands consistently.
+// Pages are numbered from 1.
+type ListResult struct {
+	Items    []string `json:"items"`
+	Total    int      `json:"total"`
+	Page     int      `json:"page"`
+	PageSize int      `json:"page_size"`
+}
+
+// Paginate returns the requested page of the items.
+// The page is clamped to the valid range.
+func Paginate(items []string, page, pageSize int) *ListResult {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	list := &ListResult{
+		Total:    len(items),
+		PageSize: pageSize,
+	}
+	list.Page = min(max(page, 1), list.TotalPages())
+
+	start := min((list.Page-1)*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+	list.Items = items[start:end]
+
+	return list
+}
+
+// TotalPages returns the number of the pages. An empty list has one empty page.
+func (l *ListResult) TotalPages() int {
+	if l.Total == 0 {
+		return 1
+	}
+
+	return (l.Total + l.PageSize - 1) / l.PageSize
+}
+
+func (l *ListResult) HasNext() bool {
+	return l.Page < l.TotalPages()
+}
+
+func (l *ListResult) HasPrev() bool {
+	return l.Page > 1
+}
+
+// Footer returns the pagination summary of the list, like "Page 1/3 (25 items)".
+func (l *ListResult) Footer() string {
+	return fmt.Sprintf("Page %d/%d (%d items)", l.Page, l.TotalPages(), l.Total)
+}
+
+// MakeListResult makes a successful result of the list.
+// The message only contains the items of the page, the front-ends render the pagination metadata.
+func MakeListResult(list *ListResult) *CommandResult {
+	return &CommandResult{
+		Message:    strings.Join(list.Items, "\n"),
+		Successful: true,
+		List:       list,
+	}
+}
+
+// parsePage parses the optional page argument of the list commands.
+func parsePage(args []string) (int, error) {
+	if len(args) == 0 || args[0] == "" {
+		return 1, nil
+	}
+
+	page, err := strconv.Atoi(args[0])
+	if err != nil || page < 1 {
+		return 0, fmt.Errorf("invalid page number: %s", args[0])
+	}
+
+	return page, nil
+}
+
+// PageInputs returns the inputs of the command, like "committee 2 testnet", to show another page of its list.
+// The skipped optional arguments before the page are passed empty.
+// It returns nil if the command has no page argument.
+func (cmd *Command) PageInputs(inputs []string, page int) []string {
+	index := slices.IndexFunc(cmd.Args, func(arg Args) bool {
+		return arg.Name == PageArgName
+	})
+	if index == -1 || len(inputs) == 0 {
+		return nil
+	}
+
+	// the first input is the name of the command.
+	pageInputs := slices.Clone(inputs)
+	for len(pageInputs) <= index+1 {
+		pageInputs = append(pageInputs, "")
+	}
+	pageInputs[index+1] = strconv.Itoa(page)
+
+	return pageInputs
+}
@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func makeItems(n int) []string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i+1)
+	}
+
+	return items
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		page      int
+		wantPage  int
+		wantItems []string
+		wantPages int
+		wantNext  bool
+		wantPrev  bool
+	}{
+		{"empty list", 0, 1, 1, []string{}, 1, false, false},
+		{"first page", 25, 1, 1, makeItems(10), 3, true, false},
+		{"middle page", 25, 2, 2, makeItems(20)[10:], 3, true, true},
+		{"last page", 25, 3, 3, makeItems(25)[20:], 3, false, true},
+		{"page after the last", 25, 9, 3, makeItems(25)[20:], 3, false, true},
+		{"page before the first", 25, 0, 1, makeItems(10), 3, true, false},
+		{"exact pages", 20, 2, 2, makeItems(20)[10:], 2, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := Paginate(makeItems(tt.total), tt.page, 10)
+
+			assert.Equal(t, tt.total, list.Total)
+			assert.Equal(t, tt.wantPage, list.Page)
+			assert.Equal(t, tt.wantItems, list.Items)
+			assert.Equal(t, tt.wantPages, list.TotalPages())
+			assert.Equal(t, tt.wantNext, list.HasNext())
+			assert.Equal(t, tt.wantPrev, list.HasPrev())
+		})
+	}
+
+	t.Run("default page size", func(t *testing.T) {
+		list := Paginate(makeItems(25), 1, 0)
+		assert.Equal(t, defaultPageSize, list.PageSize)
+	})
+
+	t.Run("footer", func(t *testing.T) {
+		assert.Equal(t, "Page 2/3 (25 items)", Paginate(makeItems(25), 2, 10).Footer())
+	})
+}
+
+func TestPageInputs(t *testing.T) {
+	block := &Command{Name: "block", Args: []Args{
+		{Name: "height"},
+		{Name: PageArgName, Optional: true},
+		{Name: "network", Optional: true},
+	}}
+	assert.Equal(t, []string{"block", "100", "2"}, block.PageInputs([]string{"block", "100"}, 2))
+	assert.Equal(t, []string{"block", "100", "3", "testnet"},
+		block.PageInputs([]string{"block", "100", "2", "testnet"}, 3))
+
+	audit := &Command{Name: "audit-log", Args: []Args{
+		{Name: "kind", Optional: true},
+		{Name: "caller", Optional: true},
+		{Name: PageArgName, Optional: true},
+	}}
+	assert.Equal(t, []string{"audit-log", "", "", "2"}, audit.PageInputs([]string{"audit-log"}, 2),
+		"the skipped arguments are empty")
+
+	assert.Nil(t, (&Command{Name: "me"}).PageInputs([]string{"me"}, 2))
+}
+
+func TestPeersCommand(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+
+	peers := make([]*pactus.PeerInfo, 15)
+	for i := range peers {
+		peers[i] = &pactus.PeerInfo{Moniker: fmt.Sprintf("peer-%d", i+1), Agent: "pactus"}
+	}
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(
+		&pactus.GetNetworkInfoResponse{ConnectedPeers: peers}, nil).AnyTimes()
+
+	t.Run("first page", func(t *testing.T) {
+		res, err := be.peersHandler(AppIdCLI, "")
+		require.NoError(t, err)
+
+		assert.True(t, res.Successful)
+		assert.Equal(t, 15, res.List.Total)
+		assert.Equal(t, 1, res.List.Page)
+		assert.Len(t, res.List.Items, 10)
+		assert.Equal(t, "peer-1 (pactus)", res.List.Items[0])
+	})
+
+	t.Run("second page", func(t *testing.T) {
+		res, err := be.peersHandler(AppIdCLI, "", "2")
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, res.List.Page)
+		assert.Len(t, res.List.Items, 5)
+		assert.False(t, res.List.HasNext())
+	})
+
+	t.Run("invalid page", func(t *testing.T) {
+		res, err := be.peersHandler(AppIdCLI, "", "two")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+}
+
+func TestPeerSearchCommand(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	be.RegisterCommands()
+
+	mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(&pactus.GetNetworkInfoResponse{
+		ConnectedPeers: []*pactus.PeerInfo{
+			{Moniker: "my-pactus-node", ConsensusAddress: []string{"pc1pabc111"}},
+			{Moniker: "other", ConsensusAddress: []string{"pc1pxyz999"}},
+		},
+	}, nil).AnyTimes()
+
+	t.Run("matches", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{PeerSearchCommandName, "pactus"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		require.Equal(t, 1, res.List.Total)
+		assert.Equal(t, "my-pactus-node (moniker)\naddress: pc1pabc111", res.List.Items[0])
+		assert.Equal(t, []string{NodeInfoCommandName}, res.Suggestions)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{PeerSearchCommandName, "nothing"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("short query", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "1", []string{PeerSearchCommandName, "pc"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "at least 3 characters")
+	})
+}
+
+func TestCommitteeCommand(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+
+	committee := make([]*pactus.ValidatorInfo, 12)
+	for i := range committee {
+		committee[i] = &pactus.ValidatorInfo{
+			Number:              int32(i),
+			Address:             fmt.Sprintf("pc1p%d", i),
+			Stake:               1000e9,
+			AvailabilityScore:   0.9,
+			LastSortitionHeight: uint32(100 + i%5),
+		}
+	}
+	committee[5].Stake = 2000e9
+	mockClient.EXPECT().GetBlockchainInfo(gomock.Any()).Return(
+		&pactus.GetBlockchainInfoResponse{CommitteeValidators: committee, CommitteePower: 13000e9}, nil).Times(2)
+
+	res, err := be.committeeHandler(AppIdCLI, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, res.List.Total)
+	assert.Equal(t, 2, res.List.TotalPages())
+	assert.Equal(t, "#5 pc1p5: 2,000 PAC, availability 0.90", res.List.Items[0], "the larger stake first")
+	assert.Equal(t, "#0 pc1p0: 1,000 PAC, availability 0.90", res.List.Items[1])
+	assert.Equal(t, "12", res.Fields[0].Value)
+	assert.Equal(t, "13,000 PAC", res.Fields[1].Value)
+	assert.Equal(t, "#4 pc1p4 at 104", res.Fields[2].Value)
+	assert.Contains(t, res.Warnings[0], CommitteeCommandName+" 2")
+
+	res, err = be.committeeHandler(AppIdCLI, "", "2")
+	require.NoError(t, err)
+	assert.Len(t, res.List.Items, 2)
+	assert.Equal(t, "#11 pc1p11: 1,000 PAC, availability 0.90", res.List.Items[1])
+	assert.Empty(t, res.Warnings)
+}
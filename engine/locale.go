@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/kehiy/RoboPac/store"
+)
+
+// DefaultLocale is the language of the default messages, it's used for the callers without a language.
+const DefaultLocale = "en"
+
+// LoadLocales loads the translated messages from the JSON files of the file system, named by the locale like "es.json".
+// The messages that are not translated fall back to the default messages. Only the messages of the catalog
+// are translated, the results of the command handlers are in English.
+func (be *BotEngine) LoadLocales(fsys fs.FS) error {
+	paths, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return err
+	}
+
+	locales := make(map[string]*MessageCatalog, len(paths))
+	for _, path := range paths {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+
+		catalog := newLocaleCatalog(be.messages)
+		if err := catalog.Load(data); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		locales[strings.TrimSuffix(path, ".json")] = catalog
+	}
+
+	be.cmdsLk.Lock()
+	defer be.cmdsLk.Unlock()
+
+	be.locales = locales
+
+	return nil
+}
+
+// Locales returns the available languages, the default one first.
+func (be *BotEngine) Locales() []string {
+	be.cmdsLk.RLock()
+	defer be.cmdsLk.RUnlock()
+
+	locales := make([]string, 0, len(be.locales))
+	for locale := range be.locales {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+
+	return append([]string{DefaultLocale}, locales...)
+}
+
+// MessagesFor returns the message catalog in the language of the caller.
+func (be *BotEngine) MessagesFor(callerID string) *MessageCatalog {
+	be.cmdsLk.RLock()
+	locales := be.locales
+	be.cmdsLk.RUnlock()
+
+	// without any translation, the preferences of the caller are not looked up.
+	if len(locales) == 0 || be.store == nil || callerID == "" {
+		return be.messages
+	}
+
+	prefs := be.store.UserPrefs(callerID)
+	if prefs == nil {
+		return be.messages
+	}
+
+	if catalog, ok := locales[prefs.Locale]; ok {
+		return catalog
+	}
+
+	return be.messages
+}
+
+// MessageFor returns a formatted message in the language of the caller.
+func (be *BotEngine) MessageFor(callerID string, key MessageKey, a ...interface{}) string {
+	return be.MessagesFor(callerID).Get(key, a...)
+}
+
+func (be *BotEngine) setLanguageHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	locale := args[0]
+
+	prefs := be.store.UserPrefs(callerID)
+	if prefs == nil {
+		prefs = &store.UserPrefs{DiscordID: callerID}
+	}
+	prefs.Locale = locale
+	if locale == DefaultLocale {
+		prefs.Locale = ""
+	}
+
+	if err := be.store.SaveUserPrefs(prefs); err != nil {
+		return nil, err
+	}
+
+	return MakeSuccessfulResult(be.MessageFor(callerID, MsgLanguageSet)), nil
+}
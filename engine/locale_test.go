@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"encoding/json"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/kehiy/RoboPac/locales"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLocales(t *testing.T) {
+	be := setupTestEngine(t)
+	mockStore := store.NewMockIStore(gomock.NewController(t))
+	be.store = mockStore
+
+	prefs := map[string]*store.UserPrefs{}
+	mockStore.EXPECT().UserPrefs(gomock.Any()).DoAndReturn(func(id string) *store.UserPrefs {
+		return prefs[id]
+	}).AnyTimes()
+	mockStore.EXPECT().SaveUserPrefs(gomock.Any()).DoAndReturn(func(p *store.UserPrefs) error {
+		prefs[p.DiscordID] = p
+
+		return nil
+	}).AnyTimes()
+
+	t.Run("not registered without translations", func(t *testing.T) {
+		be.RegisterCommands()
+		assert.Nil(t, be.FindCommand(SetLanguageCommandName))
+		assert.Equal(t, be.messages, be.MessagesFor("123"))
+	})
+
+	require.NoError(t, be.LoadLocales(fstest.MapFS{
+		"es.json": {Data: []byte(`{"unknown_command": "comando desconocido: %s", "language_set": "hola"}`)},
+	}))
+	be.Cmds = nil
+	be.RegisterCommands()
+	assert.Equal(t, []string{"en", "es"}, be.Locales())
+
+	t.Run("set the language", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "123", []string{SetLanguageCommandName, "es"})
+		require.NoError(t, err)
+		assert.Equal(t, "hola", res.Message)
+		assert.Equal(t, "es", prefs["123"].Locale)
+
+		_, err = be.Run(AppIdDiscord, "123", []string{"unknown"})
+		assert.EqualError(t, err, "comando desconocido: unknown")
+
+		// the other callers and the untranslated messages are in the default language.
+		_, err = be.Run(AppIdDiscord, "456", []string{"unknown"})
+		assert.EqualError(t, err, "unknown command: unknown")
+		assert.Equal(t, "No results found", be.MessageFor("123", MsgNoResults))
+	})
+
+	t.Run("unknown language", func(t *testing.T) {
+		_, err := be.Run(AppIdDiscord, "123", []string{SetLanguageCommandName, "xx"})
+		assert.Error(t, err)
+	})
+
+	t.Run("back to the default language", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "123", []string{SetLanguageCommandName, "en"})
+		require.NoError(t, err)
+		assert.Equal(t, "The bot speaks English to you now.", res.Message)
+		assert.Empty(t, prefs["123"].Locale)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		err := be.LoadLocales(fstest.MapFS{"de.json": {Data: []byte(`{`)}})
+		assert.ErrorContains(t, err, "de.json")
+	})
+}
+
+// TestBuiltinLocales checks that the translations have all the keys and the same verbs as the default messages.
+func TestBuiltinLocales(t *testing.T) {
+	paths, err := fs.Glob(locales.FS, "*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		data, err := fs.ReadFile(locales.FS, path)
+		require.NoError(t, err)
+
+		messages := map[MessageKey]string{}
+		require.NoError(t, json.Unmarshal(data, &messages), path)
+
+		for key := range defaultMessages {
+			assert.Contains(t, messages, key, "%s: missing key %s", path, key)
+		}
+
+		for key, msg := range messages {
+			def, ok := defaultMessages[key]
+			if assert.True(t, ok, "%s: unknown key %s", path, key) {
+				assert.Equal(t, strings.Count(def, "%"), strings.Count(msg, "%"), "%s: %s", path, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/kehiy/RoboPac/log"
+)
+
+// completeLogModule suggests the modules of the sub loggers, after the default module.
+func completeLogModule(_, prefix string) []string {
+	return withPrefix(append([]string{log.DefaultModule}, log.Modules()...), prefix)
+}
+
+// setLogLevelHandler changes the level of a module, or the default level if the module is empty,
+// then lists the levels of all the modules. The levels are not saved, they are reset by a restart or a reload.
+func (be *BotEngine) setLogLevelHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	module := log.DefaultModule
+	if len(args) > 1 && args[1] != "" {
+		module = args[1]
+	}
+
+	if err := log.SetLevel(module, args[0]); err != nil {
+		return MakeFailedResult("%s", err.Error()), nil
+	}
+	be.logger.Info("log level changed", "module", module, "level", args[0], "callerID", callerID)
+
+	levels := log.ModuleLevels()
+	modules := make([]string, 0, len(levels))
+	for name := range levels {
+		if name != log.DefaultModule {
+			modules = append(modules, name)
+		}
+	}
+	sort.Strings(modules)
+
+	res := MakeSuccessfulResult("The log level of `%s` is changed to %s", module, levels[module])
+	res.Title = "Log Levels"
+	res.SetTable("Module", "Level")
+	for _, name := range append([]string{log.DefaultModule}, modules...) {
+		res.AddRow(name, levels[name])
+	}
+
+	return res, nil
+}
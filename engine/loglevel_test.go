@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/kehiy/RoboPac/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	be, _ := setupTestEngineWithNodes(t, "node-a:50051")
+	be.AuthIDs = []string{"admin"}
+	t.Cleanup(func() {
+		require.NoError(t, log.SetLevels(map[string]string{log.DefaultModule: "info"}))
+	})
+
+	t.Run("not admin", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "user", []string{SetLogLevelCommandName, "debug"})
+		assert.Error(t, err)
+	})
+
+	t.Run("module", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{SetLogLevelCommandName, "trace", "test"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Contains(t, res.Table.Rows, []string{"test", "trace"})
+		assert.Equal(t, "trace", log.ModuleLevels()["test"])
+	})
+
+	t.Run("default", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{SetLogLevelCommandName, "warn"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, []string{log.DefaultModule, "warn"}, res.Table.Rows[0])
+		assert.Contains(t, res.Table.Rows, []string{"test", "trace"})
+	})
+
+	t.Run("unknown module", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{SetLogLevelCommandName, "debug", "unknown"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("autocomplete", func(t *testing.T) {
+		assert.Equal(t, []string{log.DefaultModule}, be.Autocomplete("admin", []string{SetLogLevelCommandName}, "module", "def"))
+		assert.Contains(t, be.Autocomplete("admin", []string{SetLogLevelCommandName}, "module", "te"), "test")
+	})
+}
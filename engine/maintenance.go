@@ -0,0 +1,55 @@
+package engine
+
+import "sync"
+
+// maintenanceMode rejects the commands during a planned downtime.
+// The admins can still run all the commands, so they can check the bot and turn the maintenance off.
+type maintenanceMode struct {
+	lk sync.RWMutex
+
+	enabled bool
+	reason  string
+}
+
+func (m *maintenanceMode) set(enabled bool, reason string) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	m.enabled = enabled
+	m.reason = reason
+	if !enabled {
+		m.reason = ""
+	}
+}
+
+func (m *maintenanceMode) get() (bool, string) {
+	m.lk.RLock()
+	defer m.lk.RUnlock()
+
+	return m.enabled, m.reason
+}
+
+// SetMaintenance turns the maintenance mode on or off.
+// The reason is shown to the users while the maintenance mode is on.
+func (be *BotEngine) SetMaintenance(enabled bool, reason string) {
+	be.maintenance.set(enabled, reason)
+}
+
+// Maintenance reports whether the maintenance mode is on, and its reason.
+func (be *BotEngine) Maintenance() (bool, string) {
+	return be.maintenance.get()
+}
+
+// MakeMaintenanceResult makes the result of a command rejected by the maintenance mode, with the messages of the catalog.
+func (be *BotEngine) MakeMaintenanceResult(msgs *MessageCatalog, reason string) *CommandResult {
+	msg := msgs.Get(MsgMaintenance)
+	if reason != "" {
+		msg += "\n" + msgs.Get(MsgMaintenanceReason, reason)
+	}
+
+	return &CommandResult{
+		Message:     msg,
+		Successful:  false,
+		Maintenance: true,
+	}
+}
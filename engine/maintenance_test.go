@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenance(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "cmd", AppIDs: []AppID{AppIdCLI}, Handler: okHandler},
+	)
+	be.AuthIDs = []string{"admin"}
+	be.Cmds = append(be.Cmds, Command{
+		Name:    MaintenanceCommandName,
+		AppIDs:  []AppID{AppIdCLI},
+		Handler: be.maintenanceHandler,
+		MinRole: RoleAdmin,
+		Args: []Args{
+			{Name: "state", Choices: []string{"on", "off"}},
+			{Name: "reason", Optional: true},
+		},
+	})
+
+	t.Run("only admins can toggle", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "user", []string{MaintenanceCommandName, "on"})
+		assert.Error(t, err)
+
+		enabled, _ := be.Maintenance()
+		assert.False(t, enabled)
+	})
+
+	t.Run("users are rejected during the maintenance", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{MaintenanceCommandName, "on", "node upgrade"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		res, err = be.Run(AppIdCLI, "user", []string{"cmd"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.True(t, res.Maintenance)
+		assert.Contains(t, res.Message, "Reason: node upgrade")
+	})
+
+	t.Run("admins bypass the maintenance", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{"cmd"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.False(t, res.Maintenance)
+	})
+
+	t.Run("turned off", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "admin", []string{MaintenanceCommandName, "off"})
+		require.NoError(t, err)
+
+		enabled, reason := be.Maintenance()
+		assert.False(t, enabled)
+		assert.Empty(t, reason)
+
+		res, err := be.Run(AppIdCLI, "user", []string{"cmd"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+	})
+}
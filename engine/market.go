@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kehiy/RoboPac/market"
+	"github.com/kehiy/RoboPac/utils"
+	"github.com/pactus-project/pactus/util"
+)
+
+const (
+	marketTimeout = 15 * time.Second
+	// staleMarketData is the age that the market data is shown with a warning.
+	staleMarketData = time.Hour
+)
+
+// ErrMarketDisabled is returned when no market data source is set in the config.
+var ErrMarketDisabled = errors.New("market data is not enabled")
+
+// MarketPrice returns the PAC market data, if the market data is enabled.
+func (be *BotEngine) MarketPrice() (*market.Price, error) {
+	if be.market == nil {
+		return nil, ErrMarketDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(be.ctx, marketTimeout)
+	defer cancel()
+
+	return be.market.Price(ctx)
+}
+
+func (be *BotEngine) priceHandler(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+	price, err := be.MarketPrice()
+	if err != nil {
+		return nil, err
+	}
+
+	res := MakeSuccessfulResult("1 PAC = %s", FormatUSD(price.USD))
+	res.Title = "PAC Price"
+	res.AddField("Price", FormatUSD(price.USD), true)
+	res.AddField("24h Change", fmt.Sprintf("%+.2f%%", price.Change24h), true)
+	res.AddField("24h Volume", "$"+utils.FormatNumber(int64(price.Volume24h)), true)
+
+	// the market cap is calculated by the circulating supply, if the source doesn't provide it.
+	marketCap := "not available"
+	if price.MarketCap > 0 {
+		marketCap = "$" + utils.FormatNumber(int64(price.MarketCap))
+	} else if supply, err := be.clientMgr.GetCirculatingSupply(); err == nil {
+		marketCap = "$" + utils.FormatNumber(int64(util.ChangeToCoin(supply)*price.USD))
+	} else {
+		be.logger.Warn("unable to get the circulating supply", "err", err)
+	}
+	res.AddField("Market Cap", marketCap, true)
+	res.AddField("Source", price.Source, true)
+
+	if age := time.Since(price.UpdatedAt); age > staleMarketData {
+		res.AddWarning("The market data is from %s ago", age.Round(time.Minute))
+	}
+
+	return res, nil
+}
+
+// FormatUSD formats the price in USD, with more decimals for the small prices.
+func FormatUSD(price float64) string {
+	if price < 1 {
+		return fmt.Sprintf("$%.4f", price)
+	}
+
+	return fmt.Sprintf("$%.2f", price)
+}
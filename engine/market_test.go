@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakePriceSource struct {
+	price *market.Price
+}
+
+func (*fakePriceSource) Name() string {
+	return "fake"
+}
+
+func (s *fakePriceSource) Fetch(_ context.Context) (*market.Price, error) {
+	return s.price, nil
+}
+
+func TestPriceCommand(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+
+	t.Run("not registered if disabled", func(t *testing.T) {
+		be.RegisterCommands()
+
+		_, err := be.Run(AppIdCLI, "123", []string{PriceCommandName})
+		assert.Error(t, err)
+
+		_, err = be.MarketPrice()
+		assert.ErrorIs(t, err, ErrMarketDisabled)
+	})
+
+	source := &fakePriceSource{price: &market.Price{
+		Source:    "fake",
+		USD:       0.35,
+		Change24h: -2.5,
+		Volume24h: 12345.6,
+		MarketCap: 5_000_000,
+		UpdatedAt: time.Now(),
+	}}
+	be.Cmds = nil
+	be.market = market.NewMarket([]market.Source{source}, 0)
+	be.RegisterCommands()
+
+	t.Run("price", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "123", []string{PriceCommandName})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "PAC Price", res.Title)
+		assert.Contains(t, res.Message, "$0.3500")
+		assert.Equal(t, []ResultField{
+			{Name: "Price", Value: "$0.3500", Inline: true},
+			{Name: "24h Change", Value: "-2.50%", Inline: true},
+			{Name: "24h Volume", Value: "$12,345", Inline: true},
+			{Name: "Market Cap", Value: "$5,000,000", Inline: true},
+			{Name: "Source", Value: "fake", Inline: true},
+		}, res.Fields)
+		assert.Empty(t, res.Warnings)
+	})
+
+	t.Run("stale data without market cap", func(t *testing.T) {
+		source.price.MarketCap = 0
+		source.price.UpdatedAt = time.Now().Add(-3 * time.Hour)
+		mockClient.EXPECT().GetBlockchainInfo(gomock.Any()).Return(nil, errors.New("unavailable")).AnyTimes()
+
+		res, err := be.Run(AppIdCLI, "123", []string{PriceCommandName})
+		require.NoError(t, err)
+		assert.Equal(t, ResultField{Name: "Market Cap", Value: "not available", Inline: true}, res.Fields[3])
+		require.Len(t, res.Warnings, 1)
+		assert.Contains(t, res.Warnings[0], "3h0m0s ago")
+	})
+}
+
+func TestFormatUSD(t *testing.T) {
+	assert.Equal(t, "$0.0012", FormatUSD(0.00123))
+	assert.Equal(t, "$1.50", FormatUSD(1.5))
+}
@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+type MessageKey string
+
+const (
+	MsgUnknownCommand         MessageKey = "unknown_command"
+	MsgUnknownSubCommand      MessageKey = "unknown_subcommand"
+	MsgUnauthorizedApp        MessageKey = "unauthorized_app"
+	MsgCommandNotAllowed      MessageKey = "command_not_allowed"
+	MsgUnauthorized           MessageKey = "unauthorized"
+	MsgCommandDisabled        MessageKey = "command_disabled"
+	MsgTooManyArgs            MessageKey = "too_many_args"
+	MsgArgTooLong             MessageKey = "arg_too_long"
+	MsgTemporarilyUnavailable MessageKey = "temporarily_unavailable"
+	MsgNetworkUnavailable     MessageKey = "network_unavailable"
+	MsgNodeUnavailable        MessageKey = "node_unavailable"
+	MsgNodeLastReached        MessageKey = "node_last_reached"
+	MsgStaleResult            MessageKey = "stale_result"
+	MsgMaintenance            MessageKey = "maintenance"
+	MsgMaintenanceReason      MessageKey = "maintenance_reason"
+	MsgGuildCommandDisabled   MessageKey = "guild_command_disabled"
+	MsgDMOnly                 MessageKey = "dm_only"
+	MsgCooldown               MessageKey = "cooldown"
+	MsgBanned                 MessageKey = "banned"
+	MsgThrottled              MessageKey = "throttled"
+	MsgAccountTooNew          MessageKey = "account_too_new"
+	MsgMemberTooNew           MessageKey = "member_too_new"
+	MsgNoResults              MessageKey = "no_results"
+	MsgErrorFallback          MessageKey = "error_fallback"
+	MsgStillWorking           MessageKey = "still_working"
+	MsgTitleSuccessful        MessageKey = "title_successful"
+	MsgTitleFailed            MessageKey = "title_failed"
+	MsgTitleError             MessageKey = "title_error"
+	MsgTitleMaintenance       MessageKey = "title_maintenance"
+	MsgTitlePending           MessageKey = "title_pending"
+	MsgLanguageSet            MessageKey = "language_set"
+)
+
+var defaultMessages = map[MessageKey]string{
+	MsgUnknownCommand:         "unknown command: %s",
+	MsgUnknownSubCommand:      "command %s expects one of the subcommands: %s",
+	MsgUnauthorizedApp:        "unauthorized appID: %v",
+	MsgCommandNotAllowed:      "command %s is not available on %v",
+	MsgUnauthorized:           "unauthorized person",
+	MsgCommandDisabled:        "command %s is disabled",
+	MsgTooManyArgs:            "too many arguments: %d, the maximum is %d",
+	MsgArgTooLong:             "argument %d is too long, the maximum length is %d characters",
+	MsgTemporarilyUnavailable: "command %s is temporarily unavailable, please try again later",
+	MsgNetworkUnavailable:     "The network is unavailable right now, please try again later.",
+	MsgNodeUnavailable:        "node unavailable, please try again later",
+	MsgNodeLastReached:        "The node was last reached %v ago.",
+	MsgStaleResult:            "The network is unavailable, this is the last known result from %v ago.",
+	MsgMaintenance:            "The bot is under planned maintenance, please try again later.",
+	MsgMaintenanceReason:      "Reason: %s",
+	MsgGuildCommandDisabled:   "Arr, the `/%s` command be disabled here, matey!",
+	MsgDMOnly:                 "Send a message in a bottle, ye say? Cast it into me DMs, and I'll be at yer service!",
+	MsgCooldown:               "Slow down, matey! Try again in %v.",
+	MsgBanned:                 "Ye have been banned from using the bot, matey.",
+	MsgThrottled:              "Too many commands, matey! Ye can use the bot again in %v.",
+	MsgAccountTooNew:          "Yer Discord account must be at least %s old to use `/%s`, matey!",
+	MsgMemberTooNew:           "Ye must be aboard this server for at least %s to use `/%s`, matey!",
+	MsgNoResults:              "No results found",
+	MsgErrorFallback:          "Something went wrong, please try again later",
+	MsgStillWorking:           "This is taking longer than usual, the result will be shown here once it's ready.",
+	MsgTitleSuccessful:        "Successful",
+	MsgTitleFailed:            "Failed",
+	MsgTitleError:             "Error",
+	MsgTitleMaintenance:       "Maintenance",
+	MsgTitlePending:           "Working on it",
+	MsgLanguageSet:            "The bot speaks English to you now.",
+}
+
+// MessageCatalog holds the generic messages of the bot.
+// Deployments can override the default messages to reword or translate them.
+type MessageCatalog struct {
+	lk       sync.RWMutex
+	messages map[MessageKey]string
+	// fallback has the messages that are not in the catalog, like the untranslated messages of a locale.
+	fallback *MessageCatalog
+}
+
+func NewMessageCatalog() *MessageCatalog {
+	messages := make(map[MessageKey]string, len(defaultMessages))
+	for key, msg := range defaultMessages {
+		messages[key] = msg
+	}
+
+	return &MessageCatalog{
+		messages: messages,
+	}
+}
+
+// newLocaleCatalog creates an empty catalog for the translated messages of a locale.
+func newLocaleCatalog(fallback *MessageCatalog) *MessageCatalog {
+	return &MessageCatalog{
+		messages: make(map[MessageKey]string),
+		fallback: fallback,
+	}
+}
+
+// Set overrides the message of the key.
+func (mc *MessageCatalog) Set(key MessageKey, msg string) {
+	mc.lk.Lock()
+	defer mc.lk.Unlock()
+
+	mc.messages[key] = msg
+}
+
+// Get formats the message of the key with the given arguments.
+// The key itself is returned if there is no message for it.
+func (mc *MessageCatalog) Get(key MessageKey, a ...interface{}) string {
+	mc.lk.RLock()
+	msg, ok := mc.messages[key]
+	mc.lk.RUnlock()
+
+	if !ok {
+		if mc.fallback != nil {
+			return mc.fallback.Get(key, a...)
+		}
+
+		return string(key)
+	}
+
+	if len(a) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf(msg, a...)
+}
+
+// LoadFile overrides the messages with a JSON file of key/message pairs.
+func (mc *MessageCatalog) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return mc.Load(data)
+}
+
+// Load overrides the messages with the JSON data of key/message pairs.
+func (mc *MessageCatalog) Load(data []byte) error {
+	overrides := map[MessageKey]string{}
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return fmt.Errorf("invalid messages file: %w", err)
+	}
+
+	for key, msg := range overrides {
+		mc.Set(key, msg)
+	}
+
+	return nil
+}
+
+// replace replaces the messages of the catalog with the messages of the other catalog.
+func (mc *MessageCatalog) replace(other *MessageCatalog) {
+	other.lk.RLock()
+	messages := make(map[MessageKey]string, len(other.messages))
+	for key, msg := range other.messages {
+		messages[key] = msg
+	}
+	other.lk.RUnlock()
+
+	mc.lk.Lock()
+	defer mc.lk.Unlock()
+
+	mc.messages = messages
+}
+
+// Messages returns the message catalog of the engine.
+func (be *BotEngine) Messages() *MessageCatalog {
+	return be.messages
+}
+
+// Message returns a formatted message from the message catalog.
+func (be *BotEngine) Message(key MessageKey, a ...interface{}) string {
+	return be.messages.Get(key, a...)
+}
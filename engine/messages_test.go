@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageCatalog(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		mc := NewMessageCatalog()
+
+		assert.Equal(t, "unknown command: foo", mc.Get(MsgUnknownCommand, "foo"))
+		assert.Equal(t, "unauthorized person", mc.Get(MsgUnauthorized))
+		assert.Equal(t, "not-exists", mc.Get(MessageKey("not-exists")))
+	})
+
+	t.Run("override", func(t *testing.T) {
+		be := setupTestEngine(t)
+		be.Messages().Set(MsgUnknownCommand, "no such command: %s")
+
+		_, err := be.Run(AppIdCLI, "1", []string{"foo"})
+		assert.EqualError(t, err, "no such command: foo")
+
+		// other catalogs keep the defaults.
+		assert.Equal(t, "unknown command: foo", NewMessageCatalog().Get(MsgUnknownCommand, "foo"))
+	})
+
+	t.Run("load file", func(t *testing.T) {
+		filePath := path.Join(t.TempDir(), "messages.json")
+		err := os.WriteFile(filePath, []byte(`{"no_results": "Nothing here"}`), 0o600)
+		require.NoError(t, err)
+
+		mc := NewMessageCatalog()
+		require.NoError(t, mc.LoadFile(filePath))
+
+		assert.Equal(t, "Nothing here", mc.Get(MsgNoResults))
+		assert.Equal(t, "unauthorized person", mc.Get(MsgUnauthorized))
+	})
+}
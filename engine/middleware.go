@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"errors"
+	"runtime/debug"
+	"time"
+
+	"github.com/kehiy/RoboPac/audit"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+)
+
+// Middleware wraps the handler of the command, it can short-circuit the execution by returning a result.
+type Middleware func(cmd *Command, next CommandHandler) CommandHandler
+
+// execution is the context of one run of a command, which the built-in middlewares need.
+type execution struct {
+	role   Role
+	msgs   *MessageCatalog
+	logger *log.SubLogger
+	inputs []string
+}
+
+// Use adds the middlewares, they wrap the handlers in order, so the first one runs first.
+// The built-in middlewares always run before the added ones, in this order:
+// audit logging, panic recovery, bans and throttling, auth checks, maintenance mode and rate limits.
+func (be *BotEngine) Use(mws ...Middleware) {
+	be.cmdsLk.Lock()
+	defer be.cmdsLk.Unlock()
+
+	be.middlewares = append(be.middlewares, mws...)
+}
+
+// handlerOf returns the handler of the command wrapped by the middlewares.
+// The innermost handler checks the circuit breaker of the node and runs the command handler.
+func (be *BotEngine) handlerOf(cmd *Command, exec *execution) CommandHandler {
+	mws := []Middleware{
+		be.auditMiddleware(exec),
+		recoveryMiddleware(exec),
+		be.moderationMiddleware(exec),
+		authMiddleware(exec),
+		be.maintenanceMiddleware(exec),
+		be.rateLimitMiddleware,
+	}
+
+	be.cmdsLk.RLock()
+	mws = append(mws, be.middlewares...)
+	be.cmdsLk.RUnlock()
+
+	handler := be.nodeBreakerHandler(cmd, exec)
+	for i := len(mws) - 1; i >= 0; i-- {
+		handler = mws[i](cmd, handler)
+	}
+
+	return handler
+}
+
+// auditMiddleware logs the executions of the commands, with their callers and durations.
+// The executions of the admin commands are recorded in the audit log too, even if they are unauthorized.
+func (be *BotEngine) auditMiddleware(exec *execution) Middleware {
+	return func(cmd *Command, next CommandHandler) CommandHandler {
+		return func(source AppID, callerID string, args ...string) (*CommandResult, error) {
+			start := time.Now()
+			res, err := next(source, callerID, args...)
+
+			keyvals := []any{
+				"command", cmd.Name, "appID", source, "callerID", callerID,
+				"role", exec.role, "duration", time.Since(start),
+			}
+			if err != nil {
+				exec.logger.Warn("command failed", append(keyvals, "error", err)...)
+			} else {
+				exec.logger.Info("command executed", append(keyvals, "successful", res != nil && res.Successful)...)
+			}
+
+			if be.events != nil {
+				be.events.publish(EventCommand, CommandExecution{
+					Command:    cmd.Name,
+					AppID:      source.String(),
+					Successful: err == nil && res != nil && res.Successful,
+					DurationMs: time.Since(start).Milliseconds(),
+				})
+			}
+
+			if cmd.MinRole >= RoleAdmin {
+				be.recordAudit(audit.Entry{
+					Kind:     audit.KindAdminCommand,
+					AppID:    source.String(),
+					CallerID: callerID,
+					Inputs:   exec.inputs,
+					Error:    auditError(res, err),
+				})
+			}
+
+			return res, err
+		}
+	}
+}
+
+// recoveryMiddleware turns the panics of the handlers into the generic error,
+// so one broken command doesn't bring the apps down.
+func recoveryMiddleware(exec *execution) Middleware {
+	return func(cmd *Command, next CommandHandler) CommandHandler {
+		return func(source AppID, callerID string, args ...string) (res *CommandResult, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					metrics.EngineErrorsTotal.Inc(cmd.Name)
+					exec.logger.Error("command panicked", "command", cmd.Name, "panic", r, "stack", string(debug.Stack()))
+
+					res, err = nil, errors.New(exec.msgs.Get(MsgErrorFallback))
+				}
+			}()
+
+			return next(source, callerID, args...)
+		}
+	}
+}
+
+// authMiddleware checks the role of the caller against the minimum role of the command.
+func authMiddleware(exec *execution) Middleware {
+	return func(cmd *Command, next CommandHandler) CommandHandler {
+		if cmd.MinRole <= RoleUser {
+			return next
+		}
+
+		return func(source AppID, callerID string, args ...string) (*CommandResult, error) {
+			if exec.role < cmd.MinRole {
+				return nil, errors.New(exec.msgs.Get(MsgUnauthorized))
+			}
+
+			return next(source, callerID, args...)
+		}
+	}
+}
+
+// maintenanceMiddleware answers the non-admin callers by the maintenance result, if it's enabled.
+func (be *BotEngine) maintenanceMiddleware(exec *execution) Middleware {
+	return func(_ *Command, next CommandHandler) CommandHandler {
+		return func(source AppID, callerID string, args ...string) (*CommandResult, error) {
+			if enabled, reason := be.Maintenance(); enabled && exec.role < RoleAdmin {
+				return be.MakeMaintenanceResult(exec.msgs, reason), nil
+			}
+
+			return next(source, callerID, args...)
+		}
+	}
+}
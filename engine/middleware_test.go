@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	be := setupTestEngine(t, Command{Name: "ok", AppIDs: []AppID{AppIdCLI}, Handler: okHandler})
+
+	order := []string{}
+	mw := func(name string) Middleware {
+		return func(cmd *Command, next CommandHandler) CommandHandler {
+			return func(source AppID, callerID string, args ...string) (*CommandResult, error) {
+				order = append(order, name+":"+cmd.Name)
+
+				return next(source, callerID, args...)
+			}
+		}
+	}
+	be.Use(mw("first"), mw("second"))
+
+	_, err := be.Run(AppIdCLI, "1", []string{"ok"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first:ok", "second:ok"}, order)
+
+	t.Run("short-circuit", func(t *testing.T) {
+		be.Use(func(_ *Command, _ CommandHandler) CommandHandler {
+			return func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+				return MakeFailedResult("blocked"), nil
+			}
+		})
+
+		res, err := be.Run(AppIdCLI, "1", []string{"ok"})
+		require.NoError(t, err)
+		assert.Equal(t, "blocked", res.Message)
+	})
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{
+			Name:   "panicky",
+			AppIDs: []AppID{AppIdCLI},
+			Handler: func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+				panic("nil map")
+			},
+		},
+		Command{Name: "ok", AppIDs: []AppID{AppIdCLI}, Handler: okHandler},
+	)
+
+	res, err := be.Run(AppIdCLI, "1", []string{"panicky"})
+	assert.Nil(t, res)
+	assert.EqualError(t, err, be.Message(MsgErrorFallback))
+	assert.Equal(t, float64(1), metrics.EngineErrorsTotal.Value("panicky"))
+
+	res, err = be.Run(AppIdCLI, "1", []string{"ok"})
+	require.NoError(t, err)
+	assert.True(t, res.Successful)
+}
+
+func TestBuiltInMiddlewaresRunFirst(t *testing.T) {
+	be := setupTestEngine(t, Command{Name: "admin-cmd", AppIDs: []AppID{AppIdCLI}, Handler: okHandler, MinRole: RoleAdmin})
+
+	calls := 0
+	be.Use(func(_ *Command, next CommandHandler) CommandHandler {
+		return func(source AppID, callerID string, args ...string) (*CommandResult, error) {
+			calls++
+
+			return next(source, callerID, args...)
+		}
+	})
+
+	_, err := be.Run(AppIdCLI, "user", []string{"admin-cmd"})
+	assert.EqualError(t, err, be.Message(MsgUnauthorized))
+	assert.Zero(t, calls, "the unauthorized callers don't reach the added middlewares")
+
+	be.SetMaintenance(true, "upgrading")
+	defer be.SetMaintenance(false, "")
+
+	res, err := be.RunWithOptions(RunOptions{Role: RoleAdmin}, AppIdCLI, "admin", []string{"admin-cmd"})
+	require.NoError(t, err)
+	assert.True(t, res.Successful, "the admins bypass the maintenance mode")
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,284 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/store"
+)
+
+// Ban is a ban of a user by an admin, the banned users can't run any command.
+type Ban struct {
+	UserID   string `json:"user_id"`
+	Reason   string `json:"reason,omitempty"`
+	BannedBy string `json:"banned_by"`
+	Time     int64  `json:"time"`
+	// Until is the unix time that the ban expires at, zero means it's permanent.
+	Until int64 `json:"until,omitempty"`
+}
+
+// Active reports whether the ban is in effect at the time.
+func (b *Ban) Active(now time.Time) bool {
+	return b.Until == 0 || now.Unix() < b.Until
+}
+
+// moderation keeps the bans and throttles the users that send bursts of the commands.
+// The zero value is ready to use, it keeps the bans in memory only and doesn't throttle.
+type moderation struct {
+	lk sync.Mutex
+
+	kv   store.KV
+	bans map[string]*Ban
+
+	burstLimit  int
+	burstWindow time.Duration
+	throttle    time.Duration
+	requests    map[string][]time.Time
+	throttled   map[string]time.Time
+}
+
+// enableModeration loads the bans from the storage, they are saved there from now on.
+func (be *BotEngine) enableModeration(cfg *config.Config) error {
+	kv, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "user_bans")
+	if err != nil {
+		return err
+	}
+
+	return be.moderation.load(kv)
+}
+
+func (m *moderation) load(kv store.KV) error {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	bans := make(map[string]*Ban)
+	var decodeErr error
+	err := kv.Iterate(func(key string, value []byte) bool {
+		ban := &Ban{}
+		if err := json.Unmarshal(value, ban); err != nil {
+			decodeErr = fmt.Errorf("ban of %s: %w", key, err)
+
+			return false
+		}
+		bans[key] = ban
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	m.kv = kv
+	m.bans = bans
+
+	return nil
+}
+
+// SetBurstLimit throttles the users that run more than limit commands in the window, for the throttle duration.
+// A non-positive limit disables the throttling.
+func (be *BotEngine) SetBurstLimit(limit int, window, throttle time.Duration) {
+	be.moderation.lk.Lock()
+	defer be.moderation.lk.Unlock()
+
+	be.moderation.burstLimit = limit
+	be.moderation.burstWindow = window
+	be.moderation.throttle = throttle
+}
+
+// BanUser saves the ban, it replaces the previous ban of the user.
+func (be *BotEngine) BanUser(ban Ban) error {
+	m := &be.moderation
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	if m.kv != nil {
+		data, err := json.Marshal(ban)
+		if err != nil {
+			return err
+		}
+		if err := m.kv.Set(ban.UserID, data); err != nil {
+			return err
+		}
+	}
+
+	if m.bans == nil {
+		m.bans = make(map[string]*Ban)
+	}
+	m.bans[ban.UserID] = &ban
+
+	return nil
+}
+
+// UnbanUser lifts the ban of the user, it returns false if the user is not banned.
+func (be *BotEngine) UnbanUser(userID string) (bool, error) {
+	m := &be.moderation
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	ban, ok := m.bans[userID]
+	if !ok {
+		return false, nil
+	}
+
+	if m.kv != nil {
+		if err := m.kv.Delete(userID); err != nil {
+			return false, err
+		}
+	}
+	delete(m.bans, userID)
+
+	return ban.Active(time.Now()), nil
+}
+
+// BanOf returns the active ban of the user, or nil if the user is not banned.
+func (be *BotEngine) BanOf(userID string) *Ban {
+	m := &be.moderation
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	ban, ok := m.bans[userID]
+	if !ok || !ban.Active(time.Now()) {
+		return nil
+	}
+	copied := *ban
+
+	return &copied
+}
+
+// take records a command of the user, and returns the remaining time of the throttling, rounded up to the seconds,
+// if the user is throttled.
+func (m *moderation) take(userID string, now time.Time) (time.Duration, bool) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	if m.burstLimit <= 0 {
+		return 0, true
+	}
+
+	if m.requests == nil {
+		m.requests = make(map[string][]time.Time)
+		m.throttled = make(map[string]time.Time)
+	}
+
+	if wait := m.throttled[userID].Sub(now); wait > 0 {
+		return (wait + time.Second - 1).Truncate(time.Second), false
+	}
+
+	// the idle users are removed from time to time to keep the memory bounded.
+	if len(m.requests) >= 1024 {
+		for key, times := range m.requests {
+			if now.Sub(times[len(times)-1]) >= m.burstWindow {
+				delete(m.requests, key)
+			}
+		}
+		for key, until := range m.throttled {
+			if !until.After(now) {
+				delete(m.throttled, key)
+			}
+		}
+	}
+
+	times := m.requests[userID]
+	for len(times) > 0 && now.Sub(times[0]) >= m.burstWindow {
+		times = times[1:]
+	}
+	times = append(times, now)
+
+	if len(times) > m.burstLimit {
+		delete(m.requests, userID)
+		m.throttled[userID] = now.Add(m.throttle)
+
+		return m.throttle, false
+	}
+	m.requests[userID] = times
+
+	return 0, true
+}
+
+// moderationMiddleware rejects the commands of the banned and the throttled users, the admins are exempt.
+func (be *BotEngine) moderationMiddleware(exec *execution) Middleware {
+	return func(_ *Command, next CommandHandler) CommandHandler {
+		return func(source AppID, callerID string, args ...string) (*CommandResult, error) {
+			if exec.role >= RoleAdmin {
+				return next(source, callerID, args...)
+			}
+
+			if ban := be.BanOf(callerID); ban != nil {
+				return MakeFailedResult(exec.msgs.Get(MsgBanned)), nil
+			}
+
+			if wait, ok := be.moderation.take(callerID, time.Now()); !ok {
+				exec.logger.Warn("user throttled", "appID", source, "callerID", callerID, "wait", wait)
+
+				return MakeFailedResult(exec.msgs.Get(MsgThrottled, wait)), nil
+			}
+
+			return next(source, callerID, args...)
+		}
+	}
+}
+
+// banUserHandler bans the user, for the duration if it's given, otherwise permanently.
+func (be *BotEngine) banUserHandler(appID AppID, callerID string, args ...string) (*CommandResult, error) {
+	userID := strings.TrimSpace(args[0])
+	if userID == "" {
+		return MakeFailedResult("The user ID is empty"), nil
+	}
+
+	if be.callerRole(appID, userID, RoleUser) >= RoleAdmin {
+		return MakeFailedResult("The admins can't be banned"), nil
+	}
+
+	now := time.Now()
+	ban := Ban{
+		UserID:   userID,
+		BannedBy: callerID,
+		Time:     now.Unix(),
+	}
+
+	if len(args) > 1 && args[1] != "" {
+		duration, err := time.ParseDuration(args[1])
+		if err != nil || duration <= 0 {
+			return MakeFailedResult("Invalid duration: %s, it should be like 12h", args[1]), nil
+		}
+		ban.Until = now.Add(duration).Unix()
+	}
+	if len(args) > 2 {
+		ban.Reason = args[2]
+	}
+
+	if err := be.BanUser(ban); err != nil {
+		return nil, err
+	}
+	be.logger.Info("user banned", "userID", userID, "callerID", callerID, "until", ban.Until, "reason", ban.Reason)
+
+	if ban.Until == 0 {
+		return MakeSuccessfulResult("User `%s` is banned permanently", userID), nil
+	}
+
+	return MakeSuccessfulResult("User `%s` is banned until %s", userID,
+		time.Unix(ban.Until, 0).UTC().Format(time.RFC1123)), nil
+}
+
+// unbanUserHandler lifts the ban of the user.
+func (be *BotEngine) unbanUserHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	userID := strings.TrimSpace(args[0])
+
+	unbanned, err := be.UnbanUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !unbanned {
+		return MakeFailedResult("User `%s` is not banned", userID), nil
+	}
+	be.logger.Info("user unbanned", "userID", userID, "callerID", callerID)
+
+	return MakeSuccessfulResult("User `%s` is unbanned", userID), nil
+}
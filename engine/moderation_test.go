@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupModerationEngine(t *testing.T) *BotEngine {
+	t.Helper()
+
+	be := setupTestEngine(t,
+		Command{Name: "cmd", AppIDs: []AppID{AppIdCLI}, Handler: okHandler},
+	)
+	be.AuthIDs = []string{"admin"}
+	be.Cmds = append(be.Cmds,
+		Command{
+			Name:    BanUserCommandName,
+			AppIDs:  []AppID{AppIdCLI},
+			Handler: be.banUserHandler,
+			MinRole: RoleAdmin,
+			Args: []Args{
+				{Name: "user-id"},
+				{Name: "duration", Optional: true},
+				{Name: "reason", Optional: true},
+			},
+		},
+		Command{
+			Name:    UnbanUserCommandName,
+			AppIDs:  []AppID{AppIdCLI},
+			Handler: be.unbanUserHandler,
+			MinRole: RoleAdmin,
+			Args:    []Args{{Name: "user-id"}},
+		},
+	)
+
+	return be
+}
+
+func TestBanUser(t *testing.T) {
+	be := setupModerationEngine(t)
+
+	kv, err := store.OpenKV(store.BackendJSON, t.TempDir(), "user_bans")
+	require.NoError(t, err)
+	require.NoError(t, be.moderation.load(kv))
+
+	t.Run("only admins can ban", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "user", []string{BanUserCommandName, "other"})
+		assert.Error(t, err)
+		assert.Nil(t, be.BanOf("other"))
+	})
+
+	t.Run("admins can't be banned", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{BanUserCommandName, "admin"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{BanUserCommandName, "user", "forever"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "Invalid duration")
+	})
+
+	t.Run("banned users are rejected", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{BanUserCommandName, "user", "", "spam"})
+		require.NoError(t, err)
+		require.True(t, res.Successful)
+		assert.Contains(t, res.Message, "permanently")
+
+		res, err = be.Run(AppIdCLI, "user", []string{"cmd"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Equal(t, defaultMessages[MsgBanned], res.Message)
+
+		ban := be.BanOf("user")
+		require.NotNil(t, ban)
+		assert.Equal(t, "spam", ban.Reason)
+		assert.Equal(t, "admin", ban.BannedBy)
+	})
+
+	t.Run("bans are persisted", func(t *testing.T) {
+		other := setupModerationEngine(t)
+		require.NoError(t, other.moderation.load(kv))
+		assert.NotNil(t, other.BanOf("user"))
+	})
+
+	t.Run("unbanned", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{UnbanUserCommandName, "user"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		res, err = be.Run(AppIdCLI, "user", []string{"cmd"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		res, err = be.Run(AppIdCLI, "admin", []string{UnbanUserCommandName, "user"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("expired ban", func(t *testing.T) {
+		require.NoError(t, be.BanUser(Ban{UserID: "user", Until: time.Now().Add(-time.Minute).Unix()}))
+		assert.Nil(t, be.BanOf("user"))
+
+		res, err := be.Run(AppIdCLI, "user", []string{"cmd"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+	})
+}
+
+func TestBurstThrottle(t *testing.T) {
+	be := setupModerationEngine(t)
+	be.SetBurstLimit(3, time.Minute, 10*time.Minute)
+
+	for i := 0; i < 3; i++ {
+		res, err := be.Run(AppIdCLI, "user", []string{"cmd"})
+		require.NoError(t, err)
+		require.True(t, res.Successful)
+	}
+
+	res, err := be.Run(AppIdCLI, "user", []string{"cmd"})
+	require.NoError(t, err)
+	assert.False(t, res.Successful)
+	assert.Contains(t, res.Message, "10m0s")
+
+	t.Run("other users are not throttled", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "other", []string{"cmd"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+	})
+
+	t.Run("admins are exempt", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			res, err := be.Run(AppIdCLI, "admin", []string{"cmd"})
+			require.NoError(t, err)
+			assert.True(t, res.Successful)
+		}
+	})
+}
+
+func TestModerationTake(t *testing.T) {
+	m := &moderation{}
+	now := time.Now()
+
+	_, ok := m.take("user", now)
+	assert.True(t, ok, "the throttling is disabled by default")
+
+	m.burstLimit = 2
+	m.burstWindow = time.Minute
+	m.throttle = time.Hour
+
+	_, ok = m.take("user", now)
+	assert.True(t, ok)
+	_, ok = m.take("user", now.Add(30*time.Second))
+	assert.True(t, ok)
+
+	// the first request is out of the window.
+	_, ok = m.take("user", now.Add(70*time.Second))
+	assert.True(t, ok)
+
+	wait, ok := m.take("user", now.Add(80*time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, time.Hour, wait)
+
+	wait, ok = m.take("user", now.Add(90*time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, time.Hour-10*time.Second, wait)
+
+	_, ok = m.take("user", now.Add(80*time.Second+time.Hour))
+	assert.True(t, ok)
+}
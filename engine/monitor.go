@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/monitor"
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/pactus-project/pactus/crypto"
+)
+
+const (
+	monitorJobName = "validator-monitor"
+	// monitorJitter spreads the snapshots, so they don't hit the nodes with the other jobs.
+	monitorJitter = 5 * time.Second
+)
+
+// enableMonitor creates the validator monitor and schedules its snapshots.
+func (be *BotEngine) enableMonitor(cfg *config.Config) error {
+	history, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "validator_history")
+	if err != nil {
+		return err
+	}
+
+	subs, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "validator_alerts")
+	if err != nil {
+		return err
+	}
+
+	be.monitor = monitor.NewMonitor(be.clientMgr, history, subs, cfg.Monitor.AlertThreshold)
+
+	return be.Schedule(scheduler.Job{
+		Name:   monitorJobName,
+		Spec:   fmt.Sprintf("@every %s", cfg.Monitor.Interval),
+		Jitter: monitorJitter,
+		Run:    func(_ context.Context) { be.monitor.Snapshot() },
+	})
+}
+
+// SetValidatorAlertHandler sets the function that delivers the validator alerts, if the monitor is enabled.
+func (be *BotEngine) SetValidatorAlertHandler(fn func(monitor.Alert)) {
+	if be.monitor != nil {
+		be.monitor.SetAlertHandler(fn)
+	}
+}
+
+func (be *BotEngine) validatorUptimeHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	uptime, err := be.monitor.Uptime(strings.ToLower(strings.TrimSpace(args[0])))
+	if err != nil {
+		return nil, err
+	}
+
+	res := MakeSuccessfulResult("Validator: %s", uptime.Address)
+	res.Fields = []ResultField{
+		{Name: "Availability Score", Value: fmt.Sprintf("%.2f", uptime.Current.AvailabilityScore), Inline: true},
+		{Name: "Last Sortition", Value: fmt.Sprintf("%d", uptime.Current.LastSortitionHeight), Inline: true},
+		{Name: "Blocks Since Sortition", Value: fmt.Sprintf("%d",
+			uptime.Current.Height-min(uptime.Current.Height, uptime.Current.LastSortitionHeight)), Inline: true},
+	}
+
+	if uptime.Current.Unbonded {
+		res.AddWarning("The validator is unbonded")
+	}
+
+	if uptime.Samples == 0 {
+		res.Fields = append(res.Fields, ResultField{
+			Name:  "History",
+			Value: fmt.Sprintf("Not recorded, use `/%s on` to monitor this validator", ValidatorAlertsCommandName),
+		})
+	} else {
+		res.Fields = append(res.Fields,
+			ResultField{Name: "Average Score", Value: fmt.Sprintf("%.2f", uptime.AvgScore), Inline: true},
+			ResultField{Name: "Lowest Score", Value: fmt.Sprintf("%.2f", uptime.MinScore), Inline: true},
+			ResultField{Name: "Recorded Since", Value: uptime.Since.UTC().Format(time.DateTime), Inline: true},
+		)
+	}
+
+	be.rememberValidator(callerID, uptime.Address)
+
+	return res, nil
+}
+
+// validatorAlertsHandler subscribes the caller to the alerts of the given validator,
+// or the validator linked to their account.
+func (be *BotEngine) validatorAlertsHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	if args[0] == "off" {
+		valAddress, err := be.monitor.Unsubscribe(callerID)
+		if err != nil {
+			return nil, err
+		}
+		if valAddress == "" {
+			return MakeFailedResult("You are not subscribed to any validator alerts"), nil
+		}
+
+		return MakeSuccessfulResult("Alerts of validator `%s` are turned off", valAddress), nil
+	}
+
+	valAddress := ""
+	if len(args) > 1 {
+		valAddress = args[1]
+	} else {
+		valAddress = be.linkedValidator(callerID)
+	}
+
+	if valAddress == "" {
+		res := MakeFailedResult("No validator is provided or linked to your account")
+		res.Suggest(LinkCommandName)
+
+		return res, nil
+	}
+
+	addr, err := crypto.AddressFromString(valAddress)
+	if err != nil || !addr.IsValidatorAddress() {
+		return MakeFailedResult("Invalid validator address: %s", valAddress), nil
+	}
+
+	if err := be.monitor.Subscribe(callerID, addr.String()); err != nil {
+		return nil, err
+	}
+
+	res := MakeSuccessfulResult("You will be alerted by DM when validator `%s` goes down", addr.String())
+	res.Suggest(ValidatorUptimeCommandName)
+
+	return res, nil
+}
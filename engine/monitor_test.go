@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"path"
+	"testing"
+
+	"github.com/kehiy/RoboPac/monitor"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/pactus-project/pactus/crypto"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupMonitor(t *testing.T) (*BotEngine, *store.MockIStore) {
+	t.Helper()
+
+	be, mockClient := setupTestEngineWithClient(t)
+	mockStore := store.NewMockIStore(gomock.NewController(t))
+	be.store = mockStore
+
+	history, err := store.NewJSONKV(path.Join(t.TempDir(), "history.json"))
+	require.NoError(t, err)
+	subs, err := store.NewJSONKV(path.Join(t.TempDir(), "subs.json"))
+	require.NoError(t, err)
+	be.monitor = monitor.NewMonitor(be.clientMgr, history, subs, 0.9)
+
+	mockClient.EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(1000), nil).AnyTimes()
+	mockClient.EXPECT().GetValidatorInfo(gomock.Any(), gomock.Any()).Return(&pactus.GetValidatorResponse{
+		Validator: &pactus.ValidatorInfo{AvailabilityScore: 0.95, LastSortitionHeight: 980},
+	}, nil).AnyTimes()
+
+	return be, mockStore
+}
+
+func TestValidatorAlerts(t *testing.T) {
+	be, mockStore := setupMonitor(t)
+	valAddr := crypto.NewAddress(crypto.AddressTypeValidator, make([]byte, 20)).String()
+
+	t.Run("nothing linked", func(t *testing.T) {
+		mockStore.EXPECT().UserPrefs("123").Return(nil)
+
+		res, err := be.validatorAlertsHandler(AppIdDiscord, "123", "on")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("linked validator", func(t *testing.T) {
+		mockStore.EXPECT().UserPrefs("123").Return(&store.UserPrefs{DiscordID: "123", ValidatorAddr: valAddr})
+
+		res, err := be.validatorAlertsHandler(AppIdDiscord, "123", "on")
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, valAddr, be.monitor.Subscription("123"))
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		res, err := be.validatorAlertsHandler(AppIdDiscord, "123", "on", "invalid-addr")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("turn off", func(t *testing.T) {
+		res, err := be.validatorAlertsHandler(AppIdDiscord, "123", "off")
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		res, err = be.validatorAlertsHandler(AppIdDiscord, "123", "off")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+}
+
+func TestValidatorUptime(t *testing.T) {
+	be, mockStore := setupMonitor(t)
+	valAddr := crypto.NewAddress(crypto.AddressTypeValidator, make([]byte, 20)).String()
+
+	// the looked up validator is remembered for the autocomplete.
+	var saved *store.UserPrefs
+	mockStore.EXPECT().UserPrefs("1").DoAndReturn(func(_ string) *store.UserPrefs { return saved }).AnyTimes()
+	mockStore.EXPECT().SaveUserPrefs(gomock.Any()).DoAndReturn(func(p *store.UserPrefs) error {
+		saved = p
+
+		return nil
+	}).Times(1)
+
+	t.Run("without history", func(t *testing.T) {
+		res, err := be.validatorUptimeHandler(AppIdCLI, "1", valAddr)
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Contains(t, res.Fields[len(res.Fields)-1].Value, "Not recorded")
+	})
+
+	t.Run("with history", func(t *testing.T) {
+		require.NoError(t, be.monitor.Subscribe("123", valAddr))
+		be.monitor.Snapshot()
+
+		res, err := be.validatorUptimeHandler(AppIdCLI, "1", valAddr)
+		require.NoError(t, err)
+
+		values := map[string]string{}
+		for _, f := range res.Fields {
+			values[f.Name] = f.Value
+		}
+		assert.Equal(t, "0.95", values["Average Score"])
+		assert.Equal(t, "20", values["Blocks Since Sortition"])
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		be.Cmds = append(be.Cmds, Command{
+			Name:    ValidatorUptimeCommandName,
+			AppIDs:  []AppID{AppIdCLI},
+			Handler: be.validatorUptimeHandler,
+			Args:    []Args{{Name: "validator-address", Format: FormatValidatorAddress}},
+		})
+
+		_, err := be.Run(AppIdCLI, "1", []string{ValidatorUptimeCommandName, "invalid-addr"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// networkStatusMaxAge is how long a fetched network status is reused by CachedNetworkStatusOf.
+const networkStatusMaxAge = 30 * time.Second
+
+type cachedNetStatus struct {
+	status    *NetStatus
+	fetchedAt time.Time
+}
+
+// netStatusCache keeps the last fetched status of each network, and merges the concurrent fetches
+// of a network into one. The zero value is ready to use.
+type netStatusCache struct {
+	lk      sync.Mutex
+	entries map[string]cachedNetStatus
+	fetches singleflight.Group
+}
+
+func (c *netStatusCache) get(network string, maxAge time.Duration, now time.Time) *NetStatus {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	entry, ok := c.entries[network]
+	if !ok || now.Sub(entry.fetchedAt) > maxAge {
+		return nil
+	}
+
+	return entry.status
+}
+
+func (c *netStatusCache) set(network string, status *NetStatus, now time.Time) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]cachedNetStatus)
+	}
+	c.entries[network] = cachedNetStatus{status: status, fetchedAt: now}
+}
+
+// CachedNetworkStatus returns the status of the primary network, see CachedNetworkStatusOf.
+func (be *BotEngine) CachedNetworkStatus() (*NetStatus, error) {
+	return be.CachedNetworkStatusOf("")
+}
+
+// CachedNetworkStatusOf returns the last fetched status of the network if it's fresh, or fetches it.
+// The status is shared by the callers, so it must not be modified.
+func (be *BotEngine) CachedNetworkStatusOf(network string) (*NetStatus, error) {
+	cm, err := be.networkClient(network)
+	if err != nil {
+		return nil, err
+	}
+
+	if status := be.netStatuses.get(cm.Network(), networkStatusMaxAge, time.Now()); status != nil {
+		return status, nil
+	}
+
+	return be.NetworkStatusOf(cm.Network())
+}
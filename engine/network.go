@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kehiy/RoboPac/client"
+)
+
+// networkArgName is the optional argument of the network commands, when the bot is connected to more than one network.
+const networkArgName = "network"
+
+// Networks returns the networks that the bot is connected to, the primary network first.
+func (be *BotEngine) Networks() []string {
+	others := make([]string, 0, len(be.networks))
+	for network := range be.networks {
+		others = append(others, network)
+	}
+	slices.Sort(others)
+
+	return append([]string{be.clientMgr.Network()}, others...)
+}
+
+// networkClient returns the client of the network, or the client of the primary network if it's empty.
+func (be *BotEngine) networkClient(network string) (*client.Mgr, error) {
+	if network == "" || network == be.clientMgr.Network() {
+		return be.clientMgr, nil
+	}
+
+	cm, ok := be.networks[network]
+	if !ok {
+		return nil, fmt.Errorf("the bot is not connected to the %s", network)
+	}
+
+	return cm, nil
+}
+
+// networkOf returns the network argument at the index, it's empty if the argument is not set.
+func networkOf(args []string, idx int) string {
+	if idx < len(args) {
+		return args[idx]
+	}
+
+	return ""
+}
+
+// addNetworkArgs adds the network argument to the commands with a default network,
+// if the bot is connected to more than one network.
+func (be *BotEngine) addNetworkArgs() {
+	if len(be.networks) == 0 {
+		return
+	}
+
+	networks := be.Networks()
+	for i, cmd := range be.Cmds {
+		if cmd.Network == "" {
+			continue
+		}
+
+		be.Cmds[i].Args = append(slices.Clone(cmd.Args), Args{
+			Name:     networkArgName,
+			Desc:     fmt.Sprintf("the network to query, defaults to %s", be.defaultNetwork(&cmd)),
+			Optional: true,
+			Choices:  networks,
+		})
+	}
+}
+
+// defaultNetwork returns the default network of the command, or the primary network
+// if the bot is not connected to the default one.
+func (be *BotEngine) defaultNetwork(cmd *Command) string {
+	if _, err := be.networkClient(cmd.Network); err != nil {
+		return be.clientMgr.Network()
+	}
+
+	return cmd.Network
+}
+
+// withDefaultNetwork sets the network argument of the command to its default network, if it's not set.
+func (be *BotEngine) withDefaultNetwork(cmd *Command, args []string) []string {
+	idx := slices.IndexFunc(cmd.Args, func(arg Args) bool { return arg.Name == networkArgName })
+	if cmd.Network == "" || idx < 0 || networkOf(args, idx) != "" {
+		return args
+	}
+
+	filled := make([]string, len(cmd.Args))
+	copy(filled, args)
+	filled[idx] = be.defaultNetwork(cmd)
+
+	return filled
+}
@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupTestEngineWithTestnet(t *testing.T) (*BotEngine, *client.MockIClient, *client.MockIClient) {
+	t.Helper()
+
+	be, mainnetClient := setupTestEngineWithClient(t)
+
+	testnetClient := client.NewMockIClient(gomock.NewController(t))
+	cm := client.NewClientMgr(be.ctx)
+	cm.SetNetwork(client.NetworkTestnet)
+	cm.AddClient(testnetClient)
+	be.networks = map[string]*client.Mgr{client.NetworkTestnet: cm}
+
+	be.RegisterCommands()
+
+	return be, mainnetClient, testnetClient
+}
+
+func TestNetworkArgs(t *testing.T) {
+	t.Run("single network", func(t *testing.T) {
+		be, _ := setupTestEngineWithClient(t)
+		be.RegisterCommands()
+
+		assert.Equal(t, []string{client.NetworkMainnet}, be.Networks())
+		assert.Len(t, be.commandByName(BlockCommandName).Args, 2)
+	})
+
+	t.Run("dual network", func(t *testing.T) {
+		be, _, _ := setupTestEngineWithTestnet(t)
+
+		assert.Equal(t, []string{client.NetworkMainnet, client.NetworkTestnet}, be.Networks())
+
+		args := be.commandByName(BlockCommandName).Args
+		require.Len(t, args, 3)
+		assert.Equal(t, networkArgName, args[2].Name)
+		assert.True(t, args[2].Optional)
+		assert.Equal(t, be.Networks(), args[2].Choices)
+
+		assert.Len(t, be.commandByName(PeersCommandName).Args, 1, "the peers are of the primary network only")
+	})
+}
+
+func TestNetworkCommands(t *testing.T) {
+	be, mainnetClient, testnetClient := setupTestEngineWithTestnet(t)
+
+	t.Run("default network", func(t *testing.T) {
+		mainnetClient.EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(109), nil)
+
+		res, err := be.Run(AppIdCLI, "1", []string{BlockCommandName, "110"})
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "the last block is 109")
+	})
+
+	t.Run("testnet", func(t *testing.T) {
+		testnetClient.EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(5), nil)
+
+		res, err := be.Run(AppIdCLI, "1", []string{BlockCommandName, "110", "", client.NetworkTestnet})
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "the last block is 5")
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{BlockCommandName, "110", "", "devnet"})
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultNetwork(t *testing.T) {
+	be, _, _ := setupTestEngineWithTestnet(t)
+
+	cmd := &Command{Network: client.NetworkTestnet, Args: []Args{{Name: "id"}, {Name: networkArgName, Optional: true}}}
+	assert.Equal(t, []string{"abc", client.NetworkTestnet}, be.withDefaultNetwork(cmd, []string{"abc"}))
+	assert.Equal(t, []string{"abc", client.NetworkMainnet}, be.withDefaultNetwork(cmd, []string{"abc", client.NetworkMainnet}))
+
+	// the primary network is the default, if the bot is not connected to the default network.
+	be.networks = nil
+	assert.Equal(t, client.NetworkMainnet, be.defaultNetwork(cmd))
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/client/mock"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNodeDiagnostic(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		be, mockClient := setupTestEngineWithClient(t)
+		be.AuthIDs = []string{"admin"}
+
+		mockClient.EXPECT().GetNodeInfo(gomock.Any()).Return(
+			&pactus.GetNodeInfoResponse{Moniker: "robopac", Agent: "node=daemon/pactus=1.0.0"}, nil)
+		mockClient.EXPECT().LastBlockTime(gomock.Any()).Return(
+			uint32(time.Now().Add(-5*time.Second).Unix()), uint32(1000), nil)
+		mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(
+			&pactus.GetNetworkInfoResponse{NetworkName: "pactus", ConnectedPeersCount: 42}, nil)
+		mockClient.EXPECT().Target().Return("localhost:50052")
+
+		res, err := be.nodeHandler(AppIdDiscord, "admin")
+		require.NoError(t, err)
+
+		assert.True(t, res.Successful)
+		assert.Empty(t, res.Warnings)
+		assert.Contains(t, res.Message, "Moniker: robopac")
+		assert.Contains(t, res.Message, "Synced✅")
+		assert.Contains(t, res.Message, "Connected Peers: 42")
+		assert.Contains(t, res.Message, "Connected To: localhost:50052")
+	})
+
+	t.Run("degraded", func(t *testing.T) {
+		be, mockClient := setupTestEngineWithClient(t)
+		be.AuthIDs = []string{"admin"}
+
+		mockClient.EXPECT().GetNodeInfo(gomock.Any()).Return(nil, errors.New("unavailable"))
+		mockClient.EXPECT().LastBlockTime(gomock.Any()).Return(
+			uint32(time.Now().Add(-time.Hour).Unix()), uint32(1000), nil)
+		mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(nil, errors.New("unavailable"))
+		mockClient.EXPECT().Target().Return("localhost:50052")
+
+		res, err := be.nodeHandler(AppIdDiscord, "admin")
+		require.NoError(t, err)
+
+		assert.True(t, res.Successful)
+		assert.Len(t, res.Warnings, 3)
+		assert.Contains(t, res.Warnings, "node information is not available")
+		assert.Contains(t, res.Warnings, "peer information is not available")
+		assert.Contains(t, res.Message, "Behind⚠️")
+		assert.NotContains(t, res.Message, "Moniker")
+	})
+
+	t.Run("clock skew", func(t *testing.T) {
+		be, mockClient := setupTestEngineWithClient(t)
+		be.AuthIDs = []string{"admin"}
+
+		mockClient.EXPECT().GetNodeInfo(gomock.Any()).Return(&pactus.GetNodeInfoResponse{}, nil)
+		mockClient.EXPECT().LastBlockTime(gomock.Any()).Return(
+			uint32(time.Now().Add(time.Minute).Unix()), uint32(1000), nil)
+		mockClient.EXPECT().GetNetworkInfo(gomock.Any()).Return(&pactus.GetNetworkInfoResponse{}, nil)
+		mockClient.EXPECT().Target().Return("localhost:50052")
+
+		res, err := be.nodeHandler(AppIdDiscord, "admin")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"the last block time is in the future, check the clock"}, res.Warnings)
+	})
+}
+
+func TestNodeDiff(t *testing.T) {
+	be, nodes := setupTestEngineWithNodes(t, "node-a:50051", "node-b:50051", "node-c:50051")
+	be.AuthIDs = []string{"admin"}
+
+	t.Run("synced", func(t *testing.T) {
+		res, err := be.Run(AppIdCLI, "admin", []string{NodeDiffCommandName})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Empty(t, res.Warnings)
+		assert.Contains(t, res.Message, "Common Height: 1,000")
+		require.Len(t, res.Table.Rows, 3)
+		assert.Equal(t, []string{"node-a:50051", "1,000", "0", "abcd", "Synced✅"}, res.Table.Rows[0])
+	})
+
+	t.Run("lagging and forked", func(t *testing.T) {
+		for _, node := range nodes {
+			_, err := node.AddBlock(990, mock.FixtureBlockTime-100)
+			require.NoError(t, err)
+		}
+		nodes[1].SetHeight(990)
+
+		forked, err := nodes[2].AddBlock(mock.FixtureHeight, mock.FixtureBlockTime)
+		require.NoError(t, err)
+		forked.Hash = []byte{0xee}
+		nodes[2].SetHeight(mock.FixtureHeight)
+
+		// the hashes are compared at the common height, so the lagging node doesn't look forked.
+		res, err := be.Run(AppIdCLI, "admin", []string{NodeDiffCommandName})
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "Common Height: 990")
+		assert.Equal(t, []string{"`node-b:50051` is 10 blocks behind"}, res.Warnings)
+		assert.Equal(t, "Behind⚠️", res.Table.Rows[1][4])
+
+		nodes[1].SetHeight(mock.FixtureHeight)
+		res, err = be.Run(AppIdCLI, "admin", []string{NodeDiffCommandName})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"`node-c:50051` has a different block at the height 1,000"}, res.Warnings)
+		assert.Equal(t, "Forked❌", res.Table.Rows[2][4])
+		assert.Equal(t, "Synced✅", res.Table.Rows[1][4])
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		nodes[0].SetDown(errors.New("connection refused"))
+
+		res, err := be.Run(AppIdCLI, "admin", []string{NodeDiffCommandName})
+		require.NoError(t, err)
+		assert.Contains(t, res.Warnings, "`node-a:50051` is unreachable")
+		assert.Equal(t, []string{"node-a:50051", "-", "-", "-", "Unreachable❌"}, res.Table.Rows[0])
+	})
+}
+
+func TestGrpcRaw(t *testing.T) {
+	be, _ := setupTestEngineWithNodes(t, "node-a:50051")
+	be.AuthIDs = []string{"admin"}
+
+	t.Run("not admin", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "user", []string{GrpcRawCommandName, "Blockchain.GetBlockchainInfo"})
+		assert.Error(t, err)
+	})
+
+	t.Run("not allowed methods", func(t *testing.T) {
+		for _, method := range []string{"Wallet.GetBalance", "Transaction.BroadcastTransaction", "Blockchain.Unknown"} {
+			res, err := be.Run(AppIdCLI, "admin", []string{GrpcRawCommandName, method})
+			require.NoError(t, err)
+			assert.False(t, res.Successful, method)
+		}
+	})
+
+	t.Run("mock nodes", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "admin", []string{GrpcRawCommandName, "Blockchain.GetBlockchainInfo"})
+		assert.ErrorIs(t, err, client.ErrRawNotSupported)
+	})
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/notification"
+	"github.com/kehiy/RoboPac/payout"
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/pactus-project/pactus/util"
+)
+
+const (
+	lowBalanceJobName = "low-balance"
+	lowBalanceSpec    = "@every 10m"
+
+	notifyTimeout = 30 * time.Second
+)
+
+// newNotifier creates the sinks of the webhooks, it's nil if no webhook is set.
+func newNotifier(cfg config.NotificationConfig) (notification.Sink, error) {
+	if len(cfg.Webhooks) == 0 {
+		return nil, nil
+	}
+
+	sinks := make(notification.Sinks, 0, len(cfg.Webhooks))
+	for _, wh := range cfg.Webhooks {
+		sink, err := notification.NewWebhook(wh.URL, wh.Format)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return sinks, nil
+}
+
+// notify delivers the event to the operators in the background, the failures are logged only.
+func notify(sink notification.Sink, event notification.Event) {
+	if sink == nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+
+		if err := sink.Notify(ctx, event); err != nil {
+			log.Error("unable to notify the operators", "err", err, "kind", event.Kind)
+		}
+	}()
+}
+
+// healthChangeNotifier returns the handler of the node health changes that notifies the operators,
+// or nil if there is no sink.
+func healthChangeNotifier(sink notification.Sink) func(client.HealthChange) {
+	if sink == nil {
+		return nil
+	}
+
+	return func(change client.HealthChange) {
+		notify(sink, nodeFailoverEvent(change))
+	}
+}
+
+func nodeFailoverEvent(change client.HealthChange) notification.Event {
+	event := notification.NewEvent(notification.KindNodeFailover, "Node Is Down",
+		"The %s node %s is failing, the calls fail over to the other nodes", change.Network, change.Target)
+	if change.Healthy {
+		event = notification.NewEvent(notification.KindNodeFailover, "Node Is Back",
+			"The %s node %s is responding again", change.Network, change.Target)
+	}
+
+	event = event.AddField("Node", change.Target).AddField("Network", change.Network)
+	if change.Err != nil {
+		event = event.AddField("Error", change.Err.Error())
+	}
+
+	return event
+}
+
+// enableLowBalanceCheck schedules checking the wallet balance, if the threshold and the notifier are set.
+func (be *BotEngine) enableLowBalanceCheck(threshold int64) error {
+	if threshold <= 0 || be.notifier == nil {
+		return nil
+	}
+
+	return be.Schedule(scheduler.Job{
+		Name: lowBalanceJobName,
+		Spec: lowBalanceSpec,
+		Run:  func(_ context.Context) { be.checkLowBalance(threshold) },
+	})
+}
+
+// checkLowBalance notifies the operators once when the wallet balance drops below the threshold,
+// and again only after it's recovered and dropped again.
+func (be *BotEngine) checkLowBalance(threshold int64) {
+	balance := be.wallet.Balance()
+	low := balance < threshold
+	if be.lowBalance.Swap(low) || !low {
+		return
+	}
+
+	notify(be.notifier, notification.NewEvent(notification.KindLowBalance, "Low Wallet Balance",
+		"The balance of the wallet %s is below %s PAC", be.wallet.Address(), util.ChangeToString(threshold)).
+		AddField("Balance", util.ChangeToString(balance)+" PAC"))
+}
+
+func payoutCompletedEvent(progress payout.Progress) notification.Event {
+	return notification.NewEvent(notification.KindPayoutCompleted, "Payout Completed",
+		"Payout %s is done, %d of %d transfers are sent", progress.PlanID, progress.Sent, progress.Total).
+		AddField("Sent", fmt.Sprint(progress.Sent)).
+		AddField("Failed", fmt.Sprint(progress.Failed)).
+		AddField("Receipt", progress.Receipt)
+}
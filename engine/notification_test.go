@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/notification"
+	"github.com/kehiy/RoboPac/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// chanSink passes the events to the channel, since they are delivered in the background.
+type chanSink chan notification.Event
+
+func (s chanSink) Notify(_ context.Context, event notification.Event) error {
+	s <- event
+
+	return nil
+}
+
+func (s chanSink) next(t *testing.T) notification.Event {
+	t.Helper()
+
+	select {
+	case event := <-s:
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event is notified")
+
+		return notification.Event{}
+	}
+}
+
+func TestCheckLowBalance(t *testing.T) {
+	be := setupTestEngine(t)
+	mockWallet := wallet.NewMockIWallet(gomock.NewController(t))
+	sink := make(chanSink, 4)
+	be.wallet = mockWallet
+	be.notifier = sink
+
+	mockWallet.EXPECT().Address().Return("pc1zbot").AnyTimes()
+	gomock.InOrder(
+		mockWallet.EXPECT().Balance().Return(int64(5e9)),
+		mockWallet.EXPECT().Balance().Return(int64(4e9)),
+		mockWallet.EXPECT().Balance().Return(int64(20e9)),
+		mockWallet.EXPECT().Balance().Return(int64(3e9)),
+	)
+
+	be.checkLowBalance(10e9)
+	event := sink.next(t)
+	assert.Equal(t, notification.KindLowBalance, event.Kind)
+	assert.Contains(t, event.Message, "pc1zbot is below 10 PAC")
+	assert.Equal(t, "5 PAC", event.Fields[0].Value)
+
+	// it's notified once while the balance is low.
+	be.checkLowBalance(10e9)
+	be.checkLowBalance(10e9)
+	be.checkLowBalance(10e9)
+	event = sink.next(t)
+	assert.Equal(t, "3 PAC", event.Fields[0].Value, "notified again after the balance recovered")
+	assert.Empty(t, sink)
+}
+
+func TestNodeFailoverEvent(t *testing.T) {
+	event := nodeFailoverEvent(client.HealthChange{
+		Network: client.NetworkMainnet, Target: "node1:50051", Err: errors.New("node is down"),
+	})
+	assert.Equal(t, "Node Is Down", event.Title)
+	assert.Equal(t, "node is down", event.Fields[2].Value)
+
+	event = nodeFailoverEvent(client.HealthChange{Network: client.NetworkMainnet, Target: "node1:50051", Healthy: true})
+	assert.Equal(t, "Node Is Back", event.Title)
+	assert.Len(t, event.Fields, 2)
+}
@@ -0,0 +1,245 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/audit"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/payout"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/pactus-project/pactus/util"
+)
+
+const (
+	// maxPayoutFileSize caps the payout list, a list of payout.MaxEntries lines is far below it.
+	maxPayoutFileSize  = 1 << 20
+	payoutFetchTimeout = 30 * time.Second
+)
+
+// payouts keeps the loaded payout until it's started, and the progress of the running one.
+// Only one payout runs at a time, so the transfers don't race for the wallet balance.
+type payouts struct {
+	payer *payout.Payer
+
+	lk         sync.Mutex
+	plan       *payout.Plan
+	running    bool
+	last       *payout.Progress
+	onProgress func(callerID string, p payout.Progress)
+}
+
+// enablePayouts creates the payer that sends the payouts by the bot wallet.
+func (be *BotEngine) enablePayouts(cfg *config.Config) {
+	be.payouts = &payouts{
+		payer: payout.NewPayer(be.wallet, be.clientMgr, filepath.Join(cfg.StorePath, "payouts")),
+	}
+}
+
+// SetPayoutProgressHandler sets the function that reports the progress of the payouts to the admin who started them.
+func (be *BotEngine) SetPayoutProgressHandler(fn func(callerID string, p payout.Progress)) {
+	if be.payouts == nil {
+		return
+	}
+
+	be.payouts.lk.Lock()
+	defer be.payouts.lk.Unlock()
+
+	be.payouts.onProgress = fn
+}
+
+func (be *BotEngine) payoutLoadHandler(appID AppID, _ string, args ...string) (*CommandResult, error) {
+	data, err := readPayoutFile(be.ctx, appID, args[0])
+	if err != nil {
+		return MakeFailedResult("Unable to read the payout list: %s", err.Error()), nil
+	}
+
+	entries, err := payout.ParseEntries(data)
+	if err != nil {
+		return MakeFailedResult("Invalid payout list: %s", err.Error()), nil
+	}
+
+	id, err := gonanoid.Generate("0123456789abcdefghijklmnopqrstuvwxyz", 8)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := be.payouts.payer.Prepare(id, entries)
+	if err != nil {
+		return MakeFailedResult("Unable to prepare the payout: %s", err.Error()), nil
+	}
+
+	be.payouts.lk.Lock()
+	defer be.payouts.lk.Unlock()
+
+	if be.payouts.running {
+		return MakeFailedResult("A payout is running, wait until it's done"), nil
+	}
+
+	res := MakeSuccessfulResult("Payout `%s` is loaded, run `%s start` to send it", plan.ID, PayoutCommandName)
+	if be.payouts.plan != nil {
+		res.AddWarning(fmt.Sprintf("It replaces the loaded payout `%s`", be.payouts.plan.ID))
+	}
+	be.payouts.plan = plan
+
+	res.Title = "Payout"
+	res.AddField("Transfers", fmt.Sprintf("%d", len(plan.Entries)), true)
+	res.AddField("Total", util.ChangeToString(plan.Total)+" PAC", true)
+	res.AddField("Fees", util.ChangeToString(plan.Fees)+" PAC", true)
+	res.AddField("Wallet Balance", util.ChangeToString(be.wallet.Balance())+" PAC", true)
+
+	return res, nil
+}
+
+func (be *BotEngine) payoutStartHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	be.payouts.lk.Lock()
+	defer be.payouts.lk.Unlock()
+
+	if be.payouts.running {
+		return MakeFailedResult("A payout is running, wait until it's done"), nil
+	}
+	if be.payouts.plan == nil {
+		return MakeFailedResult("No payout is loaded, run `%s load` first", PayoutCommandName), nil
+	}
+
+	plan := be.payouts.plan
+	be.payouts.plan = nil
+	be.payouts.running = true
+	be.payouts.last = &payout.Progress{PlanID: plan.ID, Total: len(plan.Entries)}
+
+	go be.runPayout(callerID, plan)
+
+	return MakeSuccessfulResult("Payout `%s` is started, %d transfers in batches of %d",
+		plan.ID, len(plan.Entries), payout.DefaultBatchSize), nil
+}
+
+// runPayout sends the payout and reports its progress, the payout is stopped when the engine stops.
+func (be *BotEngine) runPayout(callerID string, plan *payout.Plan) {
+	be.logger.Info("payout started", "planID", plan.ID, "callerID", callerID, "transfers", len(plan.Entries))
+
+	progress, err := be.payouts.payer.Run(be.ctx, plan, func(p payout.Progress) {
+		for _, record := range p.Batch {
+			be.recordAudit(audit.Entry{
+				Kind:     audit.KindTransfer,
+				CallerID: callerID,
+				Inputs:   []string{PayoutCommandName, plan.ID, record.Address, util.ChangeToString(record.Amount) + " PAC"},
+				TxID:     record.TxID,
+				Error:    record.Error,
+			})
+		}
+
+		be.payouts.lk.Lock()
+		be.payouts.last = &p
+		be.payouts.running = !p.Done && p.Err == nil
+		onProgress := be.payouts.onProgress
+		be.payouts.lk.Unlock()
+
+		if onProgress != nil {
+			onProgress(callerID, p)
+		}
+	})
+	if err != nil {
+		be.logger.Error("payout stopped", "err", err, "planID", plan.ID, "sent", progress.Sent)
+
+		return
+	}
+
+	be.logger.Info("payout is done", "planID", plan.ID, "sent", progress.Sent, "failed", progress.Failed)
+	notify(be.notifier, payoutCompletedEvent(progress))
+}
+
+func (be *BotEngine) payoutStatusHandler(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+	be.payouts.lk.Lock()
+	defer be.payouts.lk.Unlock()
+
+	if be.payouts.last == nil && be.payouts.plan == nil {
+		return MakeSuccessfulResult("No payout is loaded"), nil
+	}
+
+	res := MakeSuccessfulResult("")
+	res.Title = "Payout"
+
+	if last := be.payouts.last; last != nil {
+		res.Message = payoutProgressMessage(*last)
+		res.AddField("Sent", fmt.Sprintf("%d/%d", last.Sent, last.Total), true)
+		res.AddField("Failed", fmt.Sprintf("%d", last.Failed), true)
+		res.AddField("Batches", fmt.Sprintf("%d", last.Batches), true)
+		if last.Receipt != "" {
+			res.AddField("Receipt", last.Receipt, false)
+		}
+	}
+
+	if plan := be.payouts.plan; plan != nil {
+		res.AddWarning(fmt.Sprintf("Payout `%s` of %s PAC to %d addresses is loaded, run `%s start` to send it",
+			plan.ID, util.ChangeToString(plan.Total), len(plan.Entries), PayoutCommandName))
+	}
+
+	return res, nil
+}
+
+func payoutProgressMessage(p payout.Progress) string {
+	switch {
+	case p.Done:
+		return fmt.Sprintf("Payout `%s` is done", p.PlanID)
+	case p.Err != nil:
+		return fmt.Sprintf("Payout `%s` is stopped: %s", p.PlanID, p.Err.Error())
+	default:
+		return fmt.Sprintf("Payout `%s` is running", p.PlanID)
+	}
+}
+
+// readPayoutFile reads the payout list from the URL of the attachment, or from a local file on the CLI.
+func readPayoutFile(ctx context.Context, appID AppID, file string) ([]byte, error) {
+	var reader io.Reader
+	switch {
+	case strings.HasPrefix(file, "https://") || strings.HasPrefix(file, "http://"):
+		ctx, cancel := context.WithTimeout(ctx, payoutFetchTimeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, file, http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+		}
+		reader = resp.Body
+
+	case appID == AppIdCLI:
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		reader = f
+
+	default:
+		return nil, errors.New("the payout list should be an attachment")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxPayoutFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxPayoutFileSize {
+		return nil, fmt.Errorf("the payout list is larger than %d KB", maxPayoutFileSize/1024)
+	}
+
+	return data, nil
+}
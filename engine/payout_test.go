@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/payout"
+	"github.com/kehiy/RoboPac/wallet"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPayoutCommands(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	mockWallet := wallet.NewMockIWallet(gomock.NewController(t))
+	be.wallet = mockWallet
+	be.enablePayouts(&config.Config{StorePath: t.TempDir()})
+
+	progress := make(chan payout.Progress, 10)
+	be.SetPayoutProgressHandler(func(callerID string, p payout.Progress) {
+		assert.Equal(t, "admin", callerID)
+		progress <- p
+	})
+
+	addr1 := crypto.NewAddress(crypto.AddressTypeBLSAccount, make([]byte, 20)).String()
+	addr2 := crypto.NewAddress(crypto.AddressTypeBLSAccount, append(make([]byte, 19), 1)).String()
+	list := fmt.Sprintf("address,amount\n%s,1.5\n%s,2\n", addr1, addr2)
+
+	mockClient.EXPECT().CalculateFee(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1e7), nil).AnyTimes()
+	mockWallet.EXPECT().Balance().Return(int64(100e9)).AnyTimes()
+
+	t.Run("start without a loaded payout", func(t *testing.T) {
+		res, err := be.payoutStartHandler(AppIdCLI, "admin")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("local file is only read on the CLI", func(t *testing.T) {
+		file := path.Join(t.TempDir(), "payout.csv")
+		require.NoError(t, os.WriteFile(file, []byte(list), 0o600))
+
+		res, err := be.payoutLoadHandler(AppIdDiscord, "admin", file)
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+
+		res, err = be.payoutLoadHandler(AppIdCLI, "admin", file)
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "2", res.Fields[0].Value)
+		assert.Equal(t, "3.5 PAC", res.Fields[1].Value)
+		assert.Equal(t, "0.02 PAC", res.Fields[2].Value)
+	})
+
+	t.Run("invalid list", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(addr1 + ",1\n" + addr1 + ",2\n"))
+		}))
+		defer srv.Close()
+
+		res, err := be.payoutLoadHandler(AppIdDiscord, "admin", srv.URL)
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "duplicated address")
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(list))
+	}))
+	defer srv.Close()
+
+	res, err := be.payoutLoadHandler(AppIdDiscord, "admin", srv.URL)
+	require.NoError(t, err)
+	require.True(t, res.Successful)
+	assert.NotEmpty(t, res.Warnings, "the previous payout is replaced")
+
+	mockWallet.EXPECT().LockTime().Return(uint32(10), nil)
+	mockWallet.EXPECT().TransferTransactionAt(uint32(10), addr1, int64(1.5e9), gomock.Any()).Return("tx1", nil)
+	mockWallet.EXPECT().TransferTransactionAt(uint32(10), addr2, int64(2e9), gomock.Any()).Return("tx2", nil)
+	mockWallet.EXPECT().SignMessage(gomock.Any()).Return("sig", "pub", nil)
+
+	res, err = be.payoutStartHandler(AppIdDiscord, "admin")
+	require.NoError(t, err)
+	require.True(t, res.Successful)
+
+	var last payout.Progress
+	for !last.Done {
+		select {
+		case last = <-progress:
+			require.NoError(t, last.Err)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "payout is not done")
+		}
+	}
+	assert.Equal(t, 2, last.Sent)
+	assert.FileExists(t, last.Receipt)
+
+	res, err = be.payoutStatusHandler(AppIdDiscord, "admin")
+	require.NoError(t, err)
+	assert.Contains(t, res.Message, "is done")
+	assert.Equal(t, "2/2", res.Fields[0].Value)
+}
+
+func TestPayoutCommandIsAdminOnly(t *testing.T) {
+	be := setupTestEngine(t)
+	be.enablePayouts(&config.Config{StorePath: t.TempDir()})
+	be.RegisterCommands()
+
+	_, err := be.Run(AppIdDiscord, "user", []string{PayoutCommandName, "status"})
+	assert.Error(t, err)
+}
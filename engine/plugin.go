@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// CommandHandler handles a command. See Command.Handler.
+type CommandHandler func(source AppID, callerID string, args ...string) (*CommandResult, error)
+
+// commandNameRegexp matches the names that all the apps accept, like Discord slash commands.
+var commandNameRegexp = regexp.MustCompile(`^[-_a-z0-9]{1,32}$`)
+
+// RegisterCommand registers a custom command, so operators can add commands without forking the bot.
+// The name of the command must be unique and the spec must be well-formed.
+// The command with subcommands has no handler, the handlers of the subcommands are set in the spec.
+//
+// It's safe to call at any time, but the commands registered after the bot started
+// are only registered on Discord on the next start.
+func (be *BotEngine) RegisterCommand(spec Command, handler CommandHandler) error {
+	if handler == nil && len(spec.SubCommands) == 0 {
+		return errors.New("the handler of the command is missing")
+	}
+
+	if err := validateSpec(&spec); err != nil {
+		return fmt.Errorf("invalid command %q: %w", spec.Name, err)
+	}
+
+	spec.Handler = handler
+
+	be.cmdsLk.Lock()
+	defer be.cmdsLk.Unlock()
+
+	for _, cmd := range be.Cmds {
+		if cmd.Name == spec.Name {
+			return fmt.Errorf("command %q is already registered", spec.Name)
+		}
+	}
+
+	be.Cmds = append(be.Cmds, spec)
+	be.logger.Info("custom command registered", "name", spec.Name)
+
+	return nil
+}
+
+// validateSpec checks that the command spec is well-formed.
+func validateSpec(spec *Command) error {
+	if !commandNameRegexp.MatchString(spec.Name) {
+		return errors.New("the name must be 1-32 lowercase letters, digits, '-' or '_'")
+	}
+
+	if len(spec.AppIDs) == 0 {
+		return errors.New("no app is set")
+	}
+
+	if len(spec.SubCommands) > 0 {
+		return validateSubCommands(spec)
+	}
+
+	return validateArgs(spec.Args)
+}
+
+// validateSubCommands checks the subcommands of the spec, they can't be nested further.
+func validateSubCommands(spec *Command) error {
+	if len(spec.Args) > 0 {
+		return errors.New("the command with subcommands can't have arguments")
+	}
+
+	names := make(map[string]bool, len(spec.SubCommands))
+	for _, sub := range spec.SubCommands {
+		if !commandNameRegexp.MatchString(sub.Name) {
+			return fmt.Errorf("invalid subcommand name %q", sub.Name)
+		}
+
+		if names[sub.Name] {
+			return fmt.Errorf("duplicated subcommand %q", sub.Name)
+		}
+		names[sub.Name] = true
+
+		if len(sub.SubCommands) > 0 {
+			return fmt.Errorf("subcommand %q has nested subcommands", sub.Name)
+		}
+
+		if sub.Handler == nil {
+			return fmt.Errorf("the handler of subcommand %q is missing", sub.Name)
+		}
+
+		if err := validateArgs(sub.Args); err != nil {
+			return fmt.Errorf("subcommand %q: %w", sub.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateArgs checks that the arguments are well-formed.
+func validateArgs(args []Args) error {
+	names := make(map[string]bool, len(args))
+	optional := false
+	for _, arg := range args {
+		if !commandNameRegexp.MatchString(arg.Name) {
+			return fmt.Errorf("invalid argument name %q", arg.Name)
+		}
+
+		if names[arg.Name] {
+			return fmt.Errorf("duplicated argument %q", arg.Name)
+		}
+		names[arg.Name] = true
+
+		if optional && !arg.Optional {
+			return fmt.Errorf("required argument %q after an optional one", arg.Name)
+		}
+		optional = arg.Optional
+
+		if arg.MinValue != nil && arg.MaxValue != nil && *arg.MinValue > *arg.MaxValue {
+			return fmt.Errorf("the range of argument %q is empty", arg.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCommand(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "builtin", AppIDs: []AppID{AppIdCLI}, Handler: okHandler},
+	)
+
+	t.Run("successful registration", func(t *testing.T) {
+		err := be.RegisterCommand(Command{
+			Name:   "custom",
+			Desc:   "a custom command",
+			AppIDs: []AppID{AppIdCLI},
+			Args:   []Args{{Name: "count", Type: ArgTypeInteger, MinValue: Bound(1)}},
+		}, okHandler)
+		require.NoError(t, err)
+
+		res, err := be.Run(AppIdCLI, "1", []string{"custom", "3"})
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res.Message)
+	})
+
+	t.Run("colliding names", func(t *testing.T) {
+		err := be.RegisterCommand(Command{Name: "builtin", AppIDs: []AppID{AppIdCLI}}, okHandler)
+		assert.EqualError(t, err, `command "builtin" is already registered`)
+
+		err = be.RegisterCommand(Command{Name: "custom", AppIDs: []AppID{AppIdCLI}}, okHandler)
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed specs", func(t *testing.T) {
+		specs := []Command{
+			{Name: "", AppIDs: []AppID{AppIdCLI}},
+			{Name: "Upper", AppIDs: []AppID{AppIdCLI}},
+			{Name: "no-app"},
+			{Name: "dup-args", AppIDs: []AppID{AppIdCLI}, Args: []Args{{Name: "a"}, {Name: "a"}}},
+			{Name: "order", AppIDs: []AppID{AppIdCLI}, Args: []Args{{Name: "a", Optional: true}, {Name: "b"}}},
+			{Name: "range", AppIDs: []AppID{AppIdCLI}, Args: []Args{
+				{Name: "a", Type: ArgTypeInteger, MinValue: Bound(5), MaxValue: Bound(1)},
+			}},
+		}
+
+		for _, spec := range specs {
+			assert.Error(t, be.RegisterCommand(spec, okHandler), spec.Name)
+		}
+
+		assert.Error(t, be.RegisterCommand(Command{Name: "no-handler", AppIDs: []AppID{AppIdCLI}}, nil))
+	})
+
+	t.Run("concurrent registration", func(t *testing.T) {
+		wg := sync.WaitGroup{}
+		for n := 0; n < 10; n++ {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+
+				name := fmt.Sprintf("concurrent-%d", n)
+				assert.NoError(t, be.RegisterCommand(Command{Name: name, AppIDs: []AppID{AppIdCLI}}, okHandler))
+				_, err := be.Run(AppIdCLI, "1", []string{name})
+				assert.NoError(t, err)
+			}(n)
+		}
+		wg.Wait()
+
+		assert.Len(t, be.Commands(), 12)
+	})
+}
+
+func TestRegisterSubCommands(t *testing.T) {
+	be := setupTestEngine(t)
+
+	err := be.RegisterCommand(Command{
+		Name:        "custom",
+		AppIDs:      []AppID{AppIdCLI},
+		SubCommands: []Command{{Name: "sub", Handler: okHandler}},
+	}, nil)
+	require.NoError(t, err)
+
+	res, err := be.Run(AppIdCLI, "1", []string{"custom", "sub"})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res.Message)
+
+	specs := []Command{
+		{Name: "with-args", AppIDs: []AppID{AppIdCLI}, Args: []Args{{Name: "a"}},
+			SubCommands: []Command{{Name: "sub", Handler: okHandler}}},
+		{Name: "dup-subs", AppIDs: []AppID{AppIdCLI},
+			SubCommands: []Command{{Name: "sub", Handler: okHandler}, {Name: "sub", Handler: okHandler}}},
+		{Name: "no-sub-handler", AppIDs: []AppID{AppIdCLI},
+			SubCommands: []Command{{Name: "sub"}}},
+		{Name: "nested", AppIDs: []AppID{AppIdCLI},
+			SubCommands: []Command{{Name: "sub", Handler: okHandler,
+				SubCommands: []Command{{Name: "deep", Handler: okHandler}}}}},
+	}
+
+	for _, spec := range specs {
+		assert.Error(t, be.RegisterCommand(spec, nil), spec.Name)
+	}
+}
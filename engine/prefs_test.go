@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/kehiy/RoboPac/store"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLinkValidator(t *testing.T) {
+	be := setupTestEngine(t)
+	mockStore := store.NewMockIStore(gomock.NewController(t))
+	be.store = mockStore
+
+	valAddr := crypto.NewAddress(crypto.AddressTypeValidator, make([]byte, 20)).String()
+	accAddr := crypto.NewAddress(crypto.AddressTypeBLSAccount, make([]byte, 20)).String()
+
+	t.Run("invalid address", func(t *testing.T) {
+		be.Cmds = append(be.Cmds, Command{
+			Name:    LinkCommandName,
+			AppIDs:  []AppID{AppIdDiscord},
+			Handler: be.linkHandler,
+			Args:    []Args{{Name: "validator-address", Format: FormatValidatorAddress}},
+		})
+
+		_, err := be.Run(AppIdDiscord, "123", []string{LinkCommandName, "invalid-addr"})
+		assert.Error(t, err)
+
+		_, err = be.Run(AppIdDiscord, "123", []string{LinkCommandName, accAddr})
+		assert.ErrorContains(t, err, "expected a validator address like pc1p...")
+	})
+
+	t.Run("link validator", func(t *testing.T) {
+		mockStore.EXPECT().UserPrefs("123").Return(nil)
+		mockStore.EXPECT().SaveUserPrefs(&store.UserPrefs{
+			DiscordID:     "123",
+			ValidatorAddr: valAddr,
+		}).Return(nil)
+
+		res, err := be.linkHandler(AppIdDiscord, "123", valAddr)
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+	})
+
+	t.Run("show linked validator", func(t *testing.T) {
+		mockStore.EXPECT().UserPrefs("123").Return(
+			&store.UserPrefs{DiscordID: "123", ValidatorAddr: valAddr})
+
+		res, err := be.meHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, valAddr)
+	})
+
+	t.Run("unlink validator", func(t *testing.T) {
+		mockStore.EXPECT().UserPrefs("123").Return(
+			&store.UserPrefs{DiscordID: "123", ValidatorAddr: valAddr})
+		mockStore.EXPECT().SaveUserPrefs(&store.UserPrefs{DiscordID: "123"}).Return(nil)
+
+		res, err := be.unlinkHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		mockStore.EXPECT().UserPrefs("123").Return(&store.UserPrefs{DiscordID: "123"})
+
+		res, err = be.unlinkHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("node info without linked validator", func(t *testing.T) {
+		mockStore.EXPECT().UserPrefs("123").Return(nil)
+
+		res, err := be.nodeInfoHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+}
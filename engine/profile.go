@@ -0,0 +1,314 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/utils"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/util"
+)
+
+// MaxLinkedAddresses is the number of the addresses that each user can link to their profile.
+const MaxLinkedAddresses = 10
+
+// LinkedAddress is an address that the user proved to own by signing the link message.
+type LinkedAddress struct {
+	Address  string `json:"address"`
+	LinkedAt int64  `json:"linked_at"`
+}
+
+// IsValidator reports whether the linked address is a validator address.
+func (l LinkedAddress) IsValidator() bool {
+	addr, err := crypto.AddressFromString(l.Address)
+
+	return err == nil && addr.IsValidatorAddress()
+}
+
+// Profile is the addresses that are linked to a user ID of an app, like a Discord user.
+type Profile struct {
+	UserID    string          `json:"user_id"`
+	Addresses []LinkedAddress `json:"addresses"`
+}
+
+// Find returns the linked address, or nil if it's not linked.
+func (p *Profile) Find(address string) *LinkedAddress {
+	for i := range p.Addresses {
+		if p.Addresses[i].Address == address {
+			return &p.Addresses[i]
+		}
+	}
+
+	return nil
+}
+
+// AccountAddresses returns the linked account addresses.
+func (p *Profile) AccountAddresses() []string {
+	return p.filter(false)
+}
+
+// ValidatorAddresses returns the linked validator addresses.
+func (p *Profile) ValidatorAddresses() []string {
+	return p.filter(true)
+}
+
+func (p *Profile) filter(validator bool) []string {
+	addrs := []string{}
+	for _, l := range p.Addresses {
+		if l.IsValidator() == validator {
+			addrs = append(addrs, l.Address)
+		}
+	}
+
+	return addrs
+}
+
+// LinkMessage is the message that the users sign with the key of their address to link it,
+// it contains the user ID, so the signature can't be used to link the address to another user.
+func LinkMessage(userID string) string {
+	return fmt.Sprintf("RoboPac link: %s", userID)
+}
+
+// verifyAddressSignature checks that the public key belongs to the address, and the signature of the message
+// is made by it.
+func verifyAddressSignature(pubKey, address, msg, signature string) error {
+	pub, err := bls.PublicKeyFromString(pubKey)
+	if err != nil {
+		return err
+	}
+
+	addr, err := crypto.AddressFromString(address)
+	if err != nil {
+		return err
+	}
+
+	if err := pub.VerifyAddress(addr); err != nil {
+		return err
+	}
+
+	sig, err := bls.SignatureFromString(signature)
+	if err != nil {
+		return errors.New("invalid signature format")
+	}
+
+	return pub.Verify([]byte(msg), sig)
+}
+
+// enableProfiles opens the storage of the user profiles.
+func (be *BotEngine) enableProfiles(cfg *config.Config) error {
+	profiles, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "user_profiles")
+	if err != nil {
+		return err
+	}
+	be.profiles = profiles
+
+	return nil
+}
+
+// profileOf returns the profile of the user, it's empty if the user has no linked addresses.
+func (be *BotEngine) profileOf(userID string) (*Profile, error) {
+	profile := &Profile{UserID: userID, Addresses: []LinkedAddress{}}
+
+	data, err := be.profiles.Get(userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return profile, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (be *BotEngine) saveProfile(profile *Profile) error {
+	if len(profile.Addresses) == 0 {
+		return be.profiles.Delete(profile.UserID)
+	}
+
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	return be.profiles.Set(profile.UserID, data)
+}
+
+// linkAddressHandler links the address to the profile of the caller, once the signature of the link message
+// is verified. Without the signature, it shows the message to sign.
+func (be *BotEngine) linkAddressHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	address := strings.ToLower(strings.TrimSpace(args[0]))
+	msg := LinkMessage(callerID)
+
+	if len(args) < 3 || args[1] == "" || args[2] == "" {
+		return MakeSuccessfulResult("To link `%s`, sign this message by the key of the address in your wallet:\n"+
+			"`%s`\nThen run `%s %s <public-key> <signature>`", address, msg, LinkAddressCommandName, address), nil
+	}
+
+	profile, err := be.profileOf(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.Find(address) != nil {
+		return MakeFailedResult("`%s` is already linked to your profile", address), nil
+	}
+
+	if len(profile.Addresses) >= MaxLinkedAddresses {
+		return MakeFailedResult("You can link up to %d addresses, unlink one with `%s` first",
+			MaxLinkedAddresses, UnlinkCommandName), nil
+	}
+
+	if err := verifyAddressSignature(strings.TrimSpace(args[1]), address, msg, strings.TrimSpace(args[2])); err != nil {
+		return MakeFailedResult("Can't verify the ownership of `%s`: %s", address, err), nil
+	}
+
+	profile.Addresses = append(profile.Addresses, LinkedAddress{Address: address, LinkedAt: time.Now().Unix()})
+	if err := be.saveProfile(profile); err != nil {
+		return nil, err
+	}
+
+	res := MakeSuccessfulResult("`%s` is linked to your profile", address)
+	res.Suggest(MyAddressesCommandName, MyBalanceCommandName, MyValidatorCommandName)
+
+	return res, nil
+}
+
+// myAddressesHandler lists the addresses that are linked to the profile of the caller.
+func (be *BotEngine) myAddressesHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	profile, err := be.profileOf(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(profile.Addresses) == 0 {
+		res := MakeFailedResult("No address is linked to your profile, use `%s` to link one", LinkAddressCommandName)
+		res.Suggest(LinkAddressCommandName)
+
+		return res, nil
+	}
+
+	res := MakeSuccessfulResult("%d address(es) are linked to your profile", len(profile.Addresses))
+	res.Title = "My Addresses"
+	res.SetTable("Address", "Type", "Linked At")
+	for _, l := range profile.Addresses {
+		typ := "Account"
+		if l.IsValidator() {
+			typ = "Validator"
+		}
+		res.AddRow(l.Address, typ, time.Unix(l.LinkedAt, 0).UTC().Format("2006-01-02"))
+	}
+	res.Suggest(MyBalanceCommandName, MyValidatorCommandName)
+
+	return res, nil
+}
+
+// unlinkAddress removes the address from the profile of the caller.
+func (be *BotEngine) unlinkAddress(callerID, address string) (*CommandResult, error) {
+	profile, err := be.profileOf(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.Find(address) == nil {
+		return MakeFailedResult("`%s` is not linked to your profile", address), nil
+	}
+
+	profile.Addresses = slices.DeleteFunc(profile.Addresses, func(l LinkedAddress) bool {
+		return l.Address == address
+	})
+	if err := be.saveProfile(profile); err != nil {
+		return nil, err
+	}
+
+	return MakeSuccessfulResult("`%s` is unlinked from your profile", address), nil
+}
+
+// myBalanceHandler shows the balances of the account addresses that are linked to the profile of the caller.
+func (be *BotEngine) myBalanceHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	profile, err := be.profileOf(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := profile.AccountAddresses()
+	if len(addrs) == 0 {
+		res := MakeFailedResult("No account address is linked to your profile, use `%s` to link one",
+			LinkAddressCommandName)
+		res.Suggest(LinkAddressCommandName)
+
+		return res, nil
+	}
+
+	res := MakeSuccessfulResult("The balances of %d linked account(s)", len(addrs))
+	res.Title = "My Balance"
+	total := int64(0)
+	for _, addr := range addrs {
+		acc, err := be.clientMgr.GetAccount(addr)
+		if err != nil {
+			if !errors.Is(err, client.ErrAccountNotFound) {
+				return nil, err
+			}
+
+			res.AddField(addr, "not found on the chain", false)
+
+			continue
+		}
+
+		total += acc.Balance
+		res.AddField(addr, util.ChangeToString(acc.Balance)+" PAC", false)
+	}
+	res.AddField("Total", util.ChangeToString(total)+" PAC", true)
+
+	return res, nil
+}
+
+// myValidatorHandler shows the validators that are linked to the profile of the caller.
+func (be *BotEngine) myValidatorHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	profile, err := be.profileOf(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := profile.ValidatorAddresses()
+	if len(addrs) == 0 {
+		res := MakeFailedResult("No validator address is linked to your profile, use `%s` to link one",
+			LinkAddressCommandName)
+		res.Suggest(LinkAddressCommandName)
+
+		return res, nil
+	}
+
+	res := MakeSuccessfulResult("The state of %d linked validator(s)", len(addrs))
+	res.Title = "My Validator"
+	totalStake := int64(0)
+	for _, addr := range addrs {
+		val, err := be.clientMgr.GetValidatorInfo(addr)
+		if err != nil {
+			be.logger.Warn("unable to get the linked validator", "err", err, "validator", addr)
+			res.AddField(addr, "not found on the chain", false)
+
+			continue
+		}
+
+		totalStake += val.Validator.Stake
+		res.AddField(addr, fmt.Sprintf("Number: %s\nStake: %s PAC\nAvailability Score: %.2f\nLast Sortition: %s",
+			utils.FormatNumber(int64(val.Validator.Number)), util.ChangeToString(val.Validator.Stake),
+			val.Validator.AvailabilityScore, utils.FormatNumber(int64(val.Validator.LastSortitionHeight))), false)
+	}
+	res.AddField("Total Stake", util.ChangeToString(totalStake)+" PAC", true)
+
+	return res, nil
+}
@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/pactus-project/pactus/crypto/bls"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProfile(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	profiles, err := store.NewJSONKV(path.Join(t.TempDir(), "user_profiles.json"))
+	require.NoError(t, err)
+	be.profiles = profiles
+
+	prv, err := bls.KeyGen(make([]byte, 32), nil)
+	require.NoError(t, err)
+	pub := prv.PublicKeyNative()
+	account := pub.AccountAddress().String()
+	validator := pub.ValidatorAddress().String()
+	signature := prv.Sign([]byte(LinkMessage("123"))).String()
+
+	t.Run("no linked address", func(t *testing.T) {
+		res, err := be.myAddressesHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+
+		res, err = be.myBalanceHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("message to sign", func(t *testing.T) {
+		res, err := be.linkAddressHandler(AppIdDiscord, "123", account)
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, "`RoboPac link: 123`")
+	})
+
+	t.Run("signature of another user", func(t *testing.T) {
+		res, err := be.linkAddressHandler(AppIdDiscord, "456", account, pub.String(), signature)
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("key of another address", func(t *testing.T) {
+		other, err := bls.KeyGen(make([]byte, 32), []byte("other"))
+		require.NoError(t, err)
+
+		res, err := be.linkAddressHandler(AppIdDiscord, "123",
+			other.PublicKeyNative().AccountAddress().String(), pub.String(), signature)
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("link the addresses", func(t *testing.T) {
+		res, err := be.linkAddressHandler(AppIdDiscord, "123", account, pub.String(), signature)
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		res, err = be.linkAddressHandler(AppIdDiscord, "123", validator, pub.String(), signature)
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		res, err = be.linkAddressHandler(AppIdDiscord, "123", account, pub.String(), signature)
+		require.NoError(t, err)
+		assert.False(t, res.Successful, "it's already linked")
+
+		res, err = be.myAddressesHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.Equal(t, "2 address(es) are linked to your profile", res.Message)
+		assert.Equal(t, []string{account, "Account"}, res.Table.Rows[0][:2])
+		assert.Equal(t, []string{validator, "Validator"}, res.Table.Rows[1][:2])
+
+		assert.Equal(t, []string{validator}, be.completeLinkedAddress("123", "pc1p"))
+	})
+
+	t.Run("my balance", func(t *testing.T) {
+		mockClient.EXPECT().GetAccount(gomock.Any(), account).Return(&pactus.AccountInfo{Balance: 12e9}, nil)
+
+		res, err := be.myBalanceHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.Equal(t, account, res.Fields[0].Name)
+		assert.Equal(t, "12 PAC", res.Fields[0].Value)
+		assert.Equal(t, "12 PAC", res.Fields[1].Value)
+
+		mockClient.EXPECT().GetAccount(gomock.Any(), account).Return(nil,
+			fmt.Errorf("%w: %s", client.ErrAccountNotFound, account))
+
+		res, err = be.myBalanceHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.Equal(t, "not found on the chain", res.Fields[0].Value)
+	})
+
+	t.Run("my validator", func(t *testing.T) {
+		mockClient.EXPECT().GetValidatorInfo(gomock.Any(), validator).Return(&pactus.GetValidatorResponse{
+			Validator: &pactus.ValidatorInfo{Number: 7, Stake: 1000e9, AvailabilityScore: 0.95},
+		}, nil)
+
+		res, err := be.myValidatorHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.Contains(t, res.Fields[0].Value, "Stake: 1000 PAC")
+		assert.Equal(t, "1000 PAC", res.Fields[1].Value)
+
+		mockClient.EXPECT().GetValidatorInfo(gomock.Any(), validator).Return(nil, errors.New("not found"))
+
+		res, err = be.myValidatorHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.Equal(t, "not found on the chain", res.Fields[0].Value)
+	})
+
+	t.Run("unlink an address", func(t *testing.T) {
+		res, err := be.unlinkHandler(AppIdDiscord, "123", validator)
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		res, err = be.unlinkHandler(AppIdDiscord, "123", validator)
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+
+		res, err = be.myValidatorHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+}
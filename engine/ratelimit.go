@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimit is the minimum time between the executions of a command, zero means no limit.
+type RateLimit struct {
+	// PerUser limits each caller separately, like "network once per 30s per user".
+	PerUser time.Duration
+	// PerCommand limits all the callers together.
+	PerCommand time.Duration
+}
+
+// rateLimitMiddleware answers the callers by the cooldown message if the command is called too often.
+func (be *BotEngine) rateLimitMiddleware(cmd *Command, next CommandHandler) CommandHandler {
+	if cmd.RateLimit.PerUser <= 0 && cmd.RateLimit.PerCommand <= 0 {
+		return next
+	}
+
+	return func(source AppID, callerID string, args ...string) (*CommandResult, error) {
+		if wait, ok := be.limiter.take(cmd.Name, callerID, cmd.RateLimit, time.Now()); !ok {
+			return MakeFailedResult(be.MessageFor(callerID, MsgCooldown, wait)), nil
+		}
+
+		return next(source, callerID, args...)
+	}
+}
+
+// rateLimiter is the shared store of the limits, the zero value is ready to use.
+type rateLimiter struct {
+	lk    sync.Mutex
+	until map[string]time.Time
+}
+
+// take records an execution of the command by the caller if the limits allow it,
+// otherwise it returns the remaining time, rounded up to the seconds.
+func (rl *rateLimiter) take(cmdName, callerID string, limit RateLimit, now time.Time) (time.Duration, bool) {
+	rl.lk.Lock()
+	defer rl.lk.Unlock()
+
+	if rl.until == nil {
+		rl.until = make(map[string]time.Time)
+	}
+
+	userKey := cmdName + "/user/" + callerID
+	cmdKey := cmdName + "/command"
+
+	wait := max(rl.until[userKey].Sub(now), rl.until[cmdKey].Sub(now))
+	if wait > 0 {
+		return (wait + time.Second - 1).Truncate(time.Second), false
+	}
+
+	// the expired entries are removed from time to time to keep the memory bounded.
+	if len(rl.until) >= 1024 {
+		for key, until := range rl.until {
+			if !until.After(now) {
+				delete(rl.until, key)
+			}
+		}
+	}
+
+	if limit.PerUser > 0 {
+		rl.until[userKey] = now.Add(limit.PerUser)
+	}
+	if limit.PerCommand > 0 {
+		rl.until[cmdKey] = now.Add(limit.PerCommand)
+	}
+
+	return 0, true
+}
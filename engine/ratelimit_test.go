@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	rl := &rateLimiter{}
+	now := time.Unix(1700000000, 0)
+	limit := RateLimit{PerUser: 30 * time.Second}
+
+	_, ok := rl.take("network", "alice", limit, now)
+	assert.True(t, ok)
+
+	wait, ok := rl.take("network", "alice", limit, now.Add(10*time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, 20*time.Second, wait)
+
+	wait, ok = rl.take("network", "alice", limit, now.Add(10*time.Second+time.Millisecond))
+	assert.False(t, ok)
+	assert.Equal(t, 20*time.Second, wait, "rounded up to the seconds")
+
+	_, ok = rl.take("network", "bob", limit, now.Add(10*time.Second))
+	assert.True(t, ok, "the users are limited separately")
+
+	_, ok = rl.take("peers", "alice", limit, now.Add(10*time.Second))
+	assert.True(t, ok, "the commands are limited separately")
+
+	_, ok = rl.take("network", "alice", limit, now.Add(30*time.Second))
+	assert.True(t, ok)
+}
+
+func TestRateLimiterPerCommand(t *testing.T) {
+	rl := &rateLimiter{}
+	now := time.Unix(1700000000, 0)
+	limit := RateLimit{PerCommand: time.Minute}
+
+	_, ok := rl.take("announce", "alice", limit, now)
+	assert.True(t, ok)
+
+	wait, ok := rl.take("announce", "bob", limit, now.Add(15*time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, 45*time.Second, wait)
+}
+
+func TestRunRateLimit(t *testing.T) {
+	calls := 0
+	be := setupTestEngine(t, Command{
+		Name:   "limited",
+		AppIDs: []AppID{AppIdCLI},
+		Handler: func(_ AppID, _ string, _ ...string) (*CommandResult, error) {
+			calls++
+
+			return MakeSuccessfulResult("ok"), nil
+		},
+		RateLimit: RateLimit{PerUser: time.Minute},
+	})
+
+	res, err := be.Run(AppIdCLI, "1", []string{"limited"})
+	require.NoError(t, err)
+	assert.True(t, res.Successful)
+
+	res, err = be.Run(AppIdCLI, "1", []string{"limited"})
+	require.NoError(t, err)
+	assert.False(t, res.Successful)
+	assert.Contains(t, res.Message, "Try again in 1m0s")
+	assert.Equal(t, 1, calls)
+
+	res, err = be.Run(AppIdCLI, "2", []string{"limited"})
+	require.NoError(t, err)
+	assert.True(t, res.Successful)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,174 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/kehiy/RoboPac/audit"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/referral"
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/utils"
+	"github.com/pactus-project/pactus/util"
+)
+
+const referralPayoutJobName = "referral-payouts"
+
+// enableReferrals opens the storage of the referrals and schedules their payouts,
+// if the referrals are enabled in the config.
+func (be *BotEngine) enableReferrals(cfg *config.Config) error {
+	if cfg.Referral.Reward <= 0 {
+		return nil
+	}
+
+	referrers, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "referrers")
+	if err != nil {
+		return err
+	}
+
+	referred, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "referrals")
+	if err != nil {
+		return err
+	}
+
+	be.referrals = referral.NewReferrals(be.wallet, be.clientMgr, referrers, referred,
+		cfg.Referral.Reward, cfg.Referral.MinBalance)
+
+	return be.Schedule(scheduler.Job{
+		Name: referralPayoutJobName,
+		Spec: cfg.Referral.PayoutSchedule,
+		Run:  func(_ context.Context) { be.payReferrals() },
+	})
+}
+
+func (be *BotEngine) createReferralHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	referrer, err := be.referrals.Code(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := MakeSuccessfulResult("Your referral code is `%s`, the users can claim it with `%s %s`",
+		referrer.Code, ClaimReferralCommandName, referrer.Code)
+	res.Title = "Referral"
+	res.AddField("Reward", util.ChangeToString(be.referrals.Reward())+" PAC per referred user", false)
+	res.AddField("Referred", strconv.Itoa(referrer.Referred), true)
+	res.AddField("Rewarded", strconv.Itoa(referrer.Rewarded), true)
+	res.AddField("Rewards", util.ChangeToString(referrer.Rewards)+" PAC", true)
+
+	return res, nil
+}
+
+// claimReferralHandler records the caller as referred by the code, by their linked account address.
+func (be *BotEngine) claimReferralHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	profile, err := be.profileOf(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := profile.AccountAddresses()
+	if len(addrs) == 0 {
+		res := MakeFailedResult("Link your account address with `%s` first, to claim a referral code",
+			LinkAddressCommandName)
+		res.Suggest(LinkAddressCommandName)
+
+		return res, nil
+	}
+
+	referrer, err := be.referrals.Claim(callerID, args[0], addrs)
+	if err != nil {
+		var balanceErr referral.BalanceError
+		if errors.As(err, &balanceErr) || errors.Is(err, referral.ErrCodeNotFound) ||
+			errors.Is(err, referral.ErrOwnCode) || errors.Is(err, referral.ErrAlreadyClaimed) {
+			return MakeFailedResult("%s", err.Error()), nil
+		}
+
+		return nil, err
+	}
+
+	return MakeSuccessfulResult("You are referred by <@%s>, thanks for joining 🎉", referrer.UserID), nil
+}
+
+// payReferrals sends the pending rewards, one transfer for each referrer to their first linked account address.
+// The referrers without a linked account address are paid once they link one.
+func (be *BotEngine) payReferrals() {
+	referrerIDs, err := be.referrals.Pending()
+	if err != nil {
+		be.logger.Error("unable to read the referrals", "err", err)
+
+		return
+	}
+
+	for _, referrerID := range referrerIDs {
+		if err := be.payReferrer(referrerID); err != nil {
+			be.logger.Error("unable to pay the referral rewards", "err", err, "referrerID", referrerID)
+		}
+	}
+}
+
+func (be *BotEngine) payReferrer(referrerID string) error {
+	profile, err := be.profileOf(referrerID)
+	if err != nil {
+		return err
+	}
+
+	addrs := profile.AccountAddresses()
+	if len(addrs) == 0 {
+		be.logger.Info("referrer has no linked account address, the rewards are pending", "referrerID", referrerID)
+
+		return nil
+	}
+
+	txID, amount, err := be.referrals.Pay(referrerID, addrs[0])
+	if amount > 0 {
+		be.recordAudit(audit.Entry{
+			Kind:     audit.KindReferral,
+			CallerID: referrerID,
+			Inputs:   []string{referralPayoutJobName, addrs[0], util.ChangeToString(amount) + " PAC"},
+			TxID:     txID,
+			Error:    auditError(nil, err),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	be.logger.Info("referral rewards sent", "referrerID", referrerID, "amount", amount, "txID", txID)
+
+	return nil
+}
+
+// referralReportHandler reports the referrers by the number of their referred users.
+func (be *BotEngine) referralReportHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	referrers, err := be.referrals.Referrers()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(referrers) == 0 {
+		return MakeFailedResult(be.MessageFor(callerID, MsgNoResults)), nil
+	}
+
+	var referred, rewarded int
+	var rewards int64
+	res := MakeSuccessfulResult("The referrers by the number of their referred users")
+	res.Title = "Referral Report"
+	res.SetTable("Referrer", "Code", "Referred", "Pending", "Rewards")
+	for _, referrer := range referrers {
+		referred += referrer.Referred
+		rewarded += referrer.Rewarded
+		rewards += referrer.Rewards
+
+		res.AddRow(referrer.UserID, referrer.Code, strconv.Itoa(referrer.Referred),
+			strconv.Itoa(referrer.Referred-referrer.Rewarded), util.ChangeToString(referrer.Rewards)+" PAC")
+	}
+
+	res.AddField("Referrers", utils.FormatNumber(int64(len(referrers))), true)
+	res.AddField("Referred Users", utils.FormatNumber(int64(referred)), true)
+	res.AddField("Pending Rewards",
+		util.ChangeToString(be.referrals.Reward()*int64(referred-rewarded))+" PAC", true)
+	res.AddField("Sent Rewards", util.ChangeToString(rewards)+" PAC", true)
+
+	return res, nil
+}
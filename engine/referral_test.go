@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/referral"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/wallet"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReferrals(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	be.AuthIDs = []string{"admin"}
+	mockWallet := wallet.NewMockIWallet(gomock.NewController(t))
+	be.wallet = mockWallet
+
+	profiles, err := store.NewJSONKV(path.Join(t.TempDir(), "user_profiles.json"))
+	require.NoError(t, err)
+	be.profiles = profiles
+
+	referrers, err := store.NewJSONKV(path.Join(t.TempDir(), "referrers.json"))
+	require.NoError(t, err)
+	referred, err := store.NewJSONKV(path.Join(t.TempDir(), "referrals.json"))
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 24, 12, 0, 0, 0, time.UTC)
+	be.referrals = referral.NewReferrals(mockWallet, be.clientMgr, referrers, referred, 2e9, 1e9)
+
+	be.RegisterCommands()
+
+	addrOf := func(b byte) string {
+		return crypto.NewAddress(crypto.AddressTypeBLSAccount, append(make([]byte, 19), b)).String()
+	}
+	link := func(userID, address string) {
+		require.NoError(t, be.saveProfile(&Profile{
+			UserID:    userID,
+			Addresses: []LinkedAddress{{Address: address, LinkedAt: now.Unix()}},
+		}))
+	}
+
+	res, err := be.Run(AppIdDiscord, "alice", []string{CreateReferralCommandName})
+	require.NoError(t, err)
+	require.True(t, res.Successful)
+	referrer, err := be.referrals.ReferrerOf("alice")
+	require.NoError(t, err)
+	code := referrer.Code
+	assert.Len(t, code, referral.CodeLength)
+	assert.Contains(t, res.Message, code)
+
+	t.Run("same code", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "alice", []string{CreateReferralCommandName})
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, code)
+	})
+
+	t.Run("no linked address", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "bob", []string{ClaimReferralCommandName, code})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Equal(t, []string{LinkAddressCommandName}, res.Suggestions)
+	})
+
+	link("alice", addrOf(1))
+	link("bob", addrOf(2))
+	link("carol", addrOf(2))
+
+	t.Run("own code", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "alice", []string{ClaimReferralCommandName, code})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "bob", []string{ClaimReferralCommandName, "XXXXXXXX"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("not discord", func(t *testing.T) {
+		_, err := be.Run(AppIdTelegram, "bob", []string{ClaimReferralCommandName, code})
+		assert.Error(t, err, "the account ages are checked on Discord only")
+	})
+
+	t.Run("empty address", func(t *testing.T) {
+		mockClient.EXPECT().GetBalance(gomock.Any(), addrOf(2)).Return(int64(0), nil)
+
+		res, err := be.Run(AppIdDiscord, "bob", []string{ClaimReferralCommandName, code})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "needs a balance of at least 1 PAC")
+	})
+
+	t.Run("claim", func(t *testing.T) {
+		mockClient.EXPECT().GetBalance(gomock.Any(), addrOf(2)).Return(int64(5e9), nil)
+
+		res, err := be.Run(AppIdDiscord, "bob", []string{ClaimReferralCommandName, " " + code})
+		require.NoError(t, err)
+		assert.True(t, res.Successful, res.Message)
+		assert.Contains(t, res.Message, "<@alice>")
+
+		res, err = be.Run(AppIdDiscord, "bob", []string{ClaimReferralCommandName, code})
+		require.NoError(t, err)
+		assert.False(t, res.Successful, "each user claims once")
+
+		res, err = be.Run(AppIdDiscord, "carol", []string{ClaimReferralCommandName, code})
+		require.NoError(t, err)
+		assert.False(t, res.Successful, "each address claims once")
+	})
+
+	t.Run("payout", func(t *testing.T) {
+		// dave refers erin, but dave has no linked address, so the reward is pending.
+		_, err := be.Run(AppIdDiscord, "dave", []string{CreateReferralCommandName})
+		require.NoError(t, err)
+		dave, err := be.referrals.ReferrerOf("dave")
+		require.NoError(t, err)
+		link("erin", addrOf(3))
+		mockClient.EXPECT().GetBalance(gomock.Any(), addrOf(3)).Return(int64(5e9), nil)
+		res, err := be.Run(AppIdDiscord, "erin", []string{ClaimReferralCommandName, dave.Code})
+		require.NoError(t, err)
+		require.True(t, res.Successful)
+
+		mockWallet.EXPECT().Balance().Return(int64(10e9))
+		mockWallet.EXPECT().TransferTransaction(addrOf(1), int64(2e9), gomock.Any()).Return("0x123", nil)
+		be.payReferrals()
+
+		referrer, err := be.referrals.ReferrerOf("alice")
+		require.NoError(t, err)
+		assert.Equal(t, 1, referrer.Rewarded)
+		assert.Equal(t, int64(2e9), referrer.Rewards)
+
+		bob, err := store.GetJSON[referral.Referral](referred, "bob")
+		require.NoError(t, err)
+		assert.Equal(t, "0x123", bob.TxID)
+
+		// the sent rewards are not sent again.
+		be.payReferrals()
+	})
+
+	t.Run("report", func(t *testing.T) {
+		_, err := be.Run(AppIdDiscord, "alice", []string{ReferralReportCommandName})
+		assert.Error(t, err)
+
+		res, err := be.Run(AppIdDiscord, "admin", []string{ReferralReportCommandName})
+		require.NoError(t, err)
+		require.Len(t, res.Table.Rows, 2)
+		assert.Equal(t, []string{"alice", code, "1", "0", "2 PAC"}, res.Table.Rows[0])
+		assert.Equal(t, "dave", res.Table.Rows[1][0])
+		assert.Equal(t, "2", res.Fields[1].Value)
+		assert.Equal(t, "2 PAC", res.Fields[2].Value, "the reward of dave is pending")
+	})
+}
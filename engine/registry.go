@@ -0,0 +1,37 @@
+package engine
+
+// RegistryInfo describes the live configuration of the registered commands.
+type RegistryInfo struct {
+	Total      int
+	PerApp     map[AppID]int
+	Disabled   []string
+	Deprecated []string
+	Confirmed  []string
+}
+
+// RegistryInfo returns the introspection of the command registry.
+// Disabled only contains the globally disabled commands.
+func (be *BotEngine) RegistryInfo() *RegistryInfo {
+	cmds := be.Commands()
+	info := &RegistryInfo{
+		Total:    len(cmds),
+		PerApp:   make(map[AppID]int),
+		Disabled: be.toggles.globallyDisabled(),
+	}
+
+	for _, cmd := range cmds {
+		for _, appID := range cmd.AppIDs {
+			info.PerApp[appID]++
+		}
+
+		if cmd.Deprecated {
+			info.Deprecated = append(info.Deprecated, cmd.Name)
+		}
+
+		if cmd.ConfirmPhrase != "" {
+			info.Confirmed = append(info.Confirmed, cmd.Name)
+		}
+	}
+
+	return info
+}
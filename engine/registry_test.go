@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryInfo(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "cmd-1", AppIDs: []AppID{AppIdCLI, AppIdDiscord}},
+		Command{Name: "cmd-2", AppIDs: []AppID{AppIdDiscord}, Deprecated: true},
+		Command{Name: "cmd-3", AppIDs: []AppID{AppIdCLI}, ConfirmPhrase: "confirm"},
+		Command{Name: "cmd-4", AppIDs: []AppID{AppIdDiscord}},
+	)
+	be.AuthIDs = []string{"admin"}
+
+	require.NoError(t, be.SetCommandEnabled("cmd-4", "", false))
+	require.NoError(t, be.SetCommandEnabled("cmd-1", "guild-1", false))
+
+	info := be.RegistryInfo()
+	assert.Equal(t, 4, info.Total)
+	assert.Equal(t, 2, info.PerApp[AppIdCLI])
+	assert.Equal(t, 3, info.PerApp[AppIdDiscord])
+	assert.Equal(t, []string{"cmd-4"}, info.Disabled)
+	assert.Equal(t, []string{"cmd-2"}, info.Deprecated)
+	assert.Equal(t, []string{"cmd-3"}, info.Confirmed)
+
+	t.Run("rendered fields", func(t *testing.T) {
+		res, err := be.commandsHandler(AppIdDiscord, "admin")
+		require.NoError(t, err)
+
+		assert.Equal(t, "4 commands are registered", res.Message)
+		assert.Equal(t, []ResultField{
+			{Name: "CLI", Value: "2", Inline: true},
+			{Name: "Discord", Value: "3", Inline: true},
+			{Name: "Telegram", Value: "0", Inline: true},
+			{Name: "HTTP", Value: "0", Inline: true},
+			{Name: "Matrix", Value: "0", Inline: true},
+			{Name: "Slack", Value: "0", Inline: true},
+			{Name: "Disabled", Value: "`cmd-4`"},
+			{Name: "Deprecated", Value: "`cmd-2`"},
+			{Name: "Confirmation Required", Value: "`cmd-3`"},
+		}, res.Fields)
+	})
+}
@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/log"
+)
+
+// ConfigLoader loads the config again, from the same source that the bot started with.
+type ConfigLoader func() (*config.Config, error)
+
+// configReload keeps how the config is reloaded and who is notified, the zero value can't reload.
+type configReload struct {
+	lk      sync.Mutex
+	loader  ConfigLoader
+	onApply []func(*config.Config)
+}
+
+// SetConfigLoader enables reloading the config, by SIGHUP or the reload-config command.
+func (be *BotEngine) SetConfigLoader(loader ConfigLoader) {
+	be.reload.lk.Lock()
+	defer be.reload.lk.Unlock()
+
+	be.reload.loader = loader
+}
+
+// OnConfigReload adds a function that applies the reloaded config, like the settings of the apps.
+// The secrets and the settings that are used at start, like the tokens and the nodes, need a restart.
+func (be *BotEngine) OnConfigReload(fn func(*config.Config)) {
+	be.reload.lk.Lock()
+	defer be.reload.lk.Unlock()
+
+	be.reload.onApply = append(be.reload.onApply, fn)
+}
+
+// ReloadConfig loads the config again and applies the settings that can change at runtime.
+// If the config is invalid, nothing is changed.
+func (be *BotEngine) ReloadConfig() error {
+	be.reload.lk.Lock()
+	defer be.reload.lk.Unlock()
+
+	if be.reload.loader == nil {
+		return errors.New("reloading the config is not available")
+	}
+
+	cfg, err := be.reload.loader()
+	if err != nil {
+		return err
+	}
+
+	if err := be.applyConfig(cfg); err != nil {
+		return err
+	}
+
+	for _, fn := range be.reload.onApply {
+		fn(cfg)
+	}
+
+	be.logger.Info("config reloaded")
+
+	return nil
+}
+
+// applyConfig applies the settings of the engine that can change at runtime.
+// All the settings are checked before any of them is applied.
+func (be *BotEngine) applyConfig(cfg *config.Config) error {
+	allow, err := appLists(cfg.CommandAccess.Allow)
+	if err != nil {
+		return fmt.Errorf("COMMANDS_ALLOW is invalid: %w", err)
+	}
+
+	deny, err := appLists(cfg.CommandAccess.Deny)
+	if err != nil {
+		return fmt.Errorf("COMMANDS_DENY is invalid: %w", err)
+	}
+
+	statusEntries, err := parseStatusEntries(cfg.Status.Entries)
+	if err != nil {
+		return fmt.Errorf("STATUS_ENTRIES is invalid: %w", err)
+	}
+
+	messages := NewMessageCatalog()
+	if cfg.MessagesPath != "" {
+		if err := messages.LoadFile(cfg.MessagesPath); err != nil {
+			return err
+		}
+		be.logger.Info("messages loaded successfully", "path", cfg.MessagesPath)
+	}
+
+	// the levels are checked and applied last, so they aren't changed if the other settings are invalid.
+	if err := log.SetLevels(cfg.Log.Levels); err != nil {
+		return fmt.Errorf("LOG_LEVELS is invalid: %w", err)
+	}
+
+	be.setStatusEntries(statusEntries)
+	be.SetCircuitBreaker(cfg.CircuitBreaker.Threshold, cfg.CircuitBreaker.Cooldown)
+	be.SetInputLimits(cfg.InputLimits.MaxArgs, cfg.InputLimits.MaxArgLength)
+	be.SetBurstLimit(cfg.Moderation.BurstLimit, cfg.Moderation.BurstWindow, cfg.Moderation.Throttle)
+	for _, appID := range []AppID{AppIdCLI, AppIdDiscord, AppIdTelegram, AppIdHTTP, AppIdMatrix, AppIdSlack} {
+		be.SetAppAllowList(appID, allow[appID])
+		be.SetAppDenyList(appID, deny[appID])
+	}
+	be.messages.replace(messages)
+
+	return nil
+}
+
+// appLists returns the lists of the commands keyed by the apps.
+func appLists(lists map[string][]string) (map[AppID][]string, error) {
+	appLists := make(map[AppID][]string, len(lists))
+	for name, cmdNames := range lists {
+		appID, err := ParseAppID(name)
+		if err != nil {
+			return nil, err
+		}
+		appLists[appID] = append(appLists[appID], cmdNames...)
+	}
+
+	return appLists, nil
+}
+
+func (be *BotEngine) reloadConfigHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	if err := be.ReloadConfig(); err != nil {
+		return MakeFailedResult("Unable to reload the config: %v", err), nil
+	}
+	be.logger.Info("config reloaded by command", "callerID", callerID)
+
+	return MakeSuccessfulResult("The config is reloaded, the secrets and the nodes still need a restart"), nil
+}
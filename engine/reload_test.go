@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadConfig(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "ok", AppIDs: []AppID{AppIdCLI, AppIdDiscord}, Handler: okHandler},
+	)
+
+	t.Run("no loader", func(t *testing.T) {
+		assert.Error(t, be.ReloadConfig())
+	})
+
+	cfg := &config.Config{
+		InputLimits:   config.InputLimitsConfig{MaxArgs: 1},
+		CommandAccess: config.CommandAccessConfig{Deny: map[string][]string{"discord": {"ok"}}},
+	}
+	var loadErr error
+	be.SetConfigLoader(func() (*config.Config, error) {
+		return cfg, loadErr
+	})
+
+	applied := 0
+	be.OnConfigReload(func(*config.Config) {
+		applied++
+	})
+
+	t.Run("successful reload", func(t *testing.T) {
+		res, err := be.reloadConfigHandler(AppIdCLI, "1")
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, 1, applied)
+
+		_, err = be.Run(AppIdCLI, "1", []string{"ok", "a", "b"})
+		assert.Error(t, err)
+		assert.False(t, be.IsCommandAllowed("ok", AppIdDiscord))
+	})
+
+	t.Run("the lists are reset", func(t *testing.T) {
+		cfg = &config.Config{}
+		require.NoError(t, be.ReloadConfig())
+		assert.True(t, be.IsCommandAllowed("ok", AppIdDiscord))
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		cfg = &config.Config{
+			InputLimits:   config.InputLimitsConfig{MaxArgs: 5},
+			CommandAccess: config.CommandAccessConfig{Allow: map[string][]string{"unknown": {"ok"}}},
+		}
+		assert.Error(t, be.ReloadConfig())
+
+		cfg = &config.Config{
+			Status:       config.StatusConfig{Entries: []string{"height: {{.Height}}"}},
+			MessagesPath: filepath.Join(t.TempDir(), "missing.json"),
+		}
+		assert.Error(t, be.ReloadConfig())
+		assert.Empty(t, be.StatusEntries(), "nothing is applied if the messages can't be loaded")
+
+		loadErr = errors.New("invalid file")
+		res, err := be.reloadConfigHandler(AppIdCLI, "1")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Equal(t, 2, applied)
+	})
+}
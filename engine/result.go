@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ResultTable is the tabular data of a result. The apps without tables, like Discord,
+// render it as a code block, see ResultTable.String.
+type ResultTable struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// ResultAttachment is a file that is sent along with the result, on the apps that support it.
+type ResultAttachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// String renders the table as plain text with aligned columns, the headers are underlined.
+// The missing cells of the short rows are left empty.
+func (t *ResultTable) String() string {
+	widths := make([]int, len(t.Headers))
+	for _, row := range append([][]string{t.Headers}, t.Rows...) {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	lines := make([]string, 0, len(t.Rows)+2)
+	if len(t.Headers) > 0 {
+		lines = append(lines, tableLine(t.Headers, widths))
+
+		dashes := make([]string, len(widths))
+		for i, width := range widths {
+			dashes[i] = strings.Repeat("-", width)
+		}
+		lines = append(lines, strings.Join(dashes, "  "))
+	}
+
+	for _, row := range t.Rows {
+		lines = append(lines, tableLine(row, widths))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func tableLine(cells []string, widths []int) string {
+	sb := strings.Builder{}
+	for i, width := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(cell)
+		sb.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(cell)))
+	}
+
+	return strings.TrimRight(sb.String(), " ")
+}
@@ -0,0 +1,33 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultTable(t *testing.T) {
+	res := MakeSuccessfulResult("validators")
+	res.SetTable("Address", "Stake")
+	res.AddRow("pc1p...a", "1,000")
+	res.AddRow("pc1p...bcd", "25")
+	res.AddRow("pc1p...e")
+
+	assert.Equal(t, "Address     Stake\n"+
+		"----------  -----\n"+
+		"pc1p...a    1,000\n"+
+		"pc1p...bcd  25\n"+
+		"pc1p...e", res.Table.String())
+
+	noHeaders := &ResultTable{Rows: [][]string{{"a", "b"}, {"ccc", "d"}}}
+	assert.Equal(t, "a    b\nccc  d", noHeaders.String())
+}
+
+func TestResultAttachment(t *testing.T) {
+	res := MakeSuccessfulResult("exported")
+	res.Attach("peers.csv", "text/csv", []byte("id,address\n"))
+
+	assert.Equal(t, "peers.csv", res.Attachment.Name)
+	assert.Equal(t, "text/csv", res.Attachment.ContentType)
+	assert.Equal(t, []byte("id,address\n"), res.Attachment.Data)
+}
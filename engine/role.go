@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Role is the permission level of a caller.
+type Role int
+
+const (
+	RoleUser Role = iota
+	RoleAdmin
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleUser:
+		return "user"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(r))
+	}
+}
+
+// Authorizer resolves the role of the callers on the engine side.
+// The apps can grant a higher role through RunOptions, like the Discord admin roles.
+type Authorizer interface {
+	Role(appID AppID, callerID string) Role
+}
+
+// authIDsAuthorizer makes the callers in the AuthIDs admins on all the apps.
+type authIDsAuthorizer struct {
+	be *BotEngine
+}
+
+func (a authIDsAuthorizer) Role(_ AppID, callerID string) Role {
+	if slices.Contains(a.be.AuthIDs, callerID) {
+		return RoleAdmin
+	}
+
+	return RoleUser
+}
+
+// SetAuthorizer replaces the authorizer of the engine. By default, the callers in the AuthIDs are admins.
+func (be *BotEngine) SetAuthorizer(authorizer Authorizer) {
+	be.authorizer = authorizer
+}
+
+// callerRole returns the higher role of the one granted by the app and the one resolved by the authorizer.
+func (be *BotEngine) callerRole(appID AppID, callerID string, granted Role) Role {
+	var authorizer Authorizer = authIDsAuthorizer{be: be}
+	if be.authorizer != nil {
+		authorizer = be.authorizer
+	}
+
+	return max(granted, authorizer.Role(appID, callerID))
+}
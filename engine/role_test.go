@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAuthorizer map[string]Role
+
+func (a fakeAuthorizer) Role(_ AppID, callerID string) Role {
+	return a[callerID]
+}
+
+func TestMinRole(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "admin-cmd", AppIDs: []AppID{AppIdDiscord}, Handler: okHandler, MinRole: RoleAdmin},
+		Command{Name: "user-cmd", AppIDs: []AppID{AppIdDiscord}, Handler: okHandler},
+	)
+	be.AuthIDs = []string{"admin"}
+
+	t.Run("users", func(t *testing.T) {
+		_, err := be.Run(AppIdDiscord, "user", []string{"admin-cmd"})
+		assert.EqualError(t, err, be.Message(MsgUnauthorized))
+
+		_, err = be.Run(AppIdDiscord, "user", []string{"user-cmd"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("auth IDs", func(t *testing.T) {
+		_, err := be.Run(AppIdDiscord, "admin", []string{"admin-cmd"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("granted by the app", func(t *testing.T) {
+		_, err := be.RunWithOptions(RunOptions{Role: RoleAdmin}, AppIdDiscord, "moderator", []string{"admin-cmd"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom authorizer", func(t *testing.T) {
+		be.SetAuthorizer(fakeAuthorizer{"moderator": RoleAdmin})
+		defer be.SetAuthorizer(nil)
+
+		_, err := be.Run(AppIdDiscord, "moderator", []string{"admin-cmd"})
+		assert.NoError(t, err)
+
+		// the auth IDs are resolved by the default authorizer only.
+		_, err = be.Run(AppIdDiscord, "admin", []string{"admin-cmd"})
+		assert.Error(t, err)
+	})
+}
+
+func TestAdminCommands(t *testing.T) {
+	be := setupTestEngine(t)
+	be.RegisterCommands()
+
+	for _, name := range []string{
+		CommandsCommandName, NodeCommandName, NodeDiffCommandName, ToggleCommandCommandName,
+		MaintenanceCommandName, DiagCommandName, BoosterWhitelistCommandName,
+	} {
+		cmd := be.FindCommand(name)
+		require.NotNil(t, cmd, name)
+		assert.Equal(t, RoleAdmin, cmd.MinRole, name)
+	}
+}
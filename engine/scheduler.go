@@ -0,0 +1,19 @@
+package engine
+
+import "github.com/kehiy/RoboPac/scheduler"
+
+// Schedule runs the job periodically, see scheduler.Job.
+// The scheduled jobs start running after the engine is started.
+func (be *BotEngine) Schedule(job scheduler.Job) error {
+	return be.scheduler.Add(job)
+}
+
+// Unschedule stops running the job periodically.
+func (be *BotEngine) Unschedule(name string) {
+	be.scheduler.Remove(name)
+}
+
+// ScheduledJobs returns the state of the scheduled jobs.
+func (be *BotEngine) ScheduledJobs() []scheduler.JobStats {
+	return be.scheduler.Stats()
+}
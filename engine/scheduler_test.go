@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule(t *testing.T) {
+	be := setupTestEngine(t)
+
+	t.Run("invalid spec", func(t *testing.T) {
+		err := be.Schedule(scheduler.Job{Name: "invalid", Spec: "not a cron expression", Run: func(context.Context) {}})
+		assert.Error(t, err)
+	})
+
+	t.Run("jobs run after start", func(t *testing.T) {
+		runs := atomic.Int32{}
+		err := be.Schedule(scheduler.Job{Name: "counter", Spec: "@every 1s", Run: func(context.Context) { runs.Add(1) }})
+		require.NoError(t, err)
+
+		require.NoError(t, be.scheduler.Start(context.Background()))
+		defer be.scheduler.Stop()
+
+		assert.Eventually(t, func() bool { return runs.Load() > 0 }, 3*time.Second, 10*time.Millisecond)
+		assert.Len(t, be.ScheduledJobs(), 1)
+
+		be.Unschedule("counter")
+		assert.Empty(t, be.ScheduledJobs())
+	})
+}
@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kehiy/RoboPac/chart"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/scheduler"
+	"github.com/kehiy/RoboPac/snapshot"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/utils"
+)
+
+const (
+	snapshotJobName    = "snapshot"
+	defaultChartPeriod = "7d"
+)
+
+// chartPeriod is a period that the charts can be drawn for, the longest one is kept by snapshot.DefaultRetention.
+type chartPeriod struct {
+	Name     string
+	Duration time.Duration
+}
+
+var chartPeriods = []chartPeriod{
+	{Name: "1d", Duration: 24 * time.Hour},
+	{Name: "7d", Duration: 7 * 24 * time.Hour},
+	{Name: "30d", Duration: 30 * 24 * time.Hour},
+	{Name: "90d", Duration: 90 * 24 * time.Hour},
+}
+
+func chartPeriodNames() []string {
+	names := make([]string, 0, len(chartPeriods))
+	for _, p := range chartPeriods {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+func findChartPeriod(name string) (chartPeriod, bool) {
+	for _, p := range chartPeriods {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return chartPeriod{}, false
+}
+
+// metricTitle returns the title and the unit of the metric.
+func metricTitle(metric string) (string, string) {
+	switch metric {
+	case snapshot.MetricHeight:
+		return "Block Height", ""
+	case snapshot.MetricSupply:
+		return "Circulating Supply", " PAC"
+	case snapshot.MetricValidators:
+		return "Validators", ""
+	case snapshot.MetricPower:
+		return "Total Power", " PAC"
+	default:
+		return metric, ""
+	}
+}
+
+// enableSnapshots schedules recording the network metrics for the charts.
+func (be *BotEngine) enableSnapshots(cfg *config.Config) error {
+	kv, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "network_snapshots")
+	if err != nil {
+		return err
+	}
+
+	be.snapshots = snapshot.NewRecorder(be.clientMgr, kv)
+
+	return be.Schedule(scheduler.Job{
+		Name: snapshotJobName,
+		Spec: fmt.Sprintf("@every %s", cfg.Snapshot.Interval),
+		Run: func(_ context.Context) {
+			if err := be.snapshots.Record(); err != nil {
+				be.logger.Warn("unable to record the network snapshot", "err", err)
+			}
+		},
+	})
+}
+
+// chartHandler draws the chart of the metric over the period, from the recorded snapshots.
+func (be *BotEngine) chartHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	metric := args[0]
+	periodName := defaultChartPeriod
+	if len(args) > 1 {
+		periodName = args[1]
+	}
+
+	period, ok := findChartPeriod(periodName)
+	if !ok {
+		return MakeFailedResult("Invalid period: %s", periodName), nil
+	}
+
+	snapshots, err := be.snapshots.Since(time.Now().Add(-period.Duration))
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]chart.Point, 0, len(snapshots))
+	for _, s := range snapshots {
+		// the supply is not recorded when it's not available.
+		if metric == snapshot.MetricSupply && s.Supply == 0 {
+			continue
+		}
+
+		points = append(points, chart.Point{Time: time.Unix(s.Time, 0), Value: s.Value(metric)})
+	}
+
+	data, err := chart.LineChart(points, chart.DefaultWidth, chart.DefaultHeight)
+	if err != nil {
+		if errors.Is(err, chart.ErrNotEnoughPoints) {
+			return MakeFailedResult("There are not enough snapshots of the last %s to chart yet", period.Name), nil
+		}
+
+		return nil, err
+	}
+
+	title, unit := metricTitle(metric)
+	first, last := points[0].Value, points[len(points)-1].Value
+	lowest, highest := first, first
+	for _, p := range points {
+		lowest = min(lowest, p.Value)
+		highest = max(highest, p.Value)
+	}
+
+	res := MakeSuccessfulResult("%s over the last %s, from %d snapshots", title, period.Name, len(points))
+	res.Title = title
+	res.AddField("Latest", utils.FormatNumber(int64(last))+unit, true)
+	res.AddField("Lowest", utils.FormatNumber(int64(lowest))+unit, true)
+	res.AddField("Highest", utils.FormatNumber(int64(highest))+unit, true)
+	res.AddField("Change", formatChange(int64(last)-int64(first))+unit, true)
+	res.Attach(fmt.Sprintf("%s-%s.png", metric, period.Name), "image/png", data)
+
+	return res, nil
+}
+
+// formatChange formats the change with its sign, like +1,200 or -30.
+func formatChange(change int64) string {
+	if change < 0 {
+		return "-" + utils.FormatNumber(-change)
+	}
+
+	return "+" + utils.FormatNumber(change)
+}
@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"bytes"
+	"image/png"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/client/mock"
+	"github.com/kehiy/RoboPac/snapshot"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSnapshots(t *testing.T) *BotEngine {
+	t.Helper()
+
+	be, _ := setupTestEngineWithNodes(t, "local:50051")
+
+	kv, err := store.NewJSONKV(path.Join(t.TempDir(), "network_snapshots.json"))
+	require.NoError(t, err)
+	be.snapshots = snapshot.NewRecorder(be.clientMgr, kv)
+	be.RegisterCommands()
+
+	return be
+}
+
+func TestChartCommand(t *testing.T) {
+	be := setupSnapshots(t)
+
+	t.Run("not enough snapshots", func(t *testing.T) {
+		require.NoError(t, be.snapshots.Record())
+
+		res, err := be.Run(AppIdDiscord, "1", []string{ChartCommandName, snapshot.MetricHeight})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Nil(t, res.Attachment)
+	})
+
+	t.Run("height", func(t *testing.T) {
+		require.NoError(t, be.snapshots.Save(snapshot.Snapshot{
+			Time:       time.Now().Add(-2 * time.Hour).Unix(),
+			Height:     mock.FixtureHeight - 720,
+			Validators: mock.FixtureValidators - 1,
+		}))
+
+		res, err := be.Run(AppIdDiscord, "1", []string{ChartCommandName, snapshot.MetricHeight, "1d"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "Block Height", res.Title)
+		assert.Equal(t, "1,000", res.Fields[0].Value)
+		assert.Equal(t, "280", res.Fields[1].Value)
+		assert.Equal(t, "+720", res.Fields[3].Value)
+
+		require.NotNil(t, res.Attachment)
+		assert.Equal(t, "height-1d.png", res.Attachment.Name)
+		_, err = png.Decode(bytes.NewReader(res.Attachment.Data))
+		assert.NoError(t, err)
+	})
+
+	t.Run("out of the period", func(t *testing.T) {
+		require.NoError(t, be.snapshots.Save(snapshot.Snapshot{
+			Time:       time.Now().Add(-48 * time.Hour).Unix(),
+			Validators: 2,
+		}))
+
+		res, err := be.Run(AppIdDiscord, "1", []string{ChartCommandName, snapshot.MetricValidators, "1d"})
+		require.NoError(t, err)
+		assert.Equal(t, "+1", res.Fields[3].Value)
+
+		res, err = be.Run(AppIdDiscord, "1", []string{ChartCommandName, snapshot.MetricValidators})
+		require.NoError(t, err)
+		assert.Equal(t, "+2", res.Fields[3].Value, "the default period is a week")
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		_, err := be.Run(AppIdDiscord, "1", []string{ChartCommandName, snapshot.MetricHeight, "1y"})
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatChange(t *testing.T) {
+	assert.Equal(t, "+1,200", formatChange(1200))
+	assert.Equal(t, "-300", formatChange(-300))
+	assert.Equal(t, "+0", formatChange(0))
+}
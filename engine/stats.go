@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	statsWindow     = time.Hour
+	statsBucketSize = time.Minute
+)
+
+type outcomeBucket struct {
+	index     int64
+	succeeded int
+	failed    int
+}
+
+// rollingOutcomes counts command successes and failures over a rolling time window.
+// Each command keeps a fixed ring of buckets, so memory doesn't grow with the traffic.
+type rollingOutcomes struct {
+	lk sync.Mutex
+
+	bucketSize time.Duration
+	numBuckets int
+	buckets    map[string][]outcomeBucket
+	nowFunc    func() time.Time
+}
+
+func newRollingOutcomes(window, bucketSize time.Duration) *rollingOutcomes {
+	if bucketSize <= 0 || window < bucketSize {
+		bucketSize = window
+	}
+
+	return &rollingOutcomes{
+		bucketSize: bucketSize,
+		numBuckets: int(window / bucketSize),
+		buckets:    make(map[string][]outcomeBucket),
+		nowFunc:    time.Now,
+	}
+}
+
+func (ro *rollingOutcomes) currentIndex() int64 {
+	return ro.nowFunc().UnixNano() / int64(ro.bucketSize)
+}
+
+func (ro *rollingOutcomes) record(cmdName string, succeeded bool) {
+	ro.lk.Lock()
+	defer ro.lk.Unlock()
+
+	ring, ok := ro.buckets[cmdName]
+	if !ok {
+		ring = make([]outcomeBucket, ro.numBuckets)
+		ro.buckets[cmdName] = ring
+	}
+
+	index := ro.currentIndex()
+	bucket := &ring[index%int64(len(ring))]
+	if bucket.index != index {
+		*bucket = outcomeBucket{index: index}
+	}
+
+	if succeeded {
+		bucket.succeeded++
+	} else {
+		bucket.failed++
+	}
+}
+
+// counts returns the number of succeeded and failed executions inside the window.
+func (ro *rollingOutcomes) counts(cmdName string) (int, int) {
+	ro.lk.Lock()
+	defer ro.lk.Unlock()
+
+	ring, ok := ro.buckets[cmdName]
+	if !ok {
+		return 0, 0
+	}
+
+	index := ro.currentIndex()
+	oldest := index - int64(len(ring)) + 1
+
+	succeeded, failed := 0, 0
+	for _, b := range ring {
+		if b.index < oldest || b.index > index {
+			continue
+		}
+		succeeded += b.succeeded
+		failed += b.failed
+	}
+
+	return succeeded, failed
+}
+
+func (be *BotEngine) recordOutcome(cmdName string, succeeded bool) {
+	be.outcomes.record(cmdName, succeeded)
+}
+
+// SuccessRate returns the ratio of successful executions of the command in the last hour.
+// It returns 1 if the command hasn't been executed in this window.
+func (be *BotEngine) SuccessRate(cmdName string) float64 {
+	succeeded, failed := be.outcomes.counts(cmdName)
+	total := succeeded + failed
+	if total == 0 {
+		return 1
+	}
+
+	return float64(succeeded) / float64(total)
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuccessRate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	outcomes := newRollingOutcomes(10*time.Minute, time.Minute)
+	outcomes.nowFunc = func() time.Time { return now }
+
+	be := &BotEngine{outcomes: outcomes}
+
+	t.Run("no calls", func(t *testing.T) {
+		assert.Equal(t, float64(1), be.SuccessRate("cmd"))
+	})
+
+	t.Run("within the window", func(t *testing.T) {
+		be.recordOutcome("cmd", false)
+		be.recordOutcome("cmd", false)
+
+		now = now.Add(5 * time.Minute)
+		be.recordOutcome("cmd", true)
+		be.recordOutcome("cmd", true)
+
+		assert.Equal(t, 0.5, be.SuccessRate("cmd"))
+		assert.Equal(t, float64(1), be.SuccessRate("other-cmd"))
+	})
+
+	t.Run("old outcomes leave the window", func(t *testing.T) {
+		now = now.Add(5 * time.Minute)
+		be.recordOutcome("cmd", true)
+
+		// failures are recorded 10 minutes ago and they are out of the window now.
+		succeeded, failed := outcomes.counts("cmd")
+		assert.Equal(t, 3, succeeded)
+		assert.Equal(t, 0, failed)
+		assert.Equal(t, float64(1), be.SuccessRate("cmd"))
+	})
+
+	t.Run("reused bucket is reset", func(t *testing.T) {
+		now = now.Add(10 * time.Minute)
+		be.recordOutcome("cmd", false)
+
+		succeeded, failed := outcomes.counts("cmd")
+		assert.Equal(t, 0, succeeded)
+		assert.Equal(t, 1, failed)
+		assert.Equal(t, float64(0), be.SuccessRate("cmd"))
+	})
+}
+
+func TestDiag(t *testing.T) {
+	be := setupTestEngine(t, Command{Name: "help", Handler: okHandler})
+	be.recordOutcome("help", true)
+	be.recordOutcome("help", false)
+
+	res, err := be.diagHandler(AppIdCLI, "")
+	assert.NoError(t, err)
+	assert.Contains(t, res.Message, "`help`: 50.0% (1/2)")
+}
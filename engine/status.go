@@ -0,0 +1,199 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/kehiy/RoboPac/market"
+	"github.com/kehiy/RoboPac/utils"
+)
+
+// StatusData is the data of the status templates, like "{{.Height}}". The values are formatted,
+// and the amounts are in PAC.
+type StatusData struct {
+	Network    string
+	Height     string
+	Validators string
+	Accounts   string
+	Peers      string
+	CircSupply string
+	Power      string
+	// Price is empty if the market data is not available.
+	Price string
+}
+
+// NewStatusData makes the data of the status templates from the network status and the price, which can be nil.
+func NewStatusData(ns *NetStatus, price *market.Price) StatusData {
+	data := StatusData{
+		Network:    ns.Network,
+		Height:     utils.FormatNumber(int64(ns.CurrentBlockHeight)),
+		Validators: utils.FormatNumber(int64(ns.ValidatorsCount)),
+		Accounts:   utils.FormatNumber(int64(ns.TotalAccounts)),
+		Peers:      utils.FormatNumber(int64(ns.ConnectedPeersCount)),
+		CircSupply: utils.FormatNumber(int64(utils.ChangeToCoin(ns.CirculatingSupply))),
+		Power:      utils.FormatNumber(int64(utils.ChangeToCoin(ns.TotalNetworkPower))),
+	}
+	if price != nil {
+		data.Price = fmt.Sprintf("%s (%+.1f%%)", FormatUSD(price.USD), price.Change24h)
+	}
+
+	return data
+}
+
+// StatusEntry is a template of the bot status, the disabled entries are skipped in the rotation.
+type StatusEntry struct {
+	Template string
+	Enabled  bool
+}
+
+type statusEntry struct {
+	StatusEntry
+	tmpl *template.Template
+}
+
+// statusRotation keeps the status entries that the apps rotate, the zero value has no entries.
+type statusRotation struct {
+	lk      sync.Mutex
+	entries []statusEntry
+}
+
+// SetStatusEntries replaces the status templates, they are enabled unless an entry with the same template
+// was disabled before, so reloading the config keeps the changes of the admins.
+func (be *BotEngine) SetStatusEntries(templates []string) error {
+	entries, err := parseStatusEntries(templates)
+	if err != nil {
+		return err
+	}
+	be.setStatusEntries(entries)
+
+	return nil
+}
+
+func parseStatusEntries(templates []string) ([]statusEntry, error) {
+	entries := make([]statusEntry, 0, len(templates))
+	for i, text := range templates {
+		tmpl, err := template.New("status").Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("status entry %d is invalid: %w", i+1, err)
+		}
+		entries = append(entries, statusEntry{
+			StatusEntry: StatusEntry{Template: text, Enabled: true},
+			tmpl:        tmpl,
+		})
+	}
+
+	return entries, nil
+}
+
+func (be *BotEngine) setStatusEntries(entries []statusEntry) {
+	r := &be.status
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	for i := range entries {
+		for _, old := range r.entries {
+			if old.Template == entries[i].Template {
+				entries[i].Enabled = old.Enabled
+			}
+		}
+	}
+	r.entries = entries
+}
+
+// StatusEntries returns the status entries in order.
+func (be *BotEngine) StatusEntries() []StatusEntry {
+	r := &be.status
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	entries := make([]StatusEntry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, entry.StatusEntry)
+	}
+
+	return entries
+}
+
+// SetStatusEntryEnabled turns the status entry on or off by its number, starting from one.
+func (be *BotEngine) SetStatusEntryEnabled(num int, enabled bool) error {
+	r := &be.status
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	if num < 1 || num > len(r.entries) {
+		return fmt.Errorf("status entry %d is not found, there are %d entries", num, len(r.entries))
+	}
+	r.entries[num-1].Enabled = enabled
+
+	return nil
+}
+
+// RenderStatuses renders the enabled status entries by the data, in order. The entries that render empty,
+// like "{{if .Price}}price: {{.Price}}{{end}}" without the market data, are skipped.
+// It returns no status if no entry is rendered, then the apps can show their default status.
+func (be *BotEngine) RenderStatuses(data StatusData) []string {
+	r := &be.status
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	texts := []string{}
+	for _, entry := range r.entries {
+		if !entry.Enabled {
+			continue
+		}
+
+		var sb strings.Builder
+		if err := entry.tmpl.Execute(&sb, data); err != nil {
+			be.logger.Warn("unable to render the status entry", "template", entry.Template, "err", err)
+
+			continue
+		}
+
+		if text := strings.TrimSpace(sb.String()); text != "" {
+			texts = append(texts, text)
+		}
+	}
+
+	return texts
+}
+
+// statusEntriesHandler lists the status entries, or turns one of them on or off.
+func (be *BotEngine) statusEntriesHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	if len(args) > 0 && args[0] != "" {
+		if len(args) < 2 || (args[1] != "on" && args[1] != "off") {
+			return MakeFailedResult("The state is needed to change the status entry: on | off"), nil
+		}
+
+		num, err := strconv.Atoi(args[0])
+		if err != nil {
+			return MakeFailedResult("Invalid entry number: %s", args[0]), nil
+		}
+
+		if err := be.SetStatusEntryEnabled(num, args[1] == "on"); err != nil {
+			return MakeFailedResult("%s", err.Error()), nil
+		}
+		be.logger.Info("status entry changed", "number", num, "state", args[1], "callerID", callerID)
+	}
+
+	entries := be.StatusEntries()
+	if len(entries) == 0 {
+		return MakeFailedResult("No status entries are configured, see STATUS_ENTRIES"), nil
+	}
+
+	lines := make([]string, 0, len(entries))
+	for i, entry := range entries {
+		state := "on"
+		if !entry.Enabled {
+			state = "off"
+		}
+		lines = append(lines, fmt.Sprintf("%d. [%s] `%s`", i+1, state, entry.Template))
+	}
+
+	res := MakeSuccessfulResult("%s", strings.Join(lines, "\n"))
+	res.Title = "Status Entries"
+
+	return res, nil
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/kehiy/RoboPac/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStatusData(t *testing.T) {
+	ns := &NetStatus{
+		Network:            "mainnet",
+		CurrentBlockHeight: 123_456,
+		ValidatorsCount:    900,
+		CirculatingSupply:  1_234_567 * 1e9,
+	}
+
+	data := NewStatusData(ns, nil)
+	assert.Equal(t, "123,456", data.Height)
+	assert.Equal(t, "1,234,567", data.CircSupply)
+	assert.Empty(t, data.Price)
+
+	data = NewStatusData(ns, &market.Price{USD: 0.35, Change24h: 2.45})
+	assert.Equal(t, "$0.3500 (+2.5%)", data.Price)
+}
+
+func TestStatusRotation(t *testing.T) {
+	be := setupTestEngine(t)
+	data := StatusData{Height: "1,000", Validators: "4"}
+
+	assert.Empty(t, be.RenderStatuses(data), "no entries")
+
+	require.NoError(t, be.SetStatusEntries([]string{
+		"height: {{.Height}}",
+		"{{if .Price}}price: {{.Price}}{{end}}",
+		"validators: {{.Validators}}",
+	}))
+
+	assert.Equal(t, []string{"height: 1,000", "validators: 4"}, be.RenderStatuses(data),
+		"the empty entry is skipped")
+
+	t.Run("disabled entries", func(t *testing.T) {
+		require.NoError(t, be.SetStatusEntryEnabled(1, false))
+		assert.Error(t, be.SetStatusEntryEnabled(4, false))
+		assert.Equal(t, []string{"validators: 4"}, be.RenderStatuses(data))
+
+		require.NoError(t, be.SetStatusEntryEnabled(3, false))
+		assert.Empty(t, be.RenderStatuses(data))
+	})
+
+	t.Run("reload keeps the states", func(t *testing.T) {
+		require.NoError(t, be.SetStatusEntries([]string{"validators: {{.Validators}}", "peers: {{.Peers}}"}))
+
+		entries := be.StatusEntries()
+		assert.Equal(t, []StatusEntry{
+			{Template: "validators: {{.Validators}}", Enabled: false},
+			{Template: "peers: {{.Peers}}", Enabled: true},
+		}, entries)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		assert.Error(t, be.SetStatusEntries([]string{"{{.Height"}))
+		assert.Len(t, be.StatusEntries(), 2, "the entries are not changed")
+	})
+}
+
+func TestStatusEntriesCommand(t *testing.T) {
+	be := setupTestEngine(t)
+	be.AuthIDs = []string{"admin"}
+	be.Cmds = append(be.Cmds, Command{
+		Name:    StatusEntriesCommandName,
+		AppIDs:  []AppID{AppIdCLI},
+		Handler: be.statusEntriesHandler,
+		MinRole: RoleAdmin,
+		Args: []Args{
+			{Name: "number", Type: ArgTypeInteger, MinValue: Bound(1), Optional: true},
+			{Name: "state", Choices: []string{"on", "off"}, Optional: true},
+		},
+	})
+
+	res, err := be.Run(AppIdCLI, "admin", []string{StatusEntriesCommandName})
+	require.NoError(t, err)
+	assert.False(t, res.Successful, "no entries")
+
+	require.NoError(t, be.SetStatusEntries([]string{"height: {{.Height}}", "peers: {{.Peers}}"}))
+
+	_, err = be.Run(AppIdCLI, "user", []string{StatusEntriesCommandName, "1", "off"})
+	assert.Error(t, err)
+
+	res, err = be.Run(AppIdCLI, "admin", []string{StatusEntriesCommandName, "2", "off"})
+	require.NoError(t, err)
+	require.True(t, res.Successful)
+	assert.Equal(t, "1. [on] `height: {{.Height}}`\n2. [off] `peers: {{.Peers}}`", res.Message)
+
+	res, err = be.Run(AppIdCLI, "admin", []string{StatusEntriesCommandName, "3", "on"})
+	require.NoError(t, err)
+	assert.False(t, res.Successful)
+
+	res, err = be.Run(AppIdCLI, "admin", []string{StatusEntriesCommandName, "1"})
+	require.NoError(t, err)
+	assert.False(t, res.Successful, "the state is missing")
+
+	res, err = be.statusEntriesHandler(AppIdCLI, "admin", "2", "yes")
+	require.NoError(t, err)
+	assert.False(t, res.Successful, "the state is invalid")
+	assert.False(t, be.StatusEntries()[1].Enabled)
+}
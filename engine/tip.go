@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/audit"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/pactus-project/pactus/types/tx/payload"
+	"github.com/pactus-project/pactus/util"
+)
+
+const tipMemo = "RoboPac tip"
+
+// TipAccount is the daily total of the tips that a user has sent. The tips are sent from the deposit address
+// of the user, so the deposit balance is on-chain, like for the offers.
+type TipAccount struct {
+	UserID string `json:"user_id"`
+	// Day is the UTC day of the daily total, like 2024-01-24.
+	Day      string `json:"day"`
+	DayTotal int64  `json:"day_total"`
+}
+
+// tips keeps the tip accounts, the tips are sent one at a time, so the daily limits are not exceeded.
+type tips struct {
+	lk sync.Mutex
+
+	accounts   store.KV
+	dailyLimit int64
+	nowFunc    func() time.Time
+}
+
+// enableTips opens the storage of the tip accounts, if the tips are enabled in the config.
+func (be *BotEngine) enableTips(cfg *config.Config) error {
+	if cfg.Tip.DailyLimit <= 0 {
+		return nil
+	}
+
+	accounts, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "tip_accounts")
+	if err != nil {
+		return err
+	}
+
+	be.tips = &tips{
+		accounts:   accounts,
+		dailyLimit: cfg.Tip.DailyLimit,
+		nowFunc:    time.Now,
+	}
+
+	return nil
+}
+
+// account returns the tip account of the user, the daily total is reset on a new day.
+func (t *tips) account(userID string, now time.Time) (*TipAccount, error) {
+	day := now.UTC().Format(time.DateOnly)
+	account := &TipAccount{UserID: userID, Day: day}
+
+	data, err := t.accounts.Get(userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return account, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, account); err != nil {
+		return nil, err
+	}
+
+	if account.Day != day {
+		account.Day = day
+		account.DayTotal = 0
+	}
+
+	return account, nil
+}
+
+func (t *tips) save(account *TipAccount) error {
+	data, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	return t.accounts.Set(account.UserID, data)
+}
+
+func (be *BotEngine) tipHandler(appID AppID, callerID string, args ...string) (*CommandResult, error) {
+	recipientID := UserIDOf(args[0])
+	amount, err := util.StringToChange(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if recipientID == callerID {
+		return MakeFailedResult("You can't tip yourself"), nil
+	}
+
+	profile, err := be.profileOf(recipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := profile.AccountAddresses()
+	if len(addrs) == 0 {
+		return MakeFailedResult("<@%s> has no linked account address, they can link one with `%s`",
+			recipientID, LinkAddressCommandName), nil
+	}
+	recipientAddr := addrs[0]
+
+	user, err := be.db.GetUser(callerID)
+	if err != nil {
+		res := MakeFailedResult("You have no deposit to tip from, create a deposit address with `%s` "+
+			"and send some PAC to it", DepositAddressCommandName)
+		res.Suggest(DepositAddressCommandName)
+
+		return res, nil
+	}
+
+	fee, err := be.clientMgr.CalculateFee(amount, payload.TypeTransfer)
+	if err != nil {
+		return nil, err
+	}
+
+	be.tips.lk.Lock()
+	defer be.tips.lk.Unlock()
+
+	account, err := be.tips.account(callerID, be.tips.nowFunc())
+	if err != nil {
+		return nil, err
+	}
+
+	if account.DayTotal+amount > be.tips.dailyLimit {
+		return MakeFailedResult("The daily tip limit is %s PAC, you can tip %s PAC more today",
+			util.ChangeToString(be.tips.dailyLimit),
+			util.ChangeToString(max(be.tips.dailyLimit-account.DayTotal, 0))), nil
+	}
+
+	deposit, err := be.clientMgr.GetBalance(user.DepositAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if deposit < amount+fee {
+		return MakeFailedResult("Your balance is %s PAC, it's not enough for the tip and its fee of %s PAC",
+			util.ChangeToString(deposit), util.ChangeToString(fee)), nil
+	}
+
+	txID, err := be.wallet.TransferTransactionFrom(user.DepositAddress, recipientAddr, amount, tipMemo)
+	be.recordAudit(audit.Entry{
+		Kind:     audit.KindTip,
+		AppID:    appID.String(),
+		CallerID: callerID,
+		Inputs:   []string{TipCommandName, recipientID, recipientAddr, util.ChangeToString(amount) + " PAC"},
+		TxID:     txID,
+		Error:    auditError(nil, err),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	account.DayTotal += amount
+	if err := be.tips.save(account); err != nil {
+		return nil, fmt.Errorf("the tip is sent, but it's not added to your daily total: %w", err)
+	}
+
+	res := MakeSuccessfulResult("<@%s> tipped <@%s> %s PAC 🎁", callerID, recipientID, util.ChangeToString(amount))
+	res.Title = "Tip Sent"
+	res.AddField("Amount", util.ChangeToString(amount)+" PAC", true)
+	res.AddField("Fee", util.ChangeToString(fee)+" PAC", true)
+	res.AddField("Remaining Balance", util.ChangeToString(deposit-amount-fee)+" PAC", true)
+	res.AddField("Recipient Address", recipientAddr, false)
+	res.AddField("Transaction", txID, false)
+
+	return res, nil
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/database"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/wallet"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/types/tx/payload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTip(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	mockWallet := wallet.NewMockIWallet(gomock.NewController(t))
+	be.wallet = mockWallet
+
+	profiles, err := store.NewJSONKV(path.Join(t.TempDir(), "user_profiles.json"))
+	require.NoError(t, err)
+	be.profiles = profiles
+
+	accounts, err := store.NewJSONKV(path.Join(t.TempDir(), "tip_accounts.json"))
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 24, 12, 0, 0, 0, time.UTC)
+	be.tips = &tips{accounts: accounts, dailyLimit: 10e9, nowFunc: func() time.Time { return now }}
+
+	be.db, err = database.NewDB(path.Join(t.TempDir(), "robopac.db"))
+	require.NoError(t, err)
+
+	be.RegisterCommands()
+
+	deposit := crypto.NewAddress(crypto.AddressTypeBLSAccount, make([]byte, 20)).String()
+	bobAddr := crypto.NewAddress(crypto.AddressTypeBLSAccount, append(make([]byte, 19), 1)).String()
+
+	t.Run("discord only", func(t *testing.T) {
+		_, err := be.Run(AppIdTelegram, "alice", []string{TipCommandName, "bob", "1"})
+		assert.Error(t, err)
+	})
+
+	t.Run("recipient without address", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "alice", []string{TipCommandName, "<@bob>", "1"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "<@bob> has no linked account address")
+	})
+
+	require.NoError(t, be.saveProfile(&Profile{
+		UserID:    "bob",
+		Addresses: []LinkedAddress{{Address: bobAddr, LinkedAt: now.Unix()}},
+	}))
+
+	t.Run("no deposit", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "alice", []string{TipCommandName, "bob", "1"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Equal(t, []string{DepositAddressCommandName}, res.Suggestions)
+	})
+
+	require.NoError(t, be.db.AddUser(&database.DiscordUser{DiscordID: "alice", DepositAddress: deposit}))
+
+	t.Run("tip yourself", func(t *testing.T) {
+		res, err := be.Run(AppIdDiscord, "alice", []string{TipCommandName, "<@!alice>", "1"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("send tip", func(t *testing.T) {
+		mockClient.EXPECT().CalculateFee(gomock.Any(), int64(4e9), payload.TypeTransfer).Return(int64(1e7), nil)
+		mockClient.EXPECT().GetBalance(gomock.Any(), deposit).Return(int64(5e9), nil)
+		mockWallet.EXPECT().TransferTransactionFrom(deposit, bobAddr, int64(4e9), tipMemo).Return("0x123", nil)
+
+		res, err := be.Run(AppIdDiscord, "alice", []string{TipCommandName, "bob", "4"})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "<@alice> tipped <@bob> 4 PAC 🎁", res.Message)
+		assert.Equal(t, "0.99 PAC", res.Fields[2].Value)
+	})
+
+	t.Run("spent deposit", func(t *testing.T) {
+		mockClient.EXPECT().CalculateFee(gomock.Any(), int64(1e9), payload.TypeTransfer).Return(int64(1e7), nil)
+		mockClient.EXPECT().GetBalance(gomock.Any(), deposit).Return(int64(99e7), nil)
+
+		res, err := be.Run(AppIdDiscord, "alice", []string{TipCommandName, "bob", "1"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "Your balance is 0.99 PAC")
+	})
+
+	t.Run("daily limit", func(t *testing.T) {
+		mockClient.EXPECT().CalculateFee(gomock.Any(), int64(7e9), payload.TypeTransfer).Return(int64(1e7), nil)
+
+		res, err := be.Run(AppIdDiscord, "alice", []string{TipCommandName, "bob", "7"})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Equal(t, "The daily tip limit is 10 PAC, you can tip 6 PAC more today", res.Message)
+
+		now = now.Add(24 * time.Hour)
+		account, err := be.tips.account("alice", now)
+		require.NoError(t, err)
+		assert.Zero(t, account.DayTotal, "the daily total is reset on a new day")
+	})
+}
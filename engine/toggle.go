@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// commandToggles keeps the runtime enable/disable state of commands.
+// A command can be disabled globally or only for a specific guild.
+// The global state always takes precedence over the guild state.
+type commandToggles struct {
+	lk sync.RWMutex
+
+	globalDisabled map[string]bool
+	guildDisabled  map[string]map[string]bool
+}
+
+func newCommandToggles() *commandToggles {
+	return &commandToggles{
+		globalDisabled: make(map[string]bool),
+		guildDisabled:  make(map[string]map[string]bool),
+	}
+}
+
+func (ct *commandToggles) setEnabled(cmdName, guildID string, enabled bool) {
+	ct.lk.Lock()
+	defer ct.lk.Unlock()
+
+	if guildID == "" {
+		if enabled {
+			delete(ct.globalDisabled, cmdName)
+		} else {
+			ct.globalDisabled[cmdName] = true
+		}
+
+		return
+	}
+
+	guildCmds, ok := ct.guildDisabled[guildID]
+	if !ok {
+		guildCmds = make(map[string]bool)
+		ct.guildDisabled[guildID] = guildCmds
+	}
+
+	if enabled {
+		delete(guildCmds, cmdName)
+		if len(guildCmds) == 0 {
+			delete(ct.guildDisabled, guildID)
+		}
+	} else {
+		guildCmds[cmdName] = true
+	}
+}
+
+func (ct *commandToggles) isEnabled(cmdName, guildID string) bool {
+	ct.lk.RLock()
+	defer ct.lk.RUnlock()
+
+	if ct.globalDisabled[cmdName] {
+		return false
+	}
+
+	if guildID == "" {
+		return true
+	}
+
+	return !ct.guildDisabled[guildID][cmdName]
+}
+
+// globallyDisabled returns the names of the globally disabled commands.
+func (ct *commandToggles) globallyDisabled() []string {
+	ct.lk.RLock()
+	defer ct.lk.RUnlock()
+
+	names := make([]string, 0, len(ct.globalDisabled))
+	for name := range ct.globalDisabled {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// SetCommandEnabled enables or disables a command at runtime.
+// An empty guildID changes the global state of the command.
+func (be *BotEngine) SetCommandEnabled(cmdName, guildID string, enabled bool) error {
+	if be.commandByName(cmdName) == nil {
+		return errors.New(be.Message(MsgUnknownCommand, cmdName))
+	}
+
+	be.toggles.setEnabled(cmdName, guildID, enabled)
+
+	return nil
+}
+
+// IsCommandEnabled reports whether the command can be invoked in the given guild.
+// A globally disabled command is disabled in every guild.
+func (be *BotEngine) IsCommandEnabled(cmdName, guildID string) bool {
+	return be.toggles.isEnabled(cmdName, guildID)
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandToggles(t *testing.T) {
+	be := setupTestEngine(t,
+		Command{Name: "cmd-1"},
+		Command{Name: "cmd-2"},
+	)
+
+	t.Run("unknown command", func(t *testing.T) {
+		err := be.SetCommandEnabled("unknown", "", false)
+		assert.Error(t, err)
+	})
+
+	t.Run("enabled by default", func(t *testing.T) {
+		assert.True(t, be.IsCommandEnabled("cmd-1", ""))
+		assert.True(t, be.IsCommandEnabled("cmd-1", "guild-1"))
+	})
+
+	t.Run("disabled per guild", func(t *testing.T) {
+		assert.NoError(t, be.SetCommandEnabled("cmd-1", "guild-1", false))
+
+		assert.True(t, be.IsCommandEnabled("cmd-1", ""))
+		assert.False(t, be.IsCommandEnabled("cmd-1", "guild-1"))
+		assert.True(t, be.IsCommandEnabled("cmd-1", "guild-2"))
+		assert.True(t, be.IsCommandEnabled("cmd-2", "guild-1"))
+
+		assert.NoError(t, be.SetCommandEnabled("cmd-1", "guild-1", true))
+		assert.True(t, be.IsCommandEnabled("cmd-1", "guild-1"))
+	})
+
+	t.Run("global takes precedence over guild", func(t *testing.T) {
+		assert.NoError(t, be.SetCommandEnabled("cmd-2", "", false))
+		assert.NoError(t, be.SetCommandEnabled("cmd-2", "guild-1", true))
+
+		assert.False(t, be.IsCommandEnabled("cmd-2", ""))
+		assert.False(t, be.IsCommandEnabled("cmd-2", "guild-1"))
+
+		assert.NoError(t, be.SetCommandEnabled("cmd-2", "", true))
+		assert.True(t, be.IsCommandEnabled("cmd-2", "guild-1"))
+	})
+
+	t.Run("guild disable survives global enable", func(t *testing.T) {
+		assert.NoError(t, be.SetCommandEnabled("cmd-1", "guild-1", false))
+		assert.NoError(t, be.SetCommandEnabled("cmd-1", "", true))
+
+		assert.True(t, be.IsCommandEnabled("cmd-1", ""))
+		assert.False(t, be.IsCommandEnabled("cmd-1", "guild-1"))
+	})
+}
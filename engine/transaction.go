@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/utils"
+	"github.com/pactus-project/pactus/types/tx"
+	"github.com/pactus-project/pactus/types/tx/payload"
+	"github.com/pactus-project/pactus/util"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// Transaction is a decoded transaction, the amounts are in NanoPAC.
+type Transaction struct {
+	ID   string
+	Type payload.Type
+	// Subsidy is set for the block rewards, which are transferred from the treasury.
+	Subsidy  bool
+	Sender   string
+	Receiver string
+	Amount   int64
+	Fee      int64
+	Memo     string
+
+	Height uint32
+	Time   time.Time
+	// Confirmations is the number of the blocks since the block of the transaction, including it.
+	Confirmations uint32
+}
+
+const (
+	txViewSummary = "summary"
+	txViewRaw     = "raw"
+)
+
+// txHandler shows the decoded transaction of the ID, or the raw transaction in the raw view.
+// The result has the action to switch to the other view.
+func (be *BotEngine) txHandler(_ AppID, _ string, args ...string) (*CommandResult, error) {
+	txID := strings.ToLower(strings.TrimSpace(args[0]))
+	view := txViewSummary
+	if len(args) > 1 && args[1] != "" {
+		view = args[1]
+	}
+
+	cm, err := be.networkClient(networkOf(args, 2))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := cm.GetTransactionData(txID)
+	if err != nil {
+		if errors.Is(err, client.ErrTransactionNotFound) {
+			return MakeFailedResult("Transaction `%s` is not found", txID), nil
+		}
+
+		return nil, err
+	}
+
+	// the actions keep the network of the transaction.
+	viewInputs := func(view string) []string {
+		return append([]string{TxCommandName, txID, view}, args[min(len(args), 2):]...)
+	}
+
+	if view == txViewRaw {
+		if data.GetTransaction() == nil {
+			return nil, errors.New("transaction data is empty")
+		}
+
+		res := rawTransactionResult(txID, data.Transaction.Data)
+		res.AddAction("Show summary", viewInputs(txViewSummary)...)
+
+		return res, nil
+	}
+
+	lastHeight, err := cm.GetBlockchainHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	trx, err := decodeTransaction(data, lastHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	res := transactionResult(trx)
+	res.AddAction("Show raw tx", viewInputs(txViewRaw)...)
+
+	return res, nil
+}
+
+// rawTransactionResult shows the encoded transaction in hex.
+func rawTransactionResult(txID string, data []byte) *CommandResult {
+	res := MakeSuccessfulResult("ID: %s\n```\n%s\n```", txID, hex.EncodeToString(data))
+	res.Title = "Raw Transaction"
+	res.AddField("Size", fmt.Sprintf("%d bytes", len(data)), true)
+
+	return res
+}
+
+// decodeTransaction decodes the raw transaction of the response, the confirmations are counted up to lastHeight.
+func decodeTransaction(res *pactus.GetTransactionResponse, lastHeight uint32) (*Transaction, error) {
+	if res.GetTransaction() == nil {
+		return nil, errors.New("transaction data is empty")
+	}
+
+	return decodeRawTransaction(res.Transaction.Data, res.BlockHeight, res.BlockTime, lastHeight)
+}
+
+// decodeRawTransaction decodes the raw transaction of the block at the height.
+func decodeRawTransaction(data []byte, height, blockTime, lastHeight uint32) (*Transaction, error) {
+	trx, err := tx.FromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode the transaction: %w", err)
+	}
+
+	decoded := &Transaction{
+		ID:      trx.ID().String(),
+		Type:    trx.Payload().Type(),
+		Subsidy: trx.IsSubsidyTx(),
+		Sender:  trx.Payload().Signer().String(),
+		Amount:  trx.Payload().Value(),
+		Fee:     trx.Fee(),
+		Memo:    trx.Memo(),
+		Height:  height,
+		Time:    time.Unix(int64(blockTime), 0),
+	}
+
+	switch pld := trx.Payload().(type) {
+	case *payload.TransferPayload:
+		decoded.Receiver = pld.To.String()
+	case *payload.BondPayload:
+		decoded.Receiver = pld.To.String()
+	case *payload.WithdrawPayload:
+		decoded.Receiver = pld.To.String()
+	case *payload.SortitionPayload, *payload.UnbondPayload:
+		// the validator is the sender, and nothing is transferred.
+	default:
+		return nil, fmt.Errorf("unknown payload type: %s", pld.Type())
+	}
+
+	if lastHeight >= height {
+		decoded.Confirmations = lastHeight - height + 1
+	}
+
+	return decoded, nil
+}
+
+// transactionResult formats the transaction in a human-readable way.
+func transactionResult(trx *Transaction) *CommandResult {
+	typeName := typeTitle(trx.Type)
+	sender := trx.Sender
+	if trx.Subsidy {
+		typeName = "Block Reward"
+		sender = "Treasury"
+	}
+
+	res := MakeSuccessfulResult("ID: %s", trx.ID)
+	res.Title = typeName + " Transaction"
+	res.AddField("Type", typeName, true)
+	res.AddField("Block", utils.FormatNumber(int64(trx.Height)), true)
+	res.AddField("Confirmations", utils.FormatNumber(int64(trx.Confirmations)), true)
+
+	switch trx.Type {
+	case payload.TypeBond:
+		res.AddField("Sender", sender, false)
+		res.AddField("Validator", trx.Receiver, false)
+		res.AddField("Stake", util.ChangeToString(trx.Amount)+" PAC", true)
+	case payload.TypeWithdraw:
+		res.AddField("Validator", sender, false)
+		res.AddField("Receiver", trx.Receiver, false)
+		res.AddField("Amount", util.ChangeToString(trx.Amount)+" PAC", true)
+	case payload.TypeSortition, payload.TypeUnbond:
+		res.AddField("Validator", sender, false)
+	default:
+		res.AddField("Sender", sender, false)
+		res.AddField("Receiver", trx.Receiver, false)
+		res.AddField("Amount", util.ChangeToString(trx.Amount)+" PAC", true)
+	}
+
+	res.AddField("Fee", util.ChangeToString(trx.Fee)+" PAC", true)
+	res.AddField("Time", trx.Time.UTC().Format(time.DateTime), true)
+	if trx.Memo != "" {
+		res.AddField("Memo", trx.Memo, false)
+	}
+
+	return res
+}
+
+func typeTitle(t payload.Type) string {
+	name := t.String()
+
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,226 @@
+package engine
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/sortition"
+	"github.com/pactus-project/pactus/types/tx"
+	"github.com/pactus-project/pactus/types/tx/payload"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	fixtureAccount   = crypto.NewAddress(crypto.AddressTypeBLSAccount, make([]byte, 20))
+	fixtureValidator = crypto.NewAddress(crypto.AddressTypeValidator, append(make([]byte, 19), 1))
+)
+
+// fixtureTx returns the transaction as the node returns it, committed at the height 100.
+func fixtureTx(t *testing.T, trx *tx.Tx) *pactus.GetTransactionResponse {
+	t.Helper()
+
+	data, err := trx.Bytes()
+	require.NoError(t, err)
+
+	return &pactus.GetTransactionResponse{
+		BlockHeight: 100,
+		BlockTime:   1_700_000_000,
+		Transaction: &pactus.TransactionInfo{Id: trx.ID().Bytes(), Data: data},
+	}
+}
+
+func TestDecodeTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		trx      *tx.Tx
+		sender   string
+		receiver string
+		amount   int64
+		fee      int64
+		subsidy  bool
+	}{
+		{
+			name:     "transfer",
+			trx:      tx.NewTransferTx(90, fixtureAccount, fixtureValidator, 5e9, 1e7, "thanks"),
+			sender:   fixtureAccount.String(),
+			receiver: fixtureValidator.String(),
+			amount:   5e9,
+			fee:      1e7,
+		},
+		{
+			name:     "subsidy",
+			trx:      tx.NewSubsidyTx(90, fixtureAccount, 1e9, ""),
+			sender:   crypto.TreasuryAddress.String(),
+			receiver: fixtureAccount.String(),
+			amount:   1e9,
+			subsidy:  true,
+		},
+		{
+			name:     "bond",
+			trx:      tx.NewBondTx(90, fixtureAccount, fixtureValidator, nil, 100e9, 1e8, ""),
+			sender:   fixtureAccount.String(),
+			receiver: fixtureValidator.String(),
+			amount:   100e9,
+			fee:      1e8,
+		},
+		{
+			name:   "unbond",
+			trx:    tx.NewUnbondTx(90, fixtureValidator, ""),
+			sender: fixtureValidator.String(),
+		},
+		{
+			name:     "withdraw",
+			trx:      tx.NewWithdrawTx(90, fixtureValidator, fixtureAccount, 99e9, 1e7, ""),
+			sender:   fixtureValidator.String(),
+			receiver: fixtureAccount.String(),
+			amount:   99e9,
+			fee:      1e7,
+		},
+		{
+			name:   "sortition",
+			trx:    tx.NewSortitionTx(90, fixtureValidator, sortition.Proof{}),
+			sender: fixtureValidator.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := decodeTransaction(fixtureTx(t, tt.trx), 109)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.trx.ID().String(), decoded.ID)
+			assert.Equal(t, tt.trx.Payload().Type(), decoded.Type)
+			assert.Equal(t, tt.subsidy, decoded.Subsidy)
+			assert.Equal(t, tt.sender, decoded.Sender)
+			assert.Equal(t, tt.receiver, decoded.Receiver)
+			assert.Equal(t, tt.amount, decoded.Amount)
+			assert.Equal(t, tt.fee, decoded.Fee)
+			assert.Equal(t, uint32(100), decoded.Height)
+			assert.Equal(t, uint32(10), decoded.Confirmations)
+		})
+	}
+
+	t.Run("invalid data", func(t *testing.T) {
+		_, err := decodeTransaction(&pactus.GetTransactionResponse{
+			Transaction: &pactus.TransactionInfo{Data: []byte{1, 2, 3}},
+		}, 1)
+		assert.Error(t, err)
+
+		_, err = decodeTransaction(&pactus.GetTransactionResponse{}, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestTransactionResult(t *testing.T) {
+	fields := func(res *CommandResult) map[string]string {
+		values := map[string]string{}
+		for _, f := range res.Fields {
+			values[f.Name] = f.Value
+		}
+
+		return values
+	}
+
+	t.Run("transfer", func(t *testing.T) {
+		res := transactionResult(&Transaction{
+			ID:            "abcd",
+			Type:          payload.TypeTransfer,
+			Sender:        "pc1zsender",
+			Receiver:      "pc1zreceiver",
+			Amount:        1_500_000_000,
+			Fee:           10_000_000,
+			Memo:          "thanks",
+			Height:        12_345,
+			Time:          time.Unix(1_700_000_000, 0),
+			Confirmations: 3,
+		})
+		assert.True(t, res.Successful)
+		assert.Equal(t, "Transfer Transaction", res.Title)
+		assert.Equal(t, map[string]string{
+			"Type":          "Transfer",
+			"Block":         "12,345",
+			"Confirmations": "3",
+			"Sender":        "pc1zsender",
+			"Receiver":      "pc1zreceiver",
+			"Amount":        "1.5 PAC",
+			"Fee":           "0.01 PAC",
+			"Time":          "2023-11-14 22:13:20",
+			"Memo":          "thanks",
+		}, fields(res))
+	})
+
+	t.Run("block reward", func(t *testing.T) {
+		res := transactionResult(&Transaction{Type: payload.TypeTransfer, Subsidy: true, Amount: 1e9})
+		assert.Equal(t, "Block Reward Transaction", res.Title)
+		assert.Equal(t, "Treasury", fields(res)["Sender"])
+		assert.NotContains(t, fields(res), "Memo")
+	})
+
+	t.Run("bond", func(t *testing.T) {
+		values := fields(transactionResult(&Transaction{Type: payload.TypeBond, Receiver: "pc1pval", Amount: 100e9}))
+		assert.Equal(t, "pc1pval", values["Validator"])
+		assert.Equal(t, "100 PAC", values["Stake"])
+	})
+
+	t.Run("unbond", func(t *testing.T) {
+		values := fields(transactionResult(&Transaction{Type: payload.TypeUnbond, Sender: "pc1pval"}))
+		assert.Equal(t, "pc1pval", values["Validator"])
+		assert.NotContains(t, values, "Amount")
+	})
+}
+
+func TestTxCommand(t *testing.T) {
+	be, mockClient := setupTestEngineWithClient(t)
+	be.RegisterCommands()
+
+	trx := tx.NewTransferTx(90, fixtureAccount, fixtureValidator, 5e9, 1e7, "")
+	txID := trx.ID().String()
+
+	t.Run("transaction", func(t *testing.T) {
+		mockClient.EXPECT().GetTransactionData(gomock.Any(), txID).Return(fixtureTx(t, trx), nil)
+		mockClient.EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(100), nil)
+
+		res, err := be.Run(AppIdCLI, "1", []string{TxCommandName, txID})
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Equal(t, "Transfer Transaction", res.Title)
+		assert.Contains(t, res.Message, txID)
+		require.Len(t, res.Actions, 1)
+		assert.Equal(t, []string{TxCommandName, txID, "raw"}, res.Actions[0].Inputs)
+	})
+
+	t.Run("raw view", func(t *testing.T) {
+		mockClient.EXPECT().GetTransactionData(gomock.Any(), txID).Return(fixtureTx(t, trx), nil)
+
+		res, err := be.Run(AppIdCLI, "1", []string{TxCommandName, txID, "raw"})
+		require.NoError(t, err)
+		assert.Equal(t, "Raw Transaction", res.Title)
+		raw, err := trx.Bytes()
+		require.NoError(t, err)
+		assert.Contains(t, res.Message, hex.EncodeToString(raw))
+		require.Len(t, res.Actions, 1)
+		assert.Equal(t, []string{TxCommandName, txID, "summary"}, res.Actions[0].Inputs)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient.EXPECT().GetTransactionData(gomock.Any(), txID).
+			Return(nil, fmt.Errorf("%w: %s", client.ErrTransactionNotFound, txID))
+
+		res, err := be.Run(AppIdCLI, "1", []string{TxCommandName, txID})
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "is not found")
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		_, err := be.Run(AppIdCLI, "1", []string{TxCommandName, "xyz"})
+		assert.ErrorContains(t, err, "64 hex characters")
+	})
+}
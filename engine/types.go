@@ -2,15 +2,21 @@ package engine
 
 import "time"
 
-type NetHealthResponse struct {
-	HealthStatus    bool
-	CurrentTime     time.Time
-	LastBlockTime   time.Time
+// NetworkHealth is the health score of the network, with the parts that the score is made of.
+type NetworkHealth struct {
+	Score           int
+	Status          string
 	LastBlockHeight uint32
-	TimeDifference  int64
+	LastBlockTime   time.Time
+	BlockLag        time.Duration
+	ConnectedPeers  uint32
+	PeersAvailable  bool
+	Warnings        []string
 }
 
 type NetStatus struct {
+	// Network is the network of the status, like mainnet, see client.NetworkOf.
+	Network             string
 	NetworkName         string
 	ConnectedPeersCount uint32
 	ValidatorsCount     int32
@@ -21,6 +27,7 @@ type NetStatus struct {
 	TotalCommitteePower int64
 	TotalAccounts       int32
 	CirculatingSupply   int64
+	Warnings            []string
 }
 
 type NodeInfo struct {
@@ -0,0 +1,261 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/pactus-project/pactus/util"
+)
+
+const (
+	// MaxAddressWatches is the number of the addresses that each user can watch.
+	MaxAddressWatches = 10
+
+	// maxWatchedBlocks caps the blocks scanned for one block event, the older ones are skipped
+	// if the bot was behind, so the node is not flooded after a downtime.
+	maxWatchedBlocks = 100
+)
+
+// AddressActivity is raised for a watcher when a transaction touches their watched address.
+type AddressActivity struct {
+	UserID  string
+	Address string
+	Tx      *Transaction
+}
+
+// Summary describes the transaction from the side of the watched address.
+func (a AddressActivity) Summary() string {
+	amount := util.ChangeToString(a.Tx.Amount) + " PAC"
+	typeName := strings.ToLower(typeTitle(a.Tx.Type))
+
+	switch {
+	case a.Tx.Subsidy && a.Tx.Receiver == a.Address:
+		return "received a block reward of " + amount
+	case a.Tx.Receiver == a.Address && a.Tx.Sender == a.Address:
+		return "sent a " + typeName + " of " + amount + " to itself"
+	case a.Tx.Receiver == a.Address:
+		return "received a " + typeName + " of " + amount + " from `" + a.Tx.Sender + "`"
+	case a.Tx.Receiver != "":
+		return "sent a " + typeName + " of " + amount + " to `" + a.Tx.Receiver + "`"
+	default:
+		return "signed a " + typeName + " transaction"
+	}
+}
+
+// enableAddressWatches opens the storage of the address watches.
+func (be *BotEngine) enableAddressWatches(cfg *config.Config) error {
+	watches, err := store.OpenKV(cfg.StoreBackend, cfg.StorePath, "address_watches")
+	if err != nil {
+		return err
+	}
+	be.addressWatches = watches
+
+	return nil
+}
+
+// watchAddressHandler adds the address to the watches of the caller.
+func (be *BotEngine) watchAddressHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	address := strings.ToLower(strings.TrimSpace(args[0]))
+
+	watches, err := be.addressWatchesOf(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(watches, address) {
+		return MakeFailedResult("You are already watching `%s`", address), nil
+	}
+
+	if len(watches) >= MaxAddressWatches {
+		res := MakeFailedResult("You can watch up to %d addresses, unwatch one of them first", MaxAddressWatches)
+		res.Suggest(MyWatchesCommandName)
+
+		return res, nil
+	}
+
+	if err := be.saveAddressWatches(callerID, append(watches, address)); err != nil {
+		return nil, err
+	}
+
+	res := MakeSuccessfulResult("You will get a DM when a transaction touches `%s`", address)
+	res.Suggest(MyWatchesCommandName)
+
+	return res, nil
+}
+
+// unwatchHandler removes the address from the watches of the caller.
+func (be *BotEngine) unwatchHandler(_ AppID, callerID string, args ...string) (*CommandResult, error) {
+	address := strings.TrimSpace(args[0])
+
+	watches, err := be.addressWatchesOf(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.Index(watches, address)
+	if i < 0 {
+		res := MakeFailedResult("You are not watching `%s`", address)
+		res.Suggest(MyWatchesCommandName)
+
+		return res, nil
+	}
+
+	if err := be.saveAddressWatches(callerID, slices.Delete(watches, i, i+1)); err != nil {
+		return nil, err
+	}
+
+	return MakeSuccessfulResult("You stopped watching `%s`", address), nil
+}
+
+// myWatchesHandler lists the addresses that the caller is watching.
+func (be *BotEngine) myWatchesHandler(_ AppID, callerID string, _ ...string) (*CommandResult, error) {
+	watches, err := be.addressWatchesOf(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(watches) == 0 {
+		res := MakeFailedResult("You are not watching any address")
+		res.Suggest(WatchAddressCommandName)
+
+		return res, nil
+	}
+
+	lines := make([]string, 0, len(watches))
+	for _, address := range watches {
+		lines = append(lines, "`"+address+"`")
+	}
+
+	res := MakeSuccessfulResult("%s", strings.Join(lines, "\n"))
+	res.Title = "My Watches"
+	res.AddField("Watches", fmt.Sprintf("%d/%d", len(watches), MaxAddressWatches), true)
+
+	return res, nil
+}
+
+// addressWatchesOf returns the addresses that the user is watching, in the order they are added.
+func (be *BotEngine) addressWatchesOf(userID string) ([]string, error) {
+	data, err := be.addressWatches.Get(userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return []string{}, nil
+		}
+
+		return nil, err
+	}
+
+	watches := []string{}
+	if err := json.Unmarshal(data, &watches); err != nil {
+		return nil, err
+	}
+
+	return watches, nil
+}
+
+func (be *BotEngine) saveAddressWatches(userID string, watches []string) error {
+	if len(watches) == 0 {
+		return be.addressWatches.Delete(userID)
+	}
+
+	data, err := json.Marshal(watches)
+	if err != nil {
+		return err
+	}
+
+	return be.addressWatches.Set(userID, data)
+}
+
+// addressWatchers returns the watchers of each watched address.
+func (be *BotEngine) addressWatchers() map[string][]string {
+	watchers := map[string][]string{}
+	_ = be.addressWatches.Iterate(func(userID string, value []byte) bool {
+		watches := []string{}
+		if err := json.Unmarshal(value, &watches); err == nil {
+			for _, address := range watches {
+				watchers[address] = append(watchers[address], userID)
+			}
+		}
+
+		return true
+	})
+
+	return watchers
+}
+
+// WatchAddresses scans the new blocks for the transactions of the watched addresses
+// and passes the activities to fn, like the Discord DMs. It returns when the context is done.
+func (be *BotEngine) WatchAddresses(ctx context.Context, fn func(AddressActivity)) {
+	if be.addressWatches == nil {
+		return
+	}
+
+	events := be.SubscribeBlocks()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			for _, activity := range be.addressActivities(event) {
+				fn(activity)
+			}
+		}
+	}
+}
+
+// addressActivities returns the activities of the watched addresses in the blocks of the event.
+func (be *BotEngine) addressActivities(event client.BlockEvent) []AddressActivity {
+	watchers := be.addressWatchers()
+	if len(watchers) == 0 {
+		return nil
+	}
+
+	fromHeight := event.FromHeight
+	if event.Height-fromHeight >= maxWatchedBlocks {
+		fromHeight = event.Height - maxWatchedBlocks + 1
+		be.logger.Warn("skipped the old blocks of the address watches", "from", event.FromHeight, "to", fromHeight-1)
+	}
+
+	activities := []AddressActivity{}
+	for height := fromHeight; height <= event.Height; height++ {
+		block, err := be.clientMgr.GetBlockWithTxs(height)
+		if err != nil {
+			be.logger.Warn("unable to scan the block for the address watches", "err", err, "height", height)
+
+			continue
+		}
+
+		for _, info := range block.Txs {
+			trx, err := decodeRawTransaction(info.Data, height, block.BlockTime, event.Height)
+			if err != nil {
+				be.logger.Warn("unable to decode the transaction", "err", err, "height", height)
+
+				continue
+			}
+
+			addresses := []string{trx.Sender}
+			if trx.Receiver != "" && trx.Receiver != trx.Sender {
+				addresses = append(addresses, trx.Receiver)
+			}
+
+			for _, address := range addresses {
+				for _, userID := range watchers[address] {
+					activities = append(activities, AddressActivity{UserID: userID, Address: address, Tx: trx})
+				}
+			}
+		}
+	}
+
+	return activities
+}
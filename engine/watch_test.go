@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/types/tx"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupAddressWatches(t *testing.T) (*BotEngine, *client.MockIClient) {
+	t.Helper()
+
+	be, mockClient := setupTestEngineWithClient(t)
+
+	watches, err := store.NewJSONKV(path.Join(t.TempDir(), "address_watches.json"))
+	require.NoError(t, err)
+	be.addressWatches = watches
+
+	return be, mockClient
+}
+
+// fixtureBlock returns the block as the node returns it with the transactions.
+func fixtureBlock(t *testing.T, trxs ...*tx.Tx) *pactus.GetBlockResponse {
+	t.Helper()
+
+	block := &pactus.GetBlockResponse{BlockTime: 1_700_000_000}
+	for _, trx := range trxs {
+		data, err := trx.Bytes()
+		require.NoError(t, err)
+
+		block.Txs = append(block.Txs, &pactus.TransactionInfo{Id: trx.ID().Bytes(), Data: data})
+	}
+
+	return block
+}
+
+func TestAddressWatchCommands(t *testing.T) {
+	be, _ := setupAddressWatches(t)
+	account := fixtureAccount.String()
+
+	t.Run("no watches", func(t *testing.T) {
+		res, err := be.myWatchesHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		be.Cmds = append(be.Cmds, Command{
+			Name:    WatchAddressCommandName,
+			AppIDs:  []AppID{AppIdDiscord},
+			Handler: be.watchAddressHandler,
+			Args:    []Args{{Name: "address", Format: FormatAddress}},
+		})
+
+		_, err := be.Run(AppIdDiscord, "123", []string{WatchAddressCommandName, "invalid-addr"})
+		assert.ErrorContains(t, err, "expected an address like pc1...")
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		res, err := be.watchAddressHandler(AppIdDiscord, "123", account)
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		res, err = be.watchAddressHandler(AppIdDiscord, "123", account)
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, "already watching")
+
+		res, err = be.myWatchesHandler(AppIdDiscord, "123")
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+		assert.Contains(t, res.Message, account)
+		assert.Equal(t, []string{account}, be.completeWatchedAddress("123", "pc1z"))
+	})
+
+	t.Run("too many watches", func(t *testing.T) {
+		for i := 1; i < MaxAddressWatches; i++ {
+			addr := crypto.NewAddress(crypto.AddressTypeBLSAccount, append(make([]byte, 19), byte(i)))
+			res, err := be.watchAddressHandler(AppIdDiscord, "123", addr.String())
+			require.NoError(t, err)
+			require.True(t, res.Successful)
+		}
+
+		res, err := be.watchAddressHandler(AppIdDiscord, "123", fixtureValidator.String())
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+		assert.Contains(t, res.Message, fmt.Sprintf("up to %d", MaxAddressWatches))
+	})
+
+	t.Run("unwatch", func(t *testing.T) {
+		res, err := be.unwatchHandler(AppIdDiscord, "123", fixtureValidator.String())
+		require.NoError(t, err)
+		assert.False(t, res.Successful)
+
+		res, err = be.unwatchHandler(AppIdDiscord, "123", account)
+		require.NoError(t, err)
+		assert.True(t, res.Successful)
+
+		watches, err := be.addressWatchesOf("123")
+		require.NoError(t, err)
+		assert.Len(t, watches, MaxAddressWatches-1)
+		assert.NotContains(t, watches, account)
+	})
+}
+
+func TestAddressActivities(t *testing.T) {
+	be, mockClient := setupAddressWatches(t)
+	account := fixtureAccount.String()
+	validator := fixtureValidator.String()
+	other := crypto.NewAddress(crypto.AddressTypeBLSAccount, append(make([]byte, 19), 7))
+
+	t.Run("nothing is watched", func(t *testing.T) {
+		assert.Empty(t, be.addressActivities(client.BlockEvent{FromHeight: 100, Height: 101}))
+	})
+
+	_, err := be.watchAddressHandler(AppIdDiscord, "alice", account)
+	require.NoError(t, err)
+	_, err = be.watchAddressHandler(AppIdDiscord, "bob", validator)
+	require.NoError(t, err)
+
+	transfer := tx.NewTransferTx(90, fixtureAccount, fixtureValidator, 5e9, 1e7, "")
+	unrelated := tx.NewTransferTx(90, other, other, 1e9, 1e7, "")
+	reward := tx.NewSubsidyTx(90, fixtureAccount, 1e9, "")
+
+	mockClient.EXPECT().GetBlockWithTxs(gomock.Any(), uint32(100)).Return(fixtureBlock(t, transfer, unrelated), nil)
+	mockClient.EXPECT().GetBlockWithTxs(gomock.Any(), uint32(101)).Return(fixtureBlock(t, reward), nil)
+
+	activities := be.addressActivities(client.BlockEvent{FromHeight: 100, Height: 101})
+	require.Len(t, activities, 3)
+
+	assert.Equal(t, "alice", activities[0].UserID)
+	assert.Equal(t, transfer.ID().String(), activities[0].Tx.ID)
+	assert.Equal(t, "sent a transfer of 5 PAC to `"+validator+"`", activities[0].Summary())
+
+	assert.Equal(t, "bob", activities[1].UserID)
+	assert.Equal(t, "received a transfer of 5 PAC from `"+account+"`", activities[1].Summary())
+
+	assert.Equal(t, "alice", activities[2].UserID)
+	assert.Equal(t, uint32(101), activities[2].Tx.Height)
+	assert.Equal(t, "received a block reward of 1 PAC", activities[2].Summary())
+}
+
+func TestAddressActivitiesSkipOldBlocks(t *testing.T) {
+	be, mockClient := setupAddressWatches(t)
+
+	_, err := be.watchAddressHandler(AppIdDiscord, "alice", fixtureAccount.String())
+	require.NoError(t, err)
+
+	mockClient.EXPECT().GetBlockWithTxs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, height uint32) (*pactus.GetBlockResponse, error) {
+			assert.Greater(t, height, uint32(1000-maxWatchedBlocks))
+
+			return fixtureBlock(t), nil
+		}).Times(maxWatchedBlocks)
+
+	assert.Empty(t, be.addressActivities(client.BlockEvent{FromHeight: 1, Height: 1000}))
+}
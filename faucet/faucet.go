@@ -0,0 +1,112 @@
+package faucet
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/wallet"
+	"github.com/pactus-project/pactus/crypto"
+)
+
+const memo = "RoboPac faucet"
+
+var (
+	ErrInvalidAddress      = errors.New("invalid address")
+	ErrInsufficientBalance = errors.New("the faucet is out of coins, please try again later")
+)
+
+// CooldownError is returned when the user or the address has claimed from the faucet recently.
+type CooldownError struct {
+	Remaining time.Duration
+}
+
+func (e CooldownError) Error() string {
+	return fmt.Sprintf("you have claimed from the faucet recently, please try again in %v",
+		e.Remaining.Round(time.Minute))
+}
+
+// Faucet sends a fixed amount of testnet coins from the wallet to the requested addresses.
+// The claims are kept in the store, so the cooldowns survive restarts.
+type Faucet struct {
+	wallet          wallet.IWallet
+	store           store.IStore
+	amount          int64
+	userCooldown    time.Duration
+	addressCooldown time.Duration
+	nowFunc         func() time.Time
+	lk              sync.Mutex
+}
+
+func NewFaucet(w wallet.IWallet, s store.IStore, amount int64, userCooldown, addressCooldown time.Duration) *Faucet {
+	return &Faucet{
+		wallet:          w,
+		store:           s,
+		amount:          amount,
+		userCooldown:    userCooldown,
+		addressCooldown: addressCooldown,
+		nowFunc:         time.Now,
+	}
+}
+
+// Amount returns the amount of each claim in nanoPAC.
+func (f *Faucet) Amount() int64 {
+	return f.amount
+}
+
+// Send transfers the faucet amount to the address and returns the transaction ID.
+// It fails with a CooldownError if the user or the address has claimed in their cooldown.
+func (f *Faucet) Send(userID, address string) (string, error) {
+	if _, err := crypto.AddressFromString(address); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidAddress, address)
+	}
+
+	f.lk.Lock()
+	defer f.lk.Unlock()
+
+	now := f.nowFunc()
+	userKey := "user:" + userID
+	addressKey := "address:" + address
+
+	remaining := max(
+		f.remaining(userKey, f.userCooldown, now),
+		f.remaining(addressKey, f.addressCooldown, now),
+	)
+	if remaining > 0 {
+		return "", CooldownError{Remaining: remaining}
+	}
+
+	if f.wallet.Balance() < f.amount {
+		return "", ErrInsufficientBalance
+	}
+
+	txID, err := f.wallet.TransferTransaction(address, f.amount, memo)
+	if err != nil {
+		return "", err
+	}
+
+	for _, key := range []string{userKey, addressKey} {
+		err := f.store.SaveFaucetClaim(&store.FaucetClaim{
+			Key:       key,
+			ClaimedAt: now.Unix(),
+			TxID:      txID,
+		})
+		if err != nil {
+			return txID, fmt.Errorf("coins are sent, but the claim is not saved: %w", err)
+		}
+	}
+
+	return txID, nil
+}
+
+// remaining returns how long is left from the cooldown of the key.
+func (f *Faucet) remaining(key string, cooldown time.Duration, now time.Time) time.Duration {
+	claim := f.store.FaucetClaim(key)
+	if claim == nil {
+		return 0
+	}
+
+	return time.Unix(claim.ClaimedAt, 0).Add(cooldown).Sub(now)
+}
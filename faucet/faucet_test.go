@@ -0,0 +1,121 @@
+package faucet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/wallet"
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setup(t *testing.T) (*Faucet, *wallet.MockIWallet, *store.MockIStore, time.Time) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockWallet := wallet.NewMockIWallet(ctrl)
+	mockStore := store.NewMockIStore(ctrl)
+
+	now := time.Unix(1700000000, 0)
+	f := NewFaucet(mockWallet, mockStore, 5e9, 24*time.Hour, 72*time.Hour)
+	f.nowFunc = func() time.Time { return now }
+
+	return f, mockWallet, mockStore, now
+}
+
+func TestSend(t *testing.T) {
+	addr := crypto.NewAddress(crypto.AddressTypeBLSAccount, make([]byte, 20)).String()
+
+	t.Run("invalid address", func(t *testing.T) {
+		f, _, _, _ := setup(t)
+
+		_, err := f.Send("123", "invalid-addr")
+		assert.ErrorIs(t, err, ErrInvalidAddress)
+	})
+
+	t.Run("first claim", func(t *testing.T) {
+		f, mockWallet, mockStore, now := setup(t)
+
+		mockStore.EXPECT().FaucetClaim("user:123").Return(nil)
+		mockStore.EXPECT().FaucetClaim("address:" + addr).Return(nil)
+		mockWallet.EXPECT().Balance().Return(int64(100e9))
+		mockWallet.EXPECT().TransferTransaction(addr, int64(5e9), memo).Return("0x123", nil)
+		mockStore.EXPECT().SaveFaucetClaim(&store.FaucetClaim{
+			Key: "user:123", ClaimedAt: now.Unix(), TxID: "0x123",
+		}).Return(nil)
+		mockStore.EXPECT().SaveFaucetClaim(&store.FaucetClaim{
+			Key: "address:" + addr, ClaimedAt: now.Unix(), TxID: "0x123",
+		}).Return(nil)
+
+		txID, err := f.Send("123", addr)
+		require.NoError(t, err)
+		assert.Equal(t, "0x123", txID)
+	})
+
+	t.Run("user cooldown", func(t *testing.T) {
+		f, _, mockStore, now := setup(t)
+
+		mockStore.EXPECT().FaucetClaim("user:123").Return(
+			&store.FaucetClaim{Key: "user:123", ClaimedAt: now.Add(-time.Hour).Unix()})
+		mockStore.EXPECT().FaucetClaim("address:" + addr).Return(nil)
+
+		_, err := f.Send("123", addr)
+		var cooldownErr CooldownError
+		require.ErrorAs(t, err, &cooldownErr)
+		assert.Equal(t, 23*time.Hour, cooldownErr.Remaining)
+	})
+
+	t.Run("address cooldown", func(t *testing.T) {
+		f, _, mockStore, now := setup(t)
+
+		mockStore.EXPECT().FaucetClaim("user:456").Return(nil)
+		mockStore.EXPECT().FaucetClaim("address:" + addr).Return(
+			&store.FaucetClaim{Key: "address:" + addr, ClaimedAt: now.Add(-48 * time.Hour).Unix()})
+
+		_, err := f.Send("456", addr)
+		var cooldownErr CooldownError
+		require.ErrorAs(t, err, &cooldownErr)
+		assert.Equal(t, 24*time.Hour, cooldownErr.Remaining)
+	})
+
+	t.Run("cooldown is passed", func(t *testing.T) {
+		f, mockWallet, mockStore, now := setup(t)
+
+		mockStore.EXPECT().FaucetClaim("user:123").Return(
+			&store.FaucetClaim{Key: "user:123", ClaimedAt: now.Add(-25 * time.Hour).Unix()})
+		mockStore.EXPECT().FaucetClaim("address:" + addr).Return(
+			&store.FaucetClaim{Key: "address:" + addr, ClaimedAt: now.Add(-73 * time.Hour).Unix()})
+		mockWallet.EXPECT().Balance().Return(int64(100e9))
+		mockWallet.EXPECT().TransferTransaction(addr, int64(5e9), memo).Return("0x456", nil)
+		mockStore.EXPECT().SaveFaucetClaim(gomock.Any()).Return(nil).Times(2)
+
+		txID, err := f.Send("123", addr)
+		require.NoError(t, err)
+		assert.Equal(t, "0x456", txID)
+	})
+
+	t.Run("insufficient balance", func(t *testing.T) {
+		f, mockWallet, mockStore, _ := setup(t)
+
+		mockStore.EXPECT().FaucetClaim(gomock.Any()).Return(nil).Times(2)
+		mockWallet.EXPECT().Balance().Return(int64(1e9))
+
+		_, err := f.Send("123", addr)
+		assert.ErrorIs(t, err, ErrInsufficientBalance)
+	})
+
+	t.Run("transfer failed", func(t *testing.T) {
+		f, mockWallet, mockStore, _ := setup(t)
+
+		mockStore.EXPECT().FaucetClaim(gomock.Any()).Return(nil).Times(2)
+		mockWallet.EXPECT().Balance().Return(int64(100e9))
+		mockWallet.EXPECT().TransferTransaction(addr, int64(5e9), memo).Return("", errors.New("broadcast failed"))
+
+		_, err := f.Send("123", addr)
+		assert.Error(t, err)
+	})
+}
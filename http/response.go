@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+type commandRequest struct {
+	Args []string `json:"args"`
+}
+
+type argResponse struct {
+	Name     string   `json:"name"`
+	Desc     string   `json:"desc"`
+	Type     string   `json:"type"`
+	Optional bool     `json:"optional"`
+	Choices  []string `json:"choices,omitempty"`
+}
+
+type commandResponse struct {
+	Name        string            `json:"name"`
+	Desc        string            `json:"desc"`
+	Help        string            `json:"help,omitempty"`
+	Args        []argResponse     `json:"args"`
+	SubCommands []commandResponse `json:"sub_commands,omitempty"`
+	Deprecated  bool              `json:"deprecated,omitempty"`
+}
+
+type fieldResponse struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type tableResponse struct {
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+}
+
+// attachmentResponse carries the file of the result, the data is encoded in base64.
+type attachmentResponse struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
+type resultResponse struct {
+	Successful  bool                `json:"successful"`
+	Title       string              `json:"title,omitempty"`
+	Message     string              `json:"message"`
+	Warnings    []string            `json:"warnings,omitempty"`
+	Fields      []fieldResponse     `json:"fields,omitempty"`
+	Table       *tableResponse      `json:"table,omitempty"`
+	Attachment  *attachmentResponse `json:"attachment,omitempty"`
+	List        *engine.ListResult  `json:"list,omitempty"`
+	Suggestions []string            `json:"suggestions,omitempty"`
+	Maintenance bool                `json:"maintenance,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func newCommandResponse(cmd engine.Command) commandResponse {
+	res := commandResponse{
+		Name:       cmd.Name,
+		Desc:       cmd.Desc,
+		Help:       cmd.Help,
+		Args:       []argResponse{},
+		Deprecated: cmd.Deprecated,
+	}
+
+	for _, arg := range cmd.Args {
+		res.Args = append(res.Args, argResponse{
+			Name:     arg.Name,
+			Desc:     arg.Desc,
+			Type:     arg.Type.String(),
+			Optional: arg.Optional,
+			Choices:  arg.Choices,
+		})
+	}
+
+	for _, sub := range cmd.SubCommands {
+		if sub.ConfirmPhrase != "" {
+			continue
+		}
+
+		res.SubCommands = append(res.SubCommands, newCommandResponse(sub))
+	}
+
+	return res
+}
+
+func newResultResponse(res *engine.CommandResult) resultResponse {
+	resp := resultResponse{
+		Successful:  res.Successful,
+		Title:       res.Title,
+		Message:     res.Message,
+		Warnings:    res.Warnings,
+		List:        res.List,
+		Suggestions: res.Suggestions,
+		Maintenance: res.Maintenance,
+	}
+
+	for _, f := range res.Fields {
+		resp.Fields = append(resp.Fields, fieldResponse{Name: f.Name, Value: f.Value})
+	}
+
+	if res.Table != nil {
+		resp.Table = &tableResponse{Headers: res.Table.Headers, Rows: res.Table.Rows}
+	}
+
+	if res.Attachment != nil {
+		resp.Attachment = &attachmentResponse{
+			Name:        res.Attachment.Name,
+			ContentType: res.Attachment.ContentType,
+			Data:        res.Attachment.Data,
+		}
+	}
+
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("unable to write the HTTP response", "err", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, errStr string) {
+	writeJSON(w, status, errorResponse{Error: errStr})
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+const (
+	// callerPrefix keeps the HTTP clients apart from the other callers in the engine,
+	// so the authorized IDs of the clients are like "http:<name>".
+	callerPrefix = "http:"
+
+	commandsPath   = "/commands"
+	maxRequestSize = 64 * 1024
+)
+
+// Engine is the part of the bot engine that the gateway serves.
+type Engine interface {
+	Commands() []engine.Command
+	FindCommand(cmdName string) *engine.Command
+	IsCommandAllowed(cmdName string, appID engine.AppID) bool
+	RunWithOptions(opts engine.RunOptions, appID engine.AppID, callerID string,
+		inputs []string) (*engine.CommandResult, error)
+}
+
+// Server serves the engine commands over REST/JSON, for the clients with an API key:
+//
+//	GET  /commands                   lists the commands.
+//	POST /commands/{name}[/{sub}]    runs the command, the body is like {"args": ["..."]}.
+//
+// The key is sent in the X-API-Key header, or as a bearer token.
+type Server struct {
+	engine  Engine
+	apiKeys map[string]string
+	srv     *http.Server
+}
+
+func NewServer(botEngine Engine, cfg config.HTTPConfig) (*Server, error) {
+	if len(cfg.APIKeys) == 0 {
+		return nil, errors.New("no API key is set for the HTTP gateway")
+	}
+
+	s := &Server{
+		engine:  botEngine,
+		apiKeys: cfg.APIKeys,
+	}
+	s.srv = &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s, nil
+}
+
+// Handler returns the HTTP handler of the gateway.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(commandsPath, s.listCommands)
+	mux.HandleFunc(commandsPath+"/", s.runCommand)
+
+	return s.authenticate(mux)
+}
+
+// Start listens on the address and serves the commands in the background.
+func (s *Server) Start(_ context.Context) error {
+	listener, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return err
+	}
+
+	log.Info("HTTP gateway started", "addr", listener.Addr().String())
+
+	go func() {
+		if err := s.srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("HTTP gateway stopped", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+func (*Server) Name() string {
+	return "http"
+}
+
+func (*Server) AppID() engine.AppID {
+	return engine.AppIdHTTP
+}
+
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.srv.Shutdown(ctx); err != nil {
+		log.Error("unable to shut the HTTP gateway down", "err", err)
+	}
+}
+
+// authenticate rejects the requests without a valid API key, and passes the client name in the context.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := s.clientName(r)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "invalid API key")
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientKey{}, name)))
+	})
+}
+
+type clientKey struct{}
+
+func (s *Server) clientName(r *http.Request) (string, bool) {
+	key := r.Header.Get("X-API-Key")
+	if key == "" {
+		key, _ = strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	if key == "" {
+		return "", false
+	}
+
+	// all the keys are compared, so the timing doesn't tell which key is close.
+	name, found := "", false
+	for apiKey, client := range s.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) == 1 {
+			name, found = client, true
+		}
+	}
+
+	return name, found
+}
+
+func (s *Server) listCommands(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "use GET to list the commands")
+
+		return
+	}
+
+	cmds := []commandResponse{}
+	for _, cmd := range s.engine.Commands() {
+		if !s.isAvailable(cmd) {
+			continue
+		}
+
+		cmds = append(cmds, newCommandResponse(cmd))
+	}
+
+	writeJSON(w, http.StatusOK, cmds)
+}
+
+func (s *Server) runCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "use POST to run the commands")
+
+		return
+	}
+
+	// the path is like /commands/wallet/balance for the subcommands.
+	names := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, commandsPath), "/"), "/")
+	cmd := s.engine.FindCommand(names[0])
+	if cmd == nil || !s.isAvailable(*cmd) || len(names) > 2 {
+		writeError(w, http.StatusNotFound, "unknown command: "+strings.Join(names, " "))
+
+		return
+	}
+
+	if len(names) == 2 {
+		if sub := cmd.SubCommand(names[1]); sub != nil && sub.ConfirmPhrase != "" {
+			writeError(w, http.StatusForbidden, "the command needs a confirmation and is not available over HTTP")
+
+			return
+		}
+	}
+
+	req := commandRequest{}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "the request is too large")
+
+		return
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request: "+err.Error())
+
+			return
+		}
+	}
+
+	reqID := r.Header.Get("X-Request-ID")
+	if reqID == "" {
+		reqID = strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	callerID := callerPrefix + r.Context().Value(clientKey{}).(string)
+	inputs := make([]string, 0, len(names)+len(req.Args))
+	inputs = append(inputs, names...)
+	inputs = append(inputs, req.Args...)
+
+	log.Debug("HTTP command", "requestID", reqID, "command", strings.Join(names, " "), "by", callerID)
+
+	res, err := s.engine.RunWithOptions(engine.RunOptions{RequestID: reqID}, engine.AppIdHTTP, callerID, inputs)
+	if err != nil {
+		log.Warn("HTTP command failed", "requestID", reqID, "command", strings.Join(names, " "), "error", err)
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newResultResponse(res))
+}
+
+// isAvailable reports whether the command can be run over HTTP.
+// The commands that need a confirmation are not available, like on Telegram.
+func (s *Server) isAvailable(cmd engine.Command) bool {
+	return cmd.HasAppId(engine.AppIdHTTP) &&
+		s.engine.IsCommandAllowed(cmd.Name, engine.AppIdHTTP) &&
+		cmd.ConfirmPhrase == ""
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEngine struct {
+	cmds     []engine.Command
+	callerID string
+	inputs   []string
+}
+
+func (e *fakeEngine) Commands() []engine.Command {
+	return e.cmds
+}
+
+func (e *fakeEngine) FindCommand(cmdName string) *engine.Command {
+	for i := range e.cmds {
+		if e.cmds[i].Name == cmdName {
+			return &e.cmds[i]
+		}
+	}
+
+	return nil
+}
+
+func (e *fakeEngine) IsCommandAllowed(cmdName string, appID engine.AppID) bool {
+	cmd := e.FindCommand(cmdName)
+
+	return cmd != nil && cmd.HasAppId(appID)
+}
+
+func (e *fakeEngine) RunWithOptions(_ engine.RunOptions, _ engine.AppID, callerID string,
+	inputs []string,
+) (*engine.CommandResult, error) {
+	e.callerID = callerID
+	e.inputs = inputs
+	if inputs[0] == "fail" {
+		return nil, errors.New("node is not reachable")
+	}
+
+	res := engine.MakeSuccessfulResult("ok")
+	res.AddField("Height", "100", true)
+
+	return res, nil
+}
+
+func setup(t *testing.T) (http.Handler, *fakeEngine) {
+	t.Helper()
+
+	eng := &fakeEngine{
+		cmds: []engine.Command{
+			{
+				Name:   "node-info",
+				Desc:   "node information",
+				Args:   []engine.Args{{Name: "address", Desc: "validator address"}},
+				AppIDs: []engine.AppID{engine.AppIdHTTP},
+			},
+			{
+				Name:   "wallet",
+				AppIDs: []engine.AppID{engine.AppIdHTTP},
+				SubCommands: []engine.Command{
+					{Name: "balance"},
+					{Name: "reset", ConfirmPhrase: "reset the wallet"},
+				},
+			},
+			{Name: "fail", AppIDs: []engine.AppID{engine.AppIdHTTP}},
+			{Name: "discord-only", AppIDs: []engine.AppID{engine.AppIdDiscord}},
+			{Name: "toggle", AppIDs: []engine.AppID{engine.AppIdHTTP}, ConfirmPhrase: "toggle"},
+		},
+	}
+
+	s, err := NewServer(eng, config.HTTPConfig{APIKeys: map[string]string{"secret": "scripts"}})
+	require.NoError(t, err)
+
+	return s.Handler(), eng
+}
+
+func request(handler http.Handler, method, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestAuthentication(t *testing.T) {
+	handler, _ := setup(t)
+
+	assert.Equal(t, http.StatusUnauthorized, request(handler, http.MethodGet, "/commands", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, request(handler, http.MethodGet, "/commands", "wrong", "").Code)
+	assert.Equal(t, http.StatusOK, request(handler, http.MethodGet, "/commands", "secret", "").Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/commands", http.NoBody)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	_, err := NewServer(&fakeEngine{}, config.HTTPConfig{})
+	assert.Error(t, err)
+}
+
+func TestListCommands(t *testing.T) {
+	handler, _ := setup(t)
+
+	rec := request(handler, http.MethodGet, "/commands", "secret", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	cmds := []commandResponse{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cmds))
+	require.Len(t, cmds, 3)
+	assert.Equal(t, "node-info", cmds[0].Name)
+	assert.Equal(t, "string", cmds[0].Args[0].Type)
+	assert.Equal(t, "wallet", cmds[1].Name)
+	require.Len(t, cmds[1].SubCommands, 1)
+	assert.Equal(t, "balance", cmds[1].SubCommands[0].Name)
+	assert.Equal(t, "fail", cmds[2].Name)
+}
+
+func TestRunCommand(t *testing.T) {
+	handler, eng := setup(t)
+
+	t.Run("run the command", func(t *testing.T) {
+		rec := request(handler, http.MethodPost, "/commands/node-info", "secret", `{"args": ["pc1p..."]}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		res := resultResponse{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.True(t, res.Successful)
+		assert.Equal(t, "ok", res.Message)
+		assert.Equal(t, []fieldResponse{{Name: "Height", Value: "100"}}, res.Fields)
+		assert.Equal(t, "http:scripts", eng.callerID)
+		assert.Equal(t, []string{"node-info", "pc1p..."}, eng.inputs)
+	})
+
+	t.Run("run the subcommand", func(t *testing.T) {
+		rec := request(handler, http.MethodPost, "/commands/wallet/balance", "secret", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []string{"wallet", "balance"}, eng.inputs)
+	})
+
+	t.Run("engine error", func(t *testing.T) {
+		rec := request(handler, http.MethodPost, "/commands/fail", "secret", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{"error": "node is not reachable"}`, rec.Body.String())
+	})
+
+	t.Run("not available", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, request(handler, http.MethodPost, "/commands/unknown", "secret", "").Code)
+		assert.Equal(t, http.StatusNotFound, request(handler, http.MethodPost, "/commands/discord-only", "secret", "").Code)
+		assert.Equal(t, http.StatusNotFound, request(handler, http.MethodPost, "/commands/toggle", "secret", "").Code)
+		assert.Equal(t, http.StatusForbidden, request(handler, http.MethodPost, "/commands/wallet/reset", "secret", "").Code)
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, request(handler, http.MethodPost, "/commands/node-info", "secret", "{").Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, request(handler, http.MethodGet, "/commands/node-info", "secret", "").Code)
+	})
+}
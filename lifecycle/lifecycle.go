@@ -0,0 +1,93 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+)
+
+// Runner is a subsystem that runs in the background, like a bot or a server.
+// Start returns once the runner is started, the background work ends when the context is canceled.
+// Stop waits for the background work to finish.
+type Runner interface {
+	Start(ctx context.Context) error
+	Stop()
+}
+
+type namedRunner struct {
+	name   string
+	runner Runner
+}
+
+// Group starts the runners in the order that they are added, and stops them in the reverse order,
+// so the runners that depend on the others, like the bots on the engine, are stopped first.
+type Group struct {
+	runners      []namedRunner
+	started      int
+	cancel       context.CancelFunc
+	drainTimeout time.Duration
+}
+
+// NewGroup creates a group that waits at most the drain timeout for all its runners to stop.
+func NewGroup(drainTimeout time.Duration) *Group {
+	return &Group{
+		drainTimeout: drainTimeout,
+	}
+}
+
+// Add adds the runner to the group, the name is used in the logs.
+func (g *Group) Add(name string, runner Runner) {
+	g.runners = append(g.runners, namedRunner{name: name, runner: runner})
+}
+
+// Start starts the runners. If one of them fails, the started ones are stopped.
+func (g *Group) Start(ctx context.Context) error {
+	ctx, g.cancel = context.WithCancel(ctx)
+
+	for _, r := range g.runners {
+		log.Info("starting", "runner", r.name)
+
+		if err := r.runner.Start(ctx); err != nil {
+			g.Stop()
+
+			return fmt.Errorf("unable to start %s: %w", r.name, err)
+		}
+		g.started++
+	}
+
+	return nil
+}
+
+// Stop cancels the context of the runners and stops them in the reverse order.
+// The runners that don't stop before the drain timeout are left behind.
+func (g *Group) Stop() {
+	if g.cancel != nil {
+		g.cancel()
+	}
+
+	deadline := time.NewTimer(g.drainTimeout)
+	defer deadline.Stop()
+
+	for ; g.started > 0; g.started-- {
+		r := g.runners[g.started-1]
+
+		done := make(chan struct{})
+		go func() {
+			r.runner.Stop()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			log.Info("stopped", "runner", r.name)
+
+		case <-deadline.C:
+			log.Warn("drain timeout passed, the runners are left behind", "runner", r.name)
+			g.started = 0
+
+			return
+		}
+	}
+}
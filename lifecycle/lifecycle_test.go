@@ -0,0 +1,78 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRunner struct {
+	name     string
+	events   *[]string
+	startErr error
+	stopWait time.Duration
+	ctx      context.Context
+}
+
+func (r *testRunner) Start(ctx context.Context) error {
+	r.ctx = ctx
+	*r.events = append(*r.events, "start "+r.name)
+
+	return r.startErr
+}
+
+func (r *testRunner) Stop() {
+	time.Sleep(r.stopWait)
+	*r.events = append(*r.events, "stop "+r.name)
+}
+
+func TestGroup(t *testing.T) {
+	t.Run("start and stop in order", func(t *testing.T) {
+		events := []string{}
+		engine := &testRunner{name: "engine", events: &events}
+		bot := &testRunner{name: "bot", events: &events}
+
+		g := NewGroup(time.Second)
+		g.Add("engine", engine)
+		g.Add("bot", bot)
+
+		require.NoError(t, g.Start(context.Background()))
+		assert.NoError(t, bot.ctx.Err())
+
+		g.Stop()
+		assert.Error(t, bot.ctx.Err(), "the context is canceled on stop")
+		assert.Equal(t, []string{"start engine", "start bot", "stop bot", "stop engine"}, events)
+
+		g.Stop()
+		assert.Len(t, events, 4, "stopping twice is no-op")
+	})
+
+	t.Run("failed start", func(t *testing.T) {
+		events := []string{}
+		g := NewGroup(time.Second)
+		g.Add("engine", &testRunner{name: "engine", events: &events})
+		g.Add("bot", &testRunner{name: "bot", events: &events, startErr: errors.New("invalid token")})
+		g.Add("server", &testRunner{name: "server", events: &events})
+
+		err := g.Start(context.Background())
+		assert.ErrorContains(t, err, "unable to start bot: invalid token")
+		assert.Equal(t, []string{"start engine", "start bot", "stop engine"}, events)
+	})
+
+	t.Run("drain timeout", func(t *testing.T) {
+		events := []string{}
+		g := NewGroup(10 * time.Millisecond)
+		g.Add("engine", &testRunner{name: "engine", events: &events})
+		g.Add("bot", &testRunner{name: "bot", events: &events, stopWait: 200 * time.Millisecond})
+
+		require.NoError(t, g.Start(context.Background()))
+
+		start := time.Now()
+		g.Stop()
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+}
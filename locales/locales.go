@@ -0,0 +1,9 @@
+// Package locales holds the translated messages of the bot, a JSON file per language named by its code.
+// The keys are the message keys of the engine, see engine.MessageCatalog, and each file has all of them.
+// Only the generic messages are translated, like the errors and the titles, the results of the commands are in English.
+package locales
+
+import "embed"
+
+//go:embed *.json
+var FS embed.FS
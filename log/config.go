@@ -0,0 +1,92 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+
+	// DefaultFile is the log file of the default config.
+	DefaultFile = "RoboPac.log"
+
+	// DefaultModule is the name of the level of the global logger, and of the sub loggers without their own level.
+	DefaultModule = "default"
+)
+
+// Config holds the settings of the logger.
+type Config struct {
+	// Format is the format of the standard error output, console by default. The file output is always in JSON.
+	Format string
+	// Levels are the levels of the sub loggers keyed by their names, like "engine" or "wallet".
+	Levels map[string]string
+
+	// File is the path of the log file, the file output is disabled if it's empty.
+	// The file is rotated after MaxSize megabytes, and the old files are removed after MaxAge days,
+	// or when there are more than MaxBackups of them. Zero keeps all the old files.
+	File       string
+	MaxSize    int
+	MaxBackups int
+	MaxAge     int
+	Compress   bool
+}
+
+// DefaultConfig returns the config of the console output and the DefaultFile output at the info level.
+func DefaultConfig() *Config {
+	return &Config{
+		Format:  FormatConsole,
+		Levels:  map[string]string{DefaultModule: zerolog.InfoLevel.String()},
+		File:    DefaultFile,
+		MaxSize: 15,
+	}
+}
+
+// BasicCheck checks that the format and the levels are valid.
+func (conf *Config) BasicCheck() error {
+	errs := []error{}
+
+	switch conf.Format {
+	case "", FormatConsole, FormatJSON:
+	default:
+		errs = append(errs, fmt.Errorf("invalid format: %s", conf.Format))
+	}
+
+	for module, level := range conf.Levels {
+		if _, err := ParseLevel(level); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", module, err))
+		}
+	}
+
+	if conf.MaxSize < 0 || conf.MaxBackups < 0 || conf.MaxAge < 0 {
+		errs = append(errs, errors.New("the rotation settings can't be negative"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Levels returns the names of the levels that can be set, from the most verbose.
+func Levels() []string {
+	return []string{
+		zerolog.TraceLevel.String(),
+		zerolog.DebugLevel.String(),
+		zerolog.InfoLevel.String(),
+		zerolog.WarnLevel.String(),
+		zerolog.ErrorLevel.String(),
+	}
+}
+
+// ParseLevel parses the level by its name, like "debug". The names are case-insensitive.
+func ParseLevel(name string) (zerolog.Level, error) {
+	for level := zerolog.TraceLevel; level <= zerolog.ErrorLevel; level++ {
+		if strings.EqualFold(strings.TrimSpace(name), level.String()) {
+			return level, nil
+		}
+	}
+
+	return zerolog.NoLevel, fmt.Errorf("invalid level: %s, expected one of %s", name, strings.Join(Levels(), ", "))
+}
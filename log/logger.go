@@ -6,22 +6,59 @@ import (
 	"io"
 	"os"
 	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-var globalInst *logger
+var (
+	globalInst *logger
+
+	// globalLevel is the level of the global logger, it's the default level.
+	globalLevel = newLevelVar(zerolog.InfoLevel)
+)
 
 type logger struct {
+	lk     sync.Mutex
 	subs   map[string]*SubLogger
 	writer io.Writer
+	// levels are the levels of the modules, the sub loggers without a level have the default level.
+	levels map[string]zerolog.Level
 }
 
 type SubLogger struct {
 	logger zerolog.Logger
 	name   string
+	level  *levelVar
+}
+
+// levelVar is the level of a logger that can be changed at runtime,
+// the sub loggers of a module and their children share it.
+type levelVar struct {
+	v atomic.Int32
+}
+
+func newLevelVar(level zerolog.Level) *levelVar {
+	lv := &levelVar{}
+	lv.set(level)
+
+	return lv
+}
+
+func (lv *levelVar) get() zerolog.Level {
+	return zerolog.Level(lv.v.Load())
+}
+
+func (lv *levelVar) set(level zerolog.Level) {
+	lv.v.Store(int32(level))
+}
+
+func (lv *levelVar) enabled(level zerolog.Level) bool {
+	return level >= lv.get()
 }
 
 func getLoggersInst() *logger {
@@ -29,6 +66,7 @@ func getLoggersInst() *logger {
 		globalInst = &logger{
 			subs:   make(map[string]*SubLogger),
 			writer: zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: "15:04:05"},
+			levels: map[string]zerolog.Level{DefaultModule: globalLevel.get()},
 		}
 		log.Logger = zerolog.New(globalInst.writer).With().Timestamp().Logger()
 	}
@@ -36,26 +74,140 @@ func getLoggersInst() *logger {
 	return globalInst
 }
 
-func InitGlobalLogger() {
+// InitGlobalLogger initializes the global logger by the config, it should be called before creating the sub loggers.
+// If the global logger is already initialized, only the levels are applied.
+func InitGlobalLogger(conf *Config) error {
+	if err := conf.BasicCheck(); err != nil {
+		return err
+	}
+
 	if globalInst == nil {
 		writers := []io.Writer{}
-		writers = append(writers, zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: "15:04:05"})
+		if conf.Format == FormatJSON {
+			writers = append(writers, os.Stderr)
+		} else {
+			writers = append(writers, zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: "15:04:05"})
+		}
 
-		fw := &lumberjack.Logger{
-			Filename: "RoboPac.log",
-			MaxSize:  15,
+		if conf.File != "" {
+			writers = append(writers, &lumberjack.Logger{
+				Filename:   conf.File,
+				MaxSize:    conf.MaxSize,
+				MaxBackups: conf.MaxBackups,
+				MaxAge:     conf.MaxAge,
+				Compress:   conf.Compress,
+			})
 		}
-		writers = append(writers, fw)
 
 		globalInst = &logger{
 			subs:   make(map[string]*SubLogger),
 			writer: io.MultiWriter(writers...),
+			levels: map[string]zerolog.Level{DefaultModule: globalLevel.get()},
 		}
 		log.Logger = zerolog.New(globalInst.writer).With().Timestamp().Logger()
 	}
+
+	return SetLevels(conf.Levels)
+}
+
+// SetLevels replaces the levels of the modules, like the config levels.
+// The default level is kept if it's not set.
+func SetLevels(levels map[string]string) error {
+	parsed := make(map[string]zerolog.Level, len(levels))
+	for module, name := range levels {
+		level, err := ParseLevel(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", module, err)
+		}
+		parsed[module] = level
+	}
+
+	inst := getLoggersInst()
+	inst.lk.Lock()
+	defer inst.lk.Unlock()
+
+	if _, ok := parsed[DefaultModule]; !ok {
+		parsed[DefaultModule] = inst.levels[DefaultModule]
+	}
+	inst.levels = parsed
+	inst.applyLevels()
+
+	return nil
+}
+
+// SetLevel changes the level of a module at runtime. The default module changes the level of the global logger
+// and of the modules without their own level.
+func SetLevel(module, name string) error {
+	level, err := ParseLevel(name)
+	if err != nil {
+		return err
+	}
+
+	inst := getLoggersInst()
+	inst.lk.Lock()
+	defer inst.lk.Unlock()
+
+	if _, ok := inst.subs[module]; !ok && module != DefaultModule {
+		return fmt.Errorf("unknown module: %s", module)
+	}
+
+	inst.levels[module] = level
+	inst.applyLevels()
+
+	return nil
+}
+
+// ModuleLevels returns the current levels of the default module and the sub loggers, keyed by their names.
+func ModuleLevels() map[string]string {
+	inst := getLoggersInst()
+	inst.lk.Lock()
+	defer inst.lk.Unlock()
+
+	levels := map[string]string{DefaultModule: globalLevel.get().String()}
+	for name, sl := range inst.subs {
+		levels[name] = sl.level.get().String()
+	}
+
+	return levels
+}
+
+// Modules returns the names of the sub loggers, sorted.
+func Modules() []string {
+	inst := getLoggersInst()
+	inst.lk.Lock()
+	defer inst.lk.Unlock()
+
+	modules := make([]string, 0, len(inst.subs))
+	for name := range inst.subs {
+		modules = append(modules, name)
+	}
+	sort.Strings(modules)
+
+	return modules
+}
+
+// levelOf returns the level of the module, the caller should hold the lock.
+func (l *logger) levelOf(module string) zerolog.Level {
+	if level, ok := l.levels[module]; ok {
+		return level
+	}
+
+	return l.levels[DefaultModule]
+}
+
+// applyLevels sets the levels of the global logger and the sub loggers, the caller should hold the lock.
+func (l *logger) applyLevels() {
+	globalLevel.set(l.levelOf(DefaultModule))
+	for name, sl := range l.subs {
+		sl.level.set(l.levelOf(name))
+	}
 }
 
 func addFields(event *zerolog.Event, keyvals ...interface{}) *zerolog.Event {
+	if event == nil {
+		return nil
+	}
+
 	if len(keyvals)%2 != 0 {
 		keyvals = append(keyvals, "!MISSING-VALUE!")
 	}
@@ -88,9 +240,18 @@ func addFields(event *zerolog.Event, keyvals ...interface{}) *zerolog.Event {
 
 func NewSubLogger(name string) *SubLogger {
 	inst := getLoggersInst()
+	inst.lk.Lock()
+	defer inst.lk.Unlock()
+
+	// the sub loggers of a module share the level, so they are changed together.
+	prev, ok := inst.subs[name]
 	sl := &SubLogger{
 		logger: zerolog.New(inst.writer).With().Timestamp().Logger(),
 		name:   name,
+		level:  newLevelVar(inst.levelOf(name)),
+	}
+	if ok {
+		sl.level = prev.level
 	}
 
 	inst.subs[name] = sl
@@ -98,28 +259,69 @@ func NewSubLogger(name string) *SubLogger {
 	return sl
 }
 
+// NewSubLoggerWithWriter creates a sub logger that writes to w, mostly used for testing.
+// Unlike NewSubLogger, it's not kept by the global logger, and it logs all the levels.
+func NewSubLoggerWithWriter(name string, w io.Writer) *SubLogger {
+	return &SubLogger{
+		logger: zerolog.New(w).With().Timestamp().Logger(),
+		name:   name,
+		level:  newLevelVar(zerolog.TraceLevel),
+	}
+}
+
+// With returns a child logger that adds the key/value pairs to all of its log lines.
+func (sl *SubLogger) With(keyvals ...interface{}) *SubLogger {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "!MISSING-VALUE!")
+	}
+
+	ctx := sl.logger.With()
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = "!INVALID-KEY!"
+		}
+		ctx = ctx.Interface(key, keyvals[i+1])
+	}
+
+	return &SubLogger{
+		logger: ctx.Logger(),
+		name:   sl.name,
+		level:  sl.level,
+	}
+}
+
 func (sl *SubLogger) logObj(event *zerolog.Event, msg string, keyvals ...interface{}) {
 	addFields(event, keyvals...).Msg(msg)
 }
 
+// event returns the event of the level, or nil if the level is disabled. The nil events are not logged.
+func (sl *SubLogger) event(level zerolog.Level) *zerolog.Event {
+	if !sl.level.enabled(level) {
+		return nil
+	}
+
+	return sl.logger.WithLevel(level)
+}
+
 func (sl *SubLogger) Trace(msg string, keyvals ...interface{}) {
-	sl.logObj(sl.logger.Trace(), msg, keyvals...)
+	sl.logObj(sl.event(zerolog.TraceLevel), msg, keyvals...)
 }
 
 func (sl *SubLogger) Debug(msg string, keyvals ...interface{}) {
-	sl.logObj(sl.logger.Debug(), msg, keyvals...)
+	sl.logObj(sl.event(zerolog.DebugLevel), msg, keyvals...)
 }
 
 func (sl *SubLogger) Info(msg string, keyvals ...interface{}) {
-	sl.logObj(sl.logger.Info(), msg, keyvals...)
+	sl.logObj(sl.event(zerolog.InfoLevel), msg, keyvals...)
 }
 
 func (sl *SubLogger) Warn(msg string, keyvals ...interface{}) {
-	sl.logObj(sl.logger.Warn(), msg, keyvals...)
+	sl.logObj(sl.event(zerolog.WarnLevel), msg, keyvals...)
 }
 
 func (sl *SubLogger) Error(msg string, keyvals ...interface{}) {
-	sl.logObj(sl.logger.Error(), msg, keyvals...)
+	sl.logObj(sl.event(zerolog.ErrorLevel), msg, keyvals...)
 }
 
 func (sl *SubLogger) Fatal(msg string, keyvals ...interface{}) {
@@ -130,24 +332,33 @@ func (sl *SubLogger) Panic(msg string, keyvals ...interface{}) {
 	sl.logObj(sl.logger.Panic(), msg, keyvals...)
 }
 
+// globalEvent returns the event of the global logger, or nil if the level is disabled.
+func globalEvent(level zerolog.Level) *zerolog.Event {
+	if !globalLevel.enabled(level) {
+		return nil
+	}
+
+	return log.WithLevel(level)
+}
+
 func Trace(msg string, keyvals ...interface{}) {
-	addFields(log.Trace(), keyvals...).Msg(msg)
+	addFields(globalEvent(zerolog.TraceLevel), keyvals...).Msg(msg)
 }
 
 func Debug(msg string, keyvals ...interface{}) {
-	addFields(log.Debug(), keyvals...).Msg(msg)
+	addFields(globalEvent(zerolog.DebugLevel), keyvals...).Msg(msg)
 }
 
 func Info(msg string, keyvals ...interface{}) {
-	addFields(log.Info(), keyvals...).Msg(msg)
+	addFields(globalEvent(zerolog.InfoLevel), keyvals...).Msg(msg)
 }
 
 func Warn(msg string, keyvals ...interface{}) {
-	addFields(log.Warn(), keyvals...).Msg(msg)
+	addFields(globalEvent(zerolog.WarnLevel), keyvals...).Msg(msg)
 }
 
 func Error(msg string, keyvals ...interface{}) {
-	addFields(log.Error(), keyvals...).Msg(msg)
+	addFields(globalEvent(zerolog.ErrorLevel), keyvals...).Msg(msg)
 }
 
 func Fatal(msg string, keyvals ...interface{}) {
@@ -0,0 +1,85 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubLoggerLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sl := NewSubLoggerWithWriter("test", buf)
+	child := sl.With("key", "value")
+
+	sl.Trace("trace")
+	assert.Contains(t, buf.String(), `"message":"trace"`)
+
+	sl.level.set(zerolog.WarnLevel)
+	buf.Reset()
+	sl.Info("info")
+	child.Debug("debug")
+	assert.Empty(t, buf.String())
+
+	child.Warn("warn")
+	assert.Contains(t, buf.String(), `"key":"value"`)
+	assert.Contains(t, buf.String(), `"message":"warn"`)
+}
+
+func TestSetLevel(t *testing.T) {
+	sl := NewSubLogger("test-engine")
+	other := NewSubLogger("test-wallet")
+	require.NoError(t, SetLevels(map[string]string{DefaultModule: "info"}))
+
+	t.Run("module", func(t *testing.T) {
+		require.NoError(t, SetLevel("test-engine", "DEBUG"))
+		assert.Equal(t, zerolog.DebugLevel, sl.level.get())
+		assert.Equal(t, zerolog.InfoLevel, other.level.get())
+
+		// the sub loggers of a module share its level.
+		again := NewSubLogger("test-engine")
+		assert.Equal(t, zerolog.DebugLevel, again.level.get())
+	})
+
+	t.Run("default", func(t *testing.T) {
+		require.NoError(t, SetLevel(DefaultModule, "error"))
+		assert.Equal(t, zerolog.ErrorLevel, globalLevel.get())
+		assert.Equal(t, zerolog.ErrorLevel, other.level.get())
+		assert.Equal(t, zerolog.DebugLevel, sl.level.get())
+
+		levels := ModuleLevels()
+		assert.Equal(t, "error", levels[DefaultModule])
+		assert.Equal(t, "debug", levels["test-engine"])
+		assert.Equal(t, "error", levels["test-wallet"])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, SetLevel("test-engine", "verbose"))
+		assert.Error(t, SetLevel("test-engine", "fatal"))
+		assert.Error(t, SetLevel("unknown", "debug"))
+		assert.Error(t, SetLevels(map[string]string{"test-engine": "loud"}))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		require.NoError(t, SetLevels(map[string]string{"test-wallet": "trace"}))
+		assert.Equal(t, zerolog.ErrorLevel, globalLevel.get())
+		assert.Equal(t, zerolog.ErrorLevel, sl.level.get())
+		assert.Equal(t, zerolog.TraceLevel, other.level.get())
+	})
+}
+
+func TestConfigBasicCheck(t *testing.T) {
+	assert.NoError(t, DefaultConfig().BasicCheck())
+	assert.NoError(t, (&Config{}).BasicCheck())
+
+	conf := DefaultConfig()
+	conf.Format = "xml"
+	conf.Levels["engine"] = "loud"
+	conf.MaxAge = -1
+	err := conf.BasicCheck()
+	assert.ErrorContains(t, err, "invalid format: xml")
+	assert.ErrorContains(t, err, "engine: invalid level: loud")
+	assert.ErrorContains(t, err, "can't be negative")
+}
@@ -0,0 +1,66 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const DefaultCoinMarketCapURL = "https://pro-api.coinmarketcap.com/v2/cryptocurrency/quotes/latest?slug=pactus&convert=USD"
+
+// CoinMarketCap fetches the market data of PAC from CoinMarketCap, it needs an API key.
+type CoinMarketCap struct {
+	url    string
+	apiKey string
+}
+
+func NewCoinMarketCap(url, apiKey string) *CoinMarketCap {
+	return &CoinMarketCap{url: url, apiKey: apiKey}
+}
+
+type cmcQuote struct {
+	Price            float64   `json:"price"`
+	Volume24h        float64   `json:"volume_24h"`
+	PercentChange24h float64   `json:"percent_change_24h"`
+	MarketCap        float64   `json:"market_cap"`
+	LastUpdated      time.Time `json:"last_updated"`
+}
+
+type cmcResponse struct {
+	// Data is keyed by the ID of the coins, only one coin is requested.
+	Data map[string]struct {
+		Quote map[string]cmcQuote `json:"quote"`
+	} `json:"data"`
+}
+
+func (*CoinMarketCap) Name() string {
+	return "CoinMarketCap"
+}
+
+func (c *CoinMarketCap) Fetch(ctx context.Context) (*Price, error) {
+	resp := cmcResponse{}
+	header := http.Header{}
+	header.Set("X-CMC_PRO_API_KEY", c.apiKey)
+	if err := getJSON(ctx, c.url, header, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, coin := range resp.Data {
+		quote, ok := coin.Quote["USD"]
+		if !ok {
+			break
+		}
+
+		return &Price{
+			Source:    c.Name(),
+			USD:       quote.Price,
+			Change24h: quote.PercentChange24h,
+			Volume24h: quote.Volume24h,
+			MarketCap: quote.MarketCap,
+			UpdatedAt: quote.LastUpdated,
+		}, nil
+	}
+
+	return nil, errors.New("the USD quote is not in the response")
+}
@@ -0,0 +1,132 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/log"
+)
+
+const requestTimeout = 10 * time.Second
+
+// Price is the market data of PAC in USD.
+type Price struct {
+	Source    string
+	USD       float64
+	Change24h float64 // in percent.
+	Volume24h float64
+	// MarketCap is zero if the source doesn't provide it.
+	MarketCap float64
+	UpdatedAt time.Time
+}
+
+// Source fetches the market data from an exchange or a data aggregator.
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context) (*Price, error)
+}
+
+// Market serves the PAC market data from the sources, the first source that responds is used.
+// The data is cached, so the frequent commands don't hit the rate limits of the sources.
+// A zero cache TTL disables the cache.
+type Market struct {
+	sources  []Source
+	cacheTTL time.Duration
+	nowFunc  func() time.Time
+
+	lk      sync.Mutex
+	cached  *Price
+	expires time.Time
+}
+
+func NewMarket(sources []Source, cacheTTL time.Duration) *Market {
+	return &Market{
+		sources:  sources,
+		cacheTTL: cacheTTL,
+		nowFunc:  time.Now,
+	}
+}
+
+// NewMarketFromConfig creates the market with the sources of the config, in the same order.
+func NewMarketFromConfig(cfg config.MarketConfig) (*Market, error) {
+	sources := make([]Source, 0, len(cfg.Sources))
+	for _, name := range cfg.Sources {
+		switch name {
+		case config.MarketSourceXeggex:
+			sources = append(sources, NewXeggex(DefaultXeggexURL))
+		case config.MarketSourceCoinMarketCap:
+			sources = append(sources, NewCoinMarketCap(DefaultCoinMarketCapURL, cfg.CoinMarketCapKey))
+		default:
+			return nil, fmt.Errorf("unknown market source: %s", name)
+		}
+	}
+
+	return NewMarket(sources, cfg.CacheTTL), nil
+}
+
+// Price returns the market data. If all the sources fail, the last data is returned if there is any,
+// so a short outage of the sources doesn't break the command.
+func (m *Market) Price(ctx context.Context) (*Price, error) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	now := m.nowFunc()
+	if m.cached != nil && now.Before(m.expires) {
+		return m.cached, nil
+	}
+
+	errs := []error{}
+	for _, source := range m.sources {
+		price, err := source.Fetch(ctx)
+		if err != nil {
+			log.Warn("unable to fetch the market data", "source", source.Name(), "err", err)
+			errs = append(errs, fmt.Errorf("%s: %w", source.Name(), err))
+
+			continue
+		}
+
+		m.cached = price
+		m.expires = now.Add(m.cacheTTL)
+
+		return price, nil
+	}
+
+	if m.cached != nil {
+		return m.cached, nil
+	}
+
+	return nil, errors.Join(append([]error{errors.New("market data is not available")}, errs...)...)
+}
+
+// getJSON gets the URL and decodes the JSON response into the result.
+func getJSON(ctx context.Context, url string, header http.Header, result any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,110 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	name  string
+	price *Price
+	err   error
+	calls int
+}
+
+func (s *fakeSource) Name() string {
+	return s.name
+}
+
+func (s *fakeSource) Fetch(_ context.Context) (*Price, error) {
+	s.calls++
+
+	return s.price, s.err
+}
+
+func TestMarketPrice(t *testing.T) {
+	now := time.Now()
+	down := &fakeSource{name: "down", err: errors.New("connection refused")}
+	up := &fakeSource{name: "up", price: &Price{Source: "up", USD: 0.5}}
+
+	m := NewMarket([]Source{down, up}, time.Minute)
+	m.nowFunc = func() time.Time { return now }
+
+	t.Run("the first source that responds", func(t *testing.T) {
+		price, err := m.Price(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "up", price.Source)
+		assert.Equal(t, 1, down.calls)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		_, err := m.Price(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, up.calls)
+	})
+
+	t.Run("the last data on failure", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		up.err = errors.New("rate limited")
+
+		price, err := m.Price(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, price.USD)
+		assert.Equal(t, 2, up.calls)
+	})
+
+	t.Run("not available", func(t *testing.T) {
+		_, err := NewMarket([]Source{down}, time.Minute).Price(context.Background())
+		assert.ErrorContains(t, err, "down: connection refused")
+	})
+}
+
+func TestXeggex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"symbol": "PAC/USDT", "lastPrice": "0.3512", "changePercent": "-2.15",
+			"volumeUsdNumber": 12345.6, "marketcapNumber": 0}`))
+	}))
+	defer server.Close()
+
+	price, err := NewXeggex(server.URL).Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Xeggex", price.Source)
+	assert.Equal(t, 0.3512, price.USD)
+	assert.Equal(t, -2.15, price.Change24h)
+	assert.Equal(t, 12345.6, price.Volume24h)
+	assert.Zero(t, price.MarketCap)
+}
+
+func TestCoinMarketCap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-CMC_PRO_API_KEY") != "cmc-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data": {"29910": {"symbol": "PAC", "quote": {"USD": {"price": 0.35,
+			"volume_24h": 1000.5, "percent_change_24h": 1.5, "market_cap": 5000000,
+			"last_updated": "2024-05-01T10:00:00.000Z"}}}}}`))
+	}))
+	defer server.Close()
+
+	price, err := NewCoinMarketCap(server.URL, "cmc-key").Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "CoinMarketCap", price.Source)
+	assert.Equal(t, 0.35, price.USD)
+	assert.Equal(t, 1.5, price.Change24h)
+	assert.Equal(t, 1000.5, price.Volume24h)
+	assert.Equal(t, 5000000.0, price.MarketCap)
+	assert.Equal(t, 2024, price.UpdatedAt.Year())
+
+	_, err = NewCoinMarketCap(server.URL, "wrong").Fetch(context.Background())
+	assert.ErrorContains(t, err, "401")
+}
@@ -0,0 +1,60 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const DefaultXeggexURL = "https://api.xeggex.com/api/v2/market/getbysymbol/PAC_USDT"
+
+// Xeggex fetches the market data of the PAC/USDT market of the Xeggex exchange, it's a public API.
+type Xeggex struct {
+	url string
+}
+
+func NewXeggex(url string) *Xeggex {
+	return &Xeggex{url: url}
+}
+
+type xeggexMarket struct {
+	LastPrice       string  `json:"lastPrice"`
+	ChangePercent   string  `json:"changePercent"`
+	VolumeUSD       float64 `json:"volumeUsdNumber"`
+	MarketCapNumber float64 `json:"marketcapNumber"`
+}
+
+func (*Xeggex) Name() string {
+	return "Xeggex"
+}
+
+func (x *Xeggex) Fetch(ctx context.Context) (*Price, error) {
+	market := xeggexMarket{}
+	if err := getJSON(ctx, x.url, nil, &market); err != nil {
+		return nil, err
+	}
+
+	price, err := strconv.ParseFloat(market.LastPrice, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price: %w", err)
+	}
+
+	// the change is empty when there is no trade in the last 24 hours.
+	change := 0.0
+	if market.ChangePercent != "" {
+		change, err = strconv.ParseFloat(market.ChangePercent, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid change: %w", err)
+		}
+	}
+
+	return &Price{
+		Source:    x.Name(),
+		USD:       price,
+		Change24h: change,
+		Volume24h: market.VolumeUSD,
+		MarketCap: market.MarketCapNumber,
+		UpdatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,193 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	apiPrefix = "/_matrix/client/v3"
+
+	// syncTimeout is the long polling timeout of sync in milliseconds.
+	syncTimeout = 30000
+
+	eventRoomMessage = "m.room.message"
+	msgTypeText      = "m.text"
+	msgTypeNotice    = "m.notice"
+	formatHTML       = "org.matrix.custom.html"
+
+	errCodeNotFound = "M_NOT_FOUND"
+)
+
+type event struct {
+	Type    string         `json:"type"`
+	EventID string         `json:"event_id"`
+	Sender  string         `json:"sender"`
+	Content messageContent `json:"content"`
+}
+
+type messageContent struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+type joinedRoom struct {
+	Timeline struct {
+		Events []event `json:"events"`
+	} `json:"timeline"`
+}
+
+type syncResponse struct {
+	NextBatch string `json:"next_batch"`
+	Rooms     struct {
+		Join map[string]joinedRoom `json:"join"`
+	} `json:"rooms"`
+}
+
+type apiError struct {
+	ErrCode string `json:"errcode"`
+	Message string `json:"error"`
+}
+
+func (e *apiError) Error() string {
+	return e.ErrCode + ": " + e.Message
+}
+
+// clientAPI is a minimal client of the Matrix Client-Server API, covering the endpoints that the bot needs.
+type clientAPI struct {
+	url         string
+	accessToken string
+	httpClient  *http.Client
+}
+
+func newClientAPI(homeserverURL, accessToken string) *clientAPI {
+	return &clientAPI{
+		url:         homeserverURL,
+		accessToken: accessToken,
+		httpClient: &http.Client{
+			Timeout: syncTimeout*time.Millisecond + 10*time.Second,
+		},
+	}
+}
+
+func (api *clientAPI) call(ctx context.Context, method, path string, params, result any) error {
+	var body bytes.Buffer
+	if params != nil {
+		if err := json.NewEncoder(&body).Encode(params); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, api.url+apiPrefix+path, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+api.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &apiError{}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil || apiErr.ErrCode == "" {
+			return fmt.Errorf("matrix %s: %s", path, resp.Status)
+		}
+
+		return fmt.Errorf("matrix %s: %w", path, apiErr)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("matrix %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// joinRoom joins the room by its ID or alias, and returns the room ID.
+func (api *clientAPI) joinRoom(ctx context.Context, room string) (string, error) {
+	result := struct {
+		RoomID string `json:"room_id"`
+	}{}
+	err := api.call(ctx, http.MethodPost, "/join/"+url.PathEscape(room), map[string]any{}, &result)
+
+	return result.RoomID, err
+}
+
+// createDirectRoom creates a private room with the user, and invites them to it.
+func (api *clientAPI) createDirectRoom(ctx context.Context, userID string) (string, error) {
+	result := struct {
+		RoomID string `json:"room_id"`
+	}{}
+	err := api.call(ctx, http.MethodPost, "/createRoom", map[string]any{
+		"is_direct": true,
+		"preset":    "trusted_private_chat",
+		"invite":    []string{userID},
+	}, &result)
+
+	return result.RoomID, err
+}
+
+// directRooms returns the direct rooms of the bot user by the other users, from the m.direct account data.
+func (api *clientAPI) directRooms(ctx context.Context, botUserID string) (map[string][]string, error) {
+	rooms := map[string][]string{}
+	err := api.call(ctx, http.MethodGet, directRoomsPath(botUserID), nil, &rooms)
+
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.ErrCode == errCodeNotFound {
+		return map[string][]string{}, nil
+	}
+
+	return rooms, err
+}
+
+// setDirectRooms saves the direct rooms of the bot user in the m.direct account data.
+func (api *clientAPI) setDirectRooms(ctx context.Context, botUserID string, rooms map[string][]string) error {
+	return api.call(ctx, http.MethodPut, directRoomsPath(botUserID), rooms, nil)
+}
+
+func directRoomsPath(botUserID string) string {
+	return "/user/" + url.PathEscape(botUserID) + "/account_data/m.direct"
+}
+
+// sync returns the events since the given batch, the first sync without a batch returns the recent events.
+func (api *clientAPI) sync(ctx context.Context, since string) (*syncResponse, error) {
+	query := url.Values{}
+	query.Set("timeout", strconv.Itoa(syncTimeout))
+	if since != "" {
+		query.Set("since", since)
+	}
+
+	result := &syncResponse{}
+	err := api.call(ctx, http.MethodGet, "/sync?"+query.Encode(), nil, result)
+
+	return result, err
+}
+
+// sendMessage sends a notice with an HTML body to the room, the transaction ID makes the retries idempotent.
+func (api *clientAPI) sendMessage(ctx context.Context, roomID, txnID, body, htmlBody string) error {
+	return api.call(ctx, http.MethodPut,
+		fmt.Sprintf("/rooms/%s/send/%s/%s", url.PathEscape(roomID), eventRoomMessage, url.PathEscape(txnID)),
+		messageContent{
+			MsgType:       msgTypeNotice,
+			Body:          body,
+			Format:        formatHTML,
+			FormattedBody: htmlBody,
+		}, nil)
+}
@@ -0,0 +1,249 @@
+package matrix
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+const (
+	// callerPrefix keeps the Matrix users apart from the other apps in the engine,
+	// so the authorized IDs of Matrix users are like "matrix:@alice:matrix.org".
+	callerPrefix = "matrix:"
+
+	// commandPrefix starts the messages that are commands, like "!robopac node-info pc1p...".
+	commandPrefix = "!robopac"
+
+	syncRetryDelay = 5 * time.Second
+	replyTimeout   = 10 * time.Second
+)
+
+type MatrixBot struct {
+	BotEngine *engine.BotEngine
+
+	api    *clientAPI
+	userID string
+	rooms  []string
+	// roomIDs are the joined rooms, the messages of the other rooms are ignored.
+	roomIDs map[string]bool
+	// directRooms are the private rooms of the bot with the users, by their user IDs.
+	// They are only used by the polling, after the start.
+	directRooms map[string][]string
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+func NewMatrixBot(botEngine *engine.BotEngine, cfg config.MatrixBotConfig) (*MatrixBot, error) {
+	if cfg.AccessToken == "" {
+		return nil, errors.New("matrix access token is not set")
+	}
+
+	if len(cfg.Rooms) == 0 {
+		return nil, errors.New("matrix rooms are not set")
+	}
+
+	return &MatrixBot{
+		BotEngine: botEngine,
+		api:       newClientAPI(strings.TrimSuffix(cfg.HomeserverURL, "/"), cfg.AccessToken),
+		userID:    cfg.UserID,
+		rooms:     cfg.Rooms,
+		roomIDs:   make(map[string]bool, len(cfg.Rooms)),
+	}, nil
+}
+
+// Start joins the rooms and syncs the messages, until the context is canceled or the bot is stopped.
+func (bot *MatrixBot) Start(ctx context.Context) error {
+	log.Info("starting Matrix Bot...")
+
+	bot.ctx, bot.cancel = context.WithCancel(ctx)
+	if err := bot.joinRooms(); err != nil {
+		bot.cancel()
+
+		return err
+	}
+
+	directRooms, err := bot.api.directRooms(bot.ctx, bot.userID)
+	if err != nil {
+		bot.cancel()
+		log.Error("can not get matrix direct rooms", "error", err)
+
+		return err
+	}
+	bot.directRooms = directRooms
+
+	bot.wg.Add(1)
+	go func() {
+		defer bot.wg.Done()
+		bot.poll()
+	}()
+
+	return nil
+}
+
+func (bot *MatrixBot) joinRooms() error {
+	for _, room := range bot.rooms {
+		roomID, err := bot.api.joinRoom(bot.ctx, room)
+		if err != nil {
+			log.Error("can not join matrix room", "room", room, "error", err)
+			return err
+		}
+
+		bot.roomIDs[roomID] = true
+	}
+	log.Info("matrix rooms joined", "count", len(bot.roomIDs))
+
+	return nil
+}
+
+// poll receives the events by long polling, until the bot is stopped.
+// The messages that are sent before starting the bot are not handled.
+func (bot *MatrixBot) poll() {
+	since := ""
+	for {
+		res, err := bot.api.sync(bot.ctx, since)
+		if err != nil {
+			if bot.ctx.Err() != nil {
+				return
+			}
+
+			log.Error("can't sync matrix events", "error", err)
+			select {
+			case <-bot.ctx.Done():
+				return
+			case <-time.After(syncRetryDelay):
+			}
+
+			continue
+		}
+
+		if since != "" {
+			for roomID, room := range res.Rooms.Join {
+				for _, ev := range room.Timeline.Events {
+					bot.handleEvent(roomID, ev)
+				}
+			}
+		}
+		since = res.NextBatch
+	}
+}
+
+// handleEvent runs the command of the message, in the joined rooms or the direct rooms of the sender.
+// The results are private, so they are always sent to a direct room of the sender.
+func (bot *MatrixBot) handleEvent(roomID string, ev event) {
+	direct := slices.Contains(bot.directRooms[ev.Sender], roomID)
+	if (!bot.roomIDs[roomID] && !direct) || ev.Type != eventRoomMessage ||
+		ev.Content.MsgType != msgTypeText || ev.Sender == bot.userID {
+		return
+	}
+
+	inputs, ok := parseCommand(ev.Content.Body)
+	if !ok {
+		return
+	}
+
+	if !direct {
+		var err error
+		roomID, err = bot.directRoom(ev.Sender)
+		if err != nil {
+			log.Error("can't open matrix direct room", "error", err, "userID", ev.Sender)
+
+			return
+		}
+	}
+
+	cmdName := inputs[0]
+	reqID := ev.EventID
+	callerID := callerPrefix + ev.Sender
+	msgs := bot.BotEngine.MessagesFor(callerID)
+
+	log.Debug("matrix command", "requestID", reqID, "command", cmdName, "by", callerID)
+
+	if cmd := bot.BotEngine.FindCommand(cmdName); cmd != nil && cmd.ConfirmPhrase != "" {
+		bot.respond(roomID, reqID, errorMessage(msgs,
+			"`"+cmdName+"` needs a confirmation and is not available on Matrix"))
+		return
+	}
+
+	res, err := bot.BotEngine.RunWithOptions(engine.RunOptions{RequestID: reqID}, engine.AppIdMatrix, callerID, inputs)
+	if err != nil {
+		log.Warn("matrix command failed", "requestID", reqID, "command", cmdName, "error", err)
+		bot.respond(roomID, reqID, errorMessage(msgs, err.Error()))
+		return
+	}
+
+	bot.respond(roomID, reqID, resultMessage(res, msgs))
+}
+
+// directRoom returns the direct room with the user, the room is created if the user has none.
+func (bot *MatrixBot) directRoom(userID string) (string, error) {
+	if rooms := bot.directRooms[userID]; len(rooms) > 0 {
+		return rooms[0], nil
+	}
+
+	roomID, err := bot.api.createDirectRoom(bot.ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	bot.directRooms[userID] = []string{roomID}
+	if err := bot.api.setDirectRooms(bot.ctx, bot.userID, bot.directRooms); err != nil {
+		log.Warn("can't save matrix direct rooms", "error", err)
+	}
+
+	return roomID, nil
+}
+
+// respond sends the message to the room, a command that is handled while the bot is stopping is still answered.
+// The event ID of the command is the transaction ID of the reply, so a command is answered once.
+func (bot *MatrixBot) respond(roomID, eventID string, msg message) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(bot.ctx), replyTimeout)
+	defer cancel()
+
+	if err := bot.api.sendMessage(ctx, roomID, eventID, msg.body, msg.html); err != nil {
+		log.Error("can't send matrix message", "error", err, "roomID", roomID)
+	}
+}
+
+func (*MatrixBot) Name() string {
+	return "matrix"
+}
+
+func (*MatrixBot) AppID() engine.AppID {
+	return engine.AppIdMatrix
+}
+
+// Stop stops syncing and waits for the handling event to be answered.
+func (bot *MatrixBot) Stop() {
+	log.Info("shutting down Matrix Bot...")
+
+	if bot.cancel != nil {
+		bot.cancel()
+	}
+	bot.wg.Wait()
+}
+
+// parseCommand parses a message like "!robopac node-info pc1p..." into the engine inputs.
+// The prefix alone shows the help.
+func parseCommand(text string) ([]string, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.EqualFold(fields[0], commandPrefix) {
+		return nil, false
+	}
+
+	if len(fields) == 1 {
+		return []string{engine.HelpCommandName}, true
+	}
+
+	inputs := fields[1:]
+	inputs[0] = strings.ToLower(inputs[0])
+
+	return inputs, true
+}
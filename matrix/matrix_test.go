@@ -0,0 +1,152 @@
+package matrix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	inputs, ok := parseCommand("!robopac Node-Info  pc1pabc")
+	require.True(t, ok)
+	assert.Equal(t, []string{"node-info", "pc1pabc"}, inputs)
+
+	inputs, ok = parseCommand("!RoboPac")
+	require.True(t, ok)
+	assert.Equal(t, []string{engine.HelpCommandName}, inputs)
+
+	_, ok = parseCommand("hello !robopac help")
+	assert.False(t, ok)
+
+	_, ok = parseCommand("")
+	assert.False(t, ok)
+}
+
+func TestResultMessage(t *testing.T) {
+	messages := engine.NewMessageCatalog()
+
+	res := engine.MakeSuccessfulResult("height <100>\nsynced")
+	res.AddWarning("deprecated")
+	res.AddField("Peers", "10", true)
+	res.List = &engine.ListResult{Total: 25, Page: 2, PageSize: 10}
+	res.Suggest("node-info")
+
+	msg := resultMessage(res, messages)
+	assert.Equal(t, "Successful\n\n"+
+		"⚠️ deprecated\n\n"+
+		"height <100>\nsynced\n\n"+
+		"Peers: 10\n\n"+
+		"Page 2/3 (25 items) • Try next: !robopac node-info", msg.body)
+	assert.Equal(t, "<strong>Successful</strong><br><br>"+
+		"⚠️ deprecated<br><br>"+
+		"height &lt;100&gt;<br>synced<br><br>"+
+		"<strong>Peers</strong>: 10<br><br>"+
+		"<em>Page 2/3 (25 items) • Try next: !robopac node-info</em>", msg.html)
+
+	table := engine.MakeSuccessfulResult("stakes")
+	table.Title = "Validators"
+	table.SetTable("Address", "Stake")
+	table.AddRow("pc1p<a>", "10")
+	assert.Equal(t, "<strong>Validators</strong><br><br>stakes<br><br>"+
+		"<pre><code>Address  Stake\n-------  -----\npc1p&lt;a&gt;  10</code></pre>", resultMessage(table, messages).html)
+
+	errMsg := errorMessage(messages, "")
+	assert.Equal(t, "Error\n\nSomething went wrong, please try again later", errMsg.body)
+	assert.Equal(t, "<strong>Error</strong><br><br>Something went wrong, please try again later", errMsg.html)
+}
+
+func TestClientAPI(t *testing.T) {
+	var received messageContent
+	var receivedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == apiPrefix+"/join/#pactus:matrix.org":
+			_, _ = w.Write([]byte(`{"room_id":"!room:matrix.org"}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/sync":
+			assert.Equal(t, "batch-1", r.URL.Query().Get("since"))
+			_, _ = w.Write([]byte(`{"next_batch":"batch-2","rooms":{"join":{"!room:matrix.org":{"timeline":{"events":[` +
+				`{"type":"m.room.message","event_id":"$ev","sender":"@alice:matrix.org",` +
+				`"content":{"msgtype":"m.text","body":"!robopac help"}}]}}}}}`))
+
+		case r.Method == http.MethodPost && r.URL.Path == apiPrefix+"/createRoom":
+			var params map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+			assert.Equal(t, true, params["is_direct"])
+			assert.Equal(t, []any{"@alice:matrix.org"}, params["invite"])
+			_, _ = w.Write([]byte(`{"room_id":"!dm:matrix.org"}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/user/@bot:matrix.org/account_data/m.direct":
+			_, _ = w.Write([]byte(`{"@alice:matrix.org":["!dm:matrix.org"]}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/user/@new:matrix.org/account_data/m.direct":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errcode":"M_NOT_FOUND","error":"Account data not found"}`))
+
+		case r.Method == http.MethodPut:
+			receivedPath = r.URL.EscapedPath()
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_, _ = w.Write([]byte(`{"event_id":"$reply"}`))
+
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errcode":"M_FORBIDDEN","error":"You are not invited to this room."}`))
+		}
+	}))
+	defer server.Close()
+
+	api := newClientAPI(server.URL, "token")
+
+	t.Run("join room", func(t *testing.T) {
+		roomID, err := api.joinRoom(context.Background(), "#pactus:matrix.org")
+		require.NoError(t, err)
+		assert.Equal(t, "!room:matrix.org", roomID)
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		res, err := api.sync(context.Background(), "batch-1")
+		require.NoError(t, err)
+		assert.Equal(t, "batch-2", res.NextBatch)
+
+		events := res.Rooms.Join["!room:matrix.org"].Timeline.Events
+		require.Len(t, events, 1)
+		assert.Equal(t, "@alice:matrix.org", events[0].Sender)
+		assert.Equal(t, "!robopac help", events[0].Content.Body)
+	})
+
+	t.Run("send message", func(t *testing.T) {
+		err := api.sendMessage(context.Background(), "!room:matrix.org", "$ev", "hi", "<strong>hi</strong>")
+		require.NoError(t, err)
+		assert.Equal(t, apiPrefix+"/rooms/%21room:matrix.org/send/m.room.message/$ev", receivedPath)
+		assert.Equal(t, msgTypeNotice, received.MsgType)
+		assert.Equal(t, formatHTML, received.Format)
+		assert.Equal(t, "<strong>hi</strong>", received.FormattedBody)
+	})
+
+	t.Run("direct rooms", func(t *testing.T) {
+		roomID, err := api.createDirectRoom(context.Background(), "@alice:matrix.org")
+		require.NoError(t, err)
+		assert.Equal(t, "!dm:matrix.org", roomID)
+
+		rooms, err := api.directRooms(context.Background(), "@bot:matrix.org")
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"@alice:matrix.org": {"!dm:matrix.org"}}, rooms)
+
+		rooms, err = api.directRooms(context.Background(), "@new:matrix.org")
+		require.NoError(t, err)
+		assert.Empty(t, rooms, "the bot has no direct rooms yet")
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		_, err := api.joinRoom(context.Background(), "#private:matrix.org")
+		assert.ErrorContains(t, err, "M_FORBIDDEN")
+	})
+}
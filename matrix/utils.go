@@ -0,0 +1,113 @@
+package matrix
+
+import (
+	"html"
+	"strings"
+
+	"github.com/kehiy/RoboPac/engine"
+)
+
+// message is a Matrix message, the plain body is shown by the clients that don't render HTML.
+type message struct {
+	body string
+	html string
+}
+
+// builder writes the plain body and the HTML body of a message side by side.
+type builder struct {
+	body strings.Builder
+	html strings.Builder
+}
+
+func (b *builder) text(s string) {
+	b.body.WriteString(s)
+	b.html.WriteString(strings.ReplaceAll(html.EscapeString(s), "\n", "<br>"))
+}
+
+func (b *builder) tagged(tag, s string) {
+	b.body.WriteString(s)
+	b.html.WriteString("<" + tag + ">" + strings.ReplaceAll(html.EscapeString(s), "\n", "<br>") + "</" + tag + ">")
+}
+
+func (b *builder) pre(s string) {
+	b.body.WriteString(s)
+	b.html.WriteString("<pre><code>" + html.EscapeString(s) + "</code></pre>")
+}
+
+func (b *builder) message() message {
+	return message{body: b.body.String(), html: b.html.String()}
+}
+
+// resultMessage renders the result of a command as a Matrix message.
+func resultMessage(res *engine.CommandResult, messages *engine.MessageCatalog) message {
+	var title string
+	switch {
+	case res.Maintenance:
+		title = "🔧 " + messages.Get(engine.MsgTitleMaintenance)
+	case res.Successful:
+		title = messages.Get(engine.MsgTitleSuccessful)
+	default:
+		title = messages.Get(engine.MsgTitleFailed)
+	}
+
+	if res.Title != "" && !res.Maintenance {
+		title = res.Title
+	}
+
+	b := &builder{}
+	b.tagged("strong", title)
+	b.text("\n\n")
+
+	for _, w := range res.Warnings {
+		b.text("⚠️ " + w + "\n")
+	}
+	if len(res.Warnings) > 0 {
+		b.text("\n")
+	}
+
+	b.text(res.Message)
+
+	if res.Table != nil {
+		b.text("\n\n")
+		b.pre(res.Table.String())
+	}
+
+	if len(res.Fields) > 0 {
+		b.text("\n")
+	}
+	for _, f := range res.Fields {
+		b.text("\n")
+		b.tagged("strong", f.Name)
+		b.text(": " + f.Value)
+	}
+
+	footer := []string{}
+	if res.List != nil {
+		footer = append(footer, res.List.Footer())
+	}
+	if len(res.Suggestions) > 0 {
+		cmds := make([]string, 0, len(res.Suggestions))
+		for _, s := range res.Suggestions {
+			cmds = append(cmds, commandPrefix+" "+s)
+		}
+		footer = append(footer, "Try next: "+strings.Join(cmds, ", "))
+	}
+	if len(footer) > 0 {
+		b.text("\n\n")
+		b.tagged("em", strings.Join(footer, " • "))
+	}
+
+	return b.message()
+}
+
+func errorMessage(messages *engine.MessageCatalog, errStr string) message {
+	if errStr == "" {
+		errStr = messages.Get(engine.MsgErrorFallback)
+	}
+
+	b := &builder{}
+	b.tagged("strong", messages.Get(engine.MsgTitleError))
+	b.text("\n\n" + errStr)
+
+	return b.message()
+}
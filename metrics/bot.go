@@ -0,0 +1,63 @@
+package metrics
+
+const (
+	ResultSuccess = "success"
+	ResultFailed  = "failed"
+	ResultError   = "error"
+	ResultSkipped = "skipped"
+	ResultPanic   = "panic"
+)
+
+// The metrics of the bot, they are registered in the Default registry.
+var (
+	// CommandsTotal counts the executed commands by the app, the command name and the result.
+	CommandsTotal = NewCounterVec("robopac_commands_total",
+		"Number of the executed commands.", "app", "command", "result")
+
+	// EngineErrorsTotal counts the errors returned by the command handlers.
+	EngineErrorsTotal = NewCounterVec("robopac_engine_errors_total",
+		"Number of the errors returned by the command handlers.", "command")
+
+	// GRPCCallDuration observes the latencies of the gRPC calls to the nodes.
+	GRPCCallDuration = NewHistogramVec("robopac_grpc_call_duration_seconds",
+		"Latency of the gRPC calls to the nodes.", DefaultBuckets, "method", "code")
+
+	// DiscordInteractionFailuresTotal counts the failed responses to the Discord interactions.
+	DiscordInteractionFailuresTotal = NewCounterVec("robopac_discord_interaction_failures_total",
+		"Number of the failed responses to the Discord interactions.", "kind")
+
+	// DiscordConnectionEventsTotal counts the events of the Discord gateway connection, like the disconnects,
+	// the resumed sessions, the new sessions and the commands registered again.
+	DiscordConnectionEventsTotal = NewCounterVec("robopac_discord_connection_events_total",
+		"Number of the events of the Discord gateway connection.", "event")
+
+	// SchedulerJobRunsTotal counts the runs of the scheduled jobs by the job name and the result.
+	SchedulerJobRunsTotal = NewCounterVec("robopac_scheduler_job_runs_total",
+		"Number of the runs of the scheduled jobs.", "job", "result")
+
+	// SchedulerJobDuration observes the durations of the scheduled jobs.
+	SchedulerJobDuration = NewHistogramVec("robopac_scheduler_job_duration_seconds",
+		"Duration of the scheduled jobs.", DefaultBuckets, "job")
+
+	Default = NewRegistry(
+		CommandsTotal,
+		EngineErrorsTotal,
+		GRPCCallDuration,
+		DiscordInteractionFailuresTotal,
+		DiscordConnectionEventsTotal,
+		SchedulerJobRunsTotal,
+		SchedulerJobDuration,
+	)
+)
+
+// CommandResult returns the result label of a command execution.
+func CommandResult(successful bool, err error) string {
+	switch {
+	case err != nil:
+		return ResultError
+	case successful:
+		return ResultSuccess
+	default:
+		return ResultFailed
+	}
+}
@@ -0,0 +1,272 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds of the histogram buckets in seconds, suitable for the RPC latencies.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Collector writes its metrics in the Prometheus text format.
+type Collector interface {
+	Collect(w io.Writer) error
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// labelKey joins the label values to key the samples, the separator can't appear in the valid UTF-8 strings.
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+func checkLabels(name string, labels, labelValues []string) {
+	if len(labels) != len(labelValues) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", name, len(labels), len(labelValues)))
+	}
+}
+
+// formatLabels formats the labels like `{app="Discord",command="help"}`, the extra pair is appended if it's set.
+func formatLabels(labels, labelValues []string, extra ...string) string {
+	pairs := make([]string, 0, len(labels)+1)
+	for i, label := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", label, labelEscaper.Replace(labelValues[i])))
+	}
+	if len(extra) == 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extra[0], labelEscaper.Replace(extra[1])))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type counterSample struct {
+	labelValues []string
+	value       float64
+}
+
+// CounterVec is a set of counters, partitioned by the label values.
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+
+	lk      sync.Mutex
+	samples map[string]*counterSample
+}
+
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		samples: make(map[string]*counterSample),
+	}
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds v to the counter of the label values. It panics if the number of the label values is wrong.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	checkLabels(c.name, c.labels, labelValues)
+
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	key := labelKey(labelValues)
+	sample, ok := c.samples[key]
+	if !ok {
+		sample = &counterSample{labelValues: slices.Clone(labelValues)}
+		c.samples[key] = sample
+	}
+	sample.value += v
+}
+
+// Value returns the current value of the counter of the label values.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	sample, ok := c.samples[labelKey(labelValues)]
+	if !ok {
+		return 0
+	}
+
+	return sample.value
+}
+
+func (c *CounterVec) Collect(w io.Writer) error {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name); err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(c.samples) {
+		sample := c.samples[key]
+		_, err := fmt.Fprintf(w, "%s%s %s\n",
+			c.name, formatLabels(c.labels, sample.labelValues), formatFloat(sample.value))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GaugeFunc is a gauge that is read by its function when it's collected, like the state of the connections.
+type GaugeFunc struct {
+	name string
+	help string
+	fn   func() float64
+}
+
+func NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	return &GaugeFunc{
+		name: name,
+		help: help,
+		fn:   fn,
+	}
+}
+
+func (g *GaugeFunc) Collect(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n",
+		g.name, g.help, g.name, g.name, formatFloat(g.fn()))
+
+	return err
+}
+
+type histogramSample struct {
+	labelValues []string
+	counts      []uint64
+	sum         float64
+	count       uint64
+}
+
+// HistogramVec is a set of histograms, partitioned by the label values.
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	lk      sync.Mutex
+	samples map[string]*histogramSample
+}
+
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+
+	return &HistogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: buckets,
+		samples: make(map[string]*histogramSample),
+	}
+}
+
+// Observe adds v to the histogram of the label values. It panics if the number of the label values is wrong.
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	checkLabels(h.name, h.labels, labelValues)
+
+	h.lk.Lock()
+	defer h.lk.Unlock()
+
+	key := labelKey(labelValues)
+	sample, ok := h.samples[key]
+	if !ok {
+		sample = &histogramSample{
+			labelValues: slices.Clone(labelValues),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.samples[key] = sample
+	}
+
+	for i, bound := range h.buckets {
+		if v <= bound {
+			sample.counts[i]++
+
+			break
+		}
+	}
+	sample.sum += v
+	sample.count++
+}
+
+// Count returns the number of the observations of the label values.
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.lk.Lock()
+	defer h.lk.Unlock()
+
+	sample, ok := h.samples[labelKey(labelValues)]
+	if !ok {
+		return 0
+	}
+
+	return sample.count
+}
+
+func (h *HistogramVec) Collect(w io.Writer) error {
+	h.lk.Lock()
+	defer h.lk.Unlock()
+
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name); err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(h.samples) {
+		sample := h.samples[key]
+
+		// the buckets of the Prometheus histograms are cumulative.
+		cumulative := uint64(0)
+		for i, bound := range h.buckets {
+			cumulative += sample.counts[i]
+			_, err := fmt.Fprintf(w, "%s_bucket%s %d\n",
+				h.name, formatLabels(h.labels, sample.labelValues, "le", formatFloat(bound)), cumulative)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			h.name, formatLabels(h.labels, sample.labelValues, "le", "+Inf"), sample.count,
+			h.name, formatLabels(h.labels, sample.labelValues), formatFloat(sample.sum),
+			h.name, formatLabels(h.labels, sample.labelValues), sample.count)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
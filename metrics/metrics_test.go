@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterVec(t *testing.T) {
+	c := NewCounterVec("test_total", "Test counter.", "app", "command")
+	c.Inc("Discord", "help")
+	c.Inc("Discord", "help")
+	c.Add(3, "CLI", "node-info")
+
+	assert.Equal(t, float64(2), c.Value("Discord", "help"))
+	assert.Equal(t, float64(0), c.Value("Telegram", "help"))
+	assert.Panics(t, func() { c.Inc("Discord") })
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.Collect(buf))
+	assert.Equal(t, "# HELP test_total Test counter.\n"+
+		"# TYPE test_total counter\n"+
+		"test_total{app=\"CLI\",command=\"node-info\"} 3\n"+
+		"test_total{app=\"Discord\",command=\"help\"} 2\n", buf.String())
+}
+
+func TestCounterVecEscaping(t *testing.T) {
+	c := NewCounterVec("test_total", "Test counter.", "name")
+	c.Inc("a\"b\\c\nd")
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.Collect(buf))
+	assert.Contains(t, buf.String(), `test_total{name="a\"b\\c\nd"} 1`)
+}
+
+func TestGaugeFunc(t *testing.T) {
+	value := 2.0
+	g := NewGaugeFunc("test_gauge", "Test gauge.", func() float64 { return value })
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, g.Collect(buf))
+	assert.Equal(t, "# HELP test_gauge Test gauge.\n# TYPE test_gauge gauge\ntest_gauge 2\n", buf.String())
+
+	value = 5
+	buf.Reset()
+	require.NoError(t, g.Collect(buf))
+	assert.Contains(t, buf.String(), "test_gauge 5\n")
+}
+
+func TestHistogramVec(t *testing.T) {
+	h := NewHistogramVec("test_seconds", "Test histogram.", []float64{1, 0.1}, "method")
+	h.Observe(0.05, "get")
+	h.Observe(0.5, "get")
+	h.Observe(5, "get")
+
+	assert.Equal(t, uint64(3), h.Count("get"))
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, h.Collect(buf))
+	assert.Equal(t, "# HELP test_seconds Test histogram.\n"+
+		"# TYPE test_seconds histogram\n"+
+		"test_seconds_bucket{method=\"get\",le=\"0.1\"} 1\n"+
+		"test_seconds_bucket{method=\"get\",le=\"1\"} 2\n"+
+		"test_seconds_bucket{method=\"get\",le=\"+Inf\"} 3\n"+
+		"test_seconds_sum{method=\"get\"} 5.55\n"+
+		"test_seconds_count{method=\"get\"} 3\n", buf.String())
+}
+
+func TestCommandResult(t *testing.T) {
+	assert.Equal(t, ResultSuccess, CommandResult(true, nil))
+	assert.Equal(t, ResultFailed, CommandResult(false, nil))
+	assert.Equal(t, ResultError, CommandResult(false, errors.New("error")))
+}
+
+func TestHandler(t *testing.T) {
+	c := NewCounterVec("test_total", "Test counter.")
+	c.Inc()
+	reg := NewRegistry(c)
+
+	srv := httptest.NewServer(reg.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+	assert.Contains(t, string(body), "test_total 1\n")
+}
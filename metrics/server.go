@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+)
+
+// Registry holds the collectors that are exposed together.
+type Registry struct {
+	lk         sync.RWMutex
+	collectors []Collector
+}
+
+func NewRegistry(collectors ...Collector) *Registry {
+	return &Registry{
+		collectors: collectors,
+	}
+}
+
+func (r *Registry) Register(collectors ...Collector) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	r.collectors = append(r.collectors, collectors...)
+}
+
+// Write writes all the metrics of the registry in the Prometheus text format.
+func (r *Registry) Write(w io.Writer) error {
+	r.lk.RLock()
+	defer r.lk.RUnlock()
+
+	for _, c := range r.collectors {
+		if err := c.Collect(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Handler returns the HTTP handler of the metrics, to be scraped by Prometheus.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		buf := &bytes.Buffer{}
+		if err := r.Write(buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+// Server serves the metrics of the registry on the /metrics endpoint.
+type Server struct {
+	srv *http.Server
+}
+
+func NewServer(addr string, reg *Registry) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", reg.Handler())
+
+	return &Server{
+		srv: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Start listens on the address and serves the metrics in the background.
+func (s *Server) Start(_ context.Context) error {
+	listener, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return err
+	}
+
+	log.Info("metrics server started", "addr", listener.Addr().String())
+
+	go func() {
+		if err := s.srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("metrics server stopped", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.srv.Shutdown(ctx); err != nil {
+		log.Error("unable to shut the metrics server down", "err", err)
+	}
+}
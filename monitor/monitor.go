@@ -0,0 +1,281 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/store"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// DefaultMaxHistory keeps a week of the snapshots with the default interval of 10 minutes.
+const DefaultMaxHistory = 7 * 24 * 6
+
+// Snapshot is the state of a validator at a point in time.
+type Snapshot struct {
+	Time                int64   `json:"time"`
+	Height              uint32  `json:"height"`
+	AvailabilityScore   float64 `json:"availability_score"`
+	LastSortitionHeight uint32  `json:"last_sortition_height"`
+	Unbonded            bool    `json:"unbonded"`
+}
+
+// Uptime is the current state of a validator and the summary of its recorded history.
+// Without the history, the scores are the current score.
+type Uptime struct {
+	Address  string
+	Current  Snapshot
+	Samples  int
+	Since    time.Time
+	AvgScore float64
+	MinScore float64
+}
+
+// Alert is raised for a subscriber when their validator goes down.
+type Alert struct {
+	UserID  string
+	Address string
+	Reason  string
+}
+
+type validatorSource interface {
+	GetValidatorInfo(address string) (*pactus.GetValidatorResponse, error)
+	GetBlockchainHeight() (uint32, error)
+}
+
+// Monitor snapshots the validators that users subscribed to, keeps their history
+// and alerts the subscribers when the availability of their validator drops.
+type Monitor struct {
+	lk sync.Mutex
+
+	source     validatorSource
+	history    store.KV
+	subs       store.KV
+	threshold  float64
+	maxHistory int
+	onAlert    func(Alert)
+	nowFunc    func() time.Time
+}
+
+// NewMonitor creates a monitor that keeps the history and the subscriptions in the given buckets.
+// The subscribers are alerted when the availability score falls below the threshold.
+func NewMonitor(source validatorSource, history, subs store.KV, threshold float64) *Monitor {
+	return &Monitor{
+		source:     source,
+		history:    history,
+		subs:       subs,
+		threshold:  threshold,
+		maxHistory: DefaultMaxHistory,
+		onAlert:    func(Alert) {},
+		nowFunc:    time.Now,
+	}
+}
+
+// SetAlertHandler sets the function that delivers the alerts, like the Discord DMs.
+func (m *Monitor) SetAlertHandler(fn func(Alert)) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	m.onAlert = fn
+}
+
+// Subscribe subscribes the user to the alerts of the validator, replacing their previous subscription.
+func (m *Monitor) Subscribe(userID, address string) error {
+	data, err := json.Marshal(address)
+	if err != nil {
+		return err
+	}
+
+	return m.subs.Set(userID, data)
+}
+
+// Unsubscribe removes the subscription of the user and returns the validator address they were subscribed to.
+func (m *Monitor) Unsubscribe(userID string) (string, error) {
+	address := m.Subscription(userID)
+	if address == "" {
+		return "", nil
+	}
+
+	return address, m.subs.Delete(userID)
+}
+
+// Subscription returns the validator address that the user is subscribed to, or an empty string.
+func (m *Monitor) Subscription(userID string) string {
+	data, err := m.subs.Get(userID)
+	if err != nil {
+		return ""
+	}
+
+	var address string
+	if err := json.Unmarshal(data, &address); err != nil {
+		return ""
+	}
+
+	return address
+}
+
+// subscribers returns the subscribers of each subscribed validator.
+func (m *Monitor) subscribers() map[string][]string {
+	subscribers := map[string][]string{}
+	_ = m.subs.Iterate(func(userID string, value []byte) bool {
+		var address string
+		if err := json.Unmarshal(value, &address); err == nil {
+			subscribers[address] = append(subscribers[address], userID)
+		}
+
+		return true
+	})
+
+	return subscribers
+}
+
+// Snapshot records the state of the subscribed validators and raises the alerts.
+// It's called periodically by the engine scheduler.
+func (m *Monitor) Snapshot() {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	height, err := m.source.GetBlockchainHeight()
+	if err != nil {
+		log.Warn("unable to snapshot the validators", "err", err)
+
+		return
+	}
+
+	for address, userIDs := range m.subscribers() {
+		current, err := m.snapshot(address, height)
+		if err != nil {
+			log.Warn("unable to snapshot the validator", "err", err, "address", address)
+
+			continue
+		}
+
+		history, err := m.History(address)
+		if err != nil {
+			log.Warn("unable to load the validator history", "err", err, "address", address)
+
+			continue
+		}
+
+		if len(history) > 0 {
+			if reason := m.alertReason(history[len(history)-1], current); reason != "" {
+				for _, userID := range userIDs {
+					m.onAlert(Alert{UserID: userID, Address: address, Reason: reason})
+				}
+			}
+		}
+
+		history = append(history, current)
+		if len(history) > m.maxHistory {
+			history = slices.Clone(history[len(history)-m.maxHistory:])
+		}
+
+		data, err := json.Marshal(history)
+		if err != nil {
+			continue
+		}
+
+		if err := m.history.Set(address, data); err != nil {
+			log.Error("unable to save the validator history", "err", err, "address", address)
+		}
+	}
+}
+
+// alertReason returns why the validator needs attention, only the changes are alerted, not the states.
+func (m *Monitor) alertReason(prev, current Snapshot) string {
+	switch {
+	case current.Unbonded && !prev.Unbonded:
+		return "the validator is unbonded"
+
+	case current.AvailabilityScore < m.threshold && prev.AvailabilityScore >= m.threshold:
+		return fmt.Sprintf("the availability score dropped to %.2f", current.AvailabilityScore)
+
+	default:
+		return ""
+	}
+}
+
+func (m *Monitor) snapshot(address string, height uint32) (Snapshot, error) {
+	res, err := m.source.GetValidatorInfo(address)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	val := res.GetValidator()
+	if val == nil {
+		return Snapshot{}, errors.New("validator not found")
+	}
+
+	return Snapshot{
+		Time:                m.nowFunc().Unix(),
+		Height:              height,
+		AvailabilityScore:   val.AvailabilityScore,
+		LastSortitionHeight: val.LastSortitionHeight,
+		Unbonded:            val.UnbondingHeight > 0,
+	}, nil
+}
+
+// History returns the recorded snapshots of the validator, the oldest first.
+func (m *Monitor) History(address string) ([]Snapshot, error) {
+	data, err := m.history.Get(address)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return []Snapshot{}, nil
+		}
+
+		return nil, err
+	}
+
+	history := []Snapshot{}
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// Uptime returns the current state of the validator and the summary of its history.
+// Only the subscribed validators have a history.
+func (m *Monitor) Uptime(address string) (*Uptime, error) {
+	height, err := m.source.GetBlockchainHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := m.snapshot(address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := m.History(address)
+	if err != nil {
+		return nil, err
+	}
+
+	uptime := &Uptime{
+		Address:  address,
+		Current:  current,
+		Samples:  len(history),
+		AvgScore: current.AvailabilityScore,
+		MinScore: current.AvailabilityScore,
+	}
+
+	if len(history) > 0 {
+		uptime.Since = time.Unix(history[0].Time, 0)
+
+		sum := 0.0
+		uptime.MinScore = history[0].AvailabilityScore
+		for _, s := range history {
+			sum += s.AvailabilityScore
+			uptime.MinScore = min(uptime.MinScore, s.AvailabilityScore)
+		}
+		uptime.AvgScore = sum / float64(len(history))
+	}
+
+	return uptime, nil
+}
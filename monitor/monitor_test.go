@@ -0,0 +1,168 @@
+package monitor
+
+import (
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/store"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	height     uint32
+	validators map[string]*pactus.ValidatorInfo
+}
+
+func (s *fakeSource) GetValidatorInfo(address string) (*pactus.GetValidatorResponse, error) {
+	val, ok := s.validators[address]
+	if !ok {
+		return nil, errors.New("validator not found")
+	}
+
+	return &pactus.GetValidatorResponse{Validator: val}, nil
+}
+
+func (s *fakeSource) GetBlockchainHeight() (uint32, error) {
+	return s.height, nil
+}
+
+func setup(t *testing.T) (*Monitor, *fakeSource, *[]Alert) {
+	t.Helper()
+
+	history, err := store.NewJSONKV(path.Join(t.TempDir(), "history.json"))
+	require.NoError(t, err)
+	subs, err := store.NewJSONKV(path.Join(t.TempDir(), "subs.json"))
+	require.NoError(t, err)
+
+	source := &fakeSource{
+		height: 1000,
+		validators: map[string]*pactus.ValidatorInfo{
+			"val-a": {Address: "val-a", AvailabilityScore: 1, LastSortitionHeight: 990},
+		},
+	}
+
+	now := time.Unix(1700000000, 0)
+	m := NewMonitor(source, history, subs, 0.9)
+	m.nowFunc = func() time.Time {
+		now = now.Add(10 * time.Minute)
+
+		return now
+	}
+
+	alerts := []Alert{}
+	m.SetAlertHandler(func(a Alert) {
+		alerts = append(alerts, a)
+	})
+
+	return m, source, &alerts
+}
+
+func TestSubscriptions(t *testing.T) {
+	m, _, _ := setup(t)
+
+	assert.Empty(t, m.Subscription("alice"))
+
+	require.NoError(t, m.Subscribe("alice", "val-a"))
+	assert.Equal(t, "val-a", m.Subscription("alice"))
+
+	require.NoError(t, m.Subscribe("alice", "val-b"))
+	assert.Equal(t, "val-b", m.Subscription("alice"))
+
+	address, err := m.Unsubscribe("alice")
+	require.NoError(t, err)
+	assert.Equal(t, "val-b", address)
+	assert.Empty(t, m.Subscription("alice"))
+
+	address, err = m.Unsubscribe("alice")
+	require.NoError(t, err)
+	assert.Empty(t, address)
+}
+
+func TestSnapshot(t *testing.T) {
+	m, source, alerts := setup(t)
+	require.NoError(t, m.Subscribe("alice", "val-a"))
+	require.NoError(t, m.Subscribe("bob", "val-a"))
+
+	t.Run("unsubscribed validators are not recorded", func(t *testing.T) {
+		history, err := m.History("val-b")
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+
+	t.Run("first snapshot", func(t *testing.T) {
+		m.Snapshot()
+
+		history, err := m.History("val-a")
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, uint32(1000), history[0].Height)
+		assert.Empty(t, *alerts)
+	})
+
+	t.Run("availability dropped", func(t *testing.T) {
+		source.validators["val-a"].AvailabilityScore = 0.5
+		m.Snapshot()
+
+		require.Len(t, *alerts, 2)
+		assert.ElementsMatch(t, []string{"alice", "bob"}, []string{(*alerts)[0].UserID, (*alerts)[1].UserID})
+		assert.Contains(t, (*alerts)[0].Reason, "0.50")
+	})
+
+	t.Run("still down, no new alert", func(t *testing.T) {
+		*alerts = []Alert{}
+		m.Snapshot()
+		assert.Empty(t, *alerts)
+	})
+
+	t.Run("unbonded", func(t *testing.T) {
+		source.validators["val-a"].UnbondingHeight = 1000
+		m.Snapshot()
+
+		require.Len(t, *alerts, 2)
+		assert.Equal(t, "the validator is unbonded", (*alerts)[0].Reason)
+	})
+
+	t.Run("history is capped", func(t *testing.T) {
+		m.maxHistory = 3
+		m.Snapshot()
+
+		history, err := m.History("val-a")
+		require.NoError(t, err)
+		assert.Len(t, history, 3)
+	})
+}
+
+func TestUptime(t *testing.T) {
+	m, source, _ := setup(t)
+
+	t.Run("without history", func(t *testing.T) {
+		uptime, err := m.Uptime("val-a")
+		require.NoError(t, err)
+		assert.Equal(t, 0, uptime.Samples)
+		assert.Equal(t, float64(1), uptime.AvgScore)
+		assert.Equal(t, uint32(990), uptime.Current.LastSortitionHeight)
+	})
+
+	t.Run("with history", func(t *testing.T) {
+		require.NoError(t, m.Subscribe("alice", "val-a"))
+		m.Snapshot()
+		source.validators["val-a"].AvailabilityScore = 0.5
+		m.Snapshot()
+
+		uptime, err := m.Uptime("val-a")
+		require.NoError(t, err)
+		assert.Equal(t, 2, uptime.Samples)
+		assert.Equal(t, 0.75, uptime.AvgScore)
+		assert.Equal(t, 0.5, uptime.MinScore)
+		assert.False(t, uptime.Since.IsZero())
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		_, err := m.Uptime("val-unknown")
+		assert.Error(t, err)
+	})
+}
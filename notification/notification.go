@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The kinds of the events that the operators are notified of.
+const (
+	KindNodeFailover    = "node-failover"
+	KindLowBalance      = "low-balance"
+	KindPayoutCompleted = "payout-completed"
+)
+
+// Field is a named value of the event, like the endpoint of the node.
+type Field struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Event is an internal event of the bot that the operators should know about.
+type Event struct {
+	Kind    string    `json:"kind"`
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	Fields  []Field   `json:"fields,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// NewEvent creates the event of the kind, at the current time.
+func NewEvent(kind, title, format string, args ...any) Event {
+	return Event{
+		Kind:    kind,
+		Title:   title,
+		Message: fmt.Sprintf(format, args...),
+		Time:    time.Now().UTC(),
+	}
+}
+
+// AddField adds a named value to the event.
+func (e Event) AddField(name, value string) Event {
+	e.Fields = append(e.Fields, Field{Name: name, Value: value})
+
+	return e
+}
+
+// Sink delivers the events to the operators, outside of the bot's platforms.
+type Sink interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// Sinks delivers the events to all the sinks, the failures of some don't stop the others.
+type Sinks []Sink
+
+func (s Sinks) Notify(ctx context.Context, event Event) error {
+	errs := make([]error, 0)
+	for _, sink := range s {
+		if err := sink.Notify(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,115 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupServer records the bodies of the posts, and responds by the status.
+func setupServer(t *testing.T, status int) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+
+	bodies := []map[string]any{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		data, _ := io.ReadAll(r.Body)
+		body := map[string]any{}
+		assert.NoError(t, json.Unmarshal(data, &body))
+		bodies = append(bodies, body)
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &bodies
+}
+
+func testEvent() Event {
+	event := NewEvent(KindLowBalance, "Low Balance", "The wallet balance is %d PAC", 10)
+	event.Time = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	return event.AddField("Balance", "10 PAC")
+}
+
+func TestWebhook(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		srv, bodies := setupServer(t, http.StatusOK)
+		w, err := NewWebhook(srv.URL, FormatJSON)
+		require.NoError(t, err)
+
+		require.NoError(t, w.Notify(context.Background(), testEvent()))
+		require.Len(t, *bodies, 1)
+		assert.Equal(t, KindLowBalance, (*bodies)[0]["kind"])
+		assert.Equal(t, "The wallet balance is 10 PAC", (*bodies)[0]["message"])
+		assert.Equal(t, "2024-01-02T03:04:05Z", (*bodies)[0]["time"])
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		srv, bodies := setupServer(t, http.StatusOK)
+		w, err := NewWebhook(srv.URL, FormatSlack)
+		require.NoError(t, err)
+
+		require.NoError(t, w.Notify(context.Background(), testEvent()))
+		assert.Equal(t, "*Low Balance*\nThe wallet balance is 10 PAC\nBalance: 10 PAC", (*bodies)[0]["text"])
+	})
+
+	t.Run("discord", func(t *testing.T) {
+		srv, bodies := setupServer(t, http.StatusNoContent)
+		w, err := NewWebhook(srv.URL, FormatDiscord)
+		require.NoError(t, err)
+
+		require.NoError(t, w.Notify(context.Background(), testEvent()))
+		embeds := (*bodies)[0]["embeds"].([]any)
+		require.Len(t, embeds, 1)
+		embed := embeds[0].(map[string]any)
+		assert.Equal(t, "Low Balance", embed["title"])
+		assert.Len(t, embed["fields"], 1)
+	})
+
+	t.Run("failed response", func(t *testing.T) {
+		srv, _ := setupServer(t, http.StatusBadRequest)
+		w, err := NewWebhook(srv.URL, FormatJSON)
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, w.Notify(context.Background(), testEvent()), "400")
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		_, err := NewWebhook("https://example.com", "teams")
+		assert.Error(t, err)
+
+		_, err = NewWebhook("example.com", FormatJSON)
+		assert.Error(t, err)
+	})
+}
+
+type testSink struct {
+	events []Event
+	err    error
+}
+
+func (s *testSink) Notify(_ context.Context, event Event) error {
+	s.events = append(s.events, event)
+
+	return s.err
+}
+
+func TestSinks(t *testing.T) {
+	failing := &testSink{err: errors.New("down")}
+	working := &testSink{}
+
+	err := Sinks{failing, working}.Notify(context.Background(), testEvent())
+	assert.ErrorContains(t, err, "down")
+	assert.Len(t, working.events, 1, "the failures don't stop the other sinks")
+}
@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The payload formats of the webhooks.
+const (
+	FormatJSON    = "json"
+	FormatSlack   = "slack"
+	FormatDiscord = "discord"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Webhook posts the events to the URL, in the payload format of the service.
+type Webhook struct {
+	url    string
+	format string
+	client *http.Client
+}
+
+// NewWebhook creates the webhook sink, the format is json, slack or discord.
+func NewWebhook(url, format string) (*Webhook, error) {
+	switch format {
+	case FormatJSON, FormatSlack, FormatDiscord:
+	default:
+		return nil, fmt.Errorf("unknown webhook format: %s", format)
+	}
+
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return nil, fmt.Errorf("invalid webhook URL: %s", url)
+	}
+
+	return &Webhook{
+		url:    url,
+		format: format,
+		client: &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+func (w *Webhook) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(w.payload(event))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to post the %s webhook: %w", w.format, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the %s webhook responded %s", w.format, resp.Status)
+	}
+
+	return nil
+}
+
+// payload returns the body of the webhook, the generic JSON payload is the event itself.
+func (w *Webhook) payload(event Event) any {
+	switch w.format {
+	case FormatSlack:
+		return map[string]any{"text": plainText(event)}
+
+	case FormatDiscord:
+		fields := make([]map[string]any, 0, len(event.Fields))
+		for _, f := range event.Fields {
+			fields = append(fields, map[string]any{"name": f.Name, "value": f.Value, "inline": true})
+		}
+
+		return map[string]any{
+			"embeds": []map[string]any{{
+				"title":       event.Title,
+				"description": event.Message,
+				"fields":      fields,
+				"timestamp":   event.Time.Format(time.RFC3339),
+			}},
+		}
+
+	default:
+		return event
+	}
+}
+
+// plainText formats the event as text, like "*Low Balance*\nThe balance ...\nBalance: 10 PAC".
+func plainText(event Event) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%s*\n%s", event.Title, event.Message)
+	for _, f := range event.Fields {
+		fmt.Fprintf(&sb, "\n%s: %s", f.Name, f.Value)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,169 @@
+package payout
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pactus-project/pactus/crypto"
+)
+
+// MaxEntries caps the transfers of one payout, to keep it reviewable.
+const MaxEntries = 1000
+
+// Entry is one transfer of the payout, the amount is in NanoPAC.
+type Entry struct {
+	Address string `json:"address"`
+	Amount  int64  `json:"amount"`
+}
+
+// ParseEntries parses the payout list. The list is a JSON array of {"address", "amount"} objects,
+// a JSON object of address to amount, or CSV lines of address and amount with an optional header.
+// The amounts are in PAC. The entries are validated, see ValidateEntries.
+func ParseEntries(data []byte) ([]Entry, error) {
+	data = bytes.TrimSpace(data)
+
+	var entries []Entry
+	var err error
+	switch {
+	case len(data) == 0:
+		return nil, errors.New("payout list is empty")
+	case data[0] == '[':
+		entries, err = parseJSONArray(data)
+	case data[0] == '{':
+		entries, err = parseJSONObject(data)
+	default:
+		entries, err = parseCSV(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, ValidateEntries(entries)
+}
+
+func parseJSONArray(data []byte) ([]Entry, error) {
+	items := []struct {
+		Address string      `json:"address"`
+		Amount  json.Number `json:"amount"`
+	}{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(items))
+	for i, item := range items {
+		amount, err := parseAmount(item.Amount.String())
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		entries = append(entries, Entry{Address: item.Address, Amount: amount})
+	}
+
+	return entries, nil
+}
+
+func parseJSONObject(data []byte) ([]Entry, error) {
+	// the object is decoded token by token, to keep the order of the entries.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	entries := []Entry{}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		var value json.Number
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("invalid JSON amount of %v: %w", key, err)
+		}
+
+		address, _ := key.(string)
+		amount, err := parseAmount(value.String())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", address, err)
+		}
+		entries = append(entries, Entry{Address: address, Amount: amount})
+	}
+
+	return entries, nil
+}
+
+func parseCSV(data []byte) ([]Entry, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	entries := []Entry{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		amount, err := parseAmount(record[1])
+		if err != nil {
+			// the first line can be the header, like "address,amount".
+			if line == 1 {
+				continue
+			}
+
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, Entry{Address: strings.TrimSpace(record[0]), Amount: amount})
+	}
+
+	return entries, nil
+}
+
+// parseAmount parses the amount in PAC, it's rounded to NanoPAC, unlike util.StringToChange
+// that truncates the amounts like 0.3 to 0.299999999.
+func parseAmount(raw string) (int64, error) {
+	coin, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil || math.IsNaN(coin) || math.IsInf(coin, 0) {
+		return 0, fmt.Errorf("invalid amount: %q", raw)
+	}
+
+	return int64(math.Round(coin * 1e9)), nil
+}
+
+// ValidateEntries checks the addresses and the amounts of the entries, the addresses must be unique,
+// so the transfers of one payout have different IDs.
+func ValidateEntries(entries []Entry) error {
+	if len(entries) == 0 {
+		return errors.New("payout list is empty")
+	}
+	if len(entries) > MaxEntries {
+		return fmt.Errorf("payout list has %d entries, the maximum is %d", len(entries), MaxEntries)
+	}
+
+	seen := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		if _, err := crypto.AddressFromString(entry.Address); err != nil {
+			return fmt.Errorf("entry %d: invalid address: %q", i+1, entry.Address)
+		}
+		if entry.Amount <= 0 {
+			return fmt.Errorf("entry %d: the amount of %s should be positive", i+1, entry.Address)
+		}
+		if seen[entry.Address] {
+			return fmt.Errorf("entry %d: duplicated address: %s", i+1, entry.Address)
+		}
+		seen[entry.Address] = true
+	}
+
+	return nil
+}
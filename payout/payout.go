@@ -0,0 +1,248 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+	"github.com/pactus-project/pactus/types/tx/payload"
+	"github.com/pactus-project/pactus/util"
+)
+
+const (
+	DefaultBatchSize = 10
+	// DefaultBatchInterval is about the block time, so the batches are spread over the blocks.
+	DefaultBatchInterval = 10 * time.Second
+
+	defaultMaxAttempts  = 3
+	defaultRetryBackoff = 2 * time.Second
+)
+
+// Wallet sends the transfers of the payouts and signs their receipts, like the bot wallet.
+type Wallet interface {
+	Balance() int64
+	LockTime() (uint32, error)
+	TransferTransactionAt(lockTime uint32, toAddress string, amount int64, memo string) (string, error)
+	SignMessage(msg []byte) (string, string, error)
+}
+
+type feeCalculator interface {
+	CalculateFee(amount int64, payloadType payload.Type) (int64, error)
+}
+
+// Plan is a validated payout that is ready to be sent. The amounts are in NanoPAC.
+type Plan struct {
+	ID      string
+	Entries []Entry
+	Total   int64
+	Fees    int64
+}
+
+// Memo is the memo of the transfers of the plan.
+func (plan *Plan) Memo() string {
+	return "RoboPac payout " + plan.ID
+}
+
+// Progress is the state of a running payout, it's reported after each batch.
+type Progress struct {
+	PlanID  string
+	Total   int
+	Sent    int
+	Failed  int
+	Batches int
+	Done    bool
+	// Err is the reason that the run is stopped before it's done.
+	Err error
+	// Receipt is the path of the receipt file.
+	Receipt string
+	// Batch is the records of the batch that is just sent, it's empty in the final progress.
+	Batch []Record
+}
+
+// Payer sends the payouts in batches through the wallet, and writes their signed receipts.
+type Payer struct {
+	wallet     Wallet
+	fees       feeCalculator
+	receiptDir string
+
+	batchSize     int
+	batchInterval time.Duration
+	maxAttempts   int
+	retryBackoff  time.Duration
+	nowFunc       func() time.Time
+}
+
+// NewPayer creates a payer that writes the receipts in receiptDir.
+func NewPayer(w Wallet, fees feeCalculator, receiptDir string) *Payer {
+	return &Payer{
+		wallet:        w,
+		fees:          fees,
+		receiptDir:    receiptDir,
+		batchSize:     DefaultBatchSize,
+		batchInterval: DefaultBatchInterval,
+		maxAttempts:   defaultMaxAttempts,
+		retryBackoff:  defaultRetryBackoff,
+		nowFunc:       time.Now,
+	}
+}
+
+// Prepare validates the entries and checks that the wallet balance covers the amounts and the fees.
+func (p *Payer) Prepare(id string, entries []Entry) (*Plan, error) {
+	if err := ValidateEntries(entries); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{ID: id, Entries: entries}
+	for _, entry := range entries {
+		fee, err := p.fees.CalculateFee(entry.Amount, payload.TypeTransfer)
+		if err != nil {
+			return nil, err
+		}
+
+		plan.Total += entry.Amount
+		plan.Fees += fee
+	}
+
+	if err := p.checkBalance(plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (p *Payer) checkBalance(plan *Plan) error {
+	if balance := p.wallet.Balance(); balance < plan.Total+plan.Fees {
+		return fmt.Errorf("wallet balance %s PAC is not enough for %s PAC of the payout and %s PAC of the fees",
+			util.ChangeToString(balance), util.ChangeToString(plan.Total), util.ChangeToString(plan.Fees))
+	}
+
+	return nil
+}
+
+// Run sends the transfers of the plan in batches, and reports the progress after each batch and at the end.
+// The transfers of a batch share the lock time, so a failed transfer is retried with the same ID,
+// and the node rejects it if the previous attempt is committed. The receipt is signed when the run ends,
+// even if it's canceled by the context.
+func (p *Payer) Run(ctx context.Context, plan *Plan, onProgress func(Progress)) (Progress, error) {
+	progress := Progress{
+		PlanID:  plan.ID,
+		Total:   len(plan.Entries),
+		Receipt: filepath.Join(p.receiptDir, plan.ID+".jsonl"),
+	}
+
+	err := p.run(ctx, plan, &progress, onProgress)
+
+	progress.Batch = nil
+	progress.Done = err == nil
+	progress.Err = err
+	onProgress(progress)
+
+	return progress, err
+}
+
+func (p *Payer) run(ctx context.Context, plan *Plan, progress *Progress, onProgress func(Progress)) error {
+	if err := p.checkBalance(plan); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(p.receiptDir, 0o750); err != nil {
+		return err
+	}
+	rcpt, err := createReceipt(progress.Receipt)
+	if err != nil {
+		return err
+	}
+
+	runErr := p.sendBatches(ctx, plan, rcpt, progress, onProgress)
+
+	if err := rcpt.sign(p.wallet); err != nil {
+		log.Error("unable to sign the payout receipt", "err", err, "planID", plan.ID)
+
+		return errors.Join(runErr, err)
+	}
+
+	return runErr
+}
+
+func (p *Payer) sendBatches(ctx context.Context, plan *Plan, rcpt *receipt,
+	progress *Progress, onProgress func(Progress),
+) error {
+	for start := 0; start < len(plan.Entries); start += p.batchSize {
+		if start > 0 && !sleep(ctx, p.batchInterval) {
+			return ctx.Err()
+		}
+
+		lockTime, err := p.wallet.LockTime()
+		if err != nil {
+			return fmt.Errorf("unable to get the lock time: %w", err)
+		}
+
+		progress.Batch = nil
+		for _, entry := range plan.Entries[start:min(start+p.batchSize, len(plan.Entries))] {
+			record := p.send(ctx, lockTime, plan.Memo(), entry)
+			progress.Batch = append(progress.Batch, record)
+			if record.TxID != "" {
+				progress.Sent++
+			} else {
+				progress.Failed++
+			}
+
+			if err := rcpt.add(record); err != nil {
+				return fmt.Errorf("unable to write the receipt: %w", err)
+			}
+		}
+
+		progress.Batches++
+		onProgress(*progress)
+	}
+
+	return nil
+}
+
+// send sends the transfer of the entry, it's retried with the same lock time.
+func (p *Payer) send(ctx context.Context, lockTime uint32, memo string, entry Entry) Record {
+	record := Record{Address: entry.Address, Amount: entry.Amount}
+
+	var err error
+	for record.Attempts < p.maxAttempts {
+		if record.Attempts > 0 && !sleep(ctx, p.retryBackoff*time.Duration(record.Attempts)) {
+			break
+		}
+		record.Attempts++
+
+		record.TxID, err = p.wallet.TransferTransactionAt(lockTime, entry.Address, entry.Amount, memo)
+		if err == nil {
+			break
+		}
+		log.Warn("payout transfer failed", "err", err, "address", entry.Address, "attempt", record.Attempts)
+	}
+
+	if err != nil {
+		record.TxID = ""
+		record.Error = err.Error()
+	}
+	record.Time = p.nowFunc().Unix()
+
+	return record
+}
+
+// sleep waits for the duration, it returns false if the context is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
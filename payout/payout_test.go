@@ -0,0 +1,213 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/types/tx/payload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWallet records the transfers, the failures are the number of the failed attempts of each address.
+type fakeWallet struct {
+	balance   int64
+	lockTime  uint32
+	failures  map[string]int
+	transfers []string
+	key       *bls.PrivateKey
+}
+
+func newFakeWallet(t *testing.T, balance int64) *fakeWallet {
+	t.Helper()
+
+	key, err := bls.KeyGen(make([]byte, 32), nil)
+	require.NoError(t, err)
+
+	return &fakeWallet{balance: balance, lockTime: 100, failures: map[string]int{}, key: key}
+}
+
+func (w *fakeWallet) Balance() int64 {
+	return w.balance
+}
+
+func (w *fakeWallet) LockTime() (uint32, error) {
+	w.lockTime++
+
+	return w.lockTime, nil
+}
+
+func (w *fakeWallet) TransferTransactionAt(lockTime uint32, toAddress string, amount int64, memo string,
+) (string, error) {
+	w.transfers = append(w.transfers, fmt.Sprintf("%d:%s:%d:%s", lockTime, toAddress, amount, memo))
+	if w.failures[toAddress] > 0 {
+		w.failures[toAddress]--
+
+		return "", errors.New("node is down")
+	}
+
+	return "tx-" + toAddress, nil
+}
+
+func (w *fakeWallet) SignMessage(msg []byte) (string, string, error) {
+	return w.key.Sign(msg).String(), w.key.PublicKey().String(), nil
+}
+
+type fixedFee int64
+
+func (f fixedFee) CalculateFee(_ int64, _ payload.Type) (int64, error) {
+	return int64(f), nil
+}
+
+func testAddress(i int) string {
+	return crypto.NewAddress(crypto.AddressTypeBLSAccount, append(make([]byte, 19), byte(i))).String()
+}
+
+func testEntries(count int) []Entry {
+	entries := make([]Entry, 0, count)
+	for i := 1; i <= count; i++ {
+		entries = append(entries, Entry{Address: testAddress(i), Amount: int64(i) * 1e9})
+	}
+
+	return entries
+}
+
+func setupPayer(t *testing.T, w *fakeWallet) *Payer {
+	t.Helper()
+
+	payer := NewPayer(w, fixedFee(1e7), filepath.Join(t.TempDir(), "payouts"))
+	payer.batchSize = 2
+	payer.batchInterval = 0
+	payer.retryBackoff = 0
+
+	return payer
+}
+
+func TestParseEntries(t *testing.T) {
+	addr1, addr2 := testAddress(1), testAddress(2)
+	want := []Entry{{Address: addr1, Amount: 1.5e9}, {Address: addr2, Amount: 3e8}}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"csv", addr1 + ",1.5\n" + addr2 + ",0.3\n"},
+		{"csv with header", "address,amount\n" + addr1 + ", 1.5\n" + addr2 + ",0.3"},
+		{"json array", `[{"address":"` + addr1 + `","amount":1.5},{"address":"` + addr2 + `","amount":"0.3"}]`},
+		{"json object", `{"` + addr1 + `": 1.5, "` + addr2 + `": 0.3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ParseEntries([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, want, entries)
+		})
+	}
+
+	for name, data := range map[string]string{
+		"empty":              " \n",
+		"invalid address":    "pc1invalid,1\n",
+		"invalid amount":     addr1 + ",1\n" + addr2 + ",abc\n",
+		"zero amount":        addr1 + ",0\n",
+		"duplicated address": addr1 + ",1\n" + addr1 + ",2\n",
+		"invalid json":       `[{"address":`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseEntries([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	w := newFakeWallet(t, 10e9)
+	payer := setupPayer(t, w)
+
+	plan, err := payer.Prepare("p1", testEntries(3))
+	require.NoError(t, err)
+	assert.Equal(t, int64(6e9), plan.Total)
+	assert.Equal(t, int64(3e7), plan.Fees)
+
+	w.balance = 6e9
+	_, err = payer.Prepare("p2", testEntries(3))
+	assert.ErrorContains(t, err, "not enough")
+}
+
+func TestRun(t *testing.T) {
+	w := newFakeWallet(t, 100e9)
+	payer := setupPayer(t, w)
+	entries := testEntries(5)
+	// the third address fails once, and the fourth fails in all the attempts.
+	w.failures[entries[2].Address] = 1
+	w.failures[entries[3].Address] = defaultMaxAttempts
+
+	plan, err := payer.Prepare("p1", entries)
+	require.NoError(t, err)
+
+	reports := []Progress{}
+	progress, err := payer.Run(context.Background(), plan, func(p Progress) { reports = append(reports, p) })
+	require.NoError(t, err)
+
+	assert.True(t, progress.Done)
+	assert.Equal(t, 4, progress.Sent)
+	assert.Equal(t, 1, progress.Failed)
+	assert.Equal(t, 3, progress.Batches)
+	require.Len(t, reports, 4, "one report per batch and one at the end")
+	assert.Equal(t, 2, reports[0].Sent)
+	require.Len(t, reports[1].Batch, 2)
+	assert.Equal(t, "node is down", reports[1].Batch[1].Error)
+	assert.Empty(t, reports[3].Batch, "the batches are reported once")
+
+	// the retries of the third address use the lock time of its batch.
+	retried := fmt.Sprintf("102:%s:%d:%s", entries[2].Address, entries[2].Amount, plan.Memo())
+	assert.Equal(t, []string{retried, retried}, w.transfers[2:4])
+
+	data, err := os.ReadFile(progress.Receipt)
+	require.NoError(t, err)
+	records, sig, err := VerifyReceipt(data)
+	require.NoError(t, err)
+	assert.Equal(t, w.key.PublicKey().String(), sig.PublicKey)
+	require.Len(t, records, 5)
+	assert.Equal(t, "tx-"+entries[0].Address, records[0].TxID)
+	assert.Equal(t, 2, records[2].Attempts)
+	assert.Empty(t, records[3].TxID)
+	assert.Equal(t, "node is down", records[3].Error)
+
+	t.Run("tampered receipt", func(t *testing.T) {
+		tampered := []byte(string(data[:len(data)/3]) + "0" + string(data[len(data)/3+1:]))
+		_, _, err := VerifyReceipt(tampered)
+		assert.Error(t, err)
+	})
+
+	t.Run("the receipt is not overwritten", func(t *testing.T) {
+		_, err := payer.Run(context.Background(), plan, func(Progress) {})
+		assert.Error(t, err)
+	})
+}
+
+func TestRunCanceled(t *testing.T) {
+	w := newFakeWallet(t, 100e9)
+	payer := setupPayer(t, w)
+
+	plan, err := payer.Prepare("p1", testEntries(5))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	progress, err := payer.Run(ctx, plan, func(Progress) { cancel() })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, progress.Done)
+	assert.Equal(t, 2, progress.Sent, "only the first batch is sent")
+
+	data, err := os.ReadFile(progress.Receipt)
+	require.NoError(t, err)
+	records, _, err := VerifyReceipt(data)
+	require.NoError(t, err, "the partial receipt is signed")
+	assert.Len(t, records, 2)
+}
@@ -0,0 +1,137 @@
+package payout
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/pactus-project/pactus/crypto/bls"
+)
+
+// Record is a line of the receipt, the result of one transfer.
+type Record struct {
+	Address  string `json:"address"`
+	Amount   int64  `json:"amount"`
+	TxID     string `json:"tx_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Attempts int    `json:"attempts"`
+	Time     int64  `json:"time"`
+}
+
+// Signature is the last line of the receipt, the signature of the SHA-256 digest of the records
+// by the key of the wallet, so the receipt can't be changed afterward.
+type Signature struct {
+	Digest    string `json:"digest"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+type signer interface {
+	SignMessage(msg []byte) (string, string, error)
+}
+
+// receipt writes the records as JSON lines, and signs them on close.
+type receipt struct {
+	file   *os.File
+	digest hash.Hash
+	w      io.Writer
+}
+
+// createReceipt creates the receipt file, it fails if the file exists, so a receipt is never overwritten.
+func createReceipt(path string) (*receipt, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.New()
+
+	return &receipt{
+		file:   file,
+		digest: digest,
+		w:      io.MultiWriter(file, digest),
+	}, nil
+}
+
+func (r *receipt) add(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.w.Write(append(data, '\n'))
+
+	return err
+}
+
+// sign appends the signature of the records and closes the file.
+func (r *receipt) sign(s signer) error {
+	defer r.file.Close()
+
+	digest := r.digest.Sum(nil)
+	sig, pub, err := s.SignMessage(digest)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(Signature{Digest: hex.EncodeToString(digest), PublicKey: pub, Signature: sig})
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	return r.file.Sync()
+}
+
+// VerifyReceipt checks the signature of the receipt and returns its records.
+func VerifyReceipt(data []byte) ([]Record, *Signature, error) {
+	data = bytes.TrimRight(data, "\n")
+	lastLine := bytes.LastIndexByte(data, '\n')
+	body, sigLine := data[:lastLine+1], data[lastLine+1:]
+
+	sig := &Signature{}
+	if err := json.Unmarshal(sigLine, sig); err != nil || sig.Signature == "" {
+		return nil, nil, errors.New("receipt is not signed")
+	}
+
+	digest := sha256.Sum256(body)
+	if hex.EncodeToString(digest[:]) != sig.Digest {
+		return nil, nil, errors.New("receipt digest doesn't match the records")
+	}
+
+	pub, err := bls.PublicKeyFromString(sig.PublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	blsSig, err := bls.SignatureFromString(sig.Signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if err := pub.Verify(digest[:], blsSig); err != nil {
+		return nil, nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	records := []Record{}
+	for _, line := range bytes.Split(bytes.TrimRight(body, "\n"), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+
+		record := Record{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, sig, nil
+}
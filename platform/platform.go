@@ -0,0 +1,99 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+)
+
+// Adapter connects a platform, like Discord or Telegram, to the engine.
+// The adapters share the lifecycle of the bot, see lifecycle.Group.
+type Adapter interface {
+	Name() string
+	AppID() engine.AppID
+
+	Start(ctx context.Context) error
+	Stop()
+}
+
+// Factory creates the adapter of the platform by the config.
+type Factory func(be *engine.BotEngine, cfg *config.Config) (Adapter, error)
+
+type registered struct {
+	name    string
+	factory Factory
+	// configured reports whether the settings of the platform are set, like its token.
+	configured func(cfg *config.Config) bool
+}
+
+// Registry keeps the platforms that the bot can run on.
+type Registry struct {
+	platforms []registered
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds the platform to the registry. The platform is enabled by default if it's configured.
+func (r *Registry) Register(name string, factory Factory, configured func(cfg *config.Config) bool) {
+	r.platforms = append(r.platforms, registered{name: name, factory: factory, configured: configured})
+}
+
+// Names returns the names of the registered platforms.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.platforms))
+	for _, p := range r.platforms {
+		names = append(names, p.name)
+	}
+
+	return names
+}
+
+// Enabled returns the names of the platforms that are enabled in the config.
+// If the config doesn't list the platforms, the configured ones are enabled.
+func (r *Registry) Enabled(cfg *config.Config) ([]string, error) {
+	names := r.Names()
+	for _, name := range cfg.Platforms {
+		if !slices.Contains(names, name) {
+			return nil, fmt.Errorf("unknown platform: %s, the platforms are: %s", name, strings.Join(names, ", "))
+		}
+	}
+
+	enabled := []string{}
+	for _, p := range r.platforms {
+		if len(cfg.Platforms) > 0 && slices.Contains(cfg.Platforms, p.name) ||
+			len(cfg.Platforms) == 0 && p.configured(cfg) {
+			enabled = append(enabled, p.name)
+		}
+	}
+
+	return enabled, nil
+}
+
+// Build creates the adapters of the enabled platforms, in the order that they are registered.
+func (r *Registry) Build(be *engine.BotEngine, cfg *config.Config) ([]Adapter, error) {
+	enabled, err := r.Enabled(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	adapters := make([]Adapter, 0, len(enabled))
+	for _, p := range r.platforms {
+		if !slices.Contains(enabled, p.name) {
+			continue
+		}
+
+		adapter, err := p.factory(be, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the %s adapter: %w", p.name, err)
+		}
+		adapters = append(adapters, adapter)
+	}
+
+	return adapters, nil
+}
@@ -0,0 +1,110 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAdapter struct {
+	name  string
+	appID engine.AppID
+}
+
+func (a *testAdapter) Name() string                  { return a.name }
+func (a *testAdapter) AppID() engine.AppID           { return a.appID }
+func (a *testAdapter) Start(_ context.Context) error { return nil }
+func (a *testAdapter) Stop()                         {}
+
+func setupRegistry(t *testing.T) *Registry {
+	t.Helper()
+
+	reg := NewRegistry()
+	reg.Register("discord",
+		func(_ *engine.BotEngine, _ *config.Config) (Adapter, error) {
+			return &testAdapter{name: "discord", appID: engine.AppIdDiscord}, nil
+		},
+		func(cfg *config.Config) bool { return cfg.DiscordBotCfg.DiscordToken != "" },
+	)
+	reg.Register("telegram",
+		func(_ *engine.BotEngine, cfg *config.Config) (Adapter, error) {
+			if cfg.TelegramBotCfg.Token == "" {
+				return nil, errors.New("token is not set")
+			}
+
+			return &testAdapter{name: "telegram", appID: engine.AppIdTelegram}, nil
+		},
+		func(cfg *config.Config) bool { return cfg.TelegramBotCfg.Token != "" },
+	)
+
+	return reg
+}
+
+func TestEnabled(t *testing.T) {
+	reg := setupRegistry(t)
+	assert.Equal(t, []string{"discord", "telegram"}, reg.Names())
+
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		enabled []string
+	}{
+		{
+			name:    "configured platforms by default",
+			cfg:     &config.Config{TelegramBotCfg: config.TelegramBotConfig{Token: "abc"}},
+			enabled: []string{"telegram"},
+		},
+		{
+			name:    "nothing is configured",
+			cfg:     &config.Config{},
+			enabled: []string{},
+		},
+		{
+			name: "listed platforms",
+			cfg: &config.Config{
+				Platforms:      []string{"discord"},
+				TelegramBotCfg: config.TelegramBotConfig{Token: "abc"},
+			},
+			enabled: []string{"discord"},
+		},
+		{
+			name:    "registration order",
+			cfg:     &config.Config{Platforms: []string{"telegram", "discord"}},
+			enabled: []string{"discord", "telegram"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enabled, err := reg.Enabled(tt.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.enabled, enabled)
+		})
+	}
+
+	t.Run("unknown platform", func(t *testing.T) {
+		_, err := reg.Enabled(&config.Config{Platforms: []string{"discord", "slack"}})
+		assert.ErrorContains(t, err, "unknown platform: slack")
+	})
+}
+
+func TestBuild(t *testing.T) {
+	reg := setupRegistry(t)
+
+	adapters, err := reg.Build(nil, &config.Config{
+		DiscordBotCfg:  config.DiscordBotConfig{DiscordToken: "abc"},
+		TelegramBotCfg: config.TelegramBotConfig{Token: "abc"},
+	})
+	require.NoError(t, err)
+	require.Len(t, adapters, 2)
+	assert.Equal(t, engine.AppIdDiscord, adapters[0].AppID())
+	assert.Equal(t, "telegram", adapters[1].Name())
+
+	_, err = reg.Build(nil, &config.Config{Platforms: []string{"telegram"}})
+	assert.ErrorContains(t, err, "unable to create the telegram adapter")
+}
@@ -0,0 +1,332 @@
+package referral
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/store"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/pactus-project/pactus/util"
+)
+
+// CodeLength is the length of the referral codes.
+const CodeLength = 8
+
+const (
+	memo         = "RoboPac referral reward"
+	codeAlphabet = "0123456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+var (
+	ErrCodeNotFound        = errors.New("referral code is not found")
+	ErrOwnCode             = errors.New("you can't claim your own referral code")
+	ErrAlreadyClaimed      = errors.New("you, or your linked address, have already claimed a referral code")
+	ErrInsufficientBalance = errors.New("bot wallet hasn't enough balance for the referral rewards")
+)
+
+// BalanceError is returned when the address of the referred user has less than the minimum balance,
+// so the referrals can't be farmed by the new empty addresses.
+type BalanceError struct {
+	MinBalance int64
+}
+
+func (e BalanceError) Error() string {
+	return fmt.Sprintf("your linked address needs a balance of at least %s PAC to claim a referral code",
+		util.ChangeToString(e.MinBalance))
+}
+
+// Wallet sends the rewards, like the bot wallet.
+type Wallet interface {
+	Balance() int64
+	TransferTransaction(toAddress string, amount int64, memo string) (string, error)
+}
+
+type balanceReader interface {
+	GetBalance(addr string) (int64, error)
+}
+
+// Referrer is the referral code of a user, and the counts of the users they referred.
+type Referrer struct {
+	UserID    string `json:"user_id"`
+	Code      string `json:"code"`
+	CreatedAt int64  `json:"created_at"`
+	Referred  int    `json:"referred"`
+	// Rewarded is the number of the referred users that the referrer is rewarded for.
+	Rewarded int `json:"rewarded"`
+	// Rewards is the total of the sent rewards in nanoPAC.
+	Rewards int64 `json:"rewards"`
+}
+
+// Referral is a user that claimed a referral code by a linked address.
+type Referral struct {
+	UserID     string `json:"user_id"`
+	ReferrerID string `json:"referrer_id"`
+	Code       string `json:"code"`
+	Address    string `json:"address"`
+	ClaimedAt  int64  `json:"claimed_at"`
+	// TxID is the transaction of the reward, it's empty while the reward is pending.
+	TxID string `json:"tx_id,omitempty"`
+}
+
+// Referrals keeps the referrers by their codes, and the referred users by their IDs.
+// The rewards are sent from the wallet by the payouts.
+type Referrals struct {
+	lk sync.Mutex
+
+	wallet     Wallet
+	node       balanceReader
+	referrers  store.KV
+	referred   store.KV
+	reward     int64
+	minBalance int64
+	nowFunc    func() time.Time
+
+	// unsaved is the transactions of the referrers whose rewards are sent, but not saved.
+	// They are not paid again until the bot restarts.
+	unsaved map[string]string
+}
+
+// NewReferrals creates the referrals that reward each referred user by the reward. The address of the
+// referred user must have the minimum balance on the chain when the code is claimed.
+func NewReferrals(w Wallet, node balanceReader, referrers, referred store.KV, reward, minBalance int64) *Referrals {
+	return &Referrals{
+		wallet:     w,
+		node:       node,
+		referrers:  referrers,
+		referred:   referred,
+		reward:     reward,
+		minBalance: minBalance,
+		nowFunc:    time.Now,
+		unsaved:    make(map[string]string),
+	}
+}
+
+// Reward returns the reward of each referred user in nanoPAC.
+func (r *Referrals) Reward() int64 {
+	return r.reward
+}
+
+// ReferrerOf returns the referrer of the user, or nil if the user has no referral code.
+func (r *Referrals) ReferrerOf(userID string) (*Referrer, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return r.referrerOf(userID)
+}
+
+func (r *Referrals) referrerOf(userID string) (*Referrer, error) {
+	var found *Referrer
+	err := store.IterateJSON(r.referrers, func(_ string, referrer *Referrer) {
+		if referrer.UserID == userID {
+			found = referrer
+		}
+	})
+
+	return found, err
+}
+
+// Code returns the referrer of the user, a new referral code is created if the user has none.
+func (r *Referrals) Code(userID string) (*Referrer, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	referrer, err := r.referrerOf(userID)
+	if err != nil || referrer != nil {
+		return referrer, err
+	}
+
+	code, err := gonanoid.Generate(codeAlphabet, CodeLength)
+	if err != nil {
+		return nil, err
+	}
+
+	referrer = &Referrer{UserID: userID, Code: code, CreatedAt: r.nowFunc().Unix()}
+	if err := store.SetJSON(r.referrers, code, referrer); err != nil {
+		return nil, err
+	}
+
+	return referrer, nil
+}
+
+// Claim records the user as referred by the code, by the first of their linked addresses.
+// Each user and each address can claim one code only, and the address must have the minimum balance,
+// so the referrals are not farmed.
+func (r *Referrals) Claim(userID, code string, addrs []string) (*Referrer, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	referrer, err := store.GetJSON[Referrer](r.referrers, code)
+	if err != nil {
+		return nil, err
+	}
+	if referrer == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCodeNotFound, code)
+	}
+
+	if referrer.UserID == userID {
+		return nil, ErrOwnCode
+	}
+
+	var claimed *Referral
+	err = store.IterateJSON(r.referred, func(_ string, referral *Referral) {
+		if referral.UserID == userID || slices.Contains(addrs, referral.Address) {
+			claimed = referral
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if claimed != nil {
+		return nil, ErrAlreadyClaimed
+	}
+
+	balance, err := r.node.GetBalance(addrs[0])
+	if errors.Is(err, client.ErrAccountNotFound) {
+		// The address isn't on the chain yet, so it has no balance.
+		return nil, BalanceError{MinBalance: r.minBalance}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if balance < r.minBalance {
+		return nil, BalanceError{MinBalance: r.minBalance}
+	}
+
+	referral := &Referral{
+		UserID:     userID,
+		ReferrerID: referrer.UserID,
+		Code:       code,
+		Address:    addrs[0],
+		ClaimedAt:  r.nowFunc().Unix(),
+	}
+	if err := store.SetJSON(r.referred, userID, referral); err != nil {
+		return nil, err
+	}
+
+	referrer.Referred++
+	if err := store.SetJSON(r.referrers, code, referrer); err != nil {
+		return nil, err
+	}
+
+	return referrer, nil
+}
+
+// Pending returns the IDs of the referrers with pending rewards, in order.
+func (r *Referrals) Pending() ([]string, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	pending, err := r.pending()
+	if err != nil {
+		return nil, err
+	}
+
+	referrerIDs := make([]string, 0, len(pending))
+	for referrerID := range pending {
+		referrerIDs = append(referrerIDs, referrerID)
+	}
+	slices.Sort(referrerIDs)
+
+	return referrerIDs, nil
+}
+
+// pending returns the referred users with pending rewards, keyed by their referrers.
+func (r *Referrals) pending() (map[string][]*Referral, error) {
+	pending := make(map[string][]*Referral)
+	err := store.IterateJSON(r.referred, func(_ string, referral *Referral) {
+		if referral.TxID == "" {
+			pending[referral.ReferrerID] = append(pending[referral.ReferrerID], referral)
+		}
+	})
+
+	return pending, err
+}
+
+// Pay sends the pending rewards of the referrer to the address in one transfer.
+// The amount is zero if no transfer is sent, like when there is no pending reward.
+func (r *Referrals) Pay(referrerID, address string) (string, int64, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	if txID, ok := r.unsaved[referrerID]; ok {
+		return "", 0, fmt.Errorf("the rewards are sent by %s, but not saved", txID)
+	}
+
+	pending, err := r.pending()
+	if err != nil {
+		return "", 0, err
+	}
+
+	referred := pending[referrerID]
+	if len(referred) == 0 {
+		return "", 0, nil
+	}
+
+	amount := r.reward * int64(len(referred))
+	if r.wallet.Balance() < amount {
+		return "", 0, ErrInsufficientBalance
+	}
+
+	txID, err := r.wallet.TransferTransaction(address, amount, memo)
+	if err != nil {
+		return "", amount, err
+	}
+
+	if err := r.savePayout(referrerID, referred, amount, txID); err != nil {
+		r.unsaved[referrerID] = txID
+
+		return txID, amount, fmt.Errorf("the rewards are sent by %s, but not saved: %w", txID, err)
+	}
+
+	return txID, amount, nil
+}
+
+func (r *Referrals) savePayout(referrerID string, referred []*Referral, amount int64, txID string) error {
+	for _, referral := range referred {
+		referral.TxID = txID
+		if err := store.SetJSON(r.referred, referral.UserID, referral); err != nil {
+			return err
+		}
+	}
+
+	referrer, err := r.referrerOf(referrerID)
+	if err != nil || referrer == nil {
+		return err
+	}
+
+	referrer.Rewarded += len(referred)
+	referrer.Rewards += amount
+
+	return store.SetJSON(r.referrers, referrer.Code, referrer)
+}
+
+// Referrers returns the referrers by the number of their referred users.
+func (r *Referrals) Referrers() ([]*Referrer, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	referrers := []*Referrer{}
+	if err := store.IterateJSON(r.referrers, func(_ string, referrer *Referrer) {
+		referrers = append(referrers, referrer)
+	}); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(referrers, func(a, b *Referrer) int {
+		if c := cmp.Compare(b.Referred, a.Referred); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	return referrers, nil
+}
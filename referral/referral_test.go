@@ -0,0 +1,182 @@
+package referral
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/kehiy/RoboPac/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeNode struct {
+	balances map[string]int64
+}
+
+func (n *fakeNode) GetBalance(addr string) (int64, error) {
+	balance, ok := n.balances[addr]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", client.ErrAccountNotFound, addr)
+	}
+
+	return balance, nil
+}
+
+// failingKV fails to save the values, once the failures are enabled.
+type failingKV struct {
+	store.KV
+	fail bool
+}
+
+func (kv *failingKV) Set(key string, value []byte) error {
+	if kv.fail {
+		return errors.New("disk is full")
+	}
+
+	return kv.KV.Set(key, value)
+}
+
+func setup(t *testing.T) (*Referrals, *wallet.MockIWallet, *fakeNode, *failingKV) {
+	t.Helper()
+
+	referrers, err := store.NewJSONKV(path.Join(t.TempDir(), "referrers.json"))
+	require.NoError(t, err)
+	referred, err := store.NewJSONKV(path.Join(t.TempDir(), "referrals.json"))
+	require.NoError(t, err)
+
+	mockWallet := wallet.NewMockIWallet(gomock.NewController(t))
+	node := &fakeNode{balances: map[string]int64{"addr1": 5e9, "addr2": 5e9}}
+	failing := &failingKV{KV: referred}
+
+	r := NewReferrals(mockWallet, node, referrers, failing, 2e9, 1e9)
+	r.nowFunc = func() time.Time { return time.Unix(1_700_000_000, 0) }
+
+	return r, mockWallet, node, failing
+}
+
+func TestClaim(t *testing.T) {
+	r, _, node, _ := setup(t)
+
+	alice, err := r.Code("alice")
+	require.NoError(t, err)
+	assert.Len(t, alice.Code, CodeLength)
+	assert.Equal(t, int64(1_700_000_000), alice.CreatedAt)
+
+	again, err := r.Code("alice")
+	require.NoError(t, err)
+	assert.Equal(t, alice.Code, again.Code)
+
+	_, err = r.Claim("bob", "XXXXXXXX", []string{"addr1"})
+	assert.ErrorIs(t, err, ErrCodeNotFound)
+
+	_, err = r.Claim("alice", alice.Code, []string{"addr1"})
+	assert.ErrorIs(t, err, ErrOwnCode)
+
+	t.Run("empty address", func(t *testing.T) {
+		node.balances["addr3"] = 1e9 - 1
+
+		_, err := r.Claim("bob", alice.Code, []string{"addr3"})
+		var balanceErr BalanceError
+		require.ErrorAs(t, err, &balanceErr)
+		assert.Equal(t, int64(1e9), balanceErr.MinBalance)
+	})
+
+	t.Run("unknown address", func(t *testing.T) {
+		_, err := r.Claim("bob", alice.Code, []string{"addr9"})
+		var balanceErr BalanceError
+		require.ErrorAs(t, err, &balanceErr)
+		assert.Equal(t, int64(1e9), balanceErr.MinBalance)
+	})
+
+	t.Run("claim once", func(t *testing.T) {
+		referrer, err := r.Claim("bob", " "+alice.Code, []string{"addr1"})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", referrer.UserID)
+		assert.Equal(t, 1, referrer.Referred)
+
+		_, err = r.Claim("bob", alice.Code, []string{"addr2"})
+		assert.ErrorIs(t, err, ErrAlreadyClaimed, "each user claims once")
+
+		_, err = r.Claim("carol", alice.Code, []string{"addr2", "addr1"})
+		assert.ErrorIs(t, err, ErrAlreadyClaimed, "each address claims once")
+	})
+}
+
+func TestPay(t *testing.T) {
+	r, mockWallet, _, failing := setup(t)
+
+	alice, err := r.Code("alice")
+	require.NoError(t, err)
+	_, err = r.Claim("bob", alice.Code, []string{"addr1"})
+	require.NoError(t, err)
+	_, err = r.Claim("carol", alice.Code, []string{"addr2"})
+	require.NoError(t, err)
+
+	pending, err := r.Pending()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, pending)
+
+	t.Run("no pending rewards", func(t *testing.T) {
+		txID, amount, err := r.Pay("dave", "addr9")
+		require.NoError(t, err)
+		assert.Empty(t, txID)
+		assert.Zero(t, amount)
+	})
+
+	t.Run("insufficient balance", func(t *testing.T) {
+		mockWallet.EXPECT().Balance().Return(int64(1e9))
+
+		_, amount, err := r.Pay("alice", "addr9")
+		assert.ErrorIs(t, err, ErrInsufficientBalance)
+		assert.Zero(t, amount)
+	})
+
+	t.Run("unsaved payout", func(t *testing.T) {
+		mockWallet.EXPECT().Balance().Return(int64(10e9))
+		mockWallet.EXPECT().TransferTransaction("addr9", int64(4e9), memo).Return("0x123", nil)
+
+		failing.fail = true
+		txID, amount, err := r.Pay("alice", "addr9")
+		assert.Error(t, err)
+		assert.Equal(t, "0x123", txID)
+		assert.Equal(t, int64(4e9), amount)
+
+		failing.fail = false
+		_, amount, err = r.Pay("alice", "addr9")
+		assert.ErrorContains(t, err, "0x123", "the sent rewards are not sent again")
+		assert.Zero(t, amount)
+	})
+}
+
+func TestPaySaved(t *testing.T) {
+	r, mockWallet, _, _ := setup(t)
+
+	alice, err := r.Code("alice")
+	require.NoError(t, err)
+	_, err = r.Claim("bob", alice.Code, []string{"addr1"})
+	require.NoError(t, err)
+
+	mockWallet.EXPECT().Balance().Return(int64(10e9))
+	mockWallet.EXPECT().TransferTransaction("addr9", int64(2e9), memo).Return("0x456", nil)
+
+	txID, amount, err := r.Pay("alice", "addr9")
+	require.NoError(t, err)
+	assert.Equal(t, "0x456", txID)
+	assert.Equal(t, int64(2e9), amount)
+
+	referrers, err := r.Referrers()
+	require.NoError(t, err)
+	require.Len(t, referrers, 1)
+	assert.Equal(t, 1, referrers[0].Rewarded)
+	assert.Equal(t, int64(2e9), referrers[0].Rewards)
+
+	pending, err := r.Pending()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
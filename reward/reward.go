@@ -0,0 +1,77 @@
+package reward
+
+import (
+	"errors"
+	"time"
+)
+
+// The amounts are in NanoPAC.
+const (
+	// BlockReward is the reward of each block, paid to its proposer.
+	BlockReward = int64(1e9)
+	// BlockInterval is the target time between the blocks.
+	BlockInterval = 10 * time.Second
+
+	// MinStake and MaxStake are the bounds of the stake of a validator.
+	MinStake = int64(1e9)
+	MaxStake = int64(1000e9)
+)
+
+// Params are the state of the network that the rewards depend on, the amounts are in NanoPAC.
+type Params struct {
+	// TotalPower is the stake of all the validators.
+	TotalPower int64
+	// CommitteeSize is the number of the validators in the committee.
+	CommitteeSize int
+}
+
+// Estimate is the expected reward of a stake over a period, the amounts are in NanoPAC.
+type Estimate struct {
+	Stake  int64
+	Days   int
+	Blocks int64
+	// Share is the part of the total power that the stake has, between 0 and 1.
+	Share float64
+	// Reward is the expected reward of the period.
+	Reward int64
+	// CommitteeSeats is the expected number of the committee seats that the stake holds.
+	CommitteeSeats float64
+	// APR is the annual percentage rate of the reward.
+	APR float64
+}
+
+// BlocksPerDay returns the expected number of the blocks in a day.
+func BlocksPerDay() int64 {
+	return int64(24 * time.Hour / BlockInterval)
+}
+
+// Calculate estimates the reward of the stake over the days.
+// The validators join the committee, and propose the blocks, in proportion to their stake,
+// so in the long run the stake earns its share of the total power from the block rewards.
+// The stake is added to the total power, like a new validator joining the network.
+func Calculate(params Params, stake int64, days int) (*Estimate, error) {
+	if stake < MinStake || stake > MaxStake {
+		return nil, errors.New("the stake should be between 1 and 1,000 PAC")
+	}
+
+	if days < 1 {
+		return nil, errors.New("the days should be at least 1")
+	}
+
+	if params.TotalPower < 0 {
+		return nil, errors.New("the total power is negative")
+	}
+
+	share := float64(stake) / float64(params.TotalPower+stake)
+	blocks := BlocksPerDay() * int64(days)
+
+	return &Estimate{
+		Stake:          stake,
+		Days:           days,
+		Blocks:         blocks,
+		Share:          share,
+		Reward:         int64(float64(blocks) * share * float64(BlockReward)),
+		CommitteeSeats: share * float64(params.CommitteeSize),
+		APR:            100 * float64(BlocksPerDay()*365) * share * float64(BlockReward) / float64(stake),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package reward
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculate(t *testing.T) {
+	params := Params{TotalPower: 9_900e9, CommitteeSize: 51}
+
+	t.Run("one day", func(t *testing.T) {
+		estimate, err := Calculate(params, 100e9, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(8640), estimate.Blocks)
+		assert.InDelta(t, 0.01, estimate.Share, 1e-9)
+		assert.Equal(t, int64(86.4e9), estimate.Reward)
+		assert.InDelta(t, 0.51, estimate.CommitteeSeats, 1e-9)
+		assert.InDelta(t, 31_536, estimate.APR, 1e-6)
+	})
+
+	t.Run("the reward grows with the days", func(t *testing.T) {
+		estimate, err := Calculate(params, 100e9, 30)
+		require.NoError(t, err)
+		assert.Equal(t, int64(30*86.4e9), estimate.Reward)
+	})
+
+	t.Run("empty network", func(t *testing.T) {
+		estimate, err := Calculate(Params{}, 1e9, 1)
+		require.NoError(t, err)
+		assert.InDelta(t, 1, estimate.Share, 1e-9)
+		assert.Equal(t, BlocksPerDay()*BlockReward, estimate.Reward)
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		_, err := Calculate(params, 0.5e9, 1)
+		assert.Error(t, err)
+
+		_, err = Calculate(params, 1001e9, 1)
+		assert.Error(t, err)
+
+		_, err = Calculate(params, 100e9, 0)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,211 @@
+package scheduler
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/robfig/cron/v3"
+)
+
+// Job is a periodic task.
+type Job struct {
+	// Name identifies the job, it's used in the logs and the metrics.
+	Name string
+	// Spec is a cron expression, or a descriptor like "@daily" or "@every 6h".
+	Spec string
+	// Jitter delays each run randomly up to this duration, so the jobs don't hit the nodes at the same time.
+	Jitter time.Duration
+	// Run does the work, the context is canceled when the scheduler is stopped.
+	Run func(ctx context.Context)
+}
+
+// JobStats is the state of a scheduled job.
+type JobStats struct {
+	Name         string
+	Spec         string
+	Runs         uint64
+	Skipped      uint64
+	Panics       uint64
+	LastRun      time.Time
+	LastDuration time.Duration
+	NextRun      time.Time
+}
+
+type entry struct {
+	job     Job
+	id      cron.EntryID
+	running atomic.Bool
+
+	lk    sync.Mutex
+	stats JobStats
+}
+
+// Scheduler runs the jobs periodically. A run is skipped if the previous one is still running,
+// and a panic in a job is recovered and logged.
+type Scheduler struct {
+	lk     sync.Mutex
+	cron   *cron.Cron
+	jobs   map[string]*entry
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New creates a scheduler, the jobs start running after the scheduler is started.
+func New() *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Scheduler{
+		cron:   cron.New(),
+		jobs:   make(map[string]*entry),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Add schedules the job. The job names are unique.
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" {
+		return errors.New("job name is empty")
+	}
+	if job.Run == nil {
+		return fmt.Errorf("job %s has nothing to run", job.Name)
+	}
+
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("job %s is already scheduled", job.Name)
+	}
+
+	e := &entry{job: job, stats: JobStats{Name: job.Name, Spec: job.Spec}}
+	id, err := s.cron.AddFunc(job.Spec, func() { s.run(e) })
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	e.id = id
+	s.jobs[job.Name] = e
+
+	log.Info("job scheduled", "name", job.Name, "spec", job.Spec)
+
+	return nil
+}
+
+// Remove unschedules the job, a running instance of it is not interrupted.
+func (s *Scheduler) Remove(name string) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	e, ok := s.jobs[name]
+	if !ok {
+		return
+	}
+
+	s.cron.Remove(e.id)
+	delete(s.jobs, name)
+}
+
+// Start starts running the jobs, until the context is canceled or the scheduler is stopped.
+func (s *Scheduler) Start(ctx context.Context) error {
+	context.AfterFunc(ctx, s.cancel)
+	s.cron.Start()
+
+	return nil
+}
+
+// Stop stops the scheduler and waits for the running jobs to return.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	<-s.cron.Stop().Done()
+}
+
+// Stats returns the state of the jobs, sorted by name.
+func (s *Scheduler) Stats() []JobStats {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	stats := make([]JobStats, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		e.lk.Lock()
+		st := e.stats
+		e.lk.Unlock()
+
+		st.NextRun = s.cron.Entry(e.id).Next
+		stats = append(stats, st)
+	}
+	slices.SortFunc(stats, func(a, b JobStats) int { return cmp.Compare(a.Name, b.Name) })
+
+	return stats
+}
+
+func (s *Scheduler) run(e *entry) {
+	name := e.job.Name
+
+	if !e.running.CompareAndSwap(false, true) {
+		log.Warn("job is still running, skipping this run", "name", name)
+		metrics.SchedulerJobRunsTotal.Inc(name, metrics.ResultSkipped)
+		e.update(func(st *JobStats) { st.Skipped++ })
+
+		return
+	}
+	defer e.running.Store(false)
+
+	if e.job.Jitter > 0 && !sleep(s.ctx, time.Duration(rand.Int63n(int64(e.job.Jitter)))) {
+		return
+	}
+
+	log.Debug("running scheduled job", "name", name)
+	start := time.Now()
+	result := metrics.ResultSuccess
+
+	defer func() {
+		duration := time.Since(start)
+
+		if r := recover(); r != nil {
+			log.Error("scheduled job panicked", "name", name, "panic", r)
+			result = metrics.ResultPanic
+		}
+
+		metrics.SchedulerJobRunsTotal.Inc(name, result)
+		metrics.SchedulerJobDuration.Observe(duration.Seconds(), name)
+		e.update(func(st *JobStats) {
+			st.Runs++
+			st.LastRun = start
+			st.LastDuration = duration
+			if result == metrics.ResultPanic {
+				st.Panics++
+			}
+		})
+	}()
+
+	e.job.Run(s.ctx)
+}
+
+func (e *entry) update(fn func(st *JobStats)) {
+	e.lk.Lock()
+	defer e.lk.Unlock()
+
+	fn(&e.stats)
+}
+
+// sleep waits for the duration, it returns false if the context is canceled in the meantime.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
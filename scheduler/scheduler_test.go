@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdd(t *testing.T) {
+	s := New()
+	noop := func(context.Context) {}
+
+	assert.Error(t, s.Add(Job{Spec: "@every 1s", Run: noop}))
+	assert.Error(t, s.Add(Job{Name: "no-run", Spec: "@every 1s"}))
+	assert.Error(t, s.Add(Job{Name: "invalid", Spec: "not a cron expression", Run: noop}))
+
+	require.NoError(t, s.Add(Job{Name: "job", Spec: "@every 1s", Run: noop}))
+	assert.Error(t, s.Add(Job{Name: "job", Spec: "@every 2s", Run: noop}))
+
+	s.Remove("job")
+	require.NoError(t, s.Add(Job{Name: "job", Spec: "@every 2s", Run: noop}))
+
+	stats := s.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, "@every 2s", stats[0].Spec)
+}
+
+func TestRun(t *testing.T) {
+	t.Run("overlapping runs are skipped", func(t *testing.T) {
+		s := New()
+		release := make(chan struct{})
+		runs := atomic.Int32{}
+		require.NoError(t, s.Add(Job{Name: "slow", Spec: "@every 1s", Run: func(context.Context) {
+			runs.Add(1)
+			<-release
+		}}))
+
+		require.NoError(t, s.Start(context.Background()))
+		assert.Eventually(t, func() bool { return s.Stats()[0].Skipped > 0 }, 3*time.Second, 10*time.Millisecond)
+		close(release)
+		s.Stop()
+
+		assert.Equal(t, uint64(runs.Load()), s.Stats()[0].Runs)
+		assert.Positive(t, metrics.SchedulerJobRunsTotal.Value("slow", metrics.ResultSkipped))
+	})
+
+	t.Run("panics are recovered", func(t *testing.T) {
+		s := New()
+		require.NoError(t, s.Add(Job{Name: "panic", Spec: "@every 1s", Run: func(context.Context) {
+			panic("boom")
+		}}))
+
+		require.NoError(t, s.Start(context.Background()))
+		defer s.Stop()
+
+		assert.Eventually(t, func() bool { return s.Stats()[0].Panics > 0 }, 3*time.Second, 10*time.Millisecond)
+		assert.Positive(t, metrics.SchedulerJobRunsTotal.Value("panic", metrics.ResultPanic))
+	})
+
+	t.Run("stop cancels the jobs", func(t *testing.T) {
+		s := New()
+		started := make(chan struct{})
+		require.NoError(t, s.Add(Job{Name: "wait", Spec: "@every 1s", Jitter: time.Millisecond, Run: func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+		}}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		require.NoError(t, s.Start(ctx))
+		<-started
+		cancel()
+		s.Stop()
+
+		stats := s.Stats()[0]
+		assert.Equal(t, uint64(1), stats.Runs)
+		assert.False(t, stats.LastRun.IsZero())
+	})
+}
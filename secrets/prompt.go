@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadPassphrase asks the passphrase on the terminal, the typed passphrase is not shown.
+func ReadPassphrase(in *os.File, out io.Writer, prompt string) (string, error) {
+	var line string
+	err := withoutEcho(in, func() error {
+		fmt.Fprint(out, prompt)
+		defer fmt.Fprintln(out)
+
+		var err error
+		line, err = bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to read the passphrase: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// EnvOrPrompt returns an unlocker that finds the key in the environment variables,
+// or asks the passphrase on the terminal at the startup, if they are not set.
+func EnvOrPrompt(in *os.File, out io.Writer) func() (*Key, error) {
+	return func() (*Key, error) {
+		key, err := KeyFromEnv()
+		if key != nil || err != nil {
+			return key, err
+		}
+
+		passphrase, err := ReadPassphrase(in, out, "Passphrase of the secrets: ")
+		if err != nil {
+			return nil, errors.Join(ErrLocked, err)
+		}
+
+		return NewPassphraseKey(passphrase)
+	}
+}
@@ -0,0 +1,199 @@
+// Package secrets keeps the secrets of the config encrypted at rest, like the bot tokens and the wallet seed.
+// An encrypted value looks like "enc:v1:...", it's sealed by AES-256-GCM with a key that is given directly,
+// or derived from a passphrase by scrypt.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// Prefix is the prefix of the encrypted values, the other values are taken as plaintext.
+	Prefix = "enc:v1:"
+
+	// KeyEnv is the environment variable of the key in base64, it takes precedence over the passphrase.
+	KeyEnv = "ROBOPAC_SECRETS_KEY"
+	// PassphraseEnv is the environment variable of the passphrase.
+	PassphraseEnv = "ROBOPAC_SECRETS_PASSPHRASE"
+
+	keySize  = 32
+	saltSize = 16
+
+	// The scrypt parameters recommended for the interactive logins.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var (
+	// ErrLocked is returned when a value is encrypted, but no key or passphrase is given.
+	ErrLocked = fmt.Errorf("the secrets are locked, set %s or %s", KeyEnv, PassphraseEnv)
+	// ErrWrongKey is returned when a value can't be decrypted by the key, or it's tampered.
+	ErrWrongKey = errors.New("unable to decrypt the secret, the key or the passphrase is wrong")
+)
+
+// Key encrypts and decrypts the secrets.
+type Key struct {
+	passphrase []byte
+	raw        []byte
+
+	lk sync.Mutex
+	// derived caches the keys derived from the passphrase by their salt, scrypt is slow by design.
+	derived map[string][]byte
+}
+
+// NewPassphraseKey returns a key that is derived from the passphrase.
+// Each value has its own salt, so the same secrets are not encrypted the same.
+func NewPassphraseKey(passphrase string) (*Key, error) {
+	if passphrase == "" {
+		return nil, errors.New("the passphrase is empty")
+	}
+
+	return &Key{passphrase: []byte(passphrase), derived: make(map[string][]byte)}, nil
+}
+
+// ParseKey parses a key of 32 bytes in base64, like the keys of GenerateKey.
+func ParseKey(encoded string) (*Key, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets key: %w", err)
+	}
+
+	if len(raw) != keySize {
+		return nil, fmt.Errorf("invalid secrets key: expected %d bytes, got %d", keySize, len(raw))
+	}
+
+	return &Key{raw: raw}, nil
+}
+
+// GenerateKey returns a random key in base64.
+func GenerateKey() (string, error) {
+	raw := make([]byte, keySize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// KeyFromEnv returns the key of the environment variables, it's nil if none of them is set.
+func KeyFromEnv() (*Key, error) {
+	if encoded := os.Getenv(KeyEnv); encoded != "" {
+		return ParseKey(encoded)
+	}
+
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return NewPassphraseKey(passphrase)
+	}
+
+	return nil, nil
+}
+
+// IsEncrypted reports whether the value is encrypted.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), Prefix)
+}
+
+// Encrypt seals the plaintext, the result is like "enc:v1:...".
+func (k *Key) Encrypt(plaintext string) (string, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	aead, err := k.aead(salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	// the salt is authenticated as the additional data, so it can't be swapped.
+	sealed := aead.Seal(nil, nonce, []byte(plaintext), salt)
+	data := make([]byte, 0, len(salt)+len(nonce)+len(sealed))
+	data = append(data, salt...)
+	data = append(data, nonce...)
+	data = append(data, sealed...)
+
+	return Prefix + base64.RawStdEncoding.EncodeToString(data), nil
+}
+
+// Decrypt opens the encrypted value.
+func (k *Key) Decrypt(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(strings.TrimSpace(value), Prefix)
+	if !ok {
+		return "", errors.New("the value is not encrypted")
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(data) < saltSize {
+		return "", errors.New("the encrypted value is malformed")
+	}
+
+	salt := data[:saltSize]
+	aead, err := k.aead(salt)
+	if err != nil {
+		return "", err
+	}
+
+	data = data[saltSize:]
+	if len(data) < aead.NonceSize() {
+		return "", errors.New("the encrypted value is malformed")
+	}
+
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], salt)
+	if err != nil {
+		return "", ErrWrongKey
+	}
+
+	return string(plaintext), nil
+}
+
+// aead returns the cipher of the salt, the salt is used by the passphrase keys only.
+func (k *Key) aead(salt []byte) (cipher.AEAD, error) {
+	key := k.raw
+	if key == nil {
+		var err error
+		key, err = k.derive(salt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func (k *Key) derive(salt []byte) ([]byte, error) {
+	k.lk.Lock()
+	defer k.lk.Unlock()
+
+	if key, ok := k.derived[string(salt)]; ok {
+		return key, nil
+	}
+
+	key, err := scrypt.Key(k.passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, err
+	}
+	k.derived[string(salt)] = key
+
+	return key, nil
+}
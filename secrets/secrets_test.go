@@ -0,0 +1,92 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	encoded, err := GenerateKey()
+	require.NoError(t, err)
+	rawKey, err := ParseKey(encoded)
+	require.NoError(t, err)
+
+	passphraseKey, err := NewPassphraseKey("correct horse battery staple")
+	require.NoError(t, err)
+
+	for name, key := range map[string]*Key{"raw key": rawKey, "passphrase": passphraseKey} {
+		t.Run(name, func(t *testing.T) {
+			encrypted, err := key.Encrypt("MTEabc123")
+			require.NoError(t, err)
+			assert.True(t, IsEncrypted(encrypted))
+			assert.NotContains(t, encrypted, "MTEabc123")
+
+			again, err := key.Encrypt("MTEabc123")
+			require.NoError(t, err)
+			assert.NotEqual(t, encrypted, again, "each value has its own salt and nonce")
+
+			plaintext, err := key.Decrypt(encrypted)
+			require.NoError(t, err)
+			assert.Equal(t, "MTEabc123", plaintext)
+		})
+	}
+
+	t.Run("wrong key", func(t *testing.T) {
+		encrypted, err := rawKey.Encrypt("MTEabc123")
+		require.NoError(t, err)
+
+		otherKey, err := NewPassphraseKey("wrong")
+		require.NoError(t, err)
+		_, err = otherKey.Decrypt(encrypted)
+		assert.ErrorIs(t, err, ErrWrongKey)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		encrypted, err := rawKey.Encrypt("MTEabc123")
+		require.NoError(t, err)
+
+		last := encrypted[len(encrypted)-1:]
+		tampered := strings.TrimSuffix(encrypted, last) + map[bool]string{true: "B", false: "A"}[last == "A"]
+		_, err = rawKey.Decrypt(tampered)
+		assert.ErrorIs(t, err, ErrWrongKey)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := rawKey.Decrypt(Prefix + "!!!")
+		assert.Error(t, err)
+
+		_, err = rawKey.Decrypt("plaintext")
+		assert.Error(t, err)
+	})
+}
+
+func TestKeys(t *testing.T) {
+	_, err := ParseKey("c2hvcnQ=")
+	assert.ErrorContains(t, err, "expected 32 bytes")
+
+	_, err = NewPassphraseKey("")
+	assert.Error(t, err)
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(KeyEnv, "")
+		t.Setenv(PassphraseEnv, "")
+		key, err := KeyFromEnv()
+		require.NoError(t, err)
+		assert.Nil(t, key)
+
+		t.Setenv(PassphraseEnv, "passphrase")
+		key, err = KeyFromEnv()
+		require.NoError(t, err)
+		assert.NotNil(t, key.passphrase)
+
+		encoded, err := GenerateKey()
+		require.NoError(t, err)
+		t.Setenv(KeyEnv, encoded)
+		key, err = KeyFromEnv()
+		require.NoError(t, err)
+		assert.NotNil(t, key.raw, "the key takes precedence over the passphrase")
+	})
+}
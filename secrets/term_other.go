@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package secrets
+
+import (
+	"errors"
+	"os"
+)
+
+// withoutEcho is not supported on this platform, the passphrase should be set by the environment.
+func withoutEcho(_ *os.File, _ func() error) error {
+	return errors.New("terminal is not supported")
+}
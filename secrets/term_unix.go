@@ -0,0 +1,29 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package secrets
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// withoutEcho runs fn while the echo of the terminal is disabled, so the typed passphrase is not shown.
+// It returns an error if the file is not a terminal.
+func withoutEcho(file *os.File, fn func() error) error {
+	fd := int(file.Fd())
+	original, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return err
+	}
+
+	hidden := *original
+	hidden.Lflag &^= unix.ECHO
+	hidden.Lflag |= unix.ICANON | unix.ISIG
+	if err := unix.IoctlSetTermios(fd, ioctlWriteTermios, &hidden); err != nil {
+		return err
+	}
+	defer func() { _ = unix.IoctlSetTermios(fd, ioctlWriteTermios, original) }()
+
+	return fn()
+}
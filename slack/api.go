@@ -0,0 +1,139 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultAPIURL = "https://slack.com/api"
+
+	envelopeHello         = "hello"
+	envelopeDisconnect    = "disconnect"
+	envelopeSlashCommands = "slash_commands"
+
+	apiTimeout = 10 * time.Second
+)
+
+// envelope is a message of the Socket Mode connection, the envelope ID is acknowledged to Slack.
+type envelope struct {
+	Type       string          `json:"type"`
+	EnvelopeID string          `json:"envelope_id"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// slashCommand is the payload of a slash command, like "/robopac node-info pc1p...".
+type slashCommand struct {
+	Command     string `json:"command"`
+	Text        string `json:"text"`
+	UserID      string `json:"user_id"`
+	ChannelID   string `json:"channel_id"`
+	TriggerID   string `json:"trigger_id"`
+	ResponseURL string `json:"response_url"`
+}
+
+// webAPI is a minimal client of the Slack Web API and the Socket Mode, covering the parts that the bot needs.
+type webAPI struct {
+	url        string
+	appToken   string
+	httpClient *http.Client
+	dialer     *websocket.Dialer
+}
+
+func newWebAPI(apiURL, appToken string) *webAPI {
+	return &webAPI{
+		url:        apiURL,
+		appToken:   appToken,
+		httpClient: &http.Client{Timeout: apiTimeout},
+		dialer:     websocket.DefaultDialer,
+	}
+}
+
+// openConnection returns the URL of a new Socket Mode connection.
+func (api *webAPI) openConnection(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, api.url+"/apps.connections.open", http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+api.appToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	result := struct {
+		OK    bool   `json:"ok"`
+		URL   string `json:"url"`
+		Error string `json:"error"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("slack apps.connections.open: %s: %w", resp.Status, err)
+	}
+
+	if !result.OK {
+		return "", fmt.Errorf("slack apps.connections.open: %s", result.Error)
+	}
+
+	return result.URL, nil
+}
+
+// connect opens a Socket Mode connection.
+func (api *webAPI) connect(ctx context.Context) (*websocket.Conn, error) {
+	wsURL, err := api.openConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, resp, err := api.dialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("slack socket mode: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	return conn, nil
+}
+
+// respond sends the message to the response URL of the slash command.
+func (api *webAPI) respond(ctx context.Context, responseURL string, msg *message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack response: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// ack acknowledges the envelope, Slack retries the envelopes that are not acknowledged in 3 seconds.
+func ack(conn *websocket.Conn, envelopeID string) error {
+	if envelopeID == "" {
+		return errors.New("the envelope has no ID")
+	}
+
+	return conn.WriteJSON(map[string]string{"envelope_id": envelopeID})
+}
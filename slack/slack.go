@@ -0,0 +1,225 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+const (
+	// callerPrefix keeps the Slack users apart from the other apps in the engine,
+	// so the authorized IDs of Slack users are like "slack:U012AB3CD".
+	callerPrefix = "slack:"
+
+	// slashCommandName is the slash command of the bot, like "/robopac node-info pc1p...".
+	slashCommandName = "/robopac"
+
+	reconnectDelay = 5 * time.Second
+	replyTimeout   = 10 * time.Second
+)
+
+type SlackBot struct {
+	BotEngine *engine.BotEngine
+
+	api    *webAPI
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewSlackBot(botEngine *engine.BotEngine, cfg config.SlackBotConfig) (*SlackBot, error) {
+	if cfg.AppToken == "" {
+		return nil, errors.New("slack app token is not set")
+	}
+
+	return &SlackBot{
+		BotEngine: botEngine,
+		api:       newWebAPI(defaultAPIURL, cfg.AppToken),
+	}, nil
+}
+
+// Start connects by the Socket Mode and handles the slash commands, until the context is canceled
+// or the bot is stopped.
+func (bot *SlackBot) Start(ctx context.Context) error {
+	log.Info("starting Slack Bot...")
+
+	bot.ctx, bot.cancel = context.WithCancel(ctx)
+
+	// the first connection is checked, so the bad tokens fail the start.
+	conn, err := bot.api.connect(bot.ctx)
+	if err != nil {
+		bot.cancel()
+
+		return err
+	}
+
+	bot.wg.Add(1)
+	go func() {
+		defer bot.wg.Done()
+		bot.listen(conn)
+	}()
+
+	return nil
+}
+
+// listen reads the envelopes of the connection, and reconnects when Slack closes it, until the bot is stopped.
+func (bot *SlackBot) listen(conn *websocket.Conn) {
+	for {
+		bot.serve(conn)
+		if bot.ctx.Err() != nil {
+			return
+		}
+
+		for {
+			var err error
+			conn, err = bot.api.connect(bot.ctx)
+			if err == nil {
+				break
+			}
+
+			log.Error("can't connect to slack", "error", err)
+			select {
+			case <-bot.ctx.Done():
+				return
+			case <-time.After(reconnectDelay):
+			}
+		}
+	}
+}
+
+// serve handles the envelopes of the connection until it's closed, or Slack asks to reconnect.
+func (bot *SlackBot) serve(conn *websocket.Conn) {
+	defer conn.Close()
+
+	// the blocking read is ended by closing the connection, when the bot is stopped.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-bot.ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		var env envelope
+		if err := conn.ReadJSON(&env); err != nil {
+			if bot.ctx.Err() == nil {
+				log.Warn("slack connection closed", "error", err)
+			}
+
+			return
+		}
+
+		switch env.Type {
+		case envelopeHello:
+			log.Info("slack connected")
+
+		case envelopeDisconnect:
+			log.Info("slack asked to reconnect")
+
+			return
+
+		case envelopeSlashCommands:
+			if err := ack(conn, env.EnvelopeID); err != nil {
+				log.Error("can't acknowledge slack envelope", "error", err)
+
+				return
+			}
+
+			var cmd slashCommand
+			if err := json.Unmarshal(env.Payload, &cmd); err != nil {
+				log.Warn("invalid slack slash command", "error", err)
+
+				continue
+			}
+
+			bot.wg.Add(1)
+			go func() {
+				defer bot.wg.Done()
+				bot.handleCommand(env.EnvelopeID, cmd)
+			}()
+
+		default:
+			if env.EnvelopeID != "" {
+				_ = ack(conn, env.EnvelopeID)
+			}
+		}
+	}
+}
+
+func (bot *SlackBot) handleCommand(reqID string, cmd slashCommand) {
+	inputs := parseCommand(cmd.Text)
+	cmdName := inputs[0]
+	callerID := callerPrefix + cmd.UserID
+	msgs := bot.BotEngine.MessagesFor(callerID)
+
+	log.Debug("slack command", "requestID", reqID, "command", cmdName, "by", callerID)
+
+	if engineCmd := bot.BotEngine.FindCommand(cmdName); engineCmd != nil && engineCmd.ConfirmPhrase != "" {
+		bot.respond(cmd.ResponseURL, errorMessage(msgs,
+			"`"+cmdName+"` needs a confirmation and is not available on Slack"))
+
+		return
+	}
+
+	res, err := bot.BotEngine.RunWithOptions(engine.RunOptions{RequestID: reqID}, engine.AppIdSlack, callerID, inputs)
+	if err != nil {
+		log.Warn("slack command failed", "requestID", reqID, "command", cmdName, "error", err)
+		bot.respond(cmd.ResponseURL, errorMessage(msgs, err.Error()))
+
+		return
+	}
+
+	bot.respond(cmd.ResponseURL, resultMessage(cmdName, res, msgs))
+}
+
+// respond posts the message to the response URL of the slash command, which Slack keeps valid for a while,
+// so the reply is posted even if the bot is stopping.
+func (bot *SlackBot) respond(responseURL string, msg *message) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(bot.ctx), replyTimeout)
+	defer cancel()
+
+	if err := bot.api.respond(ctx, responseURL, msg); err != nil {
+		log.Error("can't send slack message", "error", err)
+	}
+}
+
+func (*SlackBot) Name() string {
+	return "slack"
+}
+
+func (*SlackBot) AppID() engine.AppID {
+	return engine.AppIdSlack
+}
+
+// Stop closes the connection and waits for the handling commands to be answered.
+func (bot *SlackBot) Stop() {
+	log.Info("shutting down Slack Bot...")
+
+	if bot.cancel != nil {
+		bot.cancel()
+	}
+	bot.wg.Wait()
+}
+
+// parseCommand parses the text of the slash command, like "node-info pc1p...", into the engine inputs.
+// The empty text shows the help.
+func parseCommand(text string) []string {
+	inputs := strings.Fields(text)
+	if len(inputs) == 0 {
+		return []string{engine.HelpCommandName}
+	}
+	inputs[0] = strings.ToLower(inputs[0])
+
+	return inputs
+}
@@ -0,0 +1,139 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	assert.Equal(t, []string{"node-info", "pc1pabc"}, parseCommand(" Node-Info  pc1pabc"))
+	assert.Equal(t, []string{engine.HelpCommandName}, parseCommand(""))
+}
+
+func TestResultMessage(t *testing.T) {
+	messages := engine.NewMessageCatalog()
+
+	res := engine.MakeSuccessfulResult("height `100`")
+	res.AddWarning("deprecated")
+	res.AddField("Peers", "10", true)
+	res.List = &engine.ListResult{Total: 25, Page: 2, PageSize: 10}
+	res.Suggest("node-info")
+
+	msg := resultMessage(engine.NetworkStatusCommandName, res, messages)
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"response_type": "in_channel",
+		"text": "Successful",
+		"blocks": [
+			{"type": "header", "text": {"type": "plain_text", "text": "Successful"}},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "⚠️ deprecated\n\nheight `+"`100`"+`"}},
+			{"type": "section", "fields": [{"type": "mrkdwn", "text": "*Peers*\n10"}]},
+			{"type": "context", "elements": [{"type": "mrkdwn",
+				"text": "Page 2/3 (25 items) • Try next: `+"`/robopac node-info`"+`"}]}
+		]
+	}`, string(data))
+
+	t.Run("table and many fields", func(t *testing.T) {
+		res := engine.MakeSuccessfulResult("stakes")
+		res.Title = "Validators"
+		res.SetTable("Address", "Stake")
+		res.AddRow("pc1p", "10")
+		for i := 0; i < 12; i++ {
+			res.AddField("Field", "value", true)
+		}
+
+		msg := resultMessage(engine.TopValidatorsCommandName, res, messages)
+		require.Len(t, msg.Blocks, 5)
+		assert.Equal(t, "Validators", msg.Blocks[0].Text.Text)
+		assert.Equal(t, "```\nAddress  Stake\n-------  -----\npc1p     10\n```", msg.Blocks[2].Text.Text)
+		assert.Len(t, msg.Blocks[3].Fields, 10)
+		assert.Len(t, msg.Blocks[4].Fields, 2)
+	})
+
+	t.Run("failed result", func(t *testing.T) {
+		msg := resultMessage(engine.NetworkStatusCommandName,
+			engine.MakeFailedResult(strings.Repeat("x", 4000)), messages)
+		assert.Equal(t, responseEphemeral, msg.ResponseType)
+		assert.Equal(t, "Failed", msg.Text)
+		assert.Len(t, []rune(msg.Blocks[1].Text.Text), maxTextLength)
+	})
+
+	t.Run("private command", func(t *testing.T) {
+		msg := resultMessage(engine.MyBalanceCommandName, engine.MakeSuccessfulResult("10 PAC"), messages)
+		assert.Equal(t, responseEphemeral, msg.ResponseType)
+	})
+
+	errMsg := errorMessage(messages, "")
+	assert.Equal(t, responseEphemeral, errMsg.ResponseType)
+	assert.Equal(t, "Error: Something went wrong, please try again later", errMsg.Text)
+}
+
+func TestWebAPI(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	acked := make(chan string, 1)
+	var responded message
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/apps.connections.open", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xapp-token" {
+			_, _ = w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"url":"ws` + strings.TrimPrefix(server.URL, "http") + `/socket"}`))
+	})
+	mux.HandleFunc("/socket", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteJSON(map[string]string{"type": envelopeHello}))
+
+		ackMsg := map[string]string{}
+		require.NoError(t, conn.ReadJSON(&ackMsg))
+		acked <- ackMsg["envelope_id"]
+	})
+	mux.HandleFunc("/response", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&responded))
+	})
+
+	t.Run("socket mode", func(t *testing.T) {
+		api := newWebAPI(server.URL, "xapp-token")
+		conn, err := api.connect(context.Background())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var env envelope
+		require.NoError(t, conn.ReadJSON(&env))
+		assert.Equal(t, envelopeHello, env.Type)
+
+		require.NoError(t, ack(conn, "env-1"))
+		assert.Equal(t, "env-1", <-acked)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		api := newWebAPI(server.URL, "xapp-wrong")
+		_, err := api.connect(context.Background())
+		assert.ErrorContains(t, err, "invalid_auth")
+	})
+
+	t.Run("respond", func(t *testing.T) {
+		api := newWebAPI(server.URL, "xapp-token")
+		err := api.respond(context.Background(), server.URL+"/response", &message{Text: "hi"})
+		require.NoError(t, err)
+		assert.Equal(t, "hi", responded.Text)
+	})
+}
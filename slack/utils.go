@@ -0,0 +1,180 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kehiy/RoboPac/engine"
+)
+
+const (
+	responseInChannel = "in_channel"
+	responseEphemeral = "ephemeral"
+
+	// The limits of the Block Kit.
+	maxHeaderLength  = 150
+	maxTextLength    = 3000
+	maxSectionFields = 10
+)
+
+// textObject is a text of a block, plain_text or mrkdwn.
+type textObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func plainText(text string) *textObject {
+	return &textObject{Type: "plain_text", Text: truncate(text, maxHeaderLength)}
+}
+
+func mrkdwn(text string) *textObject {
+	return &textObject{Type: "mrkdwn", Text: truncate(text, maxTextLength)}
+}
+
+// block is a Block Kit block, only the fields of its type are set.
+type block struct {
+	Type     string        `json:"type"`
+	Text     *textObject   `json:"text,omitempty"`
+	Fields   []*textObject `json:"fields,omitempty"`
+	Elements []*textObject `json:"elements,omitempty"`
+}
+
+// message is a Slack message, the text is shown in the notifications and by the clients without the blocks.
+type message struct {
+	ResponseType string  `json:"response_type,omitempty"`
+	Text         string  `json:"text"`
+	Blocks       []block `json:"blocks,omitempty"`
+}
+
+// truncate cuts the text to the limit of characters, with an ellipsis.
+func truncate(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	return string([]rune(text)[:limit-1]) + "…"
+}
+
+// publicCommands are the commands whose successful results are shown in the channel,
+// they don't reveal anything about the caller. The other results are shown to the caller only.
+var publicCommands = map[string]bool{
+	engine.HelpCommandName:          true,
+	engine.NetworkStatusCommandName: true,
+	engine.NetworkHealthCommandName: true,
+	engine.CommitteeCommandName:     true,
+	engine.TopValidatorsCommandName: true,
+	engine.FeeCommandName:           true,
+	engine.PriceCommandName:         true,
+}
+
+// resultMessage renders the result of a command as a Block Kit message. Only the successful results
+// of the public commands are shown in the channel.
+func resultMessage(cmdName string, res *engine.CommandResult, messages *engine.MessageCatalog) *message {
+	var title string
+	switch {
+	case res.Maintenance:
+		title = "🔧 " + messages.Get(engine.MsgTitleMaintenance)
+	case res.Successful:
+		title = messages.Get(engine.MsgTitleSuccessful)
+	default:
+		title = messages.Get(engine.MsgTitleFailed)
+	}
+
+	if res.Title != "" && !res.Maintenance {
+		title = res.Title
+	}
+
+	msg := &message{
+		ResponseType: responseEphemeral,
+		Text:         title,
+		Blocks:       []block{{Type: "header", Text: plainText(title)}},
+	}
+	if res.Successful && publicCommands[cmdName] {
+		msg.ResponseType = responseInChannel
+	}
+
+	text := ""
+	for _, w := range res.Warnings {
+		text += fmt.Sprintf("⚠️ %s\n", w)
+	}
+	if text != "" {
+		text += "\n"
+	}
+	text += res.Message
+	if text != "" {
+		msg.Blocks = append(msg.Blocks, block{Type: "section", Text: mrkdwn(text)})
+	}
+
+	if res.Table != nil {
+		msg.Blocks = append(msg.Blocks, block{Type: "section", Text: mrkdwn(codeBlock(res.Table.String()))})
+	}
+
+	// the fields are split into the sections of at most ten fields.
+	for i := 0; i < len(res.Fields); i += maxSectionFields {
+		section := block{Type: "section"}
+		for _, f := range res.Fields[i:min(i+maxSectionFields, len(res.Fields))] {
+			section.Fields = append(section.Fields, mrkdwn(fmt.Sprintf("*%s*\n%s", f.Name, f.Value)))
+		}
+		msg.Blocks = append(msg.Blocks, section)
+	}
+
+	footer := []string{}
+	if res.List != nil {
+		footer = append(footer, res.List.Footer())
+	}
+	if len(res.Suggestions) > 0 {
+		cmds := make([]string, 0, len(res.Suggestions))
+		for _, s := range res.Suggestions {
+			cmds = append(cmds, "`"+slashCommandName+" "+s+"`")
+		}
+		footer = append(footer, "Try next: "+strings.Join(cmds, ", "))
+	}
+	if len(footer) > 0 {
+		msg.Blocks = append(msg.Blocks, block{
+			Type:     "context",
+			Elements: []*textObject{mrkdwn(strings.Join(footer, " • "))},
+		})
+	}
+
+	return msg
+}
+
+// codeBlock wraps the text in a code block that fits in a section.
+// The lines that don't fit are dropped, and it's noted at the end of the block.
+func codeBlock(text string) string {
+	const fence = "```"
+
+	lines := strings.Split(text, "\n")
+	for kept := len(lines); kept > 0; kept-- {
+		body := strings.Join(lines[:kept], "\n")
+		if kept < len(lines) {
+			body += fmt.Sprintf("\n… %d more lines", len(lines)-kept)
+		}
+
+		block := fence + "\n" + body + "\n" + fence
+		if utf8.RuneCountInString(block) <= maxTextLength {
+			return block
+		}
+	}
+
+	return ""
+}
+
+// errorMessage renders the error, it's shown to the caller only.
+func errorMessage(messages *engine.MessageCatalog, errStr string) *message {
+	if errStr == "" {
+		errStr = messages.Get(engine.MsgErrorFallback)
+	}
+
+	title := messages.Get(engine.MsgTitleError)
+
+	return &message{
+		ResponseType: responseEphemeral,
+		Text:         title + ": " + errStr,
+		Blocks: []block{
+			{Type: "header", Text: plainText(title)},
+			{Type: "section", Text: mrkdwn(errStr)},
+		},
+	}
+}
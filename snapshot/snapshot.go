@@ -0,0 +1,187 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/log"
+	"github.com/kehiy/RoboPac/store"
+	"github.com/pactus-project/pactus/util"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+)
+
+// DefaultRetention keeps the snapshots of the longest chart period.
+const DefaultRetention = 90 * 24 * time.Hour
+
+// The metrics of the network that are recorded.
+const (
+	MetricHeight     = "height"
+	MetricSupply     = "supply"
+	MetricValidators = "validators"
+	MetricPower      = "power"
+)
+
+// Metrics returns the metrics of the network that are recorded.
+func Metrics() []string {
+	return []string{MetricHeight, MetricSupply, MetricValidators, MetricPower}
+}
+
+// Snapshot is the state of the network at a point in time, the amounts are in NanoPAC.
+type Snapshot struct {
+	Time       int64  `json:"time"`
+	Height     uint32 `json:"height"`
+	Supply     int64  `json:"supply"`
+	Validators int32  `json:"validators"`
+	Power      int64  `json:"power"`
+}
+
+// Value returns the value of the metric, the amounts are in PAC.
+func (s Snapshot) Value(metric string) float64 {
+	switch metric {
+	case MetricHeight:
+		return float64(s.Height)
+	case MetricSupply:
+		return util.ChangeToCoin(s.Supply)
+	case MetricValidators:
+		return float64(s.Validators)
+	case MetricPower:
+		return util.ChangeToCoin(s.Power)
+	default:
+		return 0
+	}
+}
+
+type networkSource interface {
+	GetBlockchainInfo() (*pactus.GetBlockchainInfoResponse, error)
+	GetCirculatingSupply() (int64, error)
+}
+
+// Recorder records the snapshots of the network in the storage, keyed by their time.
+// The snapshots older than the retention are removed.
+type Recorder struct {
+	lk sync.Mutex
+
+	source    networkSource
+	kv        store.KV
+	retention time.Duration
+	nowFunc   func() time.Time
+}
+
+// NewRecorder creates a recorder that keeps the snapshots in the bucket.
+func NewRecorder(source networkSource, kv store.KV) *Recorder {
+	return &Recorder{
+		source:    source,
+		kv:        kv,
+		retention: DefaultRetention,
+		nowFunc:   time.Now,
+	}
+}
+
+// Record takes a snapshot of the network and keeps it. It's called periodically by the engine scheduler.
+// The supply is left zero if it's not available.
+func (r *Recorder) Record() error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	info, err := r.source.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+
+	supply, err := r.source.GetCirculatingSupply()
+	if err != nil {
+		log.Warn("unable to get the circulating supply for the snapshot", "err", err)
+		supply = 0
+	}
+
+	now := r.nowFunc()
+	snapshot := Snapshot{
+		Time:       now.Unix(),
+		Height:     info.LastBlockHeight,
+		Supply:     supply,
+		Validators: info.TotalValidators,
+		Power:      info.TotalPower,
+	}
+
+	if err := r.save(snapshot); err != nil {
+		return err
+	}
+
+	return r.prune(now.Add(-r.retention))
+}
+
+// Save keeps the snapshot as it is, replacing the one at the same time.
+func (r *Recorder) Save(snapshot Snapshot) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return r.save(snapshot)
+}
+
+func (r *Recorder) save(snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	return r.kv.Set(snapshotKey(snapshot.Time), data)
+}
+
+// prune removes the snapshots before the time.
+func (r *Recorder) prune(before time.Time) error {
+	expired := []string{}
+	err := r.kv.Iterate(func(key string, _ []byte) bool {
+		if key >= snapshotKey(before.Unix()) {
+			return false
+		}
+		expired = append(expired, key)
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range expired {
+		if err := r.kv.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Since returns the snapshots from the time, the oldest first.
+func (r *Recorder) Since(from time.Time) ([]Snapshot, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	snapshots := []Snapshot{}
+	err := r.kv.Iterate(func(key string, value []byte) bool {
+		if key < snapshotKey(from.Unix()) {
+			return true
+		}
+
+		snapshot := Snapshot{}
+		if err := json.Unmarshal(value, &snapshot); err != nil {
+			log.Warn("unable to load the network snapshot", "err", err, "key", key)
+
+			return true
+		}
+		snapshots = append(snapshots, snapshot)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// snapshotKey pads the time, so the keys are sorted by the times.
+func snapshotKey(sec int64) string {
+	return fmt.Sprintf("%020d", sec)
+}
@@ -0,0 +1,93 @@
+package snapshot
+
+import (
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kehiy/RoboPac/store"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	info      *pactus.GetBlockchainInfoResponse
+	supply    int64
+	supplyErr error
+}
+
+func (s *fakeSource) GetBlockchainInfo() (*pactus.GetBlockchainInfoResponse, error) {
+	return s.info, nil
+}
+
+func (s *fakeSource) GetCirculatingSupply() (int64, error) {
+	return s.supply, s.supplyErr
+}
+
+func setup(t *testing.T) (*Recorder, *fakeSource, *time.Time) {
+	t.Helper()
+
+	kv, err := store.NewJSONKV(path.Join(t.TempDir(), "network_snapshots.json"))
+	require.NoError(t, err)
+
+	source := &fakeSource{
+		info: &pactus.GetBlockchainInfoResponse{
+			LastBlockHeight: 1000,
+			TotalValidators: 50,
+			TotalPower:      1_000_000e9,
+		},
+		supply: 20_000_000e9,
+	}
+
+	now := time.Unix(1700000000, 0)
+	r := NewRecorder(source, kv)
+	r.nowFunc = func() time.Time {
+		return now
+	}
+
+	return r, source, &now
+}
+
+func TestRecord(t *testing.T) {
+	r, source, now := setup(t)
+	start := *now
+
+	require.NoError(t, r.Record())
+
+	*now = now.Add(10 * time.Minute)
+	source.info.LastBlockHeight = 1060
+	source.supplyErr = errors.New("unavailable")
+	require.NoError(t, r.Record())
+
+	snapshots, err := r.Since(start)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, uint32(1000), snapshots[0].Height)
+	assert.Equal(t, uint32(1060), snapshots[1].Height)
+	assert.Zero(t, snapshots[1].Supply, "the supply is zero when it's not available")
+	assert.InDelta(t, 20_000_000, snapshots[0].Value(MetricSupply), 1e-9)
+	assert.InDelta(t, 1_000_000, snapshots[0].Value(MetricPower), 1e-9)
+	assert.InDelta(t, 50, snapshots[0].Value(MetricValidators), 1e-9)
+
+	snapshots, err = r.Since(start.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+}
+
+func TestRetention(t *testing.T) {
+	r, _, now := setup(t)
+	start := *now
+
+	require.NoError(t, r.Record())
+	*now = now.Add(DefaultRetention)
+	require.NoError(t, r.Record())
+	*now = now.Add(time.Second)
+	require.NoError(t, r.Record())
+
+	snapshots, err := r.Since(start)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2, "the first snapshot is expired")
+	assert.Equal(t, start.Add(DefaultRetention).Unix(), snapshots[0].Time)
+}
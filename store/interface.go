@@ -3,6 +3,8 @@ package store
 type IStore interface {
 	ClaimerInfo(testNetValAddr string) *Claimer
 	AddClaimTransaction(testNetValAddr string, txID string) error
+	AddClaimRequest(testNetValAddr, mainNetValAddr, mainNetPubKey string, requestedAt int64) error
+	PendingClaims() map[string]*Claimer
 	ClaimStatus() *ClaimStatus
 
 	SaveTwitterParty(party *TwitterParty) error
@@ -11,4 +13,12 @@ type IStore interface {
 	WhitelistTwitterAccount(twitterID, twitterName, authorizedDiscordID string) error
 	IsWhitelisted(twitterID string) bool
 	BoosterStatus() *BoosterStatus
+
+	UserPrefs(discordID string) *UserPrefs
+	SaveUserPrefs(prefs *UserPrefs) error
+
+	FaucetClaim(key string) *FaucetClaim
+	SaveFaucetClaim(claim *FaucetClaim) error
+
+	Close() error
 }
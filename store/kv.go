@@ -0,0 +1,83 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+)
+
+const (
+	BackendJSON   = "json"
+	BackendSQLite = "sqlite"
+)
+
+var ErrNotFound = errors.New("key not found")
+
+// KV is a persistent key-value storage, shared by the subsystems that keep their own records.
+// The values are opaque to the storage, see GetJSON and SetJSON for the typed access.
+type KV interface {
+	// Get returns ErrNotFound if the key doesn't exist.
+	Get(key string) ([]byte, error)
+	Set(key string, value []byte) error
+	// Delete doesn't fail if the key doesn't exist.
+	Delete(key string) error
+	// Iterate calls fn for all the entries in the order of the keys, until fn returns false.
+	Iterate(fn func(key string, value []byte) bool) error
+	Close() error
+}
+
+// OpenKV opens the named bucket of the storage at storePath with the backend.
+// The JSON backend keeps each bucket in its own file, like "user_prefs.json".
+// The SQLite backend keeps all the buckets in the "kv.db" file.
+func OpenKV(backend, storePath, bucket string) (KV, error) {
+	switch backend {
+	case "", BackendJSON:
+		return NewJSONKV(path.Join(storePath, bucket+".json"))
+	case BackendSQLite:
+		return NewSQLiteKV(path.Join(storePath, "kv.db"), bucket)
+	default:
+		return nil, fmt.Errorf("unknown store backend: %s", backend)
+	}
+}
+
+// GetJSON decodes the value of the key, it returns nil if the key doesn't exist.
+func GetJSON[T any](kv KV, key string) (*T, error) {
+	data, err := kv.Get(key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	obj := new(T)
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// SetJSON encodes the object as the value of the key.
+func SetJSON[T any](kv KV, key string, obj *T) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	return kv.Set(key, data)
+}
+
+// IterateJSON calls fn for the decoded values of all the entries, the malformed values are skipped.
+func IterateJSON[T any](kv KV, fn func(key string, obj *T)) error {
+	return kv.Iterate(func(key string, data []byte) bool {
+		obj := new(T)
+		if err := json.Unmarshal(data, obj); err == nil {
+			fn(key, obj)
+		}
+
+		return true
+	})
+}
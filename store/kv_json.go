@@ -0,0 +1,117 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+)
+
+// jsonKV keeps the entries in memory and writes the whole file on each change.
+// The file is a JSON object, so it stays readable and editable by hand,
+// and the values must be valid JSON.
+type jsonKV struct {
+	lk      sync.RWMutex
+	path    string
+	entries map[string]json.RawMessage
+}
+
+// NewJSONKV opens the key-value file at path. The file is created on the first change.
+func NewJSONKV(path string) (KV, error) {
+	kv := &jsonKV{
+		path:    path,
+		entries: make(map[string]json.RawMessage),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return kv, nil
+		}
+
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &kv.entries); err != nil {
+			return nil, err
+		}
+	}
+
+	return kv, nil
+}
+
+func (kv *jsonKV) Get(key string) ([]byte, error) {
+	kv.lk.RLock()
+	defer kv.lk.RUnlock()
+
+	value, ok := kv.entries[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return slices.Clone(value), nil
+}
+
+func (kv *jsonKV) Set(key string, value []byte) error {
+	if !json.Valid(value) {
+		return fmt.Errorf("the value of %s is not a valid JSON", key)
+	}
+
+	kv.lk.Lock()
+	defer kv.lk.Unlock()
+
+	kv.entries[key] = slices.Clone(value)
+
+	return kv.save()
+}
+
+func (kv *jsonKV) Delete(key string) error {
+	kv.lk.Lock()
+	defer kv.lk.Unlock()
+
+	if _, ok := kv.entries[key]; !ok {
+		return nil
+	}
+	delete(kv.entries, key)
+
+	return kv.save()
+}
+
+func (kv *jsonKV) Iterate(fn func(key string, value []byte) bool) error {
+	kv.lk.RLock()
+	keys := make([]string, 0, len(kv.entries))
+	for key := range kv.entries {
+		keys = append(keys, key)
+	}
+	kv.lk.RUnlock()
+
+	slices.Sort(keys)
+	for _, key := range keys {
+		value, err := kv.Get(key)
+		if err != nil {
+			// deleted while iterating.
+			continue
+		}
+
+		if !fn(key, value) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (kv *jsonKV) Close() error {
+	return nil
+}
+
+func (kv *jsonKV) save() error {
+	data, err := json.Marshal(kv.entries)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(kv.path, data, 0o600)
+}
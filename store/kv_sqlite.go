@@ -0,0 +1,101 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// kvEntry is a row of the SQLite backend, the buckets share the same table.
+type kvEntry struct {
+	Bucket string `gorm:"primaryKey"`
+	Key    string `gorm:"primaryKey"`
+	Value  []byte
+}
+
+func (kvEntry) TableName() string {
+	return "kv_entries"
+}
+
+type sqliteKV struct {
+	db     *gorm.DB
+	bucket string
+}
+
+// NewSQLiteKV opens the bucket of the SQLite database at path, the database is created if it doesn't exist.
+func NewSQLiteKV(path, bucket string) (KV, error) {
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		return nil, errors.New("can't open kv database")
+	}
+
+	if err := db.AutoMigrate(&kvEntry{}); err != nil {
+		return nil, errors.New("can't auto migrate kv table")
+	}
+
+	return &sqliteKV{
+		db:     db,
+		bucket: bucket,
+	}, nil
+}
+
+func (kv *sqliteKV) Get(key string) ([]byte, error) {
+	var entry kvEntry
+	err := kv.db.First(&entry, "bucket = ? AND key = ?", kv.bucket, key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return entry.Value, nil
+}
+
+func (kv *sqliteKV) Set(key string, value []byte) error {
+	return kv.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&kvEntry{
+		Bucket: kv.bucket,
+		Key:    key,
+		Value:  value,
+	}).Error
+}
+
+func (kv *sqliteKV) Delete(key string) error {
+	return kv.db.Delete(&kvEntry{}, "bucket = ? AND key = ?", kv.bucket, key).Error
+}
+
+func (kv *sqliteKV) Iterate(fn func(key string, value []byte) bool) error {
+	rows, err := kv.db.Model(&kvEntry{}).
+		Where("bucket = ?", kv.bucket).
+		Order("key").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry kvEntry
+		if err := kv.db.ScanRows(rows, &entry); err != nil {
+			return err
+		}
+
+		if !fn(entry.Key, entry.Value) {
+			return nil
+		}
+	}
+
+	return rows.Err()
+}
+
+func (kv *sqliteKV) Close() error {
+	sqlDB, err := kv.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
@@ -0,0 +1,127 @@
+package store_test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/kehiy/RoboPac/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKV(t *testing.T) {
+	for _, backend := range []string{store.BackendJSON, store.BackendSQLite} {
+		t.Run(backend, func(t *testing.T) {
+			storePath := t.TempDir()
+
+			kv, err := store.OpenKV(backend, storePath, "test")
+			require.NoError(t, err)
+
+			t.Run("not found", func(t *testing.T) {
+				_, err := kv.Get("a")
+				assert.ErrorIs(t, err, store.ErrNotFound)
+			})
+
+			t.Run("set and get", func(t *testing.T) {
+				require.NoError(t, kv.Set("b", []byte(`{"n":2}`)))
+				require.NoError(t, kv.Set("a", []byte(`{"n":1}`)))
+				require.NoError(t, kv.Set("a", []byte(`{"n":3}`)))
+
+				value, err := kv.Get("a")
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"n":3}`, string(value))
+			})
+
+			t.Run("iterate", func(t *testing.T) {
+				keys := []string{}
+				err := kv.Iterate(func(key string, _ []byte) bool {
+					keys = append(keys, key)
+
+					return true
+				})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"a", "b"}, keys)
+
+				keys = []string{}
+				err = kv.Iterate(func(key string, _ []byte) bool {
+					keys = append(keys, key)
+
+					return false
+				})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"a"}, keys)
+			})
+
+			t.Run("buckets are separated", func(t *testing.T) {
+				other, err := store.OpenKV(backend, storePath, "other")
+				require.NoError(t, err)
+				defer other.Close()
+
+				_, err = other.Get("a")
+				assert.ErrorIs(t, err, store.ErrNotFound)
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				require.NoError(t, kv.Delete("b"))
+				require.NoError(t, kv.Delete("unknown"))
+
+				_, err := kv.Get("b")
+				assert.ErrorIs(t, err, store.ErrNotFound)
+			})
+
+			t.Run("reopen", func(t *testing.T) {
+				require.NoError(t, kv.Close())
+
+				kv, err = store.OpenKV(backend, storePath, "test")
+				require.NoError(t, err)
+				defer kv.Close()
+
+				value, err := kv.Get("a")
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"n":3}`, string(value))
+
+				_, err = kv.Get("b")
+				assert.ErrorIs(t, err, store.ErrNotFound)
+			})
+		})
+	}
+}
+
+func TestJSONKVInvalidValue(t *testing.T) {
+	kv, err := store.NewJSONKV(path.Join(t.TempDir(), "test.json"))
+	require.NoError(t, err)
+
+	assert.Error(t, kv.Set("a", []byte("not json")))
+}
+
+func TestOpenKVUnknownBackend(t *testing.T) {
+	_, err := store.OpenKV("bolt", t.TempDir(), "test")
+	assert.Error(t, err)
+}
+
+func TestTypedKV(t *testing.T) {
+	type value struct {
+		N int `json:"n"`
+	}
+
+	kv, err := store.NewJSONKV(path.Join(t.TempDir(), "test.json"))
+	require.NoError(t, err)
+
+	missing, err := store.GetJSON[value](kv, "a")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	require.NoError(t, store.SetJSON(kv, "a", &value{N: 1}))
+	require.NoError(t, store.SetJSON(kv, "b", &value{N: 2}))
+	require.NoError(t, kv.Set("c", []byte(`"malformed"`)))
+
+	got, err := store.GetJSON[value](kv, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.N)
+
+	sum := 0
+	require.NoError(t, store.IterateJSON(kv, func(_ string, v *value) {
+		sum += v.N
+	}))
+	assert.Equal(t, 3, sum, "the malformed values are skipped")
+}
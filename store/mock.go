@@ -38,6 +38,20 @@ func (m *MockIStore) EXPECT() *MockIStoreMockRecorder {
 	return m.recorder
 }
 
+// AddClaimRequest mocks base method.
+func (m *MockIStore) AddClaimRequest(testNetValAddr, mainNetValAddr, mainNetPubKey string, requestedAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddClaimRequest", testNetValAddr, mainNetValAddr, mainNetPubKey, requestedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddClaimRequest indicates an expected call of AddClaimRequest.
+func (mr *MockIStoreMockRecorder) AddClaimRequest(testNetValAddr, mainNetValAddr, mainNetPubKey, requestedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddClaimRequest", reflect.TypeOf((*MockIStore)(nil).AddClaimRequest), testNetValAddr, mainNetValAddr, mainNetPubKey, requestedAt)
+}
+
 // AddClaimTransaction mocks base method.
 func (m *MockIStore) AddClaimTransaction(testNetValAddr, txID string) error {
 	m.ctrl.T.Helper()
@@ -94,6 +108,34 @@ func (mr *MockIStoreMockRecorder) ClaimerInfo(testNetValAddr any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimerInfo", reflect.TypeOf((*MockIStore)(nil).ClaimerInfo), testNetValAddr)
 }
 
+// Close mocks base method.
+func (m *MockIStore) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIStore)(nil).Close))
+}
+
+// FaucetClaim mocks base method.
+func (m *MockIStore) FaucetClaim(key string) *FaucetClaim {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FaucetClaim", key)
+	ret0, _ := ret[0].(*FaucetClaim)
+	return ret0
+}
+
+// FaucetClaim indicates an expected call of FaucetClaim.
+func (mr *MockIStoreMockRecorder) FaucetClaim(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FaucetClaim", reflect.TypeOf((*MockIStore)(nil).FaucetClaim), key)
+}
+
 // FindTwitterParty mocks base method.
 func (m *MockIStore) FindTwitterParty(twitterName string) *TwitterParty {
 	m.ctrl.T.Helper()
@@ -122,6 +164,34 @@ func (mr *MockIStoreMockRecorder) IsWhitelisted(twitterID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWhitelisted", reflect.TypeOf((*MockIStore)(nil).IsWhitelisted), twitterID)
 }
 
+// PendingClaims mocks base method.
+func (m *MockIStore) PendingClaims() map[string]*Claimer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingClaims")
+	ret0, _ := ret[0].(map[string]*Claimer)
+	return ret0
+}
+
+// PendingClaims indicates an expected call of PendingClaims.
+func (mr *MockIStoreMockRecorder) PendingClaims() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingClaims", reflect.TypeOf((*MockIStore)(nil).PendingClaims))
+}
+
+// SaveFaucetClaim mocks base method.
+func (m *MockIStore) SaveFaucetClaim(claim *FaucetClaim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFaucetClaim", claim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFaucetClaim indicates an expected call of SaveFaucetClaim.
+func (mr *MockIStoreMockRecorder) SaveFaucetClaim(claim any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFaucetClaim", reflect.TypeOf((*MockIStore)(nil).SaveFaucetClaim), claim)
+}
+
 // SaveTwitterParty mocks base method.
 func (m *MockIStore) SaveTwitterParty(party *TwitterParty) error {
 	m.ctrl.T.Helper()
@@ -136,6 +206,34 @@ func (mr *MockIStoreMockRecorder) SaveTwitterParty(party any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTwitterParty", reflect.TypeOf((*MockIStore)(nil).SaveTwitterParty), party)
 }
 
+// SaveUserPrefs mocks base method.
+func (m *MockIStore) SaveUserPrefs(prefs *UserPrefs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserPrefs", prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserPrefs indicates an expected call of SaveUserPrefs.
+func (mr *MockIStoreMockRecorder) SaveUserPrefs(prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserPrefs", reflect.TypeOf((*MockIStore)(nil).SaveUserPrefs), prefs)
+}
+
+// UserPrefs mocks base method.
+func (m *MockIStore) UserPrefs(discordID string) *UserPrefs {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserPrefs", discordID)
+	ret0, _ := ret[0].(*UserPrefs)
+	return ret0
+}
+
+// UserPrefs indicates an expected call of UserPrefs.
+func (mr *MockIStoreMockRecorder) UserPrefs(discordID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserPrefs", reflect.TypeOf((*MockIStore)(nil).UserPrefs), discordID)
+}
+
 // WhitelistTwitterAccount mocks base method.
 func (m *MockIStore) WhitelistTwitterAccount(twitterID, twitterName, authorizedDiscordID string) error {
 	m.ctrl.T.Helper()
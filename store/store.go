@@ -2,6 +2,7 @@ package store
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -16,6 +17,8 @@ type Store struct {
 	claimers             map[string]*Claimer
 	twitterParties       map[string]*TwitterParty
 	twitterWhitelisted   map[string]*WhitelistInfo
+	userPrefs            KV
+	faucetClaims         KV
 	claimersPath         string
 	twitterPartiesPath   string
 	twitterWhitelistPath string
@@ -49,6 +52,12 @@ func saveMap[T any](path string, mapObj map[string]*T) error {
 }
 
 func NewStore(storePath string, logger *log.SubLogger) (IStore, error) {
+	return NewStoreWithBackend(storePath, BackendJSON, logger)
+}
+
+// NewStoreWithBackend loads the store like NewStore, and keeps the records
+// of the subsystems, like the user preferences, in the backend. See OpenKV.
+func NewStoreWithBackend(storePath, backend string, logger *log.SubLogger) (IStore, error) {
 	claimers := make(map[string]*Claimer)
 	twitterParties := make(map[string]*TwitterParty)
 	twitterWhitelisted := make(map[string]*WhitelistInfo)
@@ -72,10 +81,22 @@ func NewStore(storePath string, logger *log.SubLogger) (IStore, error) {
 		return nil, err
 	}
 
+	userPrefs, err := OpenKV(backend, storePath, "user_prefs")
+	if err != nil {
+		return nil, err
+	}
+
+	faucetClaims, err := OpenKV(backend, storePath, "faucet_claims")
+	if err != nil {
+		return nil, err
+	}
+
 	ss := &Store{
 		claimers:             claimers,
 		twitterParties:       twitterParties,
 		twitterWhitelisted:   twitterWhitelisted,
+		userPrefs:            userPrefs,
+		faucetClaims:         faucetClaims,
 		claimersPath:         claimersPath,
 		twitterPartiesPath:   twitterPartiesPath,
 		twitterWhitelistPath: twitterWhitelistPath,
@@ -113,6 +134,50 @@ func (s *Store) AddClaimTransaction(testnetAddr string, txID string) error {
 	return nil
 }
 
+// AddClaimRequest records the claim of the testnet validator and the main-net validator that the reward
+// is bonded to. A claimer can't request twice, even if the payout of the first request is not done yet.
+func (s *Store) AddClaimRequest(testnetAddr, mainnetAddr, mainnetPubKey string, requestedAt int64) error {
+	entry, found := s.claimers[testnetAddr]
+	if !found {
+		return fmt.Errorf("testnetAddr not found: %s", testnetAddr)
+	}
+
+	if entry.IsClaimed() || entry.IsPending() {
+		return fmt.Errorf("the reward of %s is already claimed", testnetAddr)
+	}
+
+	entry.MainnetAddr = mainnetAddr
+	entry.MainnetPubKey = mainnetPubKey
+	entry.RequestedAt = requestedAt
+	if err := s.saveClaimers(); err != nil {
+		entry.MainnetAddr = ""
+		entry.MainnetPubKey = ""
+		entry.RequestedAt = 0
+
+		return err
+	}
+
+	s.logger.Info("new claim request added",
+		"discordID", entry.DiscordID,
+		"testnetAddr", testnetAddr,
+		"mainnetAddr", mainnetAddr)
+
+	return nil
+}
+
+// PendingClaims returns the claims that are requested but not paid yet, keyed by the testnet addresses.
+func (s *Store) PendingClaims() map[string]*Claimer {
+	pending := make(map[string]*Claimer)
+	for addr, c := range s.claimers {
+		if c.IsPending() {
+			claimer := *c
+			pending[addr] = &claimer
+		}
+	}
+
+	return pending
+}
+
 func (s *Store) ClaimStatus() *ClaimStatus {
 	cs := ClaimStatus{}
 
@@ -202,3 +267,37 @@ func (s *Store) BoosterStatus() *BoosterStatus {
 
 	return &bs
 }
+
+func (s *Store) UserPrefs(discordID string) *UserPrefs {
+	prefs, err := GetJSON[UserPrefs](s.userPrefs, discordID)
+	if err != nil {
+		s.logger.Error("unable to get the user preferences", "err", err, "discordID", discordID)
+
+		return nil
+	}
+
+	return prefs
+}
+
+func (s *Store) SaveUserPrefs(prefs *UserPrefs) error {
+	return SetJSON(s.userPrefs, prefs.DiscordID, prefs)
+}
+
+func (s *Store) FaucetClaim(key string) *FaucetClaim {
+	claim, err := GetJSON[FaucetClaim](s.faucetClaims, key)
+	if err != nil {
+		s.logger.Error("unable to get the faucet claim", "err", err, "key", key)
+
+		return nil
+	}
+
+	return claim
+}
+
+func (s *Store) SaveFaucetClaim(claim *FaucetClaim) error {
+	return SetJSON(s.faucetClaims, claim.Key, claim)
+}
+
+func (s *Store) Close() error {
+	return errors.Join(s.userPrefs.Close(), s.faucetClaims.Close())
+}
@@ -55,7 +55,9 @@ func setup(t *testing.T) store.IStore {
 	_, err = copy("./test/wallet.json", path.Join(tempDir, "/wallet.json"))
 	require.NoError(t, err)
 
-	log.InitGlobalLogger()
+	logConf := log.DefaultConfig()
+	logConf.File = path.Join(tempDir, log.DefaultFile)
+	require.NoError(t, log.InitGlobalLogger(logConf))
 	logger := log.NewSubLogger("store_test")
 
 	store, err := store.NewStore(tempDir, logger)
@@ -101,6 +103,24 @@ func TestStore(t *testing.T) {
 		assert.True(t, isClaimed)
 	})
 
+	t.Run("add claim request", func(t *testing.T) {
+		testNetValAddr := "tpc1ppuh60th5cu9qccj6vjurx2zvd7vngcrztzycfg"
+
+		err := mockStore.AddClaimRequest(testNetValAddr, "pc1p-mainnet", "public-key", 1700000000)
+		assert.NoError(t, err)
+
+		claimer := mockStore.ClaimerInfo(testNetValAddr)
+		assert.True(t, claimer.IsPending())
+		assert.Contains(t, mockStore.PendingClaims(), testNetValAddr)
+
+		err = mockStore.AddClaimRequest(testNetValAddr, "pc1p-other", "public-key", 1700000000)
+		assert.Error(t, err)
+
+		err = mockStore.AddClaimTransaction(testNetValAddr, "0x123")
+		assert.NoError(t, err)
+		assert.NotContains(t, mockStore.PendingClaims(), testNetValAddr)
+	})
+
 	t.Run("is claimed test", func(t *testing.T) {
 		claimer := mockStore.ClaimerInfo("tpc1pesz6kuv7jts6al6la3794fyj5xaj7wm93k7z6y")
 		assert.Equal(t, int64(12*1e9), claimer.TotalReward)
@@ -135,3 +155,44 @@ func TestStoreTwitterCampaign(t *testing.T) {
 		assert.Equal(t, "AbCd123", tp.TwitterName)
 	})
 }
+
+func TestStoreUserPrefs(t *testing.T) {
+	mockStore := setup(t)
+
+	t.Run("not found", func(t *testing.T) {
+		prefs := mockStore.UserPrefs("123456789")
+		assert.Nil(t, prefs)
+	})
+
+	t.Run("save user prefs", func(t *testing.T) {
+		err := mockStore.SaveUserPrefs(&store.UserPrefs{
+			DiscordID:     "123456789",
+			ValidatorAddr: "pc1pqn7uaeduklpg00rqt6uq0m9wy5txnyt0kmxmgf",
+		})
+		assert.NoError(t, err)
+
+		prefs := mockStore.UserPrefs("123456789")
+		assert.Equal(t, "pc1pqn7uaeduklpg00rqt6uq0m9wy5txnyt0kmxmgf", prefs.ValidatorAddr)
+	})
+}
+
+func TestStoreFaucetClaims(t *testing.T) {
+	mockStore := setup(t)
+
+	t.Run("not found", func(t *testing.T) {
+		claim := mockStore.FaucetClaim("user:123456789")
+		assert.Nil(t, claim)
+	})
+
+	t.Run("save faucet claim", func(t *testing.T) {
+		err := mockStore.SaveFaucetClaim(&store.FaucetClaim{
+			Key:       "user:123456789",
+			ClaimedAt: 1700000000,
+			TxID:      "0x123",
+		})
+		assert.NoError(t, err)
+
+		claim := mockStore.FaucetClaim("user:123456789")
+		assert.Equal(t, int64(1700000000), claim.ClaimedAt)
+	})
+}
@@ -4,6 +4,11 @@ type Claimer struct {
 	DiscordID   string `json:"did"`
 	TotalReward int64  `json:"r"`
 	ClaimedTxID string `json:"tx_id"`
+	// MainnetAddr and MainnetPubKey are the validator that the reward is bonded to,
+	// they are set when the claim is requested and the payout is queued.
+	MainnetAddr   string `json:"mainnet_addr,omitempty"`
+	MainnetPubKey string `json:"mainnet_pub,omitempty"`
+	RequestedAt   int64  `json:"requested_at,omitempty"`
 }
 
 type TwitterParty struct {
@@ -28,6 +33,22 @@ type WhitelistInfo struct {
 	WhitelistedBy string `json:"whitelisted_by"`
 }
 
+type UserPrefs struct {
+	DiscordID     string `json:"discord_id"`
+	ValidatorAddr string `json:"val_addr"`
+	// Locale is the language of the bot responses, like "es". It's empty for the default language.
+	Locale string `json:"locale,omitempty"`
+	// RecentValidators are the validators that the user looked up recently, the latest first.
+	RecentValidators []string `json:"recent_validators,omitempty"`
+}
+
+// FaucetClaim is the last time that the faucet sent coins to a user or an address, keyed like "user:<id>".
+type FaucetClaim struct {
+	Key       string `json:"key"`
+	ClaimedAt int64  `json:"claimed_at"`
+	TxID      string `json:"tx_id"`
+}
+
 type BoosterStatus struct {
 	Pac            int
 	Usdt           int
@@ -49,3 +70,8 @@ type ClaimStatus struct {
 func (c *Claimer) IsClaimed() bool {
 	return c.ClaimedTxID != ""
 }
+
+// IsPending returns true if the claim is requested, but the reward is not paid yet.
+func (c *Claimer) IsPending() bool {
+	return c.MainnetAddr != "" && !c.IsClaimed()
+}
@@ -0,0 +1,136 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultAPIURL = "https://api.telegram.org"
+
+	// pollTimeout is the long polling timeout of getUpdates in seconds.
+	pollTimeout = 30
+)
+
+type chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+type user struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type message struct {
+	MessageID int64  `json:"message_id"`
+	From      *user  `json:"from"`
+	Chat      chat   `json:"chat"`
+	Text      string `json:"text"`
+}
+
+type update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *message `json:"message"`
+}
+
+type botCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	Description string          `json:"description"`
+}
+
+// botAPI is a minimal client of the Telegram Bot API, covering the methods that the bot needs.
+type botAPI struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+func newBotAPI(apiURL, token string) *botAPI {
+	return &botAPI{
+		url:   apiURL,
+		token: token,
+		httpClient: &http.Client{
+			Timeout: (pollTimeout + 10) * time.Second,
+		},
+	}
+}
+
+func (api *botAPI) call(ctx context.Context, method string, params, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/bot%s/%s", api.url, api.token, method), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		// The URL of the request has the bot token, so it's kept out of the error and the logs.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var apiResp apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+
+	if !apiResp.OK {
+		return fmt.Errorf("telegram %s: %s", method, apiResp.Description)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(apiResp.Result, result)
+}
+
+func (api *botAPI) getUpdates(ctx context.Context, offset int64) ([]update, error) {
+	updates := []update{}
+	err := api.call(ctx, "getUpdates", map[string]any{
+		"offset":          offset,
+		"timeout":         pollTimeout,
+		"allowed_updates": []string{"message"},
+	}, &updates)
+
+	return updates, err
+}
+
+func (api *botAPI) sendMessage(ctx context.Context, chatID int64, text string) error {
+	return api.call(ctx, "sendMessage", map[string]any{
+		"chat_id":                  chatID,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	}, nil)
+}
+
+func (api *botAPI) setMyCommands(ctx context.Context, cmds []botCommand) error {
+	return api.call(ctx, "setMyCommands", map[string]any{
+		"commands": cmds,
+	}, nil)
+}
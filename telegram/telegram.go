@@ -0,0 +1,230 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+const (
+	// callerPrefix keeps the Telegram users apart from the Discord users in the engine,
+	// so the authorized IDs of Telegram users are like "telegram:12345".
+	callerPrefix = "telegram:"
+
+	startCommandName = "start"
+	privateChat      = "private"
+
+	maxDescriptionLength = 256
+	maxMessageLength     = 4096
+	pollRetryDelay       = 5 * time.Second
+	replyTimeout         = 10 * time.Second
+)
+
+type TelegramBot struct {
+	BotEngine *engine.BotEngine
+
+	api    *botAPI
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewTelegramBot(botEngine *engine.BotEngine, cfg config.TelegramBotConfig) (*TelegramBot, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("telegram token is not set")
+	}
+
+	return &TelegramBot{
+		BotEngine: botEngine,
+		api:       newBotAPI(defaultAPIURL, cfg.Token),
+	}, nil
+}
+
+// Start registers the commands and polls the updates, until the context is canceled or the bot is stopped.
+func (bot *TelegramBot) Start(ctx context.Context) error {
+	log.Info("starting Telegram Bot...")
+
+	bot.ctx, bot.cancel = context.WithCancel(ctx)
+	if err := bot.registerCommands(); err != nil {
+		bot.cancel()
+
+		return err
+	}
+
+	bot.wg.Add(1)
+	go func() {
+		defer bot.wg.Done()
+		bot.poll()
+	}()
+
+	return nil
+}
+
+// registerCommands publishes the commands, so Telegram can suggest them to the users.
+// The commands that need a confirmation are not available on Telegram.
+func (bot *TelegramBot) registerCommands() error {
+	tgCmds := []botCommand{}
+	for _, beCmd := range bot.BotEngine.Commands() {
+		if !beCmd.HasAppId(engine.AppIdTelegram) ||
+			!bot.BotEngine.IsCommandAllowed(beCmd.Name, engine.AppIdTelegram) ||
+			beCmd.ConfirmPhrase != "" {
+			continue
+		}
+
+		tgCmds = append(tgCmds, botCommand{
+			Command:     telegramName(beCmd.Name),
+			Description: commandDescription(beCmd),
+		})
+	}
+
+	if err := bot.api.setMyCommands(bot.ctx, tgCmds); err != nil {
+		log.Error("can not register telegram commands", "error", err)
+		return err
+	}
+	log.Info("telegram commands registered", "count", len(tgCmds))
+
+	return nil
+}
+
+// poll receives the updates by long polling, until the bot is stopped.
+func (bot *TelegramBot) poll() {
+	var offset int64
+	for {
+		updates, err := bot.api.getUpdates(bot.ctx, offset)
+		if err != nil {
+			if bot.ctx.Err() != nil {
+				return
+			}
+
+			log.Error("can't get telegram updates", "error", err)
+			select {
+			case <-bot.ctx.Done():
+				return
+			case <-time.After(pollRetryDelay):
+			}
+
+			continue
+		}
+
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			bot.handleUpdate(u)
+		}
+	}
+}
+
+func (bot *TelegramBot) handleUpdate(u update) {
+	msg := u.Message
+	if msg == nil || msg.From == nil || !strings.HasPrefix(msg.Text, "/") {
+		return
+	}
+
+	fields := strings.Fields(msg.Text)
+	cmdName := engineName(bot.BotEngine, commandName(fields[0]))
+	reqID := strconv.FormatInt(u.UpdateID, 36)
+	callerID := callerPrefix + strconv.FormatInt(msg.From.ID, 10)
+	msgs := bot.BotEngine.MessagesFor(callerID)
+
+	log.Debug("telegram command", "requestID", reqID, "command", cmdName, "by", callerID)
+
+	if msg.Chat.Type != privateChat {
+		bot.respond(msg.Chat.ID, errorText(msgs, msgs.Get(engine.MsgDMOnly)))
+		return
+	}
+
+	if cmd := bot.BotEngine.FindCommand(cmdName); cmd != nil && cmd.ConfirmPhrase != "" {
+		bot.respond(msg.Chat.ID, errorText(msgs,
+			"`/"+cmdName+"` needs a confirmation and is not available on Telegram"))
+		return
+	}
+
+	inputs := append([]string{cmdName}, fields[1:]...)
+	res, err := bot.BotEngine.RunWithOptions(engine.RunOptions{RequestID: reqID}, engine.AppIdTelegram, callerID, inputs)
+	if err != nil {
+		log.Warn("telegram command failed", "requestID", reqID, "command", cmdName, "error", err)
+		bot.respond(msg.Chat.ID, errorText(msgs, err.Error()))
+		return
+	}
+
+	bot.respond(msg.Chat.ID, resultText(res, msgs))
+}
+
+// respond sends the text to the chat within the reply timeout, even after the bot is stopped.
+// The long texts are sent in several messages.
+func (bot *TelegramBot) respond(chatID int64, text string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(bot.ctx), replyTimeout)
+	defer cancel()
+
+	for _, part := range splitText(text, maxMessageLength) {
+		if err := bot.api.sendMessage(ctx, chatID, part); err != nil {
+			log.Error("can't send telegram message", "error", err, "chatID", chatID)
+
+			return
+		}
+	}
+}
+
+func (*TelegramBot) Name() string {
+	return "telegram"
+}
+
+func (*TelegramBot) AppID() engine.AppID {
+	return engine.AppIdTelegram
+}
+
+// Stop stops polling and waits for the handling update to be answered.
+func (bot *TelegramBot) Stop() {
+	log.Info("shutting down Telegram Bot...")
+
+	if bot.cancel != nil {
+		bot.cancel()
+	}
+	bot.wg.Wait()
+}
+
+// commandName extracts the command name from the first word of a message, like "/node_info@RoboPacBot".
+func commandName(word string) string {
+	name := strings.TrimPrefix(word, "/")
+	name, _, _ = strings.Cut(name, "@")
+
+	return strings.ToLower(name)
+}
+
+// engineName maps the Telegram command name to the engine command name.
+// Telegram doesn't allow dashes in the command names, so they are replaced by underscores.
+// The start command, which Telegram sends when a user opens the bot, shows the help.
+func engineName(be *engine.BotEngine, name string) string {
+	if name == startCommandName {
+		return engine.HelpCommandName
+	}
+
+	if be.FindCommand(name) != nil {
+		return name
+	}
+
+	return strings.ReplaceAll(name, "_", "-")
+}
+
+func telegramName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func commandDescription(cmd engine.Command) string {
+	desc := cmd.Desc
+	if desc == "" {
+		desc = cmd.Name
+	}
+
+	if runes := []rune(desc); len(runes) > maxDescriptionLength {
+		desc = string(runes[:maxDescriptionLength-3]) + "..."
+	}
+
+	return desc
+}
@@ -0,0 +1,120 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandNames(t *testing.T) {
+	assert.Equal(t, "node-info", commandName("/node-info"))
+	assert.Equal(t, "node_info", commandName("/Node_Info@RoboPacBot"))
+	assert.Equal(t, "node_info", telegramName("node-info"))
+
+	long := engine.Command{Name: "cmd", Desc: strings.Repeat("a", 300)}
+	assert.Len(t, commandDescription(long), maxDescriptionLength)
+	assert.Equal(t, "cmd", commandDescription(engine.Command{Name: "cmd"}))
+}
+
+func TestResultText(t *testing.T) {
+	messages := engine.NewMessageCatalog()
+
+	res := engine.MakeSuccessfulResult("height <100>")
+	res.AddWarning("deprecated")
+	res.AddField("Peers", "10", true)
+	res.List = &engine.ListResult{Total: 25, Page: 2, PageSize: 10}
+	res.Suggest("node-info")
+
+	assert.Equal(t, "<b>Successful</b>\n\n"+
+		"⚠️ deprecated\n\n"+
+		"height &lt;100&gt;\n\n"+
+		"<b>Peers</b>: 10\n\n"+
+		"<i>Page 2/3 (25 items) • Try next: /node_info</i>", resultText(res, messages))
+
+	assert.Equal(t, "<b>Failed</b>\n\noops", resultText(engine.MakeFailedResult("oops"), messages))
+
+	table := engine.MakeSuccessfulResult("stakes")
+	table.Title = "Validators"
+	table.SetTable("Address", "Stake")
+	table.AddRow("pc1p<a>", "10")
+	assert.Equal(t, "<b>Validators</b>\n\nstakes\n\n"+
+		"<pre>Address  Stake\n-------  -----\npc1p&lt;a&gt;  10</pre>", resultText(table, messages))
+	assert.Equal(t, "<b>Error</b>\n\nSomething went wrong, please try again later", errorText(messages, ""))
+}
+
+func TestSplitText(t *testing.T) {
+	assert.Equal(t, []string{"<b>Title</b>\n\nshort"}, splitText("<b>Title</b>\n\nshort", 4096))
+
+	parts := splitText("<b>Title</b>\n<pre>row 1\nrow 2\nrow 3</pre>\ndone", 30)
+	assert.Equal(t, []string{
+		"<b>Title</b>\n",
+		"<pre>row 1\nrow 2\n</pre>",
+		"<pre>row 3</pre>\n",
+		"done",
+	}, parts)
+	for _, part := range parts {
+		assert.LessOrEqual(t, len(part), 30)
+	}
+
+	parts = splitText(strings.Repeat("a", 20)+"&amp;", 30)
+	assert.Equal(t, []string{strings.Repeat("a", 14), strings.Repeat("a", 6) + "&amp;"}, parts,
+		"the escaped characters are not cut")
+}
+
+func TestBotAPI(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bottoken/sendMessage":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+
+		case "/bottoken/getUpdates":
+			_, _ = w.Write([]byte(`{"ok":true,"result":[` +
+				`{"update_id":7,"message":{"message_id":1,"from":{"id":42},` +
+				`"chat":{"id":42,"type":"private"},"text":"/help"}}]}`))
+
+		default:
+			_, _ = w.Write([]byte(`{"ok":false,"description":"Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	api := newBotAPI(server.URL, "token")
+
+	t.Run("send message", func(t *testing.T) {
+		err := api.sendMessage(context.Background(), 42, "<b>hi</b>")
+		require.NoError(t, err)
+		assert.Equal(t, float64(42), received["chat_id"])
+		assert.Equal(t, "<b>hi</b>", received["text"])
+		assert.Equal(t, "HTML", received["parse_mode"])
+	})
+
+	t.Run("get updates", func(t *testing.T) {
+		updates, err := api.getUpdates(context.Background(), 0)
+		require.NoError(t, err)
+		require.Len(t, updates, 1)
+		assert.Equal(t, int64(7), updates[0].UpdateID)
+		assert.Equal(t, int64(42), updates[0].Message.From.ID)
+		assert.Equal(t, "/help", updates[0].Message.Text)
+	})
+
+	t.Run("token is not leaked", func(t *testing.T) {
+		closed := newBotAPI("http://127.0.0.1:1", "secret-token")
+		err := closed.sendMessage(context.Background(), 42, "hi")
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "secret-token")
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		err := api.setMyCommands(context.Background(), []botCommand{})
+		assert.ErrorContains(t, err, "Not Found")
+	})
+}
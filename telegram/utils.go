@@ -0,0 +1,138 @@
+package telegram
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kehiy/RoboPac/engine"
+)
+
+// resultText renders the result of a command as a Telegram message in the HTML mode.
+func resultText(res *engine.CommandResult, messages *engine.MessageCatalog) string {
+	var title string
+	switch {
+	case res.Maintenance:
+		title = "🔧 " + messages.Get(engine.MsgTitleMaintenance)
+	case res.Successful:
+		title = messages.Get(engine.MsgTitleSuccessful)
+	default:
+		title = messages.Get(engine.MsgTitleFailed)
+	}
+
+	if res.Title != "" && !res.Maintenance {
+		title = res.Title
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("<b>" + html.EscapeString(title) + "</b>\n\n")
+
+	for _, w := range res.Warnings {
+		sb.WriteString("⚠️ " + html.EscapeString(w) + "\n")
+	}
+	if len(res.Warnings) > 0 {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(html.EscapeString(res.Message))
+
+	if res.Table != nil {
+		sb.WriteString("\n\n<pre>" + html.EscapeString(res.Table.String()) + "</pre>")
+	}
+
+	if len(res.Fields) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, f := range res.Fields {
+		sb.WriteString("\n<b>" + html.EscapeString(f.Name) + "</b>: " + html.EscapeString(f.Value))
+	}
+
+	footer := []string{}
+	if res.List != nil {
+		footer = append(footer, res.List.Footer())
+	}
+	if len(res.Suggestions) > 0 {
+		cmds := make([]string, 0, len(res.Suggestions))
+		for _, s := range res.Suggestions {
+			cmds = append(cmds, "/"+telegramName(s))
+		}
+		footer = append(footer, "Try next: "+strings.Join(cmds, ", "))
+	}
+	if len(footer) > 0 {
+		sb.WriteString("\n\n<i>" + html.EscapeString(strings.Join(footer, " • ")) + "</i>")
+	}
+
+	return sb.String()
+}
+
+func errorText(messages *engine.MessageCatalog, errStr string) string {
+	if errStr == "" {
+		errStr = messages.Get(engine.MsgErrorFallback)
+	}
+
+	return "<b>" + html.EscapeString(messages.Get(engine.MsgTitleError)) + "</b>\n\n" + html.EscapeString(errStr)
+}
+
+// splitText splits the text in the HTML mode into the parts of at most maxLen characters, by the lines.
+// A preformatted block is closed at the end of a part and opened again in the next part.
+func splitText(text string, maxLen int) []string {
+	const preOpen, preClose = "<pre>", "</pre>"
+	limit := maxLen - len(preOpen) - len(preClose)
+
+	parts := []string{}
+	part := strings.Builder{}
+	partLen := 0
+	inPre := false
+	flush := func() {
+		if inPre {
+			part.WriteString(preClose)
+		}
+		parts = append(parts, part.String())
+
+		part.Reset()
+		partLen = 0
+		if inPre {
+			part.WriteString(preOpen)
+			partLen = len(preOpen)
+		}
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		for _, piece := range cutLine(line, limit-len(preOpen)) {
+			pieceLen := utf8.RuneCountInString(piece)
+			if partLen+pieceLen > limit {
+				flush()
+			}
+			part.WriteString(piece)
+			partLen += pieceLen
+
+			if opened, closed := strings.LastIndex(piece, preOpen), strings.LastIndex(piece, preClose); opened > closed {
+				inPre = true
+			} else if closed >= 0 {
+				inPre = false
+			}
+		}
+	}
+
+	if partLen > 0 {
+		parts = append(parts, part.String())
+	}
+
+	return parts
+}
+
+// cutLine cuts the line into the pieces of at most maxLen characters, but not inside an escaped character.
+func cutLine(line string, maxLen int) []string {
+	runes := []rune(line)
+	pieces := []string{}
+	for len(runes) > maxLen {
+		piece := string(runes[:maxLen])
+		if amp := strings.LastIndex(piece, "&"); amp > 0 && !strings.Contains(piece[amp:], ";") {
+			piece = piece[:amp]
+		}
+		pieces = append(pieces, piece)
+		runes = runes[utf8.RuneCountInString(piece):]
+	}
+
+	return append(pieces, string(runes))
+}
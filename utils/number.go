@@ -1,6 +1,10 @@
 package utils
 
-import "strconv"
+import (
+	"math"
+	"strconv"
+	"strings"
+)
 
 func FormatNumber(num int64) string {
 	numStr := strconv.FormatInt(num, 10)
@@ -15,3 +19,27 @@ func FormatNumber(num int64) string {
 
 	return formattedNum
 }
+
+// FormatCompactNumber formats the number in a short form like 1.2k or 3.4M.
+func FormatCompactNumber(num int64) string {
+	units := []struct {
+		size   float64
+		suffix string
+	}{
+		{1e12, "T"},
+		{1e9, "B"},
+		{1e6, "M"},
+		{1e3, "k"},
+	}
+
+	abs := math.Abs(float64(num))
+	for _, u := range units {
+		if abs >= u.size {
+			formatted := strconv.FormatFloat(float64(num)/u.size, 'f', 1, 64)
+
+			return strings.TrimSuffix(formatted, ".0") + u.suffix
+		}
+	}
+
+	return strconv.FormatInt(num, 10)
+}
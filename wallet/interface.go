@@ -2,7 +2,11 @@ package wallet
 
 type IWallet interface {
 	BondTransaction(string, string, string, int64) (string, error)
-	TransferTransaction(string, string, string, int64) (string, error)
+	TransferTransaction(string, int64, string) (string, error)
+	TransferTransactionAt(uint32, string, int64, string) (string, error)
+	TransferTransactionFrom(string, string, int64, string) (string, error)
+	LockTime() (uint32, error)
+	SignMessage([]byte) (string, string, error)
 	NewAddress(string) (string, error)
 	Address() string
 	Balance() int64
@@ -81,6 +81,21 @@ func (mr *MockIWalletMockRecorder) BondTransaction(arg0, arg1, arg2, arg3 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BondTransaction", reflect.TypeOf((*MockIWallet)(nil).BondTransaction), arg0, arg1, arg2, arg3)
 }
 
+// LockTime mocks base method.
+func (m *MockIWallet) LockTime() (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockTime")
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockTime indicates an expected call of LockTime.
+func (mr *MockIWalletMockRecorder) LockTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockTime", reflect.TypeOf((*MockIWallet)(nil).LockTime))
+}
+
 // NewAddress mocks base method.
 func (m *MockIWallet) NewAddress(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -96,17 +111,63 @@ func (mr *MockIWalletMockRecorder) NewAddress(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAddress", reflect.TypeOf((*MockIWallet)(nil).NewAddress), arg0)
 }
 
+// SignMessage mocks base method.
+func (m *MockIWallet) SignMessage(arg0 []byte) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignMessage", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SignMessage indicates an expected call of SignMessage.
+func (mr *MockIWalletMockRecorder) SignMessage(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignMessage", reflect.TypeOf((*MockIWallet)(nil).SignMessage), arg0)
+}
+
 // TransferTransaction mocks base method.
-func (m *MockIWallet) TransferTransaction(arg0, arg1, arg2 string, arg3 int64) (string, error) {
+func (m *MockIWallet) TransferTransaction(arg0 string, arg1 int64, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TransferTransaction", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "TransferTransaction", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // TransferTransaction indicates an expected call of TransferTransaction.
-func (mr *MockIWalletMockRecorder) TransferTransaction(arg0, arg1, arg2, arg3 any) *gomock.Call {
+func (mr *MockIWalletMockRecorder) TransferTransaction(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTransaction", reflect.TypeOf((*MockIWallet)(nil).TransferTransaction), arg0, arg1, arg2)
+}
+
+// TransferTransactionAt mocks base method.
+func (m *MockIWallet) TransferTransactionAt(arg0 uint32, arg1 string, arg2 int64, arg3 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferTransactionAt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferTransactionAt indicates an expected call of TransferTransactionAt.
+func (mr *MockIWalletMockRecorder) TransferTransactionAt(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTransactionAt", reflect.TypeOf((*MockIWallet)(nil).TransferTransactionAt), arg0, arg1, arg2, arg3)
+}
+
+// TransferTransactionFrom mocks base method.
+func (m *MockIWallet) TransferTransactionFrom(arg0, arg1 string, arg2 int64, arg3 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferTransactionFrom", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferTransactionFrom indicates an expected call of TransferTransactionFrom.
+func (mr *MockIWalletMockRecorder) TransferTransactionFrom(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTransaction", reflect.TypeOf((*MockIWallet)(nil).TransferTransaction), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTransactionFrom", reflect.TypeOf((*MockIWallet)(nil).TransferTransactionFrom), arg0, arg1, arg2, arg3)
 }
@@ -1,15 +1,22 @@
 package wallet
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/kehiy/RoboPac/config"
 	"github.com/kehiy/RoboPac/log"
 	"github.com/kehiy/RoboPac/utils"
 	"github.com/pactus-project/pactus/crypto"
 	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/genesis"
+	"github.com/pactus-project/pactus/types/tx"
 	"github.com/pactus-project/pactus/types/tx/payload"
 	pwallet "github.com/pactus-project/pactus/wallet"
+	pactus "github.com/pactus-project/pactus/www/grpc/gen/go"
 )
 
 type Balance struct {
@@ -17,113 +24,258 @@ type Balance struct {
 	Staked    float64
 }
 
+// nodeClient is the node that the wallet reads the chain from and broadcasts the transactions to,
+// like the client manager.
+type nodeClient interface {
+	GetBalance(addr string) (int64, error)
+	GetBlockchainHeight() (uint32, error)
+	GetValidatorInfo(address string) (*pactus.GetValidatorResponse, error)
+	CalculateFee(amount int64, payloadType payload.Type) (int64, error)
+	BroadcastTransaction(signedRawTx []byte) (string, error)
+}
+
+// Wallet is the hot wallet of the bot. The keys are kept in a Pactus wallet file, which is opened offline,
+// the transactions are signed locally and broadcast through the node client.
 type Wallet struct {
+	lk sync.Mutex
+
 	address  string
 	password string
 	wallet   *pwallet.Wallet
+	node     nodeClient
 	logger   *log.SubLogger
 }
 
-func Open(cfg *config.Config, logger *log.SubLogger) IWallet {
-	if doesWalletExist(cfg.WalletPath) {
+// Open opens the wallet at the configured path. If the wallet doesn't exist and a seed is configured,
+// the wallet is created from the seed. Without a configured address, the first address of the wallet is used.
+func Open(cfg *config.Config, node nodeClient, logger *log.SubLogger) (*Wallet, error) {
+	var wt *pwallet.Wallet
+	var err error
 
-		wt, err := pwallet.Open(cfg.WalletPath, true)
+	if doesWalletExist(cfg.WalletPath) {
+		wt, err = pwallet.Open(cfg.WalletPath, true)
 		if err != nil {
-			logger.Fatal("error opening existing wallet", "err", err)
+			return nil, fmt.Errorf("error opening existing wallet: %w", err)
+		}
+	} else {
+		if cfg.WalletSeed == "" {
+			return nil, fmt.Errorf("wallet does not exist at %s and no seed is set", cfg.WalletPath)
 		}
 
-		err = wt.Connect(cfg.LocalNode)
+		wt, err = create(cfg.WalletPath, cfg.WalletSeed, cfg.WalletPassword, chainType(cfg.Network))
 		if err != nil {
-			logger.Fatal("error establishing connection", "err", err)
+			return nil, fmt.Errorf("error creating wallet from seed: %w", err)
 		}
+		logger.Info("wallet created from seed", "path", cfg.WalletPath)
+	}
 
-		return &Wallet{
-			wallet:   wt,
-			address:  cfg.WalletAddress,
-			password: cfg.WalletPassword,
-			logger:   logger,
+	address := cfg.WalletAddress
+	if address == "" {
+		infos := wt.AddressInfos()
+		if len(infos) == 0 {
+			return nil, errors.New("wallet has no address")
 		}
+		address = infos[0].Address
+	}
+
+	if !wt.Contains(address) {
+		return nil, fmt.Errorf("wallet does not contain the address %s", address)
 	}
 
-	// if the wallet does not exist, create one
-	return nil
+	return &Wallet{
+		wallet:   wt,
+		address:  address,
+		password: cfg.WalletPassword,
+		node:     node,
+		logger:   logger,
+	}, nil
+}
+
+// create creates the wallet from the seed with one account address and saves it.
+func create(path, seed, password string, chain genesis.ChainType) (*pwallet.Wallet, error) {
+	if err := pwallet.CheckMnemonic(seed); err != nil {
+		return nil, err
+	}
+
+	wt, err := pwallet.Create(path, seed, password, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := wt.NewBLSAccountAddress("RoboPac"); err != nil {
+		return nil, err
+	}
+
+	if err := wt.Save(); err != nil {
+		return nil, err
+	}
+
+	return wt, nil
+}
+
+// chainType returns the chain of the network name, it's the Mainnet unless the name is a test network.
+func chainType(network string) genesis.ChainType {
+	switch strings.ToLower(network) {
+	case "testnet":
+		return genesis.Testnet
+	case "localnet":
+		return genesis.Localnet
+	default:
+		return genesis.Mainnet
+	}
 }
 
 func (w *Wallet) BondTransaction(pubKey, toAddress, memo string, amount int64) (string, error) {
-	opts := []pwallet.TxOption{
-		pwallet.OptionMemo(memo),
+	receiver, err := crypto.AddressFromString(toAddress)
+	if err != nil {
+		return "", err
 	}
-	tx, err := w.wallet.MakeBondTx(w.address, toAddress, pubKey,
-		amount, opts...)
+
+	// the public key is only needed for the new validators.
+	var pub *bls.PublicKey
+	if res, err := w.node.GetValidatorInfo(toAddress); err != nil || res.GetValidator() == nil {
+		if pubKey != "" {
+			pub, err = bls.PublicKeyFromString(pubKey)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	fee, err := w.node.CalculateFee(amount, payload.TypeBond)
 	if err != nil {
-		w.logger.Error("error creating bond transaction", "err", err, "to",
-			toAddress, "amount", utils.ChangeToCoin(amount))
 		return "", err
 	}
-	// sign transaction
-	err = w.wallet.SignTransaction(w.password, tx)
+
+	lockTime, err := w.LockTime()
 	if err != nil {
-		w.logger.Error("error signing bond transaction", "err", err,
-			"to", toAddress, "amount", utils.ChangeToCoin(amount))
 		return "", err
 	}
 
-	// broadcast transaction
-	res, err := w.wallet.BroadcastTransaction(tx)
+	trx := tx.NewBondTx(lockTime, w.sender(), receiver, pub, amount, fee, memo)
+	txID, err := w.signAndBroadcast(trx)
 	if err != nil {
-		w.logger.Error("error broadcasting bond transaction", "err", err,
+		w.logger.Error("error sending bond transaction", "err", err,
 			"to", toAddress, "amount", utils.ChangeToCoin(amount))
 		return "", err
 	}
 
-	err = w.wallet.Save()
+	return txID, nil
+}
+
+func (w *Wallet) TransferTransaction(toAddress string, amount int64, memo string) (string, error) {
+	lockTime, err := w.LockTime()
 	if err != nil {
-		w.logger.Error("error saving wallet transaction history", "err", err,
-			"to", toAddress, "amount", utils.ChangeToCoin(amount))
+		return "", err
 	}
-	return res, nil // return transaction hash
+
+	return w.TransferTransactionAt(lockTime, toAddress, amount, memo)
 }
 
-func (w *Wallet) TransferTransaction(pubKey, toAddress, memo string, amount int64) (string, error) {
-	fee, err := w.wallet.CalculateFee(int64(amount), payload.TypeTransfer)
+// TransferTransactionAt sends the transfer with the given lock time. The same transfer with the same
+// lock time has the same ID, so it can be retried safely, the node rejects it if it's already committed.
+func (w *Wallet) TransferTransactionAt(lockTime uint32, toAddress string, amount int64, memo string) (string, error) {
+	receiver, err := crypto.AddressFromString(toAddress)
 	if err != nil {
 		return "", err
 	}
 
-	opts := []pwallet.TxOption{
-		pwallet.OptionFee(fee),
-		pwallet.OptionMemo(memo),
+	fee, err := w.node.CalculateFee(amount, payload.TypeTransfer)
+	if err != nil {
+		return "", err
 	}
 
-	tx, err := w.wallet.MakeTransferTx(w.address, toAddress, int64(amount), opts...)
+	trx := tx.NewTransferTx(lockTime, w.sender(), receiver, amount, fee, memo)
+	txID, err := w.signAndBroadcast(trx)
 	if err != nil {
-		w.logger.Error("error creating transfer transaction", "err", err,
+		w.logger.Error("error sending transfer transaction", "err", err,
 			"to", toAddress, "amount", utils.ChangeToCoin(amount))
 		return "", err
 	}
 
-	// sign transaction
-	err = w.wallet.SignTransaction(w.password, tx)
+	return txID, nil
+}
+
+// TransferTransactionFrom sends the transfer from another address of the wallet, like a deposit address.
+// The fee is paid by the sender address too.
+func (w *Wallet) TransferTransactionFrom(fromAddress, toAddress string, amount int64, memo string) (string, error) {
+	sender, err := crypto.AddressFromString(fromAddress)
 	if err != nil {
-		w.logger.Error("error signing transfer transaction", "err", err,
-			"to", toAddress, "amount", utils.ChangeToCoin(amount))
 		return "", err
 	}
 
-	// broadcast transaction
-	res, err := w.wallet.BroadcastTransaction(tx)
+	receiver, err := crypto.AddressFromString(toAddress)
 	if err != nil {
-		w.logger.Error("error broadcasting transfer transaction", "err", err,
-			"to", toAddress, "amount", utils.ChangeToCoin(amount))
 		return "", err
 	}
 
-	err = w.wallet.Save()
+	lockTime, err := w.LockTime()
 	if err != nil {
-		w.logger.Error("error saving wallet transaction history", "err", err,
-			"to", toAddress, "amount", utils.ChangeToCoin(amount))
+		return "", err
+	}
+
+	fee, err := w.node.CalculateFee(amount, payload.TypeTransfer)
+	if err != nil {
+		return "", err
+	}
+
+	trx := tx.NewTransferTx(lockTime, sender, receiver, amount, fee, memo)
+	txID, err := w.signAndBroadcast(trx)
+	if err != nil {
+		w.logger.Error("error sending transfer transaction", "err", err,
+			"from", fromAddress, "to", toAddress, "amount", utils.ChangeToCoin(amount))
+		return "", err
+	}
+
+	return txID, nil
+}
+
+// LockTime returns the lock time of the new transactions, which protects them against replay.
+func (w *Wallet) LockTime() (uint32, error) {
+	height, err := w.node.GetBlockchainHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	return height + 1, nil
+}
+
+func (w *Wallet) sender() crypto.Address {
+	// the address is checked when the wallet is opened.
+	addr, _ := crypto.AddressFromString(w.address)
+
+	return addr
+}
+
+// signAndBroadcast signs the transaction by the key of the wallet and broadcasts it,
+// it returns the transaction hash.
+func (w *Wallet) signAndBroadcast(trx *tx.Tx) (string, error) {
+	w.lk.Lock()
+	err := w.wallet.SignTransaction(w.password, trx)
+	w.lk.Unlock()
+	if err != nil {
+		return "", err
 	}
-	return res, nil // return transaction hash
+
+	data, err := trx.Bytes()
+	if err != nil {
+		return "", err
+	}
+
+	return w.node.BroadcastTransaction(data)
+}
+
+// SignMessage signs the message by the key of the wallet address,
+// it returns the signature and the public key to verify it, both in hex.
+func (w *Wallet) SignMessage(msg []byte) (string, string, error) {
+	w.lk.Lock()
+	prv, err := w.wallet.PrivateKey(w.password, w.address)
+	w.lk.Unlock()
+	if err != nil {
+		return "", "", err
+	}
+
+	return prv.Sign(msg).String(), prv.PublicKey().String(), nil
 }
 
 func (w *Wallet) Address() string {
@@ -131,12 +283,25 @@ func (w *Wallet) Address() string {
 }
 
 func (w *Wallet) Balance() int64 {
-	balance, _ := w.wallet.Balance(w.address)
+	balance, err := w.node.GetBalance(w.address)
+	if err != nil {
+		w.logger.Warn("unable to get the wallet balance", "err", err)
+	}
+
 	return balance
 }
 
+// NewAddress creates a new account address with the label and saves it in the wallet.
 func (w *Wallet) NewAddress(lb string) (string, error) {
-	return w.wallet.NewBLSAccountAddress(lb)
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	addr, err := w.wallet.NewBLSAccountAddress(lb)
+	if err != nil {
+		return "", err
+	}
+
+	return addr, w.wallet.Save()
 }
 
 func IsValidData(address, pubKey string) bool {
@@ -0,0 +1,162 @@
+package wallet
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/kehiy/RoboPac/client"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/log"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/types/tx"
+	"github.com/pactus-project/pactus/types/tx/payload"
+	pwallet "github.com/pactus-project/pactus/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setup(t *testing.T) (*config.Config, *client.Mgr, *client.MockIClient) {
+	t.Helper()
+
+	seed, err := pwallet.GenerateMnemonic(128)
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		Network:        "Localnet",
+		WalletPath:     path.Join(t.TempDir(), "wallet.json"),
+		WalletPassword: "password",
+		WalletSeed:     seed,
+	}
+
+	mockClient := client.NewMockIClient(gomock.NewController(t))
+	cm := client.NewClientMgr(context.Background())
+	cm.AddClient(mockClient)
+
+	return cfg, cm, mockClient
+}
+
+func TestOpen(t *testing.T) {
+	cfg, cm, _ := setup(t)
+
+	t.Run("create from seed", func(t *testing.T) {
+		w, err := Open(cfg, cm, log.NewSubLogger("wallet"))
+		require.NoError(t, err)
+		assert.NotEmpty(t, w.Address())
+
+		newAddr, err := w.NewAddress("deposit")
+		require.NoError(t, err)
+
+		reopened, err := Open(cfg, cm, log.NewSubLogger("wallet"))
+		require.NoError(t, err)
+		assert.Equal(t, w.Address(), reopened.Address())
+		assert.True(t, reopened.wallet.Contains(newAddr))
+	})
+
+	t.Run("unknown address", func(t *testing.T) {
+		cfg := *cfg
+		cfg.WalletAddress = "tpc1zh75z7r7p3seswfpq0rs7rgxnmv6dg4drrmm2ds"
+
+		_, err := Open(&cfg, cm, log.NewSubLogger("wallet"))
+		assert.Error(t, err)
+	})
+
+	t.Run("no wallet and no seed", func(t *testing.T) {
+		cfg := *cfg
+		cfg.WalletPath = path.Join(t.TempDir(), "wallet.json")
+		cfg.WalletSeed = ""
+
+		_, err := Open(&cfg, cm, log.NewSubLogger("wallet"))
+		assert.Error(t, err)
+	})
+}
+
+func TestTransferTransaction(t *testing.T) {
+	cfg, cm, mockClient := setup(t)
+	w, err := Open(cfg, cm, log.NewSubLogger("wallet"))
+	require.NoError(t, err)
+
+	receiver, err := w.NewAddress("receiver")
+	require.NoError(t, err)
+
+	mockClient.EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(100), nil)
+	mockClient.EXPECT().CalculateFee(gomock.Any(), int64(5e9), payload.TypeTransfer).Return(int64(1e7), nil)
+	mockClient.EXPECT().BroadcastTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, data []byte) (string, error) {
+			trx, err := tx.FromBytes(data)
+			require.NoError(t, err)
+			require.NoError(t, trx.BasicCheck())
+
+			assert.Equal(t, uint32(101), trx.LockTime())
+			assert.Equal(t, int64(1e7), trx.Fee())
+			assert.Equal(t, "faucet", trx.Memo())
+			assert.Equal(t, w.Address(), trx.Payload().Signer().String())
+			assert.Equal(t, receiver, trx.Payload().Receiver().String())
+			assert.Equal(t, int64(5e9), trx.Payload().Value())
+
+			return "0x123", nil
+		})
+
+	txID, err := w.TransferTransaction(receiver, 5e9, "faucet")
+	require.NoError(t, err)
+	assert.Equal(t, "0x123", txID)
+}
+
+func TestTransferTransactionFrom(t *testing.T) {
+	cfg, cm, mockClient := setup(t)
+	w, err := Open(cfg, cm, log.NewSubLogger("wallet"))
+	require.NoError(t, err)
+
+	deposit, err := w.NewAddress("deposit")
+	require.NoError(t, err)
+
+	mockClient.EXPECT().GetBlockchainHeight(gomock.Any()).Return(uint32(100), nil)
+	mockClient.EXPECT().CalculateFee(gomock.Any(), int64(2e9), payload.TypeTransfer).Return(int64(1e7), nil)
+	mockClient.EXPECT().BroadcastTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, data []byte) (string, error) {
+			trx, err := tx.FromBytes(data)
+			require.NoError(t, err)
+			require.NoError(t, trx.BasicCheck())
+
+			assert.Equal(t, deposit, trx.Payload().Signer().String())
+			assert.Equal(t, w.Address(), trx.Payload().Receiver().String())
+			assert.Equal(t, int64(2e9), trx.Payload().Value())
+
+			return "0x456", nil
+		})
+
+	txID, err := w.TransferTransactionFrom(deposit, w.Address(), 2e9, "tip")
+	require.NoError(t, err)
+	assert.Equal(t, "0x456", txID)
+}
+
+func TestBalance(t *testing.T) {
+	cfg, cm, mockClient := setup(t)
+	w, err := Open(cfg, cm, log.NewSubLogger("wallet"))
+	require.NoError(t, err)
+
+	mockClient.EXPECT().GetBalance(gomock.Any(), w.Address()).Return(int64(42e9), nil)
+	assert.Equal(t, int64(42e9), w.Balance())
+
+	mockClient.EXPECT().GetBalance(gomock.Any(), w.Address()).Return(int64(0), client.ErrAccountNotFound)
+	assert.Zero(t, w.Balance())
+}
+
+func TestSignMessage(t *testing.T) {
+	cfg, cm, _ := setup(t)
+	w, err := Open(cfg, cm, log.NewSubLogger("wallet"))
+	require.NoError(t, err)
+
+	sigStr, pubStr, err := w.SignMessage([]byte("receipt"))
+	require.NoError(t, err)
+
+	pub, err := bls.PublicKeyFromString(pubStr)
+	require.NoError(t, err)
+	sig, err := bls.SignatureFromString(sigStr)
+	require.NoError(t, err)
+
+	assert.Equal(t, w.Address(), pub.AccountAddress().String())
+	assert.NoError(t, pub.Verify([]byte("receipt"), sig))
+	assert.Error(t, pub.Verify([]byte("forged"), sig))
+}
@@ -0,0 +1,278 @@
+package ws
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/kehiy/RoboPac/log"
+)
+
+const (
+	eventsPath = "/events"
+
+	// eventsBuffer is how many events are kept for a slow consumer, the next events are dropped for it.
+	eventsBuffer   = 64
+	maxConnections = 256
+
+	writeTimeout = 10 * time.Second
+	pingInterval = 30 * time.Second
+	pongTimeout  = 2 * pingInterval
+	maxReadSize  = 512
+)
+
+// Engine is the part of the bot engine that the server streams.
+type Engine interface {
+	SubscribeEvents(buffer int) (<-chan engine.Event, func())
+}
+
+// Server streams the engine events as JSON over WebSocket, for the consumers with a token:
+//
+//	GET /events[?types=block,command,alert]    streams the events, all the types by default.
+//
+// The token is sent as a bearer token, or in the token query parameter since the browsers can't set the headers.
+type Server struct {
+	engine   Engine
+	tokens   map[string]string
+	upgrader websocket.Upgrader
+	srv      *http.Server
+
+	lk    sync.Mutex
+	conns map[*websocket.Conn]struct{}
+	// active is the number of the connections, including the ones that are being upgraded.
+	active int
+}
+
+func NewServer(botEngine Engine, cfg config.WSConfig) (*Server, error) {
+	if len(cfg.Tokens) == 0 {
+		return nil, errors.New("no token is set for the WebSocket server")
+	}
+
+	s := &Server{
+		engine: botEngine,
+		tokens: cfg.Tokens,
+		upgrader: websocket.Upgrader{
+			// the consumers are authenticated by their tokens, so the dashboards can be on any origin.
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+		conns: make(map[*websocket.Conn]struct{}),
+	}
+	s.srv = &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s, nil
+}
+
+// Handler returns the HTTP handler of the event stream.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(eventsPath, s.streamEvents)
+
+	return mux
+}
+
+// Start listens on the address and serves the event stream in the background.
+func (s *Server) Start(_ context.Context) error {
+	listener, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return err
+	}
+
+	log.Info("WebSocket server started", "addr", listener.Addr().String())
+
+	go func() {
+		if err := s.srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("WebSocket server stopped", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops accepting the consumers and closes the connected ones.
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.srv.Shutdown(ctx); err != nil {
+		log.Error("unable to shut the WebSocket server down", "err", err)
+	}
+
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	for conn := range s.conns {
+		_ = conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is stopping"), time.Now().Add(writeTimeout))
+		_ = conn.Close()
+	}
+}
+
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	name, ok := s.consumerName(r)
+	if !ok {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+
+		return
+	}
+
+	types, err := parseTypes(r.URL.Query().Get("types"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if !s.reserve() {
+		http.Error(w, "too many connections", http.StatusServiceUnavailable)
+
+		return
+	}
+	defer s.release()
+
+	// the upgrader responds with the error itself.
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	s.track(conn, true)
+	defer s.track(conn, false)
+
+	log.Info("WebSocket consumer connected", "consumer", name, "remoteAddr", r.RemoteAddr, "types", types)
+	s.stream(conn, types)
+	log.Info("WebSocket consumer disconnected", "consumer", name, "remoteAddr", r.RemoteAddr)
+}
+
+// stream writes the events of the types to the connection, until the consumer or the server closes it.
+func (s *Server) stream(conn *websocket.Conn, types []string) {
+	events, cancel := s.engine.SubscribeEvents(eventsBuffer)
+	defer cancel()
+
+	// the consumers don't send any message, the reader handles the pongs and the close of the consumer.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+
+		conn.SetReadLimit(maxReadSize)
+		_ = conn.SetReadDeadline(time.Now().Add(pongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongTimeout))
+		})
+
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if len(types) > 0 && !slices.Contains(types, event.Type) {
+				continue
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) reserve() bool {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if s.active >= maxConnections {
+		return false
+	}
+	s.active++
+
+	return true
+}
+
+func (s *Server) release() {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	s.active--
+}
+
+func (s *Server) track(conn *websocket.Conn, connected bool) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if connected {
+		s.conns[conn] = struct{}{}
+	} else {
+		delete(s.conns, conn)
+	}
+}
+
+func (s *Server) consumerName(r *http.Request) (string, bool) {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+	if token == "" {
+		return "", false
+	}
+
+	// all the tokens are compared, so the timing doesn't tell which token is close.
+	name, found := "", false
+	for t, consumer := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			name, found = consumer, true
+		}
+	}
+
+	return name, found
+}
+
+// parseTypes parses the event types like "block,alert", all the types are streamed if it's empty.
+func parseTypes(list string) ([]string, error) {
+	types := []string{}
+	for _, typ := range strings.Split(list, ",") {
+		typ = strings.TrimSpace(typ)
+		switch typ {
+		case "":
+		case engine.EventBlock, engine.EventCommand, engine.EventAlert:
+			types = append(types, typ)
+		default:
+			return nil, fmt.Errorf("unknown event type: %s", typ)
+		}
+	}
+
+	return types, nil
+}
@@ -0,0 +1,111 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kehiy/RoboPac/config"
+	"github.com/kehiy/RoboPac/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEngine struct {
+	events chan engine.Event
+	// subscribed is signaled on each subscription, so the events are sent after the consumer is connected.
+	subscribed chan struct{}
+}
+
+func (e *fakeEngine) SubscribeEvents(_ int) (<-chan engine.Event, func()) {
+	e.subscribed <- struct{}{}
+
+	return e.events, func() {}
+}
+
+func setup(t *testing.T) (*Server, *fakeEngine, string) {
+	t.Helper()
+
+	fake := &fakeEngine{events: make(chan engine.Event, 8), subscribed: make(chan struct{}, 8)}
+	s, err := NewServer(fake, config.WSConfig{
+		ListenAddr: "127.0.0.1:0",
+		Tokens:     map[string]string{"secret-token": "dashboard"},
+	})
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(s.Handler())
+	t.Cleanup(srv.Close)
+
+	return s, fake, "ws" + strings.TrimPrefix(srv.URL, "http") + eventsPath
+}
+
+func TestNewServer(t *testing.T) {
+	_, err := NewServer(&fakeEngine{}, config.WSConfig{ListenAddr: "127.0.0.1:0"})
+	assert.Error(t, err)
+}
+
+func TestStreamEvents(t *testing.T) {
+	s, fake, url := setup(t)
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, res, err := websocket.DefaultDialer.Dial(url+"?token=wrong", nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+		_, res, err = websocket.DefaultDialer.Dial(url, nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, res, err := websocket.DefaultDialer.Dial(url+"?token=secret-token&types=block,votes", nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("filtered by type", func(t *testing.T) {
+		header := http.Header{"Authorization": []string{"Bearer secret-token"}}
+		conn, _, err := websocket.DefaultDialer.Dial(url+"?types=block,alert", header)
+		require.NoError(t, err)
+		defer conn.Close()
+		<-fake.subscribed
+
+		fake.events <- engine.Event{Type: engine.EventCommand, Data: engine.CommandExecution{Command: "help"}}
+		fake.events <- engine.Event{Type: engine.EventBlock, Data: engine.BlockSummary{FromHeight: 99, Height: 100}}
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		event := map[string]any{}
+		require.NoError(t, conn.ReadJSON(&event))
+		assert.Equal(t, "block", event["type"])
+		assert.Equal(t, map[string]any{"from_height": float64(99), "height": float64(100)}, event["data"])
+	})
+
+	t.Run("closed by stop", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(url+"?token=secret-token", nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		<-fake.subscribed
+
+		s.Stop()
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), err)
+	})
+}
+
+func TestParseTypes(t *testing.T) {
+	types, err := parseTypes(" block, alert ,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"block", "alert"}, types)
+
+	types, err = parseTypes("")
+	require.NoError(t, err)
+	assert.Empty(t, types)
+
+	_, err = parseTypes("blocks")
+	assert.Error(t, err)
+}